@@ -12,7 +12,7 @@ const path = require('path');
 
 const { detectLanguage } = require('../core/parser');
 const { ProjectIndex } = require('../core/project');
-const { expandGlob, findProjectRoot } = require('../core/discovery');
+const { expandGlob, findProjectRoot, compilePathPattern } = require('../core/discovery');
 const output = require('../core/output');
 const { getCliCommandSet, resolveCommand, FLAG_APPLICABILITY, toCliName, FILE_LOCAL_COMMANDS } = require('../core/registry');
 const { looksLikeHandle, parseSymbolHandle } = require('../core/shared');
@@ -30,6 +30,7 @@ function nameForDisplay(arg) {
 }
 const { execute } = require('../core/execute');
 const { ExpandCache } = require('../core/expand-cache');
+const log = require('../core/log');
 
 // Sentinel error for command failures that have already printed their message.
 // Thrown instead of process.exit(1) so finally blocks can run (cache save).
@@ -114,9 +115,38 @@ function validateNumericFlags(flags) {
     if (flags.contextRaw != null) {
         flags.context = validatePositiveInt(flags.contextRaw, '--context', { allowZero: true });
     }
-    // --workers: non-negative integer (0 disables parallel build).
+    // --jobs (--workers): non-negative integer (0 or 1 builds sequentially).
     if (flags.workersRaw != null) {
-        flags.workers = validatePositiveInt(flags.workersRaw, '--workers', { allowZero: true });
+        flags.workers = validatePositiveInt(flags.workersRaw, '--jobs', { allowZero: true });
+    }
+}
+
+/**
+ * Validate --fail-on, --max-findings and --max-dead-lines. Throws FlagValidationError.
+ */
+function validatePolicyFlags(flags) {
+    const { FAIL_ON, parseMaxFindings, parseMaxDeadLines } = require('../core/fail-policy');
+    if (flags.failOn != null && !FAIL_ON.includes(flags.failOn)) {
+        throw new FlagValidationError(`Invalid --fail-on value: must be one of ${FAIL_ON.join(', ')} (got "${flags.failOn}")`);
+    }
+    const { error } = parseMaxFindings(flags.maxFindings || []);
+    if (error) throw new FlagValidationError(error);
+    const dead = parseMaxDeadLines(flags.maxDeadLines);
+    if (dead.error) throw new FlagValidationError(dead.error);
+}
+
+/**
+ * Validate the re: patterns of --include/--exclude, so a bad regex fails
+ * up front instead of mid-build. Throws FlagValidationError.
+ */
+function validatePathPatterns(flags) {
+    for (const pattern of [...(flags.include || []), ...(flags.exclude || [])]) {
+        if (!pattern.startsWith('re:')) continue;
+        try {
+            new RegExp(pattern.slice(3));
+        } catch (e) {
+            throw new FlagValidationError(`Invalid regex in path pattern "${pattern}": ${e.message}`);
+        }
     }
 }
 
@@ -128,7 +158,8 @@ function validateNumericFlags(flags) {
 function fail(msg) {
     // This helper can run before parsed flags exist, so raw argv is the single
     // reliable source for the output mode.
-    const wantsJson = process.argv.includes('--json');
+    const wantsJson = process.argv.includes('--json') ||
+        process.argv.some((a, i, all) => a === '--format=json' || (a === '--format' && all[i + 1] === 'json'));
     if (wantsJson) {
         const env = { meta: { ok: false }, error: typeof msg === 'string' ? msg : String(msg) };
         try { process.stdout.write(JSON.stringify(env) + '\n'); } catch (_) { /* stdout may be closed */ }
@@ -167,14 +198,14 @@ function parseFlags(tokens) {
         }
         return null;
     }
-    function parseExclude() {
+    function parseList(flagNames) {
         const result = [];
         for (const a of tokens) {
-            if (a.startsWith('--exclude=') || a.startsWith('--not=')) {
+            if (flagNames.some(flag => a.startsWith(flag + '='))) {
                 result.push(...a.split('=').slice(1).join('=').split(','));
             }
         }
-        for (const flag of ['--exclude', '--not']) {
+        for (const flag of flagNames) {
             for (let i = 0; i < tokens.length; i++) {
                 if (tokens[i] === flag && i + 1 < tokens.length && !tokens[i + 1].startsWith('-')) {
                     result.push(...tokens[i + 1].split(','));
@@ -183,14 +214,60 @@ function parseFlags(tokens) {
         }
         return result;
     }
+    function parseRepeated(flagName) {
+        const result = [];
+        for (let i = 0; i < tokens.length; i++) {
+            if (tokens[i].startsWith(flagName + '=')) result.push(tokens[i].slice(flagName.length + 1));
+            else if (tokens[i] === flagName && i + 1 < tokens.length && !tokens[i + 1].startsWith('-')) result.push(tokens[i + 1]);
+        }
+        return result;
+    }
     return {
         file: getValueFlag('--file'),
-        exclude: parseExclude(),
+        exclude: parseList(['--exclude', '--not']),
+        include: parseList(['--include']),
+        failOn: getValueFlag('--fail-on'),
+        maxFindings: parseList(['--max-findings']),
+        maxDeadLines: getValueFlag('--max-dead-lines') ?? undefined,
         in: getValueFlag('--in'),
         includeTests: tokens.includes('--include-tests') ? true : undefined,
         excludeTests: tokens.includes('--exclude-tests') ? true : undefined,
         includeExported: tokens.includes('--include-exported') || undefined,
         includeDecorated: tokens.includes('--include-decorated') || undefined,
+        includeGenerated: tokens.includes('--include-generated') || undefined,
+        generatedMarkers: parseRepeated('--generated-marker'),
+        interfaceMethods: tokens.includes('--interface-methods') || undefined,
+        unusedParams: tokens.includes('--unused-params') || undefined,
+        unreachableCode: tokens.includes('--unreachable-code') || undefined,
+        packageVars: tokens.includes('--package-vars') || undefined,
+        types: tokens.includes('--types') || undefined,
+        unusedResults: tokens.includes('--unused-results') || undefined,
+        orphanFiles: tokens.includes('--orphan-files') || undefined,
+        buildVariants: tokens.includes('--build-variants') || undefined,
+        platforms: getValueFlag('--platforms'),
+        testOnly: tokens.includes('--test-only') || undefined,
+        testHelpers: tokens.includes('--test-helpers') || undefined,
+        sentinelErrors: tokens.includes('--sentinel-errors') || undefined,
+        library: tokens.includes('--library') || undefined,
+        dependents: getValueFlag('--dependents'),
+        embeds: tokens.includes('--embeds') || undefined,
+        channels: tokens.includes('--channels') || undefined,
+        configKnobs: tokens.includes('--config-knobs') || undefined,
+        satisfiesOnly: tokens.includes('--satisfies-only') || undefined,
+        redundantAssertions: tokens.includes('--redundant-assertions') || undefined,
+        typeParams: tokens.includes('--type-params') || undefined,
+        initEffects: tokens.includes('--init-effects') || undefined,
+        importIssues: tokens.includes('--import-issues') || undefined,
+        configKeys: tokens.includes('--config-keys') || undefined,
+        symbols: tokens.includes('--symbols') || undefined,
+        complexity: tokens.includes('--complexity') || undefined,
+        maxCyclomatic: getValueFlag('--max-cyclomatic') ?? undefined,
+        maxCognitive: getValueFlag('--max-cognitive') ?? undefined,
+        size: tokens.includes('--size') || undefined,
+        maxFunctionLines: getValueFlag('--max-function-lines') ?? undefined,
+        maxStatements: getValueFlag('--max-statements') ?? undefined,
+        maxMethods: getValueFlag('--max-methods') ?? undefined,
+        maxFields: getValueFlag('--max-fields') ?? undefined,
         includeUncertain: tokens.includes('--include-uncertain') || undefined,
         expandUnverified: tokens.includes('--expand-unverified') || undefined,
         includeMethods: tokens.some(a => a === '--include-methods=false' || a === '--no-include-methods') ? false : tokens.some(a => a === '--include-methods' || (a.startsWith('--include-methods=') && a !== '--include-methods=false')) ? true : undefined,
@@ -219,10 +296,19 @@ function parseFlags(tokens) {
         defaultValue: getValueFlag('--default-value') ?? getValueFlag('--default'),
         base: getValueFlag('--base'),
         staged: tokens.includes('--staged') || undefined,
+        // check --stdin: an unsaved buffer, in --lang when it has no --file
+        stdin: tokens.includes('--stdin') || undefined,
+        lang: getValueFlag('--lang'),
         deep: tokens.includes('--deep') || undefined,
         compact: tokens.includes('--compact') || undefined,
         maxLines: getValueFlag('--max-lines') || null,
         maxLinesRaw: getValueFlag('--max-lines'),
+        // clones thresholds (validated by the handler)
+        minLines: getValueFlag('--min-lines') ?? undefined,
+        minTokens: getValueFlag('--min-tokens') ?? undefined,
+        similarity: getValueFlag('--similarity') ?? undefined,
+        literals: tokens.includes('--literals') || undefined,
+        minFiles: getValueFlag('--min-files') ?? undefined,
         regex: tokens.includes('--no-regex') ? false : undefined,
         functions: tokens.includes('--functions') || undefined,
         hot: tokens.includes('--hot') || undefined,
@@ -257,9 +343,10 @@ function parseFlags(tokens) {
         prefix: getValueFlag('--prefix'),
         hideUncertain: tokens.includes('--hide-uncertain') || tokens.includes('--no-uncertain') || undefined,
         stack: getValueFlag('--stack'),
-        workersRaw: getValueFlag('--workers'),
+        // --jobs bounds the parallel build's worker pool; --workers is its older name
+        workersRaw: getValueFlag('--jobs') ?? getValueFlag('--workers'),
         workers: (() => {
-            const v = getValueFlag('--workers');
+            const v = getValueFlag('--jobs') ?? getValueFlag('--workers');
             if (v === null) return undefined;
             const n = parseInt(v, 10);
             return isNaN(n) ? undefined : n;
@@ -267,31 +354,73 @@ function parseFlags(tokens) {
     };
 }
 
+/**
+ * A global flag's value, from `--name=value` or `--name value`: undefined
+ * when the flag is absent, '' when it has no value (the checks below
+ * reject that with the flag's own message).
+ */
+function valueFlag(tokens, name) {
+    const at = tokens.findIndex(a => a === name || a.startsWith(name + '='));
+    if (at === -1) return undefined;
+    if (tokens[at] !== name) return tokens[at].slice(name.length + 1);
+    const next = tokens[at + 1];
+    return next !== undefined && !next.startsWith('-') ? next : '';
+}
+
 // Parse shared flags from CLI args, then add global-only flags
 const flags = parseFlags(args);
-flags.json = args.includes('--json');
+// --format=text|json|sarif|jsonl|html|html-coverage|lcov|markdown|csv|tsv|junit|tap|checkstyle|gitlab|
+// rdjson|rdjsonl|sonarqube|sql|prometheus|template|patch|mermaid; json is the same as --json, FINDING_FORMATS serve the finding commands
+// (patch only deadcode), mermaid graph and trace
+const FINDING_FORMATS = new Set(['sarif', 'jsonl', 'html', 'html-coverage', 'lcov', 'markdown', 'csv', 'tsv', 'junit', 'tap', 'checkstyle', 'gitlab', 'rdjson', 'rdjsonl', 'sonarqube', 'sql', 'prometheus', 'template', 'patch']);
+flags.format = valueFlag(args, '--format') ?? 'text';
+flags.json = args.includes('--json') || flags.format === 'json';
+// --report=<name> posts the findings to a code-review platform, next to the normal output
+flags.report = valueFlag(args, '--report');
+// --sqlite=<file> records the run in a SQLite database, next to the normal output
+flags.sqlite = valueFlag(args, '--sqlite');
+// --baseline=<file> hides the findings a baseline file already lists
+flags.baseline = valueFlag(args, '--baseline');
+// --template=<file> is the text/template file --format template renders
+flags.template = valueFlag(args, '--template');
+// --sort=<key> and --group-by=<key> arrange a finding command's findings
+flags.sort = valueFlag(args, '--sort');
+flags.groupBy = valueFlag(args, '--group-by');
 flags.quiet = !args.includes('--verbose') && !args.includes('--no-quiet');
 flags.cache = !args.includes('--no-cache');
 flags.clearCache = args.includes('--clear-cache');
 flags.interactive = args.includes('--interactive') || args.includes('-i');
+flags.dryRun = args.includes('--dry-run');
+flags.force = args.includes('--force');
+// --notify sends a summary to the .ucn.json "notify" sinks (Slack, webhooks)
+flags.notify = args.includes('--notify');
 flags.followSymlinks = !args.includes('--no-follow-symlinks');
+// --progress shows build progress on stderr; -v logs debug events, -vv
+// trace events too, as text or --log-format json lines
+flags.progress = args.includes('--progress');
+flags.verbosity = args.includes('-vv') ? 2 : args.includes('-v') && args.length > 1 ? 1 : 0;
+flags.logFormat = valueFlag(args, '--log-format') ?? 'text';
+// --port, --host and --grpc-port: where ucn serve listens
+flags.port = valueFlag(args, '--port');
+flags.host = valueFlag(args, '--host');
+flags.grpcPort = valueFlag(args, '--grpc-port');
 
 // Known flags for validation
 const knownFlags = new Set([
-    '--help', '-h', '--version', '-v', '--mcp',
-    '--json', '--verbose', '--no-quiet', '--quiet',
+    '--help', '-h', '--version', '-v', '-vv', '--progress', '--log-format', '--mcp', '--stdio', '--port', '--host', '--grpc-port',
+    '--json', '--format', '--sqlite', '--report', '--template', '--baseline', '--sort', '--group-by', '--verbose', '--no-quiet', '--quiet',
     '--code-only', '--with-types', '--top-level', '--exact', '--case-sensitive',
     '--no-cache', '--clear-cache', '--include-tests', '--exclude-tests',
-    '--include-exported', '--include-decorated', '--expand', '--interactive', '-i', '--all', '--include-methods', '--no-include-methods', '--include-uncertain', '--expand-unverified', '--detailed', '--calls-only',
-    '--file', '--context', '--exclude', '--not', '--in',
+    '--include-exported', '--include-decorated', '--include-generated', '--generated-marker', '--fail-on', '--max-findings', '--max-dead-lines', '--interface-methods', '--unused-params', '--unreachable-code', '--package-vars', '--types', '--unused-results', '--orphan-files', '--build-variants', '--test-only', '--test-helpers', '--sentinel-errors', '--library', '--embeds', '--channels', '--config-knobs', '--satisfies-only', '--redundant-assertions', '--type-params', '--init-effects', '--import-issues', '--config-keys', '--literals', '--symbols', '--complexity', '--size', '--expand', '--interactive', '-i', '--dry-run', '--force', '--notify', '--all', '--include-methods', '--no-include-methods', '--include-uncertain', '--expand-unverified', '--detailed', '--calls-only',
+    '--file', '--context', '--exclude', '--not', '--include', '--in',
     '--depth', '--direction', '--add-param', '--remove-param', '--rename-to', '--default-value',
     '--default', '--top', '--no-follow-symlinks',
-    '--base', '--staged', '--stack',
+    '--base', '--staged', '--stack', '--stdin', '--lang',
     '--regex', '--no-regex', '--functions', '--hot', '--diverse', '--git',
-    '--max-lines', '--class-name', '--line', '--limit', '--max-files',
+    '--platforms', '--dependents', '--max-lines', '--max-cyclomatic', '--max-cognitive', '--max-function-lines', '--max-statements', '--max-methods', '--max-fields', '--min-lines', '--min-tokens', '--similarity', '--min-files', '--class-name', '--line', '--limit', '--max-files',
     '--type', '--param', '--receiver', '--returns', '--decorator', '--exported', '--unused',
     '--hide-confidence', '--no-confidence', '--min-confidence', '--unreachable-only',
-    '--framework', '--workers', '--deep', '--compact',
+    '--framework', '--jobs', '--workers', '--deep', '--compact',
     '--bridge', '--server-only', '--client-only', '--unmatched',
     '--method', '--prefix', '--hide-uncertain', '--no-uncertain'
 ]);
@@ -302,8 +431,9 @@ if (args.includes('--help') || args.includes('-h')) {
     process.exit(0);
 }
 
-// Handle version flag — read from package.json (single source of truth, shared with MCP serverInfo)
-if (args.includes('--version') || args.includes('-v')) {
+// Handle version flag — read from package.json (single source of truth, shared with MCP serverInfo).
+// -v on its own is the version too; with a command it is -v verbosity
+if (args.includes('--version') || (args.length === 1 && args[0] === '-v')) {
     console.log(require('../package.json').version);
     process.exit(0);
 }
@@ -322,11 +452,78 @@ if (unknownFlags.length > 0) {
     process.exit(1);
 }
 
+if (!['text', 'json', 'mermaid', ...FINDING_FORMATS].includes(flags.format)) {
+    console.error(`Invalid --format value: must be text, json, ${[...FINDING_FORMATS].join(', ')} or mermaid (got ${flags.format || 'nothing'})`);
+    process.exit(1);
+}
+
+if (flags.report !== undefined && !require('../core/report').REPORTER_NAMES.includes(flags.report)) {
+    console.error(`Invalid --report value: must be one of ${require('../core/report').REPORTER_NAMES.join(', ')} (got ${flags.report || 'nothing'})`);
+    process.exit(1);
+}
+
+if (flags.sqlite === '') {
+    console.error('--sqlite needs a database file (e.g. --sqlite=ucn.db)');
+    process.exit(1);
+}
+
+if (flags.baseline === '') {
+    console.error('--baseline needs a baseline file (e.g. --baseline=.ucn-baseline.json)');
+    process.exit(1);
+}
+
+{
+    const { SORT_KEYS, GROUP_KEYS } = require('../core/arrange');
+    if (flags.sort !== undefined && !SORT_KEYS.includes(flags.sort)) {
+        console.error(`Invalid --sort value: must be ${SORT_KEYS.join(', ')} (got ${flags.sort || 'nothing'})`);
+        process.exit(1);
+    }
+    if (flags.groupBy !== undefined && !GROUP_KEYS.includes(flags.groupBy)) {
+        console.error(`Invalid --group-by value: must be ${GROUP_KEYS.join(', ')} (got ${flags.groupBy || 'nothing'})`);
+        process.exit(1);
+    }
+}
+
+if (!['text', 'json'].includes(flags.logFormat)) {
+    console.error(`Invalid --log-format value: must be text or json (got ${flags.logFormat || 'nothing'})`);
+    process.exit(1);
+}
+log.configure({ verbosity: flags.verbosity, format: flags.logFormat });
+
+if (flags.port !== undefined && !(/^\d+$/.test(flags.port) && Number(flags.port) <= 65535)) {
+    console.error(`Invalid --port value: must be a port number, 0-65535 (got ${flags.port || 'nothing'})`);
+    process.exit(1);
+}
+if (flags.grpcPort !== undefined && !(/^\d+$/.test(flags.grpcPort) && Number(flags.grpcPort) <= 65535)) {
+    console.error(`Invalid --grpc-port value: must be a port number, 0-65535 (got ${flags.grpcPort || 'nothing'})`);
+    process.exit(1);
+}
+if (flags.host === '') {
+    console.error('--host needs an address to listen on (e.g. --host=0.0.0.0)');
+    process.exit(1);
+}
+
+if ((flags.format === 'template') !== (flags.template !== undefined) || flags.template === '') {
+    console.error('--format template and --template=FILE go together (e.g. --format template --template=slack.tmpl)');
+    process.exit(1);
+}
+if (flags.template) {
+    try {
+        flags._template = output.compileTemplate(fs.readFileSync(flags.template, 'utf-8'), path.basename(flags.template));
+    } catch (e) {
+        console.error(e.code === 'ENOENT' ? `Template not found: ${flags.template}` : e.message);
+        process.exit(1);
+    }
+}
+
 // Validate numeric flag values up front so bad input fails before we build
 // any indexes. Applies to --top, --limit, --max-files, --max-lines, --depth,
-// --context, --workers. Throws FlagValidationError with a helpful message.
+// --context, --workers, the regexes of --include/--exclude, --fail-on and
+// --max-findings. Throws FlagValidationError with a helpful message.
 try {
     validateNumericFlags(flags);
+    validatePathPatterns(flags);
+    validatePolicyFlags(flags);
 } catch (e) {
     if (e instanceof FlagValidationError) {
         if (flags.json) {
@@ -343,17 +540,18 @@ try {
 const VALUE_FLAGS = new Set([
     '--file', '--depth', '--top', '--context', '--direction',
     '--add-param', '--remove-param', '--rename-to', '--default', '--default-value',
-    '--base', '--exclude', '--not', '--in', '--max-lines', '--class-name', '--line',
+    '--base', '--exclude', '--not', '--include', '--in', '--max-lines', '--class-name', '--line',
     '--type', '--param', '--receiver', '--returns', '--decorator',
     '--limit', '--max-files', '--min-confidence', '--stack', '--framework',
-    '--workers', '--method', '--prefix'
+    '--jobs', '--workers', '--method', '--prefix', '--format', '--sqlite', '--report', '--template', '--baseline', '--generated-marker',
+    '--fail-on', '--max-findings', '--max-dead-lines', '--lang', '--log-format', '--sort', '--group-by', '--port', '--host', '--grpc-port'
 ]);
 
 // Remove flags from args, then add args after -- (which are all positional)
 const positionalArgs = [
     ...args.filter((a, idx) =>
         !a.startsWith('--') &&
-        a !== '-i' &&
+        a !== '-i' && a !== '-v' && a !== '-vv' &&
         !(idx > 0 && VALUE_FLAGS.has(args[idx - 1]) && !args[idx - 1].includes('='))
     ),
     ...argsAfterDoubleDash
@@ -385,14 +583,421 @@ function requireArg(arg, usage) {
  * @param {Function} textFn - Function to format as text (receives result)
  */
 function printOutput(result, jsonFn, textFn) {
-    if (flags.json) {
+    if (flags._baselineCreate) {
+        const { createBaseline, parseBaseline } = require('../core/baseline');
+        const file = flags._baselineCreate;
+        let previous = null;
+        if (fs.existsSync(file)) {
+            const { baseline, error } = parseBaseline(fs.readFileSync(file, 'utf-8'));
+            if (error) fail(`Cannot update baseline ${file}: ${error}`);
+            previous = baseline;
+        }
+        const doc = createBaseline(flags._command, result?.limitInfo?.whole ?? result, previous);
+        fs.writeFileSync(file, JSON.stringify(doc, null, 2) + '\n');
+        const own = doc.findings.filter(e => e.command === flags._command).length;
+        console.log(`Baseline: ${own} ${toCliName(flags._command)} finding(s) written to ${file}`);
+        return;
+    }
+    // The fail policy counts what --top and --limit cut from the listing
+    let whole = result?.limitInfo?.whole ?? result;
+    if (flags._hookFiles) {
+        // ucn hook run: the findings in the files being committed
+        const { onlyInFiles } = require('../core/hook');
+        result = onlyInFiles(flags._command, result, flags._root, flags._hookFiles);
+        whole = onlyInFiles(flags._command, whole, flags._root, flags._hookFiles);
+    }
+    let groups = null;
+    if (flags.sort || flags.groupBy) {
+        const arranged = require('../core/arrange').arrangeFindings(flags._command, result, { sort: flags.sort, groupBy: flags.groupBy, root: flags._root });
+        result = arranged.result;
+        groups = arranged.groups;
+    }
+    if (flags._fix) {
+        runFix(result);
+        return;
+    }
+    if (flags._tui) {
+        runTui(result);
+        return;
+    }
+    if (flags.sqlite) {
+        const { resultsDbScript, writeResultsDb } = require('../core/results-db');
+        const err = writeResultsDb(flags.sqlite, resultsDbScript(flags._index, flags._command, result));
+        if (err) fail(err);
+    }
+    // .ucn.json "rules" sets severity and confidence per rule
+    const findingOptions = { root: flags._root, rules: flags._index && (file => flags._index.configFor(file).rules) };
+    if (flags.format === 'sarif') {
+        console.log(output.formatSarif(flags._command, result, findingOptions));
+    } else if (flags.format === 'jsonl') {
+        for (const line of output.jsonLines(flags._command, result, findingOptions)) process.stdout.write(line + '\n');
+    } else if (flags.format === 'html') {
+        console.log(output.formatHtmlReport(flags._command, result, findingOptions));
+    } else if (flags.format === 'html-coverage') {
+        console.log(output.formatCoverageHtml(flags._command, result, findingOptions));
+    } else if (flags.format === 'lcov') {
+        process.stdout.write(output.formatLcov(flags._command, result, findingOptions));
+    } else if (flags.format === 'markdown') {
+        console.log(output.formatMarkdown(flags._command, result, findingOptions));
+    } else if (flags.format === 'csv') {
+        console.log(output.formatCsv(flags._command, result, findingOptions));
+    } else if (flags.format === 'tsv') {
+        console.log(output.formatTsv(flags._command, result, findingOptions));
+    } else if (flags.format === 'junit') {
+        console.log(output.formatJunit(flags._command, result, findingOptions));
+    } else if (flags.format === 'tap') {
+        process.stdout.write(output.formatTap(flags._command, result, findingOptions));
+    } else if (flags.format === 'checkstyle') {
+        console.log(output.formatCheckstyle(flags._command, result, findingOptions));
+    } else if (flags.format === 'gitlab') {
+        console.log(output.formatGitlabCodeQuality(flags._command, result, findingOptions));
+    } else if (flags.format === 'rdjson') {
+        console.log(output.formatRdjson(flags._command, result, findingOptions));
+    } else if (flags.format === 'rdjsonl') {
+        for (const line of output.rdjsonLines(flags._command, result, findingOptions)) process.stdout.write(line + '\n');
+    } else if (flags.format === 'sonarqube') {
+        console.log(output.formatSonarqube(flags._command, result, findingOptions));
+    } else if (flags.format === 'patch') {
+        // The removals ucn fix would make, for git apply; notes on stderr keep stdout a clean patch
+        const { fixPatch } = require('../core/fix');
+        const { patch, fixes, skipped } = fixPatch(flags._index, result);
+        process.stdout.write(patch);
+        for (const s of skipped) console.error(`Skipped ${s.item.name} (${s.item.file}:${s.item.startLine}): ${s.reason}`);
+        console.error(`${fixes.length} removal(s) in ${new Set(fixes.map(f => f.file)).size} file(s)`);
+    } else if (flags.format === 'sql') {
+        const { resultsDbScript } = require('../core/results-db');
+        console.log(resultsDbScript(flags._index, flags._command, result));
+    } else if (flags.format === 'prometheus') {
+        process.stdout.write(output.formatPrometheus(flags._command, result, { ...findingOptions, durationSeconds: process.uptime() }));
+    } else if (flags.format === 'template') {
+        try {
+            process.stdout.write(output.formatTemplate(flags._template, flags._command, result, findingOptions));
+        } catch (e) {
+            fail(e.message);
+        }
+    } else if (flags.format === 'mermaid') {
+        console.log(output.formatMermaid(flags._command, result));
+    } else if (flags.json && groups) {
+        console.log(JSON.stringify({
+            groupBy: flags.groupBy,
+            groups: groups.map(g => ({ key: g.key, count: g.count, result: JSON.parse(jsonFn(g.result)) })),
+        }, null, 2));
+    } else if (flags.json) {
         console.log(jsonFn(result));
+    } else if (groups) {
+        // One section per group, each formatted as the command's own output
+        const sections = groups.map(g => `== ${flags.groupBy}: ${g.key} (${g.count}) ==\n${textFn(g.result) ?? ''}`);
+        console.log(sections.join('\n\n') || textFn(result));
     } else {
         const text = textFn(result);
         if (text !== undefined) {
             console.log(text);
         }
     }
+    const failed = flags._policyCommand ? applyFailPolicy(whole) : false;
+    if (flags.report) runReport(result, findingOptions, failed);
+    if (flags.notify) runNotify(result, findingOptions);
+}
+
+/**
+ * --report: post the shown findings to a code-review platform. The output
+ * is already printed; a failed post is exit code 1 and a line on stderr.
+ */
+function runReport(result, findingOptions, failed) {
+    const { postReport } = require('../core/report');
+    postReport(flags.report, flags._command, result, { ...findingOptions, failed })
+        .then(message => console.error(message))
+        .catch((e) => {
+            console.error(`--report=${flags.report}: ${e.message}`);
+            process.exitCode = 1;
+        });
+}
+
+/**
+ * --notify: send the run's summary to the .ucn.json "notify" sinks. Like
+ * --report, a failed send is exit code 1 and a line on stderr.
+ */
+function runNotify(result, findingOptions) {
+    const { notify } = require('../core/notify');
+    notify(flags._command, result, { ...findingOptions, notify: flags._index?.config?.notify })
+        .then(lines => lines.forEach(line => console.error(line)))
+        .catch((e) => {
+            console.error(`--notify: ${e.message}`);
+            process.exitCode = 1;
+        });
+}
+
+/**
+ * Exit code 1 when the run's findings, those --top or --limit left out
+ * included, break --fail-on, --max-findings or --max-dead-lines (or
+ * .ucn.json "failOn", "maxFindings" and "maxDeadLines"); the reasons go
+ * to stderr.
+ * @returns {boolean} Whether the policy failed
+ */
+function applyFailPolicy(result) {
+    const { parseMaxFindings, parseMaxDeadLines, policyFailures, FAIL_ON } = require('../core/fail-policy');
+    const config = flags._index?.config || {};
+    // A pre-commit run is there to stop the commit
+    const failOn = flags.failOn ?? config.failOn ?? (flags._hookFiles ? 'warning' : 'none');
+    if (!FAIL_ON.includes(failOn)) fail(`Invalid config failOn: must be one of ${FAIL_ON.join(', ')} (got "${failOn}")`);
+    const { budgets, error } = parseMaxFindings(flags.maxFindings.length > 0 ? flags.maxFindings : config.maxFindings);
+    if (error) fail(error);
+    const dead = parseMaxDeadLines(flags.maxDeadLines ?? config.maxDeadLines);
+    if (dead.error) fail(dead.error.replace('--max-dead-lines', 'config maxDeadLines'));
+    const failures = policyFailures(flags._policyCommand, result, {
+        failOn, budgets, maxDeadLines: dead.limit, rules: flags._index && (file => flags._index.configFor(file).rules),
+    });
+    if (failures.length === 0) return false;
+    for (const failure of failures) console.error(`Failed: ${failure}`);
+    process.exitCode = 1;
+    return true;
+}
+
+/** One line typed on stdin, read synchronously (null at end of input) */
+function promptLine(question) {
+    process.stderr.write(question);
+    const buf = Buffer.alloc(1);
+    let line = '';
+    for (;;) {
+        let n;
+        try {
+            n = fs.readSync(0, buf, 0, 1, null);
+        } catch (e) {
+            if (e.code === 'EAGAIN') continue;
+            return null;
+        }
+        if (n === 0) return line || null;
+        const c = buf.toString('utf-8');
+        if (c === '\n') return line.trim();
+        line += c;
+    }
+}
+
+/**
+ * ucn fix: delete the safe subset of deadcode's findings. --dry-run
+ * prints the removals as a unified diff (stdout stays a clean patch),
+ * --interactive shows each one and asks before it goes.
+ * @param {Array} result - deadcode result, after suppressions and baseline
+ */
+function runFix(result) {
+    const { planFixes, fixedFiles, unifiedDiff } = require('../core/fix');
+    const index = flags._index;
+    const { fixes, skipped } = planFixes(index, result);
+    const label = ({ item, kind }) => kind === 'import' ? `import "${item.name}"`
+        : item.type === 'const block' ? `const block (${item.members.join(', ')})`
+            : `${kind} ${item.name}`;
+    const where = ({ item }) => `${item.file}:${item.startLine}`;
+    for (const s of skipped) console.error(`Skipped ${label(s)} (${where(s)}): ${s.reason}`);
+    if (fixes.length === 0) {
+        console.log('Nothing to fix: no unused unexported function, constant or (with --import-issues) import can be removed safely.');
+        return;
+    }
+    if (flags.dryRun) {
+        for (const change of fixedFiles(index, fixes)) process.stdout.write(unifiedDiff(change));
+        console.error(`${fixes.length} removal(s) in ${new Set(fixes.map(f => f.file)).size} file(s) (dry run, nothing written)`);
+        return;
+    }
+    let chosen = fixes;
+    if (flags._fix.interactive) {
+        chosen = [];
+        let all = false;
+        for (const fix of fixes) {
+            if (!all) {
+                process.stdout.write(unifiedDiff(fixedFiles(index, [fix])[0]));
+                const answer = (promptLine(`Remove ${label(fix)} (${where(fix)})? [y]es/[n]o/[a]ll/[q]uit `) || 'q').toLowerCase();
+                if (answer.startsWith('q')) break;
+                if (answer.startsWith('a')) all = true;
+                else if (!answer.startsWith('y')) continue;
+            }
+            chosen.push(fix);
+        }
+    }
+    const changes = fixedFiles(index, chosen);
+    for (const { file, after } of changes) fs.writeFileSync(path.join(index.root, file), after);
+    for (const fix of chosen) console.log(`Removed ${label(fix)} (${where(fix)})`);
+    console.log(`${chosen.length} removal(s) in ${changes.length} file(s)`);
+}
+
+/**
+ * ucn tui: browse the findings full-screen. Raw-mode keypresses go to the
+ * browser (core/tui.js), which renders the screen after each one.
+ * @param {*} result - The finding command's result, after the baseline
+ */
+function runTui(result) {
+    if (!process.stdin.isTTY || !process.stdout.isTTY) fail('ucn tui needs an interactive terminal.');
+    const readline = require('readline');
+    const { createTui } = require('../core/tui');
+    const tui = createTui(flags._index, flags._command, result, { baseline: flags.baseline, rules: file => flags._index.configFor(file).rules });
+    const paint = () => process.stdout.write('\x1b[H\x1b[2J' + tui.render(process.stdout.rows, process.stdout.columns).join('\n'));
+    const leave = () => {
+        process.stdin.setRawMode(false);
+        // Back to the main screen, cursor shown
+        process.stdout.write('\x1b[?25h\x1b[?1049l');
+        process.exit(0);
+    };
+    readline.emitKeypressEvents(process.stdin);
+    process.stdin.setRawMode(true);
+    process.stdout.write('\x1b[?1049h\x1b[?25l');
+    process.stdin.on('keypress', (ch, key) => {
+        if (key?.ctrl && key.name === 'c') leave();
+        if (tui.key(key?.name || ch) === 'quit') leave();
+        paint();
+    });
+    process.stdout.on('resize', paint);
+    paint();
+}
+
+/**
+ * ucn watch: print the command's findings, then keep the index current
+ * as files change and print what each change introduced and resolved
+ * (--json: one JSON object per update).
+ * @param {object} index - Built ProjectIndex
+ * @param {string} canonical - Finding command
+ * @param {string|null} subdirScope - Implicit --in of a subdirectory target
+ */
+function runWatch(index, canonical, subdirScope) {
+    const { watchProject } = require('../core/watch');
+    const params = canonical === 'auditAsync'
+        ? { file: flags.file, exclude: flags.exclude, baseline: flags.baseline }
+        : { ...flags, in: flags.in || subdirScope };
+    const run = (ix) => {
+        const { ok, result, error } = execute(ix, canonical, params);
+        if (!ok) throw new Error(error);
+        return result;
+    };
+    const brief = f => ({ rule: f.rule, file: f.at.file, line: f.at.startLine, message: f.message });
+    const onUpdate = ({ changed, ms, total, introduced, resolved }) => {
+        if (flags.json) {
+            console.log(JSON.stringify({ changed, ms, total, introduced: introduced.map(brief), resolved: resolved.map(brief) }));
+            return;
+        }
+        const time = new Date().toTimeString().slice(0, 8);
+        console.log(`\n[${time}] ${changed.join(', ')} — ${total} finding(s), ${introduced.length} new, ${resolved.length} resolved (${ms}ms)`);
+        for (const f of introduced) console.log(`  + ${f.at.file}:${f.at.startLine}  ${f.message}`);
+        for (const f of resolved) console.log(`  - ${f.at.file}:${f.at.startLine}  ${f.message}`);
+    };
+    let watch;
+    try {
+        watch = watchProject(index, run, {
+            command: canonical,
+            onUpdate,
+            onError: e => console.error(`Error: ${e.message}`),
+            buildOptions: { followSymlinks: flags.followSymlinks, maxFiles: flags.maxFiles, workers: flags.workers, include: flags.include, exclude: flags.exclude },
+        });
+    } catch (e) {
+        fail(e.message);
+    }
+    if (flags.json) {
+        const all = [...output.findingsOf(canonical, watch.result)];
+        console.log(JSON.stringify({ changed: [], ms: 0, total: all.length, introduced: all.map(brief), resolved: [] }));
+    } else {
+        const textFn = { deadcode: output.formatDeadcode, clones: output.formatClones, auditAsync: output.formatAuditAsync, deprecated: output.formatDeprecated }[canonical];
+        console.log(textFn(watch.result));
+    }
+    console.error(`Watching ${index.root} for changes (Ctrl-C to stop)`);
+}
+
+const DEFAULT_PORT = 7070;
+
+/**
+ * ucn serve: the HTTP API over the built index, and with --grpc-port the
+ * gRPC service too, until interrupted.
+ * @param {object} index - Built ProjectIndex
+ * @param {string|null} subdirScope - Implicit --in of a subdirectory target
+ */
+function runServe(index, subdirScope) {
+    const { createApi, createApiServer } = require('../core/serve');
+    const api = createApi(index, {
+        params: { ...flags, in: flags.in || subdirScope },
+        buildOptions: { followSymlinks: flags.followSymlinks, maxFiles: flags.maxFiles, workers: flags.workers, include: flags.include, exclude: flags.exclude },
+    });
+    const host = flags.host || '127.0.0.1';
+    const address = port => `${host.includes(':') ? `[${host}]` : host}:${port}`;
+    if (flags.grpcPort !== undefined) {
+        const { createGrpcServer, listenGrpc } = require('../core/grpc');
+        let grpcServer;
+        try {
+            grpcServer = createGrpcServer(api);
+        } catch (e) {
+            fail(e.message);
+        }
+        listenGrpc(grpcServer, host, Number(flags.grpcPort))
+            .then(port => console.error(`Serving gRPC (ucn.v1.Ucn) on ${address(port)}`))
+            .catch(e => fail(`Cannot serve gRPC on ${address(flags.grpcPort)}: ${e.message}`));
+    }
+    const server = createApiServer(api);
+    server.on('error', e => fail(`Cannot serve on ${address(flags.port ?? DEFAULT_PORT)}: ${e.message}`));
+    server.listen(Number(flags.port ?? DEFAULT_PORT), host, () => {
+        const { port } = server.address();
+        console.error(`Serving ${index.root} on http://${address(port)} (Ctrl-C to stop)`);
+    });
+}
+
+/**
+ * Under the FINDING_FORMATS, --sqlite and --baseline, only the finding
+ * commands have a form, and under --format mermaid only graph and trace;
+ * remember which one runs, and where, so printOutput can build its
+ * findings (the HTML report its snippets, the SQLite export its symbols
+ * and edges).
+ * @param {string} canonical - Canonical command name
+ * @param {string} root - Project root
+ */
+function requireFindingCommand(canonical, root) {
+    if ((flags.stdin || flags.lang) && canonical !== 'check') {
+        fail(`--stdin and --lang apply to check, not '${toCliName(canonical)}'.`);
+    }
+    if (flags.lang && !flags.stdin) fail('--lang names the language of the buffer check --stdin reads.');
+    // check --stdin reports the buffer's findings, as the finding commands do
+    const findings = output.SARIF_COMMANDS.has(canonical) || (canonical === 'check' && flags.stdin);
+    if ((flags.failOn != null || flags.maxFindings.length > 0 || flags.maxDeadLines != null) && !findings) {
+        fail(`--fail-on, --max-findings and --max-dead-lines apply to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    // The exit code policy (flags or .ucn.json) judges every finding command
+    if (findings) flags._policyCommand = canonical;
+    if (flags.sqlite !== undefined && !findings) {
+        fail(`--sqlite applies to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if (flags.notify && !findings) {
+        fail(`--notify applies to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if (flags.report !== undefined && !findings) {
+        fail(`--report applies to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if ((flags.sort !== undefined || flags.groupBy !== undefined) && !findings) {
+        fail(`--sort and --group-by apply to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if ((flags.baseline !== undefined || flags._baselineCreate) && !findings) {
+        fail(`Baselines apply to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if (flags.diffBase && !output.SARIF_COMMANDS.has(canonical)) {
+        fail(`ucn diff applies to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if (flags._tui && !output.SARIF_COMMANDS.has(canonical)) {
+        fail(`ucn tui applies to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if (flags._watch && !output.SARIF_COMMANDS.has(canonical)) {
+        fail(`ucn watch applies to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    if (flags.format === 'patch' && canonical !== 'deadcode') {
+        fail(`--format patch applies to deadcode findings, not '${toCliName(canonical)}'.`);
+    }
+    if (flags._fix && canonical !== 'deadcode') {
+        fail(`ucn fix applies to deadcode findings, not '${toCliName(canonical)}'.`);
+    }
+    if (flags.format === 'mermaid') {
+        if (!output.MERMAID_COMMANDS.has(canonical)) {
+            fail(`--format mermaid applies to graph and trace, not '${toCliName(canonical)}'.`);
+        }
+        flags._command = canonical;
+        return;
+    }
+    if (!FINDING_FORMATS.has(flags.format) && flags.sqlite === undefined && flags.baseline === undefined && !flags._baselineCreate && !flags._fix && !flags._tui &&
+        !flags._hookFiles && flags.report === undefined && !flags.notify && flags.sort === undefined && flags.groupBy === undefined) return;
+    if (!findings) {
+        fail(`--format ${flags.format} applies to deadcode, clones, audit-async and deprecated, not '${toCliName(canonical)}'.`);
+    }
+    flags._command = canonical;
+    flags._root = root;
 }
 
 /**
@@ -432,6 +1037,54 @@ function printInlineExpand(ctx, root) {
     for (const c of ctx.callees || []) preview(c, 'callee');
 }
 
+// ============================================================================
+// COMPLETION AND MAN PAGE
+// ============================================================================
+
+function printGenerated(words) {
+    const completion = require('../core/completion');
+    if (words[0] === 'man') {
+        if (words.length > 1) {
+            console.error('Usage: ucn man');
+            process.exit(1);
+        }
+        process.stdout.write(completion.manPage(usageText(), require('../package.json').version));
+        return;
+    }
+    if (words.length !== 2 || !completion.SHELLS.includes(words[1])) {
+        console.error(`Usage: ucn completion <${completion.SHELLS.join('|')}>`);
+        process.exit(1);
+    }
+    const { SORT_KEYS, GROUP_KEYS } = require('../core/arrange');
+    const { FAIL_ON } = require('../core/fail-policy');
+    const findingCommands = ['deadcode', 'clones', 'audit-async', 'deprecated'];
+    const spec = completion.completionSpec(usageText(), {
+        commands: COMMANDS,
+        resolve: name => resolveCommand(name, 'cli'),
+        knownFlags,
+        valueFlags: VALUE_FLAGS,
+        applicability: FLAG_APPLICABILITY,
+        values: {
+            '--format': ['text', 'json', 'mermaid', ...FINDING_FORMATS],
+            '--sort': SORT_KEYS,
+            '--group-by': GROUP_KEYS,
+            '--fail-on': FAIL_ON,
+            '--report': require('../core/report').REPORTER_NAMES,
+            '--log-format': ['text', 'json'],
+            '--direction': ['imports', 'importers', 'both', 'callees', 'callers'],
+        },
+        subcommands: {
+            completion: completion.SHELLS,
+            baseline: ['create'],
+            hook: ['install', 'run'],
+            diff: findingCommands,
+            watch: findingCommands,
+            tui: findingCommands,
+        },
+    });
+    process.stdout.write(completion.completionScript(words[1], spec));
+}
+
 // ============================================================================
 // MAIN
 // ============================================================================
@@ -443,6 +1096,134 @@ function main() {
     // Determine target and command based on positional args
     let target, command, arg;
 
+    if (positionalArgs[0] === 'completion' || positionalArgs[0] === 'man') {
+        // ucn completion <shell>, ucn man: generated from the usage text
+        // and the command registry, printed to stdout
+        printGenerated(positionalArgs);
+        return;
+    }
+
+    if (positionalArgs[0] === 'serve') {
+        // ucn serve: build the index once, then answer the HTTP API over it
+        if (positionalArgs.length > 1) {
+            console.error('Usage: ucn serve [--port=N] [--host=ADDR] [--grpc-port=N] [deadcode flags]');
+            process.exit(1);
+        }
+        flags._serve = true;
+        positionalArgs.splice(0, positionalArgs.length, 'deadcode');
+    } else if (flags.port !== undefined || flags.host !== undefined || flags.grpcPort !== undefined) {
+        console.error('--port, --host and --grpc-port apply to ucn serve.');
+        process.exit(1);
+    }
+
+    if (positionalArgs[0] === 'lsp') {
+        // ucn lsp: a Language Server Protocol server on stdin/stdout,
+        // running deadcode with the given flags
+        if (positionalArgs.length > 1) {
+            console.error('Usage: ucn lsp [--stdio] [deadcode flags]');
+            process.exit(1);
+        }
+        const deadcode = {};
+        for (const p of FLAG_APPLICABILITY.deadcode) if (flags[p] != null) deadcode[p] = flags[p];
+        require('../core/lsp').startLspServer({
+            deadcode,
+            cache: flags.cache,
+            buildOptions: { followSymlinks: flags.followSymlinks, maxFiles: flags.maxFiles, workers: flags.workers, include: flags.include, exclude: flags.exclude },
+        });
+        return;
+    }
+
+    if (positionalArgs[0] === 'baseline') {
+        // ucn baseline create [command]: run the finding command, write its
+        // findings to the baseline file instead of printing them
+        if (positionalArgs[1] !== 'create' || positionalArgs.length > 3) {
+            console.error('Usage: ucn baseline create [deadcode|clones|audit-async|deprecated] [--baseline=FILE]');
+            process.exit(1);
+        }
+        flags._baselineCreate = flags.baseline || require('../core/baseline').DEFAULT_BASELINE_FILE;
+        flags.baseline = undefined;
+        positionalArgs.splice(0, positionalArgs.length, positionalArgs[2] || 'deadcode');
+    }
+
+    if (positionalArgs[0] === 'diff') {
+        // ucn diff [command] --base <ref>: analyze the project now and at
+        // ref, report only the findings introduced (and list the resolved)
+        if (positionalArgs.length > 2) {
+            console.error('Usage: ucn diff [deadcode|clones|audit-async|deprecated] [--base=REF]');
+            process.exit(1);
+        }
+        flags.diffBase = flags.base || 'HEAD';
+        flags.base = undefined;
+        positionalArgs.splice(0, positionalArgs.length, positionalArgs[1] || 'deadcode');
+    }
+
+    if (positionalArgs[0] === 'watch') {
+        // ucn watch [command]: print its findings, then what each change
+        // introduces and resolves
+        if (positionalArgs.length > 2) {
+            console.error('Usage: ucn watch [deadcode|clones|audit-async|deprecated]');
+            process.exit(1);
+        }
+        flags._watch = true;
+        positionalArgs.splice(0, positionalArgs.length, positionalArgs[1] || 'deadcode');
+    }
+
+    if (positionalArgs[0] === 'tui') {
+        // ucn tui [command]: browse its findings in the terminal
+        if (positionalArgs.length > 2) {
+            console.error('Usage: ucn tui [deadcode|clones|audit-async|deprecated]');
+            process.exit(1);
+        }
+        flags._tui = true;
+        positionalArgs.splice(0, positionalArgs.length, positionalArgs[1] || 'deadcode');
+    }
+
+    if (positionalArgs[0] === 'hook') {
+        // ucn hook install: a git pre-commit hook running ucn hook run.
+        // ucn hook run [files...]: deadcode's findings in the files (the
+        // staged ones by default), on the incrementally rebuilt index
+        const hook = require('../core/hook');
+        if (positionalArgs[1] === 'install' && positionalArgs.length === 2) {
+            try {
+                const { file, replaced } = hook.installHook(process.cwd(), { force: flags.force });
+                console.log(`${replaced ? 'Replaced' : 'Installed'} pre-commit hook: ${file}`);
+            } catch (e) {
+                console.error(e.message);
+                process.exit(1);
+            }
+            return;
+        }
+        if (positionalArgs[1] !== 'run') {
+            console.error('Usage: ucn hook install [--force] | ucn hook run [deadcode flags] [files...]');
+            process.exit(1);
+        }
+        let files;
+        try {
+            files = hook.hookFiles(positionalArgs.slice(2), process.cwd());
+        } catch (e) {
+            console.error(e.message);
+            process.exit(1);
+        }
+        // Nothing ucn analyzes is being committed
+        if (files.length === 0) return;
+        flags._hookFiles = new Set(files);
+        positionalArgs.splice(0, positionalArgs.length, 'deadcode');
+    } else if (flags.force) {
+        console.error('--force applies to ucn hook install.');
+        process.exit(1);
+    }
+
+    if (positionalArgs[0] === 'fix') {
+        // ucn fix: run deadcode, delete what is safe to delete
+        if (positionalArgs.length > 1) {
+            console.error('Usage: ucn fix [--dry-run] [--interactive] [--import-issues]');
+            process.exit(1);
+        }
+        flags._fix = { interactive: flags.interactive };
+        flags.interactive = false;
+        positionalArgs.splice(0, positionalArgs.length, 'deadcode');
+    }
+
     if (positionalArgs.length === 0) {
         // No args: show help
         printUsage();
@@ -671,14 +1452,29 @@ function runProjectCommand(rootDir, command, arg) {
         }
     }
 
+    // rules needs the config, not an index
+    if (resolveCommand(command, 'cli') === 'rules') {
+        const { ok, result, error } = execute(index, 'rules', {});
+        if (!ok) fail(error);
+        printOutput(result, output.formatRulesJson, output.formatRules);
+        return;
+    }
+
+    // Globs and regexes in --include/--exclude leave files out of the
+    // index itself, so such a run neither loads nor saves the cache
+    const pathPatterns = [...flags.include, ...flags.exclude].some(p => compilePathPattern(p));
+    const buildOptions = { followSymlinks: flags.followSymlinks, maxFiles: flags.maxFiles, workers: flags.workers, include: flags.include, exclude: flags.exclude };
+
     // Try to load cache if enabled
     let usedCache = false;
     let cacheWasLoaded = false;
-    if (flags.cache && !flags.clearCache) {
+    if (flags.cache && !flags.clearCache && !pathPatterns) {
         const loaded = index.loadCache();
+        const stale = loaded && index.isCacheStale();
+        log.debug('cache.load', { root: index.root, loaded: !!loaded, stale: loaded ? stale : undefined });
         if (loaded) {
             cacheWasLoaded = true;
-            if (!index.isCacheStale()) {
+            if (!stale) {
                 usedCache = true;
                 if (!flags.quiet) {
                     console.error('Using cached index');
@@ -691,8 +1487,10 @@ function runProjectCommand(rootDir, command, arg) {
     // If cache was loaded but stale, force rebuild to avoid duplicates
     let needsCacheSave = false;
     if (!usedCache) {
-        index.build(null, { quiet: flags.quiet, forceRebuild: cacheWasLoaded, followSymlinks: flags.followSymlinks, maxFiles: flags.maxFiles, workers: flags.workers });
-        needsCacheSave = flags.cache;
+        const progress = flags.progress ? log.createProgress() : undefined;
+        index.build(null, { ...buildOptions, quiet: flags.quiet, forceRebuild: cacheWasLoaded, progress });
+        if (progress) progress.finish(index.buildTimings);
+        needsCacheSave = flags.cache && !pathPatterns;
         // Clear stale expand cache — line ranges may have shifted after rebuild
         try {
             const expandPath = path.join(index.root, '.ucn-cache', 'expandable.json');
@@ -700,9 +1498,12 @@ function runProjectCommand(rootDir, command, arg) {
         } catch (_) { /* best-effort */ }
     }
 
+    const commandStart = Date.now();
     try {
     // Resolve CLI aliases to canonical command names — dispatch on canonical
     const canonical = resolveCommand(command, 'cli') || command;
+    requireFindingCommand(canonical, index.root);
+    flags._index = index;
 
     // Warn about flags that don't apply to this command
     const applicableFlags = FLAG_APPLICABILITY[canonical];
@@ -710,7 +1511,7 @@ function runProjectCommand(rootDir, command, arg) {
         // Map from camelCase flag name to CLI flag string
         const flagToCli = (f) => '--' + f.replace(/([a-z])([A-Z])/g, '$1-$2').toLowerCase();
         // Flags that are global (not command-specific) — skip warning for these
-        const globalFlags = new Set(['json', 'quiet', 'cache', 'clearCache', 'followSymlinks', 'maxFiles', 'verbose', 'expand', 'interactive', '_fileFromFileMode', 'topRaw', 'limitRaw', 'maxFilesRaw', 'maxLinesRaw', 'depthRaw', 'contextRaw', 'workersRaw']);
+        const globalFlags = new Set(['json', 'format', '_command', '_root', '_index', 'sqlite', 'template', '_template', 'baseline', '_baselineCreate', 'diffBase', '_fix', 'dryRun', '_watch', '_tui', 'quiet', 'cache', 'clearCache', 'followSymlinks', 'maxFiles', 'include', 'failOn', 'maxFindings', 'maxDeadLines', '_policyCommand', 'verbose', 'expand', 'interactive', '_fileFromFileMode', 'topRaw', 'limitRaw', 'maxFilesRaw', 'maxLinesRaw', 'depthRaw', 'contextRaw', 'workers', 'workersRaw', 'stdin', 'lang', 'progress', 'verbosity', 'logFormat', 'sort', 'groupBy', '_serve', 'port', 'host', 'grpcPort', 'force', '_hookFiles', 'report', 'notify']);
         for (const [key, value] of Object.entries(flags)) {
             if (globalFlags.has(key)) continue;
             // Skip unset values (undefined, null, 0, empty array) — but NOT false (explicit negation)
@@ -725,6 +1526,16 @@ function runProjectCommand(rootDir, command, arg) {
         printTieredNoOpNotes(canonical, flags, (m) => console.error(m));
     }
 
+    if (flags._watch) {
+        runWatch(index, canonical, subdirScope);
+        return;
+    }
+
+    if (flags._serve) {
+        runServe(index, subdirScope);
+        return;
+    }
+
     switch (canonical) {
         // ── Commands using shared executor ───────────────────────────────
 
@@ -956,6 +1767,19 @@ function runProjectCommand(rootDir, command, arg) {
         }
 
         case 'check': {
+            if (flags.stdin) {
+                // Cache the index as built from the disk before the buffer overlays it
+                if (needsCacheSave) {
+                    try { index.saveCache(); } catch (_) { /* best-effort */ }
+                    needsCacheSave = false;
+                }
+                const { ok, result, error } = execute(index, 'check', {
+                    content: fs.readFileSync(0, 'utf-8'), file: flags.file, lang: flags.lang,
+                });
+                if (!ok) fail(error);
+                printOutput(result, output.formatCheckJson, output.formatCheck);
+                break;
+            }
             const { ok, result, error } = execute(index, 'check', {
                 base: flags.base, staged: flags.staged,
                 file: flags.file, limit: flags.limit,
@@ -1031,6 +1855,12 @@ function runProjectCommand(rootDir, command, arg) {
         }
 
         case 'graph': {
+            if (flags.symbols) {
+                const { ok, result, error } = execute(index, 'graph', { symbols: true, name: arg, file: flags.file, in: flags.in, exclude: flags.exclude, includeTests: flags.includeTests, direction: flags.direction, depth: flags.depth });
+                if (!ok) fail(error);
+                printOutput(result, output.formatGraphJson, output.formatGraph);
+                break;
+            }
             const filePath = arg || flags.file;
             const { ok, result, error } = execute(index, 'graph', { file: filePath, direction: flags.direction, depth: flags.depth, all: flags.all });
             if (!ok) fail(error);
@@ -1188,6 +2018,11 @@ function runProjectCommand(rootDir, command, arg) {
                 file: flags.file,
                 exclude: flags.exclude,
                 limit: flags.limit,
+                diffBase: flags.diffBase,
+                baseline: flags.baseline,
+                quiet: flags.quiet,
+                includeGenerated: flags.includeGenerated,
+                generatedMarkers: flags.generatedMarkers,
             });
             if (!ok) fail(error);
             if (note) console.error(note);
@@ -1195,6 +2030,38 @@ function runProjectCommand(rootDir, command, arg) {
             break;
         }
 
+        case 'clones': {
+            const { ok, result, error, note } = execute(index, 'clones', { ...flags, in: flags.in || subdirScope });
+            if (!ok) fail(error);
+            if (note) console.error(note);
+            printOutput(result, output.formatClonesJson, output.formatClones);
+            break;
+        }
+
+        case 'deprecated': {
+            const { ok, result, error, note } = execute(index, 'deprecated', { ...flags, in: flags.in || subdirScope });
+            if (!ok) fail(error);
+            if (note) console.error(note);
+            printOutput(result, output.formatDeprecatedJson, output.formatDeprecated);
+            break;
+        }
+
+        case 'explain': {
+            const { ok, result, error, note } = execute(index, 'explain', { ...flags, name: arg, in: flags.in || subdirScope });
+            if (!ok) fail(error);
+            if (note) console.error(note);
+            printOutput(result, output.formatExplainJson, output.formatExplain);
+            break;
+        }
+
+        case 'why': {
+            const { ok, result, error, note } = execute(index, 'why', { ...flags, name: arg });
+            if (!ok) fail(error);
+            if (note) console.error(note);
+            printOutput(result, output.formatWhyJson, output.formatWhy);
+            break;
+        }
+
         default:
             console.error(`Unknown command: ${canonical}`);
             printUsage();
@@ -1211,8 +2078,10 @@ function runProjectCommand(rootDir, command, arg) {
         // On cache-hit runs, only re-save if callsCache was mutated OR
         // reachability was computed (MED-1: persists the BFS result so
         // subsequent cold invocations don't repeat the 7-11s tax).
+        log.debug('command.done', { command, ms: Date.now() - commandStart, exitCode: process.exitCode || 0 });
         if (flags.cache && (needsCacheSave || index.callsCacheDirty || index.reachabilityDirty)) {
             try { index.saveCache(); } catch (e) { /* best-effort */ }
+            log.debug('cache.save', { root: index.root });
         }
     }
 }
@@ -1280,8 +2149,10 @@ function runGlobCommand(pattern, command, arg) {
     // This gives glob mode the same semantics as project mode: test exclusions,
     // limit, all flags — no bespoke logic, no parity drift.
     const rootDir = findProjectRoot(path.dirname(files[0]));
+    requireFindingCommand(canonical, rootDir);
     const index = new ProjectIndex(rootDir);
     index.build(files, { quiet: true });
+    flags._index = index;
 
     // Supported commands — anything that works with an index.
     // All execute() commands are supported; only expand (requires cached state)
@@ -1296,7 +2167,7 @@ function runGlobCommand(pattern, command, arg) {
     const params = {};
     const needsName = new Set(['find', 'usages', 'fn', 'class', 'typedef', 'about', 'context',
         'smart', 'impact', 'trace', 'blast', 'reverseTrace', 'tests', 'affectedTests',
-        'example', 'verify', 'plan', 'related']);
+        'example', 'verify', 'plan', 'related', 'explain', 'why']);
     if (needsName.has(canonical)) {
         if (!arg) {
             console.error(`Usage: ucn "pattern" ${command} <name>`);
@@ -1318,7 +2189,7 @@ function runGlobCommand(pattern, command, arg) {
     const applicableFlags = FLAG_APPLICABILITY[canonical];
     if (applicableFlags) {
         const flagToCli = (f) => '--' + f.replace(/([a-z])([A-Z])/g, '$1-$2').toLowerCase();
-        const globalFlags = new Set(['json', 'quiet', 'cache', 'clearCache', 'followSymlinks', 'maxFiles', 'verbose', 'expand', 'interactive', '_fileFromFileMode', 'topRaw', 'limitRaw', 'maxFilesRaw', 'maxLinesRaw', 'depthRaw', 'contextRaw', 'workersRaw']);
+        const globalFlags = new Set(['json', 'format', '_command', '_root', '_index', 'sqlite', 'template', '_template', 'baseline', '_baselineCreate', 'diffBase', '_fix', 'dryRun', '_watch', '_tui', 'quiet', 'cache', 'clearCache', 'followSymlinks', 'maxFiles', 'include', 'failOn', 'maxFindings', 'maxDeadLines', '_policyCommand', 'verbose', 'expand', 'interactive', '_fileFromFileMode', 'topRaw', 'limitRaw', 'maxFilesRaw', 'maxLinesRaw', 'depthRaw', 'contextRaw', 'workers', 'workersRaw', 'progress', 'verbosity', 'logFormat', 'sort', 'groupBy', '_serve', 'port', 'host', 'grpcPort', 'force', '_hookFiles', 'report', 'notify']);
         for (const [key, value] of Object.entries(flags)) {
             if (globalFlags.has(key)) continue;
             if (value === undefined || value === null || value === 0 || (Array.isArray(value) && value.length === 0)) continue;
@@ -1334,7 +2205,8 @@ function runGlobCommand(pattern, command, arg) {
         params.range = arg;
     }
     if (['imports', 'exporters', 'fileExports', 'graph', 'api'].includes(canonical)) {
-        if (arg) params.file = arg;
+        if (canonical === 'graph' && flags.symbols) params.name = arg;
+        else if (arg) params.file = arg;
     }
 
     const { ok, result, error, note, structural } = execute(index, canonical, params);
@@ -1484,6 +2356,21 @@ function runGlobCommand(pattern, command, arg) {
         case 'auditAsync':
             printOutput(result, output.formatAuditAsyncJson, output.formatAuditAsync);
             break;
+        case 'clones':
+            printOutput(result, output.formatClonesJson, output.formatClones);
+            break;
+        case 'deprecated':
+            printOutput(result, output.formatDeprecatedJson, output.formatDeprecated);
+            break;
+        case 'rules':
+            printOutput(result, output.formatRulesJson, output.formatRules);
+            break;
+        case 'explain':
+            printOutput(result, output.formatExplainJson, output.formatExplain);
+            break;
+        case 'why':
+            printOutput(result, output.formatWhyJson, output.formatWhy);
+            break;
         case 'stacktrace':
             printOutput(result, output.formatStackTraceJson, output.formatStackTrace);
             break;
@@ -1510,7 +2397,13 @@ function runGlobCommand(pattern, command, arg) {
 // Single source of truth for the public CLI help. README points here ("Run `ucn --help`")
 // rather than carrying a copy — keep it that way.
 function printUsage() {
-    console.log(`UCN - Universal Code Navigator
+    console.log(usageText());
+}
+
+// The help text; also the source of the completion scripts' and the man
+// page's descriptions (core/completion.js)
+function usageText() {
+    return `UCN - Universal Code Navigator
 
 Supported: JavaScript, TypeScript, Python, Go, Rust, Java, HTML
 
@@ -1561,6 +2454,8 @@ FILE DEPENDENCIES
   exporters <file>    Who imports this file
   file-exports <file> What does file export
   graph <file>        Full dependency tree (--depth=N, --direction=imports|importers|both)
+  graph --symbols [p] Call graph of symbols matching p (glob) as Graphviz DOT, nodes marked
+                        entry point / unreachable (--in=, --depth=N, --direction=callees|callers|both)
   circular-deps       Detect circular import chains (--file=, --exclude=)
 
 ═══════════════════════════════════════════════════════════════════════════════
@@ -1570,6 +2465,8 @@ REFACTORING HELPERS
   verify <name>       Check all call sites match signature
   diff-impact         What changed in git diff and who calls it (--base, --staged)
   check               Pre-commit summary: diff-impact + verify + affected-tests in one shot
+                        --stdin: findings in an unsaved buffer read from stdin, saved as --file
+                        (or in --lang, if it has no path), against the project's index
   deadcode            Unreferenced-symbol candidates (review before deletion)
   entrypoints         Detect framework entry points (routes, DI, tasks)
   endpoints           HTTP API: list server routes + client requests; --bridge to match
@@ -1586,20 +2483,93 @@ OTHER
   orient              One-screen repo map: size, top dirs, hot functions, entry points, readiness (--top=N)
   stacktrace <text>   Parse stack trace, show code at each frame (alias: stack)
   audit-async         Find calls in async functions that are likely missing await (JS/TS/Python)
+  clones              Near-duplicate functions across files and packages
+                        --min-lines=6 --min-tokens=50 --similarity=0.9
+                        --literals: string/number literals repeated in --min-files=3 or more files
+  deprecated          Deprecated symbols: still-used ones with their callers, unreferenced ones to delete
+  rules               Every rule the finding commands report: severity, command, .ucn.json settings
+  explain <x>         A rule's entry, or why the finding with fingerprint x was reported: the checks
+                        and the caller trace (pass the run's flags, e.g. --unused-params)
+  why <name>          Shortest call chain from an entry point to name, or its direct callers if none
+  baseline create [c] Snapshot the findings of c (deadcode by default, or clones, audit-async,
+                        deprecated) into .ucn-baseline.json (--baseline=FILE to choose)
+  diff [c]            Findings of c (deadcode by default) introduced since --base=REF (HEAD by
+                        default), with the ones the change resolved
+  watch [c]           Print the findings of c (deadcode by default), then re-analyze as files
+                        change and print what each change introduced and resolved
+  tui [c]             Browse the findings of c (deadcode by default) by package or rule, with code
+                        previews and references; mark findings ignored (comment or baseline)
+  fix                 Delete unused unexported functions and constants (--import-issues: unused
+                        imports); --dry-run prints a unified diff, --interactive asks per removal
+  hook install        Write a git pre-commit hook running ucn hook run (--force replaces another)
+  hook run [files]    deadcode's findings in the files (the staged ones by default), on the cached
+                        index; exits 1 on a warning (pre-commit framework entry point)
+  completion <shell>  Print the completion script for bash, zsh, fish or powershell
+                        (e.g. source <(ucn completion bash))
+  man                 Print the ucn(1) man page (e.g. ucn man | man -l -)
+  serve               HTTP/JSON API over a persistent index (--port=7070, --host=127.0.0.1):
+                        POST /analyze, GET /findings, GET /symbols/<handle or name>/references;
+                        --grpc-port=N serves them over gRPC too (proto/ucn.proto)
+  lsp                 Language server on stdin/stdout: deadcode diagnostics as you edit, "remove
+                        unused" code actions, find-references (takes the deadcode flags)
 
 Common Flags:
   --file <pattern>    Filter by file path (e.g., --file=routes)
-  --exclude=a,b       Exclude patterns (e.g., --exclude=test,mock)
+  --exclude=a,b       Exclude patterns (e.g., --exclude=test,mock); globs (vendor/, **/*.pb.go)
+                        and re:<regex> patterns also skip the files before parsing
+  --include=a,b       Index only files matching these globs or re:<regex> patterns
   --in=<path>         Only in path (e.g., --in=src/core)
   --depth=N           Max depth: blast=3, trace=3, reverse-trace=5, graph=2, affected-tests=3
   --direction=X       Graph direction: imports, importers, or both (default: both)
   --all               Show full results: all callers/callees + unverified (about/context), full tree (trace/blast),
                         all names (related/find/fn/class/toc), all changed (diff-impact)
   --top=N             Limit callers/callees (about), similar functions (related), search results
+                        deadcode: the N findings whose deletion removes the most lines
   --limit=N           Limit result count (find, usages, search, deadcode, api, toc, entrypoints, diff-impact)
   --max-files=N       Max files to index (large projects)
   --context=N         Lines of context around matches (search, usages)
   --json              Machine-readable output
+  --format=F          Output format: text (default), json, sarif, jsonl, html, html-coverage, lcov,
+                        markdown, csv, tsv, junit, tap, checkstyle, gitlab, rdjson, rdjsonl, sonarqube,
+                        sql, prometheus, template, patch, or mermaid. For deadcode, clones, audit-async and deprecated: sarif
+                        (SARIF 2.1.0, for GitHub Code Scanning), jsonl (one finding per line), html
+                        (one self-contained report page), html-coverage (whole files, dead lines
+                        shaded like a coverage report), lcov (dead lines as uncovered, for coverage
+                        tools), markdown (a PR-comment summary), csv/tsv (file, line, symbol,
+                        kind, rule, confidence, message), junit (a failed test case per rule and
+                        package), tap (a TAP 13 stream, a failing test point per finding),
+                        checkstyle (an error per finding, for lint plugins), gitlab (a Code
+                        Quality report for merge requests), rdjson/rdjsonl (reviewdog diagnostics),
+                        sonarqube (generic issue data, for sonar.externalIssuesReportPaths),
+                        sql (a script that loads findings, symbols and call edges into SQLite),
+                        prometheus (gauges per rule and package, for a textfile collector),
+                        template (the --template file, rendered over the findings).
+                        For deadcode: patch (a unified diff of the removals ucn fix would make,
+                        for git apply). For graph and trace: mermaid (a flowchart block for markdown)
+  --template=FILE     Go text/template file for --format template; it sees .Command, .Root,
+                        .Version, .Total, .Rules and .Findings (.File, .Line, .Symbol, .Rule,
+                        .Message, ...), with printf, json, join, len, eq and the other standard
+                        functions
+  --baseline=FILE     Hide the findings a baseline file lists (deadcode, clones, audit-async,
+                        deprecated), so only new ones are shown
+  --sort=KEY          Order findings by file, symbol, size, rule or loc-saved (lines deleting
+                        them would remove), in every output format
+  --group-by=KEY      Cluster findings by package, rule or owner (CODEOWNERS); text and JSON
+                        get one section per group
+  --fail-on=S         Exit 1 when a finding is at severity S or above: error, warning, info,
+                        any or none (default; .ucn.json "failOn")
+  --max-findings=R=N  Exit 1 when rule R has more than N findings; a bare N caps all findings
+                        (comma-separated or repeated; .ucn.json "maxFindings")
+  --max-dead-lines=N  Exit 1 only when the findings' removable lines add up to more than N
+                        (a dead code budget; .ucn.json "maxDeadLines")
+  --sqlite=FILE       Also record the run (findings, symbols, call edges) in a SQLite database
+                        (Node 22.5+; successive runs append, for history)
+  --report=R          Also post the findings: github-checks (a check run with annotations;
+                        UCN_GITHUB_TOKEN or GITHUB_TOKEN), bitbucket (a Code Insights report;
+                        UCN_BITBUCKET_TOKEN), azure-devops (pull request comment threads;
+                        SYSTEM_ACCESSTOKEN or UCN_AZURE_TOKEN)
+  --notify            Also send a summary (totals, new and resolved findings since the last
+                        --notify run) to the .ucn.json "notify" sinks: Slack or a webhook
   --compact           Token-efficient about/context/impact output
   --code-only         Filter out comments/strings (search, usages)
   --with-types        Include type definitions (about, smart)
@@ -1625,6 +2595,37 @@ Common Flags:
   --diverse           Cluster call sites by argument shape (example command, pair with --top=N)
   --git               Attach git enrichment (last modified, author, recent commits) to about/brief
   --include-decorated Include decorated/annotated symbols in deadcode
+  --include-generated Report findings in generated files too (deadcode, clones, audit-async, deprecated)
+  --generated-marker=T Text that marks a file as generated when in its first 500 characters (repeatable)
+  --interface-methods Interface methods never called through the interface (deadcode)
+  --unused-params     Function parameters never read in the body (deadcode)
+  --unreachable-code  Statements no execution reaches, e.g. after return/panic (deadcode)
+  --package-vars      Package-level variables never read: write-only or unused (deadcode)
+  --types             Only type declarations, aliases and defined types included (deadcode)
+  --unused-results    Functions whose return values every caller discards (deadcode)
+  --orphan-files      Whole files nothing imports and nothing outside uses (deadcode)
+  --build-variants    Go functions only in files no target platform builds (deadcode)
+  --platforms=a/b,... Target-platform matrix for --build-variants (e.g., linux/amd64,darwin/arm64)
+  --test-only         Symbols referenced only from test files (deadcode)
+  --test-helpers      Test helpers no test calls and testdata fixtures nothing names (deadcode)
+  --sentinel-errors   Go sentinel errors never returned or compared (deadcode)
+  --library           Exported Go API no importer uses (deadcode)
+  --dependents=a,b    Dependent repo checkouts whose imports count for --library
+  --embeds            Go //go:embed variables never read and embedded files no path names (deadcode)
+  --channels          Go channels never received from or sent to, goroutines dropping results (deadcode)
+  --config-knobs      Go flags, env variables and viper keys whose values are never read (deadcode)
+  --satisfies-only    Go interfaces used only in var _ assertions, with the methods kept just for them (deadcode)
+  --redundant-assertions  Go var _ I = (*T)(nil) assertions whose interface has no other use (deadcode, low severity)
+  --type-params       Go type parameters a generic function or type never mentions (deadcode)
+  --init-effects      Go init() functions and blank imports whose side effects nothing uses (deadcode)
+  --import-issues     Go imports unused under build tags, duplicated, or shadowed, with rewrite edits (deadcode)
+  --config-keys       YAML/JSON/TOML config keys no code reads and tagged config fields no file sets (deadcode)
+  --complexity        Cyclomatic/cognitive complexity on dead functions, plus functions over threshold (deadcode)
+  --max-cyclomatic=N  Cyclomatic complexity threshold for --complexity (default 10)
+  --max-cognitive=N   Cognitive complexity threshold for --complexity (default 15)
+  --size              Functions over line/statement limits and types over method/field limits (deadcode)
+  --max-function-lines=N, --max-statements=N, --max-methods=N, --max-fields=N
+                        Limits for --size (defaults 80, 50, 20, 15; per language in .ucn.json "size")
   --deep              Add a stratified evidence profile to doctor (not measured accuracy)
   --framework=X       Filter entrypoints by framework (e.g., --framework=express,spring)
   --bridge            Match server routes to client requests (endpoints command).
@@ -1640,15 +2641,24 @@ Common Flags:
   --case-sensitive    Case-sensitive text search (search)
   --top-level         Show only top-level functions in toc
   --max-lines=N       Max source lines for class (large classes show summary)
-  --workers=N         Parallel build workers (auto-detect; 0 to disable, env: UCN_WORKERS)
+  --jobs=N            Parallel build worker pool size (auto-detect; 1 builds sequentially,
+                      env: UCN_JOBS; --workers=N is the older name)
   --no-cache          Disable caching
   --clear-cache       Clear cache before running
   --base=<ref>        Git ref for diff-impact (default: HEAD)
   --staged            Analyze staged changes (diff-impact)
+  --stdin             Read the buffer check analyzes from stdin (--lang=go when it has no --file)
   --no-follow-symlinks  Don't follow symbolic links
   --mcp               Start the MCP stdio server
+  --port=N            Port ucn serve listens on (default 7070; 0 picks a free one)
+  --host=ADDR         Address ucn serve listens on (default 127.0.0.1, local only)
+  --grpc-port=N       Also serve the gRPC service of proto/ucn.proto on port N
+  --stdio             Accepted for LSP clients that pass it; ucn lsp always serves on stdin/stdout
   -i, --interactive   Keep index in memory for multiple queries
-  -v, --version       Print the UCN version and exit
+  --progress          Show indexing progress and phase timings on stderr
+  -v, -vv             Log debug (-v) or trace (-vv, every file parsed) events to stderr
+  --log-format=json   Log events as JSON lines instead of text (with -v/-vv)
+  --version           Print the UCN version and exit (also -v on its own)
 
 Quick Start:
   ucn orient                          # First look at a new repo
@@ -1656,7 +2666,7 @@ Quick Start:
   ucn about handleRequest             # Understand a function
   ucn impact handleRequest            # Before modifying
   ucn fn handleRequest --file api     # Extract specific function
-  ucn --interactive                   # Multiple queries`);
+  ucn --interactive                   # Multiple queries`;
 }
 
 // ============================================================================
@@ -1669,6 +2679,7 @@ function runInteractive(rootDir) {
 
     console.log('Building index...');
     const index = new ProjectIndex(rootDir);
+    const iProgress = flags.progress ? log.createProgress() : undefined;
     // Same cache discipline as one-shot mode (fix #250: the REPL fully
     // re-parsed every session and never consumed cache-persisted state —
     // the divergence mechanism behind the relocation P1).
@@ -1677,15 +2688,17 @@ function runInteractive(rootDir) {
         const loaded = index.loadCache();
         iCacheFresh = loaded && !index.isCacheStale();
         if (!iCacheFresh && loaded) {
-            index.build(null, { quiet: true, forceRebuild: true, workers: flags.workers });
+            index.build(null, { quiet: true, forceRebuild: true, workers: flags.workers, progress: iProgress });
         } else if (!iCacheFresh) {
-            index.build(null, { quiet: true, workers: flags.workers });
+            index.build(null, { quiet: true, workers: flags.workers, progress: iProgress });
         }
+        if (!iCacheFresh && iProgress) iProgress.finish(index.buildTimings);
         if (!iCacheFresh) {
             try { index.saveCache(); } catch (_) { /* best-effort */ }
         }
     } else {
-        index.build(null, { quiet: true, workers: flags.workers });
+        index.build(null, { quiet: true, workers: flags.workers, progress: iProgress });
+        if (iProgress) iProgress.finish(index.buildTimings);
     }
     const iExpandCache = new ExpandCache({ maxSize: 20 });
     console.log(`Index ready: ${index.files.size} files, ${index.symbols.size} unique symbol names`);
@@ -1734,6 +2747,7 @@ Commands:
   class <name>           Extract class code (--file=)
   lines <range>          Extract lines (--file= required)
   graph <file>           File dependency tree (--direction=, --depth=)
+  graph --symbols [p]    Symbol call graph as Graphviz DOT (--in=, --depth=)
   circular-deps          Circular import chains (--file=, --exclude=)
   file-exports <file>    File's exported symbols
   imports <file>         What file imports
@@ -1756,6 +2770,11 @@ Commands:
   doctor                 Parse health, blind spots, command proofs, and task readiness
   orient                 Repository map and readiness summary
   audit-async            Find likely missing-await calls (JS/TS/Python)
+  clones                 Near-duplicate functions (--min-lines=, --min-tokens=, --similarity=; --literals --min-files=)
+  deprecated             Deprecated symbols: still-used (with callers) and unreferenced
+  rules                  Rules the finding commands report
+  explain <x>            A rule's entry, or why finding x (a fingerprint) was reported
+  why <name>             Shortest call chain from an entry point to name
   rebuild                Rebuild index
   quit                   Exit
 
@@ -1779,7 +2798,7 @@ Flags can be added per-command: context myFunc --include-methods
         const tokens = input.split(/\s+/);
         const command = tokens[0];
         // Flags that take a space-separated value (--flag value)
-        const valueFlagNames = new Set(['--file', '--in', '--base', '--add-param', '--remove-param', '--rename-to', '--default', '--depth', '--top', '--context', '--max-lines', '--direction', '--exclude', '--not', '--stack', '--type', '--param', '--receiver', '--returns', '--decorator', '--limit', '--max-files', '--min-confidence', '--class-name', '--line', '--framework', '--method', '--prefix']);
+        const valueFlagNames = new Set(['--file', '--in', '--base', '--add-param', '--remove-param', '--rename-to', '--default', '--depth', '--top', '--context', '--max-lines', '--direction', '--exclude', '--not', '--include', '--generated-marker', '--stack', '--type', '--param', '--receiver', '--returns', '--decorator', '--limit', '--max-files', '--min-confidence', '--class-name', '--line', '--framework', '--method', '--prefix']);
         const flagTokens = [];
         const argTokens = [];
         const skipNext = new Set();
@@ -1820,6 +2839,7 @@ Flags can be added per-command: context myFunc --include-methods
             // global CLI mode. MED-2/MED-3/MED-5: bad values are rejected with
             // a helpful message instead of being silently coerced.
             validateNumericFlags(iflags);
+            validatePathPatterns(iflags);
             const iCanonical = resolveCommand(command, 'cli') || command;
             executeInteractiveCommand(index, iCanonical, arg, iflags, iExpandCache);
         } catch (e) {
@@ -1874,7 +2894,7 @@ const INTERACTIVE_DISPATCH = {
     imports:      { params: 'file', format: (r, a, f) => output.formatImports(r, a || f.file) },
     exporters:    { params: 'file', format: (r, a, f) => output.formatExporters(r, a || f.file) },
     fileExports:  { params: 'file', format: (r, a, f) => output.formatFileExports(r, a || f.file) },
-    graph:        { params: (a, f) => ({ file: a || f.file, direction: f.direction, depth: f.depth, all: f.all }), format: (r, a, f) => { const d = f.depth ? parseInt(f.depth) : 2; return output.formatGraph(r, { showAll: f.all || !!f.depth, maxDepth: d, file: a || f.file }); } },
+    graph:        { params: (a, f) => (f.symbols ? { symbols: true, name: a, file: f.file, in: f.in, exclude: f.exclude, includeTests: f.includeTests, direction: f.direction, depth: f.depth } : { file: a || f.file, direction: f.direction, depth: f.depth, all: f.all }), format: (r, a, f) => { if (r.symbols) return output.formatGraph(r); const d = f.depth ? parseInt(f.depth) : 2; return output.formatGraph(r, { showAll: f.all || !!f.depth, maxDepth: d, file: a || f.file }); } },
     circularDeps: { params: (a, f) => ({ file: f.file, exclude: f.exclude }), format: (r) => output.formatCircularDeps(r) },
 
     // ── Refactoring Helpers ──────────────────────────────────────────
@@ -1896,6 +2916,11 @@ const INTERACTIVE_DISPATCH = {
     // coercion (topRaw when present, else undefined for default-10).
     stats:        { params: (a, f) => ({ functions: f.functions, hot: f.hot, top: f.topRaw != null ? f.topRaw : (f.top || undefined) }), format: (r, _a, f) => output.formatStats(r, { top: f.top }) },
     auditAsync:   { params: (a, f) => ({ file: f.file, exclude: f.exclude, limit: f.limit }), format: (r) => output.formatAuditAsync(r) },
+    clones:       { params: (a, f) => ({ file: f.file, exclude: f.exclude, in: f.in, includeTests: f.includeTests, limit: f.limit, minLines: f.minLines, minTokens: f.minTokens, similarity: f.similarity, literals: f.literals, minFiles: f.minFiles }), format: (r) => output.formatClones(r) },
+    deprecated:   { params: (a, f) => ({ file: f.file, exclude: f.exclude, in: f.in, includeTests: f.includeTests }), format: (r) => output.formatDeprecated(r) },
+    rules:        { params: () => ({}), format: (r) => output.formatRules(r) },
+    explain:      { params: 'name', format: (r) => output.formatExplain(r) },
+    why:          { params: 'name', format: (r) => output.formatWhy(r) },
 };
 
 /**
@@ -1920,7 +2945,7 @@ function executeInteractiveCommand(index, command, arg, iflags = {}, cache = nul
     const applicableFlags = FLAG_APPLICABILITY[command];
     if (applicableFlags) {
         const flagToCli = (f) => '--' + f.replace(/([a-z])([A-Z])/g, '$1-$2').toLowerCase();
-        const globalFlags = new Set(['json', 'quiet', 'cache', 'clearCache', 'followSymlinks', 'maxFiles', 'verbose', 'expand', 'interactive', '_fileFromFileMode', 'topRaw', 'limitRaw', 'maxFilesRaw', 'maxLinesRaw', 'depthRaw', 'contextRaw', 'workersRaw']);
+        const globalFlags = new Set(['json', 'format', '_command', '_root', '_index', 'sqlite', 'template', '_template', 'baseline', '_baselineCreate', 'diffBase', '_fix', 'dryRun', '_watch', '_tui', 'quiet', 'cache', 'clearCache', 'followSymlinks', 'maxFiles', 'include', 'failOn', 'maxFindings', 'maxDeadLines', '_policyCommand', 'verbose', 'expand', 'interactive', '_fileFromFileMode', 'topRaw', 'limitRaw', 'maxFilesRaw', 'maxLinesRaw', 'depthRaw', 'contextRaw', 'workers', 'workersRaw', 'progress', 'verbosity', 'logFormat', 'sort', 'groupBy', '_serve', 'port', 'host', 'grpcPort', 'force', '_hookFiles', 'report', 'notify']);
         for (const [key, value] of Object.entries(iflags)) {
             if (globalFlags.has(key)) continue;
             if (value === undefined || value === null || value === 0 || (Array.isArray(value) && value.length === 0)) continue;
@@ -2060,7 +3085,7 @@ function executeInteractiveCommand(index, command, arg, iflags = {}, cache = nul
 // RUN
 // ============================================================================
 
-if (flags.interactive) {
+if (flags.interactive && positionalArgs[0] !== 'fix') {
     let target = positionalArgs[0] || '.';
     if (COMMANDS.has(target)) target = '.';
     runInteractive(target);
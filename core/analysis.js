@@ -48,7 +48,8 @@ function tagInTestCase(index, sites) {
         const meta = { fileEntry: fe, langModule, language: fe.language, jsTestRanges: null };
         // For JS-family files, build line ranges of describe/it/test framework calls.
         if (langModule && (fe.language === 'javascript' || fe.language === 'typescript' ||
-            fe.language === 'tsx' || fe.language === 'html')) {
+            fe.language === 'tsx' || fe.language === 'html' || fe.language === 'vue' ||
+            fe.language === 'svelte')) {
             try {
                 const calls = index.getCachedCalls ? index.getCachedCalls(filePath) : null;
                 if (Array.isArray(calls)) {
@@ -882,7 +883,7 @@ function impact(index, name, options = {}) {
     // path here so `impact ClassName` agrees with `about ClassName` rather than
     // returning 0 because the legacy "function" branch doesn't recognize types.
     const TYPE_DEF_KINDS = new Set(['class', 'struct', 'interface', 'type',
-        'enum', 'trait', 'impl', 'record', 'namespace']);
+        'enum', 'trait', 'impl', 'record', 'namespace', 'table', 'view']);
     const defIsTypeDef = TYPE_DEF_KINDS.has(def.type);
 
     // BUG-H3 + tiered contract: impact always analyzes every callable site —
@@ -1323,7 +1324,7 @@ function about(index, name, options = {}) {
     // CALLERS section, hiding the actual constructor sites.
     const CALLABLE_TYPES = new Set(['function', 'method', 'static', 'constructor',
         'public', 'abstract', 'classmethod', 'class', 'struct', 'interface',
-        'type', 'enum', 'trait', 'impl', 'record', 'namespace']);
+        'type', 'enum', 'trait', 'impl', 'record', 'namespace', 'table', 'view']);
     if (CALLABLE_TYPES.has(primary.type) || primary.params !== undefined) {
         // Use maxResults to limit file iteration (with buffer for exclude filtering)
         // Reduce buffer for highly ambiguous names (many definitions = more noise, less value per caller)
@@ -1472,7 +1473,7 @@ function about(index, name, options = {}) {
         // for type definitions — callers (constructor/instantiation sites)
         // are the useful signal here.
         const TYPE_DEF_KINDS = new Set(['class', 'struct', 'interface', 'type',
-            'enum', 'trait', 'impl', 'record', 'namespace']);
+            'enum', 'trait', 'impl', 'record', 'namespace', 'table', 'view']);
         if (TYPE_DEF_KINDS.has(primary.type)) {
             allCallees = [];
         } else {
@@ -2272,7 +2273,7 @@ function parseDiff(diffText, root) {
 // Languages for which audit-async runs (those with async/await keyword we
 // track). Go/Java/Rust have async machinery but audit-async is scoped to
 // JS/TS/Python per spec.
-const _AUDIT_ASYNC_LANGS = new Set(['javascript', 'typescript', 'tsx', 'python', 'html']);
+const _AUDIT_ASYNC_LANGS = new Set(['javascript', 'typescript', 'tsx', 'python', 'html', 'vue', 'svelte']);
 
 // Built-in/standard-library callees that return promises and are commonly
 // missing-awaited. Conservative starter set (rule #9 — generic, not
@@ -2474,6 +2475,11 @@ function auditAsync(index, options = {}) {
                     if (blocks.length === 0) return;
                     const virtualJS = htmlModule.buildVirtualJSContent(content, blocks);
                     tree = safeParse(jsParser, virtualJS);
+                } else if (language === 'vue' || language === 'svelte') {
+                    content = index._readFile(filePath);
+                    const script = getLanguageModule(language).extractScript(content, getParser(language));
+                    if (!script) return;
+                    tree = safeParse(script.jsParser, script.virtualJS);
                 } else {
                     parser = getParser(language);
                     if (!parser) return;
@@ -2494,6 +2500,8 @@ function auditAsync(index, options = {}) {
                 typescript: new Set(['function_declaration', 'function_expression', 'arrow_function', 'method_definition', 'generator_function', 'generator_function_declaration', 'function_signature']),
                 tsx:        new Set(['function_declaration', 'function_expression', 'arrow_function', 'method_definition', 'generator_function', 'generator_function_declaration', 'function_signature']),
                 html:       new Set(['function_declaration', 'function_expression', 'arrow_function', 'method_definition', 'generator_function', 'generator_function_declaration']),
+                vue:        new Set(['function_declaration', 'function_expression', 'arrow_function', 'method_definition', 'generator_function', 'generator_function_declaration', 'function_signature']),
+                svelte:     new Set(['function_declaration', 'function_expression', 'arrow_function', 'method_definition', 'generator_function', 'generator_function_declaration', 'function_signature']),
                 python:     new Set(['function_definition', 'async_function_definition', 'lambda']),
             }[language] || new Set();
 
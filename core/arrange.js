@@ -0,0 +1,122 @@
+/**
+ * core/arrange.js — Finding order and grouping (--sort, --group-by)
+ *
+ * --sort orders a finding command's findings by file, symbol, size (lines
+ * the finding spans), rule or loc-saved (lines deleting it would remove).
+ * --group-by clusters them by package (directory), rule or owner (the
+ * CODEOWNERS entry of the file), ahead of the sort. The arranged result
+ * keeps its shape, so every output format shows the new order; grouped
+ * text and JSON output also get one section per group.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { findingsOf, withoutFindings, orderFindings, deadLines } = require('./output/sarif');
+const { isPathPattern, compilePathPattern } = require('./discovery');
+const { codeUnitCompare } = require('./shared');
+
+const SORT_KEYS = ['file', 'symbol', 'size', 'rule', 'loc-saved'];
+const GROUP_KEYS = ['package', 'rule', 'owner'];
+
+const UNOWNED = '(unowned)';
+
+const byPlace = (a, b) => codeUnitCompare(a.at.file, b.at.file) || a.at.startLine - b.at.startLine;
+const span = f => (f.at.endLine || f.at.startLine) - f.at.startLine + 1;
+
+// Comparators; ties fall back to file and line
+const COMPARE = {
+    'file': byPlace,
+    'symbol': (a, b) => codeUnitCompare(a.at.name || '', b.at.name || '') || byPlace(a, b),
+    'size': (a, b) => span(b) - span(a) || byPlace(a, b),
+    'rule': (a, b) => codeUnitCompare(a.rule, b.rule) || byPlace(a, b),
+    'loc-saved': (a, b) => deadLines(b) - deadLines(a) || byPlace(a, b),
+};
+
+/**
+ * The CODEOWNERS rules of a project: CODEOWNERS, .github/CODEOWNERS or
+ * docs/CODEOWNERS, the first one found, as GitHub and GitLab read it.
+ * @param {string} root - Project root
+ * @returns {Array<{test: Function, owners: string[]}>} In file order; the last match wins
+ */
+function readCodeowners(root) {
+    for (const rel of ['CODEOWNERS', '.github/CODEOWNERS', 'docs/CODEOWNERS']) {
+        let text;
+        try {
+            text = fs.readFileSync(path.join(root, rel), 'utf-8');
+        } catch {
+            continue;
+        }
+        const rules = [];
+        for (const raw of text.split('\n')) {
+            const line = raw.replace(/(^|\s)#.*$/, '').trim();
+            // GitLab [Section] headers
+            if (!line || line.startsWith('[') || line.startsWith('^[')) continue;
+            const [pattern, ...owners] = line.split(/\s+/);
+            // A bare name matches at any depth, a path with a / from the root
+            const glob = isPathPattern(pattern) ? pattern : pattern.includes('/') ? `/${pattern}` : `**/${pattern}`;
+            const test = compilePathPattern(glob);
+            if (test) rules.push({ test, owners });
+        }
+        return rules;
+    }
+    return [];
+}
+
+/**
+ * A finding's group key.
+ * @param {object} f - Finding, as findingsOf yields it
+ * @param {string} by - package, rule or owner
+ * @param {Array} owners - readCodeowners() rules, for owner
+ */
+function groupOf(f, by, owners) {
+    if (by === 'rule') return f.rule;
+    if (by === 'package') return path.posix.dirname(f.at.file.replace(/\\/g, '/'));
+    let match = null;
+    for (const rule of owners) if (rule.test(f.at.file)) match = rule;
+    return match?.owners.length ? match.owners.join(' ') : UNOWNED;
+}
+
+/**
+ * A finding command's result in --sort order, clustered by --group-by.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} options - { sort, groupBy, root (for CODEOWNERS) }
+ * @returns {{result: *, groups: Array<{key: string, count: number, result: *}>|null}}
+ *   groups, when grouping, hold each group's own result
+ */
+function arrangeFindings(command, result, options = {}) {
+    const { sort, groupBy } = options;
+    const owners = groupBy === 'owner' ? readCodeowners(options.root || '.') : [];
+    const findings = [...findingsOf(command, result)];
+    const key = new Map(findings.map(f => [f, groupBy ? groupOf(f, groupBy, owners) : '']));
+    const compareGroups = (a, b) => {
+        const ka = key.get(a), kb = key.get(b);
+        if (ka === kb) return 0;
+        if (ka === UNOWNED || kb === UNOWNED) return ka === UNOWNED ? 1 : -1;
+        return codeUnitCompare(ka, kb);
+    };
+    // Array sort is stable: without --sort a group keeps the command's order
+    const ordered = findings.slice().sort((a, b) => compareGroups(a, b) || (sort ? COMPARE[sort](a, b) : 0));
+    const arranged = orderFindings(command, result, new Map(ordered.map((f, i) => [f.data, i])));
+    if (!groupBy) return { result: arranged, groups: null };
+
+    const groups = [];
+    for (const f of ordered) {
+        const k = key.get(f);
+        if (groups.length === 0 || groups[groups.length - 1].key !== k) groups.push({ key: k, members: new Set() });
+        groups[groups.length - 1].members.add(f.data);
+    }
+    const all = new Set(findings.map(f => f.data));
+    return {
+        result: arranged,
+        groups: groups.map(g => ({
+            key: g.key,
+            count: g.members.size,
+            result: withoutFindings(command, arranged, new Set([...all].filter(d => !g.members.has(d)))),
+        })),
+    };
+}
+
+module.exports = { arrangeFindings, readCodeowners, groupOf, SORT_KEYS, GROUP_KEYS };
@@ -0,0 +1,117 @@
+/**
+ * core/baseline.js — Baseline files: a snapshot of known findings that
+ * later runs hide (ucn baseline create, --baseline=<file>)
+ *
+ * A baseline lists each finding's command and stable fingerprint (as
+ * findingsOf computes it), with its file, line and message for a reader
+ * reviewing the file. A run with --baseline drops every finding whose
+ * fingerprint the baseline holds, as many times as the baseline holds
+ * it, so a legacy repo adopts ucn and fails CI only on what a change
+ * introduces. Fingerprints leave the line out, so code moving around a
+ * known finding doesn't resurface it.
+ */
+
+'use strict';
+
+const { findingsOf, withoutFindings } = require('./output/sarif');
+
+const BASELINE_VERSION = 2;
+const DEFAULT_BASELINE_FILE = '.ucn-baseline.json';
+
+/**
+ * Baseline entry of one finding.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {object} f - Finding, as findingsOf builds it
+ * @returns {{command, fingerprint, file, line, message}}
+ */
+function baselineEntry(command, f) {
+    return {
+        command,
+        fingerprint: f.fingerprint,
+        file: f.at.file,
+        line: f.at.startLine,
+        message: f.message,
+    };
+}
+
+/** Baseline entries of one command's findings */
+function baselineEntries(command, result) {
+    return [...findingsOf(command, result)].map(f => baselineEntry(command, f));
+}
+
+/**
+ * Baseline document: entries of this command, plus those of other
+ * commands an existing baseline already held (re-creating for deadcode
+ * keeps the clones snapshot).
+ * @param {string} command - Canonical command
+ * @param {*} result - The command's result
+ * @param {object|null} [previous] - Parsed existing baseline
+ * @returns {object} { version, tool, findings }
+ */
+function createBaseline(command, result, previous) {
+    const kept = (previous?.findings || []).filter(e => e.command !== command);
+    return {
+        version: BASELINE_VERSION,
+        tool: 'ucn',
+        findings: [...kept, ...baselineEntries(command, result)],
+    };
+}
+
+/**
+ * Parse a baseline file's text.
+ * @param {string} text - File contents
+ * @returns {{baseline: object|null, error: string|null}}
+ */
+function parseBaseline(text) {
+    let doc;
+    try {
+        doc = JSON.parse(text);
+    } catch (e) {
+        return { baseline: null, error: `not JSON (${e.message})` };
+    }
+    if (!doc || !Array.isArray(doc.findings)) return { baseline: null, error: 'no "findings" array' };
+    if (doc.version > BASELINE_VERSION) {
+        return { baseline: null, error: `version ${doc.version} is newer than this ucn reads (${BASELINE_VERSION}); upgrade ucn` };
+    }
+    if (doc.version !== BASELINE_VERSION) {
+        return { baseline: null, error: `version ${doc.version} has no hashed fingerprints; re-create it with ucn baseline create` };
+    }
+    return { baseline: doc, error: null };
+}
+
+/**
+ * The findings no entry covers: an entry covers one finding of its
+ * fingerprint, so a fingerprint held twice covers two.
+ * @param {object[]} findings - Anything with a fingerprint
+ * @param {object[]} entries - Baseline entries (or findings) to match against
+ * @returns {object[]} The uncovered findings, in order
+ */
+function uncoveredFindings(findings, entries) {
+    const budget = new Map();
+    for (const e of entries) budget.set(e.fingerprint, (budget.get(e.fingerprint) || 0) + 1);
+    return findings.filter(f => {
+        const left = budget.get(f.fingerprint) || 0;
+        if (left > 0) budget.set(f.fingerprint, left - 1);
+        return left === 0;
+    });
+}
+
+/**
+ * The command's result without the findings a baseline already holds.
+ * The result keeps its shape (and its other fields), so every output
+ * format, text and JSON included, shows only the new findings.
+ * @param {string} command - Canonical command
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} baseline - Parsed baseline
+ * @returns {{result: *, suppressed: number, unmatched: object[]}} unmatched
+ *   holds the findings kept, as findingsOf builds them
+ */
+function applyBaseline(command, result, baseline) {
+    const findings = [...findingsOf(command, result)];
+    const unmatched = uncoveredFindings(findings, baseline.findings.filter(e => e.command === command));
+    const fresh = new Set(unmatched);
+    const known = new Set(findings.filter(f => !fresh.has(f)).map(f => f.data));
+    return { result: withoutFindings(command, result, known), suppressed: known.size, unmatched };
+}
+
+module.exports = { createBaseline, parseBaseline, applyBaseline, uncoveredFindings, baselineEntry, DEFAULT_BASELINE_FILE };
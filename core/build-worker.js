@@ -3,21 +3,48 @@
 /**
  * core/build-worker.js - Worker thread for parallel index building
  *
- * Receives a chunk of file paths, parses each file, extracts symbols and calls,
- * then sends results back to the main thread via MessagePort.
- * Mirrors the indexFile() logic in project.js.
+ * Claims files off the pool's shared queue one at a time, parses each,
+ * extracts symbols and calls, then sends results back to the main thread
+ * via MessagePort. Mirrors the indexFile() logic in project.js.
  */
 
 const { workerData } = require('worker_threads');
 const fs = require('fs');
 const path = require('path');
 const crypto = require('crypto');
-const { detectLanguage, getParser, getLanguageModule, langTraits } = require('../languages');
+const { detectLanguage, getParser, getLanguageModule, langTraits, registerGrammars } = require('../languages');
 const { parse } = require('./parser');
 const { extractImports, extractExports } = require('./imports');
+const { hasGeneratedHeader } = require('./generated');
 
-const { files, rootDir, existingHashes, signal, workerIndex, port } = workerData;
+const { files, rootDir, grammars, stats, digests, signal, workerIndex, port } = workerData;
+
+// Workers have their own language registry: user grammars register here too
+registerGrammars(grammars, rootDir);
 const signalArray = new Int32Array(signal);
+// Queue slots and per-file hash records, as laid out in parallel-build.js
+const NEXT = 0;
+const FINISHED = 1;
+const DONE = 2;
+const STAT_SLOTS = 3;
+const HASH_BYTES = 16;
+const statArray = new Float64Array(stats);
+const digestArray = new Uint8Array(digests);
+
+/**
+ * The index's existing entry for the file in slot i of the list, or null
+ * for a new file. hash is null when the entry had none.
+ */
+function existingEntry(i) {
+    const at = STAT_SLOTS * i;
+    if (statArray[at] !== 1) return null;
+    const digest = Buffer.from(digestArray.subarray(HASH_BYTES * i, HASH_BYTES * (i + 1)));
+    return {
+        mtime: statArray[at + 1],
+        size: statArray[at + 2],
+        hash: digest.some(b => b !== 0) ? digest.toString('hex') : null,
+    };
+}
 
 function addSymbol(fileEntry, item, type) {
     const symbol = {
@@ -55,6 +82,8 @@ function addSymbol(fileEntry, item, type) {
     if (item.className) symbol.className = item.className;
     if (item.memberType) symbol.memberType = item.memberType;
     if (item.fieldType) symbol.fieldType = item.fieldType;
+    if (item.fieldTag) symbol.fieldTag = item.fieldTag;
+    if (item.embedded) symbol.embedded = true;
     if (item.aliasOf) symbol.aliasOf = item.aliasOf;
     if (item.derefTarget) symbol.derefTarget = item.derefTarget;
     if (item.decorators && item.decorators.length > 0) symbol.decorators = item.decorators;
@@ -69,6 +98,9 @@ function addSymbol(fileEntry, item, type) {
     if (item.bodyScopedName) symbol.bodyScopedName = true;
     if (item.registryMember) symbol.registryMember = true;
     if (item.registryContainer) symbol.registryContainer = item.registryContainer;
+    if (item.isConst) symbol.isConst = true;
+    if (item.constBlock) symbol.constBlock = item.constBlock;
+    if (item.iota) symbol.iota = true;
 
     fileEntry.symbols.push(symbol);
     // Property-assignment defs declare no lexical name (fix #269) — kept in
@@ -84,9 +116,8 @@ function addSymbol(fileEntry, item, type) {
     }
 }
 
-function processFile(filePath) {
+function processFile(filePath, existing) {
     const stat = fs.statSync(filePath);
-    const existing = existingHashes[filePath];
 
     // Fast path: skip when mtime+size both match
     if (existing && existing.mtime === stat.mtimeMs && existing.size === stat.size) {
@@ -144,9 +175,7 @@ function processFile(filePath) {
         (lineCount > 0 && longLineCount > 0 && longLineCount / lineCount > 0.3)
     );
 
-    const isGenerated = /^\/\/\s*Code generated\b|^\/\/\s*DO NOT EDIT|^\/\/ @generated|^# Generated by/m.test(
-        content.slice(0, 500)
-    );
+    const isGenerated = hasGeneratedHeader(content);
 
     const relativePath = path.relative(rootDir, filePath);
 
@@ -185,6 +214,10 @@ function processFile(filePath) {
     if (parsed.parseRecovery) fileEntry.parseRecovery = true;
     if (importAliases) fileEntry.importAliases = importAliases;
     if (parsed.moduleAssignedNames) fileEntry.moduleAssignedNames = parsed.moduleAssignedNames;
+    if (parsed.partFiles) fileEntry.partFiles = parsed.partFiles;
+    if (imports.some(i => i.lazy)) {
+        fileEntry.lazyImports = imports.filter(i => i.lazy).map(i => ({ module: i.module, kind: i.lazy, line: i.line }));
+    }
     if (isBundled) fileEntry.isBundled = true;
     if (isGenerated) fileEntry.isGenerated = true;
 
@@ -219,24 +252,27 @@ function processFile(filePath) {
     };
 }
 
-// Process all files
+// Process files until the queue is empty
 try {
     const results = [];
-    for (const filePath of files) {
+    let i;
+    while ((i = Atomics.add(signalArray, NEXT, 1)) < files.length) {
+        const filePath = files[i];
         try {
-            results.push(processFile(filePath));
+            results.push(processFile(filePath, existingEntry(i)));
         } catch (e) {
             results.push({ filePath, error: e.message });
         }
+        Atomics.add(signalArray, FINISHED, 1);
     }
 
     port.postMessage(results);
     port.close();
-    Atomics.store(signalArray, workerIndex, 1);
-    Atomics.notify(signalArray, workerIndex);
+    Atomics.store(signalArray, DONE + workerIndex, 1);
+    Atomics.notify(signalArray, DONE + workerIndex);
 } catch (e) {
     // Worker-level error — signal completion with empty results
     try { port.postMessage([]); port.close(); } catch (_) { /* ignore */ }
-    Atomics.store(signalArray, workerIndex, 1);
-    Atomics.notify(signalArray, workerIndex);
+    Atomics.store(signalArray, DONE + workerIndex, 1);
+    Atomics.notify(signalArray, DONE + workerIndex);
 }
@@ -184,15 +184,32 @@ const UCN_VERSION = require('../package.json').version;
 // ownership across same-named top-level and inner classes, and cast receivers
 // retain their compiler-declared type. Rust tuple fields are indexed by numeric
 // position so `self.0.method()` participates in declared-field resolution.
-const CACHE_FORMAT_VERSION = 73;
+// v74: state symbols keep the parser's isConst flag (Go iota/exported
+// consts, Zig comptime consts) — addSymbol silently dropped it.
+// v75: fileEntry.partFiles (Dart `part` directives); persisted importGraph/
+// exportGraph gain library-part stitching edges.
+// v76: isGenerated recognizes protoc's Java and grpc-tools JS headers.
+// v77: JS/TS module-scope svelte stores are state symbols (modifiers 'store').
+// v78: fileEntry.lazyImports (React.lazy / route `lazy:` dynamic imports) and
+// JS/TS route-object component references.
+// v79: Go struct fields keep their tag (fieldTag) and one symbol per name in
+// `X, Y int`; embedded fields carry the embedded flag.
+// v80: Go const state symbols carry their const block (constBlock) and
+// iota flag.
+// v81: Go types declared in a `type ( ... )` group carry nameLine.
+// v82: Go call records carry discardedResults.
+// v83: Go functions, methods and types carry their `Deprecated:` notice.
+const CACHE_FORMAT_VERSION = 83;
 
 /**
  * Save index to cache file
  * @param {object} index - ProjectIndex instance
  * @param {string} [cachePath] - Optional custom cache path
- * @returns {string} - Path to cache file
+ * @returns {string|null} - Path to cache file (null when unsaved buffers are overlaid)
  */
 function saveCache(index, cachePath) {
+    // An overlaid buffer is not the file on disk; caching it would poison the next run
+    if (index.hasOverlays?.()) return null;
     const cacheDir = cachePath
         ? path.dirname(cachePath)
         : path.join(index.root, '.ucn-cache');
@@ -620,7 +637,7 @@ function isCacheStale(index) {
     if (gitignorePatterns.length > 0 || configExclude.length > 0) {
         globOpts.ignores = [...DEFAULT_IGNORES, ...gitignorePatterns, ...configExclude];
     }
-    const currentFiles = expandGlob(pattern, globOpts);
+    const currentFiles = expandGlob(pattern, globOpts).filter(f => !index.excludedByConfig(f));
     const cachedPaths = new Set(index.files.keys());
 
     for (const file of currentFiles) {
@@ -784,7 +801,10 @@ function _computeReachabilityFingerprint(index) {
             if (++count >= 8) break;
         }
     }
-    return `${fileCount}:${symbolCount}:${sample}`;
+    // .ucn.json "entryPoints" and "builtinEntryPoints" decide the seeds too
+    const { entryPoints: configured, builtinEntryPoints } = index.config || {};
+    const entryPoints = configured || builtinEntryPoints === false ? JSON.stringify([configured, builtinEntryPoints]) : '';
+    return `${fileCount}:${symbolCount}:${sample}${entryPoints}`;
 }
 
 module.exports = {
@@ -57,7 +57,7 @@ function getCachedCalls(index, filePath, options = {}) {
         const cached = index.callsCache.get(filePath);
 
         // Fast path: check mtime first (stat is much faster than read+hash)
-        const stat = fs.statSync(filePath);
+        const stat = index._fileStat ? index._fileStat(filePath) : fs.statSync(filePath);
         const mtime = stat.mtimeMs;
 
         if (cached && cached.mtime === mtime) {
@@ -3022,7 +3022,7 @@ function findCallers(index, name, options = {}) {
                         // attachment is a real pattern — #222(4) name-knowledge
                         // rule): visible possible-dispatch, never excluded.
                         if (!typeQualifiedReceiver && call.receiver && !call.receiverType &&
-                            ['javascript', 'typescript', 'tsx', 'html'].includes(fileEntry.language) &&
+                            ['javascript', 'typescript', 'tsx', 'html', 'vue', 'svelte'].includes(fileEntry.language) &&
                             JS_GLOBAL_RECEIVERS.has(call.receiver) &&
                             (index.symbols.get(call.receiver) || []).length === 0 &&
                             !fileEntry.bindings?.some(b => b.name === call.receiver)) {
@@ -3311,6 +3311,7 @@ function findCallers(index, name, options = {}) {
                 ...(receiver !== undefined && { receiver }),
                 ...(receiverType && { receiverType }),
                 ...(edgeCalledAs && { calledAs: edgeCalledAs }),
+                ...(call.discardedResults && { discardedResults: call.discardedResults }),
                 confidence: scored.confidence,
                 evidenceScore: scored.evidenceScore,
                 scoreKind: scored.scoreKind,
@@ -6016,7 +6017,7 @@ const _UNIVERSAL_METHOD_NAMES_JAVA = new Set([
 function _universalMethodName(language, name) {
     if (language === 'python') return /^__[A-Za-z0-9_]+__$/.test(name);
     if (language === 'java') return _UNIVERSAL_METHOD_NAMES_JAVA.has(name);
-    if (['javascript', 'typescript', 'tsx', 'html'].includes(language)) {
+    if (['javascript', 'typescript', 'tsx', 'html', 'vue', 'svelte'].includes(language)) {
         return _UNIVERSAL_METHOD_NAMES_JS.has(name);
     }
     return false;
@@ -7369,7 +7370,7 @@ function _calleeTypeQualifiedReceiver(index, def, fileEntry, call, language) {
 // a symbol defined in any of these; every other language only binds its own
 // (fix #257: java/python/rust fixture defs of CacheService.set counted as ONE
 // owner for a JavaScript call — cross-language edges are never callable).
-const _JS_CALLABLE_FAMILY = new Set(['javascript', 'typescript', 'tsx', 'html']);
+const _JS_CALLABLE_FAMILY = new Set(['javascript', 'typescript', 'tsx', 'html', 'vue', 'svelte']);
 
 function _calleeLanguageCompatible(index, def, callerLanguage) {
     if (!callerLanguage) return true;
@@ -8959,4 +8960,4 @@ function findCallbackUsages(index, name) {
     return usages;
 }
 
-module.exports = { getCachedCalls, findCallers, findCallees, getInstanceAttributeTypes, findCallbackUsages, _nameBindingReaches, _declaredFieldType, _projectTopLevelNames };
+module.exports = { getCachedCalls, findCallers, findCallees, getInstanceAttributeTypes, findCallbackUsages, _nameBindingReaches, _importReaches, _declaredFieldType, _projectTopLevelNames };
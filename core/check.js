@@ -7,11 +7,22 @@
  *   - which call sites might break (signature drift)
  *   - which tests are likely affected
  *   - which new functions look orphaned
+ *
+ * `check --stdin` instead analyzes an editor's unsaved buffer: the text
+ * stands in for its file in the project index (ProjectIndex.overlayFile),
+ * so the rest of the project resolves against it, and the findings of
+ * deadcode, audit-async and deprecated in that one file are returned.
  */
 
 'use strict';
 
+const path = require('path');
+const fs = require('fs');
 const { diffImpact, composeAccount, callNotResolvedEntries } = require('./analysis');
+const { findingsOf, withoutFindings } = require('./output/sarif');
+
+// Finding commands run on a buffer
+const BUFFER_COMMANDS = ['deadcode', 'auditAsync', 'deprecated'];
 
 function summarizeAccount(account) {
     if (!account) {
@@ -296,4 +307,52 @@ function check(index, options = {}) {
     };
 }
 
-module.exports = { check };
+/**
+ * Findings in an unsaved buffer, with the project index for the rest.
+ * The index keeps the buffer overlaid afterwards, so it must not be saved.
+ *
+ * @param {object} index - Built ProjectIndex
+ * @param {object} options - { content, file (the path the buffer is saved
+ *   as, relative to the root), lang (a language or its extension, for a
+ *   buffer without a path), and the finding commands' params }
+ * @returns {{file, language, onDisk, parts: Array<{command, result}>}}
+ */
+function checkBuffer(index, options) {
+    const { detectLanguage, LANGUAGES } = require('../languages');
+    const { content, file: bufferFile, lang, ...params } = options;
+    const wanted = lang ? (LANGUAGES[lang] ? lang : detectLanguage(`buffer.${String(lang).replace(/^\./, '')}`)) : null;
+    if (lang && !wanted) {
+        throw new Error(`Unknown language "${lang}": --lang takes a language (go, typescript) or its file extension (py, ts).`);
+    }
+    let filePath;
+    if (bufferFile) {
+        filePath = path.resolve(index.root, bufferFile);
+        if (path.relative(index.root, filePath).startsWith('..')) {
+            throw new Error(`${bufferFile} is outside the project (${index.root}).`);
+        }
+        const detected = detectLanguage(filePath);
+        if (!detected) throw new Error(`${bufferFile} is not in a language ucn analyzes.`);
+        if (wanted && detected !== wanted) throw new Error(`--lang ${lang} does not match ${bufferFile} (${detected}).`);
+    } else {
+        if (!wanted) throw new Error('--stdin needs --file=<path the buffer is saved as> or --lang=<language>.');
+        // A buffer never saved: a file of its own at the root
+        filePath = path.join(index.root, `ucn-stdin${LANGUAGES[wanted].extensions[0]}`);
+    }
+    index.overlayFile(filePath, content);
+
+    const { execute } = require('./execute');
+    const file = path.relative(index.root, filePath).replace(/\\/g, '/');
+    const parts = [];
+    for (const command of BUFFER_COMMANDS) {
+        // A test file's own findings are what its buffer asks for
+        const { ok, result } = execute(index, command, { ...params, file, includeTests: true });
+        if (!ok) continue;
+        // --file matches as a substring; keep this file's findings alone
+        const elsewhere = new Set([...findingsOf(command, result)]
+            .filter(f => f.at.file.replace(/\\/g, '/') !== file).map(f => f.data));
+        parts.push({ command, result: withoutFindings(command, result, elsewhere) });
+    }
+    return { file, language: detectLanguage(filePath), onDisk: fs.existsSync(filePath), parts };
+}
+
+module.exports = { check, checkBuffer, BUFFER_COMMANDS };
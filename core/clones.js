@@ -0,0 +1,383 @@
+/**
+ * core/clones.js — Near-duplicate function detection (clones command)
+ *
+ * Every function body is reduced to its syntax-tree leaf tokens with
+ * identifiers, numbers and strings normalized (`ID`, `NUM`, `STR`), so a
+ * copy that renames variables or changes literals tokenizes the same as
+ * the original. Two functions of one language are clones when the Dice
+ * coefficient of their token 5-gram multisets reaches the similarity
+ * threshold; clone pairs are grouped transitively.
+ *
+ * Thresholds come from options, then .ucn.json "clones"
+ * (`{ "minLines": 6, "minTokens": 50, "similarity": 0.9 }`), then the
+ * defaults below. Functions shorter than either minimum are not compared.
+ *
+ * `clones --literals` looks at copy-pasted values instead: string and
+ * number literals repeated verbatim in at least minFiles files (default
+ * 3), which likely want one shared constant. Short strings, small
+ * numbers, import paths, struct tags and docstrings are left out.
+ */
+
+'use strict';
+
+const { dirname } = require('path');
+const { getParser, safeParse } = require('../languages');
+const { isTestFile } = require('./discovery');
+const { codeUnitCompare, CALLABLE_SYMBOL_KINDS } = require('./shared');
+
+const DEFAULT_MIN_LINES = 6;
+const DEFAULT_MIN_TOKENS = 50;
+const DEFAULT_SIMILARITY = 0.9;
+const DEFAULT_MIN_FILES = 3;
+const MIN_STRING_LENGTH = 4;
+// `const MAX_RETRIES = 5`, `static final String JSON = "..."`, `TIMEOUT: int = 30`
+const CONSTANT_LINE = /^\s*(?:export\s+)?(?:(?:public|private|protected|internal|static|final|const|readonly|let|var|val)\s+)*(?:[\w.<>[\]]+\s+)?([A-Z][A-Z0-9_]*[A-Z0-9])\s*(?::\s*[\w.]+\s*)?:?=/;
+const SHINGLE = 5;
+// Shingles shared by more functions than this are boilerplate (`) { return
+// ID ;`); they still count toward similarity but do not propose pairs.
+const MAX_POSTING = 200;
+
+const STRING_NODE = /string|char_literal|rune_literal|character_literal|text_block|heredoc/;
+const NUMBER_NODE = /number|integer|float|decimal|hex|octal|binary|imaginary/;
+const NAME_NODE = /identifier|^name$|^word$|^symbol$|^constant$/;
+
+/**
+ * Normalized leaf tokens of a file, in source order.
+ * @returns {{rows: number[], tokens: string[]}} token i starts on row rows[i]
+ */
+function fileTokens(tree) {
+    const rows = [];
+    const tokens = [];
+    const visit = (node) => {
+        if (/comment/.test(node.type)) return;
+        let token = null;
+        if (node.isNamed && STRING_NODE.test(node.type)) token = 'STR';
+        else if (node.childCount === 0) {
+            if (!node.isNamed) token = node.type;
+            else if (NUMBER_NODE.test(node.type)) token = 'NUM';
+            else if (NAME_NODE.test(node.type)) token = 'ID';
+            else token = node.type;
+        }
+        if (token !== null) {
+            if (token) {
+                rows.push(node.startPosition.row);
+                tokens.push(token);
+            }
+            return;
+        }
+        for (let i = 0; i < node.childCount; i++) visit(node.child(i));
+    };
+    visit(tree.rootNode);
+    return { rows, tokens };
+}
+
+/** First index in sorted `rows` whose row is >= `row` */
+function lowerBound(rows, row) {
+    let lo = 0;
+    let hi = rows.length;
+    while (lo < hi) {
+        const mid = (lo + hi) >> 1;
+        if (rows[mid] < row) lo = mid + 1;
+        else hi = mid;
+    }
+    return lo;
+}
+
+/** Multiset of token 5-grams: Map<shingle, count> */
+function shingles(tokens) {
+    const counts = new Map();
+    for (let i = 0; i + SHINGLE <= tokens.length; i++) {
+        const key = tokens.slice(i, i + SHINGLE).join(' ');
+        counts.set(key, (counts.get(key) || 0) + 1);
+    }
+    return counts;
+}
+
+/** Dice coefficient of two shingle multisets */
+function similarityOf(a, b) {
+    let shared = 0;
+    const [small, large] = a.shingles.size <= b.shingles.size ? [a, b] : [b, a];
+    for (const [key, n] of small.shingles) {
+        const m = large.shingles.get(key);
+        if (m) shared += Math.min(n, m);
+    }
+    return (2 * shared) / (a.shingleCount + b.shingleCount);
+}
+
+/** Resolve a threshold: options, then .ucn.json "clones", then the default */
+function setting(index, options, key, fallback) {
+    if (options[key] != null) return options[key];
+    const configured = index.config?.clones?.[key];
+    return typeof configured === 'number' ? configured : fallback;
+}
+
+/**
+ * Find groups of near-duplicate functions.
+ * @param {object} index - ProjectIndex
+ * @param {object} options - { minLines, minTokens, similarity, file, exclude, in, includeTests }
+ *   `file` keeps groups with a member in a matching file; the other filters
+ *   narrow which functions are compared.
+ * @returns {{groups: Array, functions: number, minLines: number, minTokens: number, similarity: number}}
+ */
+function findClones(index, options = {}) {
+    const minLines = setting(index, options, 'minLines', DEFAULT_MIN_LINES);
+    const minTokens = setting(index, options, 'minTokens', DEFAULT_MIN_TOKENS);
+    const threshold = setting(index, options, 'similarity', DEFAULT_SIMILARITY);
+
+    index._beginOp();
+    try {
+        const fns = [];
+        for (const [filePath, fileEntry] of index.files) {
+            const lang = fileEntry.language;
+            if (!options.includeTests && isTestFile(fileEntry.relativePath, lang)) continue;
+            if (((options.exclude && options.exclude.length > 0) || options.in) &&
+                !index.matchesFilters(fileEntry.relativePath, { exclude: options.exclude, in: options.in })) continue;
+            const candidates = (fileEntry.symbols || []).filter(s =>
+                CALLABLE_SYMBOL_KINDS.has(s.type) && s.endLine - s.startLine + 1 >= minLines);
+            if (candidates.length === 0) continue;
+
+            let content;
+            try { content = index._readFile(filePath); } catch { continue; }
+            let tree = index._getParsedTree(filePath, content, lang);
+            if (!tree) {
+                try { tree = safeParse(getParser(lang), content); } catch { tree = null; }
+            }
+            if (!tree) continue;
+            const { rows, tokens } = fileTokens(tree);
+
+            for (const fn of candidates) {
+                const body = tokens.slice(lowerBound(rows, fn.startLine - 1), lowerBound(rows, fn.endLine));
+                if (body.length < minTokens) continue;
+                const counts = shingles(body);
+                fns.push({
+                    fn,
+                    lang,
+                    file: fileEntry.relativePath,
+                    tokens: body.length,
+                    text: body.join(' '),
+                    shingles: counts,
+                    shingleCount: body.length - SHINGLE + 1,
+                });
+            }
+        }
+
+        // Candidate pairs share a shingle that is not boilerplate
+        const postings = new Map();
+        fns.forEach((f, i) => {
+            for (const key of f.shingles.keys()) {
+                const k = `${f.lang}\0${key}`;
+                if (!postings.has(k)) postings.set(k, []);
+                postings.get(k).push(i);
+            }
+        });
+        const parent = fns.map((_, i) => i);
+        const find = (i) => (parent[i] === i ? i : (parent[i] = find(parent[i])));
+        const edges = [];
+        const tried = new Set();
+        for (const list of postings.values()) {
+            if (list.length < 2 || list.length > MAX_POSTING) continue;
+            for (let x = 0; x < list.length; x++) {
+                for (let y = x + 1; y < list.length; y++) {
+                    const i = list[x];
+                    const j = list[y];
+                    const pair = i * fns.length + j;
+                    if (tried.has(pair)) continue;
+                    tried.add(pair);
+                    const a = fns[i];
+                    const b = fns[j];
+                    // Dice can never exceed the size ratio
+                    const ratio = Math.min(a.shingleCount, b.shingleCount) / Math.max(a.shingleCount, b.shingleCount);
+                    if (ratio < threshold) continue;
+                    if (nested(a, b)) continue;
+                    const sim = a.text === b.text ? 1 : similarityOf(a, b);
+                    if (sim < threshold) continue;
+                    edges.push({ i, j, sim });
+                    parent[find(i)] = find(j);
+                }
+            }
+        }
+
+        const byRoot = new Map();
+        for (const { i, j, sim } of edges) {
+            const root = find(i);
+            if (!byRoot.has(root)) byRoot.set(root, { members: new Set(), similarity: 1 });
+            const g = byRoot.get(root);
+            g.members.add(i);
+            g.members.add(j);
+            g.similarity = Math.min(g.similarity, sim);
+        }
+
+        let groups = [...byRoot.values()].map(g => {
+            const members = [...g.members].map(i => fns[i]).sort((a, b) =>
+                codeUnitCompare(a.file, b.file) || a.fn.startLine - b.fn.startLine);
+            return {
+                similarity: Math.round(g.similarity * 100) / 100,
+                exact: members.every(m => m.text === members[0].text),
+                language: members[0].lang,
+                files: new Set(members.map(m => m.file)).size,
+                packages: new Set(members.map(m => dirname(m.file))).size,
+                members: members.map(m => ({
+                    name: m.fn.name,
+                    ...(m.fn.className && { className: m.fn.className }),
+                    file: m.file,
+                    startLine: m.fn.startLine,
+                    endLine: m.fn.endLine,
+                    lines: m.fn.endLine - m.fn.startLine + 1,
+                    tokens: m.tokens,
+                })),
+                _fns: members,
+            };
+        });
+
+        // A group whose every member sits inside a member of another group
+        // is the inner half of a larger clone
+        const grouped = groups.flatMap(g => g._fns.map(f => ({ f, g })));
+        groups = groups.filter(g => !g._fns.every(f =>
+            grouped.some(o => o.g !== g && nested(o.f, f) && o.f.tokens > f.tokens)));
+
+        if (options.file) {
+            groups = groups.filter(g => g.members.some(m => m.file.includes(options.file)));
+        }
+        for (const g of groups) delete g._fns;
+
+        // Most duplicated code first
+        const duplicated = g => g.members.reduce((n, m) => n + m.lines, 0) - Math.max(...g.members.map(m => m.lines));
+        groups.sort((a, b) => duplicated(b) - duplicated(a) ||
+            codeUnitCompare(a.members[0].file, b.members[0].file) ||
+            a.members[0].startLine - b.members[0].startLine);
+
+        return { groups, functions: fns.length, minLines, minTokens, similarity: threshold };
+    } finally {
+        index._endOp();
+    }
+}
+
+/** Value of a string literal node, or null when it is not a plain literal */
+function stringValue(node) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        if (/substitution|interpolation/.test(node.namedChild(i).type)) return null;
+    }
+    const text = node.text;
+    const quote = text.match(/^[A-Za-z]*("""|'''|["'`])/);
+    if (!quote) return text;
+    const open = quote[0].length;
+    return text.endsWith(quote[1]) ? text.slice(open, text.length - quote[1].length) : text.slice(open);
+}
+
+/** Whether a literal is too trivial, or too tied to its spot, to share */
+function skipLiteral(node, kind, value) {
+    if (kind === 'number') {
+        const n = Number(value.replace(/_/g, ''));
+        return Number.isNaN(n) || Math.abs(n) <= 10 || /^-?10+$/.test(String(n));
+    }
+    if (value.length < MIN_STRING_LENGTH || !/\w/.test(value)) return true;
+    const parent = node.parent;
+    if (!parent) return false;
+    // Import paths, Go struct tags, and docstrings
+    return /import|require|include|use_declaration|package_clause/.test(parent.type) ||
+        /^(require|import)\s*\(/.test(parent.parent?.text || '') ||
+        (parent.type === 'field_declaration' && parent.childForFieldName('tag')?.id === node.id) ||
+        (parent.type === 'expression_statement' && parent.namedChildCount === 1);
+}
+
+/**
+ * String and number literals repeated across files (clones --literals).
+ * @param {object} index - ProjectIndex
+ * @param {object} options - { minFiles, file, exclude, in, includeTests }
+ *   `file` keeps literals with an occurrence in a matching file.
+ * @returns {{literals: Array<{value, kind, language, count, files, locations, constant?}>,
+ *   scanned: number, minFiles: number}}
+ */
+function findDuplicateLiterals(index, options = {}) {
+    const minFiles = setting(index, options, 'minFiles', DEFAULT_MIN_FILES);
+
+    index._beginOp();
+    try {
+        const byValue = new Map();
+        let scanned = 0;
+        for (const [filePath, fileEntry] of index.files) {
+            const lang = fileEntry.language;
+            const rel = fileEntry.relativePath;
+            if (!options.includeTests && isTestFile(rel, lang)) continue;
+            if (((options.exclude && options.exclude.length > 0) || options.in) &&
+                !index.matchesFilters(rel, { exclude: options.exclude, in: options.in })) continue;
+            let content;
+            try { content = index._readFile(filePath); } catch { continue; }
+            let tree = index._getParsedTree(filePath, content, lang);
+            if (!tree) {
+                try { tree = safeParse(getParser(lang), content); } catch { tree = null; }
+            }
+            if (!tree) continue;
+            scanned++;
+            const lines = content.split('\n');
+            const constants = new Map((fileEntry.symbols || [])
+                .filter(s => s.type === 'constant' || s.isConst).map(s => [s.startLine, s.name]));
+            const constantAt = (line) => constants.get(line) || lines[line - 1]?.match(CONSTANT_LINE)?.[1];
+
+            const visit = (node) => {
+                if (/comment/.test(node.type)) return;
+                let kind = null;
+                let value = null;
+                if (node.isNamed && STRING_NODE.test(node.type)) {
+                    kind = 'string';
+                    value = stringValue(node);
+                } else if (node.isNamed && node.namedChildCount === 0 && (NUMBER_NODE.test(node.type) || node.type === 'int_literal')) {
+                    kind = 'number';
+                    value = node.text;
+                }
+                if (kind) {
+                    if (value !== null && !skipLiteral(node, kind, value)) {
+                        const key = `${lang}\0${kind}\0${value}`;
+                        if (!byValue.has(key)) byValue.set(key, { value, kind, language: lang, locations: [] });
+                        const line = node.startPosition.row + 1;
+                        byValue.get(key).locations.push({ file: rel, line, constant: constantAt(line) });
+                    }
+                    return;
+                }
+                for (let i = 0; i < node.namedChildCount; i++) visit(node.namedChild(i));
+            };
+            visit(tree.rootNode);
+        }
+
+        let literals = [];
+        for (const entry of byValue.values()) {
+            const files = new Set(entry.locations.map(l => l.file));
+            if (files.size < minFiles) continue;
+            entry.locations.sort((a, b) => codeUnitCompare(a.file, b.file) || a.line - b.line);
+            // A constant already holding the value: the other copies drifted from it
+            const declared = entry.locations.find(l => l.constant);
+            literals.push({
+                value: entry.value,
+                kind: entry.kind,
+                language: entry.language,
+                count: entry.locations.length,
+                files: files.size,
+                locations: entry.locations.map(({ file, line }) => ({ file, line })),
+                ...(declared && { constant: { name: declared.constant, file: declared.file, line: declared.line } }),
+            });
+        }
+        if (options.file) {
+            literals = literals.filter(l => l.locations.some(loc => loc.file.includes(options.file)));
+        }
+        literals.sort((a, b) => b.files - a.files || b.count - a.count || codeUnitCompare(a.value, b.value));
+        return { literals, scanned, minFiles };
+    } finally {
+        index._endOp();
+    }
+}
+
+/** One function's range lies within the other's (same file) */
+function nested(a, b) {
+    if (a.file !== b.file) return false;
+    return (a.fn.startLine <= b.fn.startLine && b.fn.endLine <= a.fn.endLine) ||
+        (b.fn.startLine <= a.fn.startLine && a.fn.endLine <= b.fn.endLine);
+}
+
+module.exports = {
+    findClones,
+    findDuplicateLiterals,
+    DEFAULT_MIN_LINES,
+    DEFAULT_MIN_TOKENS,
+    DEFAULT_SIMILARITY,
+    DEFAULT_MIN_FILES,
+};
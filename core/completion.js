@@ -0,0 +1,372 @@
+/**
+ * core/completion.js — Shell completion scripts and the man page
+ * (ucn completion bash|zsh|fish|powershell, ucn man)
+ *
+ * Both are generated from what the CLI already defines: the usage text
+ * gives each command and flag its one-line description, the command
+ * registry the command names and which flags apply to which command, and
+ * the flag tables which flags take a value. A command, alias or flag added
+ * there shows up in completion and in the man page without another edit.
+ *
+ * A flag no command's FLAG_APPLICABILITY lists is global: it is offered
+ * after every command. The others are offered after the commands they
+ * apply to, and after the CLI-only commands (fix, watch, ...), which run a
+ * finding command and take its flags.
+ */
+
+'use strict';
+
+const SHELLS = ['bash', 'zsh', 'fish', 'powershell'];
+
+const BANNER = /^═+$/;
+const kebab = s => s.replace(/([a-z])([A-Z])/g, '$1-$2').toLowerCase();
+
+/**
+ * The commands and flags of the usage text.
+ * @param {string} text - printUsage() text
+ * @returns {{title, supported, usage: string[], notes: string[],
+ *   sections: Array<{title, commands: Array<{name, args, summary}>}>,
+ *   flags: Array<{names: string[], arg, summary}>, examples: string[]}}
+ */
+function parseUsage(text) {
+    const lines = text.split('\n');
+    const out = { title: lines[0], supported: '', usage: [], notes: [], sections: [], flags: [], examples: [] };
+    let block = null;
+    let entry = null;
+    // Description: the rest of the line from its first capitalized word
+    const split = (rest) => {
+        const m = rest.match(/^(.*?)(?:^|\s+)([A-Z].*)$/);
+        return m ? [m[1].trim(), m[2].trim()] : [rest.trim(), ''];
+    };
+    for (const line of lines.slice(1)) {
+        if (!line.trim() || BANNER.test(line)) continue;
+        if (line.startsWith('Supported:')) { out.supported = line.slice(10).trim(); continue; }
+        if (line === 'Usage:') { block = 'usage'; continue; }
+        if (line === 'Common Flags:') { block = 'flags'; entry = null; continue; }
+        if (line === 'Quick Start:') { block = 'examples'; continue; }
+        if (/^[A-Z][A-Z ]+$/.test(line)) {
+            block = 'commands';
+            out.sections.push({ title: line, commands: [] });
+            entry = null;
+            continue;
+        }
+        const body = line.trim();
+        if (block === 'usage') {
+            (body.startsWith('(') ? out.notes : out.usage).push(body.replace(/^\((.*)\)$/, '$1'));
+        } else if (block === 'examples') {
+            out.examples.push(body);
+        } else if (/^ {3,}/.test(line)) {
+            // Continuation of the entry above
+            if (entry) entry.summary = entry.summary ? `${entry.summary} ${body}` : body;
+        } else if (block === 'commands') {
+            const [name, ...rest] = body.split(' ');
+            const [args, summary] = split(rest.join(' '));
+            entry = { name, args, summary };
+            out.sections[out.sections.length - 1].commands.push(entry);
+        } else if (block === 'flags' && body.startsWith('-')) {
+            const [head, summary] = split(body);
+            const names = [...head.matchAll(/(?:^|[\s,])(--?[a-z][\w-]*)/g)].map(m => m[1]);
+            entry = { names, arg: head, summary };
+            out.flags.push(entry);
+        }
+    }
+    return out;
+}
+
+/**
+ * Everything the completion scripts offer.
+ * @param {string} text - printUsage() text
+ * @param {object} cli - { commands: CLI command name set, resolve: name →
+ *   canonical command, knownFlags, valueFlags, applicability:
+ *   FLAG_APPLICABILITY, values: flag → its values, subcommands: command →
+ *   the words that may follow it }
+ * @returns {{commands: Array<{name, summary, flags: string[]|null, words: string[]}>,
+ *   flags: Array<{name, summary, value: boolean, values: string[]|null}>, global: string[]}}
+ */
+function completionSpec(text, cli) {
+    const usage = parseUsage(text);
+    const summaries = new Map();
+    for (const section of usage.sections) {
+        for (const c of section.commands) if (!summaries.has(c.name)) summaries.set(c.name, c.summary);
+    }
+    const flagSummaries = new Map();
+    for (const f of usage.flags) for (const name of f.names) flagSummaries.set(name, f.summary);
+
+    const owned = new Set();
+    const commandFlags = new Map();
+    for (const name of cli.commands) {
+        const params = cli.applicability[cli.resolve(name)];
+        if (!params) continue;
+        const flags = params.map(p => `--${kebab(p)}`).filter(f => cli.knownFlags.has(f));
+        for (const f of flags) owned.add(f);
+        commandFlags.set(name, flags);
+    }
+    const flags = [...cli.knownFlags].sort().map(name => ({
+        name,
+        summary: flagSummaries.get(name) || '',
+        value: cli.valueFlags.has(name),
+        values: cli.values[name] || null,
+    }));
+    const global = flags.map(f => f.name).filter(f => !owned.has(f));
+
+    const names = new Set([...summaries.keys(), ...cli.commands]);
+    const commands = [...names].sort().map(name => {
+        const canonical = cli.resolve(name);
+        const alias = canonical && kebab(canonical) !== name && summaries.get(kebab(canonical));
+        return {
+            name,
+            summary: summaries.get(name) || (alias ? `Alias of ${kebab(canonical)}` : ''),
+            flags: commandFlags.get(name) || null,
+            words: cli.subcommands[name] || [],
+        };
+    });
+    return { commands, flags, global };
+}
+
+// Single-quoted in every shell but PowerShell: ' ends the string
+const sq = s => `'${String(s).replace(/'/g, `'\\''`)}'`;
+const psq = s => `'${String(s).replace(/'/g, `''`)}'`;
+const short = (s) => {
+    const line = s.replace(/\s+/g, ' ');
+    return line.length > 80 ? line.slice(0, 77).replace(/\s+\S*$/, '') + '...' : line;
+};
+const flagsOf = (spec, c) => (c.flags ? [...spec.global, ...c.flags] : spec.flags.map(f => f.name)).sort();
+
+function bash(spec) {
+    const withValues = spec.flags.filter(f => f.values);
+    const out = [
+        '# ucn bash completion. Load it in the current shell with',
+        '#   source <(ucn completion bash)',
+        '# or save it as ~/.local/share/bash-completion/completions/ucn',
+        '',
+        '_ucn() {',
+        '    local cur prev cmd i',
+        '    cur="${COMP_WORDS[COMP_CWORD]}"',
+        '    prev="${COMP_WORDS[COMP_CWORD-1]}"',
+        '    # --flag=value: COMP_WORDBREAKS splits at the =',
+        '    if [[ "$cur" == "=" ]]; then',
+        '        cur=""',
+        '    elif [[ "$prev" == "=" ]]; then',
+        '        prev="${COMP_WORDS[COMP_CWORD-2]}"',
+        '    fi',
+        '',
+        '    case "$prev" in',
+        ...withValues.map(f => `        ${f.name}) COMPREPLY=($(compgen -W ${sq(f.values.join(' '))} -- "$cur")); return ;;`),
+        `        ${spec.flags.filter(f => f.value && !f.values).map(f => f.name).join('|')}) return ;;`,
+        '    esac',
+        '',
+        '    cmd=""',
+        '    for ((i = 1; i < COMP_CWORD; i++)); do',
+        '        case "${COMP_WORDS[i]}" in',
+        '            -*|=) ;;',
+        '            *) cmd="${COMP_WORDS[i]}"; break ;;',
+        '        esac',
+        '    done',
+        '',
+        '    if [[ "$cur" == -* ]]; then',
+        '        case "$cmd" in',
+        ...spec.commands.filter(c => c.flags).map(c =>
+            `            ${c.name}) COMPREPLY=($(compgen -W ${sq(flagsOf(spec, c).join(' '))} -- "$cur")) ;;`),
+        `            *) COMPREPLY=($(compgen -W ${sq(spec.flags.map(f => f.name).join(' '))} -- "$cur")) ;;`,
+        '        esac',
+        '        return',
+        '    fi',
+        '',
+        '    case "$cmd" in',
+        `        "") COMPREPLY=($(compgen -W ${sq(spec.commands.map(c => c.name).join(' '))} -- "$cur")) ;;`,
+        ...spec.commands.filter(c => c.words.length).map(c =>
+            `        ${c.name}) COMPREPLY=($(compgen -W ${sq(c.words.join(' '))} -- "$cur")); return ;;`),
+        '    esac',
+        '    # Targets and file arguments',
+        '    COMPREPLY+=($(compgen -f -- "$cur"))',
+        '}',
+        '',
+        'complete -o filenames -F _ucn ucn',
+    ];
+    return out.join('\n') + '\n';
+}
+
+function zsh(spec) {
+    // _describe splits name:description at the first unescaped colon
+    const item = (name, summary) => sq(summary ? `${name.replace(/:/g, '\\:')}:${short(summary)}` : name);
+    const described = new Map(spec.flags.map(f => [f.name, f.summary]));
+    const out = [
+        '#compdef ucn',
+        '# ucn zsh completion. Load it in the current shell with',
+        '#   source <(ucn completion zsh)',
+        '# or save it as _ucn in a directory on $fpath',
+        '',
+        '_ucn() {',
+        '    local cmd i',
+        '    local -a commands flags',
+        '',
+        '    case $words[CURRENT-1] in',
+        ...spec.flags.filter(f => f.values).map(f =>
+            `        ${f.name}) compadd -- ${f.values.join(' ')}; return ;;`),
+        `        ${spec.flags.filter(f => f.value && !f.values).map(f => f.name).join('|')}) _message value; return ;;`,
+        '    esac',
+        '',
+        '    for ((i = 2; i < CURRENT; i++)); do',
+        '        if [[ $words[i] != -* ]]; then cmd=$words[i]; break; fi',
+        '    done',
+        '',
+        '    if [[ $PREFIX == -* ]]; then',
+        '        case $cmd in',
+        ...spec.commands.filter(c => c.flags).map(c =>
+            `            ${c.name}) flags=(${flagsOf(spec, c).map(f => item(f, described.get(f))).join(' ')}) ;;`),
+        `            *) flags=(${spec.flags.map(f => item(f.name, f.summary)).join(' ')}) ;;`,
+        '        esac',
+        "        _describe -t flags 'flag' flags",
+        '        return',
+        '    fi',
+        '',
+        '    case $cmd in',
+        '        "")',
+        `            commands=(${spec.commands.map(c => item(c.name, c.summary)).join(' ')})`,
+        "            _describe -t commands 'command' commands",
+        '            ;;',
+        ...spec.commands.filter(c => c.words.length).map(c =>
+            `        ${c.name}) compadd -- ${c.words.join(' ')}; return ;;`),
+        '    esac',
+        '    _files',
+        '}',
+        '',
+        'if [[ $zsh_eval_context[-1] == loadautofunc ]]; then',
+        '    _ucn "$@"',
+        'else',
+        '    compdef _ucn ucn',
+        'fi',
+    ];
+    return out.join('\n') + '\n';
+}
+
+function fish(spec) {
+    const commandsOf = new Map();
+    for (const c of spec.commands) {
+        for (const f of c.flags || []) {
+            if (!commandsOf.has(f)) commandsOf.set(f, []);
+            commandsOf.get(f).push(c.name);
+        }
+    }
+    // CLI-only commands take every flag
+    const open = spec.commands.filter(c => !c.flags).map(c => c.name);
+    const out = [
+        '# ucn fish completion. Load it in the current shell with',
+        '#   ucn completion fish | source',
+        '# or save it as ~/.config/fish/completions/ucn.fish',
+        '',
+        ...spec.commands.map(c =>
+            `complete -c ucn -n __fish_use_subcommand -a ${c.name}${c.summary ? ` -d ${sq(short(c.summary))}` : ''}`),
+        ...spec.commands.filter(c => c.words.length).map(c =>
+            `complete -c ucn -n ${sq(`__fish_seen_subcommand_from ${c.name}`)} -f -a ${sq(c.words.join(' '))}`),
+    ];
+    for (const f of spec.flags) {
+        const option = f.name.startsWith('--') ? `-l ${f.name.slice(2)}` : f.name.length === 2 ? `-s ${f.name.slice(1)}` : `-o ${f.name.slice(1)}`;
+        const scope = spec.global.includes(f.name) ? '' : ` -n ${sq(`__fish_seen_subcommand_from ${[...commandsOf.get(f.name) || [], ...open].join(' ')}`)}`;
+        const value = f.values ? ` -x -a ${sq(f.values.join(' '))}` : f.value ? ' -r' : '';
+        out.push(`complete -c ucn${scope} ${option}${value}${f.summary ? ` -d ${sq(short(f.summary))}` : ''}`);
+    }
+    return out.join('\n') + '\n';
+}
+
+function powershell(spec) {
+    const table = (entries) => entries.map(([k, v]) => `        ${psq(k)} = ${v}`).join('\n');
+    const list = xs => `@(${xs.map(psq).join(', ')})`;
+    return `# ucn PowerShell completion. Load it in the current session with
+#   ucn completion powershell | Out-String | Invoke-Expression
+# or add that line to your $PROFILE
+
+Register-ArgumentCompleter -Native -CommandName ucn -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $commands = [ordered]@{
+${table(spec.commands.map(c => [c.name, psq(short(c.summary) || c.name)]))}
+    }
+    $flags = [ordered]@{
+${table(spec.flags.map(f => [f.name, psq(short(f.summary) || f.name)]))}
+    }
+    $global = ${list(spec.global)}
+    $commandFlags = @{
+${table(spec.commands.filter(c => c.flags).map(c => [c.name, list(c.flags)]))}
+    }
+    $values = @{
+${table(spec.flags.filter(f => f.values).map(f => [f.name, list(f.values)]))}
+    }
+    $words = @{
+${table(spec.commands.filter(c => c.words.length).map(c => [c.name, list(c.words)]))}
+    }
+
+    $elements = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+    if ($wordToComplete) { $elements = @($elements | Select-Object -SkipLast 1) }
+    $cmd = $elements | Where-Object { $_ -notlike '-*' } | Select-Object -First 1
+    $prev = if ($elements.Count) { $elements[-1] } else { $null }
+
+    $result = if ($prev -and $values.Contains($prev)) {
+        $values[$prev] | ForEach-Object { @{ Text = $_; Tip = $_ } }
+    } elseif ($wordToComplete -like '-*') {
+        $offered = if ($cmd -and $commandFlags.Contains($cmd)) { $global + $commandFlags[$cmd] } else { $flags.Keys }
+        $offered | ForEach-Object { @{ Text = $_; Tip = $flags[$_] } }
+    } elseif (-not $cmd) {
+        $commands.Keys | ForEach-Object { @{ Text = $_; Tip = $commands[$_] } }
+    } elseif ($words.Contains($cmd)) {
+        $words[$cmd] | ForEach-Object { @{ Text = $_; Tip = $_ } }
+    }
+    $result | Where-Object { $_.Text -like "$wordToComplete*" } | Sort-Object { $_.Text } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_.Text, $_.Text, 'ParameterValue', $_.Tip)
+    }
+}
+`;
+}
+
+/**
+ * A shell's completion script.
+ * @param {string} shell - bash, zsh, fish or powershell
+ * @param {object} spec - completionSpec()
+ */
+function completionScript(shell, spec) {
+    return { bash, zsh, fish, powershell }[shell](spec);
+}
+
+// roff text: backslashes and hyphens escaped, no line starting with . or '
+const roff = s => String(s).replace(/\\/g, '\\e').replace(/-/g, '\\-').replace(/^([.'])/, '\\&$1');
+
+/**
+ * The ucn(1) man page, in roff.
+ * @param {string} text - printUsage() text
+ * @param {string} version - Package version
+ */
+function manPage(text, version) {
+    const usage = parseUsage(text);
+    const [, name = 'UCN', tagline = ''] = usage.title.match(/^(\S+)\s+-\s+(.*)$/) || [];
+    const out = [
+        `.TH UCN 1 "" "ucn ${version}" "User Commands"`,
+        '.SH NAME',
+        `ucn \\- ${roff(tagline || name)}`,
+        '.SH SYNOPSIS',
+        ...usage.usage.flatMap(line => {
+            const [, form, note = ''] = line.match(/^(.*?)(?:\s{2,}(.*))?$/);
+            return ['.TP', `.B ${roff(form)}`, roff(note)];
+        }),
+        '.SH DESCRIPTION',
+        roff(`Universal Code Navigator: understand, search, extract and refactor code across a project from its symbol index. Supported: ${usage.supported}.`),
+        ...usage.notes.flatMap(n => ['.PP', roff(`${n}.`)]),
+        '.SH COMMANDS',
+    ];
+    for (const section of usage.sections) {
+        out.push(`.SS ${roff(section.title)}`);
+        for (const c of section.commands) {
+            out.push('.TP', `.B ${roff(c.name)}${c.args ? ` ${roff(c.args)}` : ''}`, roff(c.summary));
+        }
+    }
+    out.push('.SH OPTIONS');
+    for (const f of usage.flags) out.push('.TP', `.B ${roff(f.arg)}`, roff(f.summary));
+    out.push('.SH EXAMPLES');
+    for (const e of usage.examples) {
+        const [, cmd, note = ''] = e.match(/^(.*?)(?:\s+#\s*(.*))?$/);
+        out.push('.TP', `.B ${roff(cmd.trim())}`, roff(note));
+    }
+    out.push('.SH SEE ALSO', roff('ucn completion bash|zsh|fish|powershell prints a shell completion script.'));
+    return out.join('\n') + '\n';
+}
+
+module.exports = { parseUsage, completionSpec, completionScript, manPage, SHELLS };
@@ -0,0 +1,225 @@
+/**
+ * core/complexity.js — Per-function complexity metrics (deadcode --complexity)
+ *
+ * Cyclomatic complexity is one plus the decision points of a function:
+ * each if/elif, loop, catch, non-default case, ternary and `&&`/`||`.
+ * Cognitive complexity follows the SonarSource rules: structures that
+ * break the linear flow add one plus their nesting depth, `else`/`elif`
+ * add one flat, a run of the same boolean operator adds one, and so do
+ * labelled jumps and direct recursion. Nested functions deepen the
+ * nesting without adding to it themselves.
+ *
+ * Both are counted on the syntax tree with node types shared across the
+ * tree-sitter grammars, so every parsed language is covered. Thresholds
+ * come from options, then .ucn.json "complexity"
+ * (`{ "cyclomatic": 10, "cognitive": 15 }`), then the defaults below.
+ */
+
+'use strict';
+
+const { getParser, safeParse } = require('../languages');
+const { isTestFile } = require('./discovery');
+const { codeUnitCompare, CALLABLE_SYMBOL_KINDS } = require('./shared');
+
+const DEFAULT_CYCLOMATIC = 10;
+const DEFAULT_COGNITIVE = 15;
+
+const IF_NODES = new Set(['if_statement', 'if_expression', 'if_let_expression']);
+const LOOP_NODES = new Set(['for_statement', 'for_in_statement', 'for_of_statement', 'enhanced_for_statement',
+    'for_expression', 'while_statement', 'while_expression', 'do_statement', 'loop_expression', 'repeat_statement']);
+const CATCH_NODES = new Set(['catch_clause', 'except_clause', 'rescue']);
+const SWITCH_NODES = new Set(['switch_statement', 'switch_expression', 'expression_switch_statement',
+    'type_switch_statement', 'select_statement', 'match_statement', 'match_expression', 'case_statement']);
+const CASE_NODES = new Set(['switch_case', 'case_clause', 'expression_case', 'type_case', 'communication_case',
+    'match_arm', 'switch_block_statement_group', 'switch_rule', 'case_item']);
+const TERNARY_NODES = new Set(['conditional_expression', 'ternary_expression']);
+const ELSE_IF_NODES = new Set(['elif_clause', 'else_if_clause']);
+const FUNCTION_NODE = /function|method|lambda|closure|arrow|constructor|func_literal/;
+const NOT_FUNCTION_NODE = /call|invocation|reference|parameter|type|signature|modifier|name|spec|elem/;
+const LOGICAL_OPERATORS = new Set(['&&', '||', 'and', 'or']);
+
+function setting(config, options, key, option, fallback) {
+    if (options[option] != null) return Number(options[option]);
+    const configured = config?.complexity?.[key];
+    return typeof configured === 'number' ? configured : fallback;
+}
+
+/** Thresholds under one config: option, then config, then default */
+function thresholdsOf(config, options) {
+    return {
+        cyclomatic: setting(config, options, 'cyclomatic', 'maxCyclomatic', DEFAULT_CYCLOMATIC),
+        cognitive: setting(config, options, 'cognitive', 'maxCognitive', DEFAULT_COGNITIVE),
+    };
+}
+
+function isFunctionNode(node) {
+    return FUNCTION_NODE.test(node.type) && !NOT_FUNCTION_NODE.test(node.type);
+}
+
+/** Operator of a logical binary node, or null */
+function logicalOperator(node) {
+    if (node.type !== 'binary_expression' && node.type !== 'boolean_operator' && node.type !== 'binary_operator') return null;
+    const op = node.childForFieldName('operator')?.type;
+    return LOGICAL_OPERATORS.has(op) ? op : null;
+}
+
+/** Whether a node is the default branch of a switch (no decision of its own) */
+function isDefaultCase(node) {
+    if (/default/.test(node.type)) return true;
+    const first = node.namedChildCount > 0 ? node.namedChild(0) : null;
+    return first?.type === 'default' || /^default\b/.test(first?.text || '');
+}
+
+/**
+ * Cyclomatic and cognitive complexity of one function node.
+ * @param {object} fnNode - Function-like syntax node
+ * @param {string} [name] - Function name, for the recursion increment
+ * @returns {{cyclomatic: number, cognitive: number}}
+ */
+function measure(fnNode, name) {
+    let cyclomatic = 1;
+    let cognitive = 0;
+
+    const visit = (node, nesting, elseIf = false) => {
+        const type = node.type;
+        if (/comment/.test(type)) return;
+        let inner = nesting;
+
+        if (IF_NODES.has(type)) {
+            cyclomatic++;
+            cognitive += elseIf ? 1 : 1 + nesting;
+            const base = elseIf ? nesting - 1 : nesting;
+            inner = base + 1;
+            for (let i = 0; i < node.namedChildCount; i++) {
+                const child = node.namedChild(i);
+                if (ELSE_IF_NODES.has(child.type)) {
+                    visit(child, inner);
+                    continue;
+                }
+                const isAlternative = child.type === 'else_clause' || child.type === 'else' ||
+                    (node.childForFieldName('alternative') && child.id === node.childForFieldName('alternative').id);
+                if (!isAlternative) {
+                    visit(child, inner);
+                    continue;
+                }
+                // `else if` continues the chain at the same depth; a plain
+                // `else` is one flat increment
+                const body = child.type === 'else_clause' && child.namedChildCount === 1 ? child.namedChild(0) : child;
+                if (IF_NODES.has(body.type)) {
+                    visit(body, inner, true);
+                } else {
+                    cognitive++;
+                    visit(body, inner);
+                }
+            }
+            return;
+        }
+        if (ELSE_IF_NODES.has(type)) {
+            cyclomatic++;
+            cognitive++;
+        } else if (LOOP_NODES.has(type) || CATCH_NODES.has(type) || TERNARY_NODES.has(type)) {
+            cyclomatic++;
+            cognitive += 1 + nesting;
+            inner = nesting + 1;
+        } else if (SWITCH_NODES.has(type)) {
+            cognitive += 1 + nesting;
+            inner = nesting + 1;
+        } else if (CASE_NODES.has(type)) {
+            if (!isDefaultCase(node)) cyclomatic++;
+        } else if (type === 'else_clause' && node.parent && !IF_NODES.has(node.parent.type)) {
+            // Python's else on for/while/try
+            cognitive++;
+        } else if (node !== fnNode && isFunctionNode(node)) {
+            inner = nesting + 1;
+        } else if (type === 'goto_statement' ||
+            ((type === 'break_statement' || type === 'continue_statement') && node.namedChildCount > 0)) {
+            cognitive++;
+        } else if (name && (type === 'call_expression' || type === 'call' || type === 'method_invocation')) {
+            const callee = node.childForFieldName('function') || node.childForFieldName('name');
+            const text = callee?.text || '';
+            if (text === name || text.endsWith(`.${name}`)) cognitive++;
+        }
+
+        const op = logicalOperator(node);
+        if (op) {
+            cyclomatic++;
+            let parent = node.parent;
+            while (parent?.type === 'parenthesized_expression') parent = parent.parent;
+            if (!parent || logicalOperator(parent) !== op) cognitive++;
+        }
+
+        for (let i = 0; i < node.namedChildCount; i++) visit(node.namedChild(i), inner);
+    };
+    visit(fnNode, 0);
+    return { cyclomatic, cognitive };
+}
+
+/** Outermost function-like node of `tree` spanning a symbol's lines */
+function functionNodeFor(tree, symbol) {
+    const startRow = symbol.startLine - 1;
+    const endRow = symbol.endLine - 1;
+    let found = null;
+    const visit = (node) => {
+        if (found || node.startPosition.row > endRow || node.endPosition.row < startRow) return;
+        if (node.endPosition.row === endRow && node.startPosition.row >= startRow && isFunctionNode(node)) {
+            found = node;
+            return;
+        }
+        for (let i = 0; i < node.namedChildCount; i++) visit(node.namedChild(i));
+    };
+    visit(tree.rootNode);
+    return found;
+}
+
+/**
+ * Complexity of every function in the project, and those over threshold.
+ * @param {object} index - ProjectIndex
+ * @param {object} options - { maxCyclomatic, maxCognitive, file, exclude, in, includeTests }
+ * @returns {{metrics: Map<string, {cyclomatic, cognitive}>, findings: Array,
+ *   thresholds: {cyclomatic: number, cognitive: number}}} metrics keyed `file:startLine`
+ */
+function analyzeComplexity(index, options = {}) {
+    // The root's; a config file below the root may set a directory's own
+    const thresholds = thresholdsOf(index.config, options);
+    const metrics = new Map();
+    const findings = [];
+    for (const [filePath, fileEntry] of index.files) {
+        const lang = fileEntry.language;
+        const functions = (fileEntry.symbols || []).filter(s => CALLABLE_SYMBOL_KINDS.has(s.type));
+        if (functions.length === 0) continue;
+        let content;
+        try { content = index._readFile(filePath); } catch { continue; }
+        let tree = index._getParsedTree(filePath, content, lang);
+        if (!tree) {
+            try { tree = safeParse(getParser(lang), content); } catch { tree = null; }
+        }
+        if (!tree) continue;
+        const rel = fileEntry.relativePath;
+        const limit = index.configFor ? thresholdsOf(index.configFor(rel), options) : thresholds;
+        const reported = (options.includeTests || !isTestFile(rel, lang)) &&
+            (!options.file || rel.includes(options.file)) &&
+            !(((options.exclude && options.exclude.length > 0) || options.in) &&
+                !index.matchesFilters(rel, { exclude: options.exclude, in: options.in }));
+        for (const fn of functions) {
+            const node = functionNodeFor(tree, fn);
+            if (!node) continue;
+            const m = measure(node, fn.name);
+            metrics.set(`${rel}:${fn.startLine}`, m);
+            if (!reported || (m.cyclomatic <= limit.cyclomatic && m.cognitive <= limit.cognitive)) continue;
+            findings.push({
+                name: fn.name,
+                type: fn.type,
+                file: rel,
+                startLine: fn.startLine,
+                endLine: fn.endLine,
+                ...(fn.className && { className: fn.className }),
+                cyclomatic: m.cyclomatic,
+                cognitive: m.cognitive,
+            });
+        }
+    }
+    findings.sort((a, b) => codeUnitCompare(a.file, b.file) || a.startLine - b.startLine);
+    return { metrics, findings, thresholds };
+}
+
+module.exports = { analyzeComplexity, measure, functionNodeFor, isFunctionNode };
@@ -0,0 +1,277 @@
+/**
+ * core/config-keys.js — Dead configuration keys (deadcode --config-keys)
+ *
+ * Two sides of one schema. Keys present in the project's YAML, JSON and
+ * TOML config files that no code reads, and config struct fields (Go
+ * struct tags) that no config file sets.
+ *
+ * A key is read when the code names it somewhere: inside a string literal
+ * (`viper.GetInt("server.port")`, a struct tag `yaml:"port"`) or as a
+ * property (`cfg.server.port`), or as the name of an indexed field.
+ * Names compare case-insensitively with `_` and `-` dropped, the way
+ * config decoders match them. A mapping whose own name and every key
+ * below it are unread is reported once, at its top.
+ *
+ * A struct is a config struct when at least half of its yaml/toml/
+ * mapstructure/koanf-tagged fields (json too, once a config file is JSON)
+ * name a config key; its remaining tagged fields are never populated.
+ * Fields with an `env:` or `default:` tag get their value elsewhere.
+ *
+ * Config files come from .ucn.json "configFiles" (globs), else every
+ * .yaml/.yml/.json/.toml file in a config/, configs/, conf/ or settings/
+ * directory or named config*, settings*, application* or app*.
+ */
+
+'use strict';
+
+const { relative: pathRelative, dirname } = require('path');
+const { expandGlob, globToRegex, isTestFile } = require('./discovery');
+const { codeUnitCompare, escapeRegExp } = require('./shared');
+
+const CONFIG_FILE = /(^|\/)(config|configs|conf|settings)\/([^/]+\/)*[^/]+\.(ya?ml|json|toml)$|(^|\/)(config|settings|application|app)([._-][^/]*)?\.(ya?ml|json|toml)$/i;
+const NOT_CONFIG_FILE = /(^|\/)((package(-lock)?|tsconfig[^/]*|jsconfig|composer(\.lock)?|\.ucn)\.json|\.ucn\.ya?ml|ucn\.toml)$/i;
+const FIXTURE_DIR = /(^|\/)(testdata|fixtures?|__fixtures__)\//;
+const CONFIG_TAGS = ['yaml', 'toml', 'mapstructure', 'koanf', 'hcl', 'ini'];
+const VALUE_ELSEWHERE_TAGS = ['env', 'default', 'envDefault'];
+
+/** Lowercased, `_`/`-`-free form decoders match names by */
+function normalize(name) {
+    return String(name).toLowerCase().replace(/[_-]/g, '');
+}
+
+const unquote = (s) => s.replace(/^(["'])(.*)\1$/, '$2');
+
+/** Key paths of a YAML document by indentation; block scalars skipped */
+function yamlKeys(text) {
+    const keys = [];
+    const stack = [];
+    let blockIndent = -1;
+    text.split('\n').forEach((raw, i) => {
+        if (/^\s*(#.*)?$/.test(raw)) return;
+        const indent = raw.match(/^\s*/)[0].length;
+        if (blockIndent >= 0) {
+            if (indent > blockIndent) return;
+            blockIndent = -1;
+        }
+        if (/^(---|\.\.\.)/.test(raw)) {
+            stack.length = 0;
+            return;
+        }
+        const m = raw.match(/^(\s*)((?:-\s+)*)("(?:[^"\\]|\\.)*"|'[^']*'|[^\s#'"{}[\]\-<][^:#]*?)\s*:(?:\s+(.*))?$/);
+        if (!m) return;
+        const keyIndent = m[1].length + m[2].length;
+        while (stack.length && stack[stack.length - 1].indent >= keyIndent) stack.pop();
+        stack.push({ indent: keyIndent, key: unquote(m[3]) });
+        keys.push({ path: stack.map(s => s.key), line: i + 1 });
+        if (/^[|>][-+\d]*\s*(#.*)?$/.test(m[4] || '')) blockIndent = keyIndent;
+    });
+    return keys;
+}
+
+/** Key paths of a TOML document: table headers and `key = value` lines */
+function tomlKeys(text) {
+    const keys = [];
+    const dotted = (s) => (s.match(/"[^"]*"|'[^']*'|[^.\s]+/g) || []).map(unquote);
+    const push = (path, line) => {
+        for (let n = 1; n <= path.length; n++) keys.push({ path: path.slice(0, n), line });
+    };
+    let table = [];
+    let inMultiline = false;
+    text.split('\n').forEach((raw, i) => {
+        const line = raw.trim();
+        if (inMultiline) {
+            if (/"""|'''/.test(line)) inMultiline = false;
+            return;
+        }
+        if (!line || line.startsWith('#')) return;
+        let m = line.match(/^\[\[?\s*([^\]]+?)\s*\]\]?/);
+        if (m) {
+            table = dotted(m[1]);
+            push(table, i + 1);
+            return;
+        }
+        m = line.match(/^((?:"[^"]*"|'[^']*'|[\w-]+)(?:\s*\.\s*(?:"[^"]*"|'[^']*'|[\w-]+))*)\s*=(.*)$/);
+        if (!m) return;
+        push([...table, ...dotted(m[1])], i + 1);
+        if ((m[2].match(/"""|'''/g) || []).length === 1) inMultiline = true;
+    });
+    return keys;
+}
+
+/** Key paths of a JSON document; array elements share their parent's path */
+function jsonKeys(text) {
+    let data;
+    try { data = JSON.parse(text); } catch { return []; }
+    const keys = [];
+    const lineAt = (offset) => text.slice(0, offset).split('\n').length;
+    const walk = (value, path, from) => {
+        if (Array.isArray(value)) {
+            for (const element of value) walk(element, path, from);
+            return;
+        }
+        if (!value || typeof value !== 'object') return;
+        for (const key of Object.keys(value)) {
+            const re = new RegExp(`${escapeRegExp(JSON.stringify(key))}\\s*:`, 'g');
+            re.lastIndex = from;
+            const found = re.exec(text);
+            const offset = found ? found.index : from;
+            keys.push({ path: [...path, key], line: lineAt(offset) });
+            walk(value[key], [...path, key], offset);
+        }
+    };
+    walk(data, [], 0);
+    return keys;
+}
+
+function keysOf(file, text) {
+    if (/\.ya?ml$/i.test(file)) return yamlKeys(text);
+    if (/\.toml$/i.test(file)) return tomlKeys(text);
+    return jsonKeys(text);
+}
+
+/** Config files of the project, as absolute paths */
+function configFiles(index) {
+    const configured = index.config?.configFiles;
+    const globs = typeof configured === 'string' ? configured.split(',').map(s => s.trim()).filter(Boolean) : configured;
+    const all = expandGlob('**/*', { root: index.root })
+        .map(f => ({ path: f, rel: pathRelative(index.root, f).replace(/\\/g, '/') }));
+    if (Array.isArray(globs) && globs.length > 0) {
+        const patterns = globs.map(globToRegex);
+        return all.filter(f => patterns.some(re => re.test(f.rel)));
+    }
+    return all.filter(f => CONFIG_FILE.test(f.rel) && !NOT_CONFIG_FILE.test(f.rel));
+}
+
+/** Every name the code mentions in a string literal, as a property, or as a field */
+function namesRead(index) {
+    const names = new Set();
+    const add = (word) => {
+        if (word) names.add(normalize(word));
+    };
+    for (const [filePath, fileEntry] of index.files) {
+        for (const s of fileEntry.symbols || []) {
+            if (s.type === 'field') add(s.name);
+        }
+        let content;
+        try { content = index._readFile(filePath); } catch { continue; }
+        for (const m of content.matchAll(/(["'`])((?:\\.|(?!\1)[^\\\n])*)\1/g)) {
+            for (const word of m[2].split(/[^\w-]+/)) add(word);
+        }
+        for (const m of content.matchAll(/\.\s*([A-Za-z_]\w*)/g)) add(m[1]);
+    }
+    return names;
+}
+
+/** Name a field takes in config under its tags, null when it has none */
+function configTagName(symbol, tags) {
+    if (!symbol.fieldTag || symbol.embedded) return null;
+    const tagged = new Map();
+    for (const m of String(symbol.fieldTag).matchAll(/(\w+):"((?:[^"\\]|\\.)*)"/g)) tagged.set(m[1], m[2]);
+    if (VALUE_ELSEWHERE_TAGS.some(t => tagged.has(t))) return null;
+    for (const tag of tags) {
+        if (!tagged.has(tag)) continue;
+        const [name, ...flags] = tagged.get(tag).split(',');
+        if (name === '-' || flags.some(f => f === 'inline' || f === 'squash' || f === 'remain')) return null;
+        return name || symbol.name;
+    }
+    return null;
+}
+
+/**
+ * Config keys no code reads and config fields no config file sets.
+ * @param {object} index - ProjectIndex
+ * @param {object} options - { file, exclude, in, includeTests }
+ * @returns {Array} dead-code items: `configKey` (descendant key count) on
+ *   keys, `configField` (the tag name) on fields
+ */
+function deadConfigKeys(index, options = {}) {
+    const results = [];
+    const inScope = (rel, lang) => (options.includeTests || (!isTestFile(rel, lang) && !FIXTURE_DIR.test(rel))) &&
+        (!options.file || rel.includes(options.file)) &&
+        !(((options.exclude && options.exclude.length > 0) || options.in) &&
+            !index.matchesFilters(rel, { exclude: options.exclude, in: options.in }));
+
+    const files = [];
+    for (const f of configFiles(index)) {
+        let text;
+        try { text = index._readFile(f.path); } catch { continue; }
+        files.push({ ...f, keys: keysOf(f.rel, text).filter(k => /\w/.test(k.path[k.path.length - 1])) });
+    }
+    const read = files.length > 0 ? namesRead(index) : new Set();
+    const configNames = new Set();
+    for (const f of files) {
+        for (const k of f.keys) configNames.add(normalize(k.path[k.path.length - 1]));
+    }
+
+    for (const f of files) {
+        if (!inScope(f.rel)) continue;
+        const nodes = new Map();
+        const roots = [];
+        for (const k of f.keys) {
+            const id = k.path.join('\0');
+            if (nodes.has(id)) continue;
+            const node = { path: k.path, line: k.line, children: [] };
+            nodes.set(id, node);
+            const parent = nodes.get(k.path.slice(0, -1).join('\0'));
+            (parent ? parent.children : roots).push(node);
+        }
+        const unread = (node) => !read.has(normalize(node.path[node.path.length - 1])) && node.children.every(unread);
+        const descendants = (node) => node.children.reduce((n, c) => n + 1 + descendants(c), 0);
+        const report = (node) => {
+            if (!unread(node)) {
+                node.children.forEach(report);
+                return;
+            }
+            results.push({
+                name: node.path.join('.'),
+                type: 'config key',
+                file: f.rel,
+                startLine: node.line,
+                endLine: node.line,
+                isExported: false,
+                usageCount: 0,
+                configKey: descendants(node),
+            });
+        };
+        roots.forEach(report);
+    }
+
+    // Struct fields decoded from config that no config file sets
+    const tags = files.some(f => /\.json$/i.test(f.rel)) ? [...CONFIG_TAGS, 'json'] : CONFIG_TAGS;
+    const structs = new Map();
+    for (const [filePath, fileEntry] of index.files) {
+        for (const s of fileEntry.symbols || []) {
+            if (s.type !== 'field' || !s.className) continue;
+            const tagName = configTagName(s, tags);
+            if (!tagName) continue;
+            const key = `${dirname(filePath)}\0${s.className}`;
+            if (!structs.has(key)) structs.set(key, []);
+            structs.get(key).push({ symbol: s, fileEntry, tagName });
+        }
+    }
+    for (const fields of structs.values()) {
+        const unset = fields.filter(f => !configNames.has(normalize(f.tagName)));
+        if (unset.length === fields.length || unset.length * 2 > fields.length) continue;
+        for (const { symbol, fileEntry, tagName } of unset) {
+            if (!inScope(fileEntry.relativePath, fileEntry.language)) continue;
+            results.push({
+                name: symbol.name,
+                type: 'field',
+                file: fileEntry.relativePath,
+                startLine: symbol.startLine,
+                endLine: symbol.endLine,
+                className: symbol.className,
+                isExported: /^[A-Z]/.test(symbol.name),
+                configField: tagName,
+            });
+        }
+    }
+
+    results.sort((a, b) => codeUnitCompare(a.file, b.file) || a.startLine - b.startLine);
+    results.excludedDecorated = 0;
+    results.excludedExported = 0;
+    results.excludedExternalContract = 0;
+    return results;
+}
+
+module.exports = { deadConfigKeys };
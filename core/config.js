@@ -0,0 +1,520 @@
+/**
+ * core/config.js — Project config files and their per-directory overrides
+ *
+ * The root config is the first of .ucn.json, .ucn.yaml, .ucn.yml and
+ * ucn.toml found at the project root. Any directory below it may hold one
+ * too (the same names, first found wins), overriding the settings that
+ * vary by directory for the files under it:
+ *
+ *   rules            per-rule severity/confidence, or "off" to drop a rule
+ *   include/exclude  globs, relative to the directory the file sits in
+ *   entryPoints      symbols and files used from outside the project: a
+ *                    name pattern ("main", "Cli.run", "main.main", "Test*")
+ *                    or { name, file, package, exported } (file and package
+ *                    globs), or { registry } naming a call ("http.HandleFunc",
+ *                    "*.GET") whose function arguments are entry points
+ *   complexity/size  thresholds (size per language too)
+ *
+ * Like .editorconfig, a file's settings merge every config from the root
+ * down to its directory, the nearest winning: objects merge key by key,
+ * anything else (arrays included) is replaced. A config with `root: true`
+ * stops the merge there, ignoring those above it.
+ *
+ * YAML and TOML are read without dependencies, in the subset config
+ * files use: block mappings and sequences, flow [..] and {..}, quoted
+ * and plain scalars, comments; TOML tables, arrays of tables, dotted
+ * keys, inline tables and arrays. Block scalars and multi-line strings
+ * are rejected with their line number.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { globToRegex } = require('./discovery');
+
+const CONFIG_FILES = ['.ucn.json', '.ucn.yaml', '.ucn.yml', 'ucn.toml'];
+// Settings a directory's config can override; the rest are the root's alone
+const DIRECTORY_KEYS = ['rules', 'include', 'exclude', 'entryPoints', 'complexity', 'size'];
+
+class ConfigError extends Error {
+    constructor(line, message) {
+        super(`line ${line}: ${message}`);
+        this.line = line;
+    }
+}
+
+/** A line without its comment (`#` at the start or after a space, outside quotes) */
+function stripComment(line) {
+    let quote = null;
+    for (let i = 0; i < line.length; i++) {
+        const c = line[i];
+        if (quote) {
+            if (c === '\\' && quote === '"') i++;
+            else if (c === quote) quote = null;
+        } else if (c === '"' || c === "'") {
+            quote = c;
+        } else if (c === '#' && (i === 0 || /\s/.test(line[i - 1]))) {
+            return line.slice(0, i).trimEnd();
+        }
+    }
+    return line.trimEnd();
+}
+
+/** Open [ and { minus closed ones, outside quotes */
+function bracketDepth(text) {
+    let depth = 0;
+    let quote = null;
+    for (let i = 0; i < text.length; i++) {
+        const c = text[i];
+        if (quote) {
+            if (c === '\\' && quote === '"') i++;
+            else if (c === quote) quote = null;
+        } else if (c === '"' || c === "'") quote = c;
+        else if (c === '[' || c === '{') depth++;
+        else if (c === ']' || c === '}') depth--;
+    }
+    return depth;
+}
+
+/**
+ * Non-blank lines without comments, a flow collection spread over several
+ * lines joined into its first.
+ * @returns {Array<{n: number, indent: number, text: string}>}
+ */
+function logicalLines(text) {
+    const raw = text.replace(/^\uFEFF/, '').split(/\r?\n/);
+    const out = [];
+    for (let i = 0; i < raw.length; i++) {
+        let line = stripComment(raw[i]);
+        if (line.trim() === '') continue;
+        const n = i + 1;
+        while (bracketDepth(line) > 0 && i + 1 < raw.length) line += ' ' + stripComment(raw[++i]).trim();
+        if (/^\t/.test(line)) throw new ConfigError(n, 'tabs are not allowed for indentation');
+        out.push({ n, indent: line.length - line.trimStart().length, text: line.trim() });
+    }
+    return out;
+}
+
+/** A double-quoted string's value */
+function doubleQuoted(text, n) {
+    try {
+        return JSON.parse(text.replace(/\\'/g, "'"));
+    } catch {
+        throw new ConfigError(n, `bad string ${text}`);
+    }
+}
+
+/**
+ * Reader of one inline value: a [..] or {..} collection, a quoted string
+ * or a plain word. `plain` turns a plain word into its value; `separator`
+ * splits an inline map's key from its value (`:` in YAML, `=` in TOML).
+ */
+function flowParser(text, n, { plain, separator }) {
+    let i = 0;
+    const ws = () => { while (i < text.length && /\s/.test(text[i])) i++; };
+    const quoted = () => {
+        const q = text[i];
+        let j = i + 1;
+        while (j < text.length && text[j] !== q) j += text[j] === '\\' && q === '"' ? 2 : 1;
+        if (j >= text.length) throw new ConfigError(n, 'unterminated string');
+        const raw = text.slice(i, j + 1);
+        i = j + 1;
+        return q === '"' ? doubleQuoted(raw, n) : raw.slice(1, -1).replace(/''/g, "'");
+    };
+    const until = (stops) => {
+        const start = i;
+        while (i < text.length && !stops.includes(text[i])) i++;
+        return text.slice(start, i).trim();
+    };
+    const value = () => {
+        ws();
+        const c = text[i];
+        if (c === '[') {
+            i++;
+            const items = [];
+            for (;;) {
+                ws();
+                if (text[i] === ']') { i++; return items; }
+                items.push(value());
+                ws();
+                if (text[i] === ',') i++;
+                else if (text[i] !== ']') throw new ConfigError(n, `expected , or ] in ${text}`);
+            }
+        }
+        if (c === '{') {
+            i++;
+            const map = {};
+            for (;;) {
+                ws();
+                if (text[i] === '}') { i++; return map; }
+                const key = text[i] === '"' || text[i] === "'" ? quoted() : until([separator, ',', '}']);
+                ws();
+                if (text[i] !== separator) throw new ConfigError(n, `expected ${separator} after ${key}`);
+                i++;
+                map[key] = value();
+                ws();
+                if (text[i] === ',') i++;
+                else if (text[i] !== '}') throw new ConfigError(n, `expected , or } in ${text}`);
+            }
+        }
+        if (c === '"' || c === "'") return quoted();
+        const word = until([',', ']', '}']);
+        if (word === '') throw new ConfigError(n, `missing value in ${text}`);
+        return plain(word, n);
+    };
+    return {
+        parse() {
+            const v = value();
+            ws();
+            if (i < text.length) throw new ConfigError(n, `unexpected ${text.slice(i)}`);
+            return v;
+        },
+    };
+}
+
+// ── YAML ────────────────────────────────────────────────────────────────
+
+const YAML_ENTRY = /^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^\s"'{[#\-?][^:]*?|-[^\s:][^:]*?)\s*:(?:\s+(.*))?$/;
+const isItem = text => text === '-' || text.startsWith('- ');
+
+function yamlPlain(word) {
+    if (/^(true|True|TRUE)$/.test(word)) return true;
+    if (/^(false|False|FALSE)$/.test(word)) return false;
+    if (/^(null|Null|NULL|~)$/.test(word)) return null;
+    if (/^[-+]?(\d+|\d*\.\d+)([eE][-+]?\d+)?$/.test(word)) return Number(word);
+    return word;
+}
+
+function yamlScalar(text, n) {
+    if (text.startsWith('|') || text.startsWith('>')) throw new ConfigError(n, 'block scalars (| and >) are not supported');
+    if (text.startsWith('&') || text.startsWith('*')) throw new ConfigError(n, 'anchors and aliases are not supported');
+    if (/^["'[{]/.test(text)) return flowParser(text, n, { plain: yamlPlain, separator: ':' }).parse();
+    return yamlPlain(text);
+}
+
+function yamlKey(raw, n) {
+    if (raw.startsWith('"')) return doubleQuoted(raw, n);
+    if (raw.startsWith("'")) return raw.slice(1, -1).replace(/''/g, "'");
+    return raw;
+}
+
+/**
+ * Parse the YAML subset config files use.
+ * @param {string} text - File content
+ * @returns {*} The document's value (null when empty)
+ * @throws {ConfigError} With the line of what it can't read
+ */
+function parseYaml(text) {
+    const lines = logicalLines(text).filter(l => l.text !== '---' && l.text !== '...');
+    let pos = 0;
+
+    const block = () => (isItem(lines[pos].text) ? sequence(lines[pos].indent) : mapping(lines[pos].indent));
+
+    // A nested block, when the next line is one: deeper, or a sequence at the key's own indent
+    const nested = (indent) => {
+        const next = lines[pos];
+        if (next && (next.indent > indent || (next.indent === indent && isItem(next.text)))) return block();
+        return null;
+    };
+
+    const sequence = (indent) => {
+        const items = [];
+        while (pos < lines.length && lines[pos].indent === indent && isItem(lines[pos].text)) {
+            const line = lines[pos];
+            const rest = line.text.slice(1).trimStart();
+            if (rest === '') {
+                pos++;
+                items.push(lines[pos] && lines[pos].indent > indent ? block() : null);
+            } else if (YAML_ENTRY.test(rest)) {
+                // "- key: value" opens a mapping at the column of its key
+                lines[pos] = { n: line.n, indent: indent + line.text.length - rest.length, text: rest };
+                items.push(mapping(lines[pos].indent));
+            } else {
+                pos++;
+                items.push(yamlScalar(rest, line.n));
+            }
+        }
+        return items;
+    };
+
+    const mapping = (indent) => {
+        const map = {};
+        while (pos < lines.length && lines[pos].indent === indent && !isItem(lines[pos].text)) {
+            const line = lines[pos];
+            const m = line.text.match(YAML_ENTRY);
+            if (!m) throw new ConfigError(line.n, `expected "key: value", got ${line.text}`);
+            pos++;
+            const value = m[2] === undefined || m[2] === '' ? nested(indent) : yamlScalar(m[2], line.n);
+            map[yamlKey(m[1], line.n)] = value;
+        }
+        if (pos < lines.length && lines[pos].indent > indent) throw new ConfigError(lines[pos].n, 'unexpected indentation');
+        return map;
+    };
+
+    if (lines.length === 0) return null;
+    const doc = block();
+    if (pos < lines.length) throw new ConfigError(lines[pos].n, 'unexpected indentation');
+    return doc;
+}
+
+// ── TOML ────────────────────────────────────────────────────────────────
+
+function tomlPlain(word, n) {
+    if (word === 'true') return true;
+    if (word === 'false') return false;
+    const num = word.replace(/_/g, '');
+    if (/^[-+]?(\d+|\d*\.\d+)([eE][-+]?\d+)?$/.test(num) || /^[-+]?\d+[eE][-+]?\d+$/.test(num)) return Number(num);
+    if (/^0x[0-9a-fA-F]+$/.test(num)) return parseInt(num, 16);
+    // Dates and times stay as written
+    if (/^\d{4}-\d{2}-\d{2}([T ][\d:.]+(Z|[-+]\d{2}:\d{2})?)?$|^\d{2}:\d{2}/.test(word)) return word;
+    throw new ConfigError(n, `bad value ${word}`);
+}
+
+/** Dotted key parts, quoted parts unquoted */
+function tomlKey(raw, n) {
+    const parts = [];
+    const re = /\s*("(?:[^"\\]|\\.)*"|'[^']*'|[A-Za-z0-9_-]+)\s*(\.|$)/y;
+    for (;;) {
+        const m = re.exec(raw);
+        if (!m) throw new ConfigError(n, `bad key ${raw.trim()}`);
+        const p = m[1];
+        parts.push(p.startsWith('"') ? doubleQuoted(p, n) : p.startsWith("'") ? p.slice(1, -1) : p);
+        if (m[2] === '') return parts;
+    }
+}
+
+/** The table at `keys` below `table`, created as needed (an array of tables gives its last) */
+function tomlTable(table, keys, n) {
+    let t = table;
+    for (const k of keys) {
+        if (t[k] === undefined) t[k] = {};
+        t = Array.isArray(t[k]) ? t[k][t[k].length - 1] : t[k];
+        if (!t || typeof t !== 'object') throw new ConfigError(n, `${keys.join('.')} is not a table`);
+    }
+    return t;
+}
+
+/**
+ * Parse the TOML subset config files use.
+ * @param {string} text - File content
+ * @returns {object}
+ * @throws {ConfigError} With the line of what it can't read
+ */
+function parseToml(text) {
+    const doc = {};
+    let table = doc;
+    for (const { n, text: line } of logicalLines(text)) {
+        if (line.startsWith('[[')) {
+            if (!line.endsWith(']]')) throw new ConfigError(n, `bad table header ${line}`);
+            const keys = tomlKey(line.slice(2, -2), n);
+            const parent = tomlTable(doc, keys.slice(0, -1), n);
+            const last = keys[keys.length - 1];
+            if (parent[last] === undefined) parent[last] = [];
+            if (!Array.isArray(parent[last])) throw new ConfigError(n, `${keys.join('.')} is not an array of tables`);
+            parent[last].push(table = {});
+        } else if (line.startsWith('[')) {
+            if (!line.endsWith(']')) throw new ConfigError(n, `bad table header ${line}`);
+            table = tomlTable(doc, tomlKey(line.slice(1, -1), n), n);
+        } else {
+            const eq = line.search(/=(?=(?:[^"']|"(?:[^"\\]|\\.)*"|'[^']*')*$)/);
+            if (eq < 0) throw new ConfigError(n, `expected "key = value", got ${line}`);
+            const value = line.slice(eq + 1).trim();
+            if (value.startsWith('"""') || value.startsWith("'''")) throw new ConfigError(n, 'multi-line strings are not supported');
+            const keys = tomlKey(line.slice(0, eq), n);
+            const parsed = flowParser(value, n, { plain: tomlPlain, separator: '=' }).parse();
+            tomlTable(table, keys.slice(0, -1), n)[keys[keys.length - 1]] = parsed;
+        }
+    }
+    return doc;
+}
+
+// ── Files and merging ───────────────────────────────────────────────────
+
+/**
+ * The config file of one directory, if any.
+ * @param {string} dir - Absolute directory
+ * @returns {{file: string, config: object, error?: string}|null} error when
+ *   the file can't be parsed; config is then {}
+ */
+function readConfig(dir) {
+    for (const name of CONFIG_FILES) {
+        const file = path.join(dir, name);
+        let text;
+        try { text = fs.readFileSync(file, 'utf-8'); } catch { continue; }
+        try {
+            const config = name.endsWith('.json') ? JSON.parse(text) : name.endsWith('.toml') ? parseToml(text) : parseYaml(text);
+            if (config !== null && (typeof config !== 'object' || Array.isArray(config))) {
+                return { file, config: {}, error: 'the top level must be a mapping' };
+            }
+            return { file, config: config || {} };
+        } catch (e) {
+            return { file, config: {}, error: e.message };
+        }
+    }
+    return null;
+}
+
+const isObject = v => v !== null && typeof v === 'object' && !Array.isArray(v);
+
+/** `over` merged onto `base`: objects key by key, anything else replaced */
+function mergeConfig(base, over) {
+    const out = { ...base };
+    for (const [key, value] of Object.entries(over)) {
+        out[key] = isObject(value) && isObject(out[key]) ? mergeConfig(out[key], value) : value;
+    }
+    return out;
+}
+
+/** Whether a path relative to a config's directory matches one of its globs */
+function globMatches(patterns, rel) {
+    const parts = rel.split('/');
+    for (const raw of [].concat(patterns)) {
+        if (typeof raw !== 'string' || raw === '') continue;
+        const pattern = raw.replace(/^\//, '').replace(/\/$/, '');
+        const re = globToRegex(pattern);
+        if (raw.includes('/')) {
+            // Anchored at the config's directory: the path, or a directory it is under
+            for (let i = parts.length; i > 0; i--) if (re.test(parts.slice(0, i).join('/'))) return true;
+        } else if (parts.some(part => re.test(part))) {
+            return true;
+        }
+    }
+    return false;
+}
+
+/** Whether a name pattern (a glob, `*` within a name) matches a symbol or one of its qualified names */
+function namePatternMatches(pattern, symbol, qualifiers) {
+    if (pattern === symbol.name) return true;
+    const re = /[*?{]/.test(pattern) ? globToRegex(pattern) : null;
+    const test = name => (re ? re.test(name) : name === pattern);
+    if (re && test(symbol.name)) return true;
+    // Qualified: Class.method, package.Func (Go), module.func (the file's name)
+    if (!pattern.includes('.')) return false;
+    return qualifiers().some(q => test(`${q}.${symbol.name}`));
+}
+
+/**
+ * Whether one entryPoints entry names a symbol, its file relative to the
+ * config's directory. facts: { qualifiers(), exported() }, computed when
+ * an entry asks; registry entries name call sites, not symbols.
+ */
+function entryPointMatches(entry, symbol, rel, facts = {}) {
+    const { name = null, file = null, package: pkg = null, exported = null, registry = null } =
+        typeof entry === 'string' ? { name: entry } : entry || {};
+    if (registry != null) return false;
+    if (name == null && file == null && pkg == null && exported == null) return false;
+    const qualifiers = facts.qualifiers || (() => (symbol.className ? [symbol.className] : []));
+    if (name != null && !namePatternMatches(String(name), symbol, qualifiers)) return false;
+    if (file != null && !globMatches(file, rel)) return false;
+    // package: the symbol's directory, not those below it (`pkg/**` for those)
+    if (pkg != null) {
+        const dir = path.posix.dirname(rel);
+        const patterns = Array.isArray(pkg) ? pkg : [pkg];
+        if (!patterns.some(p => globToRegex(String(p).replace(/^\//, '').replace(/\/$/, '') || '.').test(dir))) return false;
+    }
+    if (exported != null && !!facts.exported?.() !== !!exported) return false;
+    return true;
+}
+
+/** A registry entry's call pattern matches a call (`receiver.name`, or the bare name) */
+function registryMatches(pattern, call) {
+    const re = globToRegex(String(pattern));
+    return re.test(call.receiver ? `${call.receiver}.${call.name}` : call.name);
+}
+
+/**
+ * Per-directory config lookup under one root.
+ * @param {string} root - Project root
+ * @param {object} rootConfig - The root's config
+ * @returns {{configFor: Function, excluded: Function, isEntryPoint: Function, errors: string[]}}
+ *   configFor(relFile) gives a file's merged settings; excluded(relFile)
+ *   whether a directory's include/exclude leaves it out of the index;
+ *   isEntryPoint(symbol, facts) whether the nearest entryPoints list names
+ *   it; registries(relFile) the registry patterns applying to a file;
+ *   errors lists the config files that could not be read
+ */
+function configLookup(root, rootConfig) {
+    const dirs = new Map();
+    const merged = new Map();
+    const errors = [];
+
+    const at = (dir) => {
+        if (!dirs.has(dir)) {
+            const found = readConfig(path.join(root, dir));
+            if (found?.error) errors.push(`${path.posix.join(dir, path.basename(found.file))}: ${found.error}`);
+            dirs.set(dir, found && { dir, config: found.config });
+        }
+        return dirs.get(dir);
+    };
+
+    /** Configs applying to a directory, outermost first: the root's, then each below it */
+    const chain = (dir) => {
+        const out = [];
+        for (let d = dir; d !== '.' && d !== '' && d !== '/'; d = path.posix.dirname(d)) {
+            const found = at(d);
+            if (!found) continue;
+            out.unshift(found);
+            if (found.config.root === true) return out;
+        }
+        out.unshift({ dir: '.', config: rootConfig });
+        return out;
+    };
+
+    const dirOf = rel => path.posix.dirname(rel.replace(/\\/g, '/'));
+
+    return {
+        errors,
+        configFor(relFile) {
+            const dir = dirOf(relFile);
+            if (!merged.has(dir)) {
+                let config = {};
+                for (const c of chain(dir)) {
+                    const own = c.dir === '.' ? c.config
+                        : Object.fromEntries(Object.entries(c.config).filter(([k]) => DIRECTORY_KEYS.includes(k)));
+                    config = mergeConfig(config, own);
+                }
+                merged.set(dir, config);
+            }
+            return merged.get(dir);
+        },
+        excluded(relFile) {
+            const rel = relFile.replace(/\\/g, '/');
+            for (const c of chain(dirOf(rel))) {
+                const below = c.dir === '.' ? rel : path.posix.relative(c.dir, rel);
+                // The root's exclude already applies during discovery
+                if (c.dir !== '.' && c.config.exclude && globMatches(c.config.exclude, below)) return true;
+                if (c.config.include && !globMatches(c.config.include, below)) return true;
+            }
+            return false;
+        },
+        isEntryPoint(symbol, facts) {
+            const rel = symbol.relativePath.replace(/\\/g, '/');
+            // Lists are replaced, not merged: the nearest config with one decides
+            const c = chain(dirOf(rel)).reverse().find(d => Array.isArray(d.config.entryPoints));
+            if (!c) return false;
+            const below = c.dir === '.' ? rel : path.posix.relative(c.dir, rel);
+            return c.config.entryPoints.some(entry => entryPointMatches(entry, symbol, below, facts));
+        },
+        registries(relFile) {
+            const rel = relFile.replace(/\\/g, '/');
+            const c = chain(dirOf(rel)).reverse().find(d => Array.isArray(d.config.entryPoints));
+            if (!c) return [];
+            return c.config.entryPoints.filter(e => e && typeof e === 'object' && e.registry)
+                .flatMap(e => (Array.isArray(e.registry) ? e.registry : [e.registry]));
+        },
+    };
+}
+
+module.exports = {
+    CONFIG_FILES,
+    DIRECTORY_KEYS,
+    ConfigError,
+    parseYaml,
+    parseToml,
+    readConfig,
+    mergeConfig,
+    configLookup,
+    entryPointMatches,
+    registryMatches,
+};
@@ -6,11 +6,13 @@
  */
 
 const { detectLanguage, getParser, getLanguageModule, safeParse, langTraits } = require('../languages');
-const { dirname: pathDirname } = require('path');
-const { isTestFile } = require('./discovery');
-const { isFrameworkEntrypoint } = require('./entrypoints');
+const { readFileSync } = require('fs');
+const { dirname: pathDirname, join: pathJoin, relative: pathRelative } = require('path');
+const { isTestFile, expandGlob } = require('./discovery');
+const { computeLinks } = require('./links');
+const { isFrameworkEntrypoint, builtinEntryPoints } = require('./entrypoints');
 const { splitParentList } = require('./graph-build');
-const { isOverrideMarked, codeUnitCompare, lineInRanges, maskBlockComments } = require('./shared');
+const { isOverrideMarked, codeUnitCompare, lineInRanges, maskBlockComments, escapeRegExp, NON_CALLABLE_TYPES } = require('./shared');
 
 const _CLASS_KINDS = ['class', 'struct', 'interface', 'trait', 'record'];
 
@@ -18,7 +20,71 @@ const _CLASS_KINDS = ['class', 'struct', 'interface', 'trait', 'record'];
 // never reported: the audit surface had no class kinds). 'impl' stays out (an
 // impl block belongs to its struct — the struct claim covers it); 'type'
 // aliases and macros stay out (deferred — each is its own claim family).
-const CLASS_AUDIT_KINDS = ['class', 'struct', 'interface', 'trait', 'record', 'enum', 'namespace'];
+// SQL tables and views ('table', 'view') join as declarations whose only
+// liveness evidence is a reference from queries.
+const CLASS_AUDIT_KINDS = ['class', 'struct', 'interface', 'trait', 'record', 'enum', 'namespace',
+    'table', 'view'];
+
+// deadcode --types audits type declarations alone, type aliases and defined
+// types ('type': `type ID = string`, Go `type Celsius float64`) included.
+const TYPE_AUDIT_KINDS = ['class', 'struct', 'interface', 'trait', 'record', 'enum', 'type'];
+
+/**
+ * Plain declarations join the audit where the language opts in: constant
+ * state symbols (auditConstants trait — Zig comptime consts) and fields
+ * (auditFields trait — GraphQL schema fields, Go struct fields). Svelte stores
+ * (`writable()`/`readable()`/`derived()` state, any JS-family file) join
+ * everywhere. Their only liveness evidence is a reference to their name.
+ */
+function _isAuditedDeclaration(symbol, lang, config) {
+    if (symbol.type === 'field') return !!langTraits(lang)?.auditFields?.(symbol, config);
+    if (symbol.type === 'state' && (symbol.modifiers || []).includes('store')) return true;
+    return symbol.type === 'state' && !!symbol.isConst && !!langTraits(lang)?.auditConstants;
+}
+
+/**
+ * Fold dead constants of one `const ( ... )` block (constBlock, Go) into a
+ * single 'const block' finding when EVERY constant of the block is dead —
+ * the fix is deleting the block. A partly dead iota block keeps its members
+ * separate, marked `iota` (deleting one renumbers the values after it).
+ * @param {object} index - ProjectIndex
+ * @param {Array} results - deadcode results
+ * @param {Map} blockOf - result item → the constant's symbol
+ */
+function _groupConstBlocks(index, results, blockOf) {
+    const groups = new Map();
+    for (const item of results) {
+        const symbol = blockOf.get(item);
+        if (!symbol) continue;
+        const key = `${symbol.file}:${symbol.constBlock}`;
+        if (!groups.has(key)) groups.set(key, []);
+        groups.get(key).push(item);
+    }
+    if (groups.size === 0) return results;
+    const folded = new Set();
+    const blocks = [];
+    for (const items of groups.values()) {
+        const symbol = blockOf.get(items[0]);
+        const members = (index.files.get(symbol.file)?.symbols || []).filter(s =>
+            s.type === 'state' && s.constBlock === symbol.constBlock);
+        if (members.length !== items.length) continue;
+        items.sort((a, b) => a.startLine - b.startLine);
+        for (const item of items) folded.add(item);
+        blocks.push({
+            name: items[0].name,
+            type: 'const block',
+            file: items[0].file,
+            startLine: symbol.constBlock,
+            endLine: Math.max(...items.map(i => i.endLine)),
+            isExported: items.some(i => i.isExported),
+            usageCount: 0,
+            members: items.map(i => i.name),
+            ...(symbol.iota && { iota: true })
+        });
+    }
+    if (folded.size === 0) return results;
+    return [...results.filter(item => !folded.has(item)), ...blocks];
+}
 
 /** Strip a base-type expression to its bare name: `Mapping[str, int]`→Mapping, `java.util.List<Foo>`→List, `a::b::C`→C. */
 function _bareBaseName(raw) {
@@ -48,6 +114,7 @@ const _PY_NON_DISPATCHING_BASES = new Set(['Generic']);
 /** True when a base name resolves to NO in-project class/struct/interface/trait/record (an out-of-tree type). */
 function _baseIsExternal(index, bare, lang) {
     if (!bare || _UNIVERSAL_ROOTS.has(bare)) return false;
+    if (bare === langTraits(lang)?.universalSupertype) return false;
     if (lang === 'python' && _PY_NON_DISPATCHING_BASES.has(bare)) return false;
     const defs = index.symbols.get(bare);
     return !(defs && defs.some(d => _CLASS_KINDS.includes(d.type)));
@@ -134,9 +201,24 @@ function _classHasExternalBase(index, symbol) {
         (symbol.modifiers || []).includes('public');
     const classDefs = (index.symbols.get(symbol.className) || []).filter(c =>
         c.file === symbol.file && _CLASS_KINDS.includes(c.type));
+    // Header/implementation split (headerExtension trait — Objective-C): the
+    // superclass and adopted protocols are declared on `Foo.h`'s @interface
+    // while the methods live in `Foo.m`, so the companion header's class def
+    // carries the member's contract, implements clause included.
+    const header = _companionHeader(symbol.file, lang);
+    const headerDefs = header ? (index.symbols.get(symbol.className) || []).filter(c =>
+        c.file === header && _CLASS_KINDS.includes(c.type)) : [];
     return classDefs.some(cd => _heritageReachesExternalBase(index, cd, lang,
         contractSatisfiable &&
-        symbol.startLine >= cd.startLine && symbol.startLine <= cd.endLine));
+        symbol.startLine >= cd.startLine && symbol.startLine <= cd.endLine)) ||
+        headerDefs.some(cd => _heritageReachesExternalBase(index, cd, lang, contractSatisfiable));
+}
+
+/** `src/Foo.m` → `src/Foo.h`, `lib/foo.ml` → `lib/foo.mli` for languages that split declarations into headers */
+function _companionHeader(file, lang) {
+    const ext = langTraits(lang)?.headerExtension;
+    if (!ext || file.endsWith(ext)) return null;
+    return file.replace(/\.[^./]+$/, ext);
 }
 
 /**
@@ -183,8 +265,78 @@ const DEF_NAME_LINE_KINDS = new Set([
     'override', 'static get', 'static set', 'override get', 'override set',
     'static override', 'static override get', 'static override set',
     'class', 'struct', 'interface', 'trait', 'record', 'enum', 'namespace', 'impl',
+    'table', 'view',
 ]);
 
+/**
+ * Module directories nothing sources (directoryModules trait — Terraform):
+ * the module is the directory, not a symbol in it, so the claim is
+ * synthetic — type 'module', named by the directory, anchored at its first
+ * file. Only directories under a `modules/` segment are reusable modules;
+ * anything else is a root configuration, applied directly.
+ */
+function _unreferencedModuleDirs(index, options) {
+    const dirs = new Map();
+    for (const [filePath, fileEntry] of index.files) {
+        if (!langTraits(fileEntry.language)?.directoryModules) continue;
+        const rel = fileEntry.relativePath;
+        if (!/(^|\/)modules\//.test(rel)) continue;
+        const dir = pathDirname(rel);
+        if (!dirs.has(dir)) dirs.set(dir, []);
+        dirs.get(dir).push({ filePath, fileEntry });
+    }
+    const claims = [];
+    for (const [dir, entries] of dirs) {
+        if (entries.some(e => (index.exportGraph.get(e.filePath)?.size || 0) > 0)) continue;
+        entries.sort((a, b) => codeUnitCompare(a.fileEntry.relativePath, b.fileEntry.relativePath));
+        const { fileEntry } = entries[0];
+        if (!_inScope(index, fileEntry.relativePath, fileEntry.language, options)) continue;
+        claims.push({
+            name: dir,
+            type: 'module',
+            file: fileEntry.relativePath,
+            startLine: 1,
+            endLine: fileEntry.lines || 1,
+            isExported: false,
+            usageCount: 0,
+        });
+    }
+    return claims;
+}
+
+/**
+ * Component files nothing uses (componentFiles trait — Vue, Svelte): a component is
+ * consumed by file — imported, lazy-loaded by a route, or auto-registered
+ * and used by tag name (`<user-card>` is recorded as a call to UserCard).
+ * Neither an importer nor a call of its PascalCase name means the file is
+ * dead. The root App component and file-routed pages/, layouts/ and
+ * SvelteKit `+page`/`+layout` files are mounted by the framework.
+ */
+function _unreferencedComponentFiles(index, options) {
+    const claims = [];
+    for (const [filePath, fileEntry] of index.files) {
+        if (!langTraits(fileEntry.language)?.componentFiles) continue;
+        const rel = fileEntry.relativePath;
+        const base = rel.split('/').pop().replace(/\.[^.]+$/, '');
+        if (base === 'App' || base === 'app' || base === 'error' || base.startsWith('+') ||
+            /(^|\/)(pages|layouts)\//.test(rel)) continue;
+        if ((index.exportGraph.get(filePath)?.size || 0) > 0) continue;
+        const name = base.split(/[-_.]/).filter(Boolean).map(p => p[0].toUpperCase() + p.slice(1)).join('');
+        if (index.calleeIndex.has(name)) continue;
+        if (!_inScope(index, rel, fileEntry.language, options)) continue;
+        claims.push({
+            name,
+            type: 'component',
+            file: rel,
+            startLine: 1,
+            endLine: fileEntry.lines || 1,
+            isExported: false,
+            usageCount: 0,
+        });
+    }
+    return claims;
+}
+
 /**
  * Is EVERY call site of `name` inside the body of a same-name definition?
  * Recursion is not liveness (fix #253c): if no code outside defs of the name
@@ -238,7 +390,8 @@ function isInsideString(line, pos, language) {
     // usage keeps the symbol alive), never masking code (#253 rule).
     let interpDepth = 0;
     const jsTemplates = language === 'javascript' || language === 'typescript' ||
-        language === 'tsx' || language === 'html';
+        language === 'tsx' || language === 'html' || language === 'vue' ||
+        language === 'svelte';
     for (let j = 0; j < pos; j++) {
         const ch = line[j];
         if (ch === '\\') { j++; continue; }
@@ -264,223 +417,2163 @@ function isInsideString(line, pos, language) {
                 inSingle = !inSingle;
             }
         }
-        if (ch === '`' && !inDouble && !inSingle) inBacktick = !inBacktick;
+        if (ch === '`' && !inDouble && !inSingle) inBacktick = !inBacktick;
+    }
+    return inSingle || inDouble || (inBacktick && interpDepth === 0);
+}
+
+/**
+ * Build a usage index for identifiers in the codebase (optimized for deadcode)
+ * Scans all files ONCE and builds a reverse index: name -> [usages]
+ * @param {object} index - ProjectIndex instance
+ * @param {Set<string>} [filterNames] - If provided, only track these names (reduces memory)
+ * @returns {Map<string, Array>} Usage index
+ */
+function buildUsageIndex(index, filterNames) {
+    const usageIndex = new Map(); // name -> [{file, line}]
+
+    for (const [filePath, fileEntry] of index.files) {
+        try {
+            const language = detectLanguage(filePath);
+            if (!language) continue;
+
+            const content = index._readFile(filePath);
+
+            // Text pre-filter: skip files that don't contain any target names
+            // (avoids expensive tree-sitter parse + AST traversal for irrelevant files)
+            if (filterNames && filterNames.size > 0) {
+                let hasAny = false;
+                for (const name of filterNames) {
+                    if (content.includes(name)) {
+                        hasAny = true;
+                        break;
+                    }
+                }
+                if (!hasAny) continue;
+            }
+
+            // For HTML files, parse the virtual JS content instead of raw HTML
+            // (HTML tree-sitter sees script content as raw_text, not JS identifiers)
+            let tree;
+            if (language === 'html') {
+                const htmlModule = getLanguageModule('html');
+                const htmlParser = getParser('html');
+                const jsParser = getParser('javascript');
+                const blocks = htmlModule.extractScriptBlocks(content, htmlParser);
+                if (blocks.length === 0 && !htmlModule.extractEventHandlerCalls) continue;
+                if (blocks.length > 0) {
+                    const virtualJS = htmlModule.buildVirtualJSContent(content, blocks);
+                    tree = safeParse(jsParser, virtualJS);
+                }
+            } else if (language === 'vue' || language === 'svelte') {
+                // Script blocks parse as JS/TS; template references are added below
+                const script = getLanguageModule(language).extractScript(content, getParser(language));
+                if (script) tree = safeParse(script.jsParser, script.virtualJS);
+            } else {
+                const parser = getParser(language);
+                if (!parser) continue;
+                tree = safeParse(parser, content);
+            }
+
+            // Collect all identifiers from this file in one pass
+            const traverse = (node) => {
+                // Match all identifier-like nodes across languages
+                if (node.type === 'identifier' ||
+                    node.type === 'property_identifier' ||
+                    node.type === 'type_identifier' ||
+                    node.type === 'shorthand_property_identifier' ||
+                    node.type === 'shorthand_property_identifier_pattern' ||
+                    node.type === 'field_identifier') {
+                    // Skip property_identifier/field_identifier when they're:
+                    // 1. The property part of a member expression (e.g., obj.Separator)
+                    // 2. An object literal key (e.g., { Separator: value })
+                    // These are NOT references to standalone symbols.
+                    // Shorthand properties ({ Separator }) use shorthand_property_identifier instead.
+                    if ((node.type === 'property_identifier' || node.type === 'field_identifier') && node.parent) {
+                        const parentType = node.parent.type;
+                        // Object literal key: { Separator: 'value' } — not a reference
+                        if (parentType === 'pair' || parentType === 'key_value_pair' ||
+                            parentType === 'dictionary_entry' || parentType === 'field_declaration') {
+                            // Check if this is the key (first child) of the pair
+                            const firstChild = node.parent.child(0);
+                            if (firstChild === node) {
+                                for (let i = 0; i < node.childCount; i++) {
+                                    traverse(node.child(i));
+                                }
+                                return;
+                            }
+                        }
+                        // Member expression property: obj.Separator — not a standalone reference
+                        // EXCEPTION: If the selector/member expression is part of a call_expression,
+                        // this IS a method call (e.g., dc.syncDeployment()) and should count as usage.
+                        if (parentType === 'member_expression' ||
+                            parentType === 'field_expression' ||
+                            parentType === 'member_access_expression' ||
+                            parentType === 'selector_expression' ||       // Go
+                            parentType === 'field_access_expression' ||   // Rust
+                            parentType === 'scoped_identifier') {         // Rust
+                            // Check if this is the property (right side) of the member expression
+                            // by checking if it's NOT the object (left side)
+                            const firstChild = node.parent.child(0);
+                            if (firstChild !== node) {
+                                // Check if this member expression is part of a call or
+                                // used as a function reference (callback argument)
+                                const grandparent = node.parent.parent;
+                                const isCall = grandparent &&
+                                    (grandparent.type === 'call_expression' ||
+                                     grandparent.type === 'argument_list' ||
+                                     grandparent.type === 'arguments');
+                                if (!isCall) {
+                                    // Pure field access — skip for deadcode counting
+                                    for (let i = 0; i < node.childCount; i++) {
+                                        traverse(node.child(i));
+                                    }
+                                    return;
+                                }
+                                // Method call or callback — fall through to count as usage
+                            }
+                        }
+                    }
+                    const name = node.text;
+                    if (filterNames && !filterNames.has(name)) {
+                        for (let i = 0; i < node.childCount; i++) {
+                            traverse(node.child(i));
+                        }
+                        return;
+                    }
+                    if (!usageIndex.has(name)) {
+                        usageIndex.set(name, []);
+                    }
+                    usageIndex.get(name).push({
+                        file: filePath,
+                        line: node.startPosition.row + 1,
+                        relativePath: fileEntry.relativePath
+                    });
+                }
+                for (let i = 0; i < node.childCount; i++) {
+                    traverse(node.child(i));
+                }
+            };
+            if (tree) traverse(tree.rootNode);
+
+            // For HTML files, also extract identifiers from event handler attributes
+            // (onclick="foo()" etc. — these are in HTML, not in <script> blocks)
+            if (language === 'html') {
+                const htmlModule = getLanguageModule('html');
+                const htmlParser = getParser('html');
+                const handlerCalls = htmlModule.extractEventHandlerCalls(content, htmlParser);
+                for (const call of handlerCalls) {
+                    if (!usageIndex.has(call.name)) {
+                        usageIndex.set(call.name, []);
+                    }
+                    usageIndex.get(call.name).push({
+                        file: filePath,
+                        line: call.line,
+                        relativePath: fileEntry.relativePath
+                    });
+                }
+            }
+
+            // For Vue/Svelte files, template references (and Svelte `$store`
+            // subscriptions, which name the store with a `$` prefix)
+            if (language === 'vue' || language === 'svelte') {
+                const templateCalls = getLanguageModule(language).extractTemplateCalls(content, getParser(language));
+                for (const call of templateCalls) {
+                    if (filterNames && !filterNames.has(call.name)) continue;
+                    if (!usageIndex.has(call.name)) {
+                        usageIndex.set(call.name, []);
+                    }
+                    usageIndex.get(call.name).push({
+                        file: filePath,
+                        line: call.line,
+                        relativePath: fileEntry.relativePath
+                    });
+                }
+            }
+        } catch (e) {
+            // Skip files that can't be processed
+        }
+    }
+
+    return usageIndex;
+}
+
+/**
+ * Is a symbol part of the public/exported API surface?
+ *
+ * Beyond direct evidence (export list, export/public modifiers, Go
+ * capitalization), methods of an exported class count as exported in
+ * languages where class members are public by default (implicitlyPublicMembers
+ * trait — JS/TS/Python): they are reachable through the class from outside
+ * the project, so claiming them dead invites deleting public API (fix #211 —
+ * zod's `strictImplement` is called by zero project files but is documented
+ * public API). Private-by-shape members (#name, _name, `private` modifier)
+ * stay claimable.
+ */
+function symbolIsExported(index, symbol, fileEntry) {
+    if (!fileEntry) return false;
+    const name = symbol.name;
+    const mods = symbol.modifiers || [];
+    // An indexed companion interface (headerExtension trait — OCaml
+    // foo.mli) alone decides what its implementation exports
+    const header = _companionHeader(symbol.file, fileEntry.language);
+    const headerEntry = header && index.files.get(header);
+    if (headerEntry && headerEntry.exports.length > 0) {
+        return headerEntry.exports.includes(name) ||
+            (!!symbol.className && headerEntry.exports.includes(symbol.className));
+    }
+    if (fileEntry.exports.includes(name) || mods.includes('export') || mods.includes('public')) {
+        return true;
+    }
+    const traits = langTraits(fileEntry.language);
+    if (traits?.exportVisibility === 'capitalization') {
+        return /^[A-Z]/.test(name);
+    }
+    if (traits?.implicitlyPublicMembers && symbol.className &&
+        !mods.includes('private') && !name.startsWith('#') && !name.startsWith('_')) {
+        const classSyms = index.symbols.get(symbol.className) || [];
+        const cls = classSyms.find(c => c.file === symbol.file &&
+            (c.type === 'class' || c.type === 'interface'));
+        if (cls) {
+            const cmods = cls.modifiers || [];
+            if (fileEntry.exports.includes(symbol.className) ||
+                cmods.includes('export') || cmods.includes('public')) {
+                return true;
+            }
+        }
+    }
+    return false;
+}
+
+/**
+ * Whether a mode's file filters keep a file: test files only with
+ * --include-tests, then --file, --exclude and --in.
+ */
+function _inScope(index, rel, lang, options) {
+    if (!options.includeTests && isTestFile(rel, lang)) return false;
+    if (options.file && !rel.includes(options.file)) return false;
+    return !(((options.exclude && options.exclude.length > 0) || options.in) &&
+        !index.matchesFilters(rel, { exclude: options.exclude, in: options.in }));
+}
+
+/**
+ * The project files a per-file mode reads, each read and parsed once:
+ * `{ filePath, fileEntry, lang, picked, content, tree, enclosing }`, where
+ * enclosing(line, endLine = line) is the innermost function spanning the
+ * lines. Unreadable files are skipped, and so are files that don't parse
+ * when they were to be parsed.
+ * @param {object} index - ProjectIndex instance
+ * @param {object} [spec]
+ * @param {Function} [spec.pick] - (fileEntry, lang) → what the mode wants
+ *   of the file (a language pass, candidates), yielded as `picked`; falsy
+ *   skips the file before it is read
+ * @param {object} [spec.options] - The mode's options, whose file filters
+ *   (_inScope) apply; without them every file is seen
+ * @param {boolean|Function} [spec.parse=true] - Parse the files, or
+ *   (fileEntry, lang) → whether to parse one; `tree` is null otherwise
+ */
+function* _sourceFiles(index, { pick = () => true, options = null, parse = true } = {}) {
+    for (const [filePath, fileEntry] of index.files) {
+        const lang = fileEntry.language;
+        if (options && !_inScope(index, fileEntry.relativePath, lang, options)) continue;
+        const picked = pick(fileEntry, lang);
+        if (!picked) continue;
+        let content;
+        try { content = index._readFile(filePath); } catch { continue; }
+        let tree = null;
+        if (typeof parse === 'function' ? parse(fileEntry, lang) : parse) {
+            tree = index._getParsedTree(filePath, content, lang) || safeParse(getParser(lang), content);
+            if (!tree) continue;
+        }
+        let functions = null;
+        const enclosing = (line, endLine = line) => {
+            if (!functions) functions = fileEntry.symbols.filter(s => !NON_CALLABLE_TYPES.has(s.type) && !_CLASS_KINDS.includes(s.type));
+            return functions
+                .filter(s => s.startLine <= line && endLine <= s.endLine)
+                .sort((a, b) => (a.endLine - a.startLine) - (b.endLine - b.startLine))[0];
+        };
+        yield { filePath, fileEntry, lang, picked, content, tree, enclosing };
+    }
+}
+
+/**
+ * A mode's results in file and line order, with the excluded* counts
+ * deadcode's summary reads.
+ */
+function _modeResults(results, excludedExported = 0) {
+    results.sort((a, b) => codeUnitCompare(a.file, b.file) || a.startLine - b.startLine);
+    results.excludedDecorated = 0;
+    results.excludedExported = excludedExported;
+    results.excludedExternalContract = 0;
+    return results;
+}
+
+/**
+ * Interface methods no call site invokes THROUGH the interface
+ * (deadcode --interface-methods). Implementations may be called directly
+ * on their concrete types — the method is still dead weight on the
+ * interface, which could shrink without changing a caller.
+ *
+ * A call reaches the interface when its receiver's type is the interface
+ * or an interface that embeds/extends it. Receivers of unknown type
+ * (untyped locals, call results, generic parameters, external types) are
+ * never evidence against the interface: they keep the method.
+ */
+function unusedInterfaceMethods(index, options = {}) {
+    const { getCachedCalls } = require('./callers');
+    if (index.loadCallsCache) index.loadCallsCache();
+    const results = [];
+    let excludedExported = 0;
+
+    const interfaceDefs = new Map();
+    for (const [, defs] of index.symbols) {
+        for (const d of defs) {
+            if (d.type === 'interface') {
+                if (!interfaceDefs.has(d.name)) interfaceDefs.set(d.name, []);
+                interfaceDefs.get(d.name).push(d);
+            }
+        }
+    }
+
+    // Direct parents: Go embedded interfaces, Java/TS `extends`
+    const parentsOf = (def) => {
+        const parents = def.extends
+            ? (Array.isArray(def.extends) ? def.extends : splitParentList(def.extends)).map(_bareBaseName)
+            : [];
+        for (const m of index.files.get(def.file)?.symbols || []) {
+            if (m.className === def.name && m.type === 'field' && m.embedded) parents.push(_bareBaseName(m.fieldType || m.name));
+        }
+        return parents.filter(Boolean);
+    };
+    // Interfaces whose values can stand in for `name` (itself + descendants)
+    const viaCache = new Map();
+    const interfacesVia = (name) => {
+        if (viaCache.has(name)) return viaCache.get(name);
+        const via = new Set([name]);
+        for (let grew = true, depth = 0; grew && depth < _HERITAGE_WALK_DEPTH; depth++) {
+            grew = false;
+            for (const [other, defs] of interfaceDefs) {
+                if (via.has(other)) continue;
+                if (defs.some(d => parentsOf(d).some(p => via.has(p)))) { via.add(other); grew = true; }
+            }
+        }
+        viaCache.set(name, via);
+        return via;
+    };
+
+    // Receiver type of a call: declared local/param type, or the declared
+    // type of a one-hop field receiver (`tm.service.Save()`)
+    const receiverTypeOf = (call) => {
+        let raw = call.receiverType;
+        if (!raw && call.receiverField && call.receiverRootType) {
+            const field = (index.symbols.get(call.receiverField) || []).find(f =>
+                f.type === 'field' && f.className === call.receiverRootType && f.fieldType);
+            raw = field?.fieldType;
+        }
+        if (!raw) return null;
+        return _bareBaseName(String(raw).replace(/^[*&\s]+/, ''));
+    };
+    const isProjectConcreteType = (name) => (index.symbols.get(name) || []).some(d =>
+        d.type !== 'interface' && _CLASS_KINDS.includes(d.type));
+
+    // Calls by method name, collected once
+    const interfaceMethodNames = new Set();
+    for (const [, defs] of interfaceDefs) {
+        for (const def of defs) {
+            for (const m of index.files.get(def.file)?.symbols || []) {
+                if (m.className === def.name && !NON_CALLABLE_TYPES.has(m.type)) interfaceMethodNames.add(m.name);
+            }
+        }
+    }
+    const callsByName = new Map();
+    for (const [filePath, fileEntry] of index.files) {
+        const calls = getCachedCalls(index, filePath) || [];
+        for (const call of calls) {
+            if (!interfaceMethodNames.has(call.name)) continue;
+            if (!callsByName.has(call.name)) callsByName.set(call.name, []);
+            callsByName.get(call.name).push({ call, language: fileEntry.language });
+        }
+    }
+
+    for (const [ifaceName, defs] of interfaceDefs) {
+        for (const def of defs) {
+            const fileEntry = index.files.get(def.file);
+            if (!_inScope(index, def.relativePath, fileEntry?.language, options)) continue;
+
+            const via = interfacesVia(ifaceName);
+            for (const method of fileEntry.symbols) {
+                if (method.className !== ifaceName || NON_CALLABLE_TYPES.has(method.type)) continue;
+                const sites = callsByName.get(method.name) || [];
+                const reachesInterface = sites.some(({ call, language }) => {
+                    if (!call.isMethod) return !!langTraits(language)?.bareCallReachesMethods;
+                    const type = receiverTypeOf(call);
+                    if (!type) return true;
+                    if (via.has(type)) return true;
+                    // Another project interface or a project class/struct:
+                    // the call is typed and does not go through this one
+                    return !(interfaceDefs.has(type) || isProjectConcreteType(type));
+                });
+                if (reachesInterface) continue;
+
+                const isExported = symbolIsExported(index, def, fileEntry) || symbolIsExported(index, method, fileEntry);
+                if (isExported && !options.includeExported) {
+                    excludedExported++;
+                    continue;
+                }
+                const implementedBy = [...new Set((index.symbols.get(method.name) || [])
+                    .filter(d => d.className && d.className !== ifaceName && isProjectConcreteType(d.className))
+                    .map(d => d.className))].sort(codeUnitCompare);
+                results.push({
+                    name: method.name,
+                    type: method.type,
+                    file: method.relativePath,
+                    startLine: method.startLine,
+                    endLine: method.endLine,
+                    className: ifaceName,
+                    isExported,
+                    usageCount: sites.length,
+                    notCalledThrough: ifaceName,
+                    implementedBy,
+                });
+            }
+        }
+    }
+
+    return _modeResults(results, excludedExported);
+}
+
+/**
+ * Find parameters never read inside their function body (deadcode
+ * --unused-params). A parameter is used when its name occurs in the
+ * function's range past its own declaration — shadowing and same-name
+ * fields only ever keep it. `_`-prefixed names are the languages' own
+ * "intentionally unused" spelling and are skipped, as are receivers
+ * (selfParam trait), body-less declarations and functions that read
+ * `arguments`.
+ *
+ * Some signatures are not the function's to change: overrides, methods a
+ * parent type declares, and — where interfaces are satisfied implicitly
+ * (structural typing, Go) — methods any project interface declares. Those parameters are reported with
+ * `signatureFixedBy` — the fix is renaming to `_`, not removing.
+ */
+function unusedParameters(index, options = {}) {
+    const results = [];
+    let excludedExported = 0;
+
+    // Method names each project interface/trait declares
+    const declaredBy = new Map();
+    for (const [, defs] of index.symbols) {
+        for (const d of defs) {
+            if (!d.className || NON_CALLABLE_TYPES.has(d.type)) continue;
+            const owner = (index.symbols.get(d.className) || []).find(c =>
+                c.file === d.file && _CLASS_KINDS.includes(c.type));
+            if (!owner) continue;
+            if (!declaredBy.has(d.name)) declaredBy.set(d.name, []);
+            declaredBy.get(d.name).push(owner);
+        }
+    }
+    const parentsOf = (def) => [
+        ...(def.extends ? (Array.isArray(def.extends) ? def.extends : splitParentList(def.extends)) : []),
+        ...(Array.isArray(def.implements) ? def.implements : []),
+    ].map(_bareBaseName).filter(Boolean);
+
+    const signatureFixedBy = (fn, lang) => {
+        if (isOverrideMarked(fn)) return fn.traitImpl ? `trait ${fn.traitImpl}` : 'an overridden base method';
+        if (!fn.className) return null;
+        if (_classHasExternalBase(index, fn)) return 'an out-of-tree base';
+        const owners = (declaredBy.get(fn.name) || []).filter(o => o.name !== fn.className);
+        if (owners.length === 0) return null;
+        // Interfaces satisfied without a declaration: structural typing, Go
+        if (langTraits(lang)?.typeSystem === 'structural' || lang === 'go') {
+            const iface = owners.find(o => o.type === 'interface');
+            if (iface) return iface.name;
+        }
+        // Nominal: only the class's own heritage constrains it
+        const seen = new Set();
+        let frontier = (index.symbols.get(fn.className) || []).filter(c => _CLASS_KINDS.includes(c.type));
+        for (let depth = 0; depth < _HERITAGE_WALK_DEPTH && frontier.length; depth++) {
+            const next = [];
+            for (const def of frontier) {
+                for (const parent of parentsOf(def)) {
+                    if (seen.has(parent)) continue;
+                    seen.add(parent);
+                    if (owners.some(o => o.name === parent)) return parent;
+                    next.push(...(index.symbols.get(parent) || []).filter(c => _CLASS_KINDS.includes(c.type)));
+                }
+            }
+            frontier = next;
+        }
+        return null;
+    };
+
+    // Functions of a file with parameters to check
+    const candidatesOf = (fileEntry, lang) => {
+        if (!langTraits(lang)?.auditParams || fileEntry.relativePath.endsWith('.d.ts')) return null;
+        const selfParams = langTraits(lang).selfParam || [];
+        const candidates = [];
+        for (const fn of fileEntry.symbols) {
+            if (NON_CALLABLE_TYPES.has(fn.type) || _CLASS_KINDS.includes(fn.type)) continue;
+            if (!Array.isArray(fn.paramsStructured) || fn.isFunctionVariable) continue;
+            if ((fn.modifiers || []).some(m => m === 'abstract' || m === 'declare')) continue;
+            const params = fn.paramsStructured.filter(p => p && typeof p.name === 'string' &&
+                /^[A-Za-z$][\w$]*$/.test(p.name) && !selfParams.includes(p.name));
+            if (params.length > 0) candidates.push({ fn, params });
+        }
+        return candidates.length > 0 ? candidates : null;
+    };
+
+    for (const { fileEntry, lang, picked: candidates, content, tree } of _sourceFiles(index, { pick: candidatesOf, options })) {
+        const langModule = getLanguageModule(lang);
+        const lines = content.split('\n');
+        const usagesOf = new Map();
+
+        for (const { fn, params } of candidates) {
+            const text = lines.slice(fn.startLine - 1, fn.endLine).join('\n');
+            // Declarations without a body: interface members, overload
+            // signatures, Go functions implemented in assembly
+            if (/;\s*$/.test(text) || (lang === 'go' && !text.includes('{'))) continue;
+            if (/\barguments\b/.test(text) && ['javascript', 'typescript', 'tsx'].includes(lang)) continue;
+            if (fn.className && (index.symbols.get(fn.className) || []).some(c =>
+                c.file === fn.file && (c.type === 'interface' || c.type === 'trait'))) continue;
+
+            const unused = params.filter(p => {
+                if (!usagesOf.has(p.name)) {
+                    usagesOf.set(p.name, langModule.findUsagesInCode(content, p.name, getParser(lang), tree));
+                }
+                // The first occurrence in the range is the declaration itself
+                const inRange = usagesOf.get(p.name).filter(u => u.line >= fn.startLine && u.line <= fn.endLine);
+                return inRange.length <= 1;
+            });
+            if (unused.length === 0) continue;
+
+            const isExported = symbolIsExported(index, fn, fileEntry);
+            if (isExported && !options.includeExported) {
+                excludedExported += unused.length;
+                continue;
+            }
+            const fixedBy = signatureFixedBy(fn, lang);
+            for (const p of unused) {
+                results.push({
+                    name: p.name,
+                    type: 'parameter',
+                    file: fileEntry.relativePath,
+                    startLine: fn.startLine,
+                    endLine: fn.endLine,
+                    ...(fn.className && { className: fn.className }),
+                    functionName: fn.name,
+                    isExported,
+                    usageCount: 0,
+                    ...(fixedBy && { signatureFixedBy: fixedBy }),
+                });
+            }
+        }
+    }
+
+    return _modeResults(results, excludedExported);
+}
+
+/**
+ * Type parameters nothing mentions (deadcode --type-params), from the
+ * language's typeParams pass. A generic function's parameter must appear
+ * in its signature, body or another parameter's constraint; a generic
+ * type's, in the type itself or in some method of the package through the
+ * name its receiver binds at that position. Dropping one changes every
+ * instantiation, so exported owners need --include-exported.
+ */
+function unusedTypeParams(index, options = {}) {
+    const results = [];
+    let excludedExported = 0;
+    const perFile = [];
+    const receiverUsed = new Set(); // dir \0 type \0 position
+    const typeParamsPass = (fileEntry, lang) => langTraits(lang)?.typeParams;
+    for (const { filePath, fileEntry, picked: pass, tree } of _sourceFiles(index, { pick: typeParamsPass })) {
+        const { declared, receiverUses } = pass(tree);
+        const dir = pathDirname(filePath);
+        for (const r of receiverUses) if (r.used) receiverUsed.add(`${dir}\0${r.typeName}\0${r.position}`);
+        if (declared.some(d => !d.used)) perFile.push({ filePath, fileEntry, dir, declared });
+    }
+
+    for (const { fileEntry, dir, declared } of perFile) {
+        const rel = fileEntry.relativePath;
+        if (!_inScope(index, rel, fileEntry.language, options)) continue;
+        for (const p of declared) {
+            if (p.used) continue;
+            if (p.ownerKind === 'type' && receiverUsed.has(`${dir}\0${p.owner}\0${p.position}`)) continue;
+            const owner = fileEntry.symbols.find(s => s.name === p.owner && s.startLine <= p.line && p.line <= s.endLine);
+            const isExported = owner ? symbolIsExported(index, owner, fileEntry) : /^[A-Z]/.test(p.owner);
+            if (isExported && !options.includeExported) {
+                excludedExported++;
+                continue;
+            }
+            results.push({
+                name: p.name,
+                type: 'type parameter',
+                file: rel,
+                startLine: p.line,
+                endLine: p.endLine,
+                functionName: p.owner,
+                isExported,
+                usageCount: 0,
+                ...(p.ownerKind === 'type' && { typeParamOf: 'type' }),
+            });
+        }
+    }
+
+    return _modeResults(results, excludedExported);
+}
+
+/**
+ * Statements no execution reaches inside function bodies (deadcode
+ * --unreachable-code), from the language's control-flow pass
+ * (unreachableCode trait). Each finding is attributed to its innermost
+ * enclosing function. With `.ucn.json` "featureFlags" set, checks of
+ * permanently on/off flags are constant conditions too, and functions
+ * called only from the branches they kill are reported with them.
+ */
+function unreachableCode(index, options = {}) {
+    const results = [];
+    const flags = options.featureFlags || index.config?.featureFlags || null;
+    const flagRanges = new Map();
+    const unreachablePass = (fileEntry, lang) => langTraits(lang)?.unreachableCode;
+    for (const { filePath, fileEntry, picked: pass, tree, enclosing } of _sourceFiles(index, { pick: unreachablePass, options })) {
+        for (const finding of pass(tree, flags)) {
+            if (finding.flag) {
+                if (!flagRanges.has(filePath)) flagRanges.set(filePath, []);
+                flagRanges.get(filePath).push([finding.line, finding.endLine]);
+            }
+            const fn = enclosing(finding.line, finding.endLine);
+            results.push({
+                name: fn ? fn.name : '(top level)',
+                type: 'unreachable',
+                file: fileEntry.relativePath,
+                startLine: finding.line,
+                endLine: finding.endLine,
+                ...(fn?.className && { className: fn.className }),
+                isExported: false,
+                usageCount: 0,
+                reason: finding.reason,
+            });
+        }
+    }
+    if (flagRanges.size > 0) results.push(...flagOnlyFunctions(index, flagRanges, options));
+
+    return _modeResults(results);
+}
+
+/**
+ * Functions whose every call site sits in a dead feature-flag branch, or
+ * in another function only such branches call — they run only if a
+ * retired flag flips. Exported functions may have callers outside the
+ * project and need --include-exported.
+ */
+function flagOnlyFunctions(index, flagRanges, options) {
+    const { getCachedCalls } = require('./callers');
+    const deadRanges = new Map([...flagRanges].map(([f, ranges]) => [f, [...ranges]]));
+    const inDead = (filePath, line) => (deadRanges.get(filePath) || []).some(([s, e]) => line >= s && line <= e);
+    const callsTo = (call, name) => call.name === name || call.resolvedName === name ||
+        (call.resolvedNames && call.resolvedNames.includes(name));
+
+    const results = [];
+    const reported = new Set();
+    const checked = new Set();
+    let changed = true;
+    while (changed) {
+        changed = false;
+        const candidates = new Set();
+        for (const [filePath] of deadRanges) {
+            for (const call of getCachedCalls(index, filePath) || []) {
+                if (inDead(filePath, call.line) && !checked.has(call.name)) candidates.add(call.name);
+            }
+        }
+        for (const name of candidates) {
+            const defs = (index.symbols.get(name) || [])
+                .filter(d => !NON_CALLABLE_TYPES.has(d.type) && !_CLASS_KINDS.includes(d.type));
+            if (defs.length === 0) { checked.add(name); continue; }
+            // A call inside the function's own body (recursion) keeps nothing alive
+            const ownBody = (f, line) => defs.some(d => d.file === f && line >= d.startLine && line <= d.endLine);
+            let sites = 0;
+            let live = false;
+            for (const f of index.calleeIndex?.get(name) || []) {
+                for (const call of getCachedCalls(index, f) || []) {
+                    if (!callsTo(call, name)) continue;
+                    sites++;
+                    if (!inDead(f, call.line) && !ownBody(f, call.line)) { live = true; break; }
+                }
+                if (live) break;
+            }
+            // Not settled yet: a later round may kill the remaining callers
+            if (live) continue;
+            checked.add(name);
+
+            for (const def of defs) {
+                const key = `${def.file}:${def.startLine}`;
+                if (reported.has(key)) continue;
+                const fileEntry = index.files.get(def.file);
+                if (!fileEntry) continue;
+                const isExported = symbolIsExported(index, def, fileEntry);
+                if (isExported && !options.includeExported) continue;
+                reported.add(key);
+                if (!deadRanges.has(def.file)) deadRanges.set(def.file, []);
+                deadRanges.get(def.file).push([def.startLine, def.endLine]);
+                changed = true;
+
+                const rel = fileEntry.relativePath;
+                if (!_inScope(index, rel, fileEntry.language, options)) continue;
+                results.push({
+                    name: def.name,
+                    type: def.type,
+                    file: rel,
+                    startLine: def.startLine,
+                    endLine: def.endLine,
+                    ...(def.className && { className: def.className }),
+                    isExported,
+                    usageCount: sites,
+                    reason: 'called only from dead feature-flag branches',
+                });
+            }
+        }
+    }
+    return results;
+}
+
+// Method names standard-library interfaces call without the project
+// naming them (fmt, encoding/*, io, sort, database/sql, net/http)
+const _IMPLICIT_INTERFACE_METHODS = new Set(['String', 'GoString', 'Error', 'Unwrap', 'Format',
+    'MarshalJSON', 'UnmarshalJSON', 'MarshalText', 'UnmarshalText', 'MarshalYAML', 'UnmarshalYAML',
+    'MarshalBinary', 'UnmarshalBinary', 'Read', 'Write', 'Close', 'Len', 'Less', 'Swap', 'Scan', 'Value',
+    'ServeHTTP']);
+
+/**
+ * Interfaces whose only mentions outside their declaration are
+ * compile-time assertions (interfaceAssertion trait), each with its
+ * assertions `{file, line, type}`, and the method names interfaces that do
+ * have values still declare.
+ */
+function _assertionOnlyCandidates(index) {
+    const sources = [];
+    const asserting = (fileEntry, lang) => langTraits(lang)?.interfaceAssertion;
+    for (const { filePath, fileEntry, content } of _sourceFiles(index, { pick: asserting, parse: false })) {
+        sources.push({ filePath, fileEntry, dir: pathDirname(filePath),
+            lines: content.split('\n').map(l => l.replace(/\/\/.*$/, '')) });
+    }
+    const interfaces = sources.flatMap(src => src.fileEntry.symbols
+        .filter(d => d.type === 'interface').map(def => ({ def, src })));
+
+    const candidates = [];
+    for (const { def, src } of interfaces) {
+        const assertion = langTraits(src.fileEntry.language).interfaceAssertion;
+        const exported = /^[A-Z]/.test(def.name);
+        const word = new RegExp(`\\b${escapeRegExp(def.name)}\\b`);
+        const qualified = new RegExp(`\\.${escapeRegExp(def.name)}\\b`);
+        const assertions = [];
+        let used = false;
+        for (const other of sources) {
+            if (other.fileEntry.language !== src.fileEntry.language) continue;
+            const re = other.dir === src.dir ? word : exported ? qualified : null;
+            if (!re) continue;
+            for (let i = 0; i < other.lines.length && !used; i++) {
+                if (other.filePath === def.file && i + 1 >= def.startLine && i + 1 <= def.endLine) continue;
+                if (!re.test(other.lines[i])) continue;
+                const type = assertion(other.lines[i], def.name);
+                if (type === null) used = true;
+                else assertions.push({ file: other.fileEntry.relativePath, line: i + 1, type });
+            }
+            if (used) break;
+        }
+        if (!used && assertions.length > 0) candidates.push({ def, src, assertions });
+    }
+
+    // Method names some interface with values still declares
+    const candidateKeys = new Set(candidates.map(c => `${c.def.file}:${c.def.startLine}`));
+    const liveMethodNames = new Set();
+    for (const { def, src } of interfaces) {
+        if (candidateKeys.has(`${def.file}:${def.startLine}`)) continue;
+        for (const m of src.fileEntry.symbols) if (m.className === def.name) liveMethodNames.add(m.name);
+    }
+    return { candidates, liveMethodNames };
+}
+
+/**
+ * Interfaces no value ever has (deadcode --satisfies-only): every mention
+ * outside the declaration is a compile-time assertion (interfaceAssertion
+ * trait — Go `var _ Repository = (*pgRepo)(nil)`). The interface is
+ * reported with each method of an asserted type that exists only to
+ * satisfy it: nothing calls it, no other project interface declares it,
+ * and it is not one the standard library calls implicitly. Interface and
+ * methods can go together.
+ */
+function assertionOnlyInterfaces(index, options = {}) {
+    const results = [];
+    let excludedExported = 0;
+    index._beginOp();
+    try {
+        const { candidates, liveMethodNames } = _assertionOnlyCandidates(index);
+        for (const { def, src, assertions } of candidates) {
+            const rel = src.fileEntry.relativePath;
+            if (!_inScope(index, rel, src.fileEntry.language, options)) continue;
+            const isExported = symbolIsExported(index, def, src.fileEntry);
+            if (isExported && !options.includeExported) {
+                excludedExported++;
+                continue;
+            }
+            const assertedTypes = [...new Set(assertions.map(a => a.type).filter(Boolean))].sort(codeUnitCompare);
+            results.push({
+                name: def.name,
+                type: def.type,
+                file: rel,
+                startLine: def.startLine,
+                endLine: def.endLine,
+                isExported,
+                usageCount: assertions.length,
+                assertionOnly: true,
+                assertedBy: assertedTypes,
+            });
+
+            const methodNames = src.fileEntry.symbols
+                .filter(m => m.className === def.name && !NON_CALLABLE_TYPES.has(m.type)).map(m => m.name);
+            for (const name of methodNames) {
+                if (liveMethodNames.has(name) || _IMPLICIT_INTERFACE_METHODS.has(name)) continue;
+                for (const method of index.symbols.get(name) || []) {
+                    const owner = method.className || String(method.receiver || '').replace(/^\*/, '').replace(/\[.*$/, '');
+                    if (method.type !== 'method' || !assertedTypes.includes(owner)) continue;
+                    const callers = index.findCallers(name, { includeMethods: true, targetDefinitions: [method] })
+                        .filter(c => !(c.file === method.file && c.line >= method.startLine && c.line <= method.endLine));
+                    if (callers.length > 0) continue;
+                    const methodFile = index.files.get(method.file);
+                    results.push({
+                        name,
+                        type: method.type,
+                        file: method.relativePath,
+                        startLine: method.startLine,
+                        endLine: method.endLine,
+                        className: owner,
+                        isExported: symbolIsExported(index, method, methodFile),
+                        usageCount: 0,
+                        satisfiesOnly: def.name,
+                    });
+                }
+            }
+        }
+    } finally { index._endOp(); }
+
+    return _modeResults(results, excludedExported);
+}
+
+/**
+ * Compile-time interface assertions that guard nothing (deadcode
+ * --redundant-assertions): `var _ Repository = (*DataService)(nil)` where
+ * Repository is never used anywhere else. Low severity — the assertion is
+ * harmless, it just keeps an otherwise unused interface looking alive.
+ */
+function redundantAssertions(index, options = {}) {
+    const results = [];
+    let excludedExported = 0;
+    index._beginOp();
+    try {
+        const { candidates } = _assertionOnlyCandidates(index);
+        for (const { def, src, assertions } of candidates) {
+            // Another package may hold values of an exported interface
+            if (symbolIsExported(index, def, src.fileEntry) && !options.includeExported) {
+                excludedExported += assertions.length;
+                continue;
+            }
+            for (const a of assertions) {
+                if (!_inScope(index, a.file, src.fileEntry.language, options)) continue;
+                results.push({
+                    name: def.name,
+                    type: 'assertion',
+                    file: a.file,
+                    startLine: a.line,
+                    endLine: a.line,
+                    isExported: false,
+                    usageCount: 0,
+                    severity: 'low',
+                    ...(a.type && { assertedType: a.type }),
+                });
+            }
+        }
+    } finally { index._endOp(); }
+
+    return _modeResults(results, excludedExported);
+}
+
+/**
+ * Channels and goroutines that go nowhere (deadcode --channels), from the
+ * language's channelFlows pass. A local channel that never escapes the
+ * function is reported when nothing receives from it (sends block or pile
+ * up unseen) or nothing sends to or closes it (receives block forever).
+ * A `go` statement calling a project function whose every definition
+ * returns values is reported too: the results are dropped.
+ */
+function channelIssues(index, options = {}) {
+    const results = [];
+    const channelPass = (fileEntry, lang) => langTraits(lang)?.channelFlows;
+    for (const { fileEntry, lang, picked: pass, tree, enclosing } of _sourceFiles(index, { pick: channelPass, options })) {
+        const { channels, goroutines } = pass(tree);
+
+        for (const ch of channels) {
+            if (ch.escapes > 0) continue;
+            const issue = ch.receives === 0 && ch.sends + ch.closes === 0 ? 'never used'
+                : ch.receives === 0 ? 'never received from'
+                : ch.sends + ch.closes === 0 ? 'never sent to or closed'
+                : null;
+            if (!issue) continue;
+            const fn = enclosing(ch.line);
+            results.push({
+                name: ch.name,
+                type: 'channel',
+                file: fileEntry.relativePath,
+                startLine: ch.line,
+                endLine: ch.line,
+                ...(fn && { functionName: fn.name }),
+                ...(fn?.className && { className: fn.className }),
+                isExported: false,
+                usageCount: ch.sends + ch.receives + ch.closes,
+                channelIssue: issue,
+            });
+        }
+        for (const g of goroutines) {
+            const defs = (index.symbols.get(g.name) || []).filter(d =>
+                index.files.get(d.file)?.language === lang &&
+                (d.type === 'function' || (g.isMethod && d.type === 'method')));
+            if (defs.length === 0 || !defs.every(d => d.returnType)) continue;
+            const fn = enclosing(g.line);
+            results.push({
+                name: g.name,
+                type: 'goroutine',
+                file: fileEntry.relativePath,
+                startLine: g.line,
+                endLine: g.line,
+                ...(fn && { functionName: fn.name }),
+                ...(fn?.className && { className: fn.className }),
+                isExported: false,
+                usageCount: 0,
+                discardedResults: defs[0].returnType,
+            });
+        }
+    }
+
+    return _modeResults(results);
+}
+
+/**
+ * Configuration knobs nothing reads (deadcode --config-knobs), from the
+ * language's configKnobs pass: flags, env variables and viper keys. A knob
+ * stored in a variable is alive when the variable is named again outside
+ * the registering line (its package; `pkg.Name` elsewhere when exported);
+ * one stored in a field, when `.field` is named again anywhere. A knob
+ * kept only by name is alive when some lookup passes that name
+ * (GetString("port"), viper.GetBool("debug")); viper.Unmarshal reads
+ * every viper key.
+ */
+function unusedConfigKnobs(index, options = {}) {
+    const results = [];
+    const parsed = [];
+    const lookedUp = new Set();
+    let unmarshal = false;
+    const knobsPass = (fileEntry, lang) => langTraits(lang)?.configKnobs;
+    for (const { filePath, fileEntry, picked: pass, content, tree } of _sourceFiles(index, { pick: knobsPass })) {
+        const found = pass(tree);
+        for (const l of found.lookups) lookedUp.add(l.key);
+        if (found.unmarshal) unmarshal = true;
+        const lines = content.split('\n').map(l => l.replace(/\/\/.*$/, ''));
+        parsed.push({ filePath, fileEntry, knobs: found.knobs, lines, dir: pathDirname(filePath) });
+    }
+
+    // Lines naming `re`, a knob's own registering line aside
+    const namedElsewhere = (re, files, knobFile, knobLine) => files.some(f =>
+        f.lines.some((line, i) => !(f.filePath === knobFile && i + 1 === knobLine) && re.test(line)));
+
+    for (const { filePath, fileEntry, knobs } of parsed) {
+        const rel = fileEntry.relativePath;
+        if (!_inScope(index, rel, fileEntry.language, options)) continue;
+        const dir = pathDirname(filePath);
+        const pkgFiles = parsed.filter(f => f.dir === dir);
+
+        for (const knob of knobs) {
+            if (lookedUp.has(knob.key) || (knob.kind === 'viper' && unmarshal)) continue;
+            if (knob.target) {
+                const parts = knob.target.split('.');
+                const last = parts[parts.length - 1];
+                let read;
+                if (parts.length > 1) {
+                    read = namedElsewhere(new RegExp(`\\.${escapeRegExp(last)}\\b`), parsed, filePath, knob.line);
+                } else {
+                    // `var port int` and `port = ...` name it without reading it
+                    const name = escapeRegExp(last);
+                    const declOrStore = new RegExp(`^\\s*(var\\s+)?${name}\\s+[\\w.*\\[\\]]+\\s*$|^\\s*${name}\\s*=[^=]`);
+                    const mention = new RegExp(`\\b${name}\\b`);
+                    read = namedElsewhere({ test: (line) => mention.test(line) && !declOrStore.test(line) }, pkgFiles, filePath, knob.line) ||
+                        (/^[A-Z]/.test(last) && namedElsewhere(new RegExp(`\\.${escapeRegExp(last)}\\b`),
+                            parsed.filter(f => f.dir !== dir), filePath, knob.line));
+                }
+                if (read) continue;
+            }
+            results.push({
+                name: knob.key,
+                type: knob.kind === 'env' ? 'env' : knob.kind === 'viper' ? 'config' : 'flag',
+                file: rel,
+                startLine: knob.line,
+                endLine: knob.line,
+                isExported: false,
+                usageCount: 0,
+                knob: knob.kind,
+                ...(knob.target && { knobTarget: knob.target }),
+            });
+        }
+    }
+
+    return _modeResults(results);
+}
+
+/**
+ * Package-level variables never read (deadcode --package-vars), from the
+ * language's packageVars pass. A variable only ever assigned is
+ * 'write-only' — the stores can go with it; one never named again is
+ * 'unused'. Unexported variables are visible to their package (directory)
+ * only; exported ones count reads from every file (`pkg.V`).
+ */
+function unusedPackageVars(index, options = {}) {
+    const results = [];
+    let excludedExported = 0;
+
+    const accessesByFile = new Map();
+    const varsPass = (fileEntry, lang) => langTraits(lang)?.packageVars;
+    for (const { filePath, picked: pass, tree } of _sourceFiles(index, { pick: varsPass })) {
+        accessesByFile.set(filePath, pass(tree));
+    }
+    // name → {reads, writes} per directory, and over all files
+    const tally = (map, key, name, kind) => {
+        if (!map.has(key)) map.set(key, new Map());
+        const counts = map.get(key);
+        if (!counts.has(name)) counts.set(name, { reads: 0, writes: 0 });
+        counts.get(name)[kind]++;
+    };
+    const byDir = new Map();
+    const global = new Map();
+    for (const [filePath, { reads, writes }] of accessesByFile) {
+        const dir = pathDirname(filePath);
+        for (const r of reads) { tally(byDir, dir, r.name, 'reads'); tally(global, '', r.name, 'reads'); }
+        for (const w of writes) { tally(byDir, dir, w.name, 'writes'); tally(global, '', w.name, 'writes'); }
+    }
+
+    for (const [filePath, { vars }] of accessesByFile) {
+        const fileEntry = index.files.get(filePath);
+        const lang = fileEntry.language;
+        if (!_inScope(index, fileEntry.relativePath, lang, options)) continue;
+        for (const v of vars) {
+            const isExported = langTraits(lang).exportVisibility === 'capitalization' && /^[A-Z]/.test(v.name);
+            const counts = (isExported ? global.get('') : byDir.get(pathDirname(filePath)))?.get(v.name) ||
+                { reads: 0, writes: 0 };
+            if (counts.reads > 0) continue;
+            if (isExported && !options.includeExported) {
+                excludedExported++;
+                continue;
+            }
+            results.push({
+                name: v.name,
+                type: 'variable',
+                file: fileEntry.relativePath,
+                startLine: v.startLine,
+                endLine: v.endLine,
+                isExported,
+                usageCount: counts.writes,
+                access: counts.writes > 0 ? 'write-only' : 'unused',
+                ...(counts.writes > 0 && { writes: counts.writes }),
+            });
+        }
+    }
+
+    return _modeResults(results, excludedExported);
+}
+
+// Blank imports of well-known registries: the package path, what it
+// registers, and the call that would ever look the registration up
+const _BLANK_IMPORT_CONSUMERS = [
+    [/(^|\/)(pq|mysql|go-sqlite3|sqlite|go-mssqldb|clickhouse-go|pgx(\/v\d+)?\/stdlib)(\/v\d+)?$/, 'database driver',
+        'sql.Open', /\b(sql|sqlx)\.(Open|OpenDB|Connect|MustConnect|MustOpen)\b/],
+    [/^image\/(png|jpeg|gif)$|^golang\.org\/x\/image\/(bmp|tiff|webp)$/, 'image format',
+        'image.Decode', /\bimage\.(Decode|DecodeConfig)\b/],
+];
+
+/**
+ * init() functions and blank imports whose side effects nobody observes
+ * (deadcode --init-effects), from the language's initEffects pass. An
+ * init is dead when all it does — directly or through the project
+ * functions it calls, like a `Register` filling a map — is store into
+ * package variables nothing reads. A blank import `_ "pkg"` of a project
+ * package is dead when the package runs no init, or only dead ones; of a
+ * well-known registry (database drivers, image formats), when nothing
+ * calls the lookup (`sql.Open`, `image.Decode`) that would find it.
+ */
+function deadInitEffects(index, options = {}) {
+    const { findGoModule, extractImports } = require('./imports');
+    const results = [];
+    const goMod = findGoModule(index.root);
+    const dirOfImport = (importPath) => {
+        if (!goMod || (importPath !== goMod.modulePath && !importPath.startsWith(`${goMod.modulePath}/`))) return null;
+        return pathJoin(goMod.root, importPath.slice(goMod.modulePath.length));
+    };
+
+    const files = [];
+    const functionsByDir = new Map(); // dir → name → function summary
+    const initsByDir = new Map();
+    const blankVarInitDirs = new Set();
+    const varsByDir = new Map();
+    const readsByDir = new Map();
+    const globalReads = new Map();
+    const count = (map, name) => map.set(name, (map.get(name) || 0) + 1);
+    const withInitEffects = (fileEntry, lang) => (langTraits(lang)?.initEffects && langTraits(lang).packageVars ? langTraits(lang) : null);
+    for (const { filePath, fileEntry, picked: traits, content, tree } of _sourceFiles(index, { pick: withInitEffects })) {
+        const dir = pathDirname(filePath);
+        const testFile = isTestFile(fileEntry.relativePath, fileEntry.language);
+        files.push({ fileEntry, content });
+        const { vars, reads } = traits.packageVars(tree);
+        if (!readsByDir.has(dir)) readsByDir.set(dir, new Map());
+        for (const r of reads) { count(readsByDir.get(dir), r.name); count(globalReads, r.name); }
+        if (testFile) continue;
+        if (!varsByDir.has(dir)) varsByDir.set(dir, new Set());
+        for (const v of vars) varsByDir.get(dir).add(v.name);
+        const { functions, blankVarInits } = traits.initEffects(tree);
+        if (blankVarInits > 0) blankVarInitDirs.add(dir);
+        if (!functionsByDir.has(dir)) functionsByDir.set(dir, new Map());
+        for (const fn of functions) {
+            if (fn.name === 'init') {
+                if (!initsByDir.has(dir)) initsByDir.set(dir, []);
+                initsByDir.get(dir).push({ fn, dir, fileEntry });
+            } else if (!functionsByDir.get(dir).has(fn.name)) {
+                functionsByDir.get(dir).set(fn.name, fn);
+            }
+        }
+    }
+
+    // Package variables a function ends up storing into, as `dir\0name`;
+    // null when it does anything else
+    const storesOf = (fn, dir, seen) => {
+        if (fn.effects || seen.has(fn)) return null;
+        seen.add(fn);
+        const keys = new Set();
+        for (const s of fn.stores) {
+            const varDir = s.importPath ? dirOfImport(s.importPath) : dir;
+            if (!varDir || !varsByDir.get(varDir)?.has(s.name)) return null;
+            keys.add(`${varDir}\0${s.name}`);
+        }
+        for (const c of fn.calls) {
+            const calleeDir = c.importPath ? dirOfImport(c.importPath) : dir;
+            const callee = calleeDir && functionsByDir.get(calleeDir)?.get(c.name);
+            const stored = callee && storesOf(callee, calleeDir, seen);
+            if (!stored) return null;
+            for (const k of stored) keys.add(k);
+        }
+        return keys;
+    };
+    const isRead = (key) => {
+        const [dir, name] = key.split('\0');
+        return /^[A-Z]/.test(name) ? (globalReads.get(name) || 0) > 0 : (readsByDir.get(dir)?.get(name) || 0) > 0;
+    };
+    const deadInits = new Map(); // init summary → stored variable names
+    for (const inits of initsByDir.values()) {
+        for (const { fn, dir } of inits) {
+            const stored = storesOf(fn, dir, new Set());
+            if (stored && ![...stored].some(isRead)) deadInits.set(fn, [...stored].map(k => k.split('\0')[1]).sort());
+        }
+    }
+
+    const reported = fileEntry => _inScope(index, fileEntry.relativePath, fileEntry.language, options);
+    for (const inits of initsByDir.values()) {
+        for (const { fn, fileEntry } of inits) {
+            if (!deadInits.has(fn) || !reported(fileEntry)) continue;
+            results.push({
+                name: 'init',
+                type: 'function',
+                file: fileEntry.relativePath,
+                startLine: fn.line,
+                endLine: fn.endLine,
+                isExported: false,
+                usageCount: 0,
+                initStores: deadInits.get(fn),
+            });
+        }
+    }
+
+    const goContents = files.map(f => f.content);
+    for (const { fileEntry, content } of files) {
+        if (!reported(fileEntry)) continue;
+        for (const imp of extractImports(content, 'go').imports) {
+            if (imp.names[0] !== '_' || imp.module === 'embed') continue;
+            let blankImport = null;
+            const dir = dirOfImport(imp.module);
+            if (dir) {
+                if (blankVarInitDirs.has(dir) || !functionsByDir.has(dir)) continue;
+                const inits = initsByDir.get(dir) || [];
+                if (inits.length === 0) blankImport = 'the package has no init';
+                else if (inits.every(({ fn }) => deadInits.has(fn))) blankImport = 'its init only fills variables nothing reads';
+            } else {
+                const known = _BLANK_IMPORT_CONSUMERS.find(([pattern]) => pattern.test(imp.module));
+                if (known && !goContents.some(c => known[3].test(c))) {
+                    blankImport = `registers a ${known[1]}, but nothing calls ${known[2]}`;
+                }
+            }
+            if (!blankImport) continue;
+            results.push({
+                name: imp.module,
+                type: 'import',
+                file: fileEntry.relativePath,
+                startLine: imp.line,
+                endLine: imp.line,
+                isExported: false,
+                usageCount: 0,
+                blankImport,
+            });
+        }
+    }
+
+    return _modeResults(results);
+}
+
+/**
+ * Import hygiene (deadcode --import-issues), from the language's
+ * importIssues pass. Three findings, each with machine-applicable edits
+ * in the refactoring plan's shape (`{file, line, expression,
+ * newExpression}`, an empty newExpression deleting the line):
+ * - unused: an import nothing in its file names, in a file only some
+ *   build tags compile — the compiler never saw it on the default build;
+ * - duplicate: one path imported twice under different names; the
+ *   less-used name is dropped and its uses rewritten;
+ * - shadowed: a local named like an imported package, hiding it in its
+ *   scope; the import is renamed and its uses follow.
+ */
+function importIssues(index, options = {}) {
+    const results = [];
+    const withImportIssues = (fileEntry, lang) => (langTraits(lang)?.importIssues ? langTraits(lang) : null);
+    for (const { fileEntry, picked: traits, content, tree } of _sourceFiles(index, { pick: withImportIssues, options })) {
+        const rel = fileEntry.relativePath;
+        const { imports, shadows } = traits.importIssues(tree);
+        const lines = content.split('\n');
+
+        const deleteEdit = (imp) => {
+            const text = lines[imp.line - 1];
+            // Only a spec alone on its line can go with the line
+            if (imp.specsOnLine !== 1 || (text.includes('(') && !text.includes(')'))) return [];
+            return [{ file: rel, line: imp.line, expression: text, newExpression: '', suggestion: `Delete import "${imp.path}"` }];
+        };
+        const rewriteEdits = (uses, from, to) => {
+            const byLine = new Map();
+            for (const u of uses) {
+                if (!byLine.has(u.line)) byLine.set(u.line, []);
+                byLine.get(u.line).push(u.column);
+            }
+            return [...byLine].sort((a, b) => a[0] - b[0]).map(([line, columns]) => {
+                let text = lines[line - 1];
+                for (const col of columns.sort((a, b) => b - a)) text = text.slice(0, col) + to + text.slice(col + from.length);
+                return { file: rel, line, expression: lines[line - 1], newExpression: text, suggestion: `Use ${to} for ${from}` };
+            });
+        };
+        const push = (imp, extra) => results.push({
+            name: imp.path,
+            type: 'import',
+            file: rel,
+            startLine: imp.line,
+            endLine: imp.line,
+            isExported: false,
+            usageCount: imp.uses.length,
+            ...extra,
+        });
+        const named = imports.filter(i => i.alias !== '_' && i.alias !== '.' && i.path !== 'C');
+
+        const constraint = traits.buildConstraint?.(content, rel);
+        if (constraint) {
+            for (const imp of named) {
+                if (imp.uses.length === 0) push(imp, { importIssue: 'unused', builtWith: constraint.expression, edits: deleteEdit(imp) });
+            }
+        }
+
+        const dropped = new Set();
+        const byPath = new Map();
+        for (const imp of named) {
+            if (!byPath.has(imp.path)) byPath.set(imp.path, []);
+            byPath.get(imp.path).push(imp);
+        }
+        for (const same of byPath.values()) {
+            if (same.length < 2) continue;
+            // Keep the name no local hides, then the most used one
+            const rank = (imp) => (shadows.some(s => s.name === imp.name) ? 0 : 1e9) + imp.uses.length;
+            const keep = same.reduce((best, imp) => (rank(imp) > rank(best) ? imp : best));
+            for (const imp of same) {
+                if (imp === keep) continue;
+                dropped.add(imp);
+                push(imp, {
+                    importIssue: 'duplicate',
+                    duplicateOf: keep.name,
+                    edits: [...deleteEdit(imp), ...rewriteEdits(imp.uses, imp.name, keep.name)],
+                });
+            }
+        }
+
+        for (const imp of named) {
+            const hiding = shadows.filter(s => s.name === imp.name);
+            if (hiding.length === 0 || imp.uses.length === 0 || dropped.has(imp)) continue;
+            const parts = imp.path.split('/').filter(p => !/^v\d+$/.test(p));
+            let alias = parts.length > 1 ? `${parts[parts.length - 2].replace(/\W/g, '')}${imp.name}` : `${imp.name}pkg`;
+            while (new RegExp(`\\b${escapeRegExp(alias)}\\b`).test(content)) alias += 'pkg';
+            const importLine = lines[imp.line - 1];
+            const renamed = imp.alias
+                ? importLine.replace(new RegExp(`\\b${escapeRegExp(imp.alias)}(\\s+)"`), `${alias}$1"`)
+                : importLine.replace(`"${imp.path}"`, `${alias} "${imp.path}"`);
+            push(imp, {
+                importIssue: 'shadowed',
+                shadowedBy: hiding,
+                suggestedAlias: alias,
+                edits: [
+                    { file: rel, line: imp.line, expression: importLine, newExpression: renamed, suggestion: `Import as ${alias}` },
+                    ...rewriteEdits(imp.uses, imp.name, alias),
+                ],
+            });
+        }
+    }
+    return _modeResults(results);
+}
+
+/**
+ * Sentinel errors nothing consumes (sentinelErrors trait — Go
+ * `var ErrX = errors.New(...)`): never compared (==, errors.Is, a switch
+ * case) and never returned or otherwise passed on, so no caller can ever
+ * see or test for it. Unexported sentinels count uses in their package,
+ * exported ones project-wide.
+ */
+function unusedSentinelErrors(index, options = {}) {
+    const results = [];
+    let excludedExported = 0;
+
+    const trees = new Map();
+    const declared = new Map(); // filePath → sentinels
+    const sentinelPass = (fileEntry, lang) => langTraits(lang)?.sentinelErrors;
+    for (const { filePath, picked: pass, tree } of _sourceFiles(index, { pick: sentinelPass })) {
+        trees.set(filePath, tree);
+        const { sentinels } = pass(tree, new Set());
+        if (sentinels.length > 0) declared.set(filePath, sentinels);
+    }
+    const names = new Set([...declared.values()].flatMap(list => list.map(s => s.name)));
+
+    // name → {compared, propagated, other} per directory, and over all files
+    const tally = (map, key, name, kind) => {
+        if (!map.has(key)) map.set(key, new Map());
+        const counts = map.get(key);
+        if (!counts.has(name)) counts.set(name, { compared: 0, propagated: 0, other: 0 });
+        counts.get(name)[kind]++;
+    };
+    const byDir = new Map();
+    const global = new Map();
+    for (const [filePath, tree] of trees) {
+        const dir = pathDirname(filePath);
+        const pass = langTraits(index.files.get(filePath).language).sentinelErrors;
+        for (const use of pass(tree, names).uses) {
+            tally(byDir, dir, use.name, use.kind);
+            tally(global, '', use.name, use.kind);
+        }
+    }
+
+    for (const [filePath, sentinels] of declared) {
+        const fileEntry = index.files.get(filePath);
+        const lang = fileEntry.language;
+        if (!_inScope(index, fileEntry.relativePath, lang, options)) continue;
+        for (const s of sentinels) {
+            const isExported = langTraits(lang).exportVisibility === 'capitalization' && /^[A-Z]/.test(s.name);
+            const counts = (isExported ? global.get('') : byDir.get(pathDirname(filePath)))?.get(s.name) ||
+                { compared: 0, propagated: 0, other: 0 };
+            if (counts.compared > 0 || counts.propagated > 0) continue;
+            if (isExported && !options.includeExported) {
+                excludedExported++;
+                continue;
+            }
+            results.push({
+                name: s.name,
+                type: 'variable',
+                file: fileEntry.relativePath,
+                startLine: s.startLine,
+                endLine: s.endLine,
+                isExported,
+                usageCount: counts.other,
+                sentinel: true,
+            });
+        }
+    }
+
+    return _modeResults(results, excludedExported);
+}
+
+/** Result types of a declared return type: `(int, error)` → ['int', 'error'] */
+function _resultTypes(returnType) {
+    let text = String(returnType).trim();
+    if (text.startsWith('(') && text.endsWith(')')) text = text.slice(1, -1);
+    const parts = [];
+    let depth = 0;
+    let current = '';
+    for (const ch of text) {
+        if ('([{<'.includes(ch)) depth++;
+        else if (')]}>'.includes(ch)) depth = Math.max(0, depth - 1);
+        if (ch === ',' && depth === 0) { parts.push(current.trim()); current = ''; } else current += ch;
+    }
+    parts.push(current.trim());
+    return parts.filter(Boolean);
+}
+
+/**
+ * Functions whose results every call site throws away (deadcode
+ * --unused-results): called only as statements (or with every result
+ * assigned to `_`), or with one result position — typically an `error` —
+ * always blanked. Either the result can go from the signature or the
+ * callers are ignoring something they should not. Call sites come from
+ * findCallers; parsers mark discards on call records (discardedResults).
+ * Functions without callers are plain dead code and stay out.
+ */
+function unusedResults(index, options = {}) {
+    const results = [];
+    let excludedExported = 0;
+
+    for (const [, fileEntry] of index.files) {
+        if (!_inScope(index, fileEntry.relativePath, fileEntry.language, options)) continue;
+
+        for (const def of fileEntry.symbols) {
+            if (NON_CALLABLE_TYPES.has(def.type) || _CLASS_KINDS.includes(def.type) || !def.returnType) continue;
+            const types = _resultTypes(def.returnType);
+            if (types.length === 0) continue;
+            const sites = index.findCallers(def.name, { includeMethods: true, targetDefinitions: [def] })
+                .filter(c => !c.isFunctionReference);
+            if (sites.length === 0) continue;
+            const discardsAt = (site, i) => site.discardedResults === 'all' ||
+                (Array.isArray(site.discardedResults) && site.discardedResults.includes(i));
+            const everyResult = sites.every(site => site.discardedResults === 'all');
+            const positions = everyResult ? [] : types.map((_, i) => i).filter(i => sites.every(site => discardsAt(site, i)));
+            if (!everyResult && positions.length === 0) continue;
+
+            const isExported = symbolIsExported(index, def, fileEntry);
+            if (isExported && !options.includeExported) {
+                excludedExported++;
+                continue;
+            }
+            results.push({
+                name: def.name,
+                type: def.type,
+                file: def.relativePath,
+                startLine: def.startLine,
+                endLine: def.endLine,
+                ...(def.className && { className: def.className }),
+                isExported,
+                usageCount: sites.length,
+                returnType: def.returnType,
+                discardedResults: everyResult ? 'all' : positions.map(i => ({ index: i, type: types[i] })),
+            });
+        }
+    }
+
+    return _modeResults(results, excludedExported);
+}
+
+// Platforms built when neither options nor .ucn.json "platforms" name any
+const DEFAULT_PLATFORMS = ['linux/amd64', 'linux/arm64', 'darwin/amd64', 'darwin/arm64', 'windows/amd64'];
+
+/**
+ * Parse a target-platform matrix: `goos/goarch` entries, as an array or a
+ * comma-separated string. .ucn.json "buildTags" are set on every platform.
+ * @returns {Array<{goos: string, goarch: string, tags: Set<string>}>|null} null when an entry is malformed
+ */
+function parsePlatforms(spec, buildTags = []) {
+    const entries = (Array.isArray(spec) ? spec : String(spec).split(',')).map(e => String(e).trim()).filter(Boolean);
+    const tags = new Set(buildTags);
+    const platforms = [];
+    for (const entry of entries) {
+        const m = /^([a-z0-9]+)\/([a-z0-9]+)$/.exec(entry);
+        if (!m) return null;
+        platforms.push({ goos: m[1], goarch: m[2], tags, name: entry });
+    }
+    return platforms.length > 0 ? platforms : null;
+}
+
+/**
+ * Functions defined only in files no target platform builds (deadcode
+ * --build-variants): a file's build constraints (buildConstraint trait —
+ * Go `//go:build` lines and `_GOOS`/`_GOARCH` file suffixes) are false on
+ * every platform of the matrix — options.platforms, then .ucn.json
+ * "platforms", then DEFAULT_PLATFORMS. Each finding counts the same-name
+ * variants that a target does build in the same directory.
+ */
+function buildVariants(index, options = {}) {
+    const results = [];
+    const platforms = parsePlatforms(options.platforms || index.config?.platforms || DEFAULT_PLATFORMS,
+        index.config?.buildTags) || parsePlatforms(DEFAULT_PLATFORMS);
+    const constraintOf = new Map();
+    const constraint = (filePath, fileEntry) => {
+        if (!constraintOf.has(filePath)) {
+            const pass = langTraits(fileEntry.language)?.buildConstraint;
+            let content = null;
+            if (pass) { try { content = index._readFile(filePath); } catch { /* unreadable */ } }
+            constraintOf.set(filePath, content === null ? null : pass(content, fileEntry.relativePath));
+        }
+        return constraintOf.get(filePath);
+    };
+    const built = (filePath, fileEntry) => {
+        const c = constraint(filePath, fileEntry);
+        return !c || platforms.some(p => c.builds(p));
+    };
+
+    for (const [filePath, fileEntry] of index.files) {
+        if (!langTraits(fileEntry.language)?.buildConstraint) continue;
+        const rel = fileEntry.relativePath;
+        if (!_inScope(index, rel, fileEntry.language, options)) continue;
+        if (built(filePath, fileEntry)) continue;
+        const { expression } = constraint(filePath, fileEntry);
+        const dir = pathDirname(filePath);
+        for (const fn of fileEntry.symbols || []) {
+            if (NON_CALLABLE_TYPES.has(fn.type)) continue;
+            const variants = (index.symbols.get(fn.name) || []).filter(d =>
+                d.file !== filePath && pathDirname(d.file) === dir && d.className === fn.className &&
+                !NON_CALLABLE_TYPES.has(d.type) && built(d.file, index.files.get(d.file)));
+            results.push({
+                name: fn.name,
+                type: fn.type,
+                file: rel,
+                startLine: fn.startLine,
+                endLine: fn.endLine,
+                ...(fn.className && { className: fn.className }),
+                isExported: symbolIsExported(index, fn, fileEntry),
+                usageCount: 0,
+                buildConstraint: expression,
+                builtVariants: variants.length,
+            });
+        }
+    }
+
+    return _modeResults(results);
+}
+
+// Calls that load modules rather than run code (orphan-files top-level check)
+const _MODULE_LOADERS = new Set(['require', 'import', 'define', '__import__', 'import_module', 'load', 'dofile', 'require_relative']);
+
+/**
+ * Whole source files nothing outside them uses (deadcode --orphan-files):
+ * no file imports them and none of their top-level declarations is
+ * referenced from another file — each is dead, or used only inside the
+ * file. A live declaration with no reference at all (entry point,
+ * framework-registered) keeps the file. Members follow their class and
+ * nested functions their outer function. In directory-scoped languages
+ * (Go) an import names the package, not the file, so only the
+ * declarations decide; unindexed package vars and consts count as
+ * declarations there (packageVars trait). A file that calls anything at
+ * top level other than a module loader runs on its own — a script or a
+ * registration — and is kept. Files declaring an exported symbol are
+ * audited under --include-exported.
+ */
+function orphanFiles(index, options = {}) {
+    const { getCachedCalls } = require('./callers');
+    const results = [];
+    let excludedExported = 0;
+    const dead = deadcode(index, {
+        includeExported: true,
+        includeDecorated: options.includeDecorated,
+        includeTests: options.includeTests,
+    });
+    const deadKeys = new Set();
+    for (const item of dead) {
+        // A folded const block stands for its members
+        for (const name of item.members || []) deadKeys.add(`${item.file}\0${name}`);
+        deadKeys.add(`${item.file}\0${item.name}\0${item.startLine}`);
+    }
+
+    index._beginOp();
+    try {
+    // Files nothing imports and that run nothing at top level; in
+    // directory-scoped languages, every file
+    const importless = (fileEntry, lang) => {
+        if (langTraits(lang)?.packageScope === 'directory') return true;
+        const filePath = fileEntry.path;
+        if ([...(index.exportGraph.get(filePath) || [])].some(f => f !== filePath)) return false;
+        const calls = getCachedCalls(index, filePath);
+        return !!calls && !calls.some(c => !c.enclosingFunction && !c.isFunctionReference && !_MODULE_LOADERS.has(c.name));
+    };
+    const withPackageVars = (fileEntry, lang) => !!langTraits(lang)?.packageVars;
+
+    // Candidate file → declarations that must not be named by another file
+    const candidates = [];
+    const files = _sourceFiles(index, { pick: importless, options, parse: withPackageVars });
+    for (const { filePath, fileEntry, lang, content, tree } of files) {
+        const rel = fileEntry.relativePath;
+        const traits = langTraits(lang);
+        const symbols = fileEntry.symbols || [];
+        const classNames = new Set(symbols.filter(s => _CLASS_KINDS.includes(s.type) || s.type === 'enum')
+            .map(s => s.name));
+        const topLevel = symbols.filter(s =>
+            !(s.className && classNames.has(s.className)) &&
+            !symbols.some(o => o !== s && o.startLine <= s.startLine && s.endLine <= o.endLine &&
+                (o.startLine !== s.startLine || o.endLine !== s.endLine)));
+        const plain = [];
+        if (tree) {
+            const { vars, consts } = traits.packageVars(tree);
+            for (const v of [...vars, ...consts]) {
+                if (!topLevel.some(s => s.name === v.name)) plain.push(v.name);
+            }
+        }
+        if (topLevel.length + plain.length === 0) continue;
+
+        const lines = content.split('\n');
+        const mustStayLocal = [...plain];
+        let keep = false;
+        for (const s of topLevel) {
+            if (deadKeys.has(`${rel}\0${s.name}\0${s.startLine}`) || deadKeys.has(`${rel}\0${s.name}`)) continue;
+            // Live: only a use inside the file may explain it
+            const word = new RegExp(`\\b${escapeRegExp(s.name)}\\b`);
+            const defLine = s.nameLine || s.startLine;
+            if (!lines.some((l, i) => i + 1 !== defLine && word.test(l))) { keep = true; break; }
+            mustStayLocal.push(s.name);
+        }
+        if (keep) continue;
+        const isExported = topLevel.some(s => symbolIsExported(index, s, fileEntry)) ||
+            (traits?.exportVisibility === 'capitalization' && plain.some(n => /^[A-Z]/.test(n)));
+        candidates.push({ filePath, fileEntry, names: mustStayLocal, declarations: topLevel.length + plain.length, isExported });
+    }
+
+    // One pass over every other file for the live names
+    const needed = new Set(candidates.flatMap(c => c.names));
+    const namedIn = new Map();
+    if (needed.size > 0) {
+        for (const { filePath, content } of _sourceFiles(index, { parse: false })) {
+            for (const name of needed) {
+                if (!content.includes(name) || !new RegExp(`\\b${escapeRegExp(name)}\\b`).test(content)) continue;
+                if (!namedIn.has(name)) namedIn.set(name, new Set());
+                namedIn.get(name).add(filePath);
+            }
+        }
+    }
+
+    for (const c of candidates) {
+        if (c.names.some(name => [...(namedIn.get(name) || [])].some(f => f !== c.filePath))) continue;
+        if (c.isExported && !options.includeExported) {
+            excludedExported++;
+            continue;
+        }
+        const rel = c.fileEntry.relativePath;
+        results.push({
+            name: rel.split('/').pop(),
+            type: 'file',
+            file: rel,
+            startLine: 1,
+            endLine: c.fileEntry.lines || 1,
+            isExported: c.isExported,
+            usageCount: 0,
+            declarations: c.declarations,
+        });
+    }
+    } finally { index._endOp(); }
+
+    return _modeResults(results, excludedExported);
+}
+
+/**
+ * Symbols whose every reference sits in a test file (--test-only).
+ * Deadcode runs twice — as-is, then with test files invisible as usage
+ * sources; what only the second run claims is kept alive by tests alone.
+ * Removing such a symbol means removing its tests too, so these are kept
+ * apart from the fully-unused findings.
+ * @param {object} index - ProjectIndex instance
+ * @param {object} options - deadcode options (includeTests is ignored)
+ * @returns {Array} Symbols with `testFiles` (relative paths referencing them)
+ */
+function testOnlyUsage(index, options = {}) {
+    const base = { ...options, testOnly: false, includeTests: false };
+    const keyOf = (item, name = item.name) => `${item.file}\0${name}`;
+    const deadKeys = new Set();
+    for (const item of deadcode(index, base)) {
+        deadKeys.add(keyOf(item));
+        for (const name of item.members || []) deadKeys.add(keyOf(item, name));
+    }
+
+    if (!index.calleeIndex) index.buildCalleeIndex();
+    const testFile = (filePath) => {
+        const fe = index.files.get(filePath);
+        return !!fe && isTestFile(fe.relativePath, fe.language);
+    };
+    const fullCalleeIndex = index.calleeIndex;
+    const withoutTests = new Map();
+    for (const [name, files] of fullCalleeIndex) {
+        const kept = new Set([...files].filter(f => !testFile(f)));
+        if (kept.size > 0) withoutTests.set(name, kept);
+    }
+    let claimed;
+    index.calleeIndex = withoutTests;
+    try {
+        claimed = deadcode(index, { ...base, _ignoreTestUsages: true });
+    } finally {
+        index.calleeIndex = fullCalleeIndex;
+    }
+
+    const results = claimed.filter(item => item.type !== 'file' && item.type !== 'directory' &&
+        !deadKeys.has(keyOf(item)) &&
+        !(item.members && item.members.every(name => deadKeys.has(keyOf(item, name)))));
+
+    // Which test files keep each one alive: call sites, then word matches
+    index._beginOp();
+    try {
+        const names = new Set(results.flatMap(item => item.members || [item.name]));
+        const namedIn = new Map();
+        const tests = (fileEntry, lang) => isTestFile(fileEntry.relativePath, lang);
+        for (const { filePath, fileEntry, content } of _sourceFiles(index, { pick: tests, parse: false })) {
+            const words = new Set(content.match(/\b[a-zA-Z_]\w*\b/g));
+            for (const name of names) {
+                if (!words.has(name) && !(fullCalleeIndex.get(name)?.has(filePath))) continue;
+                if (!namedIn.has(name)) namedIn.set(name, new Set());
+                namedIn.get(name).add(fileEntry.relativePath);
+            }
+        }
+        for (const item of results) {
+            const files = new Set((item.members || [item.name]).flatMap(name => [...(namedIn.get(name) || [])]));
+            item.testOnly = true;
+            item.testFiles = [...files].sort(codeUnitCompare);
+        }
+    } finally { index._endOp(); }
+
+    results.excludedDecorated = claimed.excludedDecorated;
+    results.excludedExported = claimed.excludedExported;
+    results.excludedExternalContract = claimed.excludedExternalContract;
+    return results;
+}
+
+// Calls that enumerate a directory: every fixture under a path named on
+// the same line is consumed without its own name appearing anywhere
+const _DIR_LISTING = /\b(ReadDir|Glob|Walk|WalkDir|DirFS|readdirSync|readdir|listdir|iterdir|glob|rglob)\b/;
+
+/**
+ * Test code nothing exercises (--test-helpers): helper functions in test
+ * files that no test calls, and files under the language's fixture
+ * directory (fixtureDir trait — Go `testdata/`) that no code of the
+ * package names. A fixture counts as named by its file name, its path
+ * under the fixture directory, its stem or one of its directory names as a
+ * word (`tc.name + ".golden"` with `name: "basic"`, goldie's t.Name()), or
+ * a directory listing of a fixture path that contains it.
+ * @param {object} index - ProjectIndex instance
+ * @param {object} options - { file, exclude, in, includeDecorated }
+ * @returns {Array} Helpers (deadcode items) and `{type: 'fixture'}` items
+ */
+function unusedTestCode(index, options = {}) {
+    const inScope = (rel) => (!options.file || rel.includes(options.file)) &&
+        (!((options.exclude && options.exclude.length > 0) || options.in) ||
+            index.matchesFilters(rel, { exclude: options.exclude, in: options.in }));
+
+    // Test files are no importable API: exported helpers are audited too
+    const dead = deadcode(index, { ...options, testHelpers: false, includeTests: true, includeExported: true });
+    const langOf = new Map([...index.files.values()].map(fe => [fe.relativePath, fe.language]));
+    const results = dead.filter(item => langOf.has(item.file) && isTestFile(item.file, langOf.get(item.file)));
+
+    index._beginOp();
+    try {
+    const packageDirs = new Map(); // package dir → fixture dir name
+    for (const fileEntry of index.files.values()) {
+        const fixtureDir = langTraits(fileEntry.language)?.fixtureDir;
+        if (!fixtureDir || !isTestFile(fileEntry.relativePath, fileEntry.language)) continue;
+        packageDirs.set(pathDirname(fileEntry.relativePath), fixtureDir);
+    }
+    for (const [dir, fixtureDir] of packageDirs) {
+        const fixtureRoot = pathJoin(index.root, dir, fixtureDir);
+        const fixtures = expandGlob('**/*', { root: fixtureRoot })
+            .map(f => ({ path: f, rel: pathRelative(index.root, f).replace(/\\/g, '/'),
+                under: pathRelative(fixtureRoot, f).replace(/\\/g, '/') }))
+            .filter(f => inScope(f.rel));
+        if (fixtures.length === 0) continue;
+
+        // What the package's own code says: words, string literals, listings
+        const words = new Set();
+        const literals = [];
+        const listings = [];
+        const inPackage = fileEntry => pathDirname(fileEntry.relativePath) === dir;
+        for (const { content } of _sourceFiles(index, { pick: inPackage, parse: false })) {
+            for (const word of [...content.match(/[\w.-]+/g) || [], ...content.match(/[\w-]+/g) || []]) words.add(word);
+            for (const line of content.split('\n')) {
+                const onLine = [...line.matchAll(/"((?:[^"\\]|\\.)*)"|`([^`]*)`|'((?:[^'\\]|\\.)*)'/g)]
+                    .map(m => m[1] ?? m[2] ?? m[3]);
+                // filepath.Join("testdata", "cases") names testdata/cases
+                const at = onLine.indexOf(fixtureDir);
+                if (at >= 0 && at < onLine.length - 1) onLine.splice(at, Infinity, onLine.slice(at).join('/'));
+                literals.push(...onLine);
+                if (_DIR_LISTING.test(line)) listings.push(...onLine);
+            }
+            for (const m of content.matchAll(/^\s*\/\/go:embed\s+(.+)$/gm)) listings.push(...m[1].split(/\s+/));
+        }
+        const listed = (under) => listings.some(lit => {
+            const prefix = lit.replace(/[*?[{].*$/, '').replace(/\/+$/, '');
+            if (prefix === fixtureDir) return true;
+            return prefix.startsWith(`${fixtureDir}/`) && `${fixtureDir}/${under}`.startsWith(`${prefix}/`);
+        });
+
+        for (const f of fixtures) {
+            const parts = f.under.split('/');
+            const base = parts[parts.length - 1];
+            const stems = new Set([base.replace(/\.[^.]*$/, ''), base.replace(/\..*$/, '')]);
+            const named = literals.some(lit => lit.includes(base) || lit.endsWith(f.under)) ||
+                [...stems, ...parts.slice(0, -1)].some(w => w && words.has(w));
+            if (named || listed(f.under)) continue;
+            let lines = 1;
+            try {
+                const content = index._readFile(f.path);
+                lines = content.split('\n').length - (content.endsWith('\n') ? 1 : 0) || 1;
+            } catch { /* unreadable */ }
+            results.push({
+                name: base,
+                type: 'fixture',
+                file: f.rel,
+                startLine: 1,
+                endLine: lines,
+                isExported: false,
+                usageCount: 0,
+                ...(base.endsWith('.golden') && { golden: true }),
+            });
+        }
+    }
+    } finally { index._endOp(); }
+
+    results.sort((a, b) => codeUnitCompare(a.file, b.file) || a.startLine - b.startLine);
+    results.excludedDecorated = dead.excludedDecorated;
+    results.excludedExported = 0;
+    results.excludedExternalContract = dead.excludedExternalContract;
+    return results;
+}
+
+/**
+ * Embedded assets nothing reads (deadcode --embeds), from the language's
+ * embed directives (embeddedAssets trait — Go `//go:embed`). A variable
+ * whose name never appears again is reported with its patterns. For a read
+ * embed.FS, each embedded file must be named by a path literal on a line
+ * using the variable: the exact path, a directory above it, or a glob
+ * (`fmt.Sprintf` verbs act as `*`). A use of the variable with no string
+ * literal on the line (`http.FS(assets)`, a path in a variable) can reach
+ * any file and keeps them all.
+ * @param {object} index - ProjectIndex instance
+ * @param {object} options - { file, exclude, in, includeTests, includeExported }
+ * @returns {Array} Unread variables and `{type: 'asset'}` items
+ */
+function unusedEmbeds(index, options = {}) {
+    const { globToRegex } = require('./discovery');
+    const results = [];
+    let excludedExported = 0;
+    const inScope = (rel) => (!options.file || rel.includes(options.file)) &&
+        (!((options.exclude && options.exclude.length > 0) || options.in) ||
+            index.matchesFilters(rel, { exclude: options.exclude, in: options.in }));
+
+    index._beginOp();
+    try {
+        // Package files by directory, read once
+        const packages = new Map();
+        const embedding = (fileEntry, lang) => langTraits(lang)?.embeddedAssets;
+        for (const { filePath, fileEntry, content } of _sourceFiles(index, { pick: embedding, parse: false })) {
+            const dir = pathDirname(filePath);
+            if (!packages.has(dir)) packages.set(dir, []);
+            packages.get(dir).push({ filePath, fileEntry, content, lines: content.split('\n') });
+        }
+
+        for (const [dir, files] of packages) {
+            let onDisk = null;
+            for (const { filePath, fileEntry, content } of files) {
+                if (!options.includeTests && isTestFile(fileEntry.relativePath, fileEntry.language)) continue;
+                for (const embed of langTraits(fileEntry.language).embeddedAssets(content)) {
+                    const word = new RegExp(`\\b${escapeRegExp(embed.name)}\\b`);
+                    // Lines naming the variable, its own declaration aside
+                    const uses = [];
+                    for (const f of files) {
+                        f.lines.forEach((line, i) => {
+                            if (f.filePath === filePath && i + 1 === embed.varLine) return;
+                            if (word.test(line.replace(/\/\/.*$/, ''))) uses.push(line);
+                        });
+                    }
+                    const isExported = /^[A-Z]/.test(embed.name);
+                    if (isExported && uses.length === 0) {
+                        // pkg.Assets from another package
+                        const qualified = new RegExp(`\\.${escapeRegExp(embed.name)}\\b`);
+                        for (const [otherDir, others] of packages) {
+                            if (otherDir === dir) continue;
+                            for (const f of others) uses.push(...f.lines.filter(l => qualified.test(l)));
+                        }
+                    }
+                    const rel = fileEntry.relativePath;
+                    if (uses.length === 0) {
+                        if (isExported && !options.includeExported) { excludedExported++; continue; }
+                        if (!inScope(rel)) continue;
+                        results.push({
+                            name: embed.name,
+                            type: 'variable',
+                            file: rel,
+                            startLine: embed.line,
+                            endLine: embed.varLine,
+                            isExported,
+                            usageCount: 0,
+                            embedPatterns: embed.patterns,
+                        });
+                        continue;
+                    }
+                    if (embed.kind !== 'fs') continue;
+
+                    // Path literals of the uses; a use without one reaches everything
+                    const literals = [];
+                    let escapes = false;
+                    for (const line of uses) {
+                        const onLine = [...line.matchAll(/"((?:[^"\\]|\\.)*)"|`([^`]*)`/g)].map(m => m[1] ?? m[2]);
+                        if (onLine.length === 0) { escapes = true; break; }
+                        literals.push(...onLine.map(l => l.replace(/%[-+# 0-9.]*[a-zA-Z]/g, '*').replace(/^\.\//, '')));
+                    }
+                    if (escapes) continue;
+                    const named = (path) => literals.some(lit => {
+                        const prefix = lit.replace(/\/+$/, '');
+                        if (prefix === '' || prefix === '.' || path === lit) return true;
+                        if (lit.endsWith('/') ? path.startsWith(lit) : path.startsWith(`${prefix}/`)) return true;
+                        return /[*?[]/.test(lit) && globToRegex(lit).test(path);
+                    });
+
+                    if (!onDisk) {
+                        onDisk = expandGlob('**/*', { root: dir })
+                            .map(f => pathRelative(dir, f).replace(/\\/g, '/'));
+                    }
+                    for (const path of embeddedBy(embed.patterns, onDisk, globToRegex)) {
+                        if (named(path)) continue;
+                        const assetRel = pathRelative(index.root, pathJoin(dir, path)).replace(/\\/g, '/');
+                        if (!inScope(assetRel)) continue;
+                        results.push({
+                            name: path,
+                            type: 'asset',
+                            file: assetRel,
+                            startLine: 1,
+                            endLine: 1,
+                            isExported: false,
+                            usageCount: 0,
+                            embeddedBy: embed.name,
+                        });
+                    }
+                }
+            }
+        }
+    } finally { index._endOp(); }
+
+    return _modeResults(results, excludedExported);
+}
+
+/**
+ * Files (package-relative) a set of go:embed patterns embeds: a pattern
+ * naming a directory embeds the tree below it, minus `.`- and `_`-prefixed
+ * names unless it starts with `all:`.
+ */
+function embeddedBy(patterns, files, globToRegex) {
+    const out = new Set();
+    for (const raw of patterns) {
+        const all = raw.startsWith('all:');
+        const re = globToRegex(all ? raw.slice(4) : raw);
+        for (const file of files) {
+            const parts = file.split('/');
+            if (re.test(file)) { out.add(file); continue; }
+            for (let k = 1; k < parts.length; k++) {
+                if (!re.test(parts.slice(0, k).join('/'))) continue;
+                if (all || !parts.slice(k).some(p => p.startsWith('.') || p.startsWith('_'))) out.add(file);
+                break;
+            }
+        }
     }
-    return inSingle || inDouble || (inBacktick && interpDepth === 0);
+    return [...out].sort(codeUnitCompare);
 }
 
 /**
- * Build a usage index for identifiers in the codebase (optimized for deadcode)
- * Scans all files ONCE and builds a reverse index: name -> [usages]
+ * Exported API no importer uses (deadcode --library). For a library, an
+ * exported Go identifier is only alive if code outside its package names
+ * it: `pkg.Name` in a file importing the package, or `.Name` for a method
+ * of an exported type. Importers are the module's other packages plus the
+ * dependent checkouts in `options.dependents` (or `.ucn.json`
+ * "dependents"), each a directory of Go sources. `main` and `internal/`
+ * packages export nothing outside the module and are skipped. usageCount
+ * counts uses inside the package itself: nonzero means unexport rather
+ * than delete.
  * @param {object} index - ProjectIndex instance
- * @param {Set<string>} [filterNames] - If provided, only track these names (reduces memory)
- * @returns {Map<string, Array>} Usage index
+ * @param {object} options - { file, exclude, in, dependents }
+ * @returns {Array} Exported symbols with `library: true`
  */
-function buildUsageIndex(index, filterNames) {
-    const usageIndex = new Map(); // name -> [{file, line}]
-
-    for (const [filePath, fileEntry] of index.files) {
-        try {
-            const language = detectLanguage(filePath);
-            if (!language) continue;
+function unusedLibraryApi(index, options = {}) {
+    const { findGoModule, extractImports } = require('./imports');
+    const results = [];
+    const goMod = findGoModule(index.root);
+    if (!goMod) return _modeResults(results);
 
-            const content = index._readFile(filePath);
+    let dependents = options.dependents || index.config?.dependents || [];
+    if (typeof dependents === 'string') dependents = dependents.split(',').map(d => d.trim()).filter(Boolean);
+    const importPathOf = (filePath) => {
+        const dir = pathRelative(goMod.root, pathDirname(filePath)).replace(/\\/g, '/');
+        return dir ? `${goMod.modulePath}/${dir}` : goMod.modulePath;
+    };
 
-            // Text pre-filter: skip files that don't contain any target names
-            // (avoids expensive tree-sitter parse + AST traversal for irrelevant files)
-            if (filterNames && filterNames.size > 0) {
-                let hasAny = false;
-                for (const name of filterNames) {
-                    if (content.includes(name)) {
-                        hasAny = true;
-                        break;
-                    }
-                }
-                if (!hasAny) continue;
+    index._beginOp();
+    try {
+        // Who names what from outside: `pkg.Name` per import path, any
+        // `.Name` selector for methods, dot-imports name everything
+        const qualified = new Set();
+        const selectors = new Map(); // `.Name` → import paths of the files using it
+        const dotImported = new Set();
+        const scan = (content, ownImportPath) => {
+            const { imports } = extractImports(content, 'go');
+            for (const imp of imports) {
+                if (imp.module === ownImportPath) continue;
+                const alias = imp.names[0];
+                if (alias === '.') dotImported.add(imp.module);
+                if (!alias || alias === '.' || alias === '_') continue;
+                const re = new RegExp(`\\b${escapeRegExp(alias)}\\.([A-Z]\\w*)`, 'g');
+                for (const m of content.matchAll(re)) qualified.add(`${imp.module}\0${m[1]}`);
             }
-
-            // For HTML files, parse the virtual JS content instead of raw HTML
-            // (HTML tree-sitter sees script content as raw_text, not JS identifiers)
-            let tree;
-            if (language === 'html') {
-                const htmlModule = getLanguageModule('html');
-                const htmlParser = getParser('html');
-                const jsParser = getParser('javascript');
-                const blocks = htmlModule.extractScriptBlocks(content, htmlParser);
-                if (blocks.length === 0 && !htmlModule.extractEventHandlerCalls) continue;
-                if (blocks.length > 0) {
-                    const virtualJS = htmlModule.buildVirtualJSContent(content, blocks);
-                    tree = safeParse(jsParser, virtualJS);
-                }
-            } else {
-                const parser = getParser(language);
-                if (!parser) continue;
-                tree = safeParse(parser, content);
+            for (const m of content.matchAll(/\.([A-Z]\w*)\b/g)) {
+                if (!selectors.has(m[1])) selectors.set(m[1], new Set());
+                selectors.get(m[1]).add(ownImportPath);
             }
+        };
+        const methodNamedOutside = (importPath, name) =>
+            [...selectors.get(name) || []].some(from => from !== importPath);
 
-            // Collect all identifiers from this file in one pass
-            const traverse = (node) => {
-                // Match all identifier-like nodes across languages
-                if (node.type === 'identifier' ||
-                    node.type === 'property_identifier' ||
-                    node.type === 'type_identifier' ||
-                    node.type === 'shorthand_property_identifier' ||
-                    node.type === 'shorthand_property_identifier_pattern' ||
-                    node.type === 'field_identifier') {
-                    // Skip property_identifier/field_identifier when they're:
-                    // 1. The property part of a member expression (e.g., obj.Separator)
-                    // 2. An object literal key (e.g., { Separator: value })
-                    // These are NOT references to standalone symbols.
-                    // Shorthand properties ({ Separator }) use shorthand_property_identifier instead.
-                    if ((node.type === 'property_identifier' || node.type === 'field_identifier') && node.parent) {
-                        const parentType = node.parent.type;
-                        // Object literal key: { Separator: 'value' } — not a reference
-                        if (parentType === 'pair' || parentType === 'key_value_pair' ||
-                            parentType === 'dictionary_entry' || parentType === 'field_declaration') {
-                            // Check if this is the key (first child) of the pair
-                            const firstChild = node.parent.child(0);
-                            if (firstChild === node) {
-                                for (let i = 0; i < node.childCount; i++) {
-                                    traverse(node.child(i));
-                                }
-                                return;
-                            }
-                        }
-                        // Member expression property: obj.Separator — not a standalone reference
-                        // EXCEPTION: If the selector/member expression is part of a call_expression,
-                        // this IS a method call (e.g., dc.syncDeployment()) and should count as usage.
-                        if (parentType === 'member_expression' ||
-                            parentType === 'field_expression' ||
-                            parentType === 'member_access_expression' ||
-                            parentType === 'selector_expression' ||       // Go
-                            parentType === 'field_access_expression' ||   // Rust
-                            parentType === 'scoped_identifier') {         // Rust
-                            // Check if this is the property (right side) of the member expression
-                            // by checking if it's NOT the object (left side)
-                            const firstChild = node.parent.child(0);
-                            if (firstChild !== node) {
-                                // Check if this member expression is part of a call or
-                                // used as a function reference (callback argument)
-                                const grandparent = node.parent.parent;
-                                const isCall = grandparent &&
-                                    (grandparent.type === 'call_expression' ||
-                                     grandparent.type === 'argument_list' ||
-                                     grandparent.type === 'arguments');
-                                if (!isCall) {
-                                    // Pure field access — skip for deadcode counting
-                                    for (let i = 0; i < node.childCount; i++) {
-                                        traverse(node.child(i));
-                                    }
-                                    return;
-                                }
-                                // Method call or callback — fall through to count as usage
-                            }
-                        }
-                    }
-                    const name = node.text;
-                    if (filterNames && !filterNames.has(name)) {
-                        for (let i = 0; i < node.childCount; i++) {
-                            traverse(node.child(i));
-                        }
-                        return;
-                    }
-                    if (!usageIndex.has(name)) {
-                        usageIndex.set(name, []);
-                    }
-                    usageIndex.get(name).push({
-                        file: filePath,
-                        line: node.startPosition.row + 1,
-                        relativePath: fileEntry.relativePath
-                    });
-                }
-                for (let i = 0; i < node.childCount; i++) {
-                    traverse(node.child(i));
-                }
-            };
-            if (tree) traverse(tree.rootNode);
+        const packages = new Map(); // import path → { files: [[filePath, fileEntry, content]], name }
+        const goFiles = (fileEntry, lang) => lang === 'go' && (options.includeTests || !isTestFile(fileEntry.relativePath, lang));
+        for (const { filePath, fileEntry, content } of _sourceFiles(index, { pick: goFiles, parse: false })) {
+            const testFile = isTestFile(fileEntry.relativePath, 'go');
+            const importPath = importPathOf(filePath);
+            // External test packages (package foo_test) import foo like any importer
+            const pkgName = content.match(/^package\s+(\w+)/m)?.[1];
+            scan(content, pkgName?.endsWith('_test') ? `${importPath}_test` : importPath);
+            if (testFile) continue;
+            if (!packages.has(importPath)) packages.set(importPath, { name: pkgName, files: [] });
+            packages.get(importPath).files.push([filePath, fileEntry, content]);
+        }
+        for (const dep of dependents) {
+            const root = pathJoin(index.root, dep);
+            for (const file of expandGlob('**/*.go', { root })) {
+                const rel = pathRelative(root, file).replace(/\\/g, '/');
+                if (rel.split('/').includes('vendor') || isTestFile(rel, 'go')) continue;
+                let content;
+                try { content = readFileSync(file, 'utf-8'); } catch { continue; }
+                scan(content, `dependent:${dep}/${pathDirname(rel)}`);
+            }
+        }
 
-            // For HTML files, also extract identifiers from event handler attributes
-            // (onclick="foo()" etc. — these are in HTML, not in <script> blocks)
-            if (language === 'html') {
-                const htmlModule = getLanguageModule('html');
-                const htmlParser = getParser('html');
-                const handlerCalls = htmlModule.extractEventHandlerCalls(content, htmlParser);
-                for (const call of handlerCalls) {
-                    if (!usageIndex.has(call.name)) {
-                        usageIndex.set(call.name, []);
+        for (const [importPath, pkg] of packages) {
+            if (pkg.name === 'main' || importPath.split('/').includes('internal')) continue;
+            if (dotImported.has(importPath)) continue;
+            const packageWords = pkg.files.map(([, , content]) => content.match(/\b[A-Z]\w*\b/g) || []);
+            const candidates = [];
+            const signatures = []; // declaration lines of used functions and methods
+            const usedReceivers = new Set();
+            for (const [, fileEntry, content] of pkg.files) {
+                const lines = content.split('\n');
+                for (const def of fileEntry.symbols) {
+                    if (!/^[A-Z]/.test(def.name) || def.type === 'field') continue;
+                    const receiver = (def.receiver || '').replace(/^\*/, '').replace(/\[.*$/, '');
+                    const isMethod = def.type === 'method' || !!def.className;
+                    if (isMethod && !/^[A-Z]/.test(receiver || def.className || '')) continue;
+                    const used = isMethod
+                        ? methodNamedOutside(importPath, def.name)
+                        : qualified.has(`${importPath}\0${def.name}`);
+                    if (!used) {
+                        candidates.push({ def, fileEntry, receiver });
+                    } else if (!NON_CALLABLE_TYPES.has(def.type) && !TYPE_AUDIT_KINDS.includes(def.type)) {
+                        signatures.push(lines[def.startLine - 1] || '');
+                        if (isMethod) usedReceivers.add(receiver || def.className);
                     }
-                    usageIndex.get(call.name).push({
-                        file: filePath,
-                        line: call.line,
-                        relativePath: fileEntry.relativePath
-                    });
                 }
             }
-        } catch (e) {
-            // Skip files that can't be processed
+            for (const { def, fileEntry, receiver } of candidates) {
+                // A type reaches importers through a used function's
+                // signature or a used method of its own
+                if (TYPE_AUDIT_KINDS.includes(def.type) && (usedReceivers.has(def.name) ||
+                    signatures.some(line => new RegExp(`\\b${escapeRegExp(def.name)}\\b`).test(line)))) continue;
+                const rel = fileEntry.relativePath;
+                if (!_inScope(index, rel, 'go', options)) continue;
+                // Occurrences in the package beyond the declaration's own
+                const inPackage = packageWords.reduce((n, words) => n + words.filter(w => w === def.name).length, 0);
+                results.push({
+                    name: def.name,
+                    type: def.type,
+                    file: rel,
+                    startLine: def.startLine,
+                    endLine: def.endLine,
+                    ...(def.className && { className: def.className }),
+                    ...(receiver && { receiver }),
+                    isExported: true,
+                    usageCount: Math.max(0, inPackage - 1),
+                    library: true,
+                });
+            }
         }
-    }
+    } finally { index._endOp(); }
 
-    return usageIndex;
+    return _modeResults(results);
 }
 
 /**
- * Is a symbol part of the public/exported API surface?
- *
- * Beyond direct evidence (export list, export/public modifiers, Go
- * capitalization), methods of an exported class count as exported in
- * languages where class members are public by default (implicitlyPublicMembers
- * trait — JS/TS/Python): they are reachable through the class from outside
- * the project, so claiming them dead invites deleting public API (fix #211 —
- * zod's `strictImplement` is called by zero project files but is documented
- * public API). Private-by-shape members (#name, _name, `private` modifier)
- * stay claimable.
+ * Attach complexity metrics to dead functions (deadcode --complexity) and
+ * list every function, dead or not, over the thresholds beside them.
  */
-function symbolIsExported(index, symbol, fileEntry) {
-    if (!fileEntry) return false;
-    const name = symbol.name;
-    const mods = symbol.modifiers || [];
-    if (fileEntry.exports.includes(name) || mods.includes('export') || mods.includes('public')) {
-        return true;
-    }
-    const traits = langTraits(fileEntry.language);
-    if (traits?.exportVisibility === 'capitalization') {
-        return /^[A-Z]/.test(name);
-    }
-    if (traits?.implicitlyPublicMembers && symbol.className &&
-        !mods.includes('private') && !name.startsWith('#') && !name.startsWith('_')) {
-        const classSyms = index.symbols.get(symbol.className) || [];
-        const cls = classSyms.find(c => c.file === symbol.file &&
-            (c.type === 'class' || c.type === 'interface'));
-        if (cls) {
-            const cmods = cls.modifiers || [];
-            if (fileEntry.exports.includes(symbol.className) ||
-                cmods.includes('export') || cmods.includes('public')) {
-                return true;
-            }
-        }
+function _withComplexity(index, results, options) {
+    const { analyzeComplexity } = require('./complexity');
+    const { metrics, findings, thresholds } = analyzeComplexity(index, options);
+    for (const item of results) {
+        const m = metrics.get(`${item.file}:${item.startLine}`);
+        if (!m || item.functionName) continue;
+        item.cyclomatic = m.cyclomatic;
+        item.cognitive = m.cognitive;
     }
-    return false;
+    results.complexityFindings = findings;
+    results.complexityThresholds = thresholds;
+    return results;
+}
+
+/** List oversized functions and god types beside the dead code (deadcode --size) */
+function _withSize(index, results, options) {
+    results.sizeFindings = require('./size').findOversized(index, options);
+    return results;
 }
 
 /**
  * Find dead code (unused functions/classes)
  * @param {object} index - ProjectIndex instance
- * @param {object} options - { includeExported, includeTests }
+ * @param {object} options - { includeExported, includeTests, interfaceMethods }
  * @returns {Array} Unused symbols
  */
 function deadcode(index, options = {}) {
+    if (options.complexity) return _withComplexity(index, deadcode(index, { ...options, complexity: false }), options);
+    if (options.size) return _withSize(index, deadcode(index, { ...options, size: false }), options);
+    if (options.interfaceMethods) return unusedInterfaceMethods(index, options);
+    if (options.unusedParams) return unusedParameters(index, options);
+    if (options.unreachableCode) return unreachableCode(index, options);
+    if (options.packageVars) return unusedPackageVars(index, options);
+    if (options.sentinelErrors) return unusedSentinelErrors(index, options);
+    if (options.unusedResults) return unusedResults(index, options);
+    if (options.orphanFiles) return orphanFiles(index, options);
+    if (options.buildVariants) return buildVariants(index, options);
+    if (options.testOnly) return testOnlyUsage(index, options);
+    if (options.testHelpers) return unusedTestCode(index, options);
+    if (options.library) return unusedLibraryApi(index, options);
+    if (options.embeds) return unusedEmbeds(index, options);
+    if (options.channels) return channelIssues(index, options);
+    if (options.configKnobs) return unusedConfigKnobs(index, options);
+    if (options.satisfiesOnly) return assertionOnlyInterfaces(index, options);
+    if (options.redundantAssertions) return redundantAssertions(index, options);
+    if (options.typeParams) return unusedTypeParams(index, options);
+    if (options.initEffects) return deadInitEffects(index, options);
+    if (options.importIssues) return importIssues(index, options);
+    if (options.configKeys) return require('./config-keys').deadConfigKeys(index, options);
     index._beginOp();
     try {
-    const results = [];
+    let results = [];
     let excludedDecorated = 0;
     let excludedExported = 0;
     let excludedExternalContract = 0;
+    // Dead constants that belong to a const block (grouped after the scan)
+    const constBlockOf = new Map();
+    // Language conventions (main, init, Test*) count unless the config turns them off
+    const builtin = builtinEntryPoints(index);
 
     // Ensure callee index is built (lazy, reused across operations)
     if (!index.calleeIndex) {
         index.buildCalleeIndex();
     }
+    // --test-only's second pass: references from test files do not count
+    const ignoresUsagesIn = (fileEntry) => !!options._ignoreTestUsages &&
+        isTestFile(fileEntry.relativePath, fileEntry.language);
 
     // Collect callable symbol names to reduce usage index scope.
     // Accessor and visibility kinds joined in fix #247 — #private methods,
@@ -495,11 +2588,14 @@ function deadcode(index, options = {}) {
         // Class-like kinds joined in fix #253a — unused classes/structs/
         // interfaces were never audited in either mode.
         ...CLASS_AUDIT_KINDS];
-    const auditTypeSet = new Set(callableTypes);
-    const classAuditSet = new Set(CLASS_AUDIT_KINDS);
+    const auditTypeSet = new Set(options.types ? TYPE_AUDIT_KINDS : callableTypes);
+    const classAuditSet = new Set(options.types ? TYPE_AUDIT_KINDS : CLASS_AUDIT_KINDS);
+    // Plain declarations (constants, fields) join the default audit only
+    const auditsDeclaration = (s) => !options.types &&
+        _isAuditedDeclaration(s, index.files.get(s.file)?.language, index.config);
     const callableNames = new Set();
     for (const [symbolName, symbols] of index.symbols) {
-        if (symbols.some(s => auditTypeSet.has(s.type))) {
+        if (symbols.some(s => auditTypeSet.has(s.type) || auditsDeclaration(s))) {
             callableNames.add(symbolName);
         }
     }
@@ -509,9 +2605,63 @@ function deadcode(index, options = {}) {
     // same-name definition's own body is only self-recursive — recursion is
     // not liveness. Those names fall through to the text scan, which excludes
     // in-body references for them (selfRecursiveNames below).
+    //
+    // Generated-code forms (generatedNameForms trait — protobuf): a .proto
+    // definition is consumed through the identifiers protoc emits for it
+    // (`UserServiceClient`, `getUser`), never by its own name alone. Only
+    // hand-written files count — generated stubs call every rpc they define.
+    // A hand-written method of an rpc's name is its server implementation.
+    const generatedForms = new Map();
+    for (const name of callableNames) {
+        for (const s of index.symbols.get(name) || []) {
+            const formsOf = langTraits(index.files.get(s.file)?.language)?.generatedNameForms;
+            if (!formsOf) continue;
+            if (!generatedForms.has(name)) generatedForms.set(name, new Set());
+            for (const form of formsOf(s)) generatedForms.get(name).add(form);
+        }
+    }
+    const handwritten = (file) => {
+        const fe = index.files.get(file);
+        return !!fe && !fe.isGenerated;
+    };
+    const generatedNameUsed = (name) => {
+        const terms = [name, ...generatedForms.get(name)];
+        if (terms.some(t => [...(index.calleeIndex.get(t) || [])].some(handwritten))) return true;
+        const owners = index.symbols.get(name) || [];
+        if (!owners.some(s => s.type === 'method' &&
+            langTraits(index.files.get(s.file)?.language)?.generatedNameForms)) return false;
+        return terms.some(t => (index.symbols.get(t) || []).some(d => d.className &&
+            handwritten(d.file) && !langTraits(index.files.get(d.file)?.language)?.generatedNameForms));
+    };
+
+    //
+    // Embedded query languages (embeddedQueries trait — GraphQL): schema
+    // types and fields are consumed only by operations — documents of the
+    // language itself (recorded as calls) and templates embedded in host
+    // files (`gql\`...\``). A host call `name()` or word `name` says nothing
+    // about a `name` field, so these definitions take liveness from their
+    // own language's call sites and embedded documents only.
+    const queryLanguageOf = (s) => {
+        const lang = index.files.get(s.file)?.language;
+        return langTraits(lang)?.embeddedQueries ? lang : null;
+    };
+    const calledFromLanguage = (name, lang) =>
+        [...(index.calleeIndex.get(name) || [])].some(f => index.files.get(f)?.language === lang);
+
     let potentiallyDeadNames = new Set();
     const selfRecursiveNames = new Set();
     for (const name of callableNames) {
+        if (generatedForms.has(name)) {
+            if (!generatedNameUsed(name)) potentiallyDeadNames.add(name);
+            continue;
+        }
+        if ((index.symbols.get(name) || []).some(s => {
+            const lang = queryLanguageOf(s);
+            return lang && !calledFromLanguage(name, lang);
+        })) {
+            potentiallyDeadNames.add(name);
+            continue;
+        }
         if (!index.calleeIndex.has(name)) {
             potentiallyDeadNames.add(name);
         } else if (nameOnlySelfRecursive(index, name)) {
@@ -520,6 +2670,42 @@ function deadcode(index, options = {}) {
         }
     }
 
+    // Bridged sources (bridgedSources trait): files UCN does not index that
+    // consume symbols by name — a mixed target's Swift calling ObjC under
+    // the names the Clang importer derives, CI configs running Gradle tasks
+    // (`./gradlew packDocs`), notebook code cells calling library functions.
+    // A word match for any of the symbol's names in a bridged file (its
+    // extract()ed code, when the trait has one) keeps it alive — the
+    // boundary is never evidence of deadness.
+    const bridgedWords = new Map();
+    const bridgedWordsFor = (bridged) => {
+        if (!bridgedWords.has(bridged)) {
+            const words = new Set();
+            for (const ext of bridged.extensions) {
+                for (const file of expandGlob(`**/*${ext}`, { root: index.root })) {
+                    let content;
+                    try { content = index._readFile(file); } catch { continue; }
+                    if (bridged.extract) content = bridged.extract(content);
+                    for (const word of content.match(/[A-Za-z_]\w*/g) || []) words.add(word);
+                }
+            }
+            bridgedWords.set(bridged, words);
+        }
+        return bridgedWords.get(bridged);
+    };
+    // Link rules (.ucn.json "links") name symbols from the other side of a
+    // language boundary — a Go handler behind an OpenAPI operationId, a CLI
+    // command a Python script runs. Linked symbols are used.
+    const bridgedSymbols = new Set(computeLinks(index).targets);
+    for (const name of potentiallyDeadNames) {
+        for (const s of index.symbols.get(name) || []) {
+            const bridged = langTraits(index.files.get(s.file)?.language)?.bridgedSources;
+            if (bridged && bridged.names(s).some(form => bridgedWordsFor(bridged).has(form))) {
+                bridgedSymbols.add(s);
+            }
+        }
+    }
+
     // Pre-filter exported symbols from the scan set when not auditing exports.
     // Go exports ~63K capitalized names on K8s — scanning these in Phase 2 only to
     // skip them in Phase 3 wastes O(63K × 11K files) = ~700M comparisons.
@@ -645,6 +2831,12 @@ function deadcode(index, options = {}) {
     // alive). Other languages keep the string skip: their in-string names
     // are reflection (a documented limitation), not a language feature.
     const classKindNames = new Set();
+    // Names owned by a stringReferenced language (SQL): application code
+    // consumes them inside query strings (`"SELECT * FROM orders"`), and
+    // `public.orders` is a schema qualifier, not a member access — both the
+    // string skip (outside the owning language) and the dotted discipline
+    // are lifted for them.
+    const stringRefNames = new Set();
     for (const name of potentiallyDeadNames) {
         const defs = index.symbols.get(name) || [];
         if (defs.some(s => ACCESSOR_KINDS.has(s.type))) {
@@ -653,11 +2845,35 @@ function deadcode(index, options = {}) {
         if (defs.some(s => classAuditSet.has(s.type))) {
             classKindNames.add(name);
         }
+        if (defs.some(s => langTraits(index.files.get(s.file)?.language)?.stringReferenced)) {
+            stringRefNames.add(name);
+        }
+    }
+
+    // Generated forms are scanned alongside their owners and folded back
+    // into the owner's usages after the scan.
+    const scanNames = new Set(potentiallyDeadNames);
+    const generatedTerms = new Set();
+    const formOwners = new Map();
+    for (const name of potentiallyDeadNames) {
+        if (!generatedForms.has(name)) continue;
+        generatedTerms.add(name);
+        for (const form of generatedForms.get(name)) {
+            scanNames.add(form);
+            generatedTerms.add(form);
+            if (!formOwners.has(form)) formOwners.set(form, []);
+            formOwners.get(form).push(name);
+        }
     }
 
     const usageIndex = new Map();
     if (potentiallyDeadNames.size > 0) {
         for (const [filePath, fileEntry] of index.files) {
+            // Languages whose every reference form is a recorded call
+            // (Terraform `var.region`) opt out: a word match there is an
+            // argument name (`region = "eu-west-1"`), not a use.
+            if (langTraits(fileEntry.language)?.textScanUsages === false) continue;
+            if (ignoresUsagesIn(fileEntry)) continue;
             try {
                 const content = index._readFile(filePath);
                 // Fast pre-filter: extract identifiers from file, intersect with target names.
@@ -669,7 +2885,9 @@ function deadcode(index, options = {}) {
                 // the symbol name make the substring search self-delimiting).
                 const fileIdentifiers = new Set(content.match(/\b[a-zA-Z_]\w*\b/g));
                 const namesInFile = [];
-                for (const name of potentiallyDeadNames) {
+                for (const name of scanNames) {
+                    // Generated stubs mention every form they generate
+                    if (fileEntry.isGenerated && generatedTerms.has(name)) continue;
                     const present = /^[a-zA-Z_]\w*$/.test(name)
                         ? fileIdentifiers.has(name)
                         : content.includes(name);
@@ -681,21 +2899,29 @@ function deadcode(index, options = {}) {
                 // name inside /* ... */ counted as usage — commented-out code
                 // silently kept its symbols "alive" and hid true dead claims.
                 const lines = maskBlockComments(content, fileEntry.language).split('\n');
+                const lineComment = langTraits(fileEntry.language)?.lineComment || '//';
+                const hashComments = lineComment === '#';
+                const declarationOnlyLine = langTraits(fileEntry.language)?.declarationOnlyLine;
+                const ownsStringRefs = !!langTraits(fileEntry.language)?.stringReferenced;
                 for (const name of namesInFile) {
                     const nameLen = name.length;
                     for (let i = 0; i < lines.length; i++) {
                         const line = lines[i];
                         if (!line.includes(name)) continue;
+                        // `ALTER TABLE orders ...` restates the object; it
+                        // does not consume it.
+                        if (declarationOnlyLine && declarationOnlyLine.test(line)) continue;
                         // Skip line if entirely inside a line comment — the
                         // markers are language-shaped (fix #259, clap-measured):
-                        // `#` comments PYTHON ONLY (a Rust attribute line
+                        // `#` comments only where the lineComment trait says
+                        // so — Python, Elixir (a Rust attribute line
                         // `#[arg(value_parser = helper)]` is code, and the old
                         // skip dropped every reference on it — clap's derive-
-                        // attribute callbacks claimed FALSE-DEAD); `//` comments
-                        // everywhere EXCEPT Python, where it is floor division.
-                        const isPython = fileEntry.language === 'python';
-                        const commentIdx = isPython ? -1 : line.indexOf('//');
-                        const hashIdx = isPython ? line.indexOf('#') : -1;
+                        // attribute callbacks claimed FALSE-DEAD); `--` in Lua,
+                        // where `//` is floor division; `//` everywhere else
+                        // (Python floor division, Elixir ranges).
+                        const commentIdx = hashComments ? -1 : line.indexOf(lineComment);
+                        const hashIdx = hashComments ? line.indexOf('#') : -1;
                         let searchFrom = 0;
                         while (searchFrom < line.length) {
                             const pos = line.indexOf(name, searchFrom);
@@ -714,7 +2940,8 @@ function deadcode(index, options = {}) {
                             // kind names in Python (fix #253a): `x: "Foo"`
                             // forward references are real type references.
                             if (isInsideString(line, pos, fileEntry.language) &&
-                                !(fileEntry.language === 'python' && classKindNames.has(name))) continue;
+                                !(fileEntry.language === 'python' && classKindNames.has(name)) &&
+                                !(stringRefNames.has(name) && !ownsStringRefs)) continue;
                             // Property/field access (preceded by '.'), not a
                             // call: resolve the RECEIVER (fix #216, express-
                             // measured false-dead — `app.all(route, user.load)`
@@ -729,7 +2956,8 @@ function deadcode(index, options = {}) {
                             //     symbol (fix #123: `Primitives.Separator` has
                             //     its own key; must not keep the export alive)
                             let dottedScope;
-                            if (pos > 0 && line[pos - 1] === '.' &&
+                            if (pos > 0 && line[pos - 1] === '.' && !stringRefNames.has(name) &&
+                                !generatedTerms.has(name) &&
                                 (pos + nameLen >= line.length || line[pos + nameLen] !== '(')) {
                                 // Bare dotted DECORATOR application (@bus.subscribe,
                                 // @a.b.helper) executes at import time — always a
@@ -822,6 +3050,85 @@ function deadcode(index, options = {}) {
         }
     }
 
+    // Embedded documents: word matches inside the trait's template pattern
+    // in host-language files. A repo with no operations at all (neither
+    // documents nor embedded templates) serves clients UCN cannot see —
+    // nothing in its schema is claimable.
+    const embeddedUsages = new Map();
+    const queryLanguages = new Set();
+    for (const name of potentiallyDeadNames) {
+        for (const s of index.symbols.get(name) || []) {
+            const lang = queryLanguageOf(s);
+            if (lang) queryLanguages.add(lang);
+        }
+    }
+    const languagesWithOperations = new Set();
+    for (const lang of queryLanguages) {
+        const pattern = langTraits(lang).embeddedQueries;
+        const ownNames = [...potentiallyDeadNames].filter(n =>
+            (index.symbols.get(n) || []).some(s => queryLanguageOf(s) === lang));
+        for (const [filePath, fileEntry] of index.files) {
+            if (fileEntry.language === lang) {
+                if (fileEntry.symbols.some(s => s.type === 'function')) languagesWithOperations.add(lang);
+                continue;
+            }
+            if (ignoresUsagesIn(fileEntry)) continue;
+            let content;
+            try { content = index._readFile(filePath); } catch { continue; }
+            pattern.lastIndex = 0;
+            let m;
+            while ((m = pattern.exec(content)) !== null) {
+                languagesWithOperations.add(lang);
+                const ids = new Set(m[1].match(/\b[_A-Za-z]\w*\b/g));
+                const line = content.slice(0, m.index).split('\n').length;
+                for (const name of ownNames) {
+                    if (!ids.has(name)) continue;
+                    if (!embeddedUsages.has(name)) embeddedUsages.set(name, []);
+                    embeddedUsages.get(name).push({ file: filePath, line, relativePath: fileEntry.relativePath });
+                }
+            }
+        }
+    }
+
+    // Field reads (fieldReads trait — Go struct fields): a field is used
+    // where it is read. The word scan also counts `s.retries = 3` and
+    // `Config{retries: 3}`, which store a value nothing looks at.
+    const fieldReadUsages = new Map();
+    const fieldNamesByLanguage = new Map();
+    for (const name of potentiallyDeadNames) {
+        for (const s of index.symbols.get(name) || []) {
+            const lang = index.files.get(s.file)?.language;
+            if (s.type !== 'field' || !langTraits(lang)?.fieldReads) continue;
+            if (!fieldNamesByLanguage.has(lang)) fieldNamesByLanguage.set(lang, new Set());
+            fieldNamesByLanguage.get(lang).add(name);
+        }
+    }
+    for (const [lang, names] of fieldNamesByLanguage) {
+        const parser = getParser(lang);
+        for (const [filePath, fileEntry] of index.files) {
+            if (fileEntry.language !== lang || ignoresUsagesIn(fileEntry)) continue;
+            let content;
+            try { content = index._readFile(filePath); } catch { continue; }
+            if (![...names].some(n => content.includes(n))) continue;
+            const tree = index._getParsedTree(filePath, content, lang) || safeParse(parser, content);
+            if (!tree) continue;
+            for (const read of langTraits(lang).fieldReads(tree)) {
+                if (!names.has(read.name)) continue;
+                if (!fieldReadUsages.has(read.name)) fieldReadUsages.set(read.name, []);
+                fieldReadUsages.get(read.name).push({ file: filePath, line: read.line, relativePath: fileEntry.relativePath });
+            }
+        }
+    }
+
+    for (const [form, owners] of formOwners) {
+        const found = usageIndex.get(form);
+        if (!found) continue;
+        for (const owner of owners) {
+            if (!usageIndex.has(owner)) usageIndex.set(owner, []);
+            usageIndex.get(owner).push(...found);
+        }
+    }
+
     for (const [name, symbols] of index.symbols) {
         // Definition NAME lines of same-name def-kind symbols are
         // declarations, not usages — two never-called same-name methods used
@@ -863,7 +3170,7 @@ function deadcode(index, options = {}) {
 
         for (const symbol of symbols) {
             // Skip non-audited types (callableTypes defined above)
-            if (!auditTypeSet.has(symbol.type)) {
+            if (!auditTypeSet.has(symbol.type) && !auditsDeclaration(symbol)) {
                 continue;
             }
 
@@ -913,9 +3220,15 @@ function deadcode(index, options = {}) {
             }
 
             // Language-specific entry points (called by runtime/test runner, not user code)
-            // Each language module declares its own isEntryPoint() rules.
+            // Each language module declares its own isEntryPoint() rules
+            // (off with .ucn.json "builtinEntryPoints": false).
             const langModule = getLanguageModule(lang);
-            if (langModule.isEntryPoint?.(symbol)) {
+            if (builtin && langModule.isEntryPoint?.(symbol)) {
+                continue;
+            }
+
+            // Entry points a config declares (.ucn.json "entryPoints")
+            if (index.isConfiguredEntryPoint?.(symbol)) {
                 continue;
             }
 
@@ -940,9 +3253,14 @@ function deadcode(index, options = {}) {
 
             // Fast path: name has call sites in callee index → definitely used → not dead
             // (unless every site is the name's own recursion — fix #253c).
-            if (index.calleeIndex.has(name) && !selfRecursiveNames.has(name)) {
+            const queryLang = queryLanguageOf(symbol);
+            if (queryLang && !languagesWithOperations.has(queryLang)) continue;
+            if (queryLang ? calledFromLanguage(name, queryLang)
+                : generatedForms.has(name) ? generatedNameUsed(name)
+                    : (index.calleeIndex.has(name) && !selfRecursiveNames.has(name))) {
                 continue;
             }
+            if (bridgedSymbols.has(symbol)) continue;
             // Constructor members are invoked through the CLASS name
             // (fix #239, G2-js-measured: `new Widget()` indexes under
             // 'Widget' — the member lookup claimed every instantiated
@@ -963,7 +3281,7 @@ function deadcode(index, options = {}) {
             if (classAuditSet.has(symbol.type)) {
                 const members = (fileEntry?.symbols || []).filter(s =>
                     s !== symbol && s.className === name);
-                if (members.some(m => langModule.isEntryPoint?.(m))) {
+                if (members.some(m => (builtin && langModule.isEntryPoint?.(m)) || index.isConfiguredEntryPoint?.(m))) {
                     continue;
                 }
                 if (members.some(m => isFrameworkEntrypoint(m, index))) {
@@ -975,7 +3293,9 @@ function deadcode(index, options = {}) {
             }
 
             // Slow path: check AST-based usage index for remaining names
-            const allUsages = usageIndex.get(name) || [];
+            const allUsages = queryLang ? (embeddedUsages.get(name) || [])
+                : symbol.type === 'field' && langTraits(lang)?.fieldReads ? (fieldReadUsages.get(name) || [])
+                    : (usageIndex.get(name) || []);
 
             // Filter out usages that are at the definition location
             // nameLine: when decorators/annotations are present, startLine is the decorator line
@@ -1071,7 +3391,13 @@ function deadcode(index, options = {}) {
                     return { kind: enclosing.type, name: symbol.className };
                 })();
 
-                results.push({
+                // A field the code stores into (assignments, map/slice
+                // element stores) but never reads is write-only data
+                const writes = symbol.type === 'field' && langTraits(lang)?.fieldReads
+                    ? (usageIndex.get(name) || []).filter(u =>
+                        !(u.file === symbol.file && (u.line === symbol.startLine || u.line === symbol.nameLine))).length
+                    : 0;
+                const item = {
                     name: symbol.name,
                     type: symbol.type,
                     file: symbol.relativePath,
@@ -1088,12 +3414,24 @@ function deadcode(index, options = {}) {
                     ...(decorators.length > 0 && { decorators }),
                     ...(annotations.length > 0 && { annotations }),
                     ...(declaredOn && { declaredOn }),
-                    ...(isExternalContract && { externalContract: true })
-                });
+                    ...(isExternalContract && { externalContract: true }),
+                    ...(symbol.iota && { iota: true }),
+                    ...(writes > 0 && { access: 'write-only', writes }),
+                    ...(options.types && symbol.generics && { generics: symbol.generics }),
+                    ...(options.types && symbol.aliasOf && { aliasOf: symbol.aliasOf })
+                };
+                results.push(item);
+                if (symbol.constBlock) constBlockOf.set(item, symbol);
             }
         }
     }
 
+    results = _groupConstBlocks(index, results, constBlockOf);
+    if (!options.types) {
+        results.push(..._unreferencedModuleDirs(index, options));
+        results.push(..._unreferencedComponentFiles(index, options));
+    }
+
     // Sort by file then line
     results.sort((a, b) => {
         if (a.file !== b.file) return codeUnitCompare(a.file, b.file);
@@ -1109,4 +3447,4 @@ function deadcode(index, options = {}) {
     } finally { index._endOp(); }
 }
 
-module.exports = { buildUsageIndex, deadcode, nameOnlySelfRecursive, DEF_NAME_LINE_KINDS };
+module.exports = { buildUsageIndex, deadcode, nameOnlySelfRecursive, parsePlatforms, symbolIsExported, DEF_NAME_LINE_KINDS };
@@ -0,0 +1,102 @@
+/**
+ * core/deprecated.js — Deprecated-symbol tracking (deprecated command)
+ *
+ * Symbols whose doc comment carries a deprecation notice (Go's
+ * `// Deprecated:` paragraph, indexed as symbol.deprecated) are split in
+ * two: those something still references, listed with every referencing
+ * site so the migration can be planned, and those nothing references,
+ * which can be deleted now. A symbol's own body and, for a type, its own
+ * methods do not count as references.
+ */
+
+'use strict';
+
+const { isTestFile } = require('./discovery');
+const { codeUnitCompare, NON_CALLABLE_TYPES } = require('./shared');
+
+const TYPE_KINDS = new Set(['class', 'struct', 'interface', 'trait', 'record', 'enum', 'type']);
+
+/**
+ * Find deprecated symbols and the sites still using them.
+ * @param {object} index - ProjectIndex
+ * @param {object} options - { file, exclude, in, includeTests }
+ *   The filters narrow which deprecated symbols are reported; references
+ *   are counted project-wide, tests included.
+ * @returns {{used: Array, unused: Array}}
+ */
+function findDeprecated(index, options = {}) {
+    index._beginOp();
+    try {
+        const used = [];
+        const unused = [];
+        for (const [, fileEntry] of index.files) {
+            const rel = fileEntry.relativePath;
+            if (!options.includeTests && isTestFile(rel, fileEntry.language)) continue;
+            if (options.file && !rel.includes(options.file)) continue;
+            if (((options.exclude && options.exclude.length > 0) || options.in) &&
+                !index.matchesFilters(rel, { exclude: options.exclude, in: options.in })) continue;
+
+            for (const def of fileEntry.symbols || []) {
+                if (def.deprecated == null) continue;
+                const references = referencesOf(index, def)
+                    .sort((a, b) => codeUnitCompare(a.file, b.file) || a.line - b.line);
+                const item = {
+                    name: def.name,
+                    type: def.type,
+                    ...(def.className && { className: def.className }),
+                    ...(def.receiver && { receiver: def.receiver }),
+                    file: rel,
+                    startLine: def.startLine,
+                    endLine: def.endLine,
+                    notice: def.deprecated,
+                    isExported: (def.modifiers || []).includes('export'),
+                    references,
+                };
+                (references.length > 0 ? used : unused).push(item);
+            }
+        }
+        const byLocation = (a, b) => codeUnitCompare(a.file, b.file) || a.startLine - b.startLine;
+        used.sort(byLocation);
+        unused.sort(byLocation);
+        return { used, unused };
+    } finally {
+        index._endOp();
+    }
+}
+
+/** Sites referencing one definition, outside the definition itself */
+function referencesOf(index, def) {
+    const inside = (file, line, sym) => file === sym.file && line >= sym.startLine && line <= sym.endLine;
+
+    if (TYPE_KINDS.has(def.type)) {
+        // Methods declared on the type name it in their receivers
+        const lang = index.files.get(def.file)?.language;
+        const own = [def, ...index.findMethodsForType(def.name)
+            .filter(m => index.files.get(m.file)?.language === lang)];
+        const seen = new Set();
+        return index.usages(def.name, {})
+            .filter(u => !u.isDefinition && u.usageType !== 'import' &&
+                !own.some(s => inside(u.file, u.line, s)))
+            .filter(u => {
+                const key = `${u.file}:${u.line}`;
+                if (seen.has(key)) return false;
+                seen.add(key);
+                return true;
+            })
+            .map(u => {
+                const caller = index.findEnclosingFunction(u.file, u.line);
+                return { file: u.relativePath, line: u.line, ...(caller && { caller }) };
+            });
+    }
+
+    if (NON_CALLABLE_TYPES.has(def.type)) return [];
+    return index.findCallers(def.name, { includeMethods: true, targetDefinitions: [def] })
+        .filter(c => !inside(c.file, c.line, def))
+        .map(c => ({
+            file: c.relativePath,
+            line: c.line,
+            ...(c.callerName && { caller: c.callerName }),
+        }));
+}
+
+module.exports = { findDeprecated };
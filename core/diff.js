@@ -0,0 +1,115 @@
+/**
+ * core/diff.js — Findings introduced or resolved since a git ref
+ * (ucn diff [command] --base <ref>)
+ *
+ * The whole project is analyzed twice: as it is now, and as it was at
+ * the base ref, exported into a temporary directory through a throwaway
+ * git index (the repository's own index and worktrees are not touched).
+ * Both runs use the current root config, so a config change doesn't read
+ * as new code. The findings are then compared by their line-free
+ * fingerprints, as baselines compare them: the result keeps only the
+ * findings the base didn't have, and carries the ones that went away.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const { execFileSync } = require('child_process');
+const { createBaseline, applyBaseline } = require('./baseline');
+const { findingsOf } = require('./output/sarif');
+
+/** Run git in a directory; failures become errors with git's fatal line */
+function git(cwd, args, env) {
+    try {
+        return execFileSync('git', args, {
+            cwd, encoding: 'utf-8', maxBuffer: 64 * 1024 * 1024,
+            stdio: ['ignore', 'pipe', 'pipe'], ...(env && { env: { ...process.env, ...env } }),
+        });
+    } catch (e) {
+        const fatal = String(e.stderr || '').split('\n').find(l => l.startsWith('fatal:'));
+        throw new Error(fatal ? `git ${args[0]} failed — ${fatal.replace(/^fatal:\s*/, '')}` : `git ${args[0]} failed: ${e.message}`, { cause: e });
+    }
+}
+
+/**
+ * Export the project as it was at a ref into a temporary directory.
+ * @param {string} root - Project root (may be below the git top level)
+ * @param {string} ref - Git ref
+ * @param {object} [config] - Root config to analyze the export with
+ * @returns {{root: string, cleanup: Function}} The project root inside the export
+ */
+function checkoutBase(root, ref, config = {}) {
+    // Same check as diff-impact: a ref can't smuggle in an option
+    if (!/^[a-zA-Z0-9._\-~/^@{}:]+$/.test(ref) || ref.startsWith('-')) {
+        throw new Error(`Invalid git ref format: ${ref}`);
+    }
+    let prefix;
+    try {
+        prefix = execFileSync('git', ['rev-parse', '--show-prefix'], { cwd: root, encoding: 'utf-8', stdio: ['ignore', 'pipe', 'ignore'] }).trim();
+    } catch (e) {
+        throw new Error('Not a git repository. diff requires git.', { cause: e });
+    }
+    try {
+        git(root, ['rev-parse', '--verify', '--quiet', `${ref}^{commit}`]);
+    } catch (e) {
+        throw new Error(`Unknown git ref: ${ref}`, { cause: e });
+    }
+    const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'ucn-base-'));
+    const cleanup = () => fs.rmSync(dir, { recursive: true, force: true });
+    try {
+        const env = { GIT_INDEX_FILE: path.join(dir, 'index') };
+        git(root, ['read-tree', ref], env);
+        git(root, ['checkout-index', '--all', `--prefix=${path.join(dir, 'tree')}${path.sep}`], env);
+        const baseRoot = path.join(dir, 'tree', prefix);
+        fs.mkdirSync(baseRoot, { recursive: true });
+        // The current config, as .ucn.json: it outranks the export's own
+        // .ucn.yaml or ucn.toml, and marks the export as the project root,
+        // which .git no longer does there
+        fs.writeFileSync(path.join(baseRoot, '.ucn.json'), JSON.stringify(config, null, 2) + '\n');
+        return { root: baseRoot, cleanup };
+    } catch (e) {
+        cleanup();
+        throw e;
+    }
+}
+
+/**
+ * The command's result narrowed to the findings introduced since a ref,
+ * with the resolved ones attached as `diff`.
+ * @param {object} index - ProjectIndex of the current tree
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result on the current tree
+ * @param {string} ref - Base git ref
+ * @param {Function} run - (index) => the same command's result on another index
+ * @returns {*} Result of the introduced findings, its `diff` = { base, introduced, resolved }
+ */
+function compareWithBase(index, command, result, ref, run) {
+    const { ProjectIndex } = require('./project');
+    const base = checkoutBase(index.root, ref, index.config);
+    let baseResult;
+    try {
+        const baseIndex = new ProjectIndex(base.root);
+        baseIndex.build(null, { quiet: true });
+        baseResult = run(baseIndex);
+    } finally {
+        base.cleanup();
+    }
+    const introduced = applyBaseline(command, result, createBaseline(command, baseResult)).result;
+    const resolved = applyBaseline(command, baseResult, createBaseline(command, result)).result;
+    const diff = {
+        base: ref,
+        introduced: [...findingsOf(command, introduced)].length,
+        resolved: [...findingsOf(command, resolved)].map(f => ({
+            rule: f.rule,
+            file: f.at.file,
+            line: f.at.startLine,
+            message: f.message,
+        })),
+    };
+    // deadcode's result is an array with properties riding on it
+    return Array.isArray(introduced) ? Object.assign(introduced, { diff }) : { ...introduced, diff };
+}
+
+module.exports = { checkoutBase, compareWithBase, git };
@@ -6,7 +6,7 @@
 
 const fs = require('fs');
 const path = require('path');
-const { langTraits } = require('../languages');
+const { langTraits, getOptionalExtensions } = require('../languages');
 
 // Always ignore - unambiguous, never user code
 const DEFAULT_IGNORES = [
@@ -28,6 +28,7 @@ const DEFAULT_IGNORES = [
     '.tox',
     '.eggs',
     '*.egg-info',
+    '.ipynb_checkpoints',
 
     // Build outputs
     'dist',
@@ -51,6 +52,8 @@ const DEFAULT_IGNORES = [
     'coverage',
     '.nyc_output',
     '.pytest_cache',
+    '.dart_tool',
+    '.terraform',
     '.mypy_cache',
 
     // Bundled/minified
@@ -70,11 +73,18 @@ const CONDITIONAL_IGNORES = {
     'Pods':        ['Podfile'],                              // iOS CocoaPods
     'Carthage':    ['Cartfile'],                             // iOS Carthage
     'deps':        ['mix.exs', 'rebar.config'],              // Elixir, Erlang
+    '_build':      ['mix.exs', 'rebar.config'],              // Elixir, Erlang build output
+    'zig-cache':   ['build.zig'],                            // Zig build cache
+    '.zig-cache':  ['build.zig'],
+    'zig-out':     ['build.zig'],                            // Zig install prefix
+    'lua_modules': ['.luarc.json', '.busted'],               // LuaRocks project tree
     'target':      ['Cargo.toml', 'pom.xml', 'build.gradle'], // Rust, Maven, Gradle
     'env':         ['requirements.txt', 'pyproject.toml'],   // Python virtualenv
 };
 
-// Project root markers
+// Project root markers. .ucn.yaml, .ucn.yml and ucn.toml are left out:
+// below the root they override a directory's settings (core/config.js)
+// rather than start a project of their own
 const PROJECT_MARKERS = [
     '.git',
     '.ucn.json',
@@ -85,6 +95,9 @@ const PROJECT_MARKERS = [
     'Cargo.toml',
     'pom.xml',
     'build.gradle',
+    'mix.exs',
+    'build.zig',
+    'pubspec.yaml',
     'Makefile'
 ];
 
@@ -118,6 +131,63 @@ const TEST_PATTERNS = {
     rust: [
         /.*_test\.rs$/,
         /(^|\/)tests\//
+    ],
+    elixir: [
+        /.*_test\.exs$/,
+        /(^|\/)test\/support\//
+    ],
+    zig: [
+        /.*_test\.zig$/,
+        /(^|\/)tests?\//
+    ],
+    lua: [
+        /.*_spec\.lua$/,
+        /.*_test\.lua$/,
+        /(^|\/)spec\//
+    ],
+    dart: [
+        /.*_test\.dart$/,
+        /(^|\/)(test|integration_test)\//
+    ],
+    bash: [
+        /.*_test\.(sh|bash)$/,
+        /(^|\/)test_[^/]*\.(sh|bash)$/,
+        /(^|\/)tests?\//
+    ],
+    sql: [
+        /.*_test\.(sql|pgsql)$/,
+        /(^|\/)tests?\//
+    ],
+    hcl: [
+        /\.tftest\.hcl$/,
+        /(^|\/)tests\//
+    ],
+    proto: [
+        /(^|\/)tests?\//
+    ],
+    graphql: [
+        /(^|\/)(tests?|__tests__)\//
+    ],
+    vue: [
+        /(^|\/)__tests__\//,
+        /(^|\/)tests?\/(unit|e2e|components)\//
+    ],
+    objc: [
+        /Tests?\.m$/,
+        /(^|\/)\w*Tests\//
+    ],
+    haskell: [
+        /(Spec|Test)\.hs$/,
+        /(^|\/)tests?\//
+    ],
+    groovy: [
+        /(Spec|Test|Tests)\.groovy$/,
+        /(^|\/)src\/(test|integrationTest|functionalTest)\//
+    ],
+    ocaml: [
+        /(^|\/)test_[^/]*\.ml$/,
+        /_test\.ml$/,
+        /(^|\/)tests?\//
     ]
 };
 
@@ -289,6 +359,62 @@ function globToRegex(glob) {
     return new RegExp('^' + regex + '$');
 }
 
+/** Whether an --include/--exclude pattern is a glob or `re:` regex rather than a plain word */
+function isPathPattern(pattern) {
+    return pattern.startsWith('re:') || /[*?{]|^\/|\/$/.test(pattern);
+}
+
+/**
+ * Compile one --include/--exclude path pattern.
+ *
+ * `re:<regex>` is a regular expression tested anywhere in the path. A
+ * pattern with a glob character (`*`, `?`, `{`), or a leading or trailing
+ * `/`, is a doublestar glob, as in .gitignore: `**` crosses directories (`**\/` also matches
+ * none), a leading or inner `/` anchors it at the project root, and
+ * without one it matches any file or directory name. A directory also
+ * covers everything below it.
+ * @param {string} pattern
+ * @returns {Function|null} (relativePath) => boolean; null for a plain word,
+ *   which matchesFilters treats as a path-segment keyword (`test`, `mock`)
+ */
+function compilePathPattern(pattern) {
+    if (!isPathPattern(pattern)) return null;
+    if (pattern.startsWith('re:')) {
+        const re = new RegExp(pattern.slice(3));
+        return rel => re.test(rel.replace(/\\/g, '/'));
+    }
+
+    const glob = pattern.replace(/^\.\//, '/').replace(/\/$/, '');
+    const anchored = glob.includes('/');
+    const source = glob.replace(/^\//, '').replace(/[.+^$[\]\\()|]/g, '\\$&')
+        .replace(/\{([^}]+)\}/g, (_, group) => '(' + group.split(',').map(t => t.trim()).join('|') + ')')
+        .replace(/\*\*\//g, '\0ANYDIRS\0')
+        .replace(/\/\*\*$/, '\0BELOW\0')
+        .replace(/\*\*/g, '\0GLOBSTAR\0')
+        .replace(/\*/g, '[^/]*')
+        .replace(/\?/g, '[^/]')
+        .replace(/\0ANYDIRS\0/g, '(.*/)?')
+        .replace(/\0BELOW\0/g, '(/.*)?')
+        .replace(/\0GLOBSTAR\0/g, '.*');
+    // Anchored at the root with a `/`, else at any directory boundary;
+    // a match on a directory covers the files below it
+    const re = new RegExp((anchored ? '^' : '(^|/)') + source + '(/|$)');
+    return rel => re.test(rel.replace(/\\/g, '/'));
+}
+
+/**
+ * Whether a relative path passes --include/--exclude path patterns (the
+ * ones compilePathPattern compiles; plain words are ignored here).
+ * @param {string} rel - Path relative to the project root
+ * @param {{include?: string[], exclude?: string[]}} filters
+ */
+function passesPathPatterns(rel, { include, exclude } = {}) {
+    const compiled = list => (list || []).map(compilePathPattern).filter(Boolean);
+    const inc = compiled(include);
+    if (inc.length > 0 && !inc.some(test => test(rel))) return false;
+    return !compiled(exclude).some(test => test(rel));
+}
+
 /**
  * Walk a directory tree, calling onFile for each matching file
  */
@@ -415,13 +541,13 @@ function findProjectRoot(startDir) {
 // All file extensions for languages UCN supports as code analysis (excludes .rb/.php/.c/.cpp etc.
 // which are extensions UCN scans but doesn't analyze). When build manifests can't tell us
 // what's in a project, we scan all of these — the file extension alone determines language.
-const ALL_SUPPORTED_EXTENSIONS = ['js', 'jsx', 'ts', 'tsx', 'mjs', 'cjs', 'py', 'go', 'java', 'rs', 'html', 'htm'];
+const ALL_SUPPORTED_EXTENSIONS = ['js', 'jsx', 'ts', 'tsx', 'mjs', 'cjs', 'py', 'go', 'java', 'rs', 'html', 'htm', 'vue', 'svelte'];
 
 // Build-manifest hints: when present, we know the project has files of that language
 // regardless of whether sources are visible at the time of scan. Used as hints, not gates —
 // any source file extension is included whether or not its manifest is present.
 const MANIFEST_HINTS = {
-    'package.json':      ['js', 'jsx', 'ts', 'tsx', 'mjs', 'cjs', 'html', 'htm'],
+    'package.json':      ['js', 'jsx', 'ts', 'tsx', 'mjs', 'cjs', 'html', 'htm', 'vue', 'svelte'],
     'pyproject.toml':    ['py'],
     'setup.py':          ['py'],
     'requirements.txt':  ['py'],
@@ -430,6 +556,11 @@ const MANIFEST_HINTS = {
     'pom.xml':           ['java'],
     'build.gradle':      ['java'],
     'build.gradle.kts':  ['java'],
+    'mix.exs':           ['ex', 'exs'],
+    'build.zig':         ['zig'],
+    '.luarc.json':       ['lua'],
+    'pubspec.yaml':      ['dart'],
+    '.terraform.lock.hcl': ['tf'],
 };
 
 /**
@@ -450,7 +581,10 @@ const MANIFEST_HINTS = {
 function detectProjectPattern(projectRoot) {
     // Always scan all supported language extensions. Build manifests no longer gate
     // language inclusion — file extension alone determines what gets analyzed.
-    return `**/*.{${ALL_SUPPORTED_EXTENSIONS.join(',')}}`;
+    // Optional-grammar languages (Elixir, ...) join only when their grammar
+    // package is installed — otherwise their files could not be parsed.
+    const exts = [...ALL_SUPPORTED_EXTENSIONS, ...getOptionalExtensions()];
+    return `**/*.{${exts.join(',')}}`;
 }
 
 /**
@@ -576,6 +710,9 @@ module.exports = {
     expandGlob,
     parseGlobPattern,
     globToRegex,
+    isPathPattern,
+    compilePathPattern,
+    passesPathPatterns,
     walkDir,
     shouldIgnore,
     findProjectRoot,
@@ -8,6 +8,12 @@
  * Two detection methods:
  * 1. Decorator/modifier matching (Python, Java, Rust, JS/TS decorators)
  * 2. Call-pattern matching (Express routes, Gin handlers, Go http.HandleFunc)
+ *
+ * A project's config adds its own: .ucn.json "entryPoints" name patterns,
+ * packages and registries (core/config.js), listed with type "config".
+ * `"builtinEntryPoints": false` turns the built-in detection off, leaving
+ * only those (and the top-level calls and handler registries that run
+ * code whatever the config says) as reachability roots.
  */
 
 'use strict';
@@ -16,6 +22,7 @@ const fs = require('fs');
 const { codeUnitCompare, NON_CALLABLE_TYPES } = require('./shared');
 const path = require('path');
 const { getCachedCalls } = require('./callers');
+const { resolveImport } = require('./imports');
 const { getLanguageModule } = require('../languages');
 
 // ============================================================================
@@ -456,6 +463,18 @@ const FRAMEWORK_PATTERNS = [
         pathPattern: /(^|\/)(pages|app)\/.*\.(js|ts|jsx|tsx|mjs|cjs)$/,
     },
 
+    // React code splitting: a module loaded by `lazy(() => import('./Page'))`
+    // has its default export rendered by React; a React Router `lazy:` route
+    // module also hands the router its Component/loader/action exports.
+    // Detected from the importer's fileEntry.lazyImports.
+    {
+        id: 'react-lazy',
+        languages: JS_LANGS,
+        type: 'runtime',
+        framework: 'react',
+        detection: 'lazyImport',
+    },
+
     // ── Python Runtime Entry Points ────────────────────────────────────
 
     // __main__.py — package executable entry (python -m pkg).
@@ -497,6 +516,12 @@ const FRAMEWORK_PATTERNS = [
     },
 ];
 
+// Route-module exports React Router reads from a `lazy:` import
+const REACT_ROUTE_MODULE_EXPORTS = new Set([
+    'Component', 'ErrorBoundary', 'HydrateFallback', 'loader', 'action',
+    'handle', 'shouldRevalidate', 'element', 'errorElement',
+]);
+
 // ============================================================================
 // DETECTION
 // ============================================================================
@@ -549,7 +574,8 @@ function matchDecoratorOrModifier(symbol, language) {
 function buildCallbackEntrypointMap(index) {
     const callPatterns = FRAMEWORK_PATTERNS.filter(p => p.detection === 'callPattern');
     const compositePatterns = FRAMEWORK_PATTERNS.filter(p => p.detection === 'compositePattern');
-    if (callPatterns.length === 0 && compositePatterns.length === 0) return new Map();
+    const lazyPatterns = FRAMEWORK_PATTERNS.filter(p => p.detection === 'lazyImport');
+    if (callPatterns.length === 0 && compositePatterns.length === 0 && lazyPatterns.length === 0) return new Map();
 
     const result = new Map(); // name -> info
 
@@ -697,6 +723,39 @@ function buildCallbackEntrypointMap(index) {
                 }
             }
         }
+
+        // Pass 4: lazy imports (React.lazy, route `lazy:`). The consumed
+        // exports live in the IMPORTED module; the import is the evidence.
+        const lazyPattern = fileEntry.lazyImports && lazyPatterns.find(p => p.languages.has(lang));
+        if (lazyPattern) {
+            for (const lazy of fileEntry.lazyImports) {
+                const target = resolveImport(lazy.module, filePath, {
+                    aliases: index.config.aliases,
+                    language: lang,
+                    root: index.root
+                });
+                const targetEntry = target && index.files.get(target);
+                if (!targetEntry) continue;
+                for (const exp of targetEntry.exportDetails || []) {
+                    const consumed = (exp.type === 'default' && exp.name !== 'default') ||
+                        (lazy.kind === 'route' && exp.type === 'named' && REACT_ROUTE_MODULE_EXPORTS.has(exp.name));
+                    if (!consumed || result.has(exp.name)) continue;
+                    const def = (index.symbols.get(exp.name) || []).find(d => d.file === target);
+                    if (!def) continue;
+                    result.set(exp.name, {
+                        framework: lazyPattern.framework,
+                        type: lazyPattern.type,
+                        patternId: lazyPattern.id,
+                        method: 'lazy',
+                        evidence: lazy.kind === 'route' ? 'lazy route module export' : 'lazy component',
+                        file: def.file,
+                        line: def.startLine,
+                        registrationFile: filePath,
+                        registrationLine: lazy.line,
+                    });
+                }
+            }
+        }
     }
 
     return result;
@@ -716,7 +775,7 @@ function detectEntrypoints(index, options = {}) {
     // Validate --type against the pattern registry up front — an unknown
     // value used to fall through to the filter and silently return nothing.
     if (options.type) {
-        const validTypes = new Set(FRAMEWORK_PATTERNS.map(p => p.type));
+        const validTypes = new Set([...FRAMEWORK_PATTERNS.map(p => p.type), 'config']);
         if (!validTypes.has(options.type)) {
             return {
                 error: 'invalid-type',
@@ -728,7 +787,7 @@ function detectEntrypoints(index, options = {}) {
     // Same discipline for --framework (fix #243) — a typo like 'flsk'
     // silently filtered everything to an empty result.
     if (options.framework) {
-        const validFrameworks = new Set(FRAMEWORK_PATTERNS.map(p => p.framework.toLowerCase()));
+        const validFrameworks = new Set([...FRAMEWORK_PATTERNS.map(p => p.framework.toLowerCase()), 'ucn']);
         const wanted = String(options.framework).split(',').map(s => s.trim().toLowerCase()).filter(Boolean);
         const unknown = wanted.filter(f => !validFrameworks.has(f));
         if (unknown.length > 0) {
@@ -740,15 +799,24 @@ function detectEntrypoints(index, options = {}) {
     }
 
     // Build callback entrypoint map (call-pattern detection)
-    const callbackMap = buildCallbackEntrypointMap(index);
+    const builtin = builtinEntryPoints(index);
+    const callbackMap = builtin ? buildCallbackEntrypointMap(index) : new Map();
 
     const results = [];
     const seen = new Set(); // file:line:name dedup key
 
+    // Configured entry points come first: the config's word wins over a
+    // convention's label for the same symbol
+    for (const ep of configuredEntrypoints(index)) {
+        seen.add(`${ep.absoluteFile}:${ep.line}:${ep.name}`);
+        results.push(ep);
+    }
+
     // Collect name-based patterns for efficient matching
-    const namePatterns = FRAMEWORK_PATTERNS.filter(p => p.detection === 'namePattern');
-    const filePathPatterns = FRAMEWORK_PATTERNS.filter(p => p.detection === 'filePath');
-    const shebangPatterns = FRAMEWORK_PATTERNS.filter(p => p.detection === 'shebang');
+    const builtinPatterns = builtin ? FRAMEWORK_PATTERNS : [];
+    const namePatterns = builtinPatterns.filter(p => p.detection === 'namePattern');
+    const filePathPatterns = builtinPatterns.filter(p => p.detection === 'filePath');
+    const shebangPatterns = builtinPatterns.filter(p => p.detection === 'shebang');
 
     // 0. Pre-compute per-file pattern matches for filePath and shebang detection.
     //    These mark every symbol in a file as an entry point.
@@ -805,7 +873,7 @@ function detectEntrypoints(index, options = {}) {
             if (!fileEntry) continue;
 
             // Check decorator/modifier-based patterns
-            const match = matchDecoratorOrModifier(symbol, fileEntry.language);
+            const match = builtin && matchDecoratorOrModifier(symbol, fileEntry.language);
             if (match) {
                 const key = `${symbol.file}:${symbol.startLine}:${name}`;
                 if (seen.has(key)) continue;
@@ -863,7 +931,7 @@ function detectEntrypoints(index, options = {}) {
         if (seen.has(key)) continue;
         seen.add(key);
 
-        let evidence = `${info.method} route handler`;
+        let evidence = info.evidence || `${info.method} route handler`;
         let registeredAt;
         if (info.registrationFile) {
             const regEntry = index.files.get(info.registrationFile);
@@ -1061,6 +1129,110 @@ function detectEntrypoints(index, options = {}) {
     return filtered;
 }
 
+// ============================================================================
+// CONFIGURED ENTRY POINTS
+// ============================================================================
+
+/** Whether the built-in conventions and framework patterns find entry points (.ucn.json "builtinEntryPoints") */
+function builtinEntryPoints(index) {
+    return index.config?.builtinEntryPoints !== false;
+}
+
+/**
+ * Names a symbol can be qualified with in an entryPoints pattern: its
+ * class, its Go package (`main.main`), and its file's name (`app.main`).
+ * @param {object} index - ProjectIndex
+ * @param {object} symbol - Symbol
+ * @returns {string[]}
+ */
+function symbolQualifiers(index, symbol) {
+    const out = symbol.className ? [symbol.className] : [];
+    const fileEntry = index.files.get(symbol.file);
+    if (fileEntry?.language === 'go') {
+        if (!index._goPackages) index._goPackages = new Map();
+        if (!index._goPackages.has(symbol.file)) {
+            let pkg = null;
+            try {
+                pkg = /^\s*package\s+(\w+)/m.exec(fs.readFileSync(symbol.file, 'utf8'))?.[1] || null;
+            } catch (_e) { /* unreadable — no package qualifier */ }
+            index._goPackages.set(symbol.file, pkg);
+        }
+        const pkg = index._goPackages.get(symbol.file);
+        if (pkg) out.push(pkg);
+    }
+    out.push(path.basename(symbol.file).replace(/\.[^.]+$/, ''));
+    return out;
+}
+
+/**
+ * Functions passed to the calls an entryPoints registry names
+ * (`{ "registry": "mux.HandleFunc" }`), as call patterns find route
+ * handlers: a function reference on the registration call's line that
+ * resolves to a project symbol.
+ * @param {object} index - ProjectIndex
+ * @returns {Map<string, {symbol, registry, registrationFile, registrationLine}>} By symbolKey
+ */
+function configuredRegistryHandlers(index) {
+    if (index._configuredRegistryHandlers) return index._configuredRegistryHandlers;
+    const { registryMatches } = require('./config');
+    const result = new Map();
+    for (const [filePath, fileEntry] of index.files) {
+        const registries = index.entryPointRegistries?.(fileEntry.relativePath || filePath) || [];
+        if (registries.length === 0) continue;
+        const calls = getCachedCalls(index, filePath);
+        if (!calls) continue;
+        const registrations = new Map(); // line -> registry pattern
+        for (const call of calls) {
+            const registry = registries.find(r => registryMatches(r, call));
+            if (registry) registrations.set(call.line, registry);
+        }
+        if (registrations.size === 0) continue;
+        for (const call of calls) {
+            if (!call.isFunctionReference && !call.isPotentialCallback) continue;
+            const registry = registrations.get(call.line);
+            if (!registry) continue;
+            const defs = index.symbols.get(call.name);
+            if (!defs || defs.length === 0) continue;
+            const def = defs.find(d => d.file === filePath) || defs[0];
+            const key = symbolKey(def.file, def.startLine);
+            if (!result.has(key)) {
+                result.set(key, { symbol: def, registry, registrationFile: fileEntry.relativePath || filePath, registrationLine: call.line });
+            }
+        }
+    }
+    index._configuredRegistryHandlers = result;
+    return result;
+}
+
+/**
+ * Entry points the config declares, in detectEntrypoints' shape.
+ * @param {object} index - ProjectIndex
+ * @returns {Array<{ name, file, absoluteFile, line, type, framework, patternId, evidence, confidence }>}
+ */
+function configuredEntrypoints(index) {
+    const results = [];
+    const entry = (symbol, evidence) => results.push({
+        name: symbol.name,
+        file: symbol.relativePath || symbol.file,
+        absoluteFile: symbol.file,
+        line: symbol.startLine,
+        type: 'config',
+        framework: 'ucn',
+        patternId: 'ucn-config',
+        evidence: [evidence],
+        confidence: 1.0,
+    });
+    const registered = configuredRegistryHandlers(index);
+    for (const [, symbols] of index.symbols) {
+        for (const symbol of symbols) {
+            const reg = registered.get(symbolKey(symbol.file, symbol.startLine));
+            if (reg) entry(symbol, `registered with ${reg.registry} at ${reg.registrationFile}:${reg.registrationLine}`);
+            else if (index.isConfiguredEntryPoint?.(symbol)) entry(symbol, '.ucn.json entryPoints');
+        }
+    }
+    return results;
+}
+
 // ============================================================================
 // REACHABILITY
 // ============================================================================
@@ -1078,52 +1250,31 @@ function symbolKey(file, line) {
 }
 
 /**
- * Compute the set of symbols transitively reachable from any detected entry point.
- *
- * Performs BFS through the call graph starting from every entry point (framework
- * handlers, main/init, test functions, etc.) and following findCallees recursively.
- *
- * Result is cached on the index instance as `index._reachableSymbols` to avoid
- * recomputation. Subsequent calls return the cached Set.
+ * Roots of reachability: framework and convention entry points
+ * (detectEntrypoints), language entry-point kinds, .ucn.json "entryPoints",
+ * handler-registry members and the callees of JS/TS top-level calls.
  *
  * @param {object} index - ProjectIndex instance
- * @returns {Set<string>} Set of symbol keys (file:startLine) reachable from entry points
+ * @returns {Array<{symbol: object, via: string}>} One per symbol, with why it is a root
  */
-function computeReachability(index) {
-    // PERF-1: when _reachableSymbols was loaded from the disk cache, verify
-    // the index hasn't drifted (e.g. because the cache was stale and a partial
-    // rebuild ran after load). If the fingerprint doesn't match, drop the
-    // cached set and recompute.
-    if (index._reachableSymbols) {
-        if (index._reachableFingerprint) {
-            const { _computeReachabilityFingerprint } = require('./cache');
-            const currentFingerprint = _computeReachabilityFingerprint(index);
-            if (currentFingerprint === index._reachableFingerprint) {
-                return index._reachableSymbols;
-            }
-            // Drift: drop stale set, recompute below.
-            index._reachableSymbols = null;
-            index._reachableFingerprint = null;
-        } else {
-            // Computed in-process this run (no fingerprint) — already trustworthy.
-            return index._reachableSymbols;
-        }
-    }
-
-    // The BFS runs thousands of findCallees calls — the per-operation caches
-    // (call counts, usage totals, content) halve its cost. Wrap so callers
-    // outside a command op (evals, direct API use) get the same warm path;
-    // _beginOp nests, so command-op callers are unaffected.
-    index._beginOp?.();
-    try {
-    const reachable = new Set();
+function reachabilitySeeds(index) {
+    const seeds = new Map();
+    const seed = (symbol, via) => {
+        const key = symbolKey(symbol.file, symbol.startLine);
+        if (!seeds.has(key)) seeds.set(key, { symbol, via });
+    };
     const entryPoints = detectEntrypoints(index);
 
     // Seed BFS queue from every entry point's matching symbol(s) in the symbol table.
     // detectEntrypoints returns entry-point hits with absoluteFile + line + name; we resolve
     // each to a real symbol object by matching name and (absoluteFile, line).
-    const queue = [];
     for (const ep of entryPoints) {
+        if (ep.type === 'config') {
+            // Registry handlers; plain config matches are seeded below
+            const reg = configuredRegistryHandlers(index).get(symbolKey(ep.absoluteFile, ep.line));
+            if (reg) seed(reg.symbol, `handler registered with ${reg.registry} (.ucn.json)`);
+            continue;
+        }
         const symbols = index.symbols.get(ep.name);
         if (!symbols) continue;
         // Match by absoluteFile + line (entry-point line should match symbol startLine).
@@ -1131,13 +1282,7 @@ function computeReachability(index) {
         const match = symbols.find(s =>
             s.file === ep.absoluteFile && s.startLine === ep.line
         ) || symbols.find(s => s.file === ep.absoluteFile);
-        if (match) {
-            const key = symbolKey(match.file, match.startLine);
-            if (!reachable.has(key)) {
-                reachable.add(key);
-                queue.push(match);
-            }
-        }
+        if (match) seed(match, `${ep.framework ? `${ep.framework} ` : ''}${ep.type}`);
     }
 
     // BUG-BE root cause 1: also seed from per-language getEntryPointKind() predicates.
@@ -1145,7 +1290,7 @@ function computeReachability(index) {
     // each language module's getEntryPointKind() (which classifies React lifecycle methods,
     // @Test annotations, Rust #[cfg(test)] modules, Go Test*/main, etc.). Without this
     // pass those entries are never seeded, so anything reachable only via them is reported
-    // as unreachable. The reachable Set already dedupes against the framework-pattern pass.
+    // as unreachable. The seed map already dedupes against the framework-pattern pass.
     const langModuleCache = new Map();
     for (const [, symbols] of index.symbols) {
         for (const symbol of symbols) {
@@ -1154,13 +1299,9 @@ function computeReachability(index) {
             // incoming edge to discover. Treat these members as conservative
             // roots so deadcode never presents a dynamically reachable handler
             // as safe to remove; callees then flow through the normal BFS.
-            if (symbol.registryMember) {
-                const registryKey = symbolKey(symbol.file, symbol.startLine);
-                if (!reachable.has(registryKey)) {
-                    reachable.add(registryKey);
-                    queue.push(symbol);
-                }
-            }
+            if (symbol.registryMember) seed(symbol, 'handler registry member');
+            // Entry points a config declares (.ucn.json "entryPoints")
+            if (index.isConfiguredEntryPoint?.(symbol)) seed(symbol, '.ucn.json entry point');
             const fileEntry = index.files.get(symbol.file);
             if (!fileEntry) continue;
             const lang = fileEntry.language;
@@ -1175,7 +1316,7 @@ function computeReachability(index) {
                 }
                 langModuleCache.set(lang, langModule);
             }
-            if (!langModule || !langModule.getEntryPointKind) continue;
+            if (!langModule || !langModule.getEntryPointKind || !builtinEntryPoints(index)) continue;
             let kind;
             try {
                 kind = langModule.getEntryPointKind(symbol);
@@ -1183,11 +1324,7 @@ function computeReachability(index) {
                 continue;
             }
             if (kind == null) continue;
-            const key = symbolKey(symbol.file, symbol.startLine);
-            if (!reachable.has(key)) {
-                reachable.add(key);
-                queue.push(symbol);
-            }
+            seed(symbol, `${fileEntry.language} ${kind}`);
         }
     }
 
@@ -1214,16 +1351,58 @@ function computeReachability(index) {
             for (const cname of names) {
                 const symbols = index.symbols.get(cname);
                 if (!symbols) continue;
-                for (const sym of symbols) {
-                    const key = symbolKey(sym.file, sym.startLine);
-                    if (!reachable.has(key)) {
-                        reachable.add(key);
-                        queue.push(sym);
-                    }
-                }
+                for (const sym of symbols) seed(sym, `called at the top level of ${fileEntry.relativePath}`);
             }
         }
     }
+    return [...seeds.values()];
+}
+
+/**
+ * Compute the set of symbols transitively reachable from any detected entry point.
+ *
+ * Performs BFS through the call graph starting from every entry point (framework
+ * handlers, main/init, test functions, etc.) and following findCallees recursively.
+ *
+ * Result is cached on the index instance as `index._reachableSymbols` to avoid
+ * recomputation. Subsequent calls return the cached Set.
+ *
+ * @param {object} index - ProjectIndex instance
+ * @returns {Set<string>} Set of symbol keys (file:startLine) reachable from entry points
+ */
+function computeReachability(index) {
+    // PERF-1: when _reachableSymbols was loaded from the disk cache, verify
+    // the index hasn't drifted (e.g. because the cache was stale and a partial
+    // rebuild ran after load). If the fingerprint doesn't match, drop the
+    // cached set and recompute.
+    if (index._reachableSymbols) {
+        if (index._reachableFingerprint) {
+            const { _computeReachabilityFingerprint } = require('./cache');
+            const currentFingerprint = _computeReachabilityFingerprint(index);
+            if (currentFingerprint === index._reachableFingerprint) {
+                return index._reachableSymbols;
+            }
+            // Drift: drop stale set, recompute below.
+            index._reachableSymbols = null;
+            index._reachableFingerprint = null;
+        } else {
+            // Computed in-process this run (no fingerprint) — already trustworthy.
+            return index._reachableSymbols;
+        }
+    }
+
+    // The BFS runs thousands of findCallees calls — the per-operation caches
+    // (call counts, usage totals, content) halve its cost. Wrap so callers
+    // outside a command op (evals, direct API use) get the same warm path;
+    // _beginOp nests, so command-op callers are unaffected.
+    index._beginOp?.();
+    try {
+    const reachable = new Set();
+    const queue = [];
+    for (const { symbol } of reachabilitySeeds(index)) {
+        reachable.add(symbolKey(symbol.file, symbol.startLine));
+        queue.push(symbol);
+    }
 
     // BFS: walk callees of every reachable symbol.
     // findCallees returns full symbol objects for every callee with file/startLine.
@@ -1281,6 +1460,58 @@ function isReachable(index, symbolKeyStr) {
     return reachable.has(symbolKeyStr);
 }
 
+/**
+ * Shortest call chain from a reachability root to a symbol: the BFS of
+ * computeReachability, remembering the caller each symbol was first
+ * reached from, stopped as soon as the symbol is reached.
+ *
+ * @param {object} index - ProjectIndex instance
+ * @param {object} target - Symbol (file, startLine)
+ * @returns {{via: string, chain: object[]}|null} The chain from the root to
+ *   the target and why the root is one; null when no root reaches it
+ */
+function reachabilityPath(index, target) {
+    index._beginOp?.();
+    try {
+        const targetKey = symbolKey(target.file, target.startLine);
+        const reachedFrom = new Map(); // key → { symbol, from: key of the caller, via (roots) }
+        const queue = [];
+        for (const { symbol, via } of reachabilitySeeds(index)) {
+            reachedFrom.set(symbolKey(symbol.file, symbol.startLine), { symbol, from: null, via });
+            queue.push(symbol);
+        }
+        for (let qi = 0; qi < queue.length && !reachedFrom.has(targetKey); qi++) {
+            const sym = queue[qi];
+            if (!sym.file || sym.startLine == null) continue;
+            let callees;
+            try {
+                callees = index.findCallees(sym, { includeMethods: true });
+            } catch (_e) {
+                continue;
+            }
+            const from = symbolKey(sym.file, sym.startLine);
+            for (const c of callees || []) {
+                if (!c.file || c.startLine == null) continue;
+                const key = symbolKey(c.file, c.startLine);
+                if (reachedFrom.has(key)) continue;
+                reachedFrom.set(key, { symbol: c, from });
+                queue.push(c);
+            }
+        }
+        if (!reachedFrom.has(targetKey)) return null;
+        const chain = [];
+        let via = null;
+        for (let key = targetKey; key; key = reachedFrom.get(key).from) {
+            const step = reachedFrom.get(key);
+            chain.unshift(step.symbol);
+            via = step.via;
+        }
+        return { via, chain };
+    } finally {
+        index._endOp?.();
+    }
+}
+
 /**
  * Check if a specific symbol is a framework entry point.
  * Used by deadcode to exclude framework-registered functions.
@@ -1291,7 +1522,7 @@ function isReachable(index, symbolKeyStr) {
  */
 function isFrameworkEntrypoint(symbol, index) {
     const fileEntry = index.files.get(symbol.file);
-    if (!fileEntry) return false;
+    if (!fileEntry || !builtinEntryPoints(index)) return false;
 
     // Fast path: check decorator/modifier patterns (no index scan needed)
     if (matchDecoratorOrModifier(symbol, fileEntry.language)) {
@@ -1313,7 +1544,12 @@ module.exports = {
     isFrameworkEntrypoint,
     matchDecoratorOrModifier,
     buildCallbackEntrypointMap,
+    builtinEntryPoints,
+    symbolQualifiers,
+    configuredRegistryHandlers,
     computeReachability,
+    reachabilitySeeds,
+    reachabilityPath,
     isReachable,
     symbolKey,
 };
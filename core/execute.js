@@ -131,7 +131,8 @@ function buildCallerOptions(p) {
 
 /** Kinds the `class` command extracts (fix #248: Java records and their
  *  fn-side suggestion were unreachable — indexed as type 'record'). */
-const CLASS_KIND_TYPES = ['class', 'interface', 'type', 'enum', 'struct', 'trait', 'record', 'namespace'];
+const CLASS_KIND_TYPES = ['class', 'interface', 'type', 'enum', 'struct', 'trait', 'record', 'namespace',
+    'table', 'view'];
 
 /**
  * Disambiguation advice that can actually work (fix #248): --file cannot
@@ -216,6 +217,110 @@ function truncationNote(index) {
     return `Index limited to ${index.truncated.indexed} files (max ${index.truncated.maxFiles}). Results may be incomplete. Use --max-files N to increase.`;
 }
 
+/**
+ * A finding command's result, from `run` (the index call that builds
+ * it): without findings in generated files (unless includeGenerated),
+ * with ucn:ignore comments applied, under diffBase narrowed to the
+ * findings introduced since that git ref, and without the findings the
+ * baseline file holds. Runs before --top and --limit, so they count what
+ * is shown.
+ */
+function findingResult(index, command, run, p) {
+    const prepare = ix => applyInlineSuppressions(ix, command,
+        withoutGenerated(ix, command, withoutDisabledRules(ix, command, run(ix)), p), p);
+    let result = prepare(index);
+    if (p.diffBase) {
+        const { compareWithBase } = require('./diff');
+        result = compareWithBase(index, command, result, p.diffBase, prepare);
+    }
+    if (p.baseline) result = withoutBaselined(command, result, p);
+    return result;
+}
+
+/**
+ * Drop the findings the baseline file p.baseline holds; the count goes
+ * to p.baselineNote (unless quiet). Throws on a missing or invalid file.
+ */
+function withoutBaselined(command, result, p) {
+    const { parseBaseline, applyBaseline } = require('./baseline');
+    let text;
+    try {
+        text = fs.readFileSync(p.baseline, 'utf-8');
+    } catch (e) {
+        throw new Error(`Baseline not found: ${p.baseline}`);
+    }
+    const { baseline, error } = parseBaseline(text);
+    if (error) throw new Error(`Invalid baseline ${p.baseline}: ${error}`);
+    const applied = applyBaseline(command, result, baseline);
+    if (applied.suppressed > 0 && !p.quiet) p.baselineNote = `${applied.suppressed} known finding(s) hidden by baseline ${p.baseline}`;
+    return applied.result;
+}
+
+/** A finding command's note: its own, the baseline's and the index truncation's */
+function findingNote(index, p, note) {
+    return [note, p.baselineNote, truncationNote(index)].filter(Boolean).join('\n') || undefined;
+}
+
+/**
+ * Mark a finding result that --top or --limit cut: limitInfo says how
+ * much, and carries the whole result (not enumerated, so JSON and
+ * comparisons see only the counts) for the fail policy.
+ */
+function withLimitInfo(sliced, info, whole) {
+    Object.defineProperty(info, 'whole', { value: whole, enumerable: false });
+    Object.defineProperty(sliced, 'limitInfo', { value: info, enumerable: false, writable: true, configurable: true });
+    return sliced;
+}
+
+/**
+ * Drop the findings that sit in generated files (core/generated.js).
+ * deadcode counts them in excludedGenerated, for the hint.
+ */
+function withoutGenerated(index, command, result, p) {
+    if (p.includeGenerated) return result;
+    const { findingsOf, withoutFindings } = require('./output/sarif');
+    const { generatedFileTest } = require('./generated');
+    const markers = toExcludeArray(p.generatedMarkers);
+    const generated = generatedFileTest(index, markers);
+    const drop = new Set();
+    for (const f of findingsOf(command, result)) {
+        if (generated(f.at.file)) drop.add(f.data);
+    }
+    const kept = withoutFindings(command, result, drop);
+    if (command === 'deadcode' && drop.size > 0) {
+        kept.excludedGenerated = [...drop].filter(d => result.includes(d)).length;
+    }
+    return kept;
+}
+
+/** Drop the findings of rules a config turns off for their directory */
+function withoutDisabledRules(index, command, result) {
+    const { findingsOf, withoutFindings, ruleDisabled } = require('./output/sarif');
+    const drop = new Set();
+    for (const f of findingsOf(command, result)) {
+        if (ruleDisabled(index.configFor(f.at.file).rules, f.rule)) drop.add(f.data);
+    }
+    return withoutFindings(command, result, drop);
+}
+
+/**
+ * Drop the findings ucn:ignore comments and .ucn.json "ignore" entries
+ * hide; their report (active and stale) rides on the result.
+ */
+function applyInlineSuppressions(index, command, result, p) {
+    const { applySuppressions } = require('./suppress');
+    const { result: kept, suppressions } = applySuppressions(index, command, result, {
+        file: p.file, in: p.in, exclude: toExcludeArray(p.exclude), includeTests: p.includeTests || false,
+        include: index.pathFilters?.include,
+    });
+    if (!suppressions) return result;
+    if (Array.isArray(kept)) {
+        kept.suppressions = suppressions;
+        return kept;
+    }
+    return { ...kept, suppressions };
+}
+
 /** Build notes for tree-based results (blast, trace, reverseTrace, affectedTests). */
 function treeNote(result) {
     const parts = [];
@@ -594,8 +699,12 @@ const HANDLERS = {
     },
 
     check: (index, p) => {
-        const { check } = require('./check');
+        const { check, checkBuffer } = require('./check');
         try {
+            // An unsaved buffer (check --stdin) stands in for its file
+            if (p.content != null) {
+                return { ok: true, result: checkBuffer(index, p) };
+            }
             const result = check(index, {
                 base: p.base || 'HEAD',
                 staged: !!p.staged,
@@ -911,36 +1020,88 @@ const HANDLERS = {
             }
             if (!anyIn) return { ok: false, error: `No files matched the 'in' directory filter '${p.in}'.` };
         }
-        let result = index.deadcode({
+        for (const [key, flag] of [['maxCyclomatic', '--max-cyclomatic'], ['maxCognitive', '--max-cognitive'],
+            ['maxFunctionLines', '--max-function-lines'], ['maxStatements', '--max-statements'],
+            ['maxMethods', '--max-methods'], ['maxFields', '--max-fields']]) {
+            if (p[key] == null) continue;
+            const n = Number(p[key]);
+            if (!Number.isInteger(n) || n < 0) {
+                return { ok: false, error: `Invalid ${flag} value: must be a non-negative integer (got ${p[key]})` };
+            }
+        }
+        if (p.platforms && !require('./deadcode').parsePlatforms(p.platforms)) {
+            return { ok: false, error: `Invalid --platforms value: expected goos/goarch entries such as linux/amd64 (got ${p.platforms})` };
+        }
+        let result = findingResult(index, 'deadcode', ix => ix.deadcode({
             includeExported: p.includeExported || false,
             includeDecorated: p.includeDecorated || false,
             includeTests: p.includeTests || false,
+            interfaceMethods: p.interfaceMethods || false,
+            unusedParams: p.unusedParams || false,
+            unreachableCode: p.unreachableCode || false,
+            packageVars: p.packageVars || false,
+            types: p.types || false,
+            unusedResults: p.unusedResults || false,
+            orphanFiles: p.orphanFiles || false,
+            buildVariants: p.buildVariants || false,
+            platforms: p.platforms,
+            testOnly: p.testOnly || false,
+            testHelpers: p.testHelpers || false,
+            sentinelErrors: p.sentinelErrors || false,
+            library: p.library || false,
+            dependents: p.dependents,
+            embeds: p.embeds || false,
+            channels: p.channels || false,
+            configKnobs: p.configKnobs || false,
+            satisfiesOnly: p.satisfiesOnly || false,
+            redundantAssertions: p.redundantAssertions || false,
+            typeParams: p.typeParams || false,
+            initEffects: p.initEffects || false,
+            importIssues: p.importIssues || false,
+            configKeys: p.configKeys || false,
+            complexity: p.complexity || p.maxCyclomatic != null || p.maxCognitive != null,
+            maxCyclomatic: num(p.maxCyclomatic, undefined),
+            maxCognitive: num(p.maxCognitive, undefined),
+            size: p.size || [p.maxFunctionLines, p.maxStatements, p.maxMethods, p.maxFields].some(v => v != null),
+            maxFunctionLines: num(p.maxFunctionLines, undefined),
+            maxStatements: num(p.maxStatements, undefined),
+            maxMethods: num(p.maxMethods, undefined),
+            maxFields: num(p.maxFields, undefined),
             exclude: toExcludeArray(p.exclude),
             in: p.in,
             file: p.file,
-        });
-        // Apply limit to dead code results (result is an array with custom properties)
+        }), p);
+        // --top keeps the N findings whose deletion removes the most lines,
+        // then --limit caps what is left (result is an array with custom properties)
+        const top = num(p.top, undefined);
         const limit = num(p.limit, undefined);
         let note;
-        if (limit && limit > 0 && Array.isArray(result) && result.length > limit) {
-            note = limitNote(limit, result.length);
-            const sliced = result.slice(0, limit);
-            // Preserve custom properties (excludedExported, excludedDecorated, excludedExternalContract) from deadcode()
-            if (result.excludedExported != null) sliced.excludedExported = result.excludedExported;
-            if (result.excludedDecorated != null) sliced.excludedDecorated = result.excludedDecorated;
-            if (result.excludedExternalContract != null) sliced.excludedExternalContract = result.excludedExternalContract;
+        if (Array.isArray(result) && ((top > 0 && result.length > top) || (limit > 0 && result.length > limit))) {
+            const { findingsOf, deadLines } = require('./output/sarif');
+            const lines = new Map();
+            for (const f of findingsOf('deadcode', result.slice())) lines.set(f.data, deadLines(f));
+            let total = 0;
+            for (const n of lines.values()) total += n;
+            let items = result;
+            if (top > 0 && result.length > top) {
+                // Stable sort: equal sizes keep the command's order
+                items = result.slice().sort((a, b) => (lines.get(b) || 0) - (lines.get(a) || 0)).slice(0, top);
+                const shownLines = items.reduce((n, item) => n + (lines.get(item) || 0), 0);
+                note = `Showing the ${top} largest of ${result.length} dead-code findings: ${shownLines} of ${total} removable lines.`;
+            }
+            if (limit > 0 && items.length > limit) {
+                note = note ? `${note}\n${limitNote(limit, items.length)}` : limitNote(limit, items.length);
+                items = items.slice(0, limit);
+            }
+            const sliced = Object.assign(items.slice(), Object.fromEntries(
+                Object.entries(result).filter(([k]) => !/^\d+$/.test(k))));
             // Truncation must be visible IN the JSON payload, not only in the
             // stderr note (fix #242) — the formatter reads this to emit
-            // meta.total + truncated.
-            Object.defineProperty(sliced, 'limitInfo', {
-                value: { total: result.length, shown: limit },
-                enumerable: false, writable: true, configurable: true,
-            });
-            result = sliced;
+            // meta.total + truncated, and deadLines the whole result's
+            // removable lines.
+            result = withLimitInfo(sliced, { total: result.length, shown: sliced.length, deadLines: total, ...(top > 0 && { top }) }, result);
         }
-        const tNote = truncationNote(index);
-        if (tNote) note = note ? `${note}\n${tNote}` : tNote;
-        return { ok: true, result, note };
+        return { ok: true, result, note: findingNote(index, p, note) };
     },
 
     entrypoints: (index, p) => {
@@ -1366,6 +1527,23 @@ const HANDLERS = {
     },
 
     graph: (index, p) => {
+        if (p.symbols) {
+            const fileErr = checkFilePatternMatch(index, p.file);
+            if (fileErr) return { ok: false, error: fileErr };
+            const result = index.symbolGraph({
+                pattern: p.name || undefined,
+                file: p.file,
+                in: p.in,
+                exclude: toExcludeArray(p.exclude),
+                includeTests: p.includeTests,
+                direction: p.direction || 'both',
+                maxDepth: num(p.depth, 2),
+            });
+            if (result && result.error === 'invalid-direction') {
+                return { ok: false, error: result.message };
+            }
+            return { ok: true, result };
+        }
         const err = requireFile(p.file);
         if (err) return { ok: false, error: err };
         const result = index.graph(p.file, {
@@ -1574,20 +1752,118 @@ const HANDLERS = {
             const fileErr = checkFilePatternMatch(index, p.file);
             if (fileErr) return { ok: false, error: fileErr };
         }
-        let result = index.auditAsync({
+        let result = findingResult(index, 'auditAsync', ix => ix.auditAsync({
             file: p.file,
             exclude: toExcludeArray(p.exclude),
-        });
+        }), p);
         // Apply limit to the issues array.
         const limit = num(p.limit, undefined);
         let note;
         if (limit && limit > 0 && result && Array.isArray(result.issues) && result.issues.length > limit) {
             note = limitNote(limit, result.issues.length);
-            result = { ...result, issues: result.issues.slice(0, limit) };
+            result = withLimitInfo({ ...result, issues: result.issues.slice(0, limit) }, { total: result.issues.length, shown: limit }, result);
         }
-        const tNote = truncationNote(index);
-        if (tNote) note = note ? `${note}\n${tNote}` : tNote;
-        return { ok: true, result, note };
+        return { ok: true, result, note: findingNote(index, p, note) };
+    },
+
+    clones: (index, p) => {
+        const fileErr = checkFilePatternMatch(index, p.file);
+        if (fileErr) return { ok: false, error: fileErr };
+        for (const [key, flag] of [['minLines', '--min-lines'], ['minTokens', '--min-tokens'], ['minFiles', '--min-files']]) {
+            if (p[key] == null) continue;
+            const n = Number(p[key]);
+            if (!Number.isInteger(n) || n <= 0) {
+                return { ok: false, error: `Invalid ${flag} value: must be a positive integer (got ${p[key]})` };
+            }
+        }
+        const similarity = num(p.similarity, undefined);
+        if (p.similarity != null && !(similarity > 0 && similarity <= 1)) {
+            return { ok: false, error: `Invalid --similarity value: must be a number in (0, 1] (got ${p.similarity})` };
+        }
+        if (p.literals || p.minFiles != null) {
+            let result = findingResult(index, 'clones', ix => ix.findDuplicateLiterals({
+                minFiles: num(p.minFiles, undefined),
+                file: p.file,
+                exclude: toExcludeArray(p.exclude),
+                in: p.in,
+                includeTests: p.includeTests || false,
+            }), p);
+            const limit = num(p.limit, undefined);
+            let note;
+            if (limit && limit > 0 && result.literals.length > limit) {
+                note = limitNote(limit, result.literals.length);
+                result = withLimitInfo({ ...result, literals: result.literals.slice(0, limit), totalLiterals: result.literals.length },
+                    { total: result.literals.length, shown: limit }, result);
+            }
+            return { ok: true, result, note: findingNote(index, p, note) };
+        }
+        let result = findingResult(index, 'clones', ix => ix.findClones({
+            minLines: num(p.minLines, undefined),
+            minTokens: num(p.minTokens, undefined),
+            similarity,
+            file: p.file,
+            exclude: toExcludeArray(p.exclude),
+            in: p.in,
+            includeTests: p.includeTests || false,
+        }), p);
+        const limit = num(p.limit, undefined);
+        let note;
+        if (limit && limit > 0 && result.groups.length > limit) {
+            note = limitNote(limit, result.groups.length);
+            result = withLimitInfo({ ...result, groups: result.groups.slice(0, limit), totalGroups: result.groups.length },
+                { total: result.groups.length, shown: limit }, result);
+        }
+        return { ok: true, result, note: findingNote(index, p, note) };
+    },
+
+    deprecated: (index, p) => {
+        const fileErr = checkFilePatternMatch(index, p.file);
+        if (fileErr) return { ok: false, error: fileErr };
+        const result = findingResult(index, 'deprecated', ix => ix.findDeprecated({
+            file: p.file,
+            exclude: toExcludeArray(p.exclude),
+            in: p.in,
+            includeTests: p.includeTests || false,
+        }), p);
+        return { ok: true, result, note: findingNote(index, p) };
+    },
+
+    rules: (index) => {
+        const { ruleList } = require('./explain');
+        return { ok: true, result: { rules: ruleList(index.config?.rules) } };
+    },
+
+    explain: (index, p) => {
+        if (!p.name) return { ok: false, error: 'Rule or finding fingerprint is required (ucn explain <rule-or-fingerprint>).' };
+        const { explain } = require('./explain');
+        const result = explain(index, String(p.name).trim(), {
+            ...p,
+            exclude: toExcludeArray(p.exclude),
+            depth: num(p.depth, undefined),
+        });
+        if (result.error) return { ok: false, error: result.error };
+        return { ok: true, result };
+    },
+
+    why: (index, p) => {
+        const err = requireName(p.name);
+        if (err) return { ok: false, error: err };
+        applyClassMethodSyntax(p);
+        const fileErr = checkFilePatternMatch(index, p.file);
+        if (fileErr) return { ok: false, error: fileErr };
+        const classErr = validateClassName(index, p.name, p.className);
+        if (classErr) return { ok: false, error: classErr };
+        const pinErr = checkDefinitionPin(index, p);
+        if (pinErr) return { ok: false, error: pinErr };
+        const { why } = require('./explain');
+        const result = why(index, p.name, {
+            file: p.file,
+            className: p.className,
+            line: num(p.line, undefined),
+        });
+        if (result.error) return { ok: false, error: result.error };
+        const note = truncationNote(index);
+        return { ok: true, result, ...(note && { note }) };
     },
 
     // ── Expand (context drill-down) ──────────────────────────────────────
@@ -1673,4 +1949,4 @@ function lookupByLocation(index, file, line) {
     return null;
 }
 
-module.exports = { execute };
+module.exports = { execute, lookupByLocation };
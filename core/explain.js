@@ -0,0 +1,242 @@
+/**
+ * core/explain.js — Rule reference and finding explanations
+ * (ucn rules, ucn explain <rule-or-fingerprint>)
+ *
+ * `rules` lists every rule the finding commands report: its id and name,
+ * what it flags, its default level, severity and confidence, the command
+ * and flag that check it, and what .ucn.json "rules" sets for it.
+ *
+ * `explain` takes a rule (id or name), or a finding's fingerprint as
+ * --format jsonl, SARIF and baselines carry it. A rule gets its entry.
+ * A fingerprint is looked up by running the finding commands, with the
+ * run's own flags, until one reports it. A symbol deadcode calls unused
+ * then gets the checks that could have kept it (entry points, framework
+ * registration, .ucn.json "entryPoints", exports) and the reachability
+ * trace that was attempted: its callers, walked up to where they stop,
+ * and whether any of them is reachable from an entry point.
+ *
+ * `why` takes a symbol and prints the shortest call chain from a
+ * reachability root (an entry point, a .ucn.json "entryPoints" match, a
+ * handler registry member or a top-level call) to it, or says there is
+ * none and lists its direct callers, so a surprising used or unused
+ * verdict can be followed back to where it comes from.
+ */
+
+'use strict';
+
+const path = require('path');
+const { RULES, findingsOf, ruleSetting, ruleDisabled, defaultConfidence, SEVERITY_OF_LEVEL } = require('./output/sarif');
+
+// Rule id → [canonical command, flag that turns its check on]
+const RULE_SOURCES = {
+    'dead-code': ['deadcode', null],
+    'interface-method': ['deadcode', '--interface-methods'],
+    'unused-param': ['deadcode', '--unused-params'],
+    'unused-type-param': ['deadcode', '--type-params'],
+    'unreachable-code': ['deadcode', '--unreachable-code'],
+    'package-var': ['deadcode', '--package-vars'],
+    'sentinel-error': ['deadcode', '--sentinel-errors'],
+    'unused-results': ['deadcode', '--unused-results'],
+    'orphan-file': ['deadcode', '--orphan-files'],
+    'build-variant': ['deadcode', '--build-variants'],
+    'test-only': ['deadcode', '--test-only'],
+    'unused-fixture': ['deadcode', '--test-helpers'],
+    'library-api': ['deadcode', '--library'],
+    'unused-embed': ['deadcode', '--embeds'],
+    'channel': ['deadcode', '--channels'],
+    'config-knob': ['deadcode', '--config-knobs'],
+    'satisfies-only': ['deadcode', '--satisfies-only'],
+    'redundant-assertion': ['deadcode', '--redundant-assertions'],
+    'dead-init': ['deadcode', '--init-effects'],
+    'blank-import': ['deadcode', '--init-effects'],
+    'import-unused': ['deadcode', '--import-issues'],
+    'import-duplicate': ['deadcode', '--import-issues'],
+    'import-shadowed': ['deadcode', '--import-issues'],
+    'config-key': ['deadcode', '--config-keys'],
+    'config-field': ['deadcode', '--config-keys'],
+    'complexity': ['deadcode', '--complexity'],
+    'size': ['deadcode', '--size'],
+    'clone': ['clones', null],
+    'duplicate-literal': ['clones', '--literals'],
+    'missing-await': ['auditAsync', null],
+    'deprecated-use': ['deprecated', null],
+    'deprecated-unused': ['deprecated', null],
+};
+
+// deadcode's mode flags (params), any of which picks the rules it reports
+const DEADCODE_MODES = ['interfaceMethods', 'unusedParams', 'typeParams', 'unreachableCode', 'packageVars',
+    'sentinelErrors', 'unusedResults', 'orphanFiles', 'buildVariants', 'testOnly', 'testHelpers', 'library',
+    'embeds', 'channels', 'configKnobs', 'satisfiesOnly', 'redundantAssertions', 'initEffects', 'importIssues',
+    'configKeys', 'complexity', 'size'];
+
+const FINGERPRINT = /^[0-9a-f]{16}$/i;
+
+/** Rule id of a rule id or name (any case), or null */
+function ruleIdOf(name) {
+    if (RULES[name]) return name;
+    const lower = String(name).toLowerCase();
+    return Object.keys(RULES).find(id => id === lower || RULES[id][0].toLowerCase() === lower) || null;
+}
+
+/**
+ * Every rule, with its defaults and configuration.
+ * @param {object} [rules] - .ucn.json "rules"
+ * @returns {Array<{id, name, description, level, severity, confidence, command, flag, configured?}>}
+ */
+function ruleList(rules) {
+    const { toCliName } = require('./registry');
+    return Object.entries(RULES).map(([id, [name, description, level]]) => {
+        const [command, flag] = RULE_SOURCES[id];
+        const set = ruleSetting(rules, id);
+        const configured = ruleDisabled(rules, id) ? { enabled: false }
+            : set.severity || set.confidence ? { ...(set.severity && { severity: set.severity }), ...(set.confidence && { confidence: set.confidence }) }
+                : null;
+        return {
+            id,
+            name,
+            description,
+            level,
+            severity: SEVERITY_OF_LEVEL[level],
+            confidence: defaultConfidence(id, null),
+            command: toCliName(command),
+            flag,
+            ...(configured && { configured }),
+        };
+    });
+}
+
+/**
+ * Why a dead-code symbol was called unused: the checks that could have
+ * kept it, and the caller trace up from it.
+ * @param {object} index - ProjectIndex
+ * @param {object} item - deadcode result entry
+ * @param {object} options - { depth } of the caller trace
+ */
+function whyUnused(index, item, options) {
+    const { getLanguageModule } = require('../languages');
+    const { isFrameworkEntrypoint, builtinEntryPoints, computeReachability, detectEntrypoints, symbolKey } = require('./entrypoints');
+    const { symbolIsExported } = require('./deadcode');
+    const symbol = (index.symbols.get(item.name) || []).find(s =>
+        s.relativePath === item.file && s.startLine === item.startLine);
+    if (!symbol) return null;
+    const fileEntry = index.files.get(symbol.file);
+
+    let langEntry = false;
+    try {
+        langEntry = builtinEntryPoints(index) && !!getLanguageModule(fileEntry?.language)?.isEntryPoint?.(symbol);
+    } catch { /* no module */ }
+    const framework = isFrameworkEntrypoint(symbol, index);
+    const exported = symbolIsExported(index, symbol, fileEntry);
+    const checks = [
+        { check: 'language entry point', passed: langEntry, note: 'main, init, test functions and the other names a runtime calls' },
+        { check: '.ucn.json "entryPoints"', passed: !!index.isConfiguredEntryPoint?.(symbol) },
+        { check: 'framework registration', passed: framework, note: framework ? 'reported because of --include-decorated' : 'decorators, annotations and route registrations' },
+        { check: 'exported', passed: exported, note: exported ? 'audited because of --include-exported' : 'public API may have callers outside the project' },
+    ];
+
+    const reachable = computeReachability(index);
+    const isReachable = node => reachable.has(symbolKey(path.join(index.root, node.file), node.line));
+    const trace = index.reverseTrace(item.name, {
+        file: item.file, className: item.className, line: item.startLine, depth: options.depth ?? 3,
+    });
+    const mark = (node) => {
+        if (!node) return null;
+        return {
+            name: node.name,
+            file: node.file,
+            line: node.line,
+            reachable: isReachable(node),
+            ...(node.entryPoint && { noCallers: true }),
+            ...(node.unverifiedCallerCount && { unverifiedCallers: node.unverifiedCallerCount }),
+            ...(node.alreadyShown && { alreadyShown: true }),
+            callers: (node.children || []).map(mark),
+        };
+    };
+    return {
+        checks,
+        reachability: {
+            entryPoints: detectEntrypoints(index).length,
+            reachable: isReachable({ file: item.file, line: item.startLine }),
+            trace: mark(trace?.tree),
+            ...(trace?.summary && { depth: trace.maxDepth, truncated: trace.summary.maxDepthReached >= trace.maxDepth }),
+        },
+    };
+}
+
+/**
+ * A rule's entry, or a finding's explanation.
+ * @param {object} index - ProjectIndex
+ * @param {string} target - Rule id or name, or a finding fingerprint
+ * @param {object} [options] - The finding command's params (deadcode
+ *   modes, includeTests, ...), and depth of the caller trace
+ * @returns {{rule: object}|{fingerprint, command, finding, rule, why?}|{error: string}}
+ */
+function explain(index, target, options = {}) {
+    const ruleId = ruleIdOf(target);
+    if (ruleId) return { rule: ruleList(index.config?.rules).find(r => r.id === ruleId) };
+    if (!FINGERPRINT.test(target)) {
+        return { error: `"${target}" is neither a rule nor a finding fingerprint (16 hex digits). Run "ucn rules" for the rules.` };
+    }
+    const fingerprint = target.toLowerCase();
+    const { execute } = require('./execute');
+    const { toCliName } = require('./registry');
+    const commands = DEADCODE_MODES.some(m => options[m]) ? ['deadcode']
+        : options.literals ? ['clones'] : ['deadcode', 'auditAsync', 'deprecated', 'clones'];
+    const { name: _name, depth: _depth, ...params } = options;
+    for (const command of commands) {
+        const { ok, result } = execute(index, command, params);
+        if (!ok) continue;
+        const f = [...findingsOf(command, result)].find(x => x.fingerprint === fingerprint);
+        if (!f) continue;
+        return {
+            fingerprint,
+            command: toCliName(command),
+            finding: { rule: f.rule, message: f.message, ...f.at, ...(f.related?.length && { related: f.related }) },
+            rule: ruleList(index.config?.rules).find(r => r.id === f.rule),
+            ...(f.rule === 'dead-code' && { why: whyUnused(index, f.data, options) }),
+        };
+    }
+    return {
+        error: `No finding with fingerprint ${fingerprint} in ${commands.map(toCliName).join(', ')}. ` +
+            'Pass the flags of the run that reported it (e.g. --unused-params, --literals, --include-tests).',
+    };
+}
+
+/**
+ * Shortest call chain from a reachability root to a symbol.
+ * @param {object} index - ProjectIndex
+ * @param {string} name - Symbol name
+ * @param {object} [options] - { file, className, line } to pick a definition
+ * @returns {{symbol, reachable, via?, chain, callers?, unverifiedCallers?, warnings?}|{error: string}}
+ */
+function why(index, name, options = {}) {
+    const { reachabilityPath } = require('./entrypoints');
+    const { def, warnings } = index.resolveSymbol(name, options);
+    if (!def) return { error: `Symbol "${name}" not found.` };
+    const at = s => ({
+        name: s.className && !String(s.name).includes('.') ? `${s.className}.${s.name}` : s.name,
+        file: s.relativePath || path.relative(index.root, s.file),
+        line: s.startLine,
+    });
+    const symbol = at(def);
+    const found = reachabilityPath(index, def);
+    if (found) {
+        return { symbol, reachable: true, via: found.via, chain: found.chain.map(at), ...(warnings?.length && { warnings }) };
+    }
+    // No chain: who calls it directly, to show where the chain breaks
+    const trace = index.reverseTrace(def.name, {
+        file: symbol.file, className: def.className, line: def.startLine, depth: 1,
+    });
+    const callers = (trace?.tree?.children || []).map(c => ({ name: c.name, file: c.file, line: c.line }));
+    const unverified = trace?.tree?.unverifiedCallerCount || 0;
+    return {
+        symbol,
+        reachable: false,
+        chain: [],
+        callers,
+        ...(unverified && { unverifiedCallers: unverified }),
+        ...(warnings?.length && { warnings }),
+    };
+}
+
+module.exports = { ruleList, explain, why, ruleIdOf, RULE_SOURCES };
@@ -0,0 +1,118 @@
+/**
+ * core/fail-policy.js — Exit code policy for finding commands
+ * (--fail-on, --max-findings, --max-dead-lines)
+ *
+ * A run fails (exit code 1) when a finding reaches the --fail-on severity
+ * (error, warning, info; `any` is every finding, `none` never fails), or
+ * when a rule has more findings than its --max-findings budget. Budgets
+ * are `rule=N` by rule id or name, or a bare `N` for all findings
+ * together. A dead code budget, --max-dead-lines, fails the run only when
+ * the lines deleting the findings would remove add up to more than it.
+ * .ucn.json "failOn", "maxFindings" and "maxDeadLines" set the same for
+ * every run; the flags win. Findings a baseline or ucn:ignore comment
+ * hides don't count, but every budget counts the ones --top or --limit
+ * leave out of the listing: a cut result carries the whole one in its
+ * limitInfo.
+ */
+
+'use strict';
+
+const { findingsOf, deadLines, RULES } = require('./output/sarif');
+
+const FAIL_ON = ['error', 'warning', 'info', 'any', 'none'];
+const SEVERITY_RANK = { info: 1, warning: 2, error: 3 };
+
+/**
+ * Parse --max-findings values.
+ * @param {Array<string>|object} specs - ["dead-code=10", "25"], or a
+ *   .ucn.json "maxFindings" object ({ "dead-code": 10, "*": 25 })
+ * @returns {{budgets: Map<string, number>, error?: string}} keyed by rule
+ *   id, `*` for the total
+ */
+function parseMaxFindings(specs) {
+    const budgets = new Map();
+    const pairs = Array.isArray(specs)
+        ? specs.map(s => (s.includes('=') ? s.split('=') : ['*', s]))
+        : Object.entries(specs || {});
+    for (const [rawRule, rawLimit] of pairs) {
+        const rule = String(rawRule).trim();
+        const limit = Number(rawLimit);
+        if (!Number.isInteger(limit) || limit < 0) {
+            return { budgets, error: `Invalid --max-findings value for ${rule === '*' ? 'all findings' : rule}: must be a non-negative integer (got ${rawLimit})` };
+        }
+        const id = rule === '*' ? '*' : RULES[rule] ? rule : Object.keys(RULES).find(k => RULES[k][0] === rule);
+        if (!id) return { budgets, error: `Unknown rule in --max-findings: ${rule}` };
+        budgets.set(id, limit);
+    }
+    return { budgets };
+}
+
+/**
+ * Parse a --max-dead-lines value.
+ * @param {string|number|undefined} value - Flag or .ucn.json "maxDeadLines"
+ * @returns {{limit: number|null, error?: string}} null when unset
+ */
+function parseMaxDeadLines(value) {
+    if (value == null) return { limit: null };
+    const limit = Number(value);
+    if (!Number.isInteger(limit) || limit < 0 || String(value).trim() === '') {
+        return { limit: null, error: `Invalid --max-dead-lines value: must be a non-negative integer (got ${value})` };
+    }
+    return { limit };
+}
+
+/** The result before --top or --limit cut it */
+function wholeResult(result) {
+    return result?.limitInfo?.whole ?? result;
+}
+
+/**
+ * Lines deleting a result's findings would remove, counting the ones
+ * --top or --limit cut from the listing.
+ * @param {string} command - Canonical command
+ * @param {*} result - The command's result, as shown
+ */
+function deadLineTotal(command, result) {
+    let total = 0;
+    for (const f of findingsOf(command, wholeResult(result))) total += deadLines(f);
+    return total;
+}
+
+/**
+ * Why a result fails the policy.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as shown
+ * @param {object} policy - { failOn, budgets (parseMaxFindings), maxDeadLines,
+ *   rules (as findingsOf takes them) }
+ * @returns {string[]} One line per broken limit; empty when the run passes
+ */
+function policyFailures(command, result, { failOn = 'none', budgets = new Map(), maxDeadLines = null, rules } = {}) {
+    const findings = [...findingsOf(command, wholeResult(result), rules)];
+    const failures = [];
+    if (failOn !== 'none') {
+        const min = failOn === 'any' ? 0 : SEVERITY_RANK[failOn];
+        const hits = findings.filter(f => SEVERITY_RANK[f.severity] >= min).length;
+        if (hits > 0) {
+            failures.push(failOn === 'any'
+                ? `${hits} finding(s) (--fail-on=any)`
+                : `${hits} finding(s) at severity ${failOn} or above (--fail-on=${failOn})`);
+        }
+    }
+    const counts = new Map();
+    for (const f of findings) counts.set(f.rule, (counts.get(f.rule) || 0) + 1);
+    for (const [rule, limit] of budgets) {
+        const count = rule === '*' ? findings.length : counts.get(rule) || 0;
+        if (count > limit) {
+            failures.push(`${count} ${rule === '*' ? 'finding(s)' : `${rule} finding(s)`}, over the budget of ${limit} (--max-findings)`);
+        }
+    }
+    if (maxDeadLines != null) {
+        const total = deadLineTotal(command, result);
+        if (total > maxDeadLines) {
+            failures.push(`${total} dead line(s), over the budget of ${maxDeadLines} (--max-dead-lines)`);
+        }
+    }
+    return failures;
+}
+
+module.exports = { parseMaxFindings, parseMaxDeadLines, deadLineTotal, policyFailures, FAIL_ON };
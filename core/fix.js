@@ -0,0 +1,275 @@
+/**
+ * core/fix.js — Delete the dead code it is safe to delete (ucn fix)
+ *
+ * Only findings nothing outside the file can reach are removed: unused
+ * unexported top-level functions, unused constants (not iota members,
+ * whose removal renumbers the rest) and, under --import-issues, unused
+ * imports with their delete edits. Methods, exported, decorated or
+ * annotated symbols and contract members stay for a person to judge.
+ *
+ * Each removal is located on the syntax tree: the outermost node that
+ * spans exactly the finding's lines must be alone on them, so a
+ * declaration sharing a line with other code (`const a = 1, b = 2`, two
+ * one-line functions) is skipped rather than cut. The removal takes the
+ * declaration's doc comment along, and one of the blank lines around it
+ * when it would otherwise leave two. Removals are whole lines, which is
+ * what the unified diff of --dry-run and `deadcode --format patch` shows.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { deadcodeRule } = require('./output/sarif');
+
+const COMMENT_LINE = /^\s*(\/\/|\/\*|\*|#|--)/;
+// What may follow a declaration on its last line: a semicolon, a comment
+const TRAILER = /^\s*;?\s*(\/\/.*|#.*|\/\*.*\*\/\s*)?$/;
+
+/**
+ * What kind of safe removal a deadcode item is, or null.
+ * @param {object} item - deadcode result entry
+ * @returns {'function'|'constant'|'import'|null}
+ */
+function fixKind(item) {
+    const rule = deadcodeRule(item);
+    if (rule === 'import-unused') {
+        return item.edits?.length && item.edits.every(e => e.newExpression === '') ? 'import' : null;
+    }
+    if (rule !== 'dead-code' || item.isExported || item.className || item.declaredOn || item.externalContract) return null;
+    if (item.decorators?.length || item.annotations?.length) return null;
+    if (item.type === 'function') return 'function';
+    // A whole dead const block goes at once; one iota member would renumber the rest
+    if (item.type === 'const block' || (item.type === 'state' && !item.iota)) return 'constant';
+    return null;
+}
+
+/** Last row a node's text is on (a node ending in a newline ends at column 0 of the next) */
+function lastRow(node) {
+    const { row, column } = node.endPosition;
+    return column === 0 && row > node.startPosition.row ? row - 1 : row;
+}
+
+/** Outermost node whose rows are exactly startRow..endRow, or null */
+function spanningNode(node, startRow, endRow) {
+    if (node.startPosition.row === startRow && lastRow(node) === endRow) return node;
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.startPosition.row <= startRow && lastRow(child) >= endRow) return spanningNode(child, startRow, endRow);
+    }
+    return null;
+}
+
+/** Text of a line from a byte column on (tree-sitter columns count UTF-8 bytes) */
+const fromColumn = (text, column) => Buffer.from(text, 'utf-8').subarray(column).toString('utf-8');
+const toColumn = (text, column) => Buffer.from(text, 'utf-8').subarray(0, column).toString('utf-8');
+
+/** Whether a node has its first and last lines to itself, but for a trailing semicolon or comment */
+function aloneOnLines(node, first, last) {
+    const ending = node.endPosition.column === 0 && node.endPosition.row > node.startPosition.row ? '' : fromColumn(last, node.endPosition.column);
+    return toColumn(first, node.startPosition.column).trim() === '' && TRAILER.test(ending);
+}
+
+/**
+ * Lines to delete for one function or constant, or the reason it can't go.
+ * @returns {{from: number, to: number}|{skip: string}}
+ */
+function declarationLines(tree, lines, item, symbols) {
+    const node = spanningNode(tree.rootNode, item.startLine - 1, item.endLine - 1);
+    if (!node) return { skip: 'its lines are not one syntax node' };
+    if (!aloneOnLines(node, lines[item.startLine - 1], lines[item.endLine - 1])) {
+        return { skip: 'it shares a line with other code' };
+    }
+    const neighbor = symbols.find(s => s.name !== item.name && s.startLine === item.startLine && !s.className);
+    if (neighbor) return { skip: `it shares its declaration with ${neighbor.name}` };
+
+    let from = item.startLine;
+    let to = item.endLine;
+    // Its doc comment goes with it
+    while (from > 1 && COMMENT_LINE.test(lines[from - 2])) from--;
+    // One blank line of the two that would meet
+    const blank = n => n < 1 || n > lines.length || lines[n - 1].trim() === '';
+    if (blank(from - 1) && blank(to + 1) && to + 1 <= lines.length) to++;
+    return { from, to };
+}
+
+/**
+ * Plan the safe removals among deadcode findings.
+ * @param {object} index - ProjectIndex
+ * @param {Array} items - deadcode result entries
+ * @returns {{fixes: Array<{item, kind, file, ranges: Array<[number, number]>}>, skipped: Array<{item, kind, reason}>}}
+ */
+function planFixes(index, items) {
+    const fixes = [];
+    const skipped = [];
+    const byFile = new Map();
+    for (const item of items) {
+        const kind = fixKind(item);
+        if (!kind) continue;
+        if (!byFile.has(item.file)) byFile.set(item.file, []);
+        byFile.get(item.file).push({ item, kind });
+    }
+    for (const [file, candidates] of byFile) {
+        const abs = path.join(index.root, file);
+        const fileEntry = index.files.get(abs);
+        let content;
+        // An editor's unsaved buffer (overlayFile) is what gets edited
+        try { content = index.readFile(abs); } catch {
+            for (const { item, kind } of candidates) skipped.push({ item, kind, reason: 'file not readable' });
+            continue;
+        }
+        const lines = content.split('\n');
+        let tree;
+        for (const { item, kind } of candidates) {
+            if (kind === 'import') {
+                const stale = item.edits.find(e => lines[e.line - 1] !== e.expression);
+                if (stale) skipped.push({ item, kind, reason: 'file changed since the analysis' });
+                else fixes.push({ item, kind, file, ranges: item.edits.map(e => [e.line, e.line]) });
+                continue;
+            }
+            if (tree === undefined) tree = fileEntry ? index.parseFile(abs, content) : null;
+            if (!tree) {
+                skipped.push({ item, kind, reason: 'no syntax tree for the file' });
+                continue;
+            }
+            const span = declarationLines(tree, lines, item, fileEntry?.symbols || []);
+            if (span.skip) skipped.push({ item, kind, reason: span.skip });
+            else fixes.push({ item, kind, file, ranges: [[span.from, span.to]] });
+        }
+    }
+    return { fixes, skipped };
+}
+
+/**
+ * Import lines that removing some lines would leave unused: every name
+ * the line imports is used in the file (per its syntax tree, so comments
+ * and strings don't count) only within the removed lines. Side-effect
+ * imports stay, and so does an import sharing its line with other code
+ * or spanning several lines.
+ * @param {object} index - ProjectIndex
+ * @param {string} file - Path relative to the project root
+ * @param {Array<[number, number]>} ranges - Lines to be removed
+ * @returns {Array<[number, number]>} One [line, line] per import line
+ */
+function orphanedImports(index, file, ranges) {
+    const { extractImports } = require('./imports');
+    const abs = path.join(index.root, file);
+    const lang = index.files.get(abs)?.language;
+    if (!lang) return [];
+    let content;
+    try { content = index.readFile(abs); } catch { return []; }
+    const lines = content.split('\n');
+    const removed = n => ranges.some(([from, to]) => from <= n && n <= to);
+    const byLine = new Map();
+    for (const imp of extractImports(content, lang).imports) {
+        if (imp.dynamic || removed(imp.line)) continue;
+        if (!byLine.has(imp.line)) byLine.set(imp.line, []);
+        byLine.get(imp.line).push(...(imp.names || []));
+    }
+    let tree;
+    const orphaned = [];
+    for (const [line, names] of byLine) {
+        const bound = names.filter(n => n && n !== '*' && n !== '_' && n !== '.');
+        if (bound.length === 0 || bound.length < names.length) continue;
+        const orphan = bound.every((name) => {
+            const uses = (index.usagesInFile(abs, name) || []).filter(u => u.line !== line);
+            return uses.length > 0 && uses.every(u => removed(u.line));
+        });
+        if (!orphan) continue;
+        if (tree === undefined) tree = index.parseFile(abs, content);
+        const node = tree && spanningNode(tree.rootNode, line - 1, line - 1);
+        if (node && aloneOnLines(node, lines[line - 1], lines[line - 1])) orphaned.push([line, line]);
+    }
+    return orphaned.sort((a, b) => a[0] - b[0]);
+}
+
+/** Sorted, merged [from, to] line ranges */
+function mergeRanges(ranges) {
+    const sorted = [...ranges].sort((a, b) => a[0] - b[0]);
+    const out = [];
+    for (const [from, to] of sorted) {
+        const prev = out[out.length - 1];
+        if (prev && from <= prev[1] + 1) prev[1] = Math.max(prev[1], to);
+        else out.push([from, to]);
+    }
+    return out;
+}
+
+/**
+ * New contents of every file the fixes touch.
+ * @param {object} index - ProjectIndex
+ * @param {Array} fixes - From planFixes
+ * @returns {Array<{file, before: string, after: string, ranges: Array<[number, number]>}>}
+ */
+function fixedFiles(index, fixes) {
+    const byFile = new Map();
+    for (const fix of fixes) {
+        if (!byFile.has(fix.file)) byFile.set(fix.file, []);
+        byFile.get(fix.file).push(...fix.ranges);
+    }
+    return [...byFile].sort((a, b) => (a[0] < b[0] ? -1 : a[0] > b[0] ? 1 : 0)).map(([file, ranges]) => {
+        const before = fs.readFileSync(path.join(index.root, file), 'utf-8');
+        const merged = mergeRanges(ranges);
+        const deleted = n => merged.some(([from, to]) => n >= from && n <= to);
+        const after = before.split('\n').filter((_, i) => !deleted(i + 1)).join('\n');
+        return { file, before, after, ranges: merged };
+    });
+}
+
+/**
+ * Unified diff of one file's removals, 3 lines of context.
+ * @param {{file, before, ranges}} change - From fixedFiles
+ * @returns {string} Diff text, newline-terminated
+ */
+function unifiedDiff({ file, before, ranges }) {
+    const lines = before.split('\n');
+    const count = before.endsWith('\n') ? lines.length - 1 : lines.length;
+    const out = [`--- a/${file}`, `+++ b/${file}`];
+    // Hunks: removals whose context overlaps share one
+    const hunks = [];
+    for (const [from, to] of ranges) {
+        const start = Math.max(1, from - 3);
+        const end = Math.min(count, to + 3);
+        const prev = hunks[hunks.length - 1];
+        if (prev && start <= prev.end + 1) {
+            prev.end = end;
+            prev.ranges.push([from, to]);
+        } else {
+            hunks.push({ start, end, ranges: [[from, to]] });
+        }
+    }
+    let removedBefore = 0;
+    for (const h of hunks) {
+        const removed = h.ranges.reduce((n, [from, to]) => n + to - from + 1, 0);
+        const oldCount = h.end - h.start + 1;
+        const newCount = oldCount - removed;
+        const newStart = newCount === 0 ? h.start - removedBefore - 1 : h.start - removedBefore;
+        out.push(`@@ -${h.start},${oldCount} +${newStart},${newCount} @@`);
+        // Removing the last lines of a file without a final newline takes
+        // the newline off the line that becomes last
+        const loseNewline = !before.endsWith('\n') && h.end === count && h.ranges.some(([, to]) => to === count);
+        const newLast = loseNewline ? h.ranges[h.ranges.length - 1][0] - 1 : 0;
+        for (let n = h.start; n <= h.end; n++) {
+            const gone = n === newLast || h.ranges.some(([from, to]) => n >= from && n <= to);
+            out.push(`${gone ? '-' : ' '}${lines[n - 1]}`);
+            if (n === count && !before.endsWith('\n')) out.push('\\ No newline at end of file');
+        }
+        if (newLast > 0) out.push(`+${lines[newLast - 1]}`, '\\ No newline at end of file');
+        removedBefore += removed;
+    }
+    return out.join('\n') + '\n';
+}
+
+/**
+ * One patch of every safe removal among deadcode findings, for git apply.
+ * @param {object} index - ProjectIndex
+ * @param {Array} items - deadcode result entries
+ * @returns {{patch: string, fixes: Array, skipped: Array}} patch is empty when nothing can be removed
+ */
+function fixPatch(index, items) {
+    const { fixes, skipped } = planFixes(index, items);
+    const patch = fixedFiles(index, fixes).map(unifiedDiff).join('');
+    return { patch, fixes, skipped };
+}
+
+module.exports = { fixKind, planFixes, orphanedImports, mergeRanges, fixedFiles, unifiedDiff, fixPatch };
@@ -0,0 +1,67 @@
+/**
+ * core/generated.js — Generated-file detection
+ *
+ * A file is generated when its head carries a generator's marker ("Code
+ * generated ... DO NOT EDIT", protoc's and mockgen's headers, @generated)
+ * or its name is one generators write (*.pb.go, *_pb2.py, *.g.dart).
+ * Generated files stay in the index, since the code they call is used;
+ * finding commands just don't report what sits in them, unless asked to.
+ * Projects with their own generators add markers in .ucn.json
+ * "generatedMarkers" or with --generated-marker.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+
+// Markers in the first HEAD_CHARS characters of a file
+const HEAD_CHARS = 500;
+const GENERATED_HEADER = /^\/\/\s*Code generated\b|^\/\/\s*DO NOT EDIT|^\/\/ @generated|^\/\/ GENERATED CODE - DO NOT MODIFY BY HAND|^\/\/ GENERATED CODE -- DO NOT EDIT|^\/\/ Generated by the protocol buffer compiler|^# Generated by/m;
+
+// Names protoc, grpc, build_runner and friends give their output
+const GENERATED_PATH = /\.pb(\.gw|\.validate)?\.go$|_grpc\.pb\.go$|_pb2(_grpc)?\.pyi?$|\.pb\.(cc|h)$|_pb\.(js|d\.ts)$|_grpc_pb\.(js|d\.ts)$|\.(g|freezed|gr|mocks)\.dart$|(^|[/._-])generated\.\w+$|_gen\.go$|zz_generated[^/]*\.go$/;
+
+/**
+ * Whether content starts like a generated file (the built-in markers).
+ * @param {string} content - File content
+ */
+function hasGeneratedHeader(content) {
+    return GENERATED_HEADER.test(content.slice(0, HEAD_CHARS));
+}
+
+/**
+ * Test for generated files in one index.
+ * @param {object} index - ProjectIndex
+ * @param {string[]} [markers] - Extra markers (plain text) to look for in a
+ *   file's head, on top of .ucn.json "generatedMarkers"
+ * @returns {Function} (relativePath) => boolean
+ */
+function generatedFileTest(index, markers = []) {
+    const extra = [...(index.config?.generatedMarkers || []), ...markers].filter(m => typeof m === 'string' && m !== '');
+    const byRel = new Map();
+    for (const [, fe] of index.files) byRel.set(fe.relativePath, fe);
+    const seen = new Map();
+    return (rel) => {
+        if (!seen.has(rel)) {
+            let generated = !!byRel.get(rel)?.isGenerated || GENERATED_PATH.test(rel.replace(/\\/g, '/'));
+            if (!generated && extra.length > 0) {
+                let head = '';
+                try {
+                    const fd = fs.openSync(path.join(index.root, rel), 'r');
+                    try {
+                        const buf = Buffer.alloc(HEAD_CHARS);
+                        head = buf.toString('utf-8', 0, fs.readSync(fd, buf, 0, HEAD_CHARS, 0));
+                    } finally {
+                        fs.closeSync(fd);
+                    }
+                } catch { /* unreadable: not generated */ }
+                generated = extra.some(m => head.includes(m));
+            }
+            seen.set(rel, generated);
+        }
+        return seen.get(rel);
+    };
+}
+
+module.exports = { hasGeneratedHeader, generatedFileTest, GENERATED_HEADER, GENERATED_PATH };
@@ -35,6 +35,7 @@ function buildDirIndex(index) {
  * imports pull in the whole package, and they are NOT recursive.
  */
 function _resolveJavaPackageImport(index, importModule, javaFileIndex, opts = {}) {
+    const ext = opts.ext || '.java';
     const isWildcard = importModule.endsWith('.*');
     // Strip wildcard suffix (e.g., "com.pkg.Class.*" -> "com.pkg.Class")
     const mod = isWildcard ? importModule.slice(0, -2) : importModule;
@@ -48,7 +49,7 @@ function _resolveJavaPackageImport(index, importModule, javaFileIndex, opts = {}
             const className = segments[i - 1];
             const candidates = javaFileIndex.get(className);
             if (candidates) {
-                const fileSuffix = '/' + segments.slice(0, i).join('/') + '.java';
+                const fileSuffix = '/' + segments.slice(0, i).join('/') + ext;
                 for (const absPath of candidates) {
                     if (absPath.endsWith(fileSuffix)) {
                         return opts.all ? [absPath] : absPath;
@@ -59,7 +60,7 @@ function _resolveJavaPackageImport(index, importModule, javaFileIndex, opts = {}
     } else {
         // Fallback: scan all files (used by imports() method outside buildImportGraph)
         for (let i = segments.length; i > 0; i--) {
-            const fileSuffix = '/' + segments.slice(0, i).join('/') + '.java';
+            const fileSuffix = '/' + segments.slice(0, i).join('/') + ext;
             for (const absPath of index.files.keys()) {
                 if (absPath.endsWith(fileSuffix)) {
                     return opts.all ? [absPath] : absPath;
@@ -76,7 +77,7 @@ function _resolveJavaPackageImport(index, importModule, javaFileIndex, opts = {}
         const dirSuffix = '/' + segments.join('/');
         const matches = [];
         for (const absPath of index.files.keys()) {
-            if (absPath.endsWith('.java') && path.dirname(absPath).endsWith(dirSuffix)) {
+            if (absPath.endsWith(ext) && path.dirname(absPath).endsWith(dirSuffix)) {
                 matches.push(absPath);
                 if (!opts.all) break;
             }
@@ -100,6 +101,14 @@ function buildImportGraph(index) {
     const dirToGoFiles = new Map();
     // Pre-build filename→files map for Java import resolution (O(1) vs O(n) scan)
     const javaFileIndex = new Map();
+    // Header file name → files for Objective-C: Xcode header maps make every
+    // project header importable as `#import "Foo.h"` from any directory
+    const headerFileIndex = new Map();
+    // Compilation unit name → .ml files for OCaml: `Cache.find` names
+    // cache.ml wherever dune puts it
+    const ocamlUnitIndex = new Map();
+    // Class name → .groovy files (Groovy imports use Java package paths)
+    const groovyFileIndex = new Map();
     for (const [fp, fe] of index.files) {
         if (langTraits(fe.language)?.packageScope === 'directory') {
             const dir = path.dirname(fp);
@@ -109,6 +118,18 @@ function buildImportGraph(index) {
             const name = path.basename(fp, '.java');
             if (!javaFileIndex.has(name)) javaFileIndex.set(name, []);
             javaFileIndex.get(name).push(fp);
+        } else if (fe.language === 'groovy' && fp.endsWith('.groovy')) {
+            const name = path.basename(fp, '.groovy');
+            if (!groovyFileIndex.has(name)) groovyFileIndex.set(name, []);
+            groovyFileIndex.get(name).push(fp);
+        } else if (fe.language === 'ocaml') {
+            const name = path.basename(fp, '.ml');
+            if (!ocamlUnitIndex.has(name)) ocamlUnitIndex.set(name, []);
+            ocamlUnitIndex.get(name).push(fp);
+        } else if (fe.language === 'objc' && fp.endsWith('.h')) {
+            const name = path.basename(fp);
+            if (!headerFileIndex.has(name)) headerFileIndex.set(name, []);
+            headerFileIndex.get(name).push(fp);
         }
     }
 
@@ -156,6 +177,27 @@ function buildImportGraph(index) {
                 }
             }
 
+            // Groovy imports name Groovy classes, or Java classes of a
+            // joint-compiled source set
+            if (!resolved && fileEntry.language === 'groovy' && /^[\w$.]+(\.\*)?$/.test(importModule)) {
+                resolved = _resolveJavaPackageImport(index, importModule, groovyFileIndex, { ext: '.groovy' }) ||
+                    _resolveJavaPackageImport(index, importModule, javaFileIndex);
+            }
+
+            // Objective-C header maps: an unambiguous file-name match anywhere
+            // in the project (`"Models/User.h"` matches by its last segment)
+            if (!resolved && fileEntry.language === 'objc') {
+                const candidates = headerFileIndex.get(path.basename(importModule)) || [];
+                if (candidates.length === 1) resolved = candidates[0];
+            }
+
+            if (!resolved && (fileEntry.language === 'ocaml' || fileEntry.language === 'ocaml_interface') &&
+                /^[A-Z]/.test(importModule)) {
+                const unit = importModule[0].toLowerCase() + importModule.slice(1);
+                const candidates = ocamlUnitIndex.get(unit) || [];
+                if (candidates.length === 1) resolved = candidates[0];
+            }
+
             if (resolved && index.files.has(resolved)) {
                 moduleResolved[importModule] = path.relative(index.root, resolved);
                 // For Go, a package import means all files in that directory are dependencies
@@ -215,6 +257,41 @@ function buildImportGraph(index) {
         index.importGraph.set(filePath, importedFiles);
         fileEntry.moduleResolved = moduleResolved;
     }
+
+    stitchLibraryParts(index);
+}
+
+/**
+ * Library parts (Dart `part 'src/x.dart'` / `part of`): a part file is
+ * compiled INTO its library — it is never imported itself, and importing
+ * the library imports everything the parts declare (library-private `_names`
+ * included, across all of the library's files). Link each part both ways
+ * with its library, and from every importer of the library, so the import
+ * graph sees one unit instead of an orphaned part.
+ */
+function stitchLibraryParts(index) {
+    const link = (from, to) => {
+        if (from === to) return;
+        if (!index.importGraph.has(from)) index.importGraph.set(from, new Set());
+        index.importGraph.get(from).add(to);
+        if (!index.exportGraph.has(to)) index.exportGraph.set(to, new Set());
+        index.exportGraph.get(to).add(from);
+    };
+    for (const [libPath, fileEntry] of index.files) {
+        if (!fileEntry.partFiles) continue;
+        const parts = fileEntry.partFiles
+            .map(m => fileEntry.moduleResolved?.[m])
+            .filter(Boolean)
+            .map(rel => path.join(index.root, rel));
+        if (parts.length === 0) continue;
+        const partSet = new Set(parts);
+        const importers = [...(index.exportGraph.get(libPath) || [])].filter(f => !partSet.has(f));
+        for (const part of parts) {
+            link(libPath, part);
+            link(part, libPath);
+            for (const importer of importers) link(importer, part);
+        }
+    }
 }
 
 /**
@@ -347,4 +424,4 @@ function splitParentList(clause) {
         .filter(Boolean);
 }
 
-module.exports = { buildDirIndex, buildImportGraph, buildInheritanceGraph, splitParentList, _resolveJavaPackageImport };
+module.exports = { buildDirIndex, buildImportGraph, buildInheritanceGraph, stitchLibraryParts, splitParentList, _resolveJavaPackageImport };
@@ -10,7 +10,7 @@
 const path = require('path');
 const { codeUnitCompare } = require('./shared');
 const { extractImports, resolveImport } = require('./imports');
-const { langTraits } = require('../languages');
+const { langTraits, getLanguageModule } = require('../languages');
 const { isTestFile } = require('./discovery');
 
 /**
@@ -752,6 +752,176 @@ function graph(index, filePath, options = {}) {
     };
 }
 
+/**
+ * Symbol call graph around the functions whose name matches a pattern.
+ * Seeds are the callable symbols in scope (file/in/exclude, tests only with
+ * includeTests) whose name or Class.name matches the glob; the walk follows
+ * confirmed callees and callers out to maxDepth hops, like the file graph.
+ * Every node carries whether deadcode's entry-point BFS reaches it, and
+ * whether it is an entry point, so the graph shows why something is (or
+ * is not) considered reachable.
+ * @param {object} index - ProjectIndex instance
+ * @param {object} options - { pattern, file, in, exclude, includeTests, direction, maxDepth }
+ * @returns {object} - { symbols: true, pattern, direction, maxDepth, depthTruncated, nodes, edges }
+ */
+function symbolGraph(index, options = {}) {
+    const DIRECTION_ALIASES = {
+        'callees': 'callees', 'out': 'callees', 'outgoing': 'callees', 'downstream': 'callees', 'down': 'callees',
+        'callers': 'callers', 'in': 'callers', 'incoming': 'callers', 'upstream': 'callers', 'up': 'callers',
+        'both': 'both',
+    };
+    const rawDirection = options.direction || 'both';
+    const direction = DIRECTION_ALIASES[rawDirection];
+    if (!direction) {
+        return {
+            error: 'invalid-direction',
+            message: `Unknown direction "${rawDirection}". Valid for --symbols: callees/out/down, callers/in/up, both.`,
+        };
+    }
+    const maxDepth = Math.max(0, options.maxDepth ?? 2);
+    const { globToRegex } = require('./discovery');
+    const { detectEntrypoints, computeReachability } = require('./entrypoints');
+    const { NON_CALLABLE_TYPES } = require('./shared');
+    const namePattern = options.pattern ? globToRegex(options.pattern) : null;
+    const exclude = options.exclude || [];
+
+    index._beginOp();
+    try {
+        const keyOf = (s) => `${s.file}:${s.startLine}`;
+        const inScope = (s) => {
+            const fileEntry = index.files.get(s.file);
+            if (!fileEntry) return false;
+            if (!options.includeTests && isTestFile(fileEntry.relativePath, fileEntry.language)) return false;
+            if (options.file && !fileEntry.relativePath.includes(options.file)) return false;
+            if ((exclude.length > 0 || options.in) &&
+                !index.matchesFilters(fileEntry.relativePath, { exclude, in: options.in })) return false;
+            return true;
+        };
+
+        const seeds = [];
+        for (const [, symbols] of index.symbols) {
+            for (const s of symbols) {
+                if (NON_CALLABLE_TYPES.has(s.type) || !s.file || s.startLine == null) continue;
+                if (namePattern && !namePattern.test(s.name) &&
+                    !(s.className && namePattern.test(`${s.className}.${s.name}`))) continue;
+                if (inScope(s)) seeds.push(s);
+            }
+        }
+
+        const nodes = new Map();
+        const edges = new Map();
+        const addNode = (s, depth) => {
+            const key = keyOf(s);
+            if (nodes.has(key)) return false;
+            nodes.set(key, { symbol: s, depth });
+            return true;
+        };
+        const addEdge = (from, to, calls) => {
+            const key = `${from}\0${to}`;
+            const edge = edges.get(key);
+            if (edge) edge.calls += calls;
+            else edges.set(key, { from, to, calls });
+        };
+        const neighbors = (s) => {
+            const out = [];
+            if (direction !== 'callers') {
+                for (const c of index.findCallees(s, { includeMethods: true })) {
+                    if (c.file && c.startLine != null) out.push({ symbol: c, from: keyOf(s), to: keyOf(c), calls: c.callCount || 1 });
+                }
+            }
+            if (direction !== 'callees') {
+                const callers = index.findCallers(s.name, { includeMethods: true, targetDefinitions: [s] });
+                for (const c of callers) {
+                    if (c.tier === 'unverified' || !c.callerFile) continue;
+                    const caller = index.findEnclosingFunction(c.callerFile, c.line, true);
+                    if (caller) out.push({ symbol: caller, from: keyOf(caller), to: keyOf(s), calls: 1 });
+                }
+            }
+            return out;
+        };
+
+        // Breadth-first, so a node's depth is its hop distance from the
+        // nearest seed; edges leaving the last ring are kept only when both
+        // ends are already in the graph (same rule as the file graph).
+        let depthTruncated = false;
+        const queue = [];
+        for (const s of seeds) {
+            if (addNode(s, 0)) queue.push(s);
+        }
+        const cut = [];
+        for (let qi = 0; qi < queue.length; qi++) {
+            const s = queue[qi];
+            const depth = nodes.get(keyOf(s)).depth;
+            if (depth >= maxDepth) {
+                cut.push(s);
+                continue;
+            }
+            for (const n of neighbors(s)) {
+                addEdge(n.from, n.to, n.calls);
+                if (addNode(n.symbol, depth + 1)) queue.push(n.symbol);
+            }
+        }
+        for (const s of cut) {
+            for (const n of neighbors(s)) {
+                if (nodes.has(keyOf(n.symbol))) addEdge(n.from, n.to, n.calls);
+                else depthTruncated = true;
+            }
+        }
+
+        const reachable = nodes.size > 0 ? computeReachability(index) : new Set();
+        const entries = new Set(nodes.size > 0 ? detectEntrypoints(index).map(ep => `${ep.absoluteFile}:${ep.line}`) : []);
+        // Language-level roots (Go main/Test*, @Test methods, ...) seed the
+        // reachability BFS without showing up in detectEntrypoints.
+        const isLangEntry = (s, fileEntry) => {
+            if (!fileEntry) return false;
+            try {
+                const langModule = getLanguageModule(fileEntry.language);
+                return !!(langModule && langModule.getEntryPointKind && langModule.getEntryPointKind(s) != null);
+            } catch (_e) {
+                return false;
+            }
+        };
+        const ids = new Map();
+        const nodeList = [...nodes.entries()]
+            .map(([key, { symbol: s, depth }]) => {
+                const fileEntry = index.files.get(s.file);
+                return {
+                    key,
+                    name: s.name,
+                    className: s.className || undefined,
+                    type: s.type,
+                    file: fileEntry ? fileEntry.relativePath : path.relative(index.root, s.file),
+                    line: s.startLine,
+                    depth,
+                    seed: depth === 0,
+                    entrypoint: entries.has(key) || isLangEntry(s, fileEntry),
+                    reachable: reachable.has(key),
+                };
+            })
+            .sort((a, b) => codeUnitCompare(a.file, b.file) || a.line - b.line);
+        nodeList.forEach(n => {
+            n.id = `${n.file}:${n.line}`;
+            ids.set(n.key, n.id);
+            delete n.key;
+        });
+        const edgeList = [...edges.values()]
+            .map(e => ({ from: ids.get(e.from), to: ids.get(e.to), calls: e.calls }))
+            .sort((a, b) => codeUnitCompare(a.from, b.from) || codeUnitCompare(a.to, b.to));
+
+        return {
+            symbols: true,
+            pattern: options.pattern || null,
+            direction,
+            maxDepth,
+            depthTruncated,
+            nodes: nodeList,
+            edges: edgeList,
+        };
+    } finally {
+        index._endOp();
+    }
+}
+
 /**
  * Detect circular dependencies in the import graph.
  * Uses DFS with 3-color marking to find all cycles.
@@ -852,4 +1022,4 @@ function circularDeps(index, options = {}) {
     }
 }
 
-module.exports = { imports, exporters, fileExports, api, graph, circularDeps };
+module.exports = { imports, exporters, fileExports, api, graph, symbolGraph, circularDeps };
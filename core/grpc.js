@@ -0,0 +1,108 @@
+/**
+ * core/grpc.js — gRPC service over the API (ucn serve --grpc-port)
+ *
+ * proto/ucn.proto defines ucn.v1.Ucn: Analyze, ListFindings and
+ * GetReferences, the operations of the HTTP API with typed messages, for
+ * services that integrate through generated clients. Both servers share
+ * one createApi() (core/serve.js), so an Analyze over gRPC is what GET
+ * /findings returns, and the other way round. A bad request is
+ * INVALID_ARGUMENT, an unknown symbol NOT_FOUND.
+ *
+ * @grpc/grpc-js and @grpc/proto-loader are loaded only when gRPC is
+ * asked for, so a plain install of ucn doesn't carry them.
+ */
+
+'use strict';
+
+const path = require('path');
+const { ApiError } = require('./serve');
+
+const PROTO_PATH = path.join(__dirname, '..', 'proto', 'ucn.proto');
+
+// gRPC status codes
+const INVALID_ARGUMENT = 3;
+const NOT_FOUND = 5;
+const INTERNAL = 13;
+
+function loadGrpc() {
+    try {
+        return { grpc: require('@grpc/grpc-js'), protoLoader: require('@grpc/proto-loader') };
+    } catch {
+        throw new Error('gRPC needs @grpc/grpc-js and @grpc/proto-loader. Install with:\n  npm install @grpc/grpc-js @grpc/proto-loader');
+    }
+}
+
+/** A google.protobuf.Value, as proto-loader decodes it, in plain JS */
+function fromValue(value) {
+    switch (value?.kind) {
+        case 'numberValue': return value.numberValue;
+        case 'stringValue': return value.stringValue;
+        case 'boolValue': return value.boolValue;
+        case 'structValue': return structToObject(value.structValue);
+        case 'listValue': return (value.listValue.values || []).map(fromValue);
+        default: return null;
+    }
+}
+
+/** A google.protobuf.Struct, as proto-loader decodes it, as a plain object */
+function structToObject(struct) {
+    return Object.fromEntries(Object.entries(struct?.fields || {}).map(([k, v]) => [k, fromValue(v)]));
+}
+
+/** gRPC status code of an error the API threw */
+function grpcCode(e) {
+    if (!(e instanceof ApiError)) return INTERNAL;
+    return e.status === 404 ? NOT_FOUND : INVALID_ARGUMENT;
+}
+
+/**
+ * The gRPC server over an API, not yet bound.
+ * @param {object} api - createApi() of core/serve.js
+ * @returns {object} grpc.Server
+ */
+function createGrpcServer(api) {
+    const { grpc, protoLoader } = loadGrpc();
+    const definition = protoLoader.loadSync(PROTO_PATH, { keepCase: false, longs: Number, enums: String, defaults: true, oneofs: true });
+    const { ucn } = grpc.loadPackageDefinition(definition);
+    const unary = fn => (call, callback) => {
+        try {
+            callback(null, fn(call.request));
+        } catch (e) {
+            callback({ code: grpcCode(e), details: e.message, message: e.message });
+        }
+    };
+    const server = new grpc.Server();
+    server.addService(ucn.v1.Ucn.service, {
+        analyze: unary(req => api.analyze({
+            ...(req.commands.length && { commands: req.commands }),
+            ...(req.params && { params: structToObject(req.params) }),
+        })),
+        listFindings: unary(req => api.findings({
+            command: req.command || undefined,
+            rule: req.rule || undefined,
+            severity: req.severity || undefined,
+            file: req.file || undefined,
+            limit: req.limit || Infinity,
+            offset: req.offset,
+        })),
+        getReferences: unary(req => api.references(req.symbol)),
+    });
+    return server;
+}
+
+/**
+ * Bind a gRPC server (plaintext) and start serving.
+ * @param {object} server - createGrpcServer()
+ * @param {string} host - Address to listen on
+ * @param {number} port - Port; 0 picks a free one
+ * @returns {Promise<number>} The bound port
+ */
+function listenGrpc(server, host, port) {
+    const { grpc } = loadGrpc();
+    const address = host.includes(':') ? `[${host}]:${port}` : `${host}:${port}`;
+    return new Promise((resolve, reject) => {
+        server.bindAsync(address, grpc.ServerCredentials.createInsecure(), (err, bound) => (err ? reject(err) : resolve(bound)));
+    });
+}
+
+module.exports = { createGrpcServer, listenGrpc, structToObject, grpcCode, PROTO_PATH };
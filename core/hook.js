@@ -0,0 +1,109 @@
+/**
+ * core/hook.js — Pre-commit runs on the files being committed
+ * (ucn hook install, ucn hook run)
+ *
+ * `ucn hook run [files...]` runs deadcode on the cached index, which
+ * re-parses only the files changed since the last run, and reports the
+ * findings in the given files: the staged ones by default, or the ones
+ * the pre-commit framework passes (.pre-commit-hooks.yaml). The whole
+ * project is still analyzed, so a symbol the commit leaves unused counts
+ * its uses anywhere. `ucn hook install` writes a git pre-commit hook
+ * that runs it.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { git } = require('./diff');
+const { detectLanguage } = require('../languages');
+const { findingsOf, withoutFindings } = require('./output/sarif');
+
+// First lines of the hook ucn hook install writes; a hook without them
+// is someone else's, and install leaves it alone unless forced
+const HOOK_MARKER = '# Installed by ucn hook install';
+const HOOK_SCRIPT = `#!/bin/sh
+${HOOK_MARKER}: dead code in the files being committed.
+# Skip it once with git commit --no-verify.
+if command -v ucn >/dev/null 2>&1; then
+    exec ucn hook run
+fi
+exec npx --no-install ucn hook run
+`;
+
+/**
+ * Absolute paths of the files staged for commit (added, copied, modified
+ * or renamed; deletions have nothing left to analyze).
+ * @param {string} cwd - A directory in the repository
+ * @returns {string[]}
+ */
+function stagedFiles(cwd) {
+    let top;
+    try {
+        top = git(cwd, ['rev-parse', '--show-toplevel']).trim();
+    } catch (e) {
+        throw new Error('Not a git repository. ucn hook run checks the staged files; name the files to check instead.', { cause: e });
+    }
+    return git(cwd, ['diff', '--cached', '--name-only', '-z', '--diff-filter=ACMR'])
+        .split('\0').filter(Boolean).map(f => path.join(top, f));
+}
+
+/**
+ * The files a hook run checks: the given ones (relative to cwd), else the
+ * staged ones, keeping those in a language ucn analyzes.
+ * @param {string[]} files - Files named on the command line
+ * @param {string} cwd - Working directory
+ * @returns {string[]} Absolute paths
+ */
+function hookFiles(files, cwd) {
+    const all = files.length > 0 ? files.map(f => path.resolve(cwd, f)) : stagedFiles(cwd);
+    return all.filter(f => detectLanguage(f));
+}
+
+/**
+ * The command's result narrowed to the findings in the given files.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - Its result
+ * @param {string} root - Project root the findings' paths are relative to
+ * @param {Set<string>} files - Absolute paths
+ */
+function onlyInFiles(command, result, root, files) {
+    const drop = new Set();
+    for (const f of findingsOf(command, result)) {
+        if (!files.has(path.resolve(root, f.at.file))) drop.add(f.data);
+    }
+    return withoutFindings(command, result, drop);
+}
+
+/**
+ * Write the pre-commit hook into the repository's hooks directory
+ * (core.hooksPath and worktrees included).
+ * @param {string} cwd - A directory in the repository
+ * @param {object} [options]
+ * @param {boolean} [options.force] - Replace a hook ucn didn't write
+ * @returns {{file: string, replaced: boolean}} The hook, and whether one was there
+ */
+function installHook(cwd, options = {}) {
+    let dir;
+    try {
+        dir = path.resolve(cwd, git(cwd, ['rev-parse', '--git-path', 'hooks']).trim());
+    } catch (e) {
+        throw new Error('Not a git repository. ucn hook install requires git.', { cause: e });
+    }
+    const file = path.join(dir, 'pre-commit');
+    let existing = null;
+    try {
+        existing = fs.readFileSync(file, 'utf-8');
+    } catch (e) {
+        if (e.code !== 'ENOENT') throw e;
+    }
+    if (existing !== null && !existing.includes(HOOK_MARKER) && !options.force) {
+        throw new Error(`${file} already exists and was not written by ucn. Add "ucn hook run" to it, or pass --force to replace it.`);
+    }
+    fs.mkdirSync(dir, { recursive: true });
+    fs.writeFileSync(file, HOOK_SCRIPT, { mode: 0o755 });
+    fs.chmodSync(file, 0o755);
+    return { file, replaced: existing !== null };
+}
+
+module.exports = { hookFiles, stagedFiles, onlyInFiles, installHook, HOOK_SCRIPT };
@@ -92,7 +92,8 @@ function resolveImport(importPath, fromFile, config = {}) {
         }
 
         // Check tsconfig paths (JS/TS only)
-        if (config.language === 'javascript' || config.language === 'typescript' || config.language === 'tsx') {
+        if (config.language === 'javascript' || config.language === 'typescript' || config.language === 'tsx' ||
+            config.language === 'vue' || config.language === 'svelte') {
             const tsconfig = findTsConfig(fromDir, config.root);
             if (tsconfig) {
                 if (tsconfig.compiledPaths) {
@@ -156,6 +157,100 @@ function resolveImport(importPath, fromFile, config = {}) {
             if (srcResolved) return srcResolved;
         }
 
+        // Zig: @import("util.zig") is relative to the importing file; bare
+        // names ("std", build.zig modules) are packages
+        if (config.language === 'zig' && importPath.endsWith('.zig')) {
+            return resolveFilePath(path.resolve(fromDir, importPath), []);
+        }
+
+        // Elixir: Mix convention maps MyApp.FooBar to lib/my_app/foo_bar.ex
+        if (config.language === 'elixir' && config.root) {
+            const resolved = resolveElixirImport(importPath, config.root);
+            if (resolved) return resolved;
+        }
+
+        // Haskell: Data.Shape lives at <source dir>/Data/Shape.hs
+        if (config.language === 'haskell' && config.root) {
+            const resolved = resolveHaskellImport(importPath, config.root);
+            if (resolved) return resolved;
+        }
+
+        // OCaml: module Cache is the compilation unit cache.ml — next to the
+        // referencing file (a dune library directory); graph-build resolves
+        // the rest by file name
+        if (config.language === 'ocaml' || config.language === 'ocaml_interface') {
+            if (!/^[A-Z][\w']*$/.test(importPath)) return null;
+            const unit = importPath[0].toLowerCase() + importPath.slice(1);
+            return resolveFilePath(path.join(fromDir, unit), ['.ml']);
+        }
+
+        // Gradle: `apply from: 'gradle/x.gradle'` is relative to the script's
+        // project directory, `$rootDir/...` to the root project
+        if (config.language === 'groovy' && importPath.endsWith('.gradle')) {
+            const rooted = importPath.match(/^\$\{?(?:rootDir|rootProject\.projectDir)\}?\/(.*)$/);
+            if (rooted) return config.root ? resolveFilePath(path.join(config.root, rooted[1]), []) : null;
+            return resolveFilePath(path.resolve(fromDir, importPath), []);
+        }
+
+        // Dart: `package:<pkg>/p.dart` maps to <pkg>/lib/p.dart for the
+        // project's own packages; scheme-less URIs are file-relative
+        if (config.language === 'dart') {
+            if (importPath.startsWith('package:')) {
+                return config.root ? resolveDartPackageImport(importPath, config.root) : null;
+            }
+            if (!/^[a-z]+:/.test(importPath)) {
+                return resolveFilePath(path.resolve(fromDir, importPath), []);
+            }
+            return null;  // dart:core, dart:async, ...
+        }
+
+        // Shell: `source lib/common.sh` searches the sourcing script's
+        // directory (the normalized `$(dirname "$0")/` form), then the
+        // project root (scripts run from the repo root)
+        if (config.language === 'bash' && !importPath.startsWith('$')) {
+            return resolveFilePath(path.resolve(fromDir, importPath), []) ||
+                (config.root ? resolveFilePath(path.resolve(config.root, importPath), []) : null);
+        }
+
+        // SvelteKit: `$lib` is the built-in alias for src/lib
+        if ((importPath === '$lib' || importPath.startsWith('$lib/')) && config.root) {
+            const libPath = path.join(config.root, 'src', 'lib', importPath.slice('$lib'.length));
+            const resolved = resolveFilePath(libPath, config.extensions || getExtensions(config.language));
+            if (resolved) return resolved;
+        }
+
+        // SQL: psql `\i schema/tables.sql` is relative to the working
+        // directory — the project root for migration runners
+        if (config.language === 'sql' && config.root) {
+            return resolveFilePath(path.resolve(config.root, importPath), []);
+        }
+
+        // Protobuf: `import "common/address.proto"` is relative to a -I
+        // include root — the importing file's directory or any ancestor up
+        // to the project root (protoc runs from the root or from proto/).
+        // google/protobuf/*.proto ships with protoc.
+        if (config.language === 'proto' && config.root) {
+            for (let dir = fromDir; ; dir = path.dirname(dir)) {
+                const candidate = resolveFilePath(path.join(dir, importPath), []);
+                if (candidate) return candidate;
+                if (dir === config.root || path.dirname(dir) === dir ||
+                    path.relative(config.root, dir).startsWith('..')) break;
+            }
+            return null;
+        }
+
+        // Objective-C: `#import "Foo.h"` is looked up next to the importing
+        // file; Xcode header maps resolve the rest by file name (graph-build)
+        if (config.language === 'objc') {
+            return resolveFilePath(path.join(fromDir, importPath), []);
+        }
+
+        // Lua: require("a.b") searches package.path templates (a/b.lua, a/b/init.lua)
+        if (config.language === 'lua' && config.root) {
+            const resolved = resolveLuaImport(importPath, fromFile, config.root);
+            if (resolved) return resolved;
+        }
+
         return null;  // External package
     }
 
@@ -179,11 +274,215 @@ function resolveImport(importPath, fromFile, config = {}) {
         }
     }
 
+    // Terraform: a module source names a directory
+    if (config.language === 'hcl') {
+        return resolveTerraformModuleDir(path.resolve(fromDir, importPath));
+    }
+
     // Relative imports
     const resolved = path.resolve(fromDir, normalizedPath);
     return resolveFilePath(resolved, config.extensions || getExtensions(config.language));
 }
 
+/**
+ * Resolve a Terraform module directory to the file that stands for it in the
+ * import graph: main.tf by convention, else the first .tf file by name.
+ */
+function resolveTerraformModuleDir(dir) {
+    let names;
+    try {
+        if (!fs.statSync(dir).isDirectory()) return null;
+        names = fs.readdirSync(dir).filter(n => n.endsWith('.tf')).sort();
+    } catch {
+        return null;
+    }
+    if (names.length === 0) return null;
+    return path.join(dir, names.includes('main.tf') ? 'main.tf' : names[0]);
+}
+
+/**
+ * Resolve an Elixir module name to its source file by the Mix layout
+ * convention: each dotted segment is snake_cased into a path segment under
+ * lib/ (or test/support/ for test helpers). Umbrella apps nest the same
+ * layout under apps/<app>/. Erlang modules (:ets) are always external.
+ */
+function resolveElixirImport(moduleName, projectRoot) {
+    if (!/^[A-Z][\w.]*$/.test(moduleName)) return null;
+    const rel = moduleName.split('.')
+        .map(seg => seg.replace(/([a-z0-9])([A-Z])/g, '$1_$2').replace(/([A-Z]+)([A-Z][a-z])/g, '$1_$2').toLowerCase())
+        .join('/');
+    const roots = [path.join(projectRoot, 'lib'), path.join(projectRoot, 'test', 'support')];
+    const appsDir = path.join(projectRoot, 'apps');
+    try {
+        for (const app of fs.readdirSync(appsDir)) roots.push(path.join(appsDir, app, 'lib'));
+    } catch { /* not an umbrella project */ }
+    for (const root of roots) {
+        const resolved = resolveFilePath(path.join(root, rel), getExtensions('elixir'));
+        if (resolved) return resolved;
+    }
+    return null;
+}
+
+// Cache for Haskell source directories per project: root -> string[]
+const haskellSourceDirCache = new Map();
+
+/**
+ * Source directories of a Haskell project: `hs-source-dirs` of the root
+ * .cabal file and `source-dirs` of package.yaml (hpack), falling back to the
+ * conventional src/, lib/, app/ and test/ plus the root itself.
+ */
+function haskellSourceDirs(projectRoot) {
+    if (haskellSourceDirCache.has(projectRoot)) return haskellSourceDirCache.get(projectRoot);
+    const dirs = new Set();
+    let manifests = [];
+    try {
+        manifests = fs.readdirSync(projectRoot).filter(n => n.endsWith('.cabal') || n === 'package.yaml');
+    } catch { /* unreadable root */ }
+    for (const name of manifests) {
+        let content;
+        try { content = fs.readFileSync(path.join(projectRoot, name), 'utf-8'); } catch { continue; }
+        for (const m of content.matchAll(/^\s*(?:hs-)?source-dirs\s*:\s*(.+)$/gm)) {
+            for (const dir of m[1].replace(/[[\]"']/g, '').split(/[\s,]+/)) {
+                if (dir && dir !== '-') dirs.add(dir);
+            }
+        }
+    }
+    for (const dir of ['src', 'lib', 'app', 'test', '.']) dirs.add(dir);
+    const result = [...dirs].map(d => path.join(projectRoot, d));
+    haskellSourceDirCache.set(projectRoot, result);
+    return result;
+}
+
+/**
+ * Resolve a Haskell module name to its source file: each dotted segment is a
+ * directory under one of the package's source directories.
+ */
+function resolveHaskellImport(moduleName, projectRoot) {
+    if (!/^[A-Z][\w.']*$/.test(moduleName)) return null;
+    const rel = moduleName.split('.').join('/');
+    for (const dir of haskellSourceDirs(projectRoot)) {
+        const resolved = resolveFilePath(path.join(dir, rel), getExtensions('haskell'));
+        if (resolved) return resolved;
+    }
+    return null;
+}
+
+// Cache for Dart package roots per project: root -> Map<packageName, libDir>
+const dartPackageCache = new Map();
+
+/**
+ * Map the project's own pub packages to their lib/ directories: the root
+ * pubspec.yaml plus one level of workspace packages (packages/*, pkgs/*,
+ * or any direct subdirectory with a pubspec). Cached per project root.
+ */
+function getDartPackages(projectRoot) {
+    if (dartPackageCache.has(projectRoot)) return dartPackageCache.get(projectRoot);
+    const packages = new Map();
+    const readName = (dir) => {
+        try {
+            const m = fs.readFileSync(path.join(dir, 'pubspec.yaml'), 'utf-8').match(/^name:\s*['"]?([\w]+)/m);
+            if (m && !packages.has(m[1])) packages.set(m[1], path.join(dir, 'lib'));
+        } catch { /* no pubspec */ }
+    };
+    readName(projectRoot);
+    for (const group of ['.', 'packages', 'pkgs']) {
+        try {
+            for (const entry of fs.readdirSync(path.join(projectRoot, group), { withFileTypes: true })) {
+                if (entry.isDirectory() && !entry.name.startsWith('.')) readName(path.join(projectRoot, group, entry.name));
+            }
+        } catch { /* no such directory */ }
+    }
+    dartPackageCache.set(projectRoot, packages);
+    return packages;
+}
+
+function resolveDartPackageImport(importPath, projectRoot) {
+    const m = importPath.match(/^package:([\w]+)\/(.+)$/);
+    if (!m) return null;
+    const libDir = getDartPackages(projectRoot).get(m[1]);
+    if (!libDir) return null;  // third-party package
+    return resolveFilePath(path.join(libDir, m[2]), []);
+}
+
+// Cache for per-project Lua search templates: root -> string[]
+const luaPathCache = new Map();
+
+// `package.path = "..."` / `package.path = package.path .. ";lib/?.lua"`
+const LUA_PACKAGE_PATH_RE = /package\.path\s*=\s*([^\n]+)/g;
+
+/**
+ * Collect `?`-templates from package.path assignments in a Lua source.
+ * Only string-literal pieces are usable; `package.path ..` concatenation
+ * keeps whatever the default path already covers.
+ */
+function luaPathTemplates(content) {
+    const templates = [];
+    for (const m of content.matchAll(LUA_PACKAGE_PATH_RE)) {
+        for (const lit of m[1].matchAll(/"([^"]*)"|'([^']*)'|\[\[([^\]]*)\]\]/g)) {
+            const value = lit[1] ?? lit[2] ?? lit[3];
+            for (const t of value.split(';')) {
+                if (t.includes('?')) templates.push(t.trim());
+            }
+        }
+    }
+    return templates;
+}
+
+/**
+ * Search templates for a Lua project: the interpreter default (`./?.lua`,
+ * `./?/init.lua`), package.path assignments in root-level scripts (the
+ * main.lua / conf.lua that bootstraps the path), and `.luarc.json`
+ * runtime.path entries. Cached per project root.
+ */
+function getLuaProjectTemplates(projectRoot) {
+    if (luaPathCache.has(projectRoot)) return luaPathCache.get(projectRoot);
+    const templates = ['./?.lua', './?/init.lua'];
+    try {
+        for (const name of fs.readdirSync(projectRoot)) {
+            if (!name.endsWith('.lua')) continue;
+            try {
+                templates.push(...luaPathTemplates(fs.readFileSync(path.join(projectRoot, name), 'utf-8')));
+            } catch { /* unreadable */ }
+        }
+    } catch { /* unreadable root */ }
+    try {
+        const luarc = JSON.parse(fs.readFileSync(path.join(projectRoot, '.luarc.json'), 'utf-8'));
+        const runtimePath = luarc['runtime.path'] || luarc.runtime?.path;
+        if (Array.isArray(runtimePath)) templates.push(...runtimePath.filter(t => typeof t === 'string' && t.includes('?')));
+    } catch { /* no .luarc.json */ }
+    const unique = [...new Set(templates)];
+    luaPathCache.set(projectRoot, unique);
+    return unique;
+}
+
+/**
+ * Resolve a Lua module name the way the `require` searcher does: dots
+ * become directory separators and each `?`-template is tried in turn.
+ * Relative templates (`./?.lua`, `lib/?.lua`) are anchored at the project
+ * root — the working directory a game/embedded host runs from — and then
+ * at the requiring file's directory. Absolute templates (system paths) are
+ * external.
+ */
+function resolveLuaImport(moduleName, fromFile, projectRoot) {
+    if (!/^[\w.\-/]+$/.test(moduleName)) return null;
+    const rel = moduleName.replace(/\./g, '/');
+    let templates = getLuaProjectTemplates(projectRoot);
+    try {
+        const own = luaPathTemplates(fs.readFileSync(fromFile, 'utf-8'));
+        if (own.length > 0) templates = [...own, ...templates];
+    } catch { /* unreadable */ }
+    const bases = [projectRoot, path.dirname(fromFile)];
+    for (const template of templates) {
+        if (path.isAbsolute(template)) continue;
+        const candidate = template.replace(/\?/g, rel);
+        for (const base of bases) {
+            const resolved = resolveFilePath(path.resolve(base, candidate), []);
+            if (resolved) return resolved;
+        }
+    }
+    return null;
+}
+
 // Cache for Go module paths
 const goModuleCache = new Map();
 
@@ -730,6 +1029,37 @@ function getExtensions(language) {
             return ['.java'];
         case 'rust':
             return ['.rs'];
+        case 'elixir':
+            return ['.ex', '.exs'];
+        case 'zig':
+            return ['.zig'];
+        case 'lua':
+            return ['.lua'];
+        case 'dart':
+            return ['.dart'];
+        case 'bash':
+            return ['.sh', '.bash'];
+        case 'sql':
+            return ['.sql', '.pgsql'];
+        case 'hcl':
+            return ['.tf'];
+        case 'proto':
+            return ['.proto'];
+        case 'graphql':
+            return ['.graphql', '.gql'];
+        case 'vue':
+            return ['.vue', '.ts', '.js'];
+        case 'svelte':
+            return ['.svelte', '.ts', '.js'];
+        case 'objc':
+            return ['.h', '.m'];
+        case 'haskell':
+            return ['.hs'];
+        case 'groovy':
+            return ['.groovy', '.gradle', '.java'];
+        case 'ocaml':
+        case 'ocaml_interface':
+            return ['.ml', '.mli'];
         default:
             return ['.js', '.ts'];
     }
@@ -0,0 +1,208 @@
+/**
+ * core/links.js — Cross-language link graph from .ucn.json bridge rules.
+ *
+ * Some references cross a boundary no parser follows: a TypeScript client
+ * generated from an OpenAPI spec names the Go handler by its operationId,
+ * a Python script runs `tool sync-users` and the Go `SyncUsers` command
+ * runs. A link rule says where such references live and what they name:
+ *
+ *   "links": [{
+ *     "name": "openapi",
+ *     "from": ["api/openapi.yaml", "web/src/api/**\/*.ts"],
+ *     "match": "operationId:\\s*['\"]?(\\w+)",
+ *     "to": { "language": "go", "file": "internal/handlers/" }
+ *   }]
+ *
+ * `from` globs select the referencing files (indexed or not), `match` is a
+ * regex whose first group (or `(?<name>...)` group) captures the referenced
+ * name, and `to` narrows the symbols it may resolve to: `language`, a
+ * `file` path fragment, and `name`, a template over the capture —
+ * `{name}`, `{camel}`, `{Pascal}`, `{snake}` (default: all four forms).
+ *
+ * Each resolved capture is a link edge from a file line to a symbol.
+ * deadcode treats linked symbols as used; doctor reports rules that are
+ * invalid or link nothing. Results are cached on `index._linksCache` and
+ * invalidated on rebuild with the endpoints cache.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { expandGlob } = require('./discovery');
+
+const DEFAULT_NAME_FORMS = ['{name}', '{camel}', '{Pascal}', '{snake}'];
+
+/** Words of an identifier or CLI token: `sync-users`, `syncUsers`, `SYNC_USERS` → [sync, users] */
+function nameWords(name) {
+    return name
+        .replace(/([a-z\d])([A-Z])/g, '$1 $2')
+        .replace(/([A-Z]+)([A-Z][a-z])/g, '$1 $2')
+        .split(/[^A-Za-z\d]+/)
+        .filter(Boolean)
+        .map(w => w.toLowerCase());
+}
+
+/**
+ * Expand a `to.name` template for one captured name.
+ * @param {string} template - e.g. 'run{Pascal}Cmd'
+ * @param {string} name - Captured text
+ */
+function expandNameTemplate(template, name) {
+    const words = nameWords(name);
+    const cap = w => w.charAt(0).toUpperCase() + w.slice(1);
+    const forms = {
+        name,
+        camel: words.map((w, i) => (i === 0 ? w : cap(w))).join(''),
+        Pascal: words.map(cap).join(''),
+        snake: words.join('_'),
+    };
+    return template.replace(/\{(name|camel|Pascal|snake)\}/g, (_, form) => forms[form]);
+}
+
+/**
+ * Validate one rule and compile its regex.
+ * @returns {{rule: object}|{error: string}}
+ */
+function compileRule(raw, i) {
+    const label = raw && typeof raw.name === 'string' ? raw.name : `#${i + 1}`;
+    if (!raw || typeof raw !== 'object') return { label, error: 'is not an object' };
+    const from = typeof raw.from === 'string' ? [raw.from] : raw.from;
+    if (!Array.isArray(from) || from.length === 0 || !from.every(f => typeof f === 'string')) {
+        return { label, error: 'needs "from" globs' };
+    }
+    if (typeof raw.match !== 'string' || !raw.match) return { label, error: 'needs a "match" regex' };
+    let regex;
+    try {
+        regex = new RegExp(raw.match, 'gm');
+    } catch (e) {
+        return { label, error: `has an invalid "match" regex: ${e.message}` };
+    }
+    const to = raw.to && typeof raw.to === 'object' ? raw.to : {};
+    const names = to.name == null ? DEFAULT_NAME_FORMS : [].concat(to.name);
+    if (!names.every(n => typeof n === 'string')) return { label, error: 'has a non-string "to.name"' };
+    return {
+        label,
+        rule: {
+            name: label,
+            from,
+            regex,
+            language: typeof to.language === 'string' ? to.language : null,
+            file: typeof to.file === 'string' ? to.file.replace(/\\/g, '/') : null,
+            names,
+        },
+    };
+}
+
+/** Files selected by a rule's `from` globs (plain paths are taken as-is). */
+function sourceFiles(index, from) {
+    const files = new Set();
+    for (const pattern of from) {
+        if (!/[*?{]/.test(pattern)) {
+            const file = path.resolve(index.root, pattern);
+            if (fs.existsSync(file) && fs.statSync(file).isFile()) files.add(file);
+            continue;
+        }
+        for (const file of expandGlob(pattern, { root: index.root })) files.add(file);
+    }
+    return [...files];
+}
+
+/** Candidate symbols for a captured name under a rule's `to` constraints. */
+function resolveTargets(index, rule, captured) {
+    const targets = [];
+    const seen = new Set();
+    for (const template of rule.names) {
+        const name = expandNameTemplate(template, captured);
+        if (seen.has(name)) continue;
+        seen.add(name);
+        for (const symbol of index.symbols.get(name) || []) {
+            if (rule.language && index.files.get(symbol.file)?.language !== rule.language) continue;
+            if (rule.file && !(symbol.relativePath || '').includes(rule.file)) continue;
+            targets.push(symbol);
+        }
+    }
+    return targets;
+}
+
+/**
+ * Compute the link graph for the project's .ucn.json "links" rules.
+ * @param {object} index - ProjectIndex
+ * @returns {{links: Array, rules: Array, targets: Set}} links: edges
+ *   `{rule, file, line, name, target: {name, file, line, language}}`;
+ *   rules: `{name, links, unresolved, error?}`; targets: linked symbols
+ */
+function computeLinks(index) {
+    if (index._linksCache) return index._linksCache;
+
+    const links = [];
+    const rules = [];
+    const targets = new Set();
+    const declared = Array.isArray(index.config?.links) ? index.config.links : [];
+
+    declared.forEach((raw, i) => {
+        const compiled = compileRule(raw, i);
+        if (compiled.error) {
+            rules.push({ name: compiled.label, links: 0, unresolved: 0, error: compiled.error });
+            return;
+        }
+        const { rule } = compiled;
+        let linked = 0;
+        let unresolved = 0;
+        for (const file of sourceFiles(index, rule.from)) {
+            let content;
+            try { content = index._readFile(file); } catch { continue; }
+            const relativePath = path.relative(index.root, file).replace(/\\/g, '/');
+            rule.regex.lastIndex = 0;
+            // Line numbers counted incrementally — matches come in order
+            let line = 1;
+            let counted = 0;
+            let m;
+            while ((m = rule.regex.exec(content)) !== null) {
+                if (m[0] === '') { rule.regex.lastIndex++; continue; }
+                const captured = m.groups?.name ?? m[1] ?? m[0];
+                if (!captured) continue;
+                const at = m.index + Math.max(0, m[0].indexOf(captured));
+                for (; counted < at; counted++) if (content.charCodeAt(counted) === 10) line++;
+                const found = resolveTargets(index, rule, captured);
+                if (found.length === 0) { unresolved++; continue; }
+                for (const symbol of found) {
+                    targets.add(symbol);
+                    linked++;
+                    links.push({
+                        rule: rule.name,
+                        file: relativePath,
+                        line,
+                        name: captured,
+                        target: {
+                            name: symbol.name,
+                            file: symbol.relativePath,
+                            line: symbol.startLine,
+                            language: index.files.get(symbol.file)?.language || null,
+                        },
+                    });
+                }
+            }
+        }
+        rules.push({ name: rule.name, links: linked, unresolved });
+    });
+
+    index._linksCache = { links, rules, targets };
+    return index._linksCache;
+}
+
+/**
+ * Link edges that reach a symbol.
+ * @param {object} index - ProjectIndex
+ * @param {object} symbol - Symbol definition
+ */
+function linksTo(index, symbol) {
+    return computeLinks(index).links.filter(l =>
+        l.target.name === symbol.name && l.target.file === symbol.relativePath && l.target.line === symbol.startLine);
+}
+
+module.exports = {
+    computeLinks,
+    linksTo,
+    expandNameTemplate,
+};
@@ -0,0 +1,120 @@
+/**
+ * core/log.js — Debug logging and build progress (ucn -v, -vv, --progress)
+ *
+ * Logging is off until a surface configures it. -v logs debug events
+ * (cache decisions, build phases and their timings, command runs), -vv
+ * adds trace events (every file parsed). Each event is one line on
+ * stderr, `ucn debug build.phase phase=parse ms=812`, or a JSON object
+ * with --log-format json, so stdout keeps only the command's output.
+ *
+ * createProgress() renders ProjectIndex.build()'s progress updates: one
+ * status line redrawn in place on a terminal, a line every few seconds
+ * when stderr is a file or a CI log.
+ */
+
+'use strict';
+
+const LEVELS = { debug: 1, trace: 2 };
+
+let verbosity = 0;
+let format = 'text';
+let write = line => process.stderr.write(line + '\n');
+
+/**
+ * Set up logging for this process.
+ * @param {object} options - { verbosity: 0 off, 1 debug, 2 trace;
+ *   format: 'text' or 'json'; write: line sink (default stderr) }
+ */
+function configure(options = {}) {
+    if (options.verbosity !== undefined) verbosity = options.verbosity;
+    if (options.format !== undefined) format = options.format;
+    if (options.write) write = options.write;
+}
+
+/** Whether events of a level ('debug' or 'trace') are logged */
+function enabled(level) {
+    return verbosity >= LEVELS[level];
+}
+
+function formatValue(value) {
+    const s = typeof value === 'string' ? value : JSON.stringify(value);
+    return /[\s"=]/.test(s) ? JSON.stringify(s) : s;
+}
+
+/**
+ * Log an event.
+ * @param {string} level - 'debug' or 'trace'
+ * @param {string} event - Dotted event name (build.phase, cache.load, ...)
+ * @param {object} [fields] - Event data; undefined values are left out
+ */
+function log(level, event, fields = {}) {
+    if (!enabled(level)) return;
+    const data = Object.fromEntries(Object.entries(fields).filter(([, v]) => v !== undefined));
+    if (format === 'json') {
+        write(JSON.stringify({ time: new Date().toISOString(), level, event, ...data }));
+    } else {
+        const pairs = Object.entries(data).map(([k, v]) => `${k}=${formatValue(v)}`);
+        write(['ucn', level, event, ...pairs].join(' '));
+    }
+}
+
+const debug = (event, fields) => log('debug', event, fields);
+const trace = (event, fields) => log('trace', event, fields);
+
+const PHASE_LABELS = {
+    discover: 'Discovering files',
+    parse: 'Parsing',
+    graphs: 'Linking imports and types',
+    calls: 'Indexing calls',
+};
+
+/**
+ * A progress renderer for ProjectIndex.build({ progress }).
+ * @param {object} [options] - { stream (default stderr), intervalMs:
+ *   how often a non-terminal stream gets a line (default 2000) }
+ * @returns {function} Takes { phase, done?, total?, symbols? }; its
+ *   finish(timings) method ends the status line and prints phase timings
+ */
+function createProgress(options = {}) {
+    const stream = options.stream || process.stderr;
+    const tty = !!stream.isTTY;
+    const intervalMs = options.intervalMs ?? (tty ? 100 : 2000);
+    let last = 0;
+    let lastPhase = null;
+    let width = 0;
+
+    const line = ({ phase, done, total, symbols }) => {
+        let text = PHASE_LABELS[phase] || phase;
+        if (total) text += ` ${done}/${total} files (${Math.floor(done * 100 / total)}%)`;
+        if (symbols !== undefined) text += `, ${symbols} symbols`;
+        return text;
+    };
+
+    const update = (state) => {
+        const now = Date.now();
+        // Always show a new phase and the end of one; throttle the rest
+        const boundary = state.phase !== lastPhase || (state.total && state.done === state.total);
+        if (!boundary && now - last < intervalMs) return;
+        last = now;
+        lastPhase = state.phase;
+        const text = line(state);
+        if (tty) {
+            stream.write('\r' + text.padEnd(width));
+            width = text.length;
+        } else {
+            stream.write(text + '\n');
+        }
+    };
+
+    update.finish = (timings) => {
+        if (tty && width) stream.write('\r' + ' '.repeat(width) + '\r');
+        width = 0;
+        if (!timings) return;
+        const parts = Object.entries(timings).map(([phase, ms]) => `${phase} ${ms}ms`);
+        stream.write(`Build phases: ${parts.join(', ')}\n`);
+    };
+
+    return update;
+}
+
+module.exports = { configure, enabled, log, debug, trace, createProgress };
@@ -0,0 +1,562 @@
+/**
+ * core/lsp.js — Language Server Protocol server (ucn lsp)
+ *
+ * Speaks LSP (JSON-RPC with Content-Length framing) on stdin/stdout, so
+ * any editor with an LSP client gets ucn's answers as it types:
+ *
+ *   - diagnostics: deadcode's findings across the project, published per
+ *     file; unused-code findings carry the Unnecessary tag, which editors
+ *     render faded
+ *   - code actions: "Remove unused ..." for the findings ucn fix would
+ *     delete, as a workspace edit of the same whole-line removals (doc
+ *     comment included) and of the imports only the removed code used;
+ *     "Suppress ... with a comment" for any finding, inserting the
+ *     ucn:ignore comment `ucn tui` writes
+ *   - references: the usages of the symbol under the cursor, from ucn's
+ *     own index: the definition the cursor's file sees, and the usages in
+ *     files that see that same definition, not every same-named one
+ *   - custom requests, for an editor extension's views (advertised under
+ *     capabilities.experimental.ucn): ucn/whyUsed, the call chain from a
+ *     reachability root to the symbol under the cursor, or its direct
+ *     callers when there is none; ucn/deadCodeTree, the last run's
+ *     findings by directory and file, or by rule. ucn/deadCodeChanged is
+ *     sent after each run, so a view knows to ask again
+ *
+ * The index is built (or loaded from the cache) on initialize. An open
+ * buffer's text stands in for its file (ProjectIndex.overlayFile) and a
+ * burst of edits settles before deadcode re-runs; changes on disk are
+ * picked up as `ucn watch` picks them up.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { pathToFileURL, fileURLToPath } = require('url');
+const { ProjectIndex } = require('./project');
+const { findProjectRoot } = require('./discovery');
+const { execute } = require('./execute');
+const { findingsOf, RULES } = require('./output/sarif');
+const { planFixes, orphanedImports, mergeRanges } = require('./fix');
+const { langTraits } = require('../languages');
+const log = require('./log');
+
+// JSON-RPC and LSP error codes
+const METHOD_NOT_FOUND = -32601;
+const INVALID_PARAMS = -32602;
+const INTERNAL_ERROR = -32603;
+const SERVER_NOT_INITIALIZED = -32002;
+
+const SEVERITY = { error: 1, warning: 2, note: 3 };
+const UNNECESSARY = 1;
+// Findings about code that is too big, not unused
+const NOT_UNUSED = new Set(['complexity', 'size']);
+
+const TREE_GROUPS = ['path', 'rule'];
+
+const uriToPath = uri => (uri && uri.startsWith('file:') ? fileURLToPath(uri) : null);
+const pathToUri = p => pathToFileURL(p).href;
+
+/**
+ * Split a byte stream into JSON-RPC messages.
+ * @param {Function} onMessage - Called with each parsed message
+ * @returns {Function} Takes the stream's chunks
+ */
+function messageReader(onMessage) {
+    let buffer = Buffer.alloc(0);
+    return (chunk) => {
+        buffer = Buffer.concat([buffer, chunk]);
+        for (;;) {
+            const headerEnd = buffer.indexOf('\r\n\r\n');
+            if (headerEnd < 0) return;
+            const length = Number(/Content-Length:\s*(\d+)/i.exec(buffer.subarray(0, headerEnd).toString('ascii'))?.[1]);
+            if (!Number.isFinite(length)) {
+                // Not a header we can read: drop it and resync on the next one
+                buffer = buffer.subarray(headerEnd + 4);
+                continue;
+            }
+            const end = headerEnd + 4 + length;
+            if (buffer.length < end) return;
+            const body = buffer.subarray(headerEnd + 4, end).toString('utf-8');
+            buffer = buffer.subarray(end);
+            let message;
+            try { message = JSON.parse(body); } catch { continue; }
+            onMessage(message);
+        }
+    };
+}
+
+/** A JSON-RPC message, framed */
+function frame(message) {
+    const body = JSON.stringify(message);
+    return `Content-Length: ${Buffer.byteLength(body, 'utf-8')}\r\n\r\n${body}`;
+}
+
+/** Range of a name's first whole-word occurrence on a line, or the whole line */
+function nameRange(lineText, line, name) {
+    const word = name && String(name).split('.').pop();
+    const m = word ? new RegExp(`(?<![\\w$])${word.replace(/[$.*+?^()[\]{}|\\]/g, '\\$&')}(?![\\w$])`).exec(lineText) : null;
+    return m
+        ? { start: { line, character: m.index }, end: { line, character: m.index + word.length } }
+        : { start: { line, character: 0 }, end: { line, character: lineText.length } };
+}
+
+/** Identifier around a character of a line, or null */
+function wordAt(lineText, character) {
+    const re = /[\w$]+/g;
+    let m;
+    while ((m = re.exec(lineText))) {
+        if (m.index <= character && character <= m.index + m[0].length) return /^\d/.test(m[0]) ? null : m[0];
+    }
+    return null;
+}
+
+/**
+ * Whether `name` in a file can mean one of defs: the file defines it, or
+ * (unless a definition of its own shadows it) shares a directory package
+ * with it or reaches it through its imports. A method is seen from the
+ * files whose imports reach its class's file. For the rest, an import
+ * chain ucn can't follow counts as seeing it: only a usage that provably
+ * means another symbol is left out.
+ * @param {object} index - ProjectIndex
+ * @param {string} file - Absolute path
+ * @param {string} name - The identifier
+ * @param {object[]} defs - Definitions named name
+ */
+function seesDefinition(index, file, name, defs) {
+    const { _nameBindingReaches, _importReaches } = require('./callers');
+    const targets = new Set(defs.map(d => d.file));
+    if (targets.has(file)) return true;
+    if (defs.every(d => d.className)) return _importReaches(index, file, targets);
+    if ((index.symbols.get(name) || []).some(s => s.file === file && !s.className)) return false;
+    const dir = path.dirname(file);
+    if (langTraits(index.files.get(file)?.language)?.packageScope === 'directory' &&
+        defs.some(d => path.dirname(d.file) === dir)) return true;
+    return _nameBindingReaches(index, file, name, targets) !== 'no';
+}
+
+/** A request's error with its JSON-RPC code */
+class RequestError extends Error {
+    constructor(code, message) {
+        super(message);
+        this.code = code;
+    }
+}
+
+/**
+ * Findings as the nodes of a tree view: directories, files and findings,
+ * or with groupBy "rule", rules, files and findings. Each node counts the
+ * findings under it; directories come before files, findings by line.
+ * @param {object[]} entries - { file (relative), uri, range, rule, ... } per finding
+ * @param {string} groupBy - path or rule
+ * @returns {object[]} The top-level nodes
+ */
+function findingTree(entries, groupBy) {
+    const root = { children: new Map() };
+    const child = (node, key, make) => {
+        if (!node.children.has(key)) node.children.set(key, { ...make(), count: 0, children: new Map() });
+        const next = node.children.get(key);
+        next.count++;
+        return next;
+    };
+    for (const { file, ...finding } of entries) {
+        const parts = file.split('/');
+        let node = root;
+        if (groupBy === 'rule') {
+            node = child(node, finding.rule, () => ({ kind: 'rule', name: finding.rule, title: RULES[finding.rule][0] }));
+        } else {
+            for (let i = 1; i < parts.length; i++) {
+                const dir = parts.slice(0, i).join('/');
+                node = child(node, dir, () => ({ kind: 'directory', name: parts[i - 1], path: dir }));
+            }
+        }
+        node = child(node, file, () => ({ kind: 'file', name: groupBy === 'rule' ? file : parts[parts.length - 1], path: file, uri: finding.uri }));
+        node.children.set(node.children.size, { kind: 'finding', ...finding });
+    }
+    const order = { rule: 0, directory: 0, file: 1 };
+    const finish = nodes => [...nodes.values()]
+        .map(n => (n.kind === 'finding' ? n : { ...n, children: finish(n.children) }))
+        .sort((a, b) => (a.kind === 'finding'
+            ? a.range.start.line - b.range.start.line
+            : order[a.kind] - order[b.kind] || a.name.localeCompare(b.name)));
+    return finish(root.children);
+}
+
+/**
+ * The server's message handling, apart from any stream.
+ * @param {object} options
+ * @param {Function} options.send - Takes each outgoing message object
+ * @param {object} [options.deadcode] - deadcode params (includeExported, ...);
+ *   the client's initializationOptions.deadcode adds to them
+ * @param {object} [options.buildOptions] - Extra options for index.build
+ * @param {boolean} [options.cache=true] - Load the index from .ucn-cache and save it there
+ * @param {boolean} [options.watch=true] - Re-analyze on changes on disk
+ * @param {number} [options.settleMs=300] - Quiet time after an edit before deadcode re-runs
+ * @param {Function} [options.onExit] - (code) on the exit notification
+ * @returns {{handle: Function, flush: Function, close: Function}} handle takes
+ *   one incoming message; flush runs a pending analysis now
+ */
+function createLspServer(options) {
+    const { send, buildOptions = {}, cache = true, watch = true, settleMs = 300, onExit = () => {} } = options;
+    let deadcodeParams = { ...options.deadcode };
+    let index = null;
+    let shutdown = false;
+    let watcher = null;
+    let timer = null;
+    let result = [];
+    let entries = [];               // the last run's findings, as tree entries
+    const docs = new Map();         // uri → text of the open buffer
+    let published = new Set();      // uris with diagnostics last published
+
+    const notify = (method, params) => send({ jsonrpc: '2.0', method, params });
+
+    const run = (ix) => {
+        const r = execute(ix, 'deadcode', deadcodeParams);
+        if (!r.ok) throw new Error(r.error);
+        return r.result;
+    };
+
+    // Lines of files as the index sees them (an open buffer's text), read once per use
+    const lineReader = () => {
+        const files = new Map();
+        return (file, n) => {
+            if (!files.has(file)) {
+                const uri = pathToUri(file);
+                let text = '';
+                try { text = docs.has(uri) ? docs.get(uri) : index.readFile(file); } catch { /* gone since the run */ }
+                files.set(file, text.split('\n'));
+            }
+            return files.get(file)[n] ?? '';
+        };
+    };
+
+    /** Publish a deadcode result's findings, clearing files that have none now */
+    function publish(deadcode) {
+        result = deadcode;
+        const byUri = new Map();
+        const lineOf = lineReader();
+        entries = [];
+        for (const f of findingsOf('deadcode', deadcode, file => index.configFor(file).rules)) {
+            const file = path.join(index.root, f.at.file);
+            const uri = pathToUri(file);
+            const line = f.at.startLine - 1;
+            const range = nameRange(lineOf(file, line), line, f.at.name);
+            entries.push({
+                file: f.at.file.replace(/\\/g, '/'), uri, range, name: f.at.name || f.rule,
+                rule: f.rule, severity: f.severity, message: f.message, fingerprint: f.fingerprint,
+            });
+            if (!byUri.has(uri)) byUri.set(uri, []);
+            byUri.get(uri).push({
+                range,
+                severity: SEVERITY[f.level] || SEVERITY.warning,
+                source: 'ucn',
+                code: f.rule,
+                message: f.message,
+                ...(!NOT_UNUSED.has(f.rule) && { tags: [UNNECESSARY] }),
+                data: { fingerprint: f.fingerprint },
+            });
+        }
+        for (const uri of published) if (!byUri.has(uri)) notify('textDocument/publishDiagnostics', { uri, diagnostics: [] });
+        for (const [uri, diagnostics] of byUri) notify('textDocument/publishDiagnostics', { uri, diagnostics });
+        published = new Set(byUri.keys());
+        notify('ucn/deadCodeChanged', { total: entries.length });
+    }
+
+    /** An LSP location of a { name, file (relative), line } */
+    function locationOf(at, lineOf) {
+        const file = path.join(index.root, at.file);
+        const line = at.line - 1;
+        return { uri: pathToUri(file), range: nameRange(lineOf(file, line), line, at.name) };
+    }
+
+    function analyze() {
+        if (timer) clearTimeout(timer);
+        timer = null;
+        const start = Date.now();
+        try {
+            publish(run(index));
+            log.debug('lsp.analyze', { findings: result.length, ms: Date.now() - start });
+        } catch (e) {
+            notify('window/logMessage', { type: 1, message: `ucn: ${e.message}` });
+        }
+    }
+
+    const schedule = () => {
+        if (timer) clearTimeout(timer);
+        timer = setTimeout(analyze, settleMs);
+    };
+
+    function openIndex(root) {
+        const ix = new ProjectIndex(root);
+        const loaded = cache && ix.loadCache();
+        if (!loaded || ix.isCacheStale()) {
+            ix.build(null, { ...buildOptions, quiet: true, forceRebuild: !!loaded });
+            // Saved before any buffer overlays it
+            if (cache) {
+                try { ix.saveCache(); } catch { /* best-effort */ }
+            }
+        }
+        return ix;
+    }
+
+    /** Overlay an open buffer unless it matches its file on disk */
+    function overlay(uri, text) {
+        const file = uriToPath(uri);
+        if (!file || path.relative(index.root, file).startsWith('..')) return;
+        let onDisk = null;
+        try { onDisk = fs.readFileSync(file, 'utf-8'); } catch { /* never saved */ }
+        if (text === onDisk) index.dropOverlay(file);
+        else index.overlayFile(file, text);
+    }
+
+    const requests = {
+        initialize(params) {
+            const start = uriToPath(params.rootUri) || uriToPath(params.workspaceFolders?.[0]?.uri) || params.rootPath || process.cwd();
+            deadcodeParams = { ...deadcodeParams, ...params.initializationOptions?.deadcode };
+            index = openIndex(findProjectRoot(path.resolve(start)));
+            log.debug('lsp.initialize', { root: index.root, files: index.files.size });
+            return {
+                capabilities: {
+                    textDocumentSync: { openClose: true, change: 1, save: { includeText: false } },
+                    referencesProvider: true,
+                    codeActionProvider: { codeActionKinds: ['quickfix'] },
+                    experimental: {
+                        ucn: { requests: ['ucn/whyUsed', 'ucn/deadCodeTree'], notifications: ['ucn/deadCodeChanged'] },
+                    },
+                },
+                serverInfo: { name: 'ucn', version: require('../package.json').version },
+            };
+        },
+
+        shutdown() {
+            shutdown = true;
+            close();
+            return null;
+        },
+
+        'textDocument/references'(params) {
+            const file = uriToPath(params.textDocument.uri);
+            if (!file) return [];
+            const lineOf = lineReader();
+            const name = wordAt(lineOf(file, params.position.line), params.position.character);
+            if (!name) return [];
+            const includeDeclaration = params.context?.includeDeclaration !== false;
+            // On a definition, that one; elsewhere, the ones this file sees
+            const all = index.symbols.get(name) || [];
+            const onDefinition = all.filter(s => s.file === file && s.startLine === params.position.line + 1);
+            const defs = onDefinition.length ? onDefinition : all.filter(d => seesDefinition(index, file, name, [d]));
+            // A name ucn can't tie to a definition keeps every usage
+            const sees = new Map();
+            const visible = (u) => {
+                if (defs.length === 0) return true;
+                if (u.isDefinition) return defs.some(d => d.file === u.file && d.startLine === u.startLine);
+                if (!sees.has(u.file)) sees.set(u.file, seesDefinition(index, u.file, name, defs));
+                return sees.get(u.file);
+            };
+            return index.usages(name, { codeOnly: true })
+                .filter(u => (includeDeclaration || !u.isDefinition) && visible(u))
+                .map((u) => {
+                    const line = (u.isDefinition ? u.startLine : u.line) - 1;
+                    return { uri: pathToUri(u.file), range: nameRange(lineOf(u.file, line), line, name) };
+                });
+        },
+
+        // The symbol under the cursor: on its definition, that one;
+        // elsewhere, the definition ucn resolves the name to
+        'ucn/whyUsed'(params) {
+            const file = uriToPath(params.textDocument?.uri);
+            if (!file || !params.position) throw new RequestError(INVALID_PARAMS, 'ucn/whyUsed takes a textDocument and a position.');
+            const lineOf = lineReader();
+            const name = wordAt(lineOf(file, params.position.line), params.position.character);
+            if (!name) return null;
+            const rel = path.relative(index.root, file).replace(/\\/g, '/');
+            const onDefinition = (index.symbols.get(name) || []).some(s =>
+                s.relativePath === rel && s.startLine === params.position.line + 1);
+            const { why } = require('./explain');
+            const r = why(index, name, onDefinition ? { file: rel, line: params.position.line + 1 } : {});
+            if (r.error) return null;
+            const node = at => ({ name: at.name, location: locationOf(at, lineOf) });
+            return {
+                symbol: node(r.symbol),
+                reachable: r.reachable,
+                ...(r.via && { via: r.via }),
+                chain: r.chain.map(node),
+                ...(r.callers && { callers: r.callers.map(node) }),
+                ...(r.unverifiedCallers && { unverifiedCallers: r.unverifiedCallers }),
+                ...(r.warnings && { warnings: r.warnings }),
+            };
+        },
+
+        'ucn/deadCodeTree'(params) {
+            const groupBy = params.groupBy ?? 'path';
+            if (!TREE_GROUPS.includes(groupBy)) {
+                throw new RequestError(INVALID_PARAMS, `groupBy must be one of ${TREE_GROUPS.join(', ')} (got "${groupBy}")`);
+            }
+            return { total: entries.length, groupBy, children: findingTree(entries, groupBy) };
+        },
+
+        'textDocument/codeAction'(params) {
+            const file = uriToPath(params.textDocument.uri);
+            if (!file) return [];
+            const rel = path.relative(index.root, file).replace(/\\/g, '/');
+            const { start, end } = params.range;
+            const items = result.filter(item => item.file === rel &&
+                item.startLine - 1 <= end.line && (item.endLine || item.startLine) - 1 >= start.line);
+            const diagnosticsAt = line => (params.context?.diagnostics || []).filter(d =>
+                d.source === 'ucn' && d.range.start.line === line);
+            const { fixes } = planFixes(index, items);
+            const removals = fixes.map((fix) => {
+                const name = fix.item.className ? `${fix.item.className}.${fix.item.name}` : fix.item.name;
+                const diagnostics = diagnosticsAt(fix.item.startLine - 1);
+                // An import is its own fix; a declaration takes the imports only it used
+                const imports = fix.kind === 'import' ? [] : orphanedImports(index, rel, fix.ranges);
+                return {
+                    title: `Remove unused ${fix.kind} ${name}` + (imports.length ? ` and ${imports.length} import(s) only it uses` : ''),
+                    kind: 'quickfix',
+                    ...(diagnostics.length && { diagnostics, isPreferred: true }),
+                    edit: {
+                        changes: {
+                            [params.textDocument.uri]: mergeRanges([...fix.ranges, ...imports]).map(([from, to]) => ({
+                                range: { start: { line: from - 1, character: 0 }, end: { line: to, character: 0 } },
+                                newText: '',
+                            })),
+                        },
+                    },
+                };
+            });
+            const lineOf = lineReader();
+            const comment = langTraits(index.files.get(file)?.language)?.lineComment || '//';
+            const suppressions = [...findingsOf('deadcode', items, rel => index.configFor(rel).rules)].map((f) => {
+                const line = f.at.startLine - 1;
+                const indent = lineOf(file, line).match(/^\s*/)[0];
+                const diagnostics = diagnosticsAt(line).filter(d => d.code === f.rule);
+                return {
+                    title: `Suppress ${f.rule} for ${f.at.name || f.at.file} with a comment`,
+                    kind: 'quickfix',
+                    ...(diagnostics.length && { diagnostics }),
+                    edit: {
+                        changes: {
+                            [params.textDocument.uri]: [{
+                                range: { start: { line, character: 0 }, end: { line, character: 0 } },
+                                newText: `${indent}${comment} ucn:ignore[${f.rule}]\n`,
+                            }],
+                        },
+                    },
+                };
+            });
+            return [...removals, ...suppressions];
+        },
+    };
+
+    const notifications = {
+        initialized() {
+            if (!watch) {
+                analyze();
+                return;
+            }
+            const { watchProject } = require('./watch');
+            watcher = watchProject(index, run, {
+                command: 'deadcode',
+                onUpdate: ({ changed, result: r }) => {
+                    log.debug('lsp.changed', { files: changed.length });
+                    publish(r);
+                },
+                onError: e => notify('window/logMessage', { type: 1, message: `ucn: ${e.message}` }),
+                buildOptions,
+            });
+            publish(watcher.result);
+        },
+
+        'textDocument/didOpen'({ textDocument }) {
+            docs.set(textDocument.uri, textDocument.text);
+            overlay(textDocument.uri, textDocument.text);
+            schedule();
+        },
+
+        'textDocument/didChange'({ textDocument, contentChanges }) {
+            // Full sync: the last change holds the whole text
+            const text = contentChanges[contentChanges.length - 1]?.text;
+            if (text === undefined) return;
+            docs.set(textDocument.uri, text);
+            overlay(textDocument.uri, text);
+            schedule();
+        },
+
+        'textDocument/didClose'({ textDocument }) {
+            docs.delete(textDocument.uri);
+            const file = uriToPath(textDocument.uri);
+            if (file && index.dropOverlay(file)) schedule();
+        },
+
+        exit() {
+            close();
+            onExit(shutdown ? 0 : 1);
+        },
+    };
+
+    function close() {
+        if (timer) clearTimeout(timer);
+        timer = null;
+        if (watcher) watcher.close();
+        watcher = null;
+    }
+
+    function handle(message) {
+        const { id, method, params } = message;
+        // A response to a request of ours (none need an answer)
+        if (!method) return;
+        if (id === undefined) {
+            const fn = notifications[method];
+            if (!fn || (!index && method !== 'exit')) return;
+            try {
+                fn(params || {});
+            } catch (e) {
+                notify('window/logMessage', { type: 1, message: `ucn: ${method}: ${e.message}` });
+            }
+            return;
+        }
+        const reply = (body) => send({ jsonrpc: '2.0', id, ...body });
+        const fn = requests[method];
+        if (!fn) return reply({ error: { code: METHOD_NOT_FOUND, message: `Unhandled method ${method}` } });
+        if (!index && method !== 'initialize') {
+            return reply({ error: { code: SERVER_NOT_INITIALIZED, message: 'The server is not initialized.' } });
+        }
+        const start = Date.now();
+        try {
+            reply({ result: fn(params || {}) });
+        } catch (e) {
+            reply({ error: { code: e instanceof RequestError ? e.code : INTERNAL_ERROR, message: e.message } });
+        }
+        log.debug('lsp.request', { method, ms: Date.now() - start });
+    }
+
+    return {
+        handle,
+        close,
+        flush() {
+            if (timer) analyze();
+        },
+    };
+}
+
+/**
+ * Serve LSP on a pair of streams until the client sends exit.
+ * @param {object} [options] - createLspServer options, and input and
+ *   output streams (default stdin and stdout)
+ */
+function startLspServer(options = {}) {
+    const input = options.input || process.stdin;
+    const output = options.output || process.stdout;
+    // stdout carries the protocol alone
+    console.log = console.error;
+    const server = createLspServer({
+        ...options,
+        send: message => output.write(frame(message)),
+        onExit: options.onExit || (code => process.exit(code)),
+    });
+    input.on('data', messageReader(server.handle));
+    input.on('end', () => server.close());
+    return server;
+}
+
+module.exports = { createLspServer, startLspServer, messageReader, frame, wordAt, nameRange, findingTree };
@@ -0,0 +1,228 @@
+/**
+ * core/notify.js — A finding command's summary to Slack or a webhook
+ * (--notify)
+ *
+ * The sinks are the root config's "notify" list:
+ *
+ *   "notify": [
+ *     { "type": "slack", "url": "${SLACK_WEBHOOK_URL}", "when": "changes" },
+ *     { "type": "webhook", "url": "https://example.com/ucn",
+ *       "headers": { "Authorization": "Bearer ${AUDIT_TOKEN}" },
+ *       "commands": ["deadcode"] }
+ *   ]
+ *
+ * ${NAME} in a url or header reads the environment, so secrets stay out
+ * of the config. "when" is always (the default), changes (new or resolved
+ * findings, or a first run) or findings (any shown); "commands" limits a
+ * sink to some finding commands.
+ *
+ * The summary is the shown findings' totals and what changed: under
+ * ucn diff, against the base ref; otherwise against the previous notified
+ * run, whose findings are kept in .ucn-cache/notify/<command>.json (a
+ * scheduled job keeps that file between runs, as it would the cache). The
+ * state moves on only when every sink took the summary, so a failed post
+ * is reported again next time.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { findingsOf } = require('./output/sarif');
+const { toCliName } = require('./registry');
+const { uncoveredFindings } = require('./baseline');
+const { requestJson } = require('./report/http');
+
+const WHEN = ['always', 'changes', 'findings'];
+// Findings listed per section of a Slack message
+const SLACK_LIST = 10;
+
+/** A string with ${NAME} replaced from the environment */
+function expandEnv(value, env) {
+    return String(value).replace(/\$\{([A-Za-z_][A-Za-z0-9_]*)\}/g, (_, name) => {
+        if (env[name] === undefined || env[name] === '') throw new Error(`environment variable ${name} is not set`);
+        return env[name];
+    });
+}
+
+/**
+ * The config's sinks, checked.
+ * @param {*} notify - The config's "notify" value
+ * @returns {{sinks: object[], error: string|null}}
+ */
+function parseSinks(notify) {
+    if (notify === undefined) return { sinks: [], error: null };
+    const list = Array.isArray(notify) ? notify : [notify];
+    const sinks = [];
+    for (const [i, sink] of list.entries()) {
+        const where = `config notify[${i}]`;
+        if (!sink || typeof sink !== 'object') return { sinks: [], error: `Invalid ${where}: expected an object with type and url` };
+        if (!SINKS[sink.type]) return { sinks: [], error: `Invalid ${where}: type must be one of ${Object.keys(SINKS).join(', ')} (got "${sink.type}")` };
+        if (typeof sink.url !== 'string' || !sink.url) return { sinks: [], error: `Invalid ${where}: missing url` };
+        const when = sink.when ?? 'always';
+        if (!WHEN.includes(when)) return { sinks: [], error: `Invalid ${where}: when must be one of ${WHEN.join(', ')} (got "${when}")` };
+        if (sink.commands !== undefined && !Array.isArray(sink.commands)) return { sinks: [], error: `Invalid ${where}: commands must be a list` };
+        if (sink.headers !== undefined && (typeof sink.headers !== 'object' || Array.isArray(sink.headers))) {
+            return { sinks: [], error: `Invalid ${where}: headers must be an object` };
+        }
+        sinks.push({ ...sink, when, where });
+    }
+    return { sinks, error: null };
+}
+
+/** The fields of a finding a summary carries */
+function summaryFinding(f) {
+    return { rule: f.rule, severity: f.severity, file: f.at.file, line: f.at.startLine, name: f.at.name, message: f.message, fingerprint: f.fingerprint };
+}
+
+/**
+ * Totals and changes of a run.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's shown result
+ * @param {object|null} previous - The previous run's state ({ at, findings })
+ * @param {object} options - { root, rules, now (ISO time) }
+ * @returns {object} { tool, command, project, at, total, bySeverity,
+ *   since, new, resolved, findings }; new and resolved are null on a
+ *   first run
+ */
+function summarize(command, result, previous, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)].map(summaryFinding);
+    const bySeverity = { error: 0, warning: 0, info: 0 };
+    for (const f of findings) bySeverity[f.severity] = (bySeverity[f.severity] || 0) + 1;
+    let since = null;
+    let introduced = null;
+    let resolved = null;
+    if (result?.diff) {
+        // ucn diff shows just the introduced findings
+        since = result.diff.base;
+        introduced = findings;
+        resolved = result.diff.resolved;
+    } else if (previous) {
+        since = previous.at;
+        introduced = uncoveredFindings(findings, previous.findings || []);
+        resolved = uncoveredFindings(previous.findings || [], findings);
+    }
+    return {
+        tool: 'ucn',
+        command: toCliName(command),
+        project: path.basename(path.resolve(options.root || '.')),
+        at: options.now || new Date().toISOString(),
+        total: findings.length,
+        bySeverity,
+        since,
+        new: introduced,
+        resolved,
+        findings,
+    };
+}
+
+/** Slack's mrkdwn with &, < and > escaped */
+function slackText(text) {
+    return String(text).replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;');
+}
+
+/**
+ * A Slack incoming-webhook message of a summary.
+ * @param {object} summary - From summarize
+ * @returns {{text: string}}
+ */
+function slackMessage(summary) {
+    const changes = summary.new === null ? ' (first run)'
+        : ` (${summary.new.length} new, ${summary.resolved.length} resolved since ${slackText(summary.since)})`;
+    const severities = Object.entries(summary.bySeverity).filter(([, n]) => n > 0).map(([s, n]) => `${n} ${s}`);
+    const lines = [
+        `*ucn ${summary.command}* · ${slackText(summary.project)}: ${summary.total} finding(s)${changes}` +
+            (severities.length ? `\n${severities.join(', ')}` : ''),
+    ];
+    const section = (title, list) => {
+        if (!list?.length) return;
+        lines.push(`*${title}*`);
+        for (const f of list.slice(0, SLACK_LIST)) lines.push(`• \`${slackText(f.file)}:${f.line}\` ${slackText(f.message)} (${f.rule})`);
+        if (list.length > SLACK_LIST) lines.push(`… and ${list.length - SLACK_LIST} more`);
+    };
+    section('New', summary.new);
+    section('Resolved', summary.resolved);
+    return { text: lines.join('\n') };
+}
+
+// Sink type → request body of a summary; a webhook gets the summary as it is
+const SINKS = {
+    slack: slackMessage,
+    webhook: summary => summary,
+};
+
+/** Whether a sink wants a summary */
+function wants(sink, summary) {
+    if (sink.commands && !sink.commands.includes(summary.command)) return false;
+    if (sink.when === 'findings') return summary.total > 0;
+    if (sink.when === 'changes') return summary.new === null || summary.new.length > 0 || summary.resolved.length > 0;
+    return true;
+}
+
+/** Where a command's last notified findings are kept */
+function statePath(root, command) {
+    return path.join(root, '.ucn-cache', 'notify', `${toCliName(command)}.json`);
+}
+
+function readState(file) {
+    try {
+        return JSON.parse(fs.readFileSync(file, 'utf-8'));
+    } catch {
+        return null;
+    }
+}
+
+/**
+ * Send a run's summary to the config's sinks.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's shown result
+ * @param {object} options - { root, rules, notify (the config's value),
+ *   env, fetch, now }
+ * @returns {Promise<string[]>} A line per sink; rejects, after trying
+ *   every sink, with the failures
+ */
+async function notify(command, result, options) {
+    const { sinks, error } = parseSinks(options.notify);
+    if (error) throw new Error(error);
+    if (sinks.length === 0) throw new Error('No sinks: add a "notify" list to .ucn.json (see ucn --help).');
+    const env = options.env || process.env;
+    const file = statePath(options.root, command);
+    const previous = result?.diff ? null : readState(file);
+    const summary = summarize(command, result, previous, options);
+    const lines = [];
+    const failures = [];
+    for (const sink of sinks) {
+        if (!wants(sink, summary)) {
+            lines.push(`Notify ${sink.type}: skipped`);
+            continue;
+        }
+        try {
+            const url = expandEnv(sink.url, env);
+            const headers = {};
+            for (const [k, v] of Object.entries(sink.headers || {})) headers[k] = expandEnv(v, env);
+            await requestJson(options.fetch || globalThis.fetch, 'POST', url, { headers, body: SINKS[sink.type](summary) });
+            lines.push(`Notify ${sink.type}: sent ${summary.total} finding(s)` +
+                (summary.new === null ? '' : `, ${summary.new.length} new, ${summary.resolved.length} resolved`));
+        } catch (e) {
+            // The URL can be a secret: name the sink, not the address
+            failures.push(`${sink.where} (${sink.type}): ${e.message.split(expandSafe(sink.url, env)).join('<url>')}`);
+        }
+    }
+    if (failures.length > 0) throw new Error(failures.join('; '));
+    if (!result?.diff) {
+        fs.mkdirSync(path.dirname(file), { recursive: true });
+        fs.writeFileSync(file, JSON.stringify({ at: summary.at, findings: summary.findings }, null, 2) + '\n');
+    }
+    return lines;
+}
+
+/** A sink's URL with what the environment has, for masking it in errors */
+function expandSafe(url, env) {
+    try {
+        return expandEnv(url, env);
+    } catch {
+        return url;
+    }
+}
+
+module.exports = { notify, parseSinks, summarize, slackMessage, statePath };
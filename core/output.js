@@ -23,4 +23,15 @@ module.exports = {
     ...require('./output/doctor'),
     ...require('./output/check'),
     ...require('./output/endpoints'),
+    ...require('./output/sarif'),
+    ...require('./output/html'),
+    ...require('./output/lcov'),
+    ...require('./output/csv'),
+    ...require('./output/xml'),
+    ...require('./output/tap'),
+    ...require('./output/platforms'),
+    ...require('./output/markdown'),
+    ...require('./output/metrics'),
+    ...require('./output/template'),
+    ...require('./output/mermaid'),
 };
@@ -4,8 +4,30 @@
 
 'use strict';
 
+const { findingsOf } = require('./sarif');
+
+/** check --stdin: the buffer's findings, one line each */
+function formatBufferCheck(result) {
+    const findings = [...findingsOf('check', result)]
+        .sort((a, b) => a.at.startLine - b.at.startLine || a.rule.localeCompare(b.rule));
+    const lines = [
+        `Buffer Check: ${result.file} (${result.language}${result.onDisk ? '' : ', not on disk'})`,
+        '═'.repeat(60),
+    ];
+    if (findings.length === 0) {
+        lines.push('No findings.');
+        return lines.join('\n');
+    }
+    lines.push(`${findings.length} finding(s):`);
+    for (const f of findings) {
+        lines.push(`  ${f.at.file}:${f.at.startLine}  ${f.severity}  ${f.rule}  ${f.message}`);
+    }
+    return lines.join('\n');
+}
+
 function formatCheck(result) {
     if (!result) return 'No check result.';
+    if (result.parts) return formatBufferCheck(result);
     if (result.empty) {
         return `Pre-commit Check (${result.base}${result.staged ? ', staged' : ''})\n${'═'.repeat(60)}\nNo changes to analyze${result.reason ? ` (${result.reason})` : ''}.`;
     }
@@ -0,0 +1,68 @@
+/**
+ * core/output/csv.js — CSV and TSV tables of findings (--format csv, --format tsv)
+ *
+ * One header row, then one row per finding from the same pass as SARIF,
+ * with a fixed column order so spreadsheets and BI loads can rely on it:
+ * file, line, symbol, kind, rule, confidence, message, severity. New
+ * columns are only ever appended. CSV quotes per RFC 4180; TSV has no quoting, so tabs
+ * and line breaks inside a value become spaces.
+ */
+
+'use strict';
+
+const { findingsOf } = require('./sarif');
+
+const COLUMNS = ['file', 'line', 'symbol', 'kind', 'rule', 'confidence', 'message', 'severity'];
+
+// Kind for findings whose result entry has no symbol type of its own
+const KIND_BY_RULE = {
+    'clone': 'function',
+    'duplicate-literal': 'literal',
+    'missing-await': 'call',
+    'deprecated-use': 'reference',
+};
+
+/** One finding as its column values */
+function rowOf(f) {
+    return [
+        f.at.file,
+        f.at.startLine,
+        f.at.name || '',
+        f.data?.type || KIND_BY_RULE[f.rule] || '',
+        f.rule,
+        f.confidence,
+        f.message,
+        f.severity,
+    ];
+}
+
+function csvField(value) {
+    const text = String(value);
+    return /[",\r\n]/.test(text) ? `"${text.replace(/"/g, '""')}"` : text;
+}
+
+/**
+ * CSV table of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} Header row and one row per finding
+ */
+function formatCsv(command, result, options = {}) {
+    const rows = [COLUMNS, ...[...findingsOf(command, result, options.rules)].map(rowOf)];
+    return rows.map(r => r.map(csvField).join(',')).join('\n');
+}
+
+/**
+ * TSV table of a finding command's result, same columns as formatCsv.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} Header row and one row per finding
+ */
+function formatTsv(command, result, options = {}) {
+    const rows = [COLUMNS, ...[...findingsOf(command, result, options.rules)].map(rowOf)];
+    return rows.map(r => r.map(v => String(v).replace(/[\t\r\n]+/g, ' ')).join('\t')).join('\n');
+}
+
+module.exports = { formatCsv, formatTsv, CSV_COLUMNS: COLUMNS };
@@ -251,6 +251,7 @@ function formatGraph(graph, options = {}) {
         options = { showAll: options };
     }
     if (graph?.error) return formatFileError(graph);
+    if (graph.symbols) return formatSymbolGraphDot(graph);
     if (graph.nodes.length === 0) {
         const file = options.file || graph.root || '';
         return file ? `File not found: ${file}` : 'File not found.';
@@ -387,6 +388,16 @@ function formatGraph(graph, options = {}) {
  */
 function formatGraphJson(graph) {
     if (graph?.error) return JSON.stringify({ found: false, error: graph.error, file: graph.filePath }, null, 2);
+    if (graph.symbols) {
+        return JSON.stringify({
+            pattern: graph.pattern,
+            direction: graph.direction,
+            maxDepth: graph.maxDepth,
+            ...(graph.depthTruncated && { depthTruncated: true }),
+            nodes: graph.nodes,
+            edges: graph.edges,
+        }, null, 2);
+    }
     const result = {
         root: graph.root,
         direction: graph.direction,
@@ -400,6 +411,55 @@ function formatGraphJson(graph) {
     return JSON.stringify(result, null, 2);
 }
 
+/** DOT string literal */
+function dotString(text) {
+    return `"${String(text).replace(/\\/g, '\\\\').replace(/"/g, '\\"').replace(/\n/g, '\\n')}"`;
+}
+
+/**
+ * Format a symbol call graph (graph --symbols) as Graphviz DOT: one cluster
+ * per directory, entry points green, symbols no entry point reaches red and
+ * dashed, symbols matching the pattern in bold. Render with
+ * `dot -Tsvg graph.dot > graph.svg`.
+ */
+function formatSymbolGraphDot(graph) {
+    const lines = [];
+    lines.push(`// ucn graph --symbols${graph.pattern ? ` ${graph.pattern}` : ''} (direction ${graph.direction}, depth ${graph.maxDepth})`);
+    lines.push('// green: entry point · red dashed: no entry point reaches it · bold: matches the pattern');
+    if (graph.depthTruncated) lines.push('// depth-truncated: raise --depth to follow more calls');
+    if (graph.nodes.length === 0) lines.push('// no symbols match');
+    lines.push('digraph ucn {');
+    lines.push('    rankdir=LR;');
+    lines.push('    node [shape=box, style="rounded,filled", fillcolor=white, fontname="Helvetica", fontsize=10];');
+    lines.push('    edge [fontname="Helvetica", fontsize=9];');
+
+    const byDir = new Map();
+    for (const n of graph.nodes) {
+        const dir = n.file.includes('/') ? n.file.slice(0, n.file.lastIndexOf('/')) : '.';
+        if (!byDir.has(dir)) byDir.set(dir, []);
+        byDir.get(dir).push(n);
+    }
+    let cluster = 0;
+    for (const [dir, nodes] of byDir) {
+        lines.push(`    subgraph cluster_${cluster++} {`);
+        lines.push(`        label=${dotString(dir)}; style=dashed; color=gray60;`);
+        for (const n of nodes) {
+            const label = `${n.className ? `${n.className}.` : ''}${n.name}\n${n.file}:${n.line}`;
+            const attrs = [`label=${dotString(label)}`];
+            if (n.entrypoint) attrs.push('fillcolor="#d4f7d4"', 'color="#1a7f37"');
+            else if (!n.reachable) attrs.push('fillcolor="#ffe3e3"', 'color="#cf222e"', 'style="rounded,filled,dashed"');
+            if (n.seed) attrs.push('penwidth=2');
+            lines.push(`        ${dotString(n.id)} [${attrs.join(', ')}];`);
+        }
+        lines.push('    }');
+    }
+    for (const e of graph.edges) {
+        lines.push(`    ${dotString(e.from)} -> ${dotString(e.to)}${e.calls > 1 ? ` [label="${e.calls}"]` : ''};`);
+    }
+    lines.push('}');
+    return lines.join('\n');
+}
+
 function formatCircularDeps(result) {
     if (!result) return 'No results.';
     const lines = [];
@@ -450,6 +510,7 @@ module.exports = {
     formatApiJson,
     formatGraph,
     formatGraphJson,
+    formatSymbolGraphDot,
     formatCircularDeps,
     formatCircularDepsJson,
 };
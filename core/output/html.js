@@ -0,0 +1,289 @@
+/**
+ * core/output/html.js — Self-contained HTML report (--format html) and
+ * coverage-style per-file view (--format html-coverage)
+ *
+ * One file with inline CSS and script, nothing fetched: a dashboard of
+ * findings per rule and dead lines per directory, then one section per
+ * package (directory) with a sortable table of its findings and the code
+ * each one points at. Findings come from the same pass as SARIF and JSON
+ * Lines; snippets are read from the project root and highlighted here, so
+ * the page needs no highlighter of its own.
+ *
+ * The coverage view renders whole files instead, with the lines the
+ * deletable findings span shaded like uncovered lines in a coverage
+ * report.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { findingsOf, deadLines, RULES } = require('./sarif');
+
+const SNIPPET_LINES = 12;
+
+// Words highlighted as keywords, across the indexed languages
+const KEYWORDS = new Set(('break case catch chan class const continue def default defer del elif else enum export ' +
+    'do end extends false final fn for from fun func function go goto if impl implements import in interface is lambda let loop match ' +
+    'mod mut new nil none null package pass private protected pub public raise range return select self static ' +
+    'struct super switch then this throw throws trait true try type typeof val var void when where while with yield async await').split(' '));
+
+// Files whose language starts a comment with `#`
+const HASH_COMMENTS = /\.(py|rb|sh|ya?ml|toml|pl|r)$/i;
+
+const TOKEN = /(\/\/.*$|#.*$|\/\*.*?\*\/)|("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|`[^`]*`)|(\b\d[\w.]*\b)|([A-Za-z_]\w*)/g;
+
+function escapeHtml(text) {
+    return String(text).replace(/[&<>"']/g, c => ({ '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;' }[c]));
+}
+
+/** One source line as highlighted HTML; `#` comments only where the language has them */
+function highlight(line, hashComments) {
+    let html = '';
+    let last = 0;
+    for (const m of line.matchAll(TOKEN)) {
+        const [text, comment, string, number, word] = m;
+        if (comment && comment.startsWith('#') && !hashComments) continue;
+        html += escapeHtml(line.slice(last, m.index));
+        const cls = comment ? 'c' : string ? 's' : number ? 'n' : KEYWORDS.has(word) ? 'k' : null;
+        html += cls ? `<span class="${cls}">${escapeHtml(text)}</span>` : escapeHtml(text);
+        last = m.index + text.length;
+        if (comment) break;
+    }
+    return html + escapeHtml(line.slice(last));
+}
+
+/** Highlighted lines around a finding, or '' when the file cannot be read */
+function snippet(root, at, cache) {
+    if (!cache.has(at.file)) {
+        let lines = null;
+        try { lines = fs.readFileSync(path.join(root, at.file), 'utf-8').split('\n'); } catch { /* not on disk */ }
+        cache.set(at.file, lines);
+    }
+    const lines = cache.get(at.file);
+    if (!lines) return '';
+    const hashComments = HASH_COMMENTS.test(at.file);
+    const end = Math.min(at.endLine || at.startLine, at.startLine + SNIPPET_LINES - 1, lines.length);
+    const rows = [];
+    for (let n = at.startLine; n <= end; n++) {
+        rows.push(`<span class="ln">${n}</span>${highlight(lines[n - 1], hashComments)}`);
+    }
+    const more = (at.endLine || at.startLine) > end ? `\n<span class="ln">…</span>${(at.endLine - end)} more line(s)` : '';
+    return `<pre class="code">${rows.join('\n')}${more}</pre>`;
+}
+
+const STYLE = `
+body{font:14px/1.45 system-ui,sans-serif;margin:0;color:#1f2328;background:#f6f8fa}
+header{background:#24292f;color:#fff;padding:16px 24px}header h1{margin:0;font-size:20px}header p{margin:4px 0 0;color:#c9d1d9}
+main{padding:16px 24px;max-width:1200px}
+.cards{display:flex;gap:12px;flex-wrap:wrap;margin-bottom:16px}
+.card{background:#fff;border:1px solid #d0d7de;border-radius:6px;padding:12px 16px;min-width:140px}
+.card b{display:block;font-size:22px}
+section{background:#fff;border:1px solid #d0d7de;border-radius:6px;margin-bottom:16px;padding:12px 16px}
+h2{font-size:16px;margin:0 0 8px}
+table{border-collapse:collapse;width:100%}
+th,td{text-align:left;padding:4px 8px;border-bottom:1px solid #eaeef2;vertical-align:top}
+th{cursor:pointer;user-select:none;background:#f6f8fa}th:after{content:" \\2195";color:#8c959f}
+.bar{background:#0969da;height:10px;border-radius:2px}
+.error{color:#cf222e;font-weight:600}.warning{color:#9a6700;font-weight:600}.info,.low{color:#57606a}
+details summary{cursor:pointer;font-weight:600}
+tr.finding td{border-bottom:none}
+pre.code{margin:0 0 8px;background:#f6f8fa;border:1px solid #eaeef2;border-radius:4px;padding:6px 8px;overflow-x:auto;font:12px/1.4 ui-monospace,monospace}
+.ln{display:inline-block;width:4em;color:#8c959f;user-select:none}
+.k{color:#cf222e}.s{color:#0a3069}.c{color:#6e7781;font-style:italic}.n{color:#0550ae}
+`;
+
+// Click a header to sort by that column; click again to reverse. A finding's
+// snippet row travels with it.
+const SCRIPT = `
+document.querySelectorAll('table.sortable').forEach(function (table) {
+  table.querySelectorAll('th').forEach(function (th, col) {
+    th.addEventListener('click', function () {
+      var body = table.tBodies[0];
+      var groups = [];
+      for (var i = 0; i < body.rows.length; i++) {
+        var row = body.rows[i];
+        if (row.classList.contains('snippet')) groups[groups.length - 1].push(row);
+        else groups.push([row]);
+      }
+      var dir = th.dataset.dir === 'asc' ? -1 : 1;
+      th.dataset.dir = dir === 1 ? 'asc' : 'desc';
+      groups.sort(function (a, b) {
+        var x = a[0].cells[col].dataset.sort || a[0].cells[col].textContent;
+        var y = b[0].cells[col].dataset.sort || b[0].cells[col].textContent;
+        var nx = parseFloat(x), ny = parseFloat(y);
+        return dir * (!isNaN(nx) && !isNaN(ny) ? nx - ny : x.localeCompare(y));
+      });
+      groups.forEach(function (g) { g.forEach(function (r) { body.appendChild(r); }); });
+    });
+  });
+});
+`;
+
+/**
+ * Self-contained HTML report of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} options - { root } project root, for snippets; { rules } from .ucn.json
+ * @returns {string} HTML document
+ */
+function formatHtmlReport(command, result, options = {}) {
+    const pkg = require('../../package.json');
+    const findings = [...findingsOf(command, result, options.rules)];
+    const spanOf = f => (f.at.endLine || f.at.startLine) - f.at.startLine + 1;
+    const lineLabel = command === 'deadcode' ? 'Dead LOC' : 'Lines flagged';
+
+    const byRule = new Map();
+    const byDir = new Map();
+    for (const f of findings) {
+        if (!byRule.has(f.rule)) byRule.set(f.rule, { severity: f.severity, count: 0 });
+        byRule.get(f.rule).count++;
+        const dir = path.posix.dirname(f.at.file);
+        if (!byDir.has(dir)) byDir.set(dir, { dir, findings: [], lines: 0 });
+        const d = byDir.get(dir);
+        d.findings.push(f);
+        d.lines += deadLines(f);
+    }
+    const dirs = [...byDir.values()].sort((a, b) => b.lines - a.lines || b.findings.length - a.findings.length ||
+        (a.dir < b.dir ? -1 : a.dir > b.dir ? 1 : 0));
+    const totalLines = dirs.reduce((n, d) => n + d.lines, 0);
+    const maxLines = Math.max(1, ...dirs.map(d => d.lines));
+    const errors = findings.filter(f => f.severity === 'error').length;
+    const warnings = findings.filter(f => f.severity === 'warning').length;
+
+    const html = [];
+    html.push('<!DOCTYPE html>', '<html lang="en">', '<head>', '<meta charset="utf-8">',
+        `<title>ucn ${escapeHtml(command)} report</title>`, `<style>${STYLE}</style>`, '</head>', '<body>');
+    html.push(`<header><h1>ucn ${escapeHtml(command)} report</h1><p>${escapeHtml(options.root || '')} · ucn ${escapeHtml(pkg.version)}</p></header>`);
+    html.push('<main>');
+    html.push('<div class="cards">',
+        `<div class="card"><b>${findings.length}</b>finding(s)</div>`,
+        ...(errors > 0 ? [`<div class="card"><b>${errors}</b>error(s)</div>`] : []),
+        `<div class="card"><b>${warnings}</b>warning(s)</div>`,
+        `<div class="card"><b>${byDir.size}</b>package(s)</div>`,
+        `<div class="card"><b>${totalLines}</b>${lineLabel}</div>`,
+        '</div>');
+
+    html.push('<section><h2>Summary by directory</h2><table class="sortable"><thead><tr>',
+        `<th>Directory</th><th>Findings</th><th>${lineLabel}</th><th></th></tr></thead><tbody>`);
+    for (const d of dirs) {
+        html.push(`<tr><td><a href="#pkg-${escapeHtml(d.dir)}">${escapeHtml(d.dir)}</a></td><td>${d.findings.length}</td><td>${d.lines}</td>` +
+            `<td data-sort="${d.lines}"><div class="bar" style="width:${Math.round((d.lines / maxLines) * 200)}px"></div></td></tr>`);
+    }
+    html.push('</tbody></table></section>');
+
+    html.push('<section><h2>Summary by rule</h2><table class="sortable"><thead><tr><th>Rule</th><th>Severity</th><th>Findings</th><th>Description</th></tr></thead><tbody>');
+    for (const [rule, { severity, count }] of [...byRule].sort((a, b) => b[1].count - a[1].count)) {
+        const [name, description] = RULES[rule];
+        html.push(`<tr><td>${escapeHtml(name)}</td><td class="${severity}">${severity}</td><td>${count}</td><td>${escapeHtml(description)}</td></tr>`);
+    }
+    html.push('</tbody></table></section>');
+
+    const cache = new Map();
+    for (const d of dirs) {
+        html.push(`<section id="pkg-${escapeHtml(d.dir)}"><details open><summary>${escapeHtml(d.dir)} — ${d.findings.length} finding(s), ${d.lines} ${command === 'deadcode' ? 'dead' : 'flagged'} line(s)</summary>`);
+        html.push('<table class="sortable"><thead><tr><th>Location</th><th>Rule</th><th>Severity</th><th>Confidence</th><th>Lines</th><th>Finding</th></tr></thead><tbody>');
+        for (const f of d.findings) {
+            const [name] = RULES[f.rule];
+            const where = `${f.at.file}:${f.at.startLine}`;
+            const related = (f.related || []).map(r => `${r.file}:${r.startLine}`).join(', ');
+            html.push(`<tr class="finding"><td data-sort="${escapeHtml(f.at.file)}:${String(f.at.startLine).padStart(8, '0')}">${escapeHtml(where)}</td>` +
+                `<td>${escapeHtml(name)}</td><td class="${f.severity}">${f.severity}</td><td class="${f.confidence}">${f.confidence}</td><td>${spanOf(f)}</td>` +
+                `<td>${escapeHtml(f.message)}${related ? `<br><small>also: ${escapeHtml(related)}</small>` : ''}</td></tr>`);
+            const code = options.root ? snippet(options.root, f.at, cache) : '';
+            if (code) html.push(`<tr class="snippet"><td colspan="6">${code}</td></tr>`);
+        }
+        html.push('</tbody></table></details></section>');
+    }
+    if (findings.length === 0) html.push('<section><p>No findings.</p></section>');
+    html.push('</main>', `<script>${SCRIPT}</script>`, '</body>', '</html>');
+    return html.join('\n');
+}
+
+const COVERAGE_STYLE = `
+body{font:14px/1.45 system-ui,sans-serif;margin:0;color:#1f2328;background:#f6f8fa}
+header{background:#24292f;color:#fff;padding:16px 24px}header h1{margin:0;font-size:20px}header p{margin:4px 0 0;color:#c9d1d9}
+main{padding:16px 24px}
+table.files{border-collapse:collapse;background:#fff;border:1px solid #d0d7de;margin-bottom:16px}
+table.files th,table.files td{text-align:left;padding:4px 10px;border-bottom:1px solid #eaeef2}
+.meter{display:inline-block;width:120px;height:10px;background:#dafbe1;border-radius:2px;vertical-align:middle}
+.meter i{display:block;height:10px;background:#cf222e;border-radius:2px}
+section{background:#fff;border:1px solid #d0d7de;border-radius:6px;margin-bottom:16px}
+h2{font-size:15px;margin:0;padding:8px 12px;border-bottom:1px solid #d0d7de;background:#f6f8fa}
+pre{margin:0;font:12px/1.5 ui-monospace,monospace;overflow-x:auto}
+pre span.line{display:block;padding-right:12px}
+.ln{display:inline-block;width:4.5em;padding-right:8px;text-align:right;color:#8c959f;user-select:none;border-right:1px solid #eaeef2;margin-right:8px}
+.dead{background:#ffebe9}.dead .ln{background:#ffcecb;color:#82071e}
+.k{color:#cf222e}.s{color:#0a3069}.c{color:#6e7781;font-style:italic}.n{color:#0550ae}
+`;
+
+/**
+ * Coverage-style HTML view of a finding command's result: every file with
+ * findings rendered in full, the lines deletable findings span highlighted
+ * the way a coverage report marks uncovered ones, behind an index of files
+ * sorted by their share of dead lines. Reads sources from the root.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} options - { root } project root; { rules } from .ucn.json
+ * @returns {string} HTML document
+ */
+function formatCoverageHtml(command, result, options = {}) {
+    const pkg = require('../../package.json');
+    const byFile = new Map();
+    for (const f of findingsOf(command, result, options.rules)) {
+        if (deadLines(f) === 0) continue;
+        if (!byFile.has(f.at.file)) byFile.set(f.at.file, []);
+        byFile.get(f.at.file).push(f);
+    }
+
+    const files = [];
+    for (const [file, findings] of byFile) {
+        let lines = null;
+        try { lines = fs.readFileSync(path.join(options.root || '.', file), 'utf-8').split('\n'); } catch { /* not on disk */ }
+        if (!lines) continue;
+        if (lines.length > 1 && lines[lines.length - 1] === '') lines.pop();
+        // Line → the findings covering it, for the hover title
+        const dead = new Map();
+        for (const f of findings) {
+            for (let n = f.at.startLine; n <= Math.min(f.at.endLine || f.at.startLine, lines.length); n++) {
+                if (!dead.has(n)) dead.set(n, []);
+                dead.get(n).push(`${f.message} (${f.severity}, ${f.confidence} confidence)`);
+            }
+        }
+        files.push({ file, lines, dead, share: dead.size / Math.max(1, lines.length) });
+    }
+    files.sort((a, b) => b.share - a.share || (a.file < b.file ? -1 : a.file > b.file ? 1 : 0));
+    const totalDead = files.reduce((n, f) => n + f.dead.size, 0);
+    const totalLines = files.reduce((n, f) => n + f.lines.length, 0);
+
+    const html = [];
+    html.push('<!DOCTYPE html>', '<html lang="en">', '<head>', '<meta charset="utf-8">',
+        `<title>ucn ${escapeHtml(command)} coverage view</title>`, `<style>${COVERAGE_STYLE}</style>`, '</head>', '<body>');
+    html.push(`<header><h1>ucn ${escapeHtml(command)}: dead lines by file</h1><p>${escapeHtml(options.root || '')} · ` +
+        `${totalDead} of ${totalLines} line(s) in ${files.length} file(s) · ucn ${escapeHtml(pkg.version)}</p></header>`);
+    html.push('<main>');
+    if (files.length === 0) html.push('<p>No dead lines.</p>');
+    else {
+        html.push('<table class="files"><thead><tr><th>File</th><th>Dead lines</th><th>Lines</th><th>Dead</th></tr></thead><tbody>');
+        for (const f of files) {
+            const pct = Math.round(f.share * 1000) / 10;
+            html.push(`<tr><td><a href="#${escapeHtml(f.file)}">${escapeHtml(f.file)}</a></td><td>${f.dead.size}</td><td>${f.lines.length}</td>` +
+                `<td><span class="meter"><i style="width:${pct}%"></i></span> ${pct}%</td></tr>`);
+        }
+        html.push('</tbody></table>');
+    }
+    for (const f of files) {
+        const hashComments = HASH_COMMENTS.test(f.file);
+        html.push(`<section id="${escapeHtml(f.file)}"><h2>${escapeHtml(f.file)} — ${f.dead.size} dead line(s)</h2><pre>`);
+        const rows = f.lines.map((text, i) => {
+            const why = f.dead.get(i + 1);
+            return `<span class="line${why ? ' dead' : ''}"${why ? ` title="${escapeHtml(why.join('\n'))}"` : ''}>` +
+                `<span class="ln">${i + 1}</span>${highlight(text, hashComments)}</span>`;
+        });
+        html.push(rows.join(''), '</pre></section>');
+    }
+    html.push('</main>', '</body>', '</html>');
+    return html.join('\n');
+}
+
+module.exports = { formatHtmlReport, formatCoverageHtml };
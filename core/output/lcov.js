@@ -0,0 +1,66 @@
+/**
+ * core/output/lcov.js — LCOV tracefile of dead lines (--format lcov)
+ *
+ * Dead code read as coverage: in every file with deletable findings, the
+ * lines they span are uncovered (hit count 0) and the file's other
+ * non-blank lines covered (1), so Coveralls, Codecov and editor coverage
+ * gutters draw a dead-code map with the UI they already have. Advisory
+ * findings (clones, duplicate literals, complexity) mark no lines, the
+ * same as in the HTML report. Each dead symbol is also a function record
+ * with no hits.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { findingsOf, deadLines } = require('./sarif');
+
+/**
+ * LCOV tracefile of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { root } to read the files from; { rules } from .ucn.json
+ * @returns {string} Tracefile text, newline-terminated
+ */
+function formatLcov(command, result, options = {}) {
+    const byFile = new Map();
+    for (const f of findingsOf(command, result, options.rules)) {
+        if (deadLines(f) === 0) continue;
+        if (!byFile.has(f.at.file)) byFile.set(f.at.file, []);
+        byFile.get(f.at.file).push(f);
+    }
+
+    const out = [];
+    const files = [...byFile].sort((a, b) => (a[0] < b[0] ? -1 : a[0] > b[0] ? 1 : 0));
+    for (const [file, findings] of files) {
+        let lines = null;
+        try { lines = fs.readFileSync(path.join(options.root || '.', file), 'utf-8').split('\n'); } catch { /* not on disk */ }
+        if (!lines) continue;
+        const dead = new Set();
+        for (const f of findings) {
+            for (let n = f.at.startLine; n <= Math.min(f.at.endLine || f.at.startLine, lines.length); n++) dead.add(n);
+        }
+
+        out.push(`TN:ucn-${command}`, `SF:${file}`);
+        const named = findings.filter(f => f.at.name).sort((a, b) => a.at.startLine - b.at.startLine);
+        for (const f of named) {
+            out.push(`FN:${f.at.startLine},${f.at.name}`);
+        }
+        for (const f of named) out.push(`FNDA:0,${f.at.name}`);
+        out.push(`FNF:${named.length}`, 'FNH:0');
+        let found = 0;
+        let hit = 0;
+        lines.forEach((text, i) => {
+            if (text.trim() === '') return;
+            const covered = !dead.has(i + 1);
+            out.push(`DA:${i + 1},${covered ? 1 : 0}`);
+            found++;
+            if (covered) hit++;
+        });
+        out.push(`LF:${found}`, `LH:${hit}`, 'end_of_record');
+    }
+    return out.length > 0 ? out.join('\n') + '\n' : '';
+}
+
+module.exports = { formatLcov };
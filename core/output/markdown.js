@@ -0,0 +1,98 @@
+/**
+ * core/output/markdown.js — Markdown summary of findings (--format markdown)
+ *
+ * Sized for a PR comment or $GITHUB_STEP_SUMMARY: a headline with the
+ * finding count and the dead lines they hold, a table per rule, the
+ * largest findings, then one collapsed table per package (directory).
+ * Dead lines are the summed spans of deletable findings, the same
+ * estimate the HTML report shows.
+ */
+
+'use strict';
+
+const path = require('path');
+const { findingsOf, deadLines, RULES } = require('./sarif');
+
+const TOP_OFFENDERS = 10;
+const ROWS_PER_PACKAGE = 50;
+
+/** Table cell text: pipes escaped, line breaks flattened */
+function cell(text) {
+    return String(text).replace(/\|/g, '\\|').replace(/\r?\n/g, ' ');
+}
+
+/**
+ * Markdown summary of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} Markdown
+ */
+function formatMarkdown(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)];
+    const lines = [];
+    const totalLines = findings.reduce((n, f) => n + deadLines(f), 0);
+    lines.push(`## ucn ${command}: ${findings.length} finding(s)${totalLines ? `, ~${totalLines} dead line(s)` : ''}`);
+    lines.push('');
+    if (findings.length === 0) {
+        lines.push('No findings.');
+        return lines.join('\n');
+    }
+
+    const byRule = new Map();
+    const byDir = new Map();
+    for (const f of findings) {
+        const r = byRule.get(f.rule) || { severity: f.severity, count: 0, lines: 0 };
+        r.count++;
+        r.lines += deadLines(f);
+        byRule.set(f.rule, r);
+        const dir = path.posix.dirname(f.at.file);
+        if (!byDir.has(dir)) byDir.set(dir, { dir, findings: [], lines: 0 });
+        byDir.get(dir).findings.push(f);
+        byDir.get(dir).lines += deadLines(f);
+    }
+
+    lines.push('| Rule | Severity | Findings | Dead lines |');
+    lines.push('|---|---|--:|--:|');
+    for (const [rule, r] of [...byRule].sort((a, b) => b[1].count - a[1].count)) {
+        lines.push(`| ${RULES[rule][0]} | ${r.severity} | ${r.count} | ${r.lines} |`);
+    }
+    lines.push('');
+
+    const top = findings.filter(f => deadLines(f) > 0)
+        .sort((a, b) => deadLines(b) - deadLines(a))
+        .slice(0, TOP_OFFENDERS);
+    if (top.length > 0) {
+        lines.push('### Top offenders');
+        lines.push('');
+        lines.push('| Symbol | Location | Lines | Rule |');
+        lines.push('|---|---|--:|---|');
+        for (const f of top) {
+            lines.push(`| \`${cell(f.at.name || path.posix.basename(f.at.file))}\` | ${cell(`${f.at.file}:${f.at.startLine}`)} | ${deadLines(f)} | ${RULES[f.rule][0]} |`);
+        }
+        lines.push('');
+    }
+
+    lines.push('### By package');
+    lines.push('');
+    const dirs = [...byDir.values()].sort((a, b) => b.lines - a.lines || b.findings.length - a.findings.length ||
+        (a.dir < b.dir ? -1 : a.dir > b.dir ? 1 : 0));
+    for (const d of dirs) {
+        lines.push(`<details><summary><code>${d.dir}</code>: ${d.findings.length} finding(s), ${d.lines} dead line(s)</summary>`);
+        lines.push('');
+        lines.push('| Location | Rule | Confidence | Finding |');
+        lines.push('|---|---|---|---|');
+        for (const f of d.findings.slice(0, ROWS_PER_PACKAGE)) {
+            lines.push(`| ${cell(`${f.at.file}:${f.at.startLine}`)} | ${RULES[f.rule][0]} | ${f.confidence} | ${cell(f.message)} |`);
+        }
+        if (d.findings.length > ROWS_PER_PACKAGE) {
+            lines.push(`| … | | | ${d.findings.length - ROWS_PER_PACKAGE} more |`);
+        }
+        lines.push('');
+        lines.push('</details>');
+        lines.push('');
+    }
+    return lines.join('\n').trimEnd();
+}
+
+module.exports = { formatMarkdown };
@@ -0,0 +1,146 @@
+/**
+ * core/output/mermaid.js — Mermaid flowcharts (--format mermaid)
+ *
+ * graph (the file import tree, one subgraph per directory so package
+ * dependencies read at a glance), graph --symbols (the call graph, with
+ * the DOT export's entry-point and unreachable marks) and trace (the call
+ * tree) render as a fenced `mermaid` block that GitHub, GitLab and most
+ * wikis draw in place. Node ids are positional (n0, n1, ...); the label
+ * carries the name.
+ */
+
+'use strict';
+
+const { formatFileError } = require('./shared');
+
+const MERMAID_COMMANDS = new Set(['graph', 'trace']);
+
+/** Mermaid node label: quoted, parser-breaking characters entity-escaped, newlines as <br/> */
+function label(text) {
+    const escaped = String(text).replace(/"/g, '#quot;').replace(/</g, '#lt;').replace(/>/g, '#gt;');
+    return `"${escaped.replace(/\n/g, '<br/>')}"`;
+}
+
+const dirOf = (file) => (file.includes('/') ? file.slice(0, file.lastIndexOf('/')) : '.');
+
+/** Nodes grouped into one subgraph per directory */
+function subgraphs(lines, nodes, fileOf, render) {
+    const byDir = new Map();
+    for (const n of nodes) {
+        const dir = dirOf(fileOf(n));
+        if (!byDir.has(dir)) byDir.set(dir, []);
+        byDir.get(dir).push(n);
+    }
+    let i = 0;
+    for (const [dir, group] of byDir) {
+        lines.push(`    subgraph d${i++}[${label(dir)}]`);
+        for (const n of group) lines.push(`        ${render(n)}`);
+        lines.push('    end');
+    }
+}
+
+function fileChart(graph) {
+    const lines = ['flowchart LR'];
+    lines.push(`    %% ucn graph ${graph.nodes.find(n => n.file === graph.root)?.relativePath || ''} (direction ${graph.direction}, depth ${graph.maxDepth})`);
+    if (graph.depthTruncated) lines.push('    %% depth-truncated: raise --depth to follow more imports');
+    const ids = new Map();
+    for (const n of graph.nodes) {
+        if (!ids.has(n.file)) ids.set(n.file, `n${ids.size}`);
+    }
+    const seen = new Set();
+    const unique = graph.nodes.filter(n => !seen.has(n.file) && seen.add(n.file));
+    subgraphs(lines, unique, n => n.relativePath, n => {
+        const name = n.relativePath.slice(n.relativePath.lastIndexOf('/') + 1);
+        return `${ids.get(n.file)}[${label(name)}]${n.file === graph.root ? ':::root' : ''}`;
+    });
+    const edges = new Set();
+    for (const e of graph.edges) {
+        if (ids.has(e.from) && ids.has(e.to)) edges.add(`${ids.get(e.from)} --> ${ids.get(e.to)}`);
+    }
+    for (const edge of edges) lines.push(`    ${edge}`);
+    lines.push('    classDef root stroke-width:3px');
+    return lines;
+}
+
+function symbolChart(graph) {
+    const lines = ['flowchart LR'];
+    lines.push(`    %% ucn graph --symbols${graph.pattern ? ` ${graph.pattern}` : ''} (direction ${graph.direction}, depth ${graph.maxDepth})`);
+    lines.push('    %% green: entry point · red dashed: no entry point reaches it · bold: matches the pattern');
+    if (graph.depthTruncated) lines.push('    %% depth-truncated: raise --depth to follow more calls');
+    const ids = new Map(graph.nodes.map((n, i) => [n.id, `n${i}`]));
+    subgraphs(lines, graph.nodes, n => n.file, n => {
+        const name = `${n.className ? `${n.className}.` : ''}${n.name}`;
+        return `${ids.get(n.id)}[${label(`${name}\n${n.file}:${n.line}`)}]`;
+    });
+    for (const e of graph.edges) {
+        lines.push(`    ${ids.get(e.from)} -->${e.calls > 1 ? `|${e.calls}|` : ''} ${ids.get(e.to)}`);
+    }
+    // A node takes several classes through `class` statements, not `:::`
+    const classOf = (cls, pick) => {
+        const members = graph.nodes.filter(pick).map(n => ids.get(n.id));
+        if (members.length > 0) lines.push(`    class ${members.join(',')} ${cls}`);
+    };
+    lines.push('    classDef entry fill:#d4f7d4,stroke:#1a7f37');
+    lines.push('    classDef dead fill:#ffe3e3,stroke:#cf222e,stroke-dasharray:4 3');
+    lines.push('    classDef seed stroke-width:3px');
+    classOf('entry', n => n.entrypoint);
+    classOf('dead', n => !n.entrypoint && !n.reachable);
+    classOf('seed', n => n.seed);
+    return lines;
+}
+
+function traceChart(trace) {
+    const lines = ['flowchart TD'];
+    lines.push(`    %% ucn trace ${trace.root} (direction ${trace.direction}, depth ${trace.maxDepth})`);
+    const ids = new Map();
+    const edges = new Set();
+    const node = (name, file, line, external) => {
+        const key = external ? `external:${name}` : `${file}:${line}`;
+        if (!ids.has(key)) {
+            const id = `n${ids.size}`;
+            ids.set(key, id);
+            const text = external ? `${name} (external)` : `${name}\n${file}:${line}`;
+            lines.push(`    ${id}[${label(text)}]${key === `${trace.file}:${trace.line}` ? ':::root' : external ? ':::external' : ''}`);
+        }
+        return ids.get(key);
+    };
+    const edge = (from, to, count) => edges.add(`${from} -->${count > 1 ? `|${count}x|` : ''} ${to}`);
+    const root = node(trace.root, trace.file, trace.line);
+    const walk = (parent, children) => {
+        for (const c of children || []) {
+            const id = node(c.name, c.file, c.line, c.external);
+            edge(parent, id, c.callCount);
+            if (!c.alreadyShown) walk(id, c.children);
+        }
+    };
+    walk(root, trace.tree?.children);
+    // Callers are call sites: key them by caller name and file
+    for (const c of trace.callers || []) {
+        const key = `caller:${c.file}:${c.name}`;
+        if (!ids.has(key)) {
+            ids.set(key, `n${ids.size}`);
+            lines.push(`    ${ids.get(key)}[${label(`${c.name}\n${c.file}`)}]`);
+        }
+        edge(ids.get(key), root, 1);
+    }
+    for (const e of edges) lines.push(`    ${e}`);
+    lines.push('    classDef root stroke-width:3px');
+    lines.push('    classDef external stroke-dasharray:4 3');
+    return lines;
+}
+
+/**
+ * Mermaid flowchart of a graph or trace result, fenced for markdown.
+ * @param {string} command - Canonical command (graph, trace)
+ * @param {object} result - The command's result, as execute returns it
+ * @returns {string} A ```mermaid block
+ */
+function formatMermaid(command, result) {
+    if (!result) return 'Function not found.';
+    if (result.error) return formatFileError(result);
+    const chart = command === 'trace' ? traceChart(result)
+        : result.symbols ? symbolChart(result) : fileChart(result);
+    return ['```mermaid', ...chart, '```'].join('\n');
+}
+
+module.exports = { formatMermaid, MERMAID_COMMANDS };
@@ -0,0 +1,87 @@
+/**
+ * core/output/metrics.js — Prometheus text exposition of a finding run
+ * (--format prometheus)
+ *
+ * Written for node_exporter's textfile collector: a cron job runs
+ * `ucn deadcode --format prometheus > dir/ucn.prom.$$ && mv ...` and the
+ * gauges below are scraped from there, so dashboards can chart dead code
+ * per package over time. All series are gauges; each run replaces the
+ * last.
+ */
+
+'use strict';
+
+const path = require('path');
+const { findingsOf, deadLines } = require('./sarif');
+
+/** Label value: backslash, double quote and line feed escaped */
+function labelValue(text) {
+    return String(text).replace(/\\/g, '\\\\').replace(/"/g, '\\"').replace(/\n/g, '\\n');
+}
+
+const labels = (obj) => `{${Object.entries(obj).map(([k, v]) => `${k}="${labelValue(v)}"`).join(',')}}`;
+
+/**
+ * Prometheus text format of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { durationSeconds, timestamp } of the run; { rules } from .ucn.json
+ * @returns {string} Exposition text, newline-terminated
+ */
+function formatPrometheus(command, result, options = {}) {
+    return formatPrometheusRuns([[command, result]], options);
+}
+
+/**
+ * Prometheus text format of several commands' results, each metric's
+ * samples under one HELP/TYPE header (ucn serve's /metrics).
+ * @param {Array<[string, *]>} runs - [canonical command, result] pairs
+ * @param {object} [options] - As formatPrometheus; the duration and
+ *   timestamp are of the whole run
+ * @returns {string} Exposition text, newline-terminated
+ */
+function formatPrometheusRuns(runs, options = {}) {
+    const findings = new Map();  // command\0rule\0severity\0package → count
+    const deadSymbols = new Map();
+    const dead = new Map();
+    for (const [command, result] of runs) {
+        for (const f of findingsOf(command, result, options.rules)) {
+            const pkg = path.posix.dirname(f.at.file);
+            const key = `${command}\0${f.rule}\0${f.severity}\0${pkg}`;
+            findings.set(key, (findings.get(key) || 0) + 1);
+            const lines = deadLines(f);
+            if (lines > 0) {
+                deadSymbols.set(pkg, (deadSymbols.get(pkg) || 0) + 1);
+                dead.set(pkg, (dead.get(pkg) || 0) + lines);
+            }
+        }
+    }
+    const sorted = (m) => [...m].sort((a, b) => (a[0] < b[0] ? -1 : a[0] > b[0] ? 1 : 0));
+    const commands = runs.map(([command]) => command);
+
+    const out = [];
+    const metric = (name, help, samples) => {
+        out.push(`# HELP ${name} ${help}`, `# TYPE ${name} gauge`, ...samples);
+    };
+    metric('ucn_findings', 'Findings of the last run, by rule, severity and package (directory).',
+        sorted(findings).map(([key, n]) => {
+            const [command, rule, severity, pkg] = key.split('\0');
+            return `ucn_findings${labels({ command, rule, severity, package: pkg })} ${n}`;
+        }));
+    if (commands.includes('deadcode')) {
+        metric('ucn_dead_symbols', 'Symbols, files and statements the last run found deletable, by package.',
+            sorted(deadSymbols).map(([pkg, n]) => `ucn_dead_symbols${labels({ package: pkg })} ${n}`));
+        metric('ucn_dead_lines', 'Lines those deletable findings span, by package.',
+            sorted(dead).map(([pkg, n]) => `ucn_dead_lines${labels({ package: pkg })} ${n}`));
+    }
+    if (options.durationSeconds != null) {
+        metric('ucn_analysis_duration_seconds', 'Wall time of the last run, index build included.',
+            commands.map(command => `ucn_analysis_duration_seconds${labels({ command })} ${options.durationSeconds.toFixed(3)}`));
+    }
+    const seconds = Math.floor((options.timestamp ?? Date.now()) / 1000);
+    metric('ucn_last_run_timestamp_seconds', 'Unix time the last run finished.',
+        commands.map(command => `ucn_last_run_timestamp_seconds${labels({ command })} ${seconds}`));
+    return out.join('\n') + '\n';
+}
+
+module.exports = { formatPrometheus, formatPrometheusRuns };
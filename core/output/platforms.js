@@ -0,0 +1,192 @@
+/**
+ * core/output/platforms.js — Finding reports in the JSON shapes that
+ * code-review and quality platforms import (--format gitlab, rdjson,
+ * rdjsonl, sonarqube)
+ *
+ * GitLab Code Quality: a JSON array of issues with a check name, a
+ * severity, a path and line range, and an MD5 fingerprint. The merge
+ * request widget compares fingerprints between the base and head
+ * pipelines to show new and fixed issues, so the fingerprint is built
+ * from the line-free finding key, not the line number.
+ *
+ * Reviewdog Diagnostic Format: rdjson is one DiagnosticResult object,
+ * rdjsonl one Diagnostic per line (each naming its source). The rule is
+ * the diagnostic code; import-issue edits in the finding's own file
+ * become suggestions, which reviewdog posts as suggested changes.
+ *
+ * SonarQube generic issue data (10.3+): the rules, each with its clean
+ * code attribute and impact on maintainability, and the issues, each
+ * with its rule, message, file and line range. SonarQube sets severity
+ * per rule, not per issue, so a rule that .ucn.json rates differently by
+ * directory is listed once per severity ("dead-code:error").
+ *
+ * Each carries the finding's severity. None has a field for its
+ * confidence; a reviewdog diagnostic states it in original_output.
+ */
+
+'use strict';
+
+const crypto = require('crypto');
+const { findingsOf, RULES } = require('./sarif');
+
+// Severity → GitLab severity (info, minor, major, critical, blocker)
+const GITLAB_SEVERITY = { error: 'major', warning: 'minor', info: 'info' };
+
+/**
+ * Line-free fingerprints, one per finding; a finding whose key repeats
+ * (two uses of one deprecated symbol in the same function) is told apart
+ * by its occurrence number, which keeps each fingerprint unique.
+ */
+function fingerprints(findings) {
+    const seen = new Map();
+    return findings.map(f => {
+        const id = f.fingerprint;
+        const n = (seen.get(id) || 0) + 1;
+        seen.set(id, n);
+        return crypto.createHash('md5').update(n > 1 ? `${id}#${n}` : id).digest('hex');
+    });
+}
+
+/**
+ * GitLab Code Quality report of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} JSON text
+ */
+function formatGitlabCodeQuality(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)];
+    const prints = fingerprints(findings);
+    return JSON.stringify(findings.map((f, i) => ({
+        type: 'issue',
+        check_name: RULES[f.rule][0],
+        description: f.message,
+        categories: ['Clarity'],
+        severity: GITLAB_SEVERITY[f.severity],
+        fingerprint: prints[i],
+        location: {
+            path: f.at.file,
+            lines: { begin: f.at.startLine, end: f.at.endLine || f.at.startLine },
+        },
+    })), null, 2);
+}
+
+const SOURCE = { name: 'ucn', url: require('../../package.json').homepage };
+
+// Severity → reviewdog severity (ERROR, WARNING, INFO)
+const RD_SEVERITY = { error: 'ERROR', warning: 'WARNING', info: 'INFO' };
+
+/** Suggestions from plan-shaped line edits; an empty newExpression deletes the line */
+function suggestionsOf(f) {
+    return (f.edits || []).filter(e => e.file === f.at.file).map(e => (e.newExpression === ''
+        ? { range: { start: { line: e.line, column: 1 }, end: { line: e.line + 1, column: 1 } }, text: '' }
+        : { range: { start: { line: e.line, column: 1 }, end: { line: e.line, column: e.expression.length + 1 } }, text: e.newExpression }));
+}
+
+/** One finding as a reviewdog Diagnostic */
+function diagnosticOf(f) {
+    const suggestions = suggestionsOf(f);
+    return {
+        message: f.message,
+        location: {
+            path: f.at.file,
+            range: { start: { line: f.at.startLine }, ...(f.at.endLine && { end: { line: f.at.endLine } }) },
+        },
+        severity: RD_SEVERITY[f.severity],
+        code: { value: f.rule },
+        original_output: `${f.severity}, ${f.confidence} confidence`,
+        ...(suggestions.length > 0 && { suggestions }),
+        ...(f.related?.length && {
+            related_locations: f.related.map(r => ({
+                message: r.name || '',
+                location: { path: r.file, range: { start: { line: r.startLine } } },
+            })),
+        }),
+    };
+}
+
+/**
+ * Reviewdog Diagnostic Format (rdjson) of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} JSON text
+ */
+function formatRdjson(command, result, options = {}) {
+    return JSON.stringify({
+        source: SOURCE,
+        diagnostics: [...findingsOf(command, result, options.rules)].map(diagnosticOf),
+    }, null, 2);
+}
+
+/**
+ * Reviewdog Diagnostic Format, one Diagnostic per line (rdjsonl).
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @yields {string} One JSON object, without its newline
+ */
+function* rdjsonLines(command, result, options = {}) {
+    for (const f of findingsOf(command, result, options.rules)) {
+        yield JSON.stringify({ source: SOURCE, ...diagnosticOf(f) });
+    }
+}
+
+// Severity → SonarQube impact severity, and the severity older servers read
+const SONAR_IMPACT = { error: 'HIGH', warning: 'MEDIUM', info: 'LOW' };
+const SONAR_SEVERITY = { error: 'MAJOR', warning: 'MINOR', info: 'INFO' };
+
+/** A SonarQube location: the text range only when there is a line to point at */
+function sonarLocation(message, file, startLine, endLine) {
+    return {
+        message,
+        filePath: file,
+        ...(startLine > 0 && { textRange: { startLine, ...(endLine > startLine && { endLine }) } }),
+    };
+}
+
+/**
+ * SonarQube generic issue import data of a finding command's result, for
+ * sonar.externalIssuesReportPaths.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} JSON text
+ */
+function formatSonarqube(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)];
+    const severities = new Map();
+    for (const f of findings) {
+        if (!severities.has(f.rule)) severities.set(f.rule, []);
+        if (!severities.get(f.rule).includes(f.severity)) severities.get(f.rule).push(f.severity);
+    }
+    // The rule's first severity keeps its plain id
+    const ruleId = f => (severities.get(f.rule)[0] === f.severity ? f.rule : `${f.rule}:${f.severity}`);
+    const rules = [];
+    for (const [rule, list] of severities) {
+        for (const [i, severity] of list.entries()) {
+            rules.push({
+                id: i === 0 ? rule : `${rule}:${severity}`,
+                name: RULES[rule][0],
+                description: RULES[rule][1],
+                engineId: 'ucn',
+                cleanCodeAttribute: 'CLEAR',
+                type: 'CODE_SMELL',
+                severity: SONAR_SEVERITY[severity],
+                impacts: [{ softwareQuality: 'MAINTAINABILITY', severity: SONAR_IMPACT[severity] }],
+            });
+        }
+    }
+    return JSON.stringify({
+        rules,
+        issues: findings.map(f => ({
+            ruleId: ruleId(f),
+            primaryLocation: sonarLocation(f.message, f.at.file, f.at.startLine, f.at.endLine),
+            ...(f.related?.length && {
+                secondaryLocations: f.related.map(r => sonarLocation(r.name || '', r.file, r.startLine, r.endLine)),
+            }),
+        })),
+    }, null, 2);
+}
+
+module.exports = { formatGitlabCodeQuality, formatRdjson, rdjsonLines, formatSonarqube };
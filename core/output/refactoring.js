@@ -2,7 +2,7 @@
  * core/output/refactoring.js - Verify/plan/stacktrace formatters
  */
 
-const { unverifiedReasonLabel, advisoryLine } = require('./shared');
+const { unverifiedReasonLabel, advisoryLine, findingSectionLines } = require('./shared');
 const { formatAccountLines } = require('./analysis');
 
 /**
@@ -437,7 +437,7 @@ function formatAuditAsync(result) {
     if (!result) return 'No async audit data.';
     const issues = Array.isArray(result.issues) ? result.issues : [];
     if (issues.length === 0) {
-        return 'Async audit: no missing-await issues found.';
+        return ['Async audit: no missing-await issues found.', ...findingSectionLines(result)].join('\n');
     }
     const lines = [];
     lines.push(`Async audit: ${result.totalIssues} likely missing-await call site(s) across ${result.filesAffected} file(s)`);
@@ -457,6 +457,7 @@ function formatAuditAsync(result) {
             lines.push(`  :${issue.line}${caller}  ${issue.calleeName}() — async, not awaited`);
         }
     }
+    lines.push(...findingSectionLines(result));
     return lines.join('\n');
 }
 
@@ -474,6 +475,8 @@ function formatAuditAsyncJson(result) {
             callerName: i.callerName,
             calleeName: i.calleeName,
         })),
+        ...(result.diff && { diff: result.diff }),
+        ...(result.suppressions && { suppressions: result.suppressions }),
     }, null, 2);
 }
 
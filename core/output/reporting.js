@@ -4,6 +4,7 @@
 
 const {
     lineRange,
+    findingSectionLines,
     dynamicImportsNote,
     formatFunctionSignature,
     formatClassSignature,
@@ -194,7 +195,8 @@ function formatStatsJson(stats) {
  * @param {string} [options.exportedHint] - Hint about exported symbols exclusion
  */
 function formatDeadcode(results, options = {}) {
-    if (results.length === 0 && !results.excludedDecorated && !results.excludedExported && !results.excludedExternalContract) {
+    if (results.length === 0 && !results.excludedDecorated && !results.excludedExported && !results.excludedExternalContract && !results.excludedGenerated &&
+        !results.complexityFindings?.length && !results.sizeFindings?.length && !results.suppressions && !results.diff) {
         return 'No dead code found.';
     }
 
@@ -204,7 +206,10 @@ function formatDeadcode(results, options = {}) {
     const hidden = results.length - showing.length;
 
     if (results.length > 0) {
-        if (hidden > 0) {
+        if (results.limitInfo?.top) {
+            // --top: the handler kept the findings that remove the most lines
+            lines.push(`Dead code: ${results.limitInfo.total} unused symbol(s) (showing the ${showing.length} largest)\n`);
+        } else if (hidden > 0) {
             lines.push(`Dead code: ${results.length} unused symbol(s) (showing ${showing.length})\n`);
         } else {
             lines.push(`Dead code: ${results.length} unused symbol(s)\n`);
@@ -238,8 +243,102 @@ function formatDeadcode(results, options = {}) {
             : '';
         // The only references are the symbol's own recursion (fix #253c).
         const recStr = item.selfRecursive ? ' [only self-references — recursive]' : '';
-        const displayName = item.className ? `${item.className}.${item.name}` : item.name;
-        lines.push(`  ${lineRange(item.startLine, item.endLine)} ${displayName} (${item.type})${exported}${hintStr}${declStr}${extStr}${recStr}`);
+        // --interface-methods: implementations may still be called directly
+        const viaStr = item.notCalledThrough
+            ? ` [never called through ${item.notCalledThrough}${item.implementedBy?.length ? `; implemented by ${item.implementedBy.join(', ')}` : ''}]`
+            : '';
+        // One member of a partly used iota enum: its value is positional
+        const iotaStr = item.iota && !item.members ? ' [iota enum — removal renumbers the values after it]' : '';
+        // --unused-params: the signature may belong to an interface/base
+        const fixedStr = item.signatureFixedBy
+            ? ` [signature fixed by ${item.signatureFixedBy} — rename to _ instead of removing]`
+            : '';
+        // --type-params: a generic type's parameter its methods skip too
+        const typeParamStr = item.typeParamOf === 'type' ? ' [unused by the type and its methods]' : '';
+        // --unreachable-code: why control never gets there
+        const reasonStr = item.reason ? ` [${item.reason}]` : '';
+        // Variables/fields assigned but never read, or never named at all
+        const accessStr = item.access === 'write-only' ? ` [written ${item.writes}x, never read]`
+            : item.access === 'unused' ? ' [never referenced]' : '';
+        // --types: what kind of type declaration this is
+        const typeStr = (item.aliasOf ? ` [alias of ${item.aliasOf}]` : '') +
+            (item.generics ? ` [generic ${item.name}${item.generics}]` : '');
+        // --unused-results: the whole result, or positions, always dropped
+        const sitesStr = `${item.usageCount} call site${item.usageCount === 1 ? '' : 's'}`;
+        const resultStr = item.discardedResults === 'all'
+            ? ` [returns ${item.returnType}; ignored at all ${sitesStr}]`
+            : Array.isArray(item.discardedResults)
+                ? ` [result ${item.discardedResults.map(r => `#${r.index + 1} ${r.type}`).join(', ')} discarded at all ${sitesStr}]`
+                : '';
+        // --orphan-files: the finding is the whole file
+        const fileStr = item.type === 'file'
+            ? ` [${item.declarations} declaration${item.declarations === 1 ? '' : 's'}, none used outside the file; imported by nothing]`
+            : '';
+        // --build-variants: the constraint no target platform satisfies
+        const buildStr = item.buildConstraint
+            ? ` [build: ${item.buildConstraint} — no target platform builds it${item.builtVariants ? `; ${item.builtVariants} built variant${item.builtVariants === 1 ? '' : 's'}` : ''}]`
+            : '';
+        // --test-only: alive, but only because tests reference it
+        const testStr = item.testOnly
+            ? ` [used only by tests: ${item.testFiles.length ? item.testFiles.join(', ') : 'test files'}]`
+            : '';
+        // --test-helpers: a fixture file, not a symbol
+        const fixtureStr = item.type === 'fixture'
+            ? ` [${item.golden ? 'golden file' : 'fixture'} — no test names it]`
+            : '';
+        // --sentinel-errors: an error value no caller can ever see
+        const sentinelStr = item.sentinel
+            ? ` [sentinel error — never returned or compared${item.usageCount ? `; ${item.usageCount} other reference${item.usageCount === 1 ? '' : 's'}` : ''}]`
+            : '';
+        // --library: exported API nobody outside the package names
+        const libraryStr = item.library
+            ? (item.usageCount ? ' [no importer — used only inside its package: unexport]' : ' [no importer and no use]')
+            : '';
+        // --embeds: embedded bytes nobody looks at
+        const embedStr = item.embedPatterns ? ` [go:embed ${item.embedPatterns.join(' ')} — never read]`
+            : item.type === 'asset' ? ` [embedded by ${item.embeddedBy} — no path names it]`
+            : '';
+        // --channels: one end of the pipe is missing
+        const channelStr = item.channelIssue ? ` [channel ${item.channelIssue}]`
+            : item.discardedResults ? ` [go statement drops results: ${item.discardedResults}]`
+            : '';
+        // --config-knobs: a setting nobody consults
+        const knobLabel = { flag: 'flag', env: 'env var', viper: 'viper key' }[item.knob];
+        const knobStr = !item.knob ? ''
+            : item.knobTarget ? ` [${knobLabel} stored in ${item.knobTarget}, never read]`
+            : ` [${knobLabel} never looked up]`;
+        // --satisfies-only: an interface kept alive by its own assertions
+        const satisfiesStr = item.assertionOnly
+            ? ` [only in var _ assertions${item.assertedBy.length ? ` for ${item.assertedBy.join(', ')}` : ''} — no value has this type]`
+            : item.satisfiesOnly ? ` [exists only to satisfy ${item.satisfiesOnly}]`
+            : item.type === 'assertion' ? ` [redundant${item.assertedType ? ` for ${item.assertedType}` : ''} — ${item.name} has no other use; low severity]`
+            : '';
+        // --init-effects: side effects nobody observes
+        const initStr = item.initStores
+            ? (item.initStores.length ? ` [init only fills ${item.initStores.join(', ')} — never read]` : ' [init has no effect]')
+            : item.blankImport ? ` [blank import — ${item.blankImport}]`
+            : '';
+        // --complexity: how hard the dead code is to read
+        const complexityStr = item.cyclomatic != null ? ` [cyclomatic ${item.cyclomatic}, cognitive ${item.cognitive}]` : '';
+        // --import-issues: what is wrong with the import, edits listed below
+        const importStr = item.importIssue === 'unused' ? ` [unused when built with ${item.builtWith}]`
+            : item.importIssue === 'duplicate' ? ` [duplicate — also imported as ${item.duplicateOf}]`
+            : item.importIssue === 'shadowed'
+                ? ` [shadowed by ${item.shadowedBy.map(s => `${s.kind} ${s.name} at line ${s.line}`).join(', ')}; import as ${item.suggestedAlias}]`
+            : '';
+        // --config-keys: the config file and the code disagree
+        const configStr = item.configKey != null
+            ? (item.configKey ? ` [config section — no code reads it or its ${item.configKey} key(s)]` : ' [config key no code reads]')
+            : item.configField ? ` [config field — no config file sets ${item.configField}]`
+            : '';
+        const owner = item.functionName && (item.className ? `${item.className}.${item.functionName}` : item.functionName);
+        const displayName = item.members ? item.members.join(', ')
+            : owner ? `${item.name} in ${owner}`
+            : item.className ? `${item.className}.${item.name}` : item.name;
+        lines.push(`  ${lineRange(item.startLine, item.endLine)} ${displayName} (${item.type})${exported}${hintStr}${declStr}${extStr}${recStr}${viaStr}${iotaStr}${fixedStr}${reasonStr}${accessStr}${typeStr}${resultStr}${fileStr}${buildStr}${testStr}${fixtureStr}${sentinelStr}${libraryStr}${embedStr}${channelStr}${knobStr}${satisfiesStr}${typeParamStr}${initStr}${importStr}${configStr}${complexityStr}`);
+        for (const edit of item.edits || []) {
+            lines.push(`      ${edit.line}: ${edit.newExpression === '' ? '(delete line)' : edit.newExpression.trim()}`);
+        }
     }
 
     if (hidden > 0) {
@@ -262,6 +361,42 @@ function formatDeadcode(results, options = {}) {
         const extHint = options.externalContractHint || `${results.excludedExternalContract} symbol(s) hidden (override an out-of-tree base class — reachable via external contract, not dead). Use --include-exported to include them.`;
         lines.push(`\n${extHint}`);
     }
+    if (results.excludedGenerated > 0) {
+        const generatedHint = options.generatedHint || `${results.excludedGenerated} symbol(s) in generated files hidden. Use --include-generated to include them.`;
+        lines.push(`\n${generatedHint}`);
+    }
+    // --complexity: live or dead, functions over the configured thresholds
+    const complex = results.complexityFindings || [];
+    if (complex.length > 0) {
+        const t = results.complexityThresholds;
+        lines.push(`\nComplexity over threshold (cyclomatic > ${t.cyclomatic} or cognitive > ${t.cognitive}): ${complex.length} function(s)\n`);
+        let file = null;
+        for (const item of complex) {
+            if (item.file !== file) {
+                file = item.file;
+                lines.push(item.file);
+            }
+            const name = item.className ? `${item.className}.${item.name}` : item.name;
+            lines.push(`  ${lineRange(item.startLine, item.endLine)} ${name} (${item.type}) cyclomatic ${item.cyclomatic}, cognitive ${item.cognitive}`);
+        }
+    }
+    // --size: oversized functions and god types, a rule set of their own
+    const oversized = results.sizeFindings || [];
+    if (oversized.length > 0) {
+        lines.push(`\nOver size limits: ${oversized.length} symbol(s)\n`);
+        let file = null;
+        for (const item of oversized) {
+            if (item.file !== file) {
+                file = item.file;
+                lines.push(item.file);
+            }
+            const name = item.className ? `${item.className}.${item.name}` : item.name;
+            const why = item.over.map(o => `${o.value} ${o.metric} > ${o.limit}`).join(', ');
+            lines.push(`  ${lineRange(item.startLine, item.endLine)} ${name} (${item.type}) ${why}`);
+        }
+    }
+    // ucn diff and ucn:ignore sections
+    lines.push(...findingSectionLines(results));
 
     if (lines.length === 0) {
         return 'No dead code found.';
@@ -283,6 +418,7 @@ function formatDeadcodeJson(results) {
             command: 'deadcode',
             count: results.length,
             ...(li && { total: li.total, truncated: true }),
+            ...(li?.top && { top: li.top, deadLines: li.deadLines }),
         },
         data: {
             count: results.length,
@@ -290,9 +426,17 @@ function formatDeadcodeJson(results) {
             ...(results.excludedExported > 0 && { excludedExported: results.excludedExported }),
             ...(results.excludedDecorated > 0 && { excludedDecorated: results.excludedDecorated }),
             ...(results.excludedExternalContract > 0 && { excludedExternalContract: results.excludedExternalContract }),
+            ...(results.excludedGenerated > 0 && { excludedGenerated: results.excludedGenerated }),
+            ...(results.complexityFindings && {
+                complexity: { thresholds: results.complexityThresholds, findings: results.complexityFindings },
+            }),
+            ...(results.sizeFindings && { size: results.sizeFindings }),
+            ...(results.diff && { diff: results.diff }),
+            ...(results.suppressions && { suppressions: results.suppressions }),
             symbols: results.map(item => {
                 const handleSym = { ...item, relativePath: item.relativePath || item.file };
-                const handle = formatSymbolHandle(handleSym);
+                // A const block spans several symbols, a parameter or a file is none
+                const handle = item.members || item.functionName || item.type === 'file' || item.type === 'fixture' || item.type === 'asset' || item.type === 'assertion' || item.knob || item.initStores || item.blankImport || item.importIssue || item.configKey != null ? null : formatSymbolHandle(handleSym);
                 return {
                     name: item.name,
                     type: item.type,
@@ -306,7 +450,45 @@ function formatDeadcodeJson(results) {
                     ...(item.annotations && item.annotations.length > 0 && { annotations: item.annotations }),
                     ...(item.declaredOn && { declaredOn: item.declaredOn }),
                     ...(item.externalContract && { externalContract: true }),
-                    ...(item.selfRecursive && { selfRecursive: true })
+                    ...(item.selfRecursive && { selfRecursive: true }),
+                    ...(item.notCalledThrough && { notCalledThrough: item.notCalledThrough, implementedBy: item.implementedBy }),
+                    ...(item.members && { members: item.members }),
+                    ...(item.functionName && { functionName: item.functionName }),
+                    ...(item.signatureFixedBy && { signatureFixedBy: item.signatureFixedBy }),
+                    ...(item.reason && { reason: item.reason }),
+                    ...(item.access && { access: item.access }),
+                    ...(item.writes && { writes: item.writes }),
+                    ...(item.aliasOf && { aliasOf: item.aliasOf }),
+                    ...(item.generics && { generics: item.generics }),
+                    ...(item.discardedResults && { returnType: item.returnType, discardedResults: item.discardedResults }),
+                    ...(item.declarations && { declarations: item.declarations }),
+                    ...(item.buildConstraint && { buildConstraint: item.buildConstraint, builtVariants: item.builtVariants }),
+                    ...(item.testOnly && { testOnly: true, testFiles: item.testFiles }),
+                    ...(item.golden && { golden: true }),
+                    ...(item.sentinel && { sentinel: true, usageCount: item.usageCount }),
+                    ...(item.library && { library: true, usageCount: item.usageCount }),
+                    ...(item.embedPatterns && { embedPatterns: item.embedPatterns }),
+                    ...(item.embeddedBy && { embeddedBy: item.embeddedBy }),
+                    ...(item.channelIssue && { channelIssue: item.channelIssue }),
+                    ...(item.discardedResults && { discardedResults: item.discardedResults }),
+                    ...(item.assertionOnly && { assertionOnly: true, assertedBy: item.assertedBy }),
+                    ...(item.satisfiesOnly && { satisfiesOnly: item.satisfiesOnly }),
+                    ...(item.severity && { severity: item.severity }),
+                    ...(item.assertedType && { assertedType: item.assertedType }),
+                    ...(item.knob && { knob: item.knob, ...(item.knobTarget && { knobTarget: item.knobTarget }) }),
+                    ...(item.initStores && { initStores: item.initStores }),
+                    ...(item.blankImport && { blankImport: item.blankImport }),
+                    ...(item.importIssue && {
+                        importIssue: item.importIssue,
+                        ...(item.builtWith && { builtWith: item.builtWith }),
+                        ...(item.duplicateOf && { duplicateOf: item.duplicateOf }),
+                        ...(item.shadowedBy && { shadowedBy: item.shadowedBy, suggestedAlias: item.suggestedAlias }),
+                        edits: item.edits,
+                    }),
+                    ...(item.configKey != null && { configKey: true, nestedKeys: item.configKey }),
+                    ...(item.configField && { configField: item.configField }),
+                    ...(item.cyclomatic != null && { cyclomatic: item.cyclomatic, cognitive: item.cognitive }),
+                    ...(item.iota && { iota: true })
                 };
             }),
         },
@@ -340,6 +522,7 @@ function formatEntrypoints(results, options = {}) {
         runtime: 'Runtime Entry Points',
         ui: 'UI Handlers',
         events: 'Event Handlers',
+        config: 'Configured (.ucn.json entryPoints)',
     };
 
     let itemNum = 0;
@@ -397,6 +580,154 @@ function formatEntrypointsJson(results) {
     }, null, 2);
 }
 
+/**
+ * Format clones command output - text.
+ * One block per group of near-duplicate functions, largest duplication first.
+ */
+function formatClones(result) {
+    if (result?.literals) return formatDuplicateLiterals(result);
+    const groups = result?.groups || [];
+    const total = result?.totalGroups ?? groups.length;
+    const thresholds = `similarity >= ${result.similarity}, >= ${result.minLines} lines, >= ${result.minTokens} tokens`;
+    if (groups.length === 0) {
+        return [`No clones found among ${result.functions} function(s) (${thresholds}).`, ...findingSectionLines(result)].join('\n');
+    }
+    const lines = [];
+    const shown = total > groups.length ? ` (showing ${groups.length})` : '';
+    lines.push(`Clones: ${total} group(s) of near-duplicate functions${shown} — ${thresholds}`);
+    lines.push('═'.repeat(60));
+    groups.forEach((g, i) => {
+        const match = g.exact ? 'identical after normalization' : `${Math.round(g.similarity * 100)}% similar`;
+        const spread = g.packages > 1 ? `, across ${g.packages} packages`
+            : g.files > 1 ? `, across ${g.files} files` : '';
+        lines.push('');
+        lines.push(`${i + 1}. ${g.members.length} functions, ${match}${spread}`);
+        for (const m of g.members) {
+            const name = m.className ? `${m.className}.${m.name}` : m.name;
+            lines.push(`  ${m.file} ${lineRange(m.startLine, m.endLine)} ${name} (${m.lines} lines, ${m.tokens} tokens)`);
+        }
+    });
+    lines.push(...findingSectionLines(result));
+    return lines.join('\n');
+}
+
+/**
+ * Format clones --literals output - text.
+ * One block per repeated literal, most widely spread first.
+ */
+function formatDuplicateLiterals(result) {
+    const literals = result.literals;
+    const total = result.totalLiterals ?? literals.length;
+    if (literals.length === 0) {
+        return [`No literal repeated in ${result.minFiles} or more files among ${result.scanned} file(s).`, ...findingSectionLines(result)].join('\n');
+    }
+    const lines = [];
+    const shown = total > literals.length ? ` (showing ${literals.length})` : '';
+    lines.push(`Duplicated literals: ${total} value(s) repeated in >= ${result.minFiles} files${shown}`);
+    lines.push('═'.repeat(60));
+    literals.forEach((l, i) => {
+        const value = l.kind === 'string' ? JSON.stringify(l.value) : l.value;
+        const constant = l.constant ? `; constant ${l.constant.name} at ${l.constant.file}:${l.constant.line} already holds it` : '';
+        lines.push('');
+        lines.push(`${i + 1}. ${value} (${l.kind}) — ${l.count} occurrence(s) in ${l.files} files${constant}`);
+        const byFile = new Map();
+        for (const loc of l.locations) {
+            if (!byFile.has(loc.file)) byFile.set(loc.file, []);
+            byFile.get(loc.file).push(loc.line);
+        }
+        for (const [file, fileLines] of byFile) lines.push(`  ${file}:${fileLines.join(', ')}`);
+    });
+    lines.push(...findingSectionLines(result));
+    return lines.join('\n');
+}
+
+/**
+ * Format clones command output - JSON.
+ */
+function formatClonesJson(result) {
+    if (result?.literals) {
+        const literals = result.literals;
+        const total = result.totalLiterals ?? literals.length;
+        return JSON.stringify({
+            meta: {
+                command: 'clones',
+                count: literals.length,
+                ...(total > literals.length && { total, truncated: true }),
+            },
+            data: { scanned: result.scanned, minFiles: result.minFiles, literals, ...(result.diff && { diff: result.diff }), ...(result.suppressions && { suppressions: result.suppressions }) },
+        }, null, 2);
+    }
+    const groups = result?.groups || [];
+    const total = result?.totalGroups ?? groups.length;
+    return JSON.stringify({
+        meta: {
+            command: 'clones',
+            count: groups.length,
+            ...(total > groups.length && { total, truncated: true }),
+        },
+        data: {
+            functions: result.functions,
+            minLines: result.minLines,
+            minTokens: result.minTokens,
+            similarity: result.similarity,
+            groups,
+            ...(result.diff && { diff: result.diff }),
+            ...(result.suppressions && { suppressions: result.suppressions }),
+        },
+    }, null, 2);
+}
+
+/**
+ * Format deprecated command output - text.
+ * Still-referenced symbols with their sites first, then the deletable ones.
+ */
+function formatDeprecated(result) {
+    const used = result?.used || [];
+    const unused = result?.unused || [];
+    if (used.length + unused.length === 0) return ['No deprecated symbols found.', ...findingSectionLines(result)].join('\n');
+    const name = d => d.receiver ? `(${d.receiver}).${d.name}` : d.className ? `${d.className}.${d.name}` : d.name;
+    const notice = d => d.notice ? ` — ${d.notice}` : '';
+    const lines = [];
+    if (used.length > 0) {
+        lines.push(`Deprecated and still used: ${used.length} symbol(s)`);
+        lines.push('═'.repeat(60));
+        for (const d of used) {
+            lines.push('');
+            lines.push(`${d.file} ${lineRange(d.startLine, d.endLine)} ${name(d)} (${d.type})${notice(d)}`);
+            lines.push(`  ${d.references.length} reference(s):`);
+            for (const r of d.references) {
+                lines.push(`    ${r.file}:${r.line}${r.caller ? ` in ${r.caller}` : ''}`);
+            }
+        }
+    }
+    if (unused.length > 0) {
+        if (lines.length > 0) lines.push('');
+        lines.push(`Deprecated and unreferenced — safe to delete: ${unused.length} symbol(s)`);
+        lines.push('═'.repeat(60));
+        for (const d of unused) {
+            const exported = d.isExported ? ' [exported — external callers may remain]' : '';
+            lines.push(`${d.file} ${lineRange(d.startLine, d.endLine)} ${name(d)} (${d.type})${exported}${notice(d)}`);
+        }
+    }
+    lines.push(...findingSectionLines(result));
+    return lines.join('\n');
+}
+
+/**
+ * Format deprecated command output - JSON.
+ */
+function formatDeprecatedJson(result) {
+    const used = result?.used || [];
+    const unused = result?.unused || [];
+    return JSON.stringify({
+        meta: {
+            command: 'deprecated',
+            count: used.length + unused.length,
+        },
+        data: { used, unused, ...(result?.diff && { diff: result.diff }), ...(result?.suppressions && { suppressions: result.suppressions }) },
+    }, null, 2);
+}
+
 /**
  * formatOrient — one-screen cold-repo orientation.
  */
@@ -472,7 +803,129 @@ function formatOrientJson(result) {
     }, null, 2);
 }
 
+/**
+ * Format rules command output - text: one line per rule, with its
+ * description below.
+ */
+function formatRules(result) {
+    const rules = result?.rules || [];
+    const width = Math.max(...rules.map(r => r.id.length));
+    const lines = [`Rules: ${rules.length}`, '═'.repeat(60)];
+    for (const r of rules) {
+        const configured = !r.configured ? ''
+            : r.configured.enabled === false ? ' [off in .ucn.json]'
+                : ` [.ucn.json: ${Object.entries(r.configured).map(([k, v]) => `${k} ${v}`).join(', ')}]`;
+        lines.push(`${r.id.padEnd(width)}  ${r.severity.padEnd(7)}  ucn ${r.command}${r.flag ? ` ${r.flag}` : ''}${configured}`);
+        lines.push(`${' '.repeat(width)}  ${r.name}: ${r.description}`);
+    }
+    lines.push('', 'Run "ucn explain <rule>" for one rule, "ucn explain <fingerprint>" for one finding.');
+    return lines.join('\n');
+}
+
+function formatRulesJson(result) {
+    return JSON.stringify({
+        meta: { command: 'rules', count: result?.rules?.length || 0 },
+        data: result,
+    }, null, 2);
+}
+
+/**
+ * Format explain command output - text: a rule's entry, or a finding
+ * with why it was reported (checks and caller trace).
+ */
+function formatExplain(result) {
+    const ruleLines = (r) => [
+        `Rule ${r.id} (${r.name}): ${r.description}`,
+        `  Default: severity ${r.severity} (SARIF level ${r.level}), confidence ${r.confidence}`,
+        ...(r.configured ? [`  .ucn.json: ${r.configured.enabled === false ? 'off' : Object.entries(r.configured).map(([k, v]) => `${k} ${v}`).join(', ')}`] : []),
+        `  Checked by: ucn ${r.command}${r.flag ? ` ${r.flag}` : ''}`,
+        `  To keep a finding: ucn:ignore[${r.id}] on its declaration, or its fingerprint in .ucn.json "ignore"`,
+    ];
+    if (!result.finding) return ruleLines(result.rule).join('\n');
+
+    const f = result.finding;
+    const lines = [
+        `Finding ${result.fingerprint} (ucn ${result.command})`,
+        `  ${f.file}:${f.startLine}${f.name ? ` ${f.name}` : ''}`,
+        `  ${f.message}`,
+        ...(f.related || []).map(loc => `  also ${loc.file}:${loc.startLine}${loc.name ? ` ${loc.name}` : ''}`),
+        '',
+        ...ruleLines(result.rule),
+    ];
+    const why = result.why;
+    if (!why) return lines.join('\n');
+
+    lines.push('', 'Checks that would have kept it:');
+    for (const c of why.checks) {
+        lines.push(`  ${c.passed ? 'yes' : 'no '}  ${c.check}${c.note ? ` (${c.note})` : ''}`);
+    }
+    const { reachability: r } = why;
+    lines.push('', `Reachability: ${r.reachable ? 'reachable' : 'not reachable'} from the ${r.entryPoints} detected entry point(s)`);
+    if (r.trace) {
+        lines.push(`Caller trace${r.depth != null ? ` (depth ${r.depth})` : ''}:`);
+        const walk = (node, depth) => {
+            const notes = [node.reachable ? 'reachable' : 'unreachable'];
+            if (node.noCallers) notes.push('no callers');
+            if (node.unverifiedCallers) notes.push(`${node.unverifiedCallers} unverified caller(s)`);
+            if (node.alreadyShown) notes.push('shown above');
+            lines.push(`  ${'  '.repeat(depth)}${depth > 0 ? '← ' : ''}${node.name} (${node.file}:${node.line}) — ${notes.join(', ')}`);
+            for (const c of node.callers) walk(c, depth + 1);
+        };
+        walk(r.trace, 0);
+        if (r.truncated) lines.push(`  (stopped at depth ${r.depth}; --depth=N goes further)`);
+    }
+    return lines.join('\n');
+}
+
+function formatExplainJson(result) {
+    return JSON.stringify({
+        meta: { command: 'explain', ...(result.fingerprint ? { fingerprint: result.fingerprint } : { rule: result.rule?.id }) },
+        data: result,
+    }, null, 2);
+}
+
+/**
+ * Format why command output - text: the chain from a reachability root
+ * to the symbol, or its direct callers when there is none.
+ */
+function formatWhy(result) {
+    const s = result.symbol;
+    const lines = [`${s.name} (${s.file}:${s.line})`];
+    for (const w of result.warnings || []) lines.push(`  Note: ${w.message || w}`);
+    if (result.reachable) {
+        lines.push(`Reachable from ${result.chain[0].name}, a ${result.via}:`);
+        result.chain.forEach((step, i) => {
+            lines.push(`  ${i > 0 ? '→ ' : ''}${step.name} (${step.file}:${step.line})`);
+        });
+        return lines.join('\n');
+    }
+    lines.push('Not reachable: no chain of confirmed calls leads here from an entry point.');
+    if (result.callers.length > 0) {
+        lines.push('Direct callers (none of them reachable):');
+        for (const c of result.callers) lines.push(`  ← ${c.name} (${c.file}:${c.line})`);
+    } else {
+        lines.push('No confirmed callers.');
+    }
+    if (result.unverifiedCallers) {
+        lines.push(`${result.unverifiedCallers} unverified call(s) by name were not followed (ucn reverse-trace --include-uncertain lists them).`);
+    }
+    return lines.join('\n');
+}
+
+function formatWhyJson(result) {
+    return JSON.stringify({
+        meta: { command: 'why', reachable: result.reachable },
+        data: result,
+    }, null, 2);
+}
+
 module.exports = {
+    formatRules,
+    formatRulesJson,
+    formatExplain,
+    formatExplainJson,
+    formatWhy,
+    formatWhyJson,
     formatToc,
     formatTocJson,
     formatOrient,
@@ -483,4 +936,8 @@ module.exports = {
     formatDeadcodeJson,
     formatEntrypoints,
     formatEntrypointsJson,
+    formatClones,
+    formatClonesJson,
+    formatDeprecated,
+    formatDeprecatedJson,
 };
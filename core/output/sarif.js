@@ -0,0 +1,505 @@
+/**
+ * core/output/sarif.js — SARIF 2.1.0 log and JSON Lines output for finding
+ * commands (--format sarif, --format jsonl)
+ *
+ * deadcode (every mode, plus its --complexity and --size findings),
+ * clones, audit-async and deprecated map onto one run of the `ucn` tool,
+ * so the log uploads to GitHub Code Scanning and other SARIF dashboards.
+ * Each finding kind is a rule with a default level: `warning` for code
+ * that can go or is wrong, `note` for advisory findings. Every finding
+ * also carries a severity (error, warning, info; the level's by default)
+ * and a confidence (high, medium, low), both of which .ucn.json "rules"
+ * can set per rule; the level SARIF shows follows the severity.
+ * Locations are relative to the project root (uriBaseId SRCROOT);
+ * import-issue edits ride along as SARIF fixes.
+ *
+ * JSON Lines carries the same findings flat, one object per line, each
+ * with the raw result entry as `data`, for jq or a log shipper. The lines
+ * come from the finished result, so the first one waits for the whole
+ * analysis.
+ *
+ * Every finding has a fingerprint: a hash of its rule, the symbol path
+ * (Class.method, and a parameter's function) and its location with the
+ * line left out (the file, in forward-slash form); findings that still
+ * share a key are numbered in file order. Lines shift with every edit above a finding, so
+ * baselines, .ucn.json "ignore" entries and ucn diff match findings by
+ * fingerprint and keep matching as code moves.
+ */
+
+'use strict';
+
+const crypto = require('crypto');
+const { formatDeadcode } = require('./reporting');
+
+const SARIF_SCHEMA = 'https://json.schemastore.org/sarif-2.1.0.json';
+const SARIF_COMMANDS = new Set(['deadcode', 'clones', 'auditAsync', 'deprecated']);
+
+// id → [name, description, level, confidence]; a rule without its own
+// confidence takes the level's (see defaultConfidence)
+const RULES = {
+    'dead-code': ['DeadCode', 'Symbol with no references', 'warning'],
+    'interface-method': ['UnusedInterfaceMethod', 'Interface method no caller invokes through the interface', 'warning'],
+    'unused-param': ['UnusedParameter', 'Function parameter never read', 'warning'],
+    'unused-type-param': ['UnusedTypeParameter', 'Type parameter the signature, body and methods never mention', 'warning'],
+    'unreachable-code': ['UnreachableCode', 'Statements no execution reaches', 'warning'],
+    'package-var': ['UnusedPackageVariable', 'Package-level variable never read', 'warning'],
+    'sentinel-error': ['UnusedSentinelError', 'Sentinel error never returned or compared', 'warning'],
+    'unused-results': ['DiscardedResults', 'Return values every caller discards', 'note'],
+    'orphan-file': ['OrphanFile', 'File nothing imports and whose declarations nothing uses', 'warning'],
+    'build-variant': ['UnbuiltVariant', 'Code no target platform builds', 'note'],
+    'test-only': ['TestOnlyUsage', 'Symbol referenced only from tests', 'note'],
+    'unused-fixture': ['UnusedFixture', 'Test fixture or golden file no test names', 'note'],
+    'library-api': ['UnusedLibraryApi', 'Exported API no importer uses', 'note'],
+    'unused-embed': ['UnusedEmbed', 'Embedded file or variable never read', 'warning'],
+    'channel': ['ChannelIssue', 'Channel missing a sender or receiver, or goroutine results dropped', 'warning'],
+    'config-knob': ['UnusedConfigKnob', 'Flag, env variable or viper key never read', 'warning'],
+    'satisfies-only': ['SatisfiesOnly', 'Interface used only in var _ assertions', 'note'],
+    'redundant-assertion': ['RedundantAssertion', 'var _ I = (*T)(nil) assertion whose interface has no other use', 'note'],
+    'dead-init': ['DeadInit', 'init() whose effects nothing observes', 'warning'],
+    'blank-import': ['DeadBlankImport', 'Blank import whose registrations nothing looks up', 'warning'],
+    'import-unused': ['UnusedImport', 'Import unused in a file only some build tags compile', 'warning'],
+    'import-duplicate': ['DuplicateImport', 'Path imported twice under different names', 'warning'],
+    'import-shadowed': ['ShadowedImport', 'Import hidden by a local of the same name', 'warning'],
+    'config-key': ['UnreadConfigKey', 'Config file key no code reads', 'note', 'medium'],
+    'config-field': ['UnpopulatedConfigField', 'Config struct field no config file sets', 'note', 'medium'],
+    'complexity': ['HighComplexity', 'Function over the cyclomatic or cognitive complexity threshold', 'note'],
+    'size': ['OversizedSymbol', 'Function or type over its size limits', 'note'],
+    'clone': ['DuplicateFunction', 'Near-duplicate function', 'note'],
+    'duplicate-literal': ['DuplicateLiteral', 'Literal repeated across files that likely wants a shared constant', 'note', 'medium'],
+    'missing-await': ['MissingAwait', 'Async call likely missing an await', 'warning', 'medium'],
+    'deprecated-use': ['DeprecatedUse', 'Reference to a deprecated symbol', 'warning'],
+    'deprecated-unused': ['UnusedDeprecated', 'Deprecated symbol nothing references', 'note'],
+};
+
+// Default level → severity, and severity → the level SARIF shows
+const SEVERITY_OF_LEVEL = { warning: 'warning', note: 'info' };
+const LEVEL_OF_SEVERITY = { error: 'error', warning: 'warning', info: 'note' };
+const CONFIDENCES = new Set(['high', 'medium', 'low']);
+
+/**
+ * How sure a finding is, before configuration: the rule's own confidence
+ * for heuristic rules (medium), else high for warnings and medium for
+ * advisory notes; low whenever something outside the index may still use
+ * the symbol (exported, decorated or annotated, so reachable through
+ * reflection or a framework, or a contract with external code).
+ */
+function defaultConfidence(rule, data) {
+    const d = data || {};
+    if (d.isExported || d.externalContract || d.decorators?.length || d.annotations?.length) return 'low';
+    const [, , level, confidence] = RULES[rule];
+    return confidence || (level === 'warning' ? 'high' : 'medium');
+}
+
+/**
+ * A rule's .ucn.json "rules" entry, keyed by rule id or name: an object
+ * { severity, confidence }, or the severity alone. Values outside the
+ * known sets are ignored.
+ */
+function ruleSetting(rules, rule) {
+    const entry = rules?.[rule] ?? rules?.[RULES[rule][0]];
+    const { severity, confidence } = typeof entry === 'string' ? { severity: entry } : (entry || {});
+    return {
+        severity: LEVEL_OF_SEVERITY[severity] ? severity : undefined,
+        confidence: CONFIDENCES.has(confidence) ? confidence : undefined,
+    };
+}
+
+/** Whether a "rules" entry turns its rule off: "off", false (YAML's off), or { enabled: false } */
+function ruleDisabled(rules, rule) {
+    const entry = rules?.[rule] ?? rules?.[RULES[rule][0]];
+    return entry === 'off' || entry === false || entry?.enabled === false;
+}
+
+/** Rule of one deadcode item, from the fields its mode sets */
+function deadcodeRule(item) {
+    if (item.importIssue) return `import-${item.importIssue}`;
+    if (item.configKey != null) return 'config-key';
+    if (item.configField) return 'config-field';
+    if (item.initStores) return 'dead-init';
+    if (item.blankImport) return 'blank-import';
+    if (item.type === 'type parameter') return 'unused-type-param';
+    if (item.type === 'parameter') return 'unused-param';
+    if (item.type === 'unreachable') return 'unreachable-code';
+    if (item.type === 'assertion') return 'redundant-assertion';
+    if (item.assertionOnly || item.satisfiesOnly) return 'satisfies-only';
+    if (item.channelIssue || item.type === 'goroutine') return 'channel';
+    if (item.knob) return 'config-knob';
+    if (item.sentinel) return 'sentinel-error';
+    if (item.discardedResults) return 'unused-results';
+    if (item.buildConstraint) return 'build-variant';
+    if (item.type === 'file') return 'orphan-file';
+    if (item.type === 'fixture') return 'unused-fixture';
+    if (item.embedPatterns || item.type === 'asset') return 'unused-embed';
+    if (item.library) return 'library-api';
+    if (item.testOnly) return 'test-only';
+    if (item.notCalledThrough) return 'interface-method';
+    if (item.access) return 'package-var';
+    return 'dead-code';
+}
+
+/** The text formatter's line for one item, without its line range */
+function deadcodeMessage(item) {
+    const text = formatDeadcode(Object.assign([item], { excludedDecorated: 0, excludedExported: 0, excludedExternalContract: 0 }));
+    const line = text.split('\n').find(l => /^\s+\[\s*\d+-\s*\d+\]/.test(l));
+    return line ? line.replace(/^\s+\[[^\]]*\]\s*/, '') : item.name;
+}
+
+const at = (file, startLine, endLine, name) => ({
+    file: file.replace(/\\/g, '/'),
+    startLine,
+    ...(endLine && endLine !== startLine && { endLine }),
+    ...(name && { name }),
+});
+
+/** SARIF location of a finding's `at` */
+function location(loc) {
+    return {
+        physicalLocation: {
+            artifactLocation: { uri: loc.file, uriBaseId: 'SRCROOT' },
+            region: { startLine: loc.startLine, ...(loc.endLine && { endLine: loc.endLine }) },
+        },
+        ...(loc.name && { logicalLocations: [{ fullyQualifiedName: loc.name }] }),
+    };
+}
+
+/** SARIF fix from plan-shaped line edits; an empty newExpression deletes the line */
+function fixOf(edits) {
+    const byFile = new Map();
+    for (const e of edits) {
+        if (!byFile.has(e.file)) byFile.set(e.file, []);
+        byFile.get(e.file).push(e.newExpression === ''
+            ? { deletedRegion: { startLine: e.line, startColumn: 1, endLine: e.line + 1, endColumn: 1 } }
+            : {
+                deletedRegion: { startLine: e.line, startColumn: 1, endLine: e.line, endColumn: e.expression.length + 1 },
+                insertedContent: { text: e.newExpression },
+            });
+    }
+    return {
+        description: { text: edits[0].suggestion },
+        artifactChanges: [...byFile].map(([file, replacements]) => ({
+            artifactLocation: { uri: file, uriBaseId: 'SRCROOT' },
+            replacements,
+        })),
+    };
+}
+
+/**
+ * Stable fingerprint of a finding: 16 hex digits of a SHA-256 over its
+ * rule and key, the key's paths in forward-slash form and a clone group's
+ * members in sorted order, so neither the OS nor the order clones were
+ * found in changes it.
+ * @param {string} rule - Rule id
+ * @param {string} key - Line-free finding key (rawFindings)
+ * @returns {string}
+ */
+function findingFingerprint(rule, key) {
+    const parts = String(key).replace(/\\/g, '/').split('|');
+    const normalized = rule === 'clone' ? parts.sort().join('|') : parts.join('|');
+    return crypto.createHash('sha256').update(`${rule}\n${normalized}`).digest('hex').slice(0, 16);
+}
+
+/**
+ * Findings of one command, one at a time: {rule, message, at, related?,
+ * edits?, key, fingerprint, data, severity, confidence, level}, where `at`
+ * and each related entry are {file, startLine, endLine?, name?}, `data` is
+ * the raw result entry and `level` the SARIF level of the severity.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object|Function} [rules] - .ucn.json "rules", per-rule severity and
+ *   confidence; or (file) => the rules of that file's directory
+ */
+function* findingsOf(command, result, rules) {
+    for (const f of rawFindings(command, result)) {
+        const set = ruleSetting(typeof rules === 'function' ? rules(f.at.file) : rules, f.rule);
+        const severity = set.severity || SEVERITY_OF_LEVEL[RULES[f.rule][2]];
+        f.severity = severity;
+        f.confidence = set.confidence || defaultConfidence(f.rule, f.data);
+        f.level = LEVEL_OF_SEVERITY[severity];
+        f.fingerprint = findingFingerprint(f.rule, f.key);
+        yield f;
+    }
+}
+
+function* rawFindings(command, result) {
+    if (command === 'check') {
+        // check --stdin: the findings of each command run on the buffer
+        for (const part of result.parts || []) yield* rawFindings(part.command, part.result);
+    } else if (command === 'deadcode') {
+        // Findings sharing a key (unreachable blocks of one function) are
+        // told apart by their order in the file
+        const seen = new Map();
+        for (const item of result) {
+            const name = item.className ? `${item.className}.${item.name}` : item.name;
+            const rule = deadcodeRule(item);
+            // A parameter or goroutine belongs to its function
+            const owner = item.functionName ? `${item.className ? `${item.className}.` : ''}${item.functionName}/` : '';
+            const base = `${item.file}:${owner}${name}:${item.type}`;
+            const nth = seen.get(base) || 0;
+            seen.set(base, nth + 1);
+            yield {
+                rule,
+                message: `${RULES[rule][1]}: ${deadcodeMessage(item)}`,
+                at: at(item.file, item.startLine, item.endLine, name),
+                ...(item.edits?.length && { edits: item.edits }),
+                data: item,
+                key: nth === 0 ? base : `${base}#${nth}`,
+            };
+        }
+        const t = result.complexityThresholds;
+        for (const f of result.complexityFindings || []) {
+            const name = f.className ? `${f.className}.${f.name}` : f.name;
+            yield {
+                rule: 'complexity',
+                message: `${name} has cyclomatic complexity ${f.cyclomatic} (limit ${t.cyclomatic}) and cognitive complexity ${f.cognitive} (limit ${t.cognitive})`,
+                at: at(f.file, f.startLine, f.endLine, name),
+                data: f,
+                key: `${f.file}:${name}`,
+            };
+        }
+        for (const f of result.sizeFindings || []) {
+            const name = f.className ? `${f.className}.${f.name}` : f.name;
+            yield {
+                rule: 'size',
+                message: `${name} (${f.type}) is over its size limits: ${f.over.map(o => `${o.value} ${o.metric} > ${o.limit}`).join(', ')}`,
+                at: at(f.file, f.startLine, f.endLine, name),
+                data: f,
+                key: `${f.file}:${name}`,
+            };
+        }
+    } else if (command === 'clones' && result.literals) {
+        for (const l of result.literals) {
+            const value = l.kind === 'string' ? JSON.stringify(l.value) : l.value;
+            const [first, ...rest] = l.locations;
+            yield {
+                rule: 'duplicate-literal',
+                message: `${value} is repeated ${l.count} time(s) in ${l.files} files${l.constant ? `; constant ${l.constant.name} already holds it` : ''}`,
+                at: at(first.file, first.line),
+                related: rest.map(loc => at(loc.file, loc.line)),
+                data: l,
+                key: `${l.kind}:${l.value}`,
+            };
+        }
+    } else if (command === 'clones') {
+        for (const g of result.groups) {
+            const [first, ...rest] = g.members;
+            const match = g.exact ? 'identical after normalization' : `${Math.round(g.similarity * 100)}% similar`;
+            yield {
+                rule: 'clone',
+                message: `${first.name} has ${rest.length} near-duplicate(s), ${match}: ${rest.map(m => `${m.name} (${m.file}:${m.startLine})`).join(', ')}`,
+                at: at(first.file, first.startLine, first.endLine, first.name),
+                related: rest.map(m => at(m.file, m.startLine, m.endLine, m.name)),
+                data: g,
+                key: g.members.map(m => `${m.file}:${m.name}`).join('|'),
+            };
+        }
+    } else if (command === 'auditAsync') {
+        for (const issue of result.issues || []) {
+            yield {
+                rule: 'missing-await',
+                message: `${issue.calleeName}() is async but not awaited${issue.callerName ? ` in ${issue.callerName}` : ''}`,
+                at: at(issue.file, issue.line, null, issue.callerName),
+                data: issue,
+                key: `${issue.file}:${issue.callerName || ''}:${issue.calleeName}`,
+            };
+        }
+    } else if (command === 'deprecated') {
+        for (const d of result.used || []) {
+            for (const r of d.references) {
+                yield {
+                    rule: 'deprecated-use',
+                    message: `${d.name} is deprecated${d.notice ? `: ${d.notice}` : ''}`,
+                    at: at(r.file, r.line, null, r.caller),
+                    related: [at(d.file, d.startLine, d.endLine, d.name)],
+                    data: { ...r, deprecated: d.name, notice: d.notice },
+                    key: `${r.file}:${r.caller || ''}:${d.name}`,
+                };
+            }
+        }
+        for (const d of result.unused || []) {
+            yield {
+                rule: 'deprecated-unused',
+                message: `${d.name} is deprecated and nothing references it${d.notice ? `: ${d.notice}` : ''}`,
+                at: at(d.file, d.startLine, d.endLine, d.name),
+                data: d,
+                key: `${d.file}:${d.name}`,
+            };
+        }
+    }
+}
+
+/**
+ * The command's result without some of its findings, in the same shape
+ * and with its other fields, so every output format (text and JSON
+ * included) shows only the rest.
+ * @param {string} command - Canonical command
+ * @param {*} result - The command's result, as execute returns it
+ * @param {Set} drop - The `data` of each finding to drop
+ * @returns {*} The filtered result
+ */
+function withoutFindings(command, result, drop) {
+    if (drop.size === 0) return result;
+    const keep = (x) => !drop.has(x);
+    if (command === 'check') {
+        return { ...result, parts: result.parts.map(p => ({ ...p, result: withoutFindings(p.command, p.result, drop) })) };
+    }
+    if (command === 'deadcode') {
+        // Keep the array's extra properties (complexity and size findings,
+        // exclusion counts ride on the result array)
+        const kept = Object.assign(result.filter(keep), Object.fromEntries(
+            Object.entries(result).filter(([k]) => !/^\d+$/.test(k))));
+        if (result.complexityFindings) kept.complexityFindings = result.complexityFindings.filter(keep);
+        if (result.sizeFindings) kept.sizeFindings = result.sizeFindings.filter(keep);
+        return kept;
+    }
+    if (command === 'clones') {
+        return { ...result, ...(result.literals ? { literals: result.literals.filter(keep) } : { groups: result.groups.filter(keep) }) };
+    }
+    if (command === 'auditAsync') {
+        const issues = (result.issues || []).filter(keep);
+        return { ...result, issues, totalIssues: issues.length, filesAffected: new Set(issues.map(i => i.file)).size };
+    }
+    // deprecated-use findings carry a copy of the reference, so match it by place
+    const uses = [...drop].filter(d => d.deprecated !== undefined);
+    return {
+        ...result,
+        used: (result.used || []).map(d => ({
+            ...d,
+            references: d.references.filter(r => !uses.some(u =>
+                u.deprecated === d.name && u.file === r.file && u.line === r.line && u.caller === r.caller)),
+        })).filter(d => d.references.length > 0),
+        unused: (result.unused || []).filter(keep),
+    };
+}
+
+/**
+ * The command's result with its findings reordered, in the same shape
+ * and with its other fields. Each list of findings in the result is
+ * ordered by itself; a deprecated symbol goes where its first use does.
+ * @param {string} command - Canonical command
+ * @param {*} result - The command's result, as execute returns it
+ * @param {Map} rank - The `data` of each finding → its position
+ * @returns {*} The reordered result
+ */
+function orderFindings(command, result, rank) {
+    const pos = (x) => rank.get(x) ?? Infinity;
+    const byRank = (a, b) => pos(a) - pos(b);
+    if (command === 'check') {
+        return { ...result, parts: result.parts.map(p => ({ ...p, result: orderFindings(p.command, p.result, rank) })) };
+    }
+    if (command === 'deadcode') {
+        const ordered = Object.assign(result.slice().sort(byRank), Object.fromEntries(
+            Object.entries(result).filter(([k]) => !/^\d+$/.test(k))));
+        if (result.complexityFindings) ordered.complexityFindings = result.complexityFindings.slice().sort(byRank);
+        if (result.sizeFindings) ordered.sizeFindings = result.sizeFindings.slice().sort(byRank);
+        return ordered;
+    }
+    if (command === 'clones') {
+        return result.literals
+            ? { ...result, literals: result.literals.slice().sort(byRank) }
+            : { ...result, groups: result.groups.slice().sort(byRank) };
+    }
+    if (command === 'auditAsync') {
+        return { ...result, issues: (result.issues || []).slice().sort(byRank) };
+    }
+    // deprecated-use findings carry a copy of the reference, so rank it by place
+    const usePos = new Map();
+    for (const [d, i] of rank) {
+        if (d.deprecated !== undefined) usePos.set(`${d.deprecated}\0${d.file}\0${d.line}\0${d.caller}`, i);
+    }
+    const refPos = (d, r) => usePos.get(`${d.name}\0${r.file}\0${r.line}\0${r.caller}`) ?? Infinity;
+    const used = (result.used || []).map(d => ({ ...d, references: d.references.slice().sort((a, b) => refPos(d, a) - refPos(d, b)) }));
+    const first = (d) => d.references.length ? refPos(d, d.references[0]) : Infinity;
+    return {
+        ...result,
+        used: used.sort((a, b) => first(a) - first(b)),
+        unused: (result.unused || []).slice().sort(byRank),
+    };
+}
+
+// Findings that flag code to restructure rather than delete
+const ADVISORY_SPAN_RULES = new Set(['clone', 'duplicate-literal', 'complexity', 'size']);
+
+/** Lines deleting a finding's code would remove; 0 for findings that are not deletions */
+function deadLines(f) {
+    if (ADVISORY_SPAN_RULES.has(f.rule)) return 0;
+    return (f.at.endLine || f.at.startLine) - f.at.startLine + 1;
+}
+
+/**
+ * SARIF 2.1.0 log of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} JSON text
+ */
+function formatSarif(command, result, options = {}) {
+    const pkg = require('../../package.json');
+    const findings = [...findingsOf(command, result, options.rules)];
+    const ruleIds = [...new Set(findings.map(f => f.rule))];
+    const rules = ruleIds.map(id => {
+        const [name, description, level] = RULES[id];
+        return {
+            id,
+            name,
+            shortDescription: { text: description },
+            // The rule's own level; .ucn.json severities show on each result
+            defaultConfiguration: { level },
+            properties: { tags: ['maintainability'] },
+        };
+    });
+    const results = findings.map(f => ({
+        ruleId: f.rule,
+        ruleIndex: ruleIds.indexOf(f.rule),
+        level: f.level,
+        message: { text: f.message },
+        locations: [location(f.at)],
+        ...(f.related?.length && { relatedLocations: f.related.map((loc, id) => ({ id, ...location(loc) })) }),
+        ...(f.edits && { fixes: [fixOf(f.edits)] }),
+        // Line-free, so a finding keeps its identity as code moves around it
+        partialFingerprints: { 'ucnFinding/v1': `${f.rule}:${f.key}`, 'ucnFinding/v2': f.fingerprint },
+        properties: { severity: f.severity, confidence: f.confidence },
+    }));
+    return JSON.stringify({
+        $schema: SARIF_SCHEMA,
+        version: '2.1.0',
+        runs: [{
+            tool: {
+                driver: {
+                    name: 'ucn',
+                    version: pkg.version,
+                    informationUri: pkg.homepage,
+                    rules,
+                },
+            },
+            originalUriBaseIds: { SRCROOT: { description: { text: 'Project root' } } },
+            results,
+        }],
+    }, null, 2);
+}
+
+/**
+ * JSON Lines of a finding command's result, one finding per line.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @yields {string} One JSON object, without its newline
+ */
+function* jsonLines(command, result, options = {}) {
+    for (const f of findingsOf(command, result, options.rules)) {
+        yield JSON.stringify({
+            command,
+            ruleId: f.rule,
+            level: f.level,
+            severity: f.severity,
+            confidence: f.confidence,
+            message: f.message,
+            ...f.at,
+            ...(f.related?.length && { related: f.related }),
+            ...(f.edits && { edits: f.edits }),
+            fingerprint: f.fingerprint,
+            data: f.data,
+        });
+    }
+}
+
+module.exports = { formatSarif, jsonLines, findingsOf, findingFingerprint, withoutFindings, orderFindings, ruleDisabled, ruleSetting, defaultConfidence, SEVERITY_OF_LEVEL, deadLines, deadcodeRule, RULES, SARIF_COMMANDS };
@@ -362,8 +362,53 @@ function advisoryLine(advisory) {
     return `Advisory: ${desc} — suggestions, not verified claims.`;
 }
 
+/**
+ * Text section of a finding run's ucn:ignore comments and .ucn.json
+ * "ignore" entries: the active ones with what they hid, then the stale
+ * ones. Empty when there are none.
+ * @param {{active: object[], stale: object[]}|undefined} suppressions
+ * @returns {string[]} Lines, starting with a blank one
+ */
+function suppressionLines(suppressions) {
+    if (!suppressions) return [];
+    const { active, stale } = suppressions;
+    const where = s => (s.fingerprint ? `.ucn.json ignore ${s.fingerprint}` : `${s.file}:${s.line} ucn:ignore`);
+    const directive = s => `${where(s)}${s.rules.length + (s.unknownRules?.length || 0) > 0 ? `[${[...s.rules, ...(s.unknownRules || [])].join(', ')}]` : ''}`;
+    const reason = s => s.reason ? ` — ${s.reason}` : '';
+    const lines = ['', `Suppressions: ${active.length} active, ${stale.length} stale`];
+    const unknown = s => s.unknownRules ? `unknown rule ${s.unknownRules.join(', ')}` : null;
+    for (const s of active) {
+        lines.push(`  ${directive(s)} hid ${s.hidden} finding(s)${unknown(s) ? ` [${unknown(s)}]` : ''}${reason(s)}`);
+    }
+    for (const s of stale) {
+        lines.push(`  ${directive(s)} [stale: ${unknown(s) || 'hid nothing — remove it'}]${reason(s)}`);
+    }
+    return lines;
+}
+
+/**
+ * Text section of a finding run compared with a git ref (ucn diff): the
+ * counts, then the findings the change resolved. Empty outside ucn diff.
+ * @param {{base, introduced, resolved: object[]}|undefined} diff
+ * @returns {string[]} Lines, starting with a blank one
+ */
+function baseDiffLines(diff) {
+    if (!diff) return [];
+    const lines = ['', `Compared with ${diff.base}: ${diff.introduced} introduced, ${diff.resolved.length} resolved`];
+    for (const r of diff.resolved) lines.push(`  resolved ${r.file}:${r.line} ${r.message}`);
+    return lines;
+}
+
+/** The sections every finding command's text ends with: base diff, then suppressions */
+function findingSectionLines(result) {
+    return [...baseDiffLines(result?.diff), ...suppressionLines(result?.suppressions)];
+}
+
 module.exports = {
     advisoryLine,
+    suppressionLines,
+    baseDiffLines,
+    findingSectionLines,
     dynamicImportsNote,
     formatFileError,
     unverifiedReasonLabel,
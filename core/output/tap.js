@@ -0,0 +1,52 @@
+/**
+ * core/output/tap.js — TAP version 13 stream of findings (--format tap)
+ *
+ * One test point per finding, `not ok` with a YAML diagnostic block
+ * (rule, severity, confidence, file, line, symbol), so prove, tap-parser
+ * and CI plugins that already aggregate TAP count them as failures.
+ * Info-severity findings (clones, complexity, duplicate literals... by
+ * default) carry a TODO directive: harnesses show them but don't fail
+ * the run on them. A run with no findings is one passing test point.
+ */
+
+'use strict';
+
+const { findingsOf, RULES } = require('./sarif');
+
+/** YAML scalar: plain when safe, otherwise double-quoted JSON */
+function yamlScalar(value) {
+    if (typeof value === 'number') return String(value);
+    const s = String(value);
+    return /^[A-Za-z0-9_./@-][A-Za-z0-9_./@ ()-]*$/.test(s) && !/\s$/.test(s) ? s : JSON.stringify(s);
+}
+
+/**
+ * TAP stream of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} TAP text, newline-terminated
+ */
+function formatTap(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)];
+    const out = ['TAP version 13', `1..${Math.max(findings.length, 1)}`];
+    if (findings.length === 0) out.push(`ok 1 - ucn ${command}: no findings`);
+    findings.forEach((f, i) => {
+        const [name] = RULES[f.rule];
+        // A description may not hold '#' unescaped, or it reads as a directive
+        const description = `${f.at.file}:${f.at.startLine} ${f.message}`.replace(/\r?\n/g, ' ').replace(/#/g, '\\#');
+        out.push(`not ok ${i + 1} - ${description}${f.severity === 'info' ? ' # TODO advisory' : ''}`);
+        out.push('  ---');
+        out.push(`  rule: ${yamlScalar(name)}`);
+        out.push(`  severity: ${f.severity}`);
+        out.push(`  confidence: ${f.confidence}`);
+        out.push(`  file: ${yamlScalar(f.at.file)}`);
+        out.push(`  line: ${f.at.startLine}`);
+        if (f.at.endLine && f.at.endLine !== f.at.startLine) out.push(`  endLine: ${f.at.endLine}`);
+        if (f.at.name) out.push(`  symbol: ${yamlScalar(f.at.name)}`);
+        out.push('  ...');
+    });
+    return out.join('\n') + '\n';
+}
+
+module.exports = { formatTap };
@@ -0,0 +1,388 @@
+/**
+ * core/output/template.js — User templates over the finding model
+ * (--format template --template <file>)
+ *
+ * A small implementation of Go's text/template, enough for the bespoke
+ * reports people otherwise script around --json (Slack payloads, custom
+ * CSVs, release notes): {{.Field}} and {{$var.Field}} lookups, pipelines
+ * with |, parenthesized calls, {{if}}/{{else if}}/{{else}}, {{range}}
+ * (with `$i, $f :=` and {{else}}), {{with}}, `$x :=` variables, comment
+ * actions, {{- -}} whitespace trimming and the standard functions
+ * (and, or, not, len, index, print, printf, println, eq, ne, lt, le,
+ * gt, ge, html, js, urlquery), plus json and join. Not covered: define,
+ * template, block, break and continue.
+ *
+ * The data is templateData(): Command, Root, Version, Total, Rules and
+ * Findings, with Go-style capitalized field names.
+ */
+
+'use strict';
+
+const path = require('path');
+const { findingsOf, deadLines, RULES } = require('./sarif');
+
+/**
+ * The value a template runs against.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { root } of the project; { rules } from .ucn.json
+ * @returns {object} { Command, Root, Version, Total, Rules, Findings }
+ */
+function templateData(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)].map(f => ({
+        Rule: f.rule,
+        RuleName: RULES[f.rule][0],
+        Level: f.level,
+        Severity: f.severity,
+        Confidence: f.confidence,
+        File: f.at.file,
+        Package: path.posix.dirname(f.at.file),
+        Line: f.at.startLine,
+        EndLine: f.at.endLine || f.at.startLine,
+        Symbol: f.at.name || '',
+        Message: f.message,
+        Key: `${f.rule}:${f.key}`,
+        Fingerprint: f.fingerprint,
+        DeadLines: deadLines(f),
+        Data: f.data,
+    }));
+    const counts = new Map();
+    const severities = new Map();
+    for (const f of findings) {
+        counts.set(f.Rule, (counts.get(f.Rule) || 0) + 1);
+        severities.set(f.Rule, f.Severity);
+    }
+    return {
+        Command: command,
+        Root: options.root || '',
+        Version: require('../../package.json').version,
+        Total: findings.length,
+        Rules: [...counts].map(([id, count]) => ({
+            ID: id, Name: RULES[id][0], Description: RULES[id][1], Severity: severities.get(id), Count: count,
+        })),
+        Findings: findings,
+    };
+}
+
+// ── Lexing ────────────────────────────────────────────────────────────
+
+const ACTION = /\{\{(-\s)?([\s\S]*?)(\s-)?\}\}/g;
+
+/** Split template text into text and action pieces, applying trim markers */
+function split(src, name) {
+    const pieces = [];
+    let last = 0;
+    let m;
+    ACTION.lastIndex = 0;
+    while ((m = ACTION.exec(src)) !== null) {
+        let text = src.slice(last, m.index);
+        if (m[1]) text = text.replace(/\s+$/, '');
+        if (pieces.length > 0 && pieces[pieces.length - 1].trimNext) text = text.replace(/^\s+/, '');
+        if (text) pieces.push({ text });
+        const line = src.slice(0, m.index).split('\n').length;
+        const body = m[2].trim();
+        if (body.startsWith('/*')) {
+            if (!body.endsWith('*/')) throw new Error(`template: ${name}:${line}: unclosed comment`);
+            pieces.push({ comment: true, trimNext: !!m[3] });
+        } else {
+            pieces.push({ action: body, line, trimNext: !!m[3] });
+        }
+        last = ACTION.lastIndex;
+    }
+    let rest = src.slice(last);
+    if (pieces.length > 0 && pieces[pieces.length - 1].trimNext) rest = rest.replace(/^\s+/, '');
+    if (rest.includes('{{')) {
+        throw new Error(`template: ${name}:${src.slice(0, last).split('\n').length}: unclosed action`);
+    }
+    if (rest) pieces.push({ text: rest });
+    return pieces;
+}
+
+const TOKEN = /\s*(?:("(?:[^"\\]|\\.)*")|(`[^`]*`)|(:=|[|(),=])|(-?\d+(?:\.\d+)?)|(\$[A-Za-z0-9_]*(?:\.[A-Za-z0-9_]+)*)|(\.(?:[A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)?)|([A-Za-z_][A-Za-z0-9_]*))/y;
+
+/** Tokens of one action's body */
+function lex(body, where) {
+    const tokens = [];
+    TOKEN.lastIndex = 0;
+    while (TOKEN.lastIndex < body.length) {
+        if (/^\s*$/.test(body.slice(TOKEN.lastIndex))) break;
+        const at = TOKEN.lastIndex;
+        const m = TOKEN.exec(body);
+        if (!m) throw new Error(`${where}: unexpected "${body.slice(at).trim()[0]}" in action`);
+        if (m[1]) tokens.push({ t: 'lit', v: JSON.parse(m[1]) });
+        else if (m[2]) tokens.push({ t: 'lit', v: m[2].slice(1, -1) });
+        else if (m[3]) tokens.push({ t: m[3] });
+        else if (m[4]) tokens.push({ t: 'lit', v: Number(m[4]) });
+        else if (m[5]) {
+            const [v, ...fields] = m[5].split('.');
+            tokens.push({ t: 'var', v, fields });
+        } else if (m[6]) tokens.push({ t: 'field', fields: m[6] === '.' ? [] : m[6].slice(1).split('.') });
+        else if (m[7] === 'true' || m[7] === 'false') tokens.push({ t: 'lit', v: m[7] === 'true' });
+        else if (m[7] === 'nil') tokens.push({ t: 'lit', v: null });
+        else tokens.push({ t: 'ident', v: m[7] });
+    }
+    return tokens;
+}
+
+// ── Parsing ───────────────────────────────────────────────────────────
+
+/** Pipeline: [decl vars] command (| command)*; a command is a list of operands */
+function parsePipeline(tokens, where, allowDecl) {
+    let pos = 0;
+    let decl = null;
+    const declEnd = tokens.findIndex(t => t.t === ':=');
+    if (declEnd !== -1) {
+        const names = tokens.slice(0, declEnd).filter(t => t.t !== ',');
+        if (!allowDecl || names.length === 0 || names.length > 2 || names.some(t => t.t !== 'var' || t.fields.length > 0)) {
+            throw new Error(`${where}: bad variable declaration`);
+        }
+        decl = names.map(t => t.v);
+        pos = declEnd + 1;
+    }
+    const parseCommands = (stopAtParen) => {
+        const commands = [[]];
+        while (pos < tokens.length) {
+            const tok = tokens[pos];
+            if (tok.t === ')') {
+                if (!stopAtParen) throw new Error(`${where}: unexpected ")"`);
+                break;
+            }
+            pos++;
+            if (tok.t === '|') {
+                if (commands[commands.length - 1].length === 0) throw new Error(`${where}: missing command before "|"`);
+                commands.push([]);
+            } else if (tok.t === '(') {
+                const inner = parseCommands(true);
+                if (tokens[pos]?.t !== ')') throw new Error(`${where}: unclosed "("`);
+                pos++;
+                commands[commands.length - 1].push({ t: 'pipe', commands: inner });
+            } else if (tok.t === ',' || tok.t === '=' || tok.t === ':=') {
+                throw new Error(`${where}: unexpected "${tok.t}"`);
+            } else {
+                commands[commands.length - 1].push(tok);
+            }
+        }
+        if (commands[commands.length - 1].length === 0) throw new Error(`${where}: missing value`);
+        return commands;
+    };
+    const commands = parseCommands(false);
+    return { decl, commands };
+}
+
+const BLOCKS = new Set(['if', 'range', 'with']);
+
+/** Node list up to a closing {{end}} or {{else}}; returns [nodes, stopper] */
+function parseList(pieces, state, name) {
+    const nodes = [];
+    while (state.i < pieces.length) {
+        const p = pieces[state.i++];
+        if (p.text !== undefined) { nodes.push({ kind: 'text', text: p.text }); continue; }
+        if (p.comment) continue;
+        const where = `template: ${name}:${p.line}`;
+        const word = p.action.match(/^[a-z]+\b/)?.[0];
+        if (word === 'end') return [nodes, { kind: 'end', where }];
+        if (word === 'else') {
+            const rest = p.action.slice(4).trim();
+            return [nodes, { kind: 'else', where, rest }];
+        }
+        if (BLOCKS.has(word)) {
+            nodes.push(parseBlock(word, p.action.slice(word.length), where, pieces, state, name));
+            continue;
+        }
+        if (['define', 'template', 'block', 'break', 'continue'].includes(word)) {
+            throw new Error(`${where}: {{${word}}} is not supported`);
+        }
+        nodes.push({ kind: 'action', pipe: parsePipeline(lex(p.action, where), where, true), where });
+    }
+    return [nodes, null];
+}
+
+function parseBlock(kind, src, where, pieces, state, name) {
+    const pipe = parsePipeline(lex(src, where), where, kind === 'range');
+    const node = { kind, pipe, where, body: [], else: null };
+    const [body, stop] = parseList(pieces, state, name);
+    if (!stop) throw new Error(`${where}: unexpected EOF, {{${kind}}} has no {{end}}`);
+    node.body = body;
+    if (stop.kind === 'else') {
+        const chained = stop.rest.match(/^(if|with)\b/)?.[1];
+        if (chained) {
+            // {{else if x}} is {{else}}{{if x}}...{{end}}{{end}} sharing one {{end}}
+            node.else = [parseBlock(chained, stop.rest.slice(chained.length), stop.where, pieces, state, name)];
+        } else {
+            if (stop.rest) throw new Error(`${stop.where}: unexpected "${stop.rest}" after else`);
+            const [elseBody, end] = parseList(pieces, state, name);
+            if (!end || end.kind !== 'end') throw new Error(`${stop.where}: expected {{end}} after {{else}}`);
+            node.else = elseBody;
+        }
+    }
+    return node;
+}
+
+// ── Evaluation ────────────────────────────────────────────────────────
+
+/** Go's truth: false, 0, nil, and empty strings, slices and maps are false */
+function truth(v) {
+    if (v === undefined || v === null || v === false || v === 0 || v === '') return false;
+    if (Array.isArray(v)) return v.length > 0;
+    if (typeof v === 'object') return Object.keys(v).length > 0;
+    return true;
+}
+
+/** Default print form, after Go's fmt %v */
+function show(v) {
+    if (v === undefined || v === null) return '<no value>';
+    if (Array.isArray(v)) return `[${v.map(show).join(' ')}]`;
+    if (typeof v === 'object') return `map[${Object.entries(v).map(([k, x]) => `${k}:${show(x)}`).join(' ')}]`;
+    return String(v);
+}
+
+function printf(format, ...args) {
+    let i = 0;
+    return String(format).replace(/%([-+ 0]*)(\d*)(?:\.(\d+))?([sdvqfxt%])/g, (all, fl, width, prec, verb) => {
+        if (verb === '%') return '%';
+        if (i >= args.length) return `%!${verb}(MISSING)`;
+        const a = args[i++];
+        let s;
+        if (verb === 'd') s = String(Math.trunc(Number(a)));
+        else if (verb === 'f') s = Number(a).toFixed(prec === undefined ? 6 : Number(prec));
+        else if (verb === 'q') s = JSON.stringify(String(a));
+        else if (verb === 'x') s = typeof a === 'number' ? a.toString(16) : Buffer.from(String(a)).toString('hex');
+        else s = show(a);
+        if (verb === 's' && prec !== undefined) s = s.slice(0, Number(prec));
+        const w = Number(width || 0);
+        if (s.length < w) s = fl.includes('-') ? s.padEnd(w) : s.padStart(w, fl.includes('0') && verb !== 's' ? '0' : ' ');
+        return s;
+    });
+}
+
+const FUNCS = {
+    and: (...a) => a.find(x => !truth(x)) ?? a[a.length - 1],
+    or: (...a) => a.find(x => truth(x)) ?? a[a.length - 1],
+    not: (a) => !truth(a),
+    len: (a) => {
+        if (typeof a === 'string' || Array.isArray(a)) return a.length;
+        if (a && typeof a === 'object') return Object.keys(a).length;
+        throw new Error(`len of ${show(a)}`);
+    },
+    index: (a, ...keys) => keys.reduce((v, k) => (v == null ? undefined : v[k]), a),
+    print: (...a) => a.map(show).join(''),
+    println: (...a) => a.map(show).join(' ') + '\n',
+    printf,
+    eq: (a, ...b) => b.some(x => x === a),
+    ne: (a, b) => a !== b,
+    lt: (a, b) => a < b,
+    le: (a, b) => a <= b,
+    gt: (a, b) => a > b,
+    ge: (a, b) => a >= b,
+    html: (...a) => a.map(show).join('').replace(/[<>&"']/g, c => ({ '<': '&lt;', '>': '&gt;', '&': '&amp;', '"': '&#34;', "'": '&#39;' }[c])),
+    js: (...a) => JSON.stringify(a.map(show).join('')).slice(1, -1).replace(/'/g, "\\'").replace(/</g, '\\u003C').replace(/>/g, '\\u003E'),
+    urlquery: (...a) => encodeURIComponent(a.map(show).join('')),
+    json: (a) => JSON.stringify(a ?? null),
+    join: (a, sep) => (Array.isArray(a) ? a.map(show).join(sep) : show(a)),
+};
+
+function lookup(value, fields) {
+    return fields.reduce((v, f) => (v == null ? undefined : v[f]), value);
+}
+
+function evalOperand(op, ctx, where) {
+    if (op.t === 'lit') return op.v;
+    if (op.t === 'field') return lookup(ctx.dot, op.fields);
+    if (op.t === 'var') {
+        if (op.v === '$') return lookup(ctx.vars[0].value, op.fields);
+        const v = [...ctx.vars].reverse().find(x => x.name === op.v);
+        if (!v) throw new Error(`${where}: undefined variable "${op.v}"`);
+        return lookup(v.value, op.fields);
+    }
+    if (op.t === 'pipe') return evalCommands(op.commands, ctx, where);
+    throw new Error(`${where}: "${op.v}" is not a function`);
+}
+
+function evalCommands(commands, ctx, where) {
+    let piped;
+    commands.forEach((cmd, n) => {
+        const [head, ...rest] = cmd;
+        if (head.t === 'ident') {
+            const fn = FUNCS[head.v];
+            if (!fn) throw new Error(`${where}: function "${head.v}" not defined`);
+            const args = rest.map(op => evalOperand(op, ctx, where));
+            if (n > 0) args.push(piped);
+            try {
+                piped = fn(...args);
+            } catch (e) {
+                throw new Error(`${where}: error calling ${head.v}: ${e.message}`);
+            }
+        } else {
+            if (rest.length > 0 || n > 0) throw new Error(`${where}: can't give argument to non-function`);
+            piped = evalOperand(head, ctx, where);
+        }
+    });
+    return piped;
+}
+
+function run(nodes, ctx, out) {
+    for (const node of nodes) {
+        if (node.kind === 'text') { out.push(node.text); continue; }
+        const value = evalCommands(node.pipe.commands, ctx, node.where);
+        if (node.kind === 'action') {
+            if (node.pipe.decl) ctx.vars.push({ name: node.pipe.decl[0], value });
+            else out.push(show(value));
+            continue;
+        }
+        const depth = ctx.vars.length;
+        if (node.kind === 'if' || node.kind === 'with') {
+            if (truth(value)) run(node.body, node.kind === 'with' ? { ...ctx, dot: value } : ctx, out);
+            else if (node.else) run(node.else, ctx, out);
+        } else {
+            const items = Array.isArray(value) ? value.map((v, i) => [i, v])
+                : typeof value === 'number' ? Array.from({ length: value }, (_, i) => [i, i])
+                    : value && typeof value === 'object' ? Object.keys(value).sort().map(k => [k, value[k]])
+                        : [];
+            if (value != null && items.length === 0 && typeof value !== 'object' && typeof value !== 'number') {
+                throw new Error(`${node.where}: range can't iterate over ${show(value)}`);
+            }
+            if (items.length === 0 && node.else) run(node.else, ctx, out);
+            for (const [key, item] of items) {
+                const decl = node.pipe.decl;
+                if (decl?.length === 1) ctx.vars.push({ name: decl[0], value: item });
+                if (decl?.length === 2) ctx.vars.push({ name: decl[0], value: key }, { name: decl[1], value: item });
+                run(node.body, { ...ctx, dot: item }, out);
+                ctx.vars.length = depth;
+            }
+        }
+        ctx.vars.length = depth;
+    }
+}
+
+/**
+ * Parse a template. Throws on syntax errors, with Go's
+ * `template: <name>:<line>: <message>` form.
+ * @param {string} src - Template text
+ * @param {string} [name] - File name, for error messages
+ * @returns {function(object): string} Runs the template against data
+ */
+function compileTemplate(src, name = 'template') {
+    const pieces = split(src, name);
+    const state = { i: 0 };
+    const [nodes, stop] = parseList(pieces, state, name);
+    if (stop) throw new Error(`${stop.where}: unexpected {{${stop.kind}}}`);
+    return (data) => {
+        const out = [];
+        run(nodes, { dot: data, vars: [{ name: '$', value: data }] }, out);
+        return out.join('');
+    };
+}
+
+/**
+ * A finding command's result rendered through a compiled template.
+ * @param {function(object): string} template - From compileTemplate
+ * @param {string} command - Canonical command
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { root } of the project; { rules } from .ucn.json
+ * @returns {string} The template's output
+ */
+function formatTemplate(template, command, result, options = {}) {
+    return template(templateData(command, result, options));
+}
+
+module.exports = { compileTemplate, formatTemplate, templateData };
@@ -0,0 +1,99 @@
+/**
+ * core/output/xml.js — XML reports of findings for CI test and lint
+ * reporters (--format junit, --format checkstyle)
+ *
+ * JUnit: one <testsuite> per rule and, inside it, one <testcase> per
+ * package (directory) with findings, failing with those findings listed,
+ * so Jenkins, GitLab and other CI test-report UIs show them as failed
+ * tests grouped by rule. A run with no findings is one passing test case.
+ *
+ * Checkstyle: one <file> per file with an <error> per finding, the shape
+ * Jenkins Warnings NG, SonarQube's checkstyle import and reviewdog's
+ * checkstyle parser already read. The finding's severity carries over
+ * (error, warning, info); the source is `ucn.<RuleName>`.
+ */
+
+'use strict';
+
+const path = require('path');
+const { findingsOf, RULES } = require('./sarif');
+
+function escapeXml(text) {
+    return String(text).replace(/[<>&"']/g, c => ({ '<': '&lt;', '>': '&gt;', '&': '&amp;', '"': '&quot;', "'": '&apos;' }[c]))
+        // Control characters other than tab and newlines are not allowed in XML 1.0
+        .replace(/[\x00-\x08\x0B\x0C\x0E-\x1F]/g, ''); // eslint-disable-line no-control-regex
+}
+
+/**
+ * JUnit XML report of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} XML document
+ */
+function formatJunit(command, result, options = {}) {
+    const byRule = new Map();
+    for (const f of findingsOf(command, result, options.rules)) {
+        if (!byRule.has(f.rule)) byRule.set(f.rule, new Map());
+        const byDir = byRule.get(f.rule);
+        const dir = path.posix.dirname(f.at.file);
+        if (!byDir.has(dir)) byDir.set(dir, []);
+        byDir.get(dir).push(f);
+    }
+    const suites = [...byRule].sort((a, b) => (a[0] < b[0] ? -1 : a[0] > b[0] ? 1 : 0));
+    const cases = suites.reduce((n, [, byDir]) => n + byDir.size, 0);
+
+    const xml = ['<?xml version="1.0" encoding="UTF-8"?>'];
+    xml.push(`<testsuites name="ucn ${escapeXml(command)}" tests="${Math.max(cases, 1)}" failures="${cases}">`);
+    if (cases === 0) {
+        xml.push(`  <testsuite name="${escapeXml(command)}" tests="1" failures="0">`);
+        xml.push(`    <testcase classname="ucn.${escapeXml(command)}" name="no findings"/>`);
+        xml.push('  </testsuite>');
+    }
+    for (const [rule, byDir] of suites) {
+        const [name, description] = RULES[rule];
+        const { severity } = byDir.values().next().value[0];
+        xml.push(`  <testsuite name="${escapeXml(rule)}" tests="${byDir.size}" failures="${byDir.size}">`);
+        xml.push(`    <properties><property name="description" value="${escapeXml(description)}"/><property name="severity" value="${severity}"/></properties>`);
+        const dirs = [...byDir].sort((a, b) => (a[0] < b[0] ? -1 : a[0] > b[0] ? 1 : 0));
+        for (const [dir, findings] of dirs) {
+            const body = findings.map(f => `${f.at.file}:${f.at.startLine}: ${f.message} (${f.confidence} confidence)`).join('\n');
+            xml.push(`    <testcase classname="ucn.${escapeXml(rule)}" name="${escapeXml(dir)}" file="${escapeXml(findings[0].at.file)}">`);
+            xml.push(`      <failure type="${escapeXml(name)}" message="${findings.length} finding(s) in ${escapeXml(dir)}">${escapeXml(body)}</failure>`);
+            xml.push('    </testcase>');
+        }
+        xml.push('  </testsuite>');
+    }
+    xml.push('</testsuites>');
+    return xml.join('\n');
+}
+
+/**
+ * Checkstyle XML report of a finding command's result.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @param {object} [options] - { rules } from .ucn.json
+ * @returns {string} XML document
+ */
+function formatCheckstyle(command, result, options = {}) {
+    const byFile = new Map();
+    for (const f of findingsOf(command, result, options.rules)) {
+        if (!byFile.has(f.at.file)) byFile.set(f.at.file, []);
+        byFile.get(f.at.file).push(f);
+    }
+    const files = [...byFile].sort((a, b) => (a[0] < b[0] ? -1 : a[0] > b[0] ? 1 : 0));
+
+    const xml = ['<?xml version="1.0" encoding="UTF-8"?>', '<checkstyle version="8.0">'];
+    for (const [file, findings] of files) {
+        xml.push(`  <file name="${escapeXml(file)}">`);
+        for (const f of findings.sort((a, b) => a.at.startLine - b.at.startLine)) {
+            const [name] = RULES[f.rule];
+            xml.push(`    <error line="${f.at.startLine}" column="1" severity="${f.severity}" message="${escapeXml(f.message)}" source="ucn.${escapeXml(name)}"/>`);
+        }
+        xml.push('  </file>');
+    }
+    xml.push('</checkstyle>');
+    return xml.join('\n');
+}
+
+module.exports = { formatJunit, formatCheckstyle, escapeXml };
@@ -3,14 +3,50 @@
 /**
  * core/parallel-build.js - Worker pool orchestration for parallel indexing
  *
- * Splits files into N chunks, spawns worker threads to parse them in parallel,
- * then merges results into the ProjectIndex. Uses Atomics.wait + MessageChannel
- * to keep the build() API synchronous.
+ * Spawns a bounded pool of worker threads that pull files off a shared
+ * queue (an Atomics counter into the file list), so a worker that drew
+ * small files takes more of them instead of idling behind one that drew
+ * large ones. The existing entries' mtime, size and hash sit in shared
+ * memory by file-list slot, so a worker reads those of the file it just
+ * claimed and none gets a copy of the whole map. Results are merged into
+ * the ProjectIndex in file-list order, never in completion order, so the
+ * index is the same however the workers were scheduled. Uses Atomics.wait
+ * + MessageChannel to keep the build() API synchronous.
  */
 
 const os = require('os');
 const path = require('path');
 const { Worker, MessageChannel, receiveMessageOnPort } = require('worker_threads');
+const log = require('./log');
+
+// Queue slots in the shared Int32Array: the next file to claim, the
+// number of files finished; then one done flag per worker
+const NEXT = 0;
+const FINISHED = 1;
+const DONE = 2;
+
+// Per file-list slot: Float64 [known, mtime, size], and the md5 digest
+const STAT_SLOTS = 3;
+const HASH_BYTES = 16;
+
+// Fewer files than this per worker and thread startup outweighs the gain
+const MIN_FILES_PER_WORKER = 100;
+
+/**
+ * Number of workers a build of fileCount files gets.
+ * @param {number} fileCount
+ * @param {number} [jobs] - Requested pool size (auto-detect if omitted)
+ * @returns {number} Below 2 means build sequentially
+ */
+function poolSize(fileCount, jobs) {
+    const availableCpus = (typeof os.availableParallelism === 'function')
+        ? os.availableParallelism()
+        : os.cpus().length;
+    // Auto-detect leaves a core for the main thread and caps at 8; an
+    // explicit --jobs is honored as given
+    const maxWorkers = jobs > 0 ? jobs : Math.min(Math.max(availableCpus - 1, 1), 8);
+    return Math.min(maxWorkers, Math.ceil(fileCount / MIN_FILES_PER_WORKER));
+}
 
 /**
  * Build index in parallel using worker threads.
@@ -20,34 +56,31 @@ const { Worker, MessageChannel, receiveMessageOnPort } = require('worker_threads
  * @param {object} options
  * @param {number} [options.workerCount] - Number of workers (auto-detect if omitted)
  * @param {boolean} [options.quiet] - Suppress output
+ * @param {function} [options.progress] - Called with the number of files
+ *   parsed so far while the workers run
  * @returns {number|false} Number of changed files, or false if too few workers
  */
 function parallelBuild(index, files, options = {}) {
-    const availableCpus = (typeof os.availableParallelism === 'function')
-        ? os.availableParallelism()
-        : os.cpus().length;
-    const autoWorkers = Math.max(availableCpus - 1, 1);
-    const maxWorkers = (options.workerCount > 0) ? options.workerCount : autoWorkers;
-    const workerCount = Math.min(
-        maxWorkers,
-        8,
-        Math.ceil(files.length / 100) // at least 100 files per worker
-    );
+    const workerCount = poolSize(files.length, options.workerCount);
 
     if (workerCount < 2) return false;
 
     if (!options.quiet) {
         console.error(`Parallel build: ${workerCount} workers for ${files.length} files`);
     }
+    log.debug('build.pool', { workers: workerCount, files: files.length });
 
-    // Partition files round-robin for balanced work distribution
-    const chunks = Array.from({ length: workerCount }, () => []);
+    // Each worker may claim any file, so it may need any file's hash
+    const stats = new Float64Array(new SharedArrayBuffer(8 * STAT_SLOTS * files.length));
+    const digests = new Uint8Array(new SharedArrayBuffer(HASH_BYTES * files.length));
     for (let i = 0; i < files.length; i++) {
-        chunks[i % workerCount].push(files[i]);
+        const entry = index.files.get(files[i]);
+        if (!entry) continue;
+        stats.set([1, entry.mtime, entry.size], STAT_SLOTS * i);
+        if (/^[0-9a-f]{32}$/.test(entry.hash)) digests.set(Buffer.from(entry.hash, 'hex'), HASH_BYTES * i);
     }
 
-    // Synchronization: one Int32 per worker in SharedArrayBuffer
-    const sab = new SharedArrayBuffer(4 * workerCount);
+    const sab = new SharedArrayBuffer(4 * (DONE + workerCount));
     const signal = new Int32Array(sab);
 
     const ports = [];
@@ -57,20 +90,13 @@ function parallelBuild(index, files, options = {}) {
         const { port1, port2 } = new MessageChannel();
         ports.push(port1);
 
-        // Build per-worker hash subset (each worker only needs hashes for its chunk)
-        const workerHashes = Object.create(null);
-        for (const fp of chunks[i]) {
-            const entry = index.files.get(fp);
-            if (entry) {
-                workerHashes[fp] = { mtime: entry.mtime, size: entry.size, hash: entry.hash };
-            }
-        }
-
         const worker = new Worker(path.join(__dirname, 'build-worker.js'), {
             workerData: {
-                files: chunks[i],
+                files,
                 rootDir: index.root,
-                existingHashes: workerHashes,
+                grammars: index.config.grammars,
+                stats: stats.buffer,
+                digests: digests.buffer,
                 signal: sab,
                 workerIndex: i,
                 port: port2,
@@ -80,81 +106,91 @@ function parallelBuild(index, files, options = {}) {
         workers.push(worker);
     }
 
-    // Block main thread until all workers finish (with timeout)
+    // Block main thread until all workers finish (with timeout), reporting
+    // the queue's progress in between
     const TIMEOUT_MS = 300_000; // 5 minutes
     const deadline = Date.now() + TIMEOUT_MS;
 
     for (let i = 0; i < workerCount; i++) {
-        while (Atomics.load(signal, i) === 0) {
+        while (Atomics.load(signal, DONE + i) === 0) {
             const remaining = deadline - Date.now();
             if (remaining <= 0) {
                 for (const w of workers) w.terminate();
                 throw new Error('Parallel build timed out after 5 minutes');
             }
-            Atomics.wait(signal, i, 0, Math.min(remaining, 5000));
+            Atomics.wait(signal, DONE + i, 0, Math.min(remaining, options.progress ? 200 : 5000));
+            if (options.progress) options.progress(Math.min(Atomics.load(signal, FINISHED), files.length));
         }
     }
 
-    // Collect and merge results from each worker
-    let changed = 0;
-
+    // Results arrive in whatever order the workers claimed files; put
+    // them back in file-list order before merging
+    const byFile = new Map();
     for (let i = 0; i < workerCount; i++) {
         const msg = receiveMessageOnPort(ports[i]);
         ports[i].close();
         if (!msg) continue;
+        for (const result of msg.message) byFile.set(result.filePath, result);
+    }
+    if (options.progress) options.progress(files.length);
 
-        for (const result of msg.message) {
-            if (result.error) {
-                index.failedFiles.add(result.filePath);
-                if (!options.quiet) {
-                    console.error(`  Warning: Could not index ${result.filePath}: ${result.error}`);
-                }
-                continue;
-            }
+    // Merge results into the index
+    let changed = 0;
 
-            if (result.skipped) {
-                // Update mtime/size if content matched but stat changed
-                if (result.mtimeUpdate !== undefined) {
-                    const existing = index.files.get(result.filePath);
-                    if (existing) {
-                        existing.mtime = result.mtimeUpdate;
-                        existing.size = result.sizeUpdate;
-                    }
-                }
-                index.failedFiles.delete(result.filePath);
-                continue;
-            }
+    for (const filePath of files) {
+        const result = byFile.get(filePath);
+        if (!result) continue;
 
-            // Changed or new file — merge into index
-            if (result.hadExisting) {
-                index.removeFileSymbols(result.filePath);
+        if (result.error) {
+            index.failedFiles.add(result.filePath);
+            if (!options.quiet) {
+                console.error(`  Warning: Could not index ${result.filePath}: ${result.error}`);
             }
+            continue;
+        }
 
-            const fe = result.fileEntry;
-
-            // Register symbols in global map
-            for (const symbol of fe.symbols) {
-                if (!index.symbols.has(symbol.name)) {
-                    index.symbols.set(symbol.name, []);
+        if (result.skipped) {
+            // Update mtime/size if content matched but stat changed
+            if (result.mtimeUpdate !== undefined) {
+                const existing = index.files.get(result.filePath);
+                if (existing) {
+                    existing.mtime = result.mtimeUpdate;
+                    existing.size = result.sizeUpdate;
                 }
-                index.symbols.get(symbol.name).push(symbol);
             }
+            index.failedFiles.delete(result.filePath);
+            continue;
+        }
+
+        // Changed or new file — merge into index
+        if (result.hadExisting) {
+            index.removeFileSymbols(result.filePath);
+        }
 
-            index.files.set(result.filePath, fe);
+        const fe = result.fileEntry;
 
-            // Populate callsCache (avoids re-parsing in buildCalleeIndex)
-            if (result.calls) {
-                index.callsCache.set(result.filePath, {
-                    mtime: result.callsMtime,
-                    hash: result.callsHash,
-                    calls: result.calls,
-                });
-                index.callsCacheDirty = true;
+        // Register symbols in global map
+        for (const symbol of fe.symbols) {
+            if (!index.symbols.has(symbol.name)) {
+                index.symbols.set(symbol.name, []);
             }
+            index.symbols.get(symbol.name).push(symbol);
+        }
 
-            index.failedFiles.delete(result.filePath);
-            changed++;
+        index.files.set(result.filePath, fe);
+
+        // Populate callsCache (avoids re-parsing in buildCalleeIndex)
+        if (result.calls) {
+            index.callsCache.set(result.filePath, {
+                mtime: result.callsMtime,
+                hash: result.callsHash,
+                calls: result.calls,
+            });
+            index.callsCacheDirty = true;
         }
+
+        index.failedFiles.delete(result.filePath);
+        changed++;
     }
 
     // Terminate workers
@@ -165,4 +201,4 @@ function parallelBuild(index, files, options = {}) {
     return changed;
 }
 
-module.exports = { parallelBuild };
+module.exports = { parallelBuild, poolSize };
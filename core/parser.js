@@ -32,7 +32,8 @@ const { detectLanguage, getParser, getLanguageModule, isSupported } = require('.
  * @property {string} name - Class/type name
  * @property {number} startLine - 1-indexed start line
  * @property {number} endLine - 1-indexed end line
- * @property {string} type - 'class', 'interface', 'type', 'enum', 'struct', 'trait', 'impl', 'module', 'macro', 'record'
+ * @property {string} type - 'class', 'interface', 'type', 'enum', 'struct', 'trait', 'impl', 'module', 'macro', 'record',
+ *   'table', 'view' (SQL)
  * @property {Array} members - Class members (methods, fields)
  * @property {string|null} [docstring] - First line of documentation
  * @property {string} [extends] - Parent class/type
@@ -272,7 +273,7 @@ function getExportedSymbols(result) {
 }
 
 /**
- * Strip <script> and </script> tags from extracted code lines for HTML files.
+ * Strip <script> and </script> tags from extracted code lines for HTML, Vue and Svelte files.
  * Only affects the first and last lines when they contain script tags alongside JS code.
  * Handles inline cases like `<p>foo</p><script>code</script><p>bar</p>` by stripping
  * the opening/closing tags wherever they appear on the line, not just at line edges.
@@ -281,7 +282,7 @@ function getExportedSymbols(result) {
  * @returns {string[]} Cleaned lines (same array mutated)
  */
 function cleanHtmlScriptTags(lines, language) {
-    if (language === 'html' && lines.length > 0) {
+    if ((language === 'html' || language === 'vue' || language === 'svelte') && lines.length > 0) {
         // Strip everything up to and including the opening <script ...> tag
         // on the first line — surrounding same-line markup is not code
         // (fix #252: `<div><script>function foo()...` leaked `<div>` into
@@ -8,15 +8,16 @@
 const fs = require('fs');
 const path = require('path');
 const crypto = require('crypto');
-const { expandGlob, findProjectRoot, detectProjectPattern, isTestFile, parseGitignore, DEFAULT_IGNORES, compareNames } = require('./discovery');
+const { expandGlob, findProjectRoot, detectProjectPattern, isTestFile, parseGitignore, DEFAULT_IGNORES, compareNames, isPathPattern, compilePathPattern, passesPathPatterns } = require('./discovery');
 const { extractImports, extractExports } = require('./imports');
 const { parse, cleanHtmlScriptTags } = require('./parser');
-const { detectLanguage, getParser, getLanguageModule, safeParse, langTraits, PARSE_OPTIONS } = require('../languages');
+const { detectLanguage, getParser, getLanguageModule, safeParse, langTraits, PARSE_OPTIONS, registerGrammars } = require('../languages');
 const { getTokenTypeAtPosition } = require('../languages/utils');
 const { escapeRegExp, NON_CALLABLE_TYPES, codeUnitCompare } = require('./shared');
 const stacktrace = require('./stacktrace');
 const indexCache = require('./cache');
 const deadcodeModule = require('./deadcode');
+const linksModule = require('./links');
 const verifyModule = require('./verify');
 const callersModule = require('./callers');
 const tracingModule = require('./tracing');
@@ -25,6 +26,11 @@ const analysisModule = require('./analysis');
 const graphModule = require('./graph');
 const graphBuildModule = require('./graph-build');
 const reportingModule = require('./reporting');
+const clonesModule = require('./clones');
+const deprecatedModule = require('./deprecated');
+const { readConfig, configLookup } = require('./config');
+const { hasGeneratedHeader } = require('./generated');
+const log = require('./log');
 
 // Lazy-initialized per-language keyword sets (populated on first isKeyword call)
 let LANGUAGE_KEYWORDS = null;
@@ -46,6 +52,9 @@ class ProjectIndex {
         this.extendsGraph = new Map();    // className -> [parentName, ...] (array of parents)
         this.extendedByGraph = new Map(); // parentName -> [childInfo]
         this.config = this.loadConfig();
+        // .ucn.json "grammars": user-supplied tree-sitter grammars (load
+        // results, with the reason for any grammar left unloaded)
+        this.grammars = registerGrammars(this.config.grammars, this.root);
         this.buildTime = null;
         this.callsCache = new Map();     // filePath -> { mtime, hash, calls, content }
         this.callsCacheDirty = false;    // set by getCachedCalls when entries are added or mutated
@@ -57,6 +66,7 @@ class ProjectIndex {
         this._opInnerSymbolRangesCache = null; // per-operation sorted class-method ranges by file
         this._opFlowTypeOriginCache = null; // per-operation annotation type identity results
         this.calleeIndex = null;         // name -> Set<filePath> — inverted call index (built lazily)
+        this._overlays = null;           // Map<filePath, content> — unsaved buffers read instead of the disk (overlayFile)
     }
 
     /**
@@ -65,6 +75,8 @@ class ProjectIndex {
      * cached for the duration of the operation to avoid redundant disk I/O.
      */
     _readFile(filePath) {
+        const overlay = this._overlays?.get(filePath);
+        if (overlay !== undefined) return overlay;
         if (this._opContentCache) {
             const cached = this._opContentCache.get(filePath);
             if (cached !== undefined) return cached;
@@ -75,6 +87,70 @@ class ProjectIndex {
         return fs.readFileSync(filePath, 'utf-8');
     }
 
+    /** A file's size and mtime, an overlaid buffer's (mtime -1) included */
+    _fileStat(filePath) {
+        const overlay = this._overlays?.get(filePath);
+        if (overlay !== undefined) return { mtimeMs: -1, size: Buffer.byteLength(overlay) };
+        return fs.statSync(filePath);
+    }
+
+    /**
+     * Analyze an editor's unsaved buffer in place of a file, for the rest
+     * of this index's life: the file is re-indexed from the text (it need
+     * not exist on disk), every read of it sees the text, and the import
+     * graph and callee index are relinked so the other files resolve
+     * against it. An overlaid index must not be saved to the cache.
+     * @param {string} filePath - Absolute path the buffer stands for
+     * @param {string} content - The buffer's text
+     */
+    overlayFile(filePath, content) {
+        if (!this._overlays) this._overlays = new Map();
+        this._overlays.set(filePath, content);
+        this.failedFiles.delete(filePath);
+        this.indexFile(filePath);
+        this._relinkOverlay();
+    }
+
+    /**
+     * Stop reading a buffer in place of its file (the editor closed it):
+     * the file is re-indexed from the disk, or dropped if it was never
+     * saved, and the graphs are relinked.
+     * @param {string} filePath - Absolute path passed to overlayFile
+     * @returns {boolean} Whether the file was overlaid
+     */
+    dropOverlay(filePath) {
+        if (!this._overlays?.delete(filePath)) return false;
+        if (fs.existsSync(filePath)) {
+            this.indexFile(filePath);
+        } else {
+            this.removeFileSymbols(filePath);
+            this.files.delete(filePath);
+            this.callsCache.delete(filePath);
+        }
+        this._relinkOverlay();
+        return true;
+    }
+
+    /** Relink the graphs after overlayFile or dropOverlay changed a file */
+    _relinkOverlay() {
+        this._completenessCache = null;
+        this._attrTypeCache = null;
+        this._endpointsCache = null;
+        this._linksCache = null;
+        this._callbackEntrypointMap = null;
+        this._configuredRegistryHandlers = null;
+        this._canonicalizeOrder();
+        this.buildImportGraph();
+        this.buildInheritanceGraph();
+        this._buildDirIndex();
+        this.buildCalleeIndex();
+    }
+
+    /** Whether unsaved buffers are overlaid (overlayFile) */
+    hasOverlays() {
+        return !!this._overlays && this._overlays.size > 0;
+    }
+
     /** Start a per-operation content cache scope (supports nesting) */
     _beginOp() {
         if (!this._opContentCache) {
@@ -216,29 +292,130 @@ class ProjectIndex {
     }
 
     /**
-     * Load .ucn.json config if present (data-only, no code execution)
+     * A file's text as the analysis sees it: an overlaid editor buffer
+     * over the disk, read once per operation. Throws when unreadable.
+     * @param {string} filePath - Absolute path
+     * @returns {string}
+     */
+    readFile(filePath) {
+        return this._readFile(filePath);
+    }
+
+    /**
+     * A file's syntax tree, parsed once per operation.
+     * @param {string} filePath - Absolute path of an indexed file
+     * @param {string} [content] - Its text, when already read
+     * @returns {object|null} null when the language has no parser or the
+     *   file does not parse
+     */
+    parseFile(filePath, content = this._readFile(filePath)) {
+        const language = this.files.get(filePath)?.language || detectLanguage(filePath);
+        const parser = language && getParser(language);
+        if (!parser) return null;
+        return this._getParsedTree(filePath, content, language) || safeParse(parser, content, undefined, PARSE_OPTIONS);
+    }
+
+    /**
+     * Where a name occurs as code in one file, from its syntax tree:
+     * imports, definitions, calls and references, never comments or
+     * strings.
+     * @param {string} filePath - Absolute path
+     * @param {string} name - Identifier
+     * @returns {Array<{line, column, usageType}>|null} null when the file
+     *   can't be parsed
+     */
+    usagesInFile(filePath, name) {
+        return this._getCachedUsages(filePath, name);
+    }
+
+    /**
+     * Load the root config (.ucn.json, .ucn.yaml, .ucn.yml or ucn.toml) if
+     * present (data-only, no code execution). One that fails to parse
+     * leaves the defaults; configErrors() says why.
      */
     loadConfig() {
-        const jsonPath = path.join(this.root, '.ucn.json');
-        if (fs.existsSync(jsonPath)) {
-            try {
-                return JSON.parse(fs.readFileSync(jsonPath, 'utf-8'));
-            } catch (e) {
-                // Config load failed, use defaults
-            }
-        }
-        return {};
+        const found = readConfig(this.root);
+        this._rootConfigError = found?.error ? `${path.basename(found.file)}: ${found.error}` : null;
+        this._configLookup = null;
+        return found ? found.config : {};
+    }
+
+    /** Per-directory config lookup (core/config.js), reset on each build */
+    _configs() {
+        if (!this._configLookup) this._configLookup = configLookup(this.root, this.config);
+        return this._configLookup;
+    }
+
+    /**
+     * Settings of one file: the root config with the overrides of every
+     * config file between the root and the file's directory.
+     * @param {string} file - Path, absolute or relative to the root
+     * @returns {object}
+     */
+    configFor(file) {
+        const rel = path.isAbsolute(file) ? path.relative(this.root, file) : file;
+        return this._configs().configFor(rel);
+    }
+
+    /**
+     * Whether a config's entryPoints names a symbol as used from outside
+     * the project (the nearest config with an entryPoints list decides),
+     * by pattern or as a function passed to one of its registries.
+     * @param {object} symbol - Symbol with name, className and relativePath
+     */
+    isConfiguredEntryPoint(symbol) {
+        if (!symbol.relativePath) return false;
+        const { symbolQualifiers, configuredRegistryHandlers, symbolKey } = require('./entrypoints');
+        const facts = {
+            qualifiers: () => symbolQualifiers(this, symbol),
+            exported: () => deadcodeModule.symbolIsExported(this, symbol, this.files.get(symbol.file)),
+        };
+        return this._configs().isEntryPoint(symbol, facts) ||
+            configuredRegistryHandlers(this).has(symbolKey(symbol.file, symbol.startLine));
+    }
+
+    /** Call patterns of the entryPoints registries applying to a file */
+    entryPointRegistries(file) {
+        const rel = path.isAbsolute(file) ? path.relative(this.root, file) : file;
+        return this._configs().registries(rel);
+    }
+
+    /** Config files that failed to parse, as "file: line N: reason" */
+    configErrors() {
+        return [...(this._rootConfigError ? [this._rootConfigError] : []), ...this._configs().errors];
+    }
+
+    /**
+     * Whether a directory config's include/exclude globs leave a file out
+     * of the index (the root's exclude applies during discovery).
+     * @param {string} file - Absolute path
+     */
+    excludedByConfig(file) {
+        return this._configs().excluded(path.relative(this.root, file));
     }
 
     /**
      * Build index for files matching pattern
      *
      * @param {string} pattern - Glob pattern (e.g., "**\/*.js")
-     * @param {object} options - { forceRebuild, maxFiles, quiet }
+     * @param {object} options - { forceRebuild, maxFiles, quiet, jobs, progress }
+     *   progress is called with { phase, done?, total?, symbols? } as the
+     *   build moves through its phases (discover, parse, graphs, calls)
      */
     build(pattern, options = {}) {
         const startTime = Date.now();
         const quiet = options.quiet !== false;
+        const progress = options.progress || (() => {});
+
+        // Per-phase timings, kept on the index as buildTimings
+        const timings = {};
+        let phaseStart = startTime;
+        const endPhase = (phase, fields) => {
+            timings[phase] = Date.now() - phaseStart;
+            log.debug('build.phase', { phase, ms: timings[phase], ...fields });
+            phaseStart = Date.now();
+        };
+        progress({ phase: 'discover' });
 
         // A (re)build invalidates any cache-loaded reachability set — the
         // fingerprint guard in computeReachability is content-shaped and
@@ -272,7 +449,18 @@ class ProjectIndex {
                 globOpts.ignores = [...DEFAULT_IGNORES, ...gitignorePatterns, ...configExclude];
             }
 
-            files = expandGlob(pattern, globOpts);
+            // Config files below the root may include or exclude more
+            this._configLookup = null;
+            this._configuredRegistryHandlers = null;
+            files = expandGlob(pattern, globOpts).filter(f => !this.excludedByConfig(f));
+
+            // --include/--exclude globs and regexes skip files before parsing
+            // (plain words like "test" only filter what commands report)
+            if (options.include?.length || options.exclude?.length) {
+                const filters = { include: options.include, exclude: options.exclude };
+                this.pathFilters = filters; // the index covers part of the project
+                files = files.filter(f => passesPathPatterns(path.relative(this.root, f).replace(/\\/g, '/'), filters));
+            }
         }
 
         // Track if files were truncated by maxFiles limit
@@ -283,6 +471,7 @@ class ProjectIndex {
             this.truncated = null;
         }
 
+        endPhase('discover', { files: files.length });
         if (!quiet) {
             console.error(`Indexing ${files.length} files in ${this.root}...`);
         }
@@ -321,16 +510,19 @@ class ProjectIndex {
         // Endpoints cache (server routes / client requests / bridges) becomes
         // stale when files change; clear on every rebuild.
         this._endpointsCache = null;
+        this._linksCache = null;
 
         let indexed = 0;
         let changed = 0;
         if (!this.failedFiles) this.failedFiles = new Set();
 
-        // Try parallel build for large projects
-        const workersSetting = options.workers;
-        const envWorkers = parseInt(process.env.UCN_WORKERS, 10);
+        // Try parallel build for large projects: jobs (or its older name
+        // workers) bounds the pool, 0 or 1 builds sequentially
+        const workersSetting = options.jobs ?? options.workers;
+        const envWorkers = parseInt(process.env.UCN_JOBS ?? process.env.UCN_WORKERS, 10);
         const disableParallel = workersSetting === 0 || envWorkers === 0;
         let usedParallel = false;
+        progress({ phase: 'parse', done: 0, total: files.length, symbols: this.symbols.size });
 
         if (!disableParallel && files.length > 150) {
             try {
@@ -338,6 +530,7 @@ class ProjectIndex {
                 const result = parallelBuild(this, files, {
                     workerCount: workersSetting > 0 ? workersSetting : (envWorkers > 0 ? envWorkers : undefined),
                     quiet,
+                    progress: done => progress({ phase: 'parse', done, total: files.length }),
                 });
                 if (result !== false) {
                     changed = result;
@@ -352,23 +545,31 @@ class ProjectIndex {
         }
 
         if (!usedParallel) {
+            let done = 0;
             for (const file of files) {
+                const fileStart = log.enabled('trace') ? Date.now() : 0;
                 try {
-                    if (this.indexFile(file)) changed++;
+                    const fileChanged = this.indexFile(file);
+                    if (fileChanged) changed++;
                     indexed++;
                     this.failedFiles.delete(file); // Succeeded now, remove from failed
+                    if (fileStart) log.trace('build.file', { file: path.relative(this.root, file), parsed: !!fileChanged, ms: Date.now() - fileStart });
                 } catch (e) {
                     this.failedFiles.add(file); // Track files that fail to index
+                    log.debug('build.file.failed', { file: path.relative(this.root, file), error: e.message });
                     if (!quiet) {
                         console.error(`  Warning: Could not index ${file}: ${e.message}`);
                     }
                 }
+                progress({ phase: 'parse', done: ++done, total: files.length, symbols: this.symbols.size });
             }
         }
+        endPhase('parse', { files: indexed, changed, parallel: usedParallel || undefined });
 
         // Canonical order BEFORE derived indexes, so graphs / dir index /
         // callee index inherit it. This is what makes incremental rebuilds
         // byte-equivalent to fresh builds (see _canonicalizeOrder).
+        progress({ phase: 'graphs', symbols: this.symbols.size });
         this._canonicalizeOrder();
 
         // Skip graph rebuild when incremental rebuild found no changes
@@ -379,17 +580,22 @@ class ProjectIndex {
 
         // Build directory→files index for O(1) same-package lookups
         this._buildDirIndex();
+        endPhase('graphs');
 
         // Build callee index eagerly: leverages warm parse cache from indexFile() above,
         // avoiding the 2+ minute deferred cost when the first analysis command runs later.
+        progress({ phase: 'calls', symbols: this.symbols.size });
         this.buildCalleeIndex();
 
         // buildCalleeIndex re-parses changed files via getCachedCalls, which
         // appends their entries at the callsCache TAIL — restore canonical
         // key order so iteration-order consumers match a fresh build.
         this.callsCache = new Map([...this.callsCache.entries()].sort((a, b) => compareNames(a[0], b[0])));
+        endPhase('calls');
 
         this.buildTime = Date.now() - startTime;
+        this.buildTimings = timings;
+        log.debug('build.done', { files: indexed, symbols: this.symbols.size, ms: this.buildTime });
 
         if (!quiet) {
             console.error(`Index complete: ${this.symbols.size} symbols in ${indexed} files (${this.buildTime}ms)`);
@@ -413,7 +619,7 @@ class ProjectIndex {
      * Index a single file
      */
     indexFile(filePath) {
-        const stat = fs.statSync(filePath);
+        const stat = this._fileStat(filePath);
         const existing = this.files.get(filePath);
 
         // Fast path: skip read entirely when mtime+size both match
@@ -421,7 +627,7 @@ class ProjectIndex {
             return false;
         }
 
-        const content = fs.readFileSync(filePath, 'utf-8');
+        const content = this._overlays?.get(filePath) ?? fs.readFileSync(filePath, 'utf-8');
         const hash = crypto.createHash('md5').update(content).digest('hex');
 
         // Content-based skip: mtime changed but content didn't (touch, git checkout)
@@ -478,11 +684,9 @@ class ProjectIndex {
         })();
 
         // Detect auto-generated files (e.g., Go client-gen, protobuf, code generators).
-        // Check first ~500 chars for common markers. These files are indexed but
-        // deprioritized in resolveSymbol() scoring.
-        const isGenerated = /^\/\/\s*Code generated\b|^\/\/\s*DO NOT EDIT|^\/\/ @generated|^# Generated by/m.test(
-            content.slice(0, 500)
-        );
+        // Check first ~500 chars for common markers (core/generated.js). These files
+        // are indexed but deprioritized in resolveSymbol() scoring.
+        const isGenerated = hasGeneratedHeader(content);
 
         const fileEntry = {
             path: filePath,
@@ -523,6 +727,14 @@ class ProjectIndex {
             // `global name`). The import-binding name-chase treats these as
             // undetermined — a dead-end verdict would be unsound.
             ...(parsed.moduleAssignedNames && { moduleAssignedNames: parsed.moduleAssignedNames }),
+            // Library part files (Dart `part 'x.dart'`): compiled INTO this
+            // file's library — graph-build stitches them to its importers.
+            ...(parsed.partFiles && { partFiles: parsed.partFiles }),
+            // React code-splitting imports (`lazy(() => import('./Page'))`,
+            // route `lazy:`): the framework consumes the target's exports.
+            ...(imports.some(i => i.lazy) && {
+                lazyImports: imports.filter(i => i.lazy).map(i => ({ module: i.module, kind: i.lazy, line: i.line }))
+            }),
             ...(isBundled && { isBundled: true }),
             ...(isGenerated && { isGenerated: true })
         };
@@ -547,6 +759,7 @@ class ProjectIndex {
                 ...(item.isGenerator && { isGenerator: true }),
                 modifiers: item.modifiers,
                 docstring: item.docstring,
+                ...(item.deprecated != null && { deprecated: item.deprecated }),
                 bindingId: `${fileEntry.relativePath}:${type}:${item.startLine}`,
                 ...(item.generics && { generics: item.generics }),
                 ...(item.extends && { extends: item.extends }),
@@ -559,6 +772,8 @@ class ProjectIndex {
                 ...(item.className && { className: item.className }),
                 ...(item.memberType && { memberType: item.memberType }),
                 ...(item.fieldType && { fieldType: item.fieldType }),
+                ...(item.fieldTag && { fieldTag: item.fieldTag }),
+                ...(item.embedded && { embedded: true }),
                 ...(item.aliasOf && { aliasOf: item.aliasOf }),
                 ...(item.derefTarget && { derefTarget: item.derefTarget }),
                 ...(item.decorators && item.decorators.length > 0 && { decorators: item.decorators }),
@@ -579,7 +794,10 @@ class ProjectIndex {
                 ...(item.memberAssigned && { memberAssigned: true }),
                 ...(item.bodyScopedName && { bodyScopedName: true }),
                 ...(item.registryMember && { registryMember: true }),
-                ...(item.registryContainer && { registryContainer: item.registryContainer })
+                ...(item.registryContainer && { registryContainer: item.registryContainer }),
+                ...(item.isConst && { isConst: true }),
+                ...(item.constBlock && { constBlock: item.constBlock }),
+                ...(item.iota && { iota: true })
             };
             fileEntry.symbols.push(symbol);
             // Property-assignment defs (fix #269: Reply.prototype.serialize
@@ -664,6 +882,7 @@ class ProjectIndex {
         this._javaFileIndex = null;
         // Endpoints cache is project-wide; safest to clear on any file removal.
         this._endpointsCache = null;
+        this._linksCache = null;
     }
 
     /**
@@ -913,35 +1132,52 @@ class ProjectIndex {
     // QUERY METHODS
     // ========================================================================
 
+    /**
+     * Test of one --exclude/--include pattern, compiled once. Globs and
+     * `re:` regexes follow compilePathPattern. A plain word (patterns like
+     * 'test', 'mock', 'spec') must be bounded on BOTH sides by path
+     * separators (/, ., _, -) or start/end of string, with optional plural
+     * 's' suffix, case-insensitively.
+     * e.g. 'test' matches 'tests/', 'test_foo', '_test.', but NOT 'backtester' or 'contest'
+     * e.g. 'spec' matches 'spec/', 'file.spec.js', but NOT 'spectrum' or 'inspector'
+     * @param {string} pattern
+     * @returns {Function} (filePath) => boolean
+     */
+    _pathPatternTest(pattern) {
+        const key = isPathPattern(pattern) ? pattern : pattern.toLowerCase();
+        let test = this._excludeRegexCache?.get(key);
+        if (!test) {
+            test = compilePathPattern(pattern);
+            if (!test) {
+                const escaped = key.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+                const regex = new RegExp(`(^|[/._\\-])${escaped}s?([/._\\-]|$)`);
+                test = filePath => regex.test(filePath.toLowerCase());
+            }
+            if (!this._excludeRegexCache) this._excludeRegexCache = new Map();
+            this._excludeRegexCache.set(key, test);
+        }
+        return test;
+    }
+
     /**
      * Check if a file path matches filter criteria
      * @param {string} filePath - File path to check
-     * @param {object} filters - { exclude: string[], in: string }
+     * @param {object} filters - { exclude: string[], include: string[], in: string }
      * @returns {boolean} True if file passes filters
      */
     matchesFilters(filePath, filters = {}) {
-        // Check exclusions (patterns like 'test', 'mock', 'spec')
-        // Requires the pattern to be bounded on BOTH sides by path separators (/, ., _, -)
-        // or start/end of string, with optional plural 's' suffix.
-        // e.g. 'test' matches 'tests/', 'test_foo', '_test.', but NOT 'backtester' or 'contest'
-        // e.g. 'spec' matches 'spec/', 'file.spec.js', but NOT 'spectrum' or 'inspector'
         if (filters.exclude && filters.exclude.length > 0) {
-            const lowerPath = filePath.toLowerCase();
             for (const pattern of filters.exclude) {
-                const lowerPattern = pattern.toLowerCase();
-                let regex = this._excludeRegexCache?.get(lowerPattern);
-                if (!regex) {
-                    const escaped = lowerPattern.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
-                    regex = new RegExp(`(^|[/._\\-])${escaped}s?([/._\\-]|$)`);
-                    if (!this._excludeRegexCache) this._excludeRegexCache = new Map();
-                    this._excludeRegexCache.set(lowerPattern, regex);
-                }
-                if (regex.test(lowerPath)) {
-                    return false;
-                }
+                if (this._pathPatternTest(pattern)(filePath)) return false;
             }
         }
 
+        // --include: at least one pattern must match
+        if (filters.include && filters.include.length > 0 &&
+            !filters.include.some(pattern => this._pathPatternTest(pattern)(filePath))) {
+            return false;
+        }
+
         // Check inclusion (directory or file path)
         if (filters.in) {
             const inPattern = filters.in.replace(/\/$/, ''); // strip trailing slash
@@ -2062,6 +2298,9 @@ class ProjectIndex {
     /** Find dead code (unused functions/classes) */
     deadcode(options) { return deadcodeModule.deadcode(this, options); }
 
+    /** Cross-language link graph from .ucn.json "links" rules */
+    links() { return linksModule.computeLinks(this); }
+
     /**
      * Get dependency graph for a file
      * @param {string} filePath - Starting file
@@ -2070,6 +2309,13 @@ class ProjectIndex {
      */
     graph(filePath, options = {}) { return graphModule.graph(this, filePath, options); }
 
+    /**
+     * Symbol call graph around functions matching a name pattern
+     * @param {object} options - { pattern, file, in, exclude, includeTests, direction: 'callees' | 'callers' | 'both', maxDepth }
+     * @returns {object} - { nodes, edges } with reachability per node
+     */
+    symbolGraph(options = {}) { return graphModule.symbolGraph(this, options); }
+
     /**
      * Detect circular dependencies in the import graph.
      * Uses DFS with 3-color marking to find all cycles.
@@ -2216,6 +2462,15 @@ class ProjectIndex {
 
     /** Audit async/await: find calls that are likely missing an `await`. */
     auditAsync(options) { return analysisModule.auditAsync(this, options); }
+
+    /** Groups of near-duplicate functions (token-normalized, similarity-thresholded) */
+    findClones(options) { return clonesModule.findClones(this, options); }
+
+    /** String and number literals repeated verbatim across files */
+    findDuplicateLiterals(options) { return clonesModule.findDuplicateLiterals(this, options); }
+
+    /** Deprecated symbols, split into still-referenced (with sites) and unreferenced */
+    findDeprecated(options) { return deprecatedModule.findDeprecated(this, options); }
 }
 
 const { parseDiff } = require('./analysis');
@@ -25,7 +25,7 @@ const CANONICAL_COMMANDS = [
     // Refactoring
     'verify', 'plan', 'diffImpact', 'check',
     // Other
-    'typedef', 'stacktrace', 'api', 'stats', 'doctor', 'auditAsync', 'orient',
+    'typedef', 'stacktrace', 'api', 'stats', 'doctor', 'auditAsync', 'orient', 'clones', 'deprecated', 'rules', 'explain', 'why',
 ];
 
 // ============================================================================
@@ -77,7 +77,43 @@ const PARAM_MAP = {
     case_sensitive:    'caseSensitive',
     include_exported:  'includeExported',
     include_decorated: 'includeDecorated',
+    include_generated: 'includeGenerated',
+    generated_markers: 'generatedMarkers',
+    interface_methods: 'interfaceMethods',
+    unused_params:     'unusedParams',
+    unreachable_code:  'unreachableCode',
+    package_vars:      'packageVars',
+    unused_results:    'unusedResults',
+    orphan_files:      'orphanFiles',
+    build_variants:    'buildVariants',
+    test_only:         'testOnly',
+    test_helpers:      'testHelpers',
+    sentinel_errors:   'sentinelErrors',
+    library:           'library',
+    dependents:        'dependents',
+    embeds:            'embeds',
+    channels:          'channels',
+    config_knobs:      'configKnobs',
+    satisfies_only:    'satisfiesOnly',
+    redundant_assertions: 'redundantAssertions',
+    type_params:       'typeParams',
+    init_effects:      'initEffects',
+    import_issues:     'importIssues',
+    config_keys:       'configKeys',
+    symbols:           'symbols',
+    complexity:        'complexity',
+    max_cyclomatic:    'maxCyclomatic',
+    max_cognitive:     'maxCognitive',
+    size:              'size',
+    max_function_lines: 'maxFunctionLines',
+    max_statements:    'maxStatements',
+    max_methods:       'maxMethods',
+    max_fields:        'maxFields',
     min_confidence:    'minConfidence',
+    min_lines:         'minLines',
+    min_tokens:        'minTokens',
+    min_files:         'minFiles',
+    literals:          'literals',
     show_confidence:   'showConfidence',
     hide_confidence:   'hideConfidence',
     calls_only:        'callsOnly',
@@ -135,7 +171,7 @@ const FLAG_APPLICABILITY = {
     search:       ['term', 'file', 'exclude', 'includeTests', 'top', 'limit', 'codeOnly', 'caseSensitive', 'context', 'regex', 'in', 'type', 'param', 'receiver', 'returns', 'decorator', 'exported', 'unused'],
     tests:        ['name', 'file', 'exclude', 'className', 'callsOnly'],
     affectedTests:['name', 'file', 'exclude', 'className', 'line', 'includeMethods', 'includeUncertain', 'depth', 'minConfidence'],
-    deadcode:     ['file', 'exclude', 'includeTests', 'includeExported', 'includeDecorated', 'limit', 'in'],
+    deadcode:     ['file', 'exclude', 'includeTests', 'includeExported', 'includeDecorated', 'interfaceMethods', 'unusedParams', 'unreachableCode', 'packageVars', 'types', 'unusedResults', 'orphanFiles', 'buildVariants', 'platforms', 'testOnly', 'testHelpers', 'sentinelErrors', 'library', 'dependents', 'embeds', 'channels', 'configKnobs', 'satisfiesOnly', 'redundantAssertions', 'typeParams', 'initEffects', 'importIssues', 'configKeys', 'complexity', 'maxCyclomatic', 'maxCognitive', 'size', 'maxFunctionLines', 'maxStatements', 'maxMethods', 'maxFields', 'includeGenerated', 'generatedMarkers', 'top', 'limit', 'in'],
     entrypoints:  ['file', 'exclude', 'includeTests', 'excludeTests', 'limit', 'type', 'framework'],
     endpoints:    ['file', 'exclude', 'limit', 'framework', 'bridge', 'serverOnly', 'clientOnly', 'unmatched', 'method', 'prefix', 'hideUncertain'],
     // Extracting code
@@ -147,7 +183,9 @@ const FLAG_APPLICABILITY = {
     imports:      ['file'],
     exporters:    ['file'],
     fileExports:  ['file'],
-    graph:        ['file', 'depth', 'direction', 'all'],
+    // symbols switches graph from the file import tree to the symbol call
+    // graph: name is then a glob over symbol names, file/in/exclude scope it.
+    graph:        ['file', 'depth', 'direction', 'all', 'symbols', 'name', 'in', 'exclude', 'includeTests'],
     circularDeps: ['file', 'exclude'],
     // Refactoring
     // verify runs the tiered caller contract (v4): includeMethods/
@@ -164,7 +202,14 @@ const FLAG_APPLICABILITY = {
     stats:        ['functions', 'hot', 'top'],
     doctor:       ['file', 'in', 'deep'],
     orient:       ['top'],
-    auditAsync:   ['file', 'exclude', 'limit'],
+    auditAsync:   ['file', 'exclude', 'limit', 'includeGenerated', 'generatedMarkers'],
+    clones:       ['file', 'exclude', 'in', 'includeTests', 'limit', 'minLines', 'minTokens', 'similarity', 'literals', 'minFiles', 'includeGenerated', 'generatedMarkers'],
+    deprecated:   ['file', 'exclude', 'in', 'includeTests', 'includeGenerated', 'generatedMarkers'],
+    rules:        [],
+    // explain re-runs the finding commands to find a fingerprint: the run's
+    // own flags pick the deadcode mode (or clones --literals) to look in.
+    explain:      ['name', 'depth', 'file', 'exclude', 'in', 'includeTests', 'includeExported', 'includeDecorated', 'includeGenerated', 'generatedMarkers', 'interfaceMethods', 'unusedParams', 'unreachableCode', 'packageVars', 'types', 'unusedResults', 'orphanFiles', 'buildVariants', 'platforms', 'testOnly', 'testHelpers', 'sentinelErrors', 'library', 'dependents', 'embeds', 'channels', 'configKnobs', 'satisfiesOnly', 'redundantAssertions', 'typeParams', 'initEffects', 'importIssues', 'configKeys', 'complexity', 'maxCyclomatic', 'maxCognitive', 'size', 'maxFunctionLines', 'maxStatements', 'maxMethods', 'maxFields', 'literals', 'minLines', 'minTokens', 'similarity', 'minFiles'],
+    why:          ['name', 'file', 'className', 'line'],
 };
 
 // Commands whose output is project-wide — truncation means you need a filter, not more text.
@@ -172,7 +217,7 @@ const FLAG_APPLICABILITY = {
 const BROAD_COMMANDS = new Set([
     'toc', 'entrypoints', 'endpoints', 'diffImpact', 'affectedTests',
     'deadcode', 'usages', 'reverseTrace', 'circularDeps',
-    'doctor', 'check', 'auditAsync', 'orient',
+    'doctor', 'check', 'auditAsync', 'orient', 'clones', 'deprecated',
 ]);
 
 // Commands that can operate on a single file without a project index.
@@ -0,0 +1,132 @@
+/**
+ * core/report/azure.js — Findings as Azure DevOps pull request comments
+ * (--report=azure-devops)
+ *
+ * A comment thread per finding on its line of the pull request. A thread
+ * records its finding's key (the line-free fingerprint) in its
+ * properties, so a later run skips findings that already have a thread,
+ * whatever its status (a thread closed as "won't fix" stays closed), and
+ * marks the active threads of findings that went away as fixed. At most
+ * 100 new threads go up per run.
+ *
+ * Credentials: SYSTEM_ACCESSTOKEN (the pipeline's token, mapped into the
+ * step's env), or UCN_AZURE_TOKEN, a personal access token with Code
+ * (read and write). The pull request and repository are the ones Azure
+ * Pipelines sets (SYSTEM_COLLECTIONURI, SYSTEM_TEAMPROJECT,
+ * BUILD_REPOSITORY_ID, SYSTEM_PULLREQUEST_PULLREQUESTID).
+ */
+
+'use strict';
+
+const { findingsOf, RULES } = require('../output/sarif');
+const { toCliName } = require('../registry');
+const { requestJson } = require('./http');
+const { repoPrefix, findingKeys } = require('./repo');
+
+const MAX_THREADS = 100;
+const API_VERSION = '7.1';
+const KEY_PROPERTY = 'ucn.finding';
+const COMMAND_PROPERTY = 'ucn.command';
+
+/** Authorization header from the environment's credentials */
+function authorization(env) {
+    if (env.UCN_AZURE_TOKEN) return `Basic ${Buffer.from(`:${env.UCN_AZURE_TOKEN}`).toString('base64')}`;
+    if (env.SYSTEM_ACCESSTOKEN) return `Bearer ${env.SYSTEM_ACCESSTOKEN}`;
+    throw new Error('Set UCN_AZURE_TOKEN to a personal access token, or map SYSTEM_ACCESSTOKEN into the step\'s env.');
+}
+
+/** A string thread property, as the API writes it */
+function property(value) {
+    return { $type: 'System.String', $value: value };
+}
+
+/**
+ * The comment threads of a command's findings, by finding key.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result
+ * @param {object} options - { rules, prefix (the project root's path in
+ *   the repository, with a trailing slash, or '') }
+ * @returns {Map<string, object>}
+ */
+function findingThreads(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)];
+    const keys = findingKeys(findings);
+    const threads = new Map();
+    findings.forEach((f, i) => {
+        const line = f.at.startLine || 1;
+        threads.set(keys[i], {
+            comments: [{
+                parentCommentId: 0,
+                commentType: 1,
+                content: `**${RULES[f.rule][0]}** (\`${f.rule}\`, ${f.severity}): ${f.message}\n\n` +
+                    `_ucn ${toCliName(command)}_ · \`ucn explain ${f.fingerprint}\` shows why`,
+            }],
+            status: 'active',
+            threadContext: {
+                filePath: '/' + (options.prefix || '') + f.at.file.replace(/\\/g, '/'),
+                rightFileStart: { line, offset: 1 },
+                rightFileEnd: { line, offset: 1 },
+            },
+            properties: { [KEY_PROPERTY]: property(keys[i]), [COMMAND_PROPERTY]: property(command) },
+        });
+    });
+    return threads;
+}
+
+/**
+ * Post the findings as pull request threads.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result
+ * @param {object} options - { root, rules, env, fetch }
+ * @returns {Promise<string>} What was posted
+ */
+async function post(command, result, options) {
+    const { env, root } = options;
+    const headers = { Authorization: authorization(env), Accept: 'application/json' };
+    const collection = env.SYSTEM_COLLECTIONURI;
+    const project = env.SYSTEM_TEAMPROJECT;
+    const repo = env.BUILD_REPOSITORY_ID;
+    if (!collection || !project || !repo) {
+        throw new Error('Set SYSTEM_COLLECTIONURI, SYSTEM_TEAMPROJECT and BUILD_REPOSITORY_ID (Azure Pipelines sets them).');
+    }
+    const pr = env.SYSTEM_PULLREQUEST_PULLREQUESTID;
+    if (!pr) throw new Error('Not a pull request build: SYSTEM_PULLREQUEST_PULLREQUESTID is not set.');
+
+    const base = `${collection.replace(/\/?$/, '/')}${encodeURIComponent(project)}/_apis/git/repositories/${encodeURIComponent(repo)}/pullRequests/${pr}/threads`;
+    const threads = findingThreads(command, result, { ...options, prefix: repoPrefix(root) });
+    const existing = (await requestJson(options.fetch, 'GET', `${base}?api-version=${API_VERSION}`, { headers }))?.value || [];
+    const known = new Set();
+    let fixed = 0;
+    for (const thread of existing) {
+        const key = thread.properties?.[KEY_PROPERTY]?.$value;
+        if (!key || thread.properties?.[COMMAND_PROPERTY]?.$value !== command) continue;
+        known.add(key);
+        if (!threads.has(key) && thread.status === 'active') {
+            await requestJson(options.fetch, 'PATCH', `${base}/${thread.id}?api-version=${API_VERSION}`, { headers, body: { status: 'fixed' } });
+            fixed++;
+        }
+    }
+    const fresh = [...threads].filter(([key]) => !known.has(key));
+    // A file the pull request doesn't touch can refuse a thread; the
+    // others still go up
+    let posted = 0;
+    let firstError = null;
+    for (const [, thread] of fresh.slice(0, MAX_THREADS)) {
+        try {
+            await requestJson(options.fetch, 'POST', `${base}?api-version=${API_VERSION}`, { headers, body: thread });
+            posted++;
+        } catch (e) {
+            firstError = firstError || e;
+        }
+    }
+    if (firstError && posted === 0) throw firstError;
+    const tried = Math.min(fresh.length, MAX_THREADS);
+    const notes = [
+        ...(fresh.length > MAX_THREADS ? [`${fresh.length - MAX_THREADS} over the limit not posted`] : []),
+        ...(tried > posted ? [`${tried - posted} refused (first: ${firstError.message})`] : []),
+    ];
+    return `Posted ${posted} new comment thread(s) on pull request ${pr}, ${threads.size - fresh.length} already there, ${fixed} marked fixed` +
+        (notes.length ? `; ${notes.join('; ')}` : '');
+}
+
+module.exports = { post, findingThreads };
@@ -0,0 +1,110 @@
+/**
+ * core/report/bitbucket.js — Findings as a Bitbucket Cloud Code Insights
+ * report (--report=bitbucket)
+ *
+ * One report per command ("ucn-deadcode") on the commit, with the finding
+ * count and a passed or failed result, and an annotation per finding,
+ * which Bitbucket shows on the pull request's diff and in its Reports
+ * panel. The report is deleted and written again on each run, so fixed
+ * findings don't linger; annotations go 100 to a request, at most 1000.
+ *
+ * Credentials: UCN_BITBUCKET_TOKEN (a repository or workspace access
+ * token), or BITBUCKET_USERNAME and BITBUCKET_APP_PASSWORD. The
+ * repository and commit are the ones Pipelines sets (BITBUCKET_WORKSPACE,
+ * BITBUCKET_REPO_SLUG, BITBUCKET_COMMIT); outside Pipelines, set them.
+ */
+
+'use strict';
+
+const { findingsOf, RULES } = require('../output/sarif');
+const { toCliName } = require('../registry');
+const { requestJson } = require('./http');
+const { gitLine, repoPrefix, findingKeys } = require('./repo');
+
+const ANNOTATIONS_PER_REQUEST = 100;
+const MAX_ANNOTATIONS = 1000;
+const MAX_SUMMARY = 450;
+
+// Severity → annotation severity
+const SEVERITY = { error: 'HIGH', warning: 'MEDIUM', info: 'LOW' };
+
+/** Authorization header from the environment's credentials */
+function authorization(env) {
+    if (env.UCN_BITBUCKET_TOKEN) return `Bearer ${env.UCN_BITBUCKET_TOKEN}`;
+    if (env.BITBUCKET_USERNAME && env.BITBUCKET_APP_PASSWORD) {
+        return `Basic ${Buffer.from(`${env.BITBUCKET_USERNAME}:${env.BITBUCKET_APP_PASSWORD}`).toString('base64')}`;
+    }
+    throw new Error('Set UCN_BITBUCKET_TOKEN, or BITBUCKET_USERNAME and BITBUCKET_APP_PASSWORD.');
+}
+
+/**
+ * The report and its annotations.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result
+ * @param {object} options - { rules, failed, prefix (the project root's
+ *   path in the repository, with a trailing slash, or '') }
+ * @returns {{id: string, report: object, annotations: object[]}}
+ */
+function insightsReport(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)];
+    const keys = findingKeys(findings);
+    const annotations = findings.slice(0, MAX_ANNOTATIONS).map((f, i) => ({
+        external_id: keys[i],
+        annotation_type: 'CODE_SMELL',
+        severity: SEVERITY[f.severity] || 'MEDIUM',
+        path: (options.prefix || '') + f.at.file.replace(/\\/g, '/'),
+        line: f.at.startLine || 1,
+        summary: f.message.length > MAX_SUMMARY ? f.message.slice(0, MAX_SUMMARY - 3) + '...' : f.message,
+        details: `${RULES[f.rule][0]} (${f.rule}), ${f.confidence} confidence. Run "ucn explain ${f.fingerprint}" for the reasoning.`,
+    }));
+    const name = toCliName(command);
+    return {
+        id: `ucn-${name}`,
+        report: {
+            title: `ucn ${name}`,
+            details: findings.length > 0
+                ? `${findings.length} finding(s)${findings.length > MAX_ANNOTATIONS ? `, the first ${MAX_ANNOTATIONS} annotated` : ''}.`
+                : 'No findings.',
+            report_type: 'BUG',
+            reporter: 'ucn',
+            link: 'https://github.com/mleoca/ucn',
+            result: options.failed ? 'FAILED' : 'PASSED',
+            data: [{ title: 'Findings', type: 'NUMBER', value: findings.length }],
+        },
+        annotations,
+    };
+}
+
+/**
+ * Post the findings as a Code Insights report.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result
+ * @param {object} options - { root, rules, failed, env, fetch }
+ * @returns {Promise<string>} What was posted
+ */
+async function post(command, result, options) {
+    const { env, root } = options;
+    const headers = { Authorization: authorization(env), Accept: 'application/json' };
+    const workspace = env.BITBUCKET_WORKSPACE;
+    const slug = env.BITBUCKET_REPO_SLUG;
+    if (!workspace || !slug) throw new Error('Set BITBUCKET_WORKSPACE and BITBUCKET_REPO_SLUG (Pipelines sets both).');
+    const commit = env.BITBUCKET_COMMIT || gitLine(root, ['rev-parse', 'HEAD']);
+    if (!commit) throw new Error('No commit to report on: set BITBUCKET_COMMIT, or run in a git checkout.');
+
+    const { id, report, annotations } = insightsReport(command, result, { ...options, prefix: repoPrefix(root) });
+    const api = (env.BITBUCKET_API_URL || 'https://api.bitbucket.org/2.0').replace(/\/$/, '');
+    const url = `${api}/repositories/${encodeURIComponent(workspace)}/${encodeURIComponent(slug)}/commit/${commit}/reports/${id}`;
+    // A rewritten report would keep the last run's annotations
+    try {
+        await requestJson(options.fetch, 'DELETE', url, { headers });
+    } catch (e) {
+        if (!/ failed with 404:/.test(e.message)) throw e;
+    }
+    await requestJson(options.fetch, 'PUT', url, { headers, body: report });
+    for (let i = 0; i < annotations.length; i += ANNOTATIONS_PER_REQUEST) {
+        await requestJson(options.fetch, 'POST', `${url}/annotations`, { headers, body: annotations.slice(i, i + ANNOTATIONS_PER_REQUEST) });
+    }
+    return `Posted Code Insights report "${report.title}" (${report.result.toLowerCase()}, ${annotations.length} annotation(s)) on ${commit.slice(0, 12)}`;
+}
+
+module.exports = { post, insightsReport };
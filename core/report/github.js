@@ -0,0 +1,148 @@
+/**
+ * core/report/github.js — Findings as a GitHub check run
+ * (--report=github-checks)
+ *
+ * Creates one completed check run per command ("ucn deadcode") on the
+ * head commit, with the Markdown summary as its output and a line
+ * annotation per finding. The checks API takes 50 annotations per
+ * request: the first 50 go with the run, the rest in updates to it.
+ *
+ * The token is a GitHub App installation token (UCN_GITHUB_TOKEN, or
+ * GITHUB_TOKEN as Actions provides it) with checks:write. The commit is
+ * the pull request's head, or the check run's or suite's when the Checks
+ * UI asked for a re-run (the check_run and check_suite "rerequested"
+ * events): the payload in GITHUB_EVENT_PATH names it. A re-run of
+ * another command's check posts nothing, so a workflow running several
+ * commands re-runs just the one asked for.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const { findingsOf, RULES } = require('../output/sarif');
+const { formatMarkdown } = require('../output/markdown');
+const { toCliName } = require('../registry');
+const { requestJson } = require('./http');
+const { gitLine, repoPrefix } = require('./repo');
+
+const ANNOTATIONS_PER_REQUEST = 50;
+const MAX_ANNOTATIONS = 1000;
+const MAX_SUMMARY = 65535;
+
+// Severity → annotation level
+const ANNOTATION_LEVEL = { error: 'failure', warning: 'warning', info: 'notice' };
+
+/** The event payload of the workflow run (or webhook delivery), if any */
+function readEvent(env) {
+    if (!env.GITHUB_EVENT_PATH) return null;
+    try {
+        return JSON.parse(fs.readFileSync(env.GITHUB_EVENT_PATH, 'utf-8'));
+    } catch {
+        return null;
+    }
+}
+
+/**
+ * The commit to report on, and the name of the check a re-run asked for.
+ * @returns {{sha: string|null, rerun: string|null}}
+ */
+function target(env, event) {
+    const rerequested = event?.action === 'rerequested';
+    if (env.GITHUB_EVENT_NAME === 'check_run' && rerequested) {
+        return { sha: event.check_run?.head_sha || null, rerun: event.check_run?.name || null };
+    }
+    if (env.GITHUB_EVENT_NAME === 'check_suite' && rerequested) {
+        return { sha: event.check_suite?.head_sha || null, rerun: null };
+    }
+    if (event?.pull_request?.head?.sha) return { sha: event.pull_request.head.sha, rerun: null };
+    return { sha: env.GITHUB_SHA || null, rerun: null };
+}
+
+/** Annotation of a finding; paths are relative to the repository */
+function annotationOf(f, prefix) {
+    const start = f.at.startLine || 1;
+    return {
+        path: prefix + f.at.file.replace(/\\/g, '/'),
+        start_line: start,
+        end_line: Math.max(f.at.endLine || start, start),
+        annotation_level: ANNOTATION_LEVEL[f.severity] || 'warning',
+        title: `${RULES[f.rule][0]}${f.at.name ? `: ${f.at.name}` : ''}`,
+        message: f.message,
+        raw_details: `rule: ${f.rule}\nconfidence: ${f.confidence}\nfingerprint: ${f.fingerprint}`,
+    };
+}
+
+/**
+ * The check run's name, conclusion and output.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result
+ * @param {object} options - { rules, failed, prefix (the project root's
+ *   path in the repository, with a trailing slash, or '') }
+ * @returns {{name, conclusion, title, summary, annotations: object[], total: number}}
+ */
+function checkRun(command, result, options = {}) {
+    const findings = [...findingsOf(command, result, options.rules)];
+    const annotations = findings.slice(0, MAX_ANNOTATIONS).map(f => annotationOf(f, options.prefix || ''));
+    let summary = formatMarkdown(command, result, { rules: options.rules });
+    if (findings.length > MAX_ANNOTATIONS) {
+        summary += `\n\nThe first ${MAX_ANNOTATIONS} of ${findings.length} findings are annotated.`;
+    }
+    if (summary.length > MAX_SUMMARY) {
+        const note = '\n\n(Summary truncated; run ucn locally for the full report.)';
+        summary = summary.slice(0, MAX_SUMMARY - note.length) + note;
+    }
+    return {
+        name: `ucn ${toCliName(command)}`,
+        conclusion: options.failed ? 'failure' : findings.length > 0 ? 'neutral' : 'success',
+        title: findings.length > 0 ? `${findings.length} finding(s)` : 'No findings',
+        summary,
+        annotations,
+        total: findings.length,
+    };
+}
+
+/**
+ * Post the findings as a check run.
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result
+ * @param {object} options - { root, rules, failed, env, fetch }
+ * @returns {Promise<string>} What was posted
+ */
+async function post(command, result, options) {
+    const { env, root } = options;
+    const token = env.UCN_GITHUB_TOKEN || env.GITHUB_TOKEN;
+    if (!token) throw new Error('Set UCN_GITHUB_TOKEN (or GITHUB_TOKEN) to a GitHub App installation token with checks:write.');
+    const repo = env.GITHUB_REPOSITORY;
+    if (!repo || !repo.includes('/')) throw new Error('Set GITHUB_REPOSITORY to owner/repo.');
+    const { sha: eventSha, rerun } = target(env, readEvent(env));
+    const run = checkRun(command, result, { ...options, prefix: repoPrefix(root) });
+    if (rerun && rerun !== run.name) return `Re-run requested for "${rerun}", not "${run.name}": nothing posted.`;
+    const sha = eventSha || gitLine(root, ['rev-parse', 'HEAD']);
+    if (!sha) throw new Error('No commit to report on: set GITHUB_SHA, or run in a git checkout.');
+
+    const api = (env.GITHUB_API_URL || 'https://api.github.com').replace(/\/$/, '');
+    const headers = { Authorization: `Bearer ${token}`, Accept: 'application/vnd.github+json', 'X-GitHub-Api-Version': '2022-11-28' };
+    const output = annotations => ({ title: run.title, summary: run.summary, annotations });
+    const batches = [];
+    for (let i = 0; i < run.annotations.length; i += ANNOTATIONS_PER_REQUEST) {
+        batches.push(run.annotations.slice(i, i + ANNOTATIONS_PER_REQUEST));
+    }
+    const created = await requestJson(options.fetch, 'POST', `${api}/repos/${repo}/check-runs`, {
+        headers,
+        body: {
+            name: run.name,
+            head_sha: sha,
+            status: 'completed',
+            conclusion: run.conclusion,
+            completed_at: new Date().toISOString(),
+            output: output(batches[0] || []),
+        },
+    });
+    // Annotations sent in updates add to the run's
+    for (const batch of batches.slice(1)) {
+        await requestJson(options.fetch, 'PATCH', `${api}/repos/${repo}/check-runs/${created.id}`, { headers, body: { output: output(batch) } });
+    }
+    return `Posted check run "${run.name}" (${run.conclusion}, ${run.annotations.length} annotation(s)) on ${sha.slice(0, 12)}${created.html_url ? `: ${created.html_url}` : ''}`;
+}
+
+module.exports = { post, checkRun, target };
@@ -0,0 +1,32 @@
+/**
+ * core/report/http.js — JSON requests to the platforms' REST APIs
+ */
+
+'use strict';
+
+/**
+ * Send a JSON request; the parsed response body, or an error naming the
+ * status and the API's own message.
+ * @param {Function} fetchFn - fetch (injectable for tests)
+ * @param {string} method - HTTP method
+ * @param {string} url - Full URL
+ * @param {object} [options] - { headers, body (serialized as JSON) }
+ * @returns {Promise<object|null>}
+ */
+async function requestJson(fetchFn, method, url, options = {}) {
+    const res = await fetchFn(url, {
+        method,
+        headers: { 'Content-Type': 'application/json', 'User-Agent': 'ucn', ...options.headers },
+        ...(options.body !== undefined && { body: JSON.stringify(options.body) }),
+    });
+    const text = await res.text();
+    let body = null;
+    try { body = text ? JSON.parse(text) : null; } catch { /* not JSON: the text is the message */ }
+    if (!res.ok) {
+        const message = body?.message || body?.error?.message || body?.error || text.trim().slice(0, 200) || res.statusText;
+        throw new Error(`${method} ${url} failed with ${res.status}: ${typeof message === 'string' ? message : JSON.stringify(message)}`);
+    }
+    return body;
+}
+
+module.exports = { requestJson };
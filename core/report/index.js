@@ -0,0 +1,34 @@
+/**
+ * core/report/index.js — Post a finding command's findings to a
+ * code-review platform (--report=<name>)
+ *
+ * Each reporter reads its credentials and target from the environment
+ * (the variables its CI sets, or UCN_ ones), posts the findings the run
+ * shows (after suppressions, --baseline and ucn diff), and resolves with
+ * a line saying what it posted.
+ */
+
+'use strict';
+
+const REPORTERS = {
+    'github-checks': () => require('./github'),
+    bitbucket: () => require('./bitbucket'),
+    'azure-devops': () => require('./azure'),
+};
+
+/**
+ * Post findings with a reporter.
+ * @param {string} name - Reporter name (a REPORTERS key)
+ * @param {string} command - Canonical finding command
+ * @param {*} result - The command's result
+ * @param {object} options - { root, rules, failed (the fail policy
+ *   tripped), env, fetch }
+ * @returns {Promise<string>} What was posted
+ */
+async function postReport(name, command, result, options) {
+    const reporter = REPORTERS[name];
+    if (!reporter) throw new Error(`Unknown reporter "${name}" (${Object.keys(REPORTERS).join(', ')})`);
+    return reporter().post(command, result, { env: process.env, fetch: globalThis.fetch, ...options });
+}
+
+module.exports = { postReport, REPORTER_NAMES: Object.keys(REPORTERS) };
@@ -0,0 +1,42 @@
+/**
+ * core/report/repo.js — Where the findings sit in the repository, and
+ * keys the platforms can match them by across runs
+ */
+
+'use strict';
+
+const { execFileSync } = require('child_process');
+
+/** Git output in a directory, or null outside a repository */
+function gitLine(root, args) {
+    try {
+        return execFileSync('git', args, { cwd: root, encoding: 'utf-8', stdio: ['ignore', 'pipe', 'ignore'] }).trim();
+    } catch {
+        return null;
+    }
+}
+
+/**
+ * The project root's path in its repository, with a trailing slash ('' at
+ * the top or outside git); the platforms want repository paths.
+ */
+function repoPrefix(root) {
+    return gitLine(root, ['rev-parse', '--show-prefix']) || '';
+}
+
+/**
+ * One key per finding, kept across runs: the line-free fingerprint, with
+ * an occurrence number when two findings share one.
+ * @param {object[]} findings - As findingsOf yields them
+ * @returns {string[]}
+ */
+function findingKeys(findings) {
+    const seen = new Map();
+    return findings.map((f) => {
+        const n = (seen.get(f.fingerprint) || 0) + 1;
+        seen.set(f.fingerprint, n);
+        return n > 1 ? `${f.fingerprint}-${n}` : f.fingerprint;
+    });
+}
+
+module.exports = { gitLine, repoPrefix, findingKeys };
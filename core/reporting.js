@@ -441,7 +441,7 @@ function getToc(index, options = {}) {
             s.type === 'classmethod'
         );
         const classes = fileEntry.symbols.filter(s =>
-            ['class', 'interface', 'type', 'enum', 'struct', 'trait', 'impl', 'record', 'namespace'].includes(s.type)
+            ['class', 'interface', 'type', 'enum', 'struct', 'trait', 'impl', 'record', 'namespace', 'table', 'view'].includes(s.type)
         );
         const state = fileEntry.symbols.filter(s => s.type === 'state');
 
@@ -664,6 +664,14 @@ function doctor(index, options = {}) {
     if (blindSpots.evalCalls.count > 0) blindSignals.push(`${blindSpots.evalCalls.count} eval/exec use(s) in ${blindSpots.evalCalls.fileCount} file(s)`);
     if (blindSpots.reflection.count > 0) blindSignals.push(`${blindSpots.reflection.count} reflection use(s) in ${blindSpots.reflection.fileCount} file(s)`);
     if (blindSpots.dynamicImports.count > 0) blindSignals.push(`${blindSpots.dynamicImports.count} dynamic import(s) in ${blindSpots.dynamicImports.fileCount} file(s)`);
+    for (const g of index.grammars || []) {
+        if (g.error) blindSignals.push(`grammar "${g.language}" ${g.error}`);
+    }
+    for (const error of index.configErrors?.() || []) blindSignals.push(`config ${error} (ignored)`);
+    for (const rule of index.links().rules) {
+        if (rule.error) blindSignals.push(`link rule "${rule.name}" ${rule.error}`);
+        else if (rule.links === 0) blindSignals.push(`link rule "${rule.name}" links nothing`);
+    }
 
     // Trust is task-specific. A healthy index can be excellent for navigation
     // while still requiring review before a breaking refactor or deletion.
@@ -0,0 +1,147 @@
+/**
+ * core/results-db.js — SQLite export of a finding command's run
+ * (--format sql, --sqlite=<file>)
+ *
+ * One run writes a row to `runs` and, keyed by its id, every finding,
+ * every indexed symbol, and every confirmed call edge between them, so a
+ * team can query dead code with plain SQL and keep runs side by side to
+ * track it over time. Tables are created if missing, so pointing
+ * successive runs at the same file appends to its history.
+ *
+ * The export is one SQL script: `--format sql` prints it for the sqlite3
+ * shell, and `--sqlite=<file>` runs it through node:sqlite (Node 22.5+).
+ */
+
+'use strict';
+
+const { findingsOf } = require('./output/sarif');
+const { NON_CALLABLE_TYPES } = require('./shared');
+
+const SCHEMA = `
+CREATE TABLE IF NOT EXISTS runs (
+    id INTEGER PRIMARY KEY,
+    command TEXT NOT NULL,       -- deadcode, clones, auditAsync, deprecated
+    root TEXT NOT NULL,          -- project root
+    ucn_version TEXT NOT NULL,
+    created_at TEXT NOT NULL     -- ISO 8601, UTC
+);
+CREATE TABLE IF NOT EXISTS findings (
+    run_id INTEGER NOT NULL REFERENCES runs(id),
+    rule TEXT NOT NULL,          -- rule id, as in SARIF (dead-code, unused-param, ...)
+    level TEXT NOT NULL,         -- SARIF level of the severity: error | warning | note
+    severity TEXT NOT NULL,      -- error | warning | info
+    confidence TEXT NOT NULL,    -- high | medium | low
+    file TEXT NOT NULL,          -- relative to the project root
+    start_line INTEGER NOT NULL,
+    end_line INTEGER,
+    symbol TEXT,                 -- Class.name or name
+    message TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,   -- line-free; stable while code moves around the finding
+    data TEXT NOT NULL           -- the raw result entry, JSON
+);
+CREATE TABLE IF NOT EXISTS symbols (
+    run_id INTEGER NOT NULL REFERENCES runs(id),
+    id TEXT NOT NULL,            -- file:start_line, unique within a run
+    name TEXT NOT NULL,
+    class_name TEXT,
+    type TEXT NOT NULL,          -- function, method, class, struct, field, ...
+    file TEXT NOT NULL,
+    start_line INTEGER NOT NULL,
+    end_line INTEGER,
+    exported INTEGER NOT NULL    -- 1 when another package can reach it
+);
+CREATE TABLE IF NOT EXISTS refs (
+    run_id INTEGER NOT NULL REFERENCES runs(id),
+    caller TEXT NOT NULL,        -- symbols.id of the calling function
+    callee TEXT NOT NULL,        -- symbols.id of the called function
+    calls INTEGER NOT NULL       -- call sites in the caller
+);
+CREATE INDEX IF NOT EXISTS findings_run ON findings(run_id, rule);
+CREATE INDEX IF NOT EXISTS symbols_run ON symbols(run_id, id);
+CREATE INDEX IF NOT EXISTS refs_callee ON refs(run_id, callee);
+`.trim();
+
+/** SQL literal */
+function sql(value) {
+    if (value === undefined || value === null) return 'NULL';
+    if (typeof value === 'number') return Number.isFinite(value) ? String(value) : 'NULL';
+    if (typeof value === 'boolean') return value ? '1' : '0';
+    return `'${String(value).replace(/'/g, "''")}'`;
+}
+
+const RUN = '(SELECT max(id) FROM runs)';
+
+/**
+ * SQL script recording one run: its findings, the index's symbols and
+ * the confirmed call edges between them.
+ * @param {object} index - ProjectIndex instance
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as execute returns it
+ * @returns {string} SQL script, one transaction
+ */
+function resultsDbScript(index, command, result) {
+    const pkg = require('../package.json');
+    const { symbolIsExported } = require('./deadcode');
+    const out = [SCHEMA, 'BEGIN;'];
+    out.push(`INSERT INTO runs (command, root, ucn_version, created_at) VALUES (${sql(command)}, ${sql(index.root)}, ${sql(pkg.version)}, ${sql(new Date().toISOString())});`);
+
+    for (const f of findingsOf(command, result, file => index.configFor(file).rules)) {
+        out.push(`INSERT INTO findings VALUES (${RUN}, ${[f.rule, f.level, f.severity, f.confidence, f.at.file, f.at.startLine,
+            f.at.endLine ?? f.at.startLine, f.at.name, f.message, f.fingerprint, JSON.stringify(f.data)].map(sql).join(', ')});`);
+    }
+
+    index._beginOp();
+    try {
+        const idOf = (s) => {
+            const fileEntry = index.files.get(s.file);
+            return fileEntry ? `${fileEntry.relativePath}:${s.startLine}` : null;
+        };
+        const callables = [];
+        for (const [, symbols] of index.symbols) {
+            for (const s of symbols) {
+                const fileEntry = index.files.get(s.file);
+                if (!fileEntry || s.startLine == null) continue;
+                out.push(`INSERT INTO symbols VALUES (${RUN}, ${[idOf(s), s.name, s.className, s.type, fileEntry.relativePath,
+                    s.startLine, s.endLine, symbolIsExported(index, s, fileEntry)].map(sql).join(', ')});`);
+                if (!NON_CALLABLE_TYPES.has(s.type)) callables.push(s);
+            }
+        }
+        for (const s of callables) {
+            const caller = idOf(s);
+            for (const c of index.findCallees(s, { includeMethods: true })) {
+                const callee = c.file && c.startLine != null ? idOf(c) : null;
+                if (callee) out.push(`INSERT INTO refs VALUES (${RUN}, ${sql(caller)}, ${sql(callee)}, ${c.callCount || 1});`);
+            }
+        }
+    } finally {
+        index._endOp();
+    }
+    out.push('COMMIT;');
+    return out.join('\n');
+}
+
+/**
+ * Run the script against a SQLite file, creating it if needed.
+ * @param {string} file - Database path
+ * @param {string} script - From resultsDbScript
+ * @returns {string|null} Error message, or null on success
+ */
+function writeResultsDb(file, script) {
+    let sqlite;
+    try {
+        sqlite = require('node:sqlite');
+    } catch {
+        return `--sqlite needs Node 22.5 or later (node:sqlite); on this Node, pipe --format sql into the sqlite3 shell instead: ucn deadcode --format sql | sqlite3 ${file}`;
+    }
+    const db = new sqlite.DatabaseSync(file);
+    try {
+        db.exec(script);
+    } catch (e) {
+        return `Could not write ${file}: ${e.message}`;
+    } finally {
+        db.close();
+    }
+    return null;
+}
+
+module.exports = { resultsDbScript, writeResultsDb, RESULTS_DB_SCHEMA: SCHEMA };
@@ -762,7 +762,7 @@ function structuralSearch(index, options = {}) {
             // classes, and records are types too.
             const functionTypes = new Set(['function', 'constructor', 'method', 'arrow', 'static', 'classmethod', 'abstract', 'private', 'property']);
             const classTypes = new Set(['class', 'struct', 'interface', 'impl', 'trait', 'record', 'enum']);
-            const typeTypes = new Set(['type', 'enum', 'interface', 'trait', 'record', 'namespace']);
+            const typeTypes = new Set(['type', 'enum', 'interface', 'trait', 'record', 'namespace', 'table', 'view']);
             const methodTypes = new Set(['method', 'constructor']);
 
             for (const [symbolName, definitions] of index.symbols) {
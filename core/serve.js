@@ -0,0 +1,296 @@
+/**
+ * core/serve.js — HTTP/JSON API over a persistent index (ucn serve)
+ *
+ *   POST /analyze                  Re-index the files changed on disk and
+ *                                  re-run the finding commands
+ *   GET  /findings                 The last analysis' findings, filtered by
+ *                                  ?command= ?rule= ?severity= ?file= and
+ *                                  paged by ?limit= ?offset=
+ *   GET  /symbols/:id/references   Where a symbol is used; :id is a handle
+ *                                  (file:line:name, as find prints it) or a name
+ *   GET  /metrics                  The last analysis in Prometheus text
+ *                                  format, for scraping
+ *
+ * The index is built once and kept, so a dashboard or an internal tool
+ * gets answers without paying for a CLI run each time. With --grpc-port
+ * the same operations are also served over gRPC (core/grpc.js). Findings are the
+ * records --format jsonl prints, fingerprint included, so they match
+ * baselines and `ucn explain`. Every response but /metrics is JSON; an
+ * error is `{"error": "..."}` with a 4xx or 5xx status.
+ */
+
+'use strict';
+
+const http = require('http');
+const { execute, lookupByLocation } = require('./execute');
+const { findingsOf } = require('./output/sarif');
+const { formatPrometheusRuns } = require('./output/metrics');
+const { resolveCommand, toCliName } = require('./registry');
+const { looksLikeHandle, parseSymbolHandle, formatSymbolHandle } = require('./shared');
+
+const FINDING_COMMANDS = ['deadcode', 'clones', 'auditAsync', 'deprecated'];
+const MAX_BODY = 1024 * 1024;
+
+// A bad request: its HTTP status (the gRPC service maps it to a code)
+class ApiError extends Error {
+    constructor(status, message) {
+        super(message);
+        this.status = status;
+    }
+}
+
+/** The JSON body of a request ({} when empty) */
+function readBody(req) {
+    return new Promise((resolve, reject) => {
+        const chunks = [];
+        let size = 0;
+        req.on('data', (chunk) => {
+            size += chunk.length;
+            if (size > MAX_BODY) {
+                reject(new ApiError(413, `Request body over ${MAX_BODY} bytes`));
+                req.destroy();
+                return;
+            }
+            chunks.push(chunk);
+        });
+        req.on('end', () => {
+            const text = Buffer.concat(chunks).toString('utf-8').trim();
+            if (!text) return resolve({});
+            try {
+                const body = JSON.parse(text);
+                if (!body || typeof body !== 'object' || Array.isArray(body)) throw new Error('not an object');
+                resolve(body);
+            } catch (e) {
+                reject(new ApiError(400, `Request body is not a JSON object: ${e.message}`));
+            }
+        });
+        req.on('error', reject);
+    });
+}
+
+/** A finding as the API returns it */
+function findingRecord(command, f) {
+    return {
+        command: toCliName(command),
+        rule: f.rule,
+        severity: f.severity,
+        confidence: f.confidence,
+        message: f.message,
+        file: f.at.file,
+        line: f.at.startLine,
+        ...(f.at.endLine && { endLine: f.at.endLine }),
+        ...(f.at.name && { symbol: f.at.name }),
+        fingerprint: f.fingerprint,
+    };
+}
+
+/** Non-negative integer query parameter, or the default */
+function intParam(query, name, dflt) {
+    const raw = query.get(name);
+    if (raw === null) return dflt;
+    if (!/^\d+$/.test(raw)) throw new ApiError(400, `${name} must be a non-negative integer (got "${raw}")`);
+    return Number(raw);
+}
+
+/**
+ * The API's operations over a built index, apart from any transport;
+ * the HTTP server and the gRPC service (core/grpc.js) share one, and so
+ * share its last analysis.
+ * @param {object} index - Built ProjectIndex
+ * @param {object} [options]
+ * @param {object} [options.params] - Default params of the finding commands
+ *   (the CLI's flags); an analyze request's params add to them
+ * @param {object} [options.buildOptions] - Extra options for index.build
+ * @returns {{analyze: Function, findings: Function, metrics: Function, references: Function, index: object}}
+ *   Each throws ApiError on a bad request
+ */
+function createApi(index, options = {}) {
+    const { buildOptions = {} } = options;
+    const defaults = { ...options.params };
+    // { at, ms, results: [[command, result]] } of the last analysis
+    let analysis = null;
+
+    function run(commands, params) {
+        const start = Date.now();
+        index.build(null, { ...buildOptions, quiet: true, forceRebuild: true });
+        const results = [];
+        for (const command of commands) {
+            // audit-async takes no deadcode modes (as ucn watch runs it)
+            const p = command === 'auditAsync' ? { file: params.file, exclude: params.exclude } : params;
+            const { ok, result, error } = execute(index, command, p);
+            if (!ok) throw new ApiError(400, `${toCliName(command)}: ${error}`);
+            results.push([command, result]);
+        }
+        analysis = { at: new Date().toISOString(), ms: Date.now() - start, results };
+        return analysis;
+    }
+
+    /**
+     * Re-index what changed on disk and re-run finding commands.
+     * @param {object} request - { commands: CLI or canonical names (default
+     *   ['deadcode']), params: command params }
+     * @returns {{analyzedAt, ms, files, findings: object}} findings: count per command
+     */
+    function analyze(request = {}) {
+        const names = request.commands === undefined ? ['deadcode'] : request.commands;
+        if (!Array.isArray(names) || names.length === 0) throw new ApiError(400, '"commands" must be a non-empty array');
+        const commands = names.map((name) => {
+            const canonical = resolveCommand(String(name), 'cli');
+            if (!FINDING_COMMANDS.includes(canonical)) {
+                throw new ApiError(400, `"${name}" is not a finding command (deadcode, clones, audit-async, deprecated)`);
+            }
+            return canonical;
+        });
+        if (request.params !== undefined && (typeof request.params !== 'object' || Array.isArray(request.params) || !request.params)) {
+            throw new ApiError(400, '"params" must be an object');
+        }
+        const { at, ms, results } = run(commands, { ...defaults, ...request.params });
+        const counts = {};
+        for (const [command, result] of results) counts[toCliName(command)] = [...findingsOf(command, result)].length;
+        return { analyzedAt: at, ms, files: index.files.size, findings: counts };
+    }
+
+    /**
+     * The last analysis' findings (running deadcode first if none ran yet).
+     * @param {object} [query] - { command, rule, severity, file (substring),
+     *   limit, offset }; unset fields don't filter
+     * @returns {{analyzedAt, total, offset, findings: object[]}}
+     */
+    function findings(query = {}) {
+        if (!analysis) run(['deadcode'], defaults);
+        const { command, rule, severity, file, limit = Infinity, offset = 0 } = query;
+        const canonical = command ? resolveCommand(command, 'cli') : null;
+        if (command && !analysis.results.some(([c]) => c === canonical)) {
+            throw new ApiError(400, `The last analysis did not run "${command}" (analyze with "commands": ["${command}"])`);
+        }
+        const all = [];
+        for (const [c, result] of analysis.results) {
+            if (canonical && c !== canonical) continue;
+            for (const f of findingsOf(c, result, rel => index.configFor(rel).rules)) {
+                if (rule && f.rule !== rule) continue;
+                if (severity && f.severity !== severity) continue;
+                if (file && !f.at.file.includes(file)) continue;
+                all.push(findingRecord(c, f));
+            }
+        }
+        return { analyzedAt: analysis.at, total: all.length, offset, findings: all.slice(offset, offset + limit) };
+    }
+
+    /**
+     * The last analysis (running deadcode first if none ran yet) as
+     * Prometheus gauges, the ones --format prometheus writes.
+     * @returns {string} Exposition text
+     */
+    function metrics() {
+        if (!analysis) run(['deadcode'], defaults);
+        return formatPrometheusRuns(analysis.results, {
+            rules: rel => index.configFor(rel).rules,
+            durationSeconds: analysis.ms / 1000,
+            timestamp: Date.parse(analysis.at),
+        });
+    }
+
+    /**
+     * Where a symbol is used.
+     * @param {string} id - Symbol handle (file:line[:name]) or name
+     * @returns {{symbol: {id, name, type, file, line}, total, references: object[]}}
+     */
+    function references(id) {
+        if (!id) throw new ApiError(400, 'No symbol given');
+        const handle = looksLikeHandle(id) ? parseSymbolHandle(id) : null;
+        let def = null;
+        if (handle && !handle.name) {
+            def = lookupByLocation(index, handle.file, handle.line);
+        } else {
+            def = index.resolveSymbol(handle ? handle.name : id, handle ? { file: handle.file, line: handle.line } : {}).def;
+        }
+        if (!def) throw new ApiError(404, `Symbol "${id}" not found`);
+        const refs = index.usages(def.name, { className: def.className, codeOnly: true })
+            .filter(u => !u.isDefinition)
+            .map(u => ({
+                file: u.relativePath,
+                line: u.line,
+                type: u.usageType,
+                content: u.content.trim(),
+                ...(u.callerName && { caller: u.callerName }),
+            }));
+        return {
+            symbol: {
+                id: formatSymbolHandle(def),
+                name: def.className && !def.name.includes('.') ? `${def.className}.${def.name}` : def.name,
+                type: def.type,
+                file: def.relativePath,
+                line: def.startLine,
+            },
+            total: refs.length,
+            references: refs,
+        };
+    }
+
+    return { analyze, findings, metrics, references, index };
+}
+
+/**
+ * The HTTP server over an API, not yet listening.
+ * @param {object} api - createApi()
+ * @returns {http.Server}
+ */
+function createApiServer(api) {
+    const routes = {
+        async 'POST /analyze'(req) {
+            return api.analyze(await readBody(req));
+        },
+
+        'GET /findings'(req, query) {
+            const field = name => query.get(name) ?? undefined;
+            return api.findings({
+                command: field('command'),
+                rule: field('rule'),
+                severity: field('severity'),
+                file: field('file'),
+                limit: intParam(query, 'limit', Infinity),
+                offset: intParam(query, 'offset', 0),
+            });
+        },
+
+        'GET /metrics'() {
+            return api.metrics();
+        },
+    };
+
+    return http.createServer(async (req, res) => {
+        const reply = (status, body, headers = {}) => {
+            const text = JSON.stringify(body) + '\n';
+            res.writeHead(status, { 'Content-Type': 'application/json; charset=utf-8', 'Content-Length': Buffer.byteLength(text), ...headers });
+            res.end(text);
+        };
+        try {
+            const url = new URL(req.url, 'http://localhost');
+            const symbol = /^\/symbols\/(.+)\/references\/?$/.exec(url.pathname);
+            if (symbol) {
+                if (req.method !== 'GET') throw Object.assign(new ApiError(405, `Use GET for ${url.pathname}`), { allow: 'GET' });
+                let id;
+                try { id = decodeURIComponent(symbol[1]); } catch { throw new ApiError(400, `Malformed symbol id "${symbol[1]}"`); }
+                return reply(200, api.references(id));
+            }
+            const route = routes[`${req.method} ${url.pathname}`];
+            if (!route) {
+                const methods = Object.keys(routes).filter(r => r.endsWith(` ${url.pathname}`)).map(r => r.split(' ')[0]);
+                if (methods.length) throw Object.assign(new ApiError(405, `Use ${methods.join(' or ')} for ${url.pathname}`), { allow: methods.join(', ') });
+                throw new ApiError(404, `No route ${req.method} ${url.pathname}`);
+            }
+            const body = await route(req, url.searchParams);
+            if (typeof body === 'string') {
+                // Prometheus text (/metrics)
+                res.writeHead(200, { 'Content-Type': 'text/plain; version=0.0.4; charset=utf-8', 'Content-Length': Buffer.byteLength(body) });
+                return res.end(body);
+            }
+            reply(200, body);
+        } catch (e) {
+            const status = e instanceof ApiError ? e.status : 500;
+            reply(status, { error: e.message }, e.allow ? { Allow: e.allow } : {});
+        }
+    });
+}
+
+module.exports = { createApi, createApiServer, ApiError };
@@ -0,0 +1,138 @@
+/**
+ * core/size.js — Large-function and god-type findings (deadcode --size)
+ *
+ * A separate rule set from dead code: functions longer than a line or
+ * statement budget, and types carrying more methods or fields than a
+ * member budget. Statements are counted on the syntax tree (every
+ * `*_statement` / `*_declaration` node, nested blocks and closures
+ * included); members are the indexed symbols naming the type as their
+ * class or receiver, across the type's package.
+ *
+ * Limits come from options (`maxFunctionLines`, `maxStatements`,
+ * `maxMethods`, `maxFields`, applying to every language), then .ucn.json
+ * "size" with optional per-language overrides
+ * (`{ "functionLines": 80, "go": { "functionLines": 120 } }`), then the
+ * defaults below.
+ */
+
+'use strict';
+
+const { dirname } = require('path');
+const { getParser, safeParse } = require('../languages');
+const { isTestFile } = require('./discovery');
+const { codeUnitCompare, CALLABLE_SYMBOL_KINDS } = require('./shared');
+const { functionNodeFor, isFunctionNode } = require('./complexity');
+
+const DEFAULTS = { functionLines: 80, statements: 50, methods: 20, fields: 15 };
+const OPTION_OF = { functionLines: 'maxFunctionLines', statements: 'maxStatements', methods: 'maxMethods', fields: 'maxFields' };
+const TYPE_KINDS = new Set(['class', 'struct', 'interface', 'trait', 'record', 'enum']);
+const STATEMENT_NODE = /_(statement|declaration)$/;
+
+/** Limits for one language: option, then per-language config, then config, then default */
+function limitsFor(config, options, lang) {
+    const limits = {};
+    for (const key of Object.keys(DEFAULTS)) {
+        const option = options[OPTION_OF[key]];
+        const configured = config[lang]?.[key] ?? config[key];
+        limits[key] = option != null ? Number(option)
+            : typeof configured === 'number' ? configured : DEFAULTS[key];
+    }
+    return limits;
+}
+
+/** Statements inside a function node, nested ones included */
+function countStatements(fnNode) {
+    let count = 0;
+    const visit = (node) => {
+        if (node !== fnNode && STATEMENT_NODE.test(node.type) && !isFunctionNode(node)) count++;
+        for (let i = 0; i < node.namedChildCount; i++) visit(node.namedChild(i));
+    };
+    visit(fnNode);
+    return count;
+}
+
+/**
+ * Functions and types over their size limits.
+ * @param {object} index - ProjectIndex
+ * @param {object} options - { maxFunctionLines, maxStatements, maxMethods, maxFields,
+ *   file, exclude, in, includeTests }
+ * @returns {Array<{name, type, file, startLine, endLine, className?, over: Array<{metric, value, limit}>}>}
+ */
+function findOversized(index, options = {}) {
+    const findings = [];
+    // Keyed by language and the file's directory config (config files below the root may set their own)
+    const limitCache = new Map();
+    const limits = (lang, filePath) => {
+        const config = (index.configFor ? index.configFor(filePath) : index.config)?.size || {};
+        if (!limitCache.has(config)) limitCache.set(config, new Map());
+        const byLang = limitCache.get(config);
+        if (!byLang.has(lang)) byLang.set(lang, limitsFor(config, options, lang));
+        return byLang.get(lang);
+    };
+    const reported = (fileEntry) => {
+        const rel = fileEntry.relativePath;
+        return (options.includeTests || !isTestFile(rel, fileEntry.language)) &&
+            (!options.file || rel.includes(options.file)) &&
+            !(((options.exclude && options.exclude.length > 0) || options.in) &&
+                !index.matchesFilters(rel, { exclude: options.exclude, in: options.in }));
+    };
+    const over = (metric, value, limit) => (value > limit ? [{ metric, value, limit }] : []);
+
+    // Members per type, keyed by package directory and type name
+    const members = new Map();
+    for (const [filePath, fileEntry] of index.files) {
+        for (const s of fileEntry.symbols || []) {
+            if (!s.className) continue;
+            const kind = s.type === 'field' ? 'fields' : CALLABLE_SYMBOL_KINDS.has(s.type) ? 'methods' : null;
+            if (!kind) continue;
+            const key = `${dirname(filePath)}\0${s.className}`;
+            if (!members.has(key)) members.set(key, { methods: 0, fields: 0 });
+            members.get(key)[kind]++;
+        }
+    }
+
+    for (const [filePath, fileEntry] of index.files) {
+        if (!reported(fileEntry)) continue;
+        const lang = fileEntry.language;
+        const limit = limits(lang, filePath);
+        const symbols = fileEntry.symbols || [];
+        let tree;
+        const treeOf = () => {
+            if (tree !== undefined) return tree;
+            let content;
+            try { content = index._readFile(filePath); } catch { return (tree = null); }
+            tree = index._getParsedTree(filePath, content, lang);
+            if (!tree) {
+                try { tree = safeParse(getParser(lang), content); } catch { tree = null; }
+            }
+            return tree;
+        };
+        for (const s of symbols) {
+            let problems = [];
+            if (CALLABLE_SYMBOL_KINDS.has(s.type)) {
+                problems = over('lines', s.endLine - s.startLine + 1, limit.functionLines);
+                if (treeOf()) {
+                    const node = functionNodeFor(tree, s);
+                    if (node) problems.push(...over('statements', countStatements(node), limit.statements));
+                }
+            } else if (TYPE_KINDS.has(s.type)) {
+                const counts = members.get(`${dirname(filePath)}\0${s.name}`) || { methods: 0, fields: 0 };
+                problems = [...over('methods', counts.methods, limit.methods), ...over('fields', counts.fields, limit.fields)];
+            }
+            if (problems.length === 0) continue;
+            findings.push({
+                name: s.name,
+                type: s.type,
+                file: fileEntry.relativePath,
+                startLine: s.startLine,
+                endLine: s.endLine,
+                ...(s.className && { className: s.className }),
+                over: problems,
+            });
+        }
+    }
+    findings.sort((a, b) => codeUnitCompare(a.file, b.file) || a.startLine - b.startLine);
+    return findings;
+}
+
+module.exports = { findOversized };
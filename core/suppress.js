@@ -0,0 +1,228 @@
+/**
+ * core/suppress.js — Inline suppression comments (ucn:ignore)
+ *
+ * A comment `ucn:ignore[rule, ...]` on a declaration hides that
+ * declaration's findings of the listed rules; without a list it hides
+ * all of them. The comment goes on the declaration's line or on its own
+ * line above it (doc comments, decorators and attributes may sit in
+ * between), in the language's own comment syntax: `//ucn:ignore[dead-code]`,
+ * `# ucn: ignore[unused-param]`, `/* ucn:ignore *\/`. Rules are named by id
+ * or name, as in .ucn.json "rules"; text after the comment is the reason.
+ * A clone group or repeated literal is hidden by a comment at any copy.
+ * `ucn:ignore` in a string literal is no directive.
+ *
+ * Each run reports the comments bearing on the rules it checked: active
+ * ones with how many findings they hid, and stale ones that hid nothing
+ * (or name a rule that doesn't exist), so suppressions don't outlive
+ * what they hid. Only comments that list their rules can go stale, and
+ * since only the files with findings are read, stale comments show up
+ * in the files that still have some.
+ *
+ * .ucn.json "ignore" hides findings by fingerprint instead, for code a
+ * comment can't go in (generated, vendored, someone else's): entries are
+ * a fingerprint, or { fingerprint, rule, reason } as --format jsonl
+ * prints them. Fingerprints leave the line out, so an entry keeps hiding
+ * its finding as code moves. An entry naming its rule is stale when a
+ * run over the whole project, tests included, checked the rule and found
+ * nothing to hide.
+ */
+
+'use strict';
+
+const path = require('path');
+const { isTestFile } = require('./discovery');
+const { findingsOf, withoutFindings, RULES } = require('./output/sarif');
+const { langTraits } = require('../languages');
+
+// A comment's text after its opener (and any doc-comment markers: /** ///)
+const DIRECTIVE = /^[/*!#-]*\s*ucn:\s*ignore\b(?:\[([^\]]*)\])?(.*)$/;
+// Lines between a directive on its own line and the declaration it covers
+const PREAMBLE = /^\s*(\/\/|\/\*|\*|#|--|@|$)/;
+
+const RULE_BY_NAME = new Map(Object.entries(RULES).map(([id, [name]]) => [name.toLowerCase(), id]));
+
+/**
+ * Rules a run checked: those it found something for, and those its
+ * command always checks. A comment naming only rules the run didn't
+ * check (deadcode without --unused-params and ignore[unused-param]) is
+ * neither active nor stale for it.
+ */
+function checkedRules(command, result, findings) {
+    const always = {
+        deadcode: ['dead-code'],
+        clones: [result.literals ? 'duplicate-literal' : 'clone'],
+        auditAsync: ['missing-await'],
+        deprecated: ['deprecated-use', 'deprecated-unused'],
+    }[command];
+    return new Set([...always, ...findings.map(f => f.rule)]);
+}
+
+/**
+ * Where the comment on a line starts, or -1: the first comment opener
+ * outside a string literal, or the `*` of a block comment's inner line.
+ * @param {string} lineText
+ * @param {string[]} openers - The language's comment openers
+ * @returns {{at: number, text: string}|null} The comment's start and its
+ *   text after the opener
+ */
+function commentOf(lineText, openers) {
+    const lead = lineText.length - lineText.trimStart().length;
+    if (openers.includes('/*') && lineText[lead] === '*') return { at: lead, text: lineText.slice(lead + 1) };
+    let quote = null;
+    for (let i = 0; i < lineText.length; i++) {
+        const ch = lineText[i];
+        if (quote) {
+            if (ch === '\\') i++;
+            else if (ch === quote) quote = null;
+            continue;
+        }
+        if (ch === '"' || ch === "'" || ch === '`') {
+            quote = ch;
+            continue;
+        }
+        const opener = openers.find(o => lineText.startsWith(o, i));
+        if (opener) return { at: i, text: lineText.slice(i + opener.length) };
+    }
+    return null;
+}
+
+/**
+ * The ucn:ignore comments of one file. A directive counts only in a
+ * comment: `ucn:ignore` in a string literal is text.
+ * @param {string} file - Path relative to the project root
+ * @param {string} text - File contents
+ * @param {string} [language] - The file's language (its comment syntax;
+ *   // and /* *\/ when unknown)
+ * @returns {Array<{file, line, from, to, rules, unknownRules, reason}>}
+ *   Each covers findings starting on lines from..to; `rules` holds rule
+ *   ids, empty for all rules
+ */
+function parseSuppressions(file, text, language) {
+    if (!text.includes('ucn:')) return [];
+    const lineComment = langTraits(language)?.lineComment || '//';
+    const openers = lineComment === '//' ? ['//', '/*'] : [lineComment];
+    const lines = text.split('\n');
+    const out = [];
+    lines.forEach((lineText, i) => {
+        if (!lineText.includes('ucn:')) return;
+        const comment = commentOf(lineText, openers);
+        const m = comment && DIRECTIVE.exec(comment.text);
+        if (!m) return;
+        const line = i + 1;
+        let to = line;
+        if (lineText.slice(0, comment.at).trim() === '') {
+            // On its own line: covers the next declaration, past comments and decorators
+            to = line + 1;
+            while (to <= lines.length && PREAMBLE.test(lines[to - 1])) to++;
+        }
+        const rules = [];
+        const unknownRules = [];
+        for (const name of (m[1] || '').split(',').map(s => s.trim()).filter(Boolean)) {
+            const id = RULES[name] ? name : RULE_BY_NAME.get(name.toLowerCase());
+            if (id) rules.push(id);
+            else unknownRules.push(name);
+        }
+        const reason = m[2].replace(/(\*\)|\*\/).*$/, '').replace(/^\s*[-:—]?\s*/, '').trim();
+        out.push({ file, line, from: line, to, rules, unknownRules, reason });
+    });
+    return out;
+}
+
+/**
+ * Entries of .ucn.json "ignore", shaped like parsed comments.
+ * @param {Array<string|object>} [list] - Fingerprints, or { fingerprint, rule, reason }
+ * @returns {Array<{fingerprint, rules, unknownRules, reason, hidden}>}
+ */
+function configIgnores(list) {
+    if (!Array.isArray(list)) return [];
+    return list.map(e => (typeof e === 'string' ? { fingerprint: e } : e))
+        .filter(e => e && typeof e.fingerprint === 'string')
+        .map(e => {
+            const name = e.rule || e.ruleId;
+            const id = !name ? null : RULES[name] ? name : RULE_BY_NAME.get(String(name).toLowerCase());
+            return {
+                fingerprint: e.fingerprint.trim().toLowerCase(),
+                rules: id ? [id] : [],
+                unknownRules: name && !id ? [String(name)] : [],
+                reason: typeof e.reason === 'string' ? e.reason.trim() : '',
+                hidden: 0,
+            };
+        });
+}
+
+/**
+ * The command's result without the findings ucn:ignore comments hide,
+ * with a report of the comments that bear on this run.
+ * @param {object} index - ProjectIndex
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result, as its handler built it
+ * @param {object} [scope] - { file, in, exclude, include, includeTests } the run was limited to
+ * @returns {{result: *, suppressions: {active: object[], stale: object[]}|null}}
+ *   suppressions is null when no comment bears on the run
+ */
+function applySuppressions(index, command, result, scope = {}) {
+    const findings = [...findingsOf(command, result)];
+    const checked = checkedRules(command, result, findings);
+    // Only the files with findings can hold a comment that hides one
+    const files = new Set(findings.flatMap(f => [f.at, ...(f.related || [])].map(loc => loc.file)));
+    const byFile = new Map();
+    for (const rel of files) {
+        const fe = index.files.get(path.join(index.root, rel));
+        if (!fe) continue;
+        if (scope.file && !rel.includes(scope.file)) continue;
+        if (!index.matchesFilters(rel, { exclude: scope.exclude, in: scope.in })) continue;
+        let text;
+        try { text = index._readFile(fe.path); } catch { continue; }
+        const relevant = parseSuppressions(rel, text, fe.language).filter(s =>
+            s.unknownRules.length > 0 || s.rules.length === 0 || s.rules.some(r => checked.has(r)));
+        // A run that skips tests can't tell whether a test file's comments are stale
+        const quiet = !scope.includeTests && isTestFile(rel, fe.language);
+        for (const s of relevant) Object.assign(s, { hidden: 0, quiet });
+        if (relevant.length > 0) byFile.set(rel, relevant);
+    }
+    const ignored = configIgnores(index.config?.ignore);
+    if (byFile.size === 0 && ignored.length === 0) return { result, suppressions: null };
+
+    const drop = new Set();
+    for (const f of findings) {
+        for (const s of ignored) {
+            if (s.fingerprint !== f.fingerprint) continue;
+            if (s.rules.length > 0 ? !s.rules.includes(f.rule) : s.unknownRules.length > 0) continue;
+            s.hidden++;
+            drop.add(f.data);
+        }
+        const places = f.rule === 'clone' || f.rule === 'duplicate-literal' ? [f.at, ...(f.related || [])] : [f.at];
+        for (const loc of places) {
+            for (const s of byFile.get(loc.file) || []) {
+                if (loc.startLine < s.from || loc.startLine > s.to) continue;
+                // A list of only unknown rules hides nothing
+                if (s.rules.length > 0 ? !s.rules.includes(f.rule) : s.unknownRules.length > 0) continue;
+                s.hidden++;
+                drop.add(f.data);
+            }
+        }
+    }
+
+    const entry = (s) => ({
+        ...(s.fingerprint ? { fingerprint: s.fingerprint } : { file: s.file, line: s.line }),
+        rules: s.rules,
+        ...(s.unknownRules.length > 0 && { unknownRules: s.unknownRules }),
+        ...(s.reason && { reason: s.reason }),
+        hidden: s.hidden,
+    });
+    const all = [...byFile.values()].flat();
+    const active = all.filter(s => s.hidden > 0).map(entry);
+    // A bare ucn:ignore may hide findings of any command, so no one run can call it stale
+    const bare = s => s.rules.length === 0 && s.unknownRules.length === 0;
+    const stale = all.filter(s => s.hidden === 0 && !s.quiet && !bare(s)).map(entry);
+    const whole = !scope.file && !scope.in && !scope.exclude?.length && !scope.include?.length && scope.includeTests;
+    active.push(...ignored.filter(s => s.hidden > 0).map(entry));
+    stale.push(...ignored.filter(s => s.hidden === 0 && (s.unknownRules.length > 0
+        || (whole && s.rules.length > 0 && s.rules.some(r => checked.has(r))))).map(entry));
+    return {
+        result: withoutFindings(command, result, drop),
+        suppressions: active.length + stale.length > 0 ? { active, stale } : null,
+    };
+}
+
+module.exports = { parseSuppressions, applySuppressions, configIgnores };
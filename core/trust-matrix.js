@@ -137,6 +137,11 @@ const COMMAND_TRUST_MATRIX = Object.freeze({
     doctor: row('diagnostic-not-accuracy', ['command-fixtures', 'systematic-options', 'surface-parity'], 'project-scan', 'advisory-only', 'Doctor reports index/evidence limitations and never presents itself as an accuracy oracle.'),
     auditAsync: row('async-advisory', ['cross-language-fixtures', 'command-fixtures', 'surface-parity'], 'project-scan', 'advisory-only', 'Missing-await semantics depend on framework/type flow; findings are advisory and fixture-tested.'),
     orient: row('diagnostic-composition', ['command-fixtures', 'systematic-options', 'surface-parity'], 'project-scan', 'navigation', 'Orient composes index counts, entrypoint hints, and doctor limitations.'),
+    clones: row('token-similarity-advisory', ['command-fixtures', 'surface-parity'], 'project-scan', 'advisory-only', 'Similarity is over normalized tokens, not behavior; a clone group is a refactoring lead, fixture-tested for exact, renamed, and near-miss copies.'),
+    deprecated: row('doc-notice-references', ['command-fixtures', 'surface-parity'], 'project-scan', 'advisory-only', 'Deprecation comes from the indexed Go `Deprecated:` doc paragraph; references use the caller and usage engines, so an unreferenced exported symbol may still have callers outside the project.'),
+    rules: row('static-rule-catalog', ['command-fixtures', 'surface-parity'], 'source-query', 'navigation', 'The rule table is fixed data merged with .ucn.json "rules"; fixtures check the defaults and overrides it reports.'),
+    explain: row('finding-provenance', ['oracle-deadcode', 'command-fixtures', 'surface-parity'], 'project-scan', 'advisory-only', 'Explanations restate the checks deadcode ran and the reverse caller trace; they inherit the dead-code claim rather than proving it.'),
+    why: row('reachability-chain', ['oracle-deadcode', 'command-fixtures', 'surface-parity'], 'project-scan', 'advisory-only', 'The chain is one shortest path through the confirmed call edges the dead-code reachability walk follows; a missing chain means no confirmed edges, not no callers.'),
 });
 
 function summarizeCommandTrust() {
@@ -0,0 +1,285 @@
+/**
+ * core/tui.js — Terminal browser over a finding command's findings (ucn tui)
+ *
+ * State, keys and rendering only: the CLI feeds keypresses in and paints
+ * the lines render() returns, so every screen can be checked without a
+ * terminal.
+ *
+ * The list groups findings by package (directory) or by rule, g switches
+ * between the two. Enter or → opens a group; the pane below previews the
+ * code at the selected finding. r lists the finding's references (its
+ * related locations, then every place its symbol is used) and the
+ * preview follows the cursor through them. i hides a finding with a
+ * ucn:ignore[rule] comment written above it, b by adding it to the
+ * baseline file; either way it stays listed, marked, for the session.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const { langTraits } = require('../languages');
+const { findingsOf, RULES } = require('./output/sarif');
+const { baselineEntry, parseBaseline, DEFAULT_BASELINE_FILE } = require('./baseline');
+
+const HELP = '↑↓ move  Enter/→ open  ← close  g group by  r references  i ignore comment  b baseline  q quit';
+const INVERSE = s => `\x1b[7m${s}\x1b[0m`;
+const DIM = s => `\x1b[2m${s}\x1b[0m`;
+
+/** Group key and label of a finding */
+function groupOf(f, groupBy) {
+    if (groupBy === 'rule') return { key: f.rule, label: `${f.rule} (${RULES[f.rule][0]})` };
+    const dir = path.posix.dirname(f.at.file);
+    return { key: dir, label: dir === '.' ? '(root)' : dir };
+}
+
+/**
+ * Write a ucn:ignore comment above a finding's first line, indented as
+ * that line is.
+ * @param {object} index - ProjectIndex
+ * @param {object} f - Finding
+ * @returns {number} The line the comment went on
+ */
+function writeSuppression(index, f) {
+    const abs = path.join(index.root, f.at.file);
+    const lines = fs.readFileSync(abs, 'utf-8').split('\n');
+    const line = f.at.startLine;
+    const indent = (lines[line - 1] || '').match(/^\s*/)[0];
+    const comment = langTraits(index.files.get(abs)?.language)?.lineComment || '//';
+    lines.splice(line - 1, 0, `${indent}${comment} ucn:ignore[${f.rule}]`);
+    fs.writeFileSync(abs, lines.join('\n'));
+    return line;
+}
+
+/**
+ * Add a finding to a baseline file, creating it when missing.
+ * @param {string} file - Baseline path
+ * @param {string} command - Canonical command
+ * @param {object} f - Finding
+ */
+function addToBaseline(file, command, f) {
+    let doc = { version: 1, tool: 'ucn', findings: [] };
+    if (fs.existsSync(file)) {
+        const { baseline, error } = parseBaseline(fs.readFileSync(file, 'utf-8'));
+        if (error) throw new Error(`Cannot update baseline ${file}: ${error}`);
+        doc = baseline;
+    }
+    doc.findings.push(baselineEntry(command, f));
+    fs.writeFileSync(file, JSON.stringify(doc, null, 2) + '\n');
+}
+
+/**
+ * A browser over one command's findings.
+ * @param {object} index - ProjectIndex the result came from
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} result - The command's result
+ * @param {object} [options] - { baseline } file for b (.ucn-baseline.json); { rules } as findingsOf takes them
+ * @returns {{state: object, key: Function, render: Function}}
+ *   key(name) handles one keypress and returns 'quit' to leave;
+ *   render(rows, cols) returns the screen's lines
+ */
+function createTui(index, command, result, options = {}) {
+    const state = {
+        findings: [...findingsOf(command, result, options.rules)],
+        groupBy: 'package',
+        open: new Set(),
+        cursor: 0,
+        top: 0,
+        refs: null,
+        ignored: new Map(),
+        message: '',
+    };
+    const baselineFile = options.baseline || DEFAULT_BASELINE_FILE;
+
+    const groups = () => {
+        const byKey = new Map();
+        for (const f of state.findings) {
+            const { key, label } = groupOf(f, state.groupBy);
+            if (!byKey.has(key)) byKey.set(key, { key, label, findings: [] });
+            byKey.get(key).findings.push(f);
+        }
+        const order = (a, b) => (a < b ? -1 : a > b ? 1 : 0);
+        for (const g of byKey.values()) g.findings.sort((a, b) => order(a.at.file, b.at.file) || a.at.startLine - b.at.startLine);
+        return [...byKey.values()].sort((a, b) => order(a.label, b.label));
+    };
+
+    // The list's rows: group headers, and the findings of open groups
+    const rows = () => {
+        const out = [];
+        for (const g of groups()) {
+            out.push({ group: g });
+            if (state.open.has(g.key)) for (const f of g.findings) out.push({ finding: f, group: g });
+        }
+        return out;
+    };
+
+    const references = (f) => {
+        const items = [{ file: f.at.file, line: f.at.startLine, label: 'finding' }];
+        for (const loc of f.related || []) items.push({ file: loc.file, line: loc.startLine, label: 'related' });
+        if (f.at.name && index.symbols.has(f.at.name)) {
+            for (const u of index.usages(f.at.name, { includeTests: true })) {
+                if (u.isDefinition) continue;
+                items.push({ file: u.relativePath, line: u.line, label: u.usageType || 'usage' });
+            }
+        }
+        return items;
+    };
+
+    /** Where the preview points: the reference or finding under the cursor */
+    const focus = () => {
+        if (state.refs) return state.refs.items[state.refs.cursor];
+        const row = rows()[state.cursor];
+        return row?.finding ? { file: row.finding.at.file, line: row.finding.at.startLine, endLine: row.finding.at.endLine } : null;
+    };
+
+    // A comment inserted at `line` pushes that file's later lines down one
+    const shift = (file, line) => {
+        for (const f of state.findings) {
+            for (const loc of [f.at, ...(f.related || [])]) {
+                if (loc.file !== file || loc.startLine < line) continue;
+                loc.startLine++;
+                if (loc.endLine) loc.endLine++;
+            }
+        }
+    };
+
+    const ignore = (how) => {
+        const row = rows()[state.cursor];
+        if (!row?.finding) {
+            state.message = 'Select a finding first';
+            return;
+        }
+        const f = row.finding;
+        if (state.ignored.has(f)) {
+            state.message = `Already ignored (${state.ignored.get(f)})`;
+            return;
+        }
+        try {
+            if (how === 'comment') {
+                shift(f.at.file, writeSuppression(index, f));
+                state.message = `Wrote ucn:ignore[${f.rule}] above ${f.at.file}:${f.at.startLine - 1}`;
+            } else {
+                addToBaseline(baselineFile, command, f);
+                state.message = `Added to ${baselineFile}`;
+            }
+            state.ignored.set(f, how === 'comment' ? 'comment' : 'baseline');
+        } catch (e) {
+            state.message = `Error: ${e.message}`;
+        }
+    };
+
+    const move = (delta) => {
+        if (state.refs) {
+            state.refs.cursor = Math.max(0, Math.min(state.refs.items.length - 1, state.refs.cursor + delta));
+        } else {
+            state.cursor = Math.max(0, Math.min(rows().length - 1, state.cursor + delta));
+        }
+    };
+
+    function key(name) {
+        state.message = '';
+        if (state.refs) {
+            if (name === 'escape' || name === 'left' || name === 'q' || name === 'r') state.refs = null;
+            else if (name === 'up' || name === 'k') move(-1);
+            else if (name === 'down' || name === 'j') move(1);
+            return undefined;
+        }
+        const list = rows();
+        const row = list[state.cursor];
+        switch (name) {
+            case 'q': case 'escape': return 'quit';
+            case 'up': case 'k': move(-1); break;
+            case 'down': case 'j': move(1); break;
+            case 'pageup': move(-10); break;
+            case 'pagedown': move(10); break;
+            case 'home': state.cursor = 0; break;
+            case 'end': state.cursor = Math.max(0, list.length - 1); break;
+            case 'return': case 'enter': case 'right':
+                if (row?.finding) {
+                    state.refs = { finding: row.finding, items: references(row.finding), cursor: 0 };
+                } else if (row) {
+                    if (state.open.has(row.group.key)) state.open.delete(row.group.key);
+                    else state.open.add(row.group.key);
+                }
+                break;
+            case 'left':
+                if (row) {
+                    state.open.delete(row.group.key);
+                    state.cursor = rows().findIndex(r => !r.finding && r.group.key === row.group.key);
+                }
+                break;
+            case 'g':
+                state.groupBy = state.groupBy === 'package' ? 'rule' : 'package';
+                state.open.clear();
+                state.cursor = 0;
+                state.top = 0;
+                break;
+            case 'r':
+                if (row?.finding) state.refs = { finding: row.finding, items: references(row.finding), cursor: 0 };
+                break;
+            case 'i': ignore('comment'); break;
+            case 'b': ignore('baseline'); break;
+        }
+        return undefined;
+    }
+
+    const fit = (s, cols) => (s.length > cols ? s.slice(0, Math.max(0, cols - 1)) + '…' : s);
+
+    function render(height, cols) {
+        const out = [];
+        const previewHeight = Math.max(3, Math.floor((height - 3) * 0.4));
+        const listHeight = Math.max(1, height - previewHeight - 3);
+        const total = state.findings.length;
+        out.push(fit(`ucn tui — ${total} finding(s), grouped by ${state.groupBy}` +
+            (state.ignored.size > 0 ? `, ${state.ignored.size} ignored` : ''), cols));
+
+        let lines;
+        let cursor;
+        let list = null;
+        if (state.refs) {
+            const f = state.refs.finding;
+            lines = [`References of ${f.at.name || f.message} — ← back`, ...state.refs.items.map(r =>
+                `  ${r.file}:${r.line}  ${r.label}`)];
+            cursor = state.refs.cursor + 1;
+        } else {
+            list = rows();
+            lines = list.map(r => {
+                if (!r.finding) return `${state.open.has(r.group.key) ? '▾' : '▸'} ${r.group.label} (${r.group.findings.length})`;
+                const mark = state.ignored.has(r.finding) ? ` [ignored: ${state.ignored.get(r.finding)}]` : '';
+                const where = state.groupBy === 'rule' ? r.finding.at.file : path.posix.basename(r.finding.at.file);
+                return `    ${where}:${r.finding.at.startLine}  ${r.finding.message}${mark}`;
+            });
+            cursor = state.cursor;
+        }
+        if (total === 0) lines = ['No findings.'];
+        // Keep the cursor inside the window
+        if (cursor < state.top) state.top = cursor;
+        if (cursor >= state.top + listHeight) state.top = cursor - listHeight + 1;
+        for (let i = state.top; i < state.top + listHeight; i++) {
+            if (i >= lines.length) { out.push(''); continue; }
+            const text = fit(lines[i], cols);
+            const ignoredRow = list?.[i]?.finding && state.ignored.has(list[i].finding);
+            out.push(i === cursor && total > 0 ? INVERSE(text) : ignoredRow ? DIM(text) : text);
+        }
+
+        const at = focus();
+        out.push(fit((at ? `── ${at.file}:${at.line} ` : '').padEnd(cols, '─'), cols));
+        let code = [];
+        if (at) {
+            try { code = fs.readFileSync(path.join(index.root, at.file), 'utf-8').split('\n'); } catch { code = []; }
+        }
+        const first = at ? Math.max(1, at.line - Math.floor(previewHeight / 3)) : 1;
+        const width = String(first + previewHeight).length;
+        for (let n = first; n < first + previewHeight; n++) {
+            if (!at || n > code.length) { out.push(''); continue; }
+            const hit = n >= at.line && n <= (at.endLine || at.line);
+            out.push(fit(`${hit ? '▶' : ' '}${String(n).padStart(width)} │ ${code[n - 1].replace(/\t/g, '    ')}`, cols));
+        }
+        out.push(fit(state.message || HELP, cols));
+        return out;
+    }
+
+    return { state, key, render };
+}
+
+module.exports = { createTui, writeSuppression, addToBaseline };
@@ -719,6 +719,10 @@ function analyzeCallSite(index, call, funcName, occurrence = 0) {
                 if (blocks.length === 0) return { args: null, argCount: 0 };
                 const virtualJS = htmlModule.buildVirtualJSContent(content, blocks);
                 tree = safeParse(jsParser, virtualJS);
+            } else if (language === 'vue' || language === 'svelte') {
+                const script = getLanguageModule(language).extractScript(content, getParser(language));
+                if (!script) return { args: null, argCount: 0 };
+                tree = safeParse(script.jsParser, script.virtualJS);
             } else {
                 const parser = getParser(language);
                 if (!parser) return { args: null, argCount: 0 };
@@ -817,6 +821,10 @@ function analyzeCallShape(index, filePath, lineNum, funcName) {
                 if (blocks.length === 0) return null;
                 const virtualJS = htmlModule.buildVirtualJSContent(content, blocks);
                 tree = safeParse(jsParser, virtualJS);
+            } else if (language === 'vue' || language === 'svelte') {
+                const script = getLanguageModule(language).extractScript(content, getParser(language));
+                if (!script) return null;
+                tree = safeParse(script.jsParser, script.virtualJS);
             } else {
                 const parser = getParser(language);
                 if (!parser) return null;
@@ -0,0 +1,98 @@
+/**
+ * core/watch.js — Re-run a finding command as files change (ucn watch)
+ *
+ * The project root is watched recursively. A burst of changes (a save,
+ * a branch switch) settles for a moment, then the index is rebuilt
+ * incrementally: only files whose size, mtime and content changed are
+ * re-parsed, deleted files are dropped, and the import graph and callee
+ * index are relinked, so the changed files' dependents see the edit.
+ * The finding command then re-runs on the updated index, and each
+ * update reports the findings that appeared and went away since the
+ * previous run, matched by fingerprint as baselines match them (a
+ * finding that only moved is neither).
+ */
+
+'use strict';
+
+const fs = require('fs');
+const { detectLanguage } = require('../languages');
+const { shouldIgnore, DEFAULT_IGNORES } = require('./discovery');
+const { findingsOf } = require('./output/sarif');
+const { applyBaseline, createBaseline } = require('./baseline');
+
+/**
+ * Findings of `after` that `before` didn't have, and the reverse.
+ * @param {string} command - Canonical command (deadcode, clones, auditAsync, deprecated)
+ * @param {*} before - Previous result (null on the first run)
+ * @param {*} after - Current result
+ * @returns {{total: number, introduced: object[], resolved: object[]}} Findings as findingsOf builds them
+ */
+function compareFindings(command, before, after) {
+    const now = [...findingsOf(command, after)];
+    if (!before) return { total: now.length, introduced: now, resolved: [] };
+    // Each side is the other's baseline: what it leaves unmatched is new on that side
+    const unmatched = (side, other) => applyBaseline(command, side, createBaseline(command, other)).unmatched;
+    return { total: now.length, introduced: unmatched(after, before), resolved: unmatched(before, after) };
+}
+
+/** Whether a changed path (relative to the root) can affect the index */
+function isWatchedPath(rel) {
+    const parts = rel.split(/[\\/]/);
+    if (parts.slice(0, -1).some(dir => dir === '.ucn-cache' || shouldIgnore(dir, DEFAULT_IGNORES))) return false;
+    return !!detectLanguage(rel);
+}
+
+/**
+ * Watch the project and re-run a command on each settled change.
+ * @param {object} index - Built ProjectIndex
+ * @param {Function} run - (index) => the command's result
+ * @param {object} options
+ * @param {string} options.command - Canonical command, to compare findings
+ * @param {Function} options.onUpdate - ({ changed, ms, result, total, introduced, resolved }) after each re-run
+ * @param {Function} [options.onError] - (error) when a rebuild or run fails; watching goes on
+ * @param {number} [options.settleMs=200] - Quiet time before a burst of changes is handled
+ * @param {object} [options.buildOptions] - Extra options for index.build
+ * @returns {{close: Function, result: *}} Stops watching; the initial result
+ */
+function watchProject(index, run, options) {
+    const { command, onUpdate, onError = () => {}, settleMs = 200, buildOptions = {} } = options;
+    let previous = run(index);
+    const pending = new Set();
+    let timer = null;
+
+    const update = () => {
+        timer = null;
+        const changed = [...pending].sort();
+        pending.clear();
+        const start = Date.now();
+        try {
+            index.build(null, { ...buildOptions, quiet: true, forceRebuild: true });
+            const result = run(index);
+            const { total, introduced, resolved } = compareFindings(command, previous, result);
+            previous = result;
+            onUpdate({ changed, ms: Date.now() - start, result, total, introduced, resolved });
+        } catch (e) {
+            onError(e);
+        }
+    };
+
+    const watcher = fs.watch(index.root, { recursive: true }, (event, name) => {
+        if (!name) return;
+        const rel = String(name).replace(/\\/g, '/');
+        if (!isWatchedPath(rel)) return;
+        pending.add(rel);
+        if (timer) clearTimeout(timer);
+        timer = setTimeout(update, settleMs);
+    });
+    watcher.on('error', onError);
+
+    return {
+        result: previous,
+        close() {
+            if (timer) clearTimeout(timer);
+            watcher.close();
+        },
+    };
+}
+
+module.exports = { watchProject, compareFindings, isWatchedPath };
@@ -0,0 +1,19 @@
+// Command ucnvet runs ucn's Go rules under go vet:
+//
+//	go install github.com/mleoca/ucn/go/cmd/ucnvet@latest
+//	go vet -vettool=$(command -v ucnvet) ./...
+//
+// Each rule is an analyzer of its own, so go vet's flags pick them
+// (-unusedparam, -packagevar, ...; all by default), and -ucn.args passes
+// extra flags to ucn (-ucn.args=--include-exported).
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/mleoca/ucn/go/ucnlint"
+)
+
+func main() {
+	unitchecker.Main(append(ucnlint.Analyzers, ucnlint.Analyzer)...)
+}
@@ -0,0 +1,64 @@
+// Package golangci is a golangci-lint module plugin running ucn's Go
+// rules. Build it into a custom golangci-lint (golangci-lint custom, with
+// this module in .custom-gcl.yml) and enable the "ucn" linter:
+//
+//	linters:
+//	  enable:
+//	    - ucn
+//	  settings:
+//	    custom:
+//	      ucn:
+//	        type: module
+//	        settings:
+//	          rules: [dead-code, unused-param, package-var]
+//	          args: [--exclude=**/*.pb.go]
+package golangci
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/mleoca/ucn/go/ucnlint"
+)
+
+func init() {
+	register.Plugin("ucn", New)
+}
+
+// Settings are the linter's settings in .golangci.yml.
+type Settings struct {
+	// Command runs ucn (default "ucn", or "npx ucn" when not on PATH).
+	Command []string `json:"command"`
+	// Rules are the rule ids to report (default dead-code).
+	Rules []string `json:"rules"`
+	// Args are extra flags for ucn, e.g. --include-exported.
+	Args []string `json:"args"`
+}
+
+// Plugin is the ucn linter.
+type Plugin struct {
+	settings Settings
+}
+
+// New decodes the settings golangci-lint passes.
+func New(settings any) (register.LinterPlugin, error) {
+	s, err := register.DecodeSettings[Settings](settings)
+	if err != nil {
+		return nil, err
+	}
+	return &Plugin{settings: s}, nil
+}
+
+// BuildAnalyzers returns the analyzer reporting the configured rules.
+func (p *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{ucnlint.NewAnalyzer(ucnlint.Config{
+		Command: p.settings.Command,
+		Rules:   p.settings.Rules,
+		Args:    p.settings.Args,
+	})}, nil
+}
+
+// GetLoadMode asks for syntax only: ucn does its own analysis.
+func (p *Plugin) GetLoadMode() string {
+	return register.LoadModeSyntax
+}
@@ -0,0 +1,31 @@
+package golangci
+
+import (
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+)
+
+func TestNew(t *testing.T) {
+	newPlugin, err := register.GetPlugin("ucn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := newPlugin(map[string]any{"rules": []string{"dead-code", "unused-param"}, "args": []string{"--include-exported"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analyzers) != 1 || analyzers[0].Name != "ucn" {
+		t.Fatalf("analyzers = %v, want the ucn analyzer", analyzers)
+	}
+	if got := p.GetLoadMode(); got != register.LoadModeSyntax {
+		t.Errorf("GetLoadMode() = %q, want %q", got, register.LoadModeSyntax)
+	}
+	if _, err := newPlugin(map[string]any{"rule": "dead-code"}); err == nil {
+		t.Error("an unknown setting should fail")
+	}
+}
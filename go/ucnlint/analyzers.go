@@ -0,0 +1,121 @@
+package ucnlint
+
+import (
+	"flag"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// goRules are the rules behind Analyzers: ucn deadcode's Go-specific and
+// language-neutral modes, with the flag turning each on.
+var goRules = map[string]string{
+	"dead-code":           "",
+	"interface-method":    "--interface-methods",
+	"unused-param":        "--unused-params",
+	"unused-type-param":   "--type-params",
+	"unreachable-code":    "--unreachable-code",
+	"package-var":         "--package-vars",
+	"sentinel-error":      "--sentinel-errors",
+	"unused-embed":        "--embeds",
+	"channel":             "--channels",
+	"config-knob":         "--config-knobs",
+	"dead-init":           "--init-effects",
+	"blank-import":        "--init-effects",
+	"import-unused":       "--import-issues",
+	"import-duplicate":    "--import-issues",
+	"import-shadowed":     "--import-issues",
+	"satisfies-only":      "--satisfies-only",
+	"redundant-assertion": "--redundant-assertions",
+}
+
+var (
+	flagCommand string
+	flagArgs    string
+)
+
+// Analyzer runs ucn deadcode once per module with every Go rule on. The
+// rule analyzers require it; its result is the module's findings by
+// absolute file path (map[string][]Finding). Its flags -command and
+// -args set how ucn runs and add flags to it.
+var Analyzer = &analysis.Analyzer{
+	Name:       "ucn",
+	Doc:        "runs ucn deadcode over the module for the ucn rule analyzers",
+	URL:        "https://github.com/mleoca/ucn",
+	Flags:      analyzerFlags(),
+	Run:        runGoRules,
+	ResultType: reflect.TypeOf(map[string][]Finding(nil)),
+}
+
+func analyzerFlags() flag.FlagSet {
+	fs := flag.NewFlagSet("ucn", flag.ExitOnError)
+	fs.StringVar(&flagCommand, "command", "", `command running ucn (default "ucn", or "npx ucn" when not on PATH)`)
+	fs.StringVar(&flagArgs, "args", "", "extra flags for ucn deadcode, space-separated (e.g. --include-exported)")
+	return *fs
+}
+
+func runGoRules(pass *analysis.Pass) (any, error) {
+	root := packageRoot(pass)
+	if root == "" {
+		return map[string][]Finding(nil), nil
+	}
+	command := ucnCommand(strings.Fields(flagCommand))
+	args := strings.Fields(flagArgs)
+	key := strings.Join(append(append([]string{"go", root}, command...), args...), "\x00")
+	return moduleFindings(root, key, command, func() (map[string][]Finding, error) {
+		var flags []string
+		wanted := map[string]bool{}
+		for id, flag := range goRules {
+			wanted[id] = true
+			if flag != "" && !contains(flags, flag) {
+				flags = append(flags, flag)
+			}
+		}
+		return analyzeModule(command, root, map[string][]string{"deadcode": flags}, args, wanted)
+	})
+}
+
+func ruleAnalyzer(name, id, doc string) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     name,
+		Doc:      doc + "\n\nThis is ucn's " + id + " rule (ucn explain " + id + "), computed across the whole module.",
+		URL:      "https://github.com/mleoca/ucn",
+		Requires: []*analysis.Analyzer{Analyzer},
+		Run: func(pass *analysis.Pass) (any, error) {
+			findings := pass.ResultOf[Analyzer].(map[string][]Finding)
+			report(pass, findings, func(f Finding) bool { return f.RuleID == id })
+			return nil, nil
+		},
+	}
+}
+
+// The analyzers of ucn's Go rules.
+var (
+	DeadCode           = ruleAnalyzer("deadcode", "dead-code", "reports symbols nothing in the module uses")
+	InterfaceMethod    = ruleAnalyzer("interfacemethod", "interface-method", "reports interface methods no caller invokes through the interface")
+	UnusedParam        = ruleAnalyzer("unusedparam", "unused-param", "reports function parameters that are never read")
+	UnusedTypeParam    = ruleAnalyzer("unusedtypeparam", "unused-type-param", "reports type parameters the signature, body and methods never mention")
+	UnreachableCode    = ruleAnalyzer("unreachablecode", "unreachable-code", "reports statements no execution reaches")
+	PackageVar         = ruleAnalyzer("packagevar", "package-var", "reports package-level variables that are never read")
+	SentinelError      = ruleAnalyzer("sentinelerror", "sentinel-error", "reports sentinel errors never returned or compared")
+	UnusedEmbed        = ruleAnalyzer("unusedembed", "unused-embed", "reports embedded files or variables that are never read")
+	Channel            = ruleAnalyzer("channel", "channel", "reports channels missing a sender or receiver, and dropped goroutine results")
+	ConfigKnob         = ruleAnalyzer("configknob", "config-knob", "reports flags, env variables and viper keys that are never read")
+	DeadInit           = ruleAnalyzer("deadinit", "dead-init", "reports init functions whose effects nothing observes")
+	BlankImport        = ruleAnalyzer("blankimport", "blank-import", "reports blank imports whose registrations nothing looks up")
+	ImportUnused       = ruleAnalyzer("importunused", "import-unused", "reports imports unused in a file only some build tags compile")
+	ImportDuplicate    = ruleAnalyzer("importduplicate", "import-duplicate", "reports paths imported twice under different names")
+	ImportShadowed     = ruleAnalyzer("importshadowed", "import-shadowed", "reports imports hidden by a local of the same name")
+	SatisfiesOnly      = ruleAnalyzer("satisfiesonly", "satisfies-only", "reports interfaces used only in var _ assertions")
+	RedundantAssertion = ruleAnalyzer("redundantassertion", "redundant-assertion", "reports var _ I = (*T)(nil) assertions whose interface has no other use")
+)
+
+// Analyzers are the rule analyzers, for multichecker.Main or
+// unitchecker.Main (Analyzer comes in as their requirement).
+var Analyzers = []*analysis.Analyzer{
+	DeadCode, InterfaceMethod, UnusedParam, UnusedTypeParam, UnreachableCode,
+	PackageVar, SentinelError, UnusedEmbed, Channel, ConfigKnob, DeadInit,
+	BlankImport, ImportUnused, ImportDuplicate, ImportShadowed, SatisfiesOnly,
+	RedundantAssertion,
+}
@@ -0,0 +1,106 @@
+package ucnlint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// cacheDir is where module results persist between processes: go vet
+// runs a vettool once per package, so the in-process once alone would
+// rerun ucn for every package. UCNLINT_CACHE overrides the directory
+// (default <user cache dir>/ucn/ucnlint); "off" turns the cache off.
+func cacheDir() string {
+	switch dir := os.Getenv("UCNLINT_CACHE"); dir {
+	case "off":
+		return ""
+	case "":
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		return filepath.Join(base, "ucn", "ucnlint")
+	default:
+		return dir
+	}
+}
+
+// cachedFindings returns the findings stored for key and the module's
+// current state, else runs analyze and stores what it returns. A cache
+// that can't be read or written only costs the ucn run.
+func cachedFindings(root, key string, command []string, analyze func() (map[string][]Finding, error)) (map[string][]Finding, error) {
+	dir := cacheDir()
+	if dir == "" {
+		return analyze()
+	}
+	state, err := moduleState(root, command)
+	if err != nil {
+		return analyze()
+	}
+	sum := sha256.Sum256([]byte(key + "\x00" + state))
+	file := filepath.Join(dir, hex.EncodeToString(sum[:16])+".json")
+	if data, err := os.ReadFile(file); err == nil {
+		var findings map[string][]Finding
+		if json.Unmarshal(data, &findings) == nil {
+			return findings, nil
+		}
+	}
+	findings, err := analyze()
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(findings); err == nil && os.MkdirAll(dir, 0o755) == nil {
+		// Through a rename, so a concurrent package never reads half a file
+		if tmp, err := os.CreateTemp(dir, "run-*"); err == nil {
+			_, werr := tmp.Write(data)
+			if cerr := tmp.Close(); werr == nil && cerr == nil {
+				werr = os.Rename(tmp.Name(), file)
+			}
+			if werr != nil {
+				os.Remove(tmp.Name())
+			}
+		}
+	}
+	return findings, nil
+}
+
+// moduleState hashes what a ucn run over root depends on: the path, size
+// and modification time of every file under root (hidden directories and
+// node_modules aside), and the same of the ucn executable, so an edit or
+// a ucn upgrade misses the cache.
+func moduleState(root string, command []string) (string, error) {
+	h := sha256.New()
+	if exe, err := exec.LookPath(command[0]); err == nil {
+		if info, err := os.Stat(exe); err == nil {
+			fmt.Fprintf(h, "%s\x00%d\x00%d\n", exe, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", filepath.ToSlash(rel), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
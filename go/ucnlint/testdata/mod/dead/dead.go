@@ -0,0 +1,7 @@
+package dead
+
+func Used() int { return 1 }
+
+func helper() int { // want `Symbol with no references: function helper \(dead-code\)`
+	return Used()
+}
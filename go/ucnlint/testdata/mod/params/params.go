@@ -0,0 +1,7 @@
+package params
+
+import "fmt"
+
+func Greet(name string, verbose bool) { // want `Function parameter never read: verbose \(unused-param\)`
+	fmt.Println("hello", name)
+}
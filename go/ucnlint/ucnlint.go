@@ -0,0 +1,280 @@
+// Package ucnlint reports ucn's findings on Go code as go/analysis
+// diagnostics, for the drivers Go teams already run: golangci-lint (see
+// package golangci), go vet -vettool (cmd/ucnvet) and any other
+// analysis driver, through one Analyzer per Go rule (Analyzers).
+//
+// ucn analyzes the whole module at once: a symbol is dead when nothing in
+// the module uses it, which no single package can tell. So the first
+// package of a module runs ucn on the module root, and every package
+// reports the findings in its own files. Drivers that run each package
+// in its own process, such as go vet -vettool, would rerun ucn per
+// package; the module's findings are therefore kept in the user cache
+// directory too, keyed by the configuration and the module's files, so
+// only the first package after an edit pays for the run (see
+// UCNLINT_CACHE in cacheDir).
+package ucnlint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// DefaultRules are the rules reported when Config.Rules is empty.
+var DefaultRules = []string{"dead-code"}
+
+// Config chooses what the analyzer reports.
+type Config struct {
+	// Command runs ucn: the executable and any leading arguments
+	// (default "ucn", or "npx ucn" when ucn is not on PATH).
+	Command []string
+	// Rules are the ids of the rules to report, as `ucn rules` lists
+	// them (default DefaultRules). Each turns on its ucn flag.
+	Rules []string
+	// Args are extra flags for every ucn run, e.g. --include-exported
+	// or --exclude=**/*.pb.go.
+	Args []string
+}
+
+// Finding is one ucn finding, as `--format jsonl` prints it.
+type Finding struct {
+	Command   string `json:"command"`
+	RuleID    string `json:"ruleId"`
+	Severity  string `json:"severity"`
+	Message   string `json:"message"`
+	File      string `json:"file"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	Name      string `json:"name"`
+}
+
+// rule is an entry of `ucn rules --json`.
+type rule struct {
+	ID      string  `json:"id"`
+	Command string  `json:"command"`
+	Flag    *string `json:"flag"`
+}
+
+// NewAnalyzer returns an analyzer reporting cfg's rules.
+func NewAnalyzer(cfg Config) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: "ucn",
+		Doc:  "reports ucn findings (dead code, unused parameters, ...) computed across the whole module",
+		URL:  "https://github.com/mleoca/ucn",
+		Run: func(pass *analysis.Pass) (any, error) {
+			return nil, run(pass, cfg)
+		},
+	}
+}
+
+func run(pass *analysis.Pass, cfg Config) error {
+	root := packageRoot(pass)
+	if root == "" {
+		return nil
+	}
+	ids := cfg.Rules
+	if len(ids) == 0 {
+		ids = DefaultRules
+	}
+	command := ucnCommand(cfg.Command)
+	key := strings.Join(append(append(append([]string{"rules", root}, command...), ids...), cfg.Args...), "\x00")
+	findings, err := moduleFindings(root, key, command, func() (map[string][]Finding, error) {
+		runs, wanted, err := resolveRules(command, root, ids)
+		if err != nil {
+			return nil, err
+		}
+		return analyzeModule(command, root, runs, cfg.Args, wanted)
+	})
+	if err != nil {
+		return err
+	}
+	report(pass, findings, func(Finding) bool { return true })
+	return nil
+}
+
+// packageRoot is the root of the module of pass's package, or "".
+func packageRoot(pass *analysis.Pass) string {
+	if len(pass.Files) == 0 {
+		return ""
+	}
+	return moduleRoot(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()))
+}
+
+// report reports the findings in pass's files that keep accepts.
+func report(pass *analysis.Pass, findings map[string][]Finding, keep func(Finding) bool) {
+	for _, f := range pass.Files {
+		tf := pass.Fset.File(f.Pos())
+		for _, finding := range findings[tf.Name()] {
+			if !keep(finding) || finding.StartLine < 1 || finding.StartLine > tf.LineCount() {
+				continue
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:      tf.LineStart(finding.StartLine),
+				Category: finding.RuleID,
+				Message:  fmt.Sprintf("%s (%s)", finding.Message, finding.RuleID),
+			})
+		}
+	}
+}
+
+// moduleRoot is the directory of the go.mod above dir, or "".
+func moduleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+type moduleRun struct {
+	once     sync.Once
+	findings map[string][]Finding // by absolute file path
+	err      error
+}
+
+var (
+	runsMu sync.Mutex
+	runs   = map[string]*moduleRun{}
+)
+
+// moduleFindings runs analyze once per key (module and configuration);
+// the packages of the module share the result, in this process and, via
+// cachedFindings, in later ones.
+func moduleFindings(root, key string, command []string, analyze func() (map[string][]Finding, error)) (map[string][]Finding, error) {
+	runsMu.Lock()
+	r, ok := runs[key]
+	if !ok {
+		r = &moduleRun{}
+		runs[key] = r
+	}
+	runsMu.Unlock()
+	r.once.Do(func() {
+		r.findings, r.err = cachedFindings(root, key, command, analyze)
+	})
+	return r.findings, r.err
+}
+
+// ucnCommand is the command running ucn: the configured one, else ucn
+// on PATH, else npx.
+func ucnCommand(configured []string) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+	if _, err := exec.LookPath("ucn"); err != nil {
+		return []string{"npx", "--yes", "ucn"}
+	}
+	return []string{"ucn"}
+}
+
+// resolveRules maps rule ids to the ucn commands and flags that report
+// them, as `ucn rules` lists them.
+func resolveRules(command []string, root string, ids []string) (map[string][]string, map[string]bool, error) {
+	out, err := ucn(command, root, "rules", "--json")
+	if err != nil {
+		return nil, nil, err
+	}
+	var listed struct {
+		Data struct {
+			Rules []rule `json:"rules"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(out, &listed); err != nil {
+		return nil, nil, fmt.Errorf("ucn rules: %w", err)
+	}
+	known := map[string]rule{}
+	for _, r := range listed.Data.Rules {
+		known[r.ID] = r
+	}
+	wanted := map[string]bool{}
+	flags := map[string][]string{}
+	for _, id := range ids {
+		r, ok := known[id]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown ucn rule %q (see ucn rules)", id)
+		}
+		wanted[id] = true
+		if _, ok := flags[r.Command]; !ok {
+			flags[r.Command] = nil
+		}
+		if r.Flag != nil && !contains(flags[r.Command], *r.Flag) {
+			flags[r.Command] = append(flags[r.Command], *r.Flag)
+		}
+	}
+	return flags, wanted, nil
+}
+
+// analyzeModule runs each ucn command with its flags (plus args) on root
+// and collects the findings of the wanted rules by absolute path.
+func analyzeModule(command []string, root string, flags map[string][]string, args []string, wanted map[string]bool) (map[string][]Finding, error) {
+	commands := make([]string, 0, len(flags))
+	for c := range flags {
+		commands = append(commands, c)
+	}
+	sort.Strings(commands)
+
+	findings := map[string][]Finding{}
+	for _, c := range commands {
+		out, err := ucn(command, root, append(append([]string{c, "--format=jsonl", "--fail-on=none"}, flags[c]...), args...)...)
+		if err != nil {
+			return nil, err
+		}
+		sc := bufio.NewScanner(bytes.NewReader(out))
+		sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for sc.Scan() {
+			var f Finding
+			if err := json.Unmarshal(sc.Bytes(), &f); err != nil {
+				return nil, fmt.Errorf("ucn %s: %w", c, err)
+			}
+			if !wanted[f.RuleID] {
+				continue
+			}
+			file := filepath.Join(root, filepath.FromSlash(f.File))
+			findings[file] = append(findings[file], f)
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("ucn %s: %w", c, err)
+		}
+	}
+	return findings, nil
+}
+
+// ucn runs a ucn command in dir and returns its stdout.
+func ucn(command []string, dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command(command[0], append(append([]string{}, command[1:]...), args...)...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && stderr.Len() > 0 {
+			return nil, fmt.Errorf("ucn %s: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("ucn %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package ucnlint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// With UCNLINT_FAKE_UCN set the test binary stands in for ucn: `rules`
+// prints a rule table, a finding command the module's ucn.jsonl. The
+// tests leave the user's cache alone.
+func TestMain(m *testing.M) {
+	if os.Getenv("UCNLINT_FAKE_UCN") != "" {
+		os.Exit(fakeUCN(os.Args[1:]))
+	}
+	os.Setenv("UCNLINT_CACHE", "off")
+	os.Exit(m.Run())
+}
+
+func fakeUCN(args []string) int {
+	switch {
+	case len(args) == 2 && args[0] == "rules" && args[1] == "--json":
+		fmt.Println(`{"meta":{"command":"rules"},"data":{"rules":[` +
+			`{"id":"dead-code","command":"deadcode","flag":null},` +
+			`{"id":"unused-param","command":"deadcode","flag":"--unused-params"}]}}`)
+	case len(args) > 0 && args[0] == "deadcode":
+		if !slices.Contains(args, "--format=jsonl") || !slices.Contains(args, "--unused-params") {
+			fmt.Fprintf(os.Stderr, "unexpected flags %v\n", args)
+			return 2
+		}
+		out, err := os.ReadFile("ucn.jsonl")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		os.Stdout.Write(out)
+	default:
+		fmt.Fprintf(os.Stderr, "unexpected command %v\n", args)
+		return 2
+	}
+	return 0
+}
+
+func fakeUCNCommand(t *testing.T) []string {
+	t.Setenv("UCNLINT_FAKE_UCN", "1")
+	return []string{os.Args[0]}
+}
+
+func TestAnalyzers(t *testing.T) {
+	flagCommand = fakeUCNCommand(t)[0]
+	defer func() { flagCommand = "" }()
+	dir := filepath.Join(analysistest.TestData(), "mod")
+	analysistest.Run(t, dir, DeadCode, "./dead")
+	analysistest.Run(t, dir, UnusedParam, "./params")
+}
+
+func TestNewAnalyzer(t *testing.T) {
+	a := NewAnalyzer(Config{Command: fakeUCNCommand(t), Rules: []string{"unused-param"}})
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "mod"), a, "./params")
+}
+
+func TestCachedFindings(t *testing.T) {
+	t.Setenv("UCNLINT_CACHE", t.TempDir())
+	root := t.TempDir()
+	src := filepath.Join(root, "a.go")
+	if err := os.WriteFile(src, []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runs := 0
+	analyze := func() (map[string][]Finding, error) {
+		runs++
+		return map[string][]Finding{src: {{RuleID: "dead-code", File: "a.go", StartLine: runs}}}, nil
+	}
+	get := func() int {
+		t.Helper()
+		findings, err := cachedFindings(root, "k", []string{"ucn"}, analyze)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return findings[src][0].StartLine
+	}
+	if got := get(); got != 1 || runs != 1 {
+		t.Fatalf("first run: line %d after %d runs", got, runs)
+	}
+	// Another process, same module: the stored findings, no ucn run
+	if got := get(); got != 1 || runs != 1 {
+		t.Errorf("unchanged module: line %d after %d runs, want the stored findings", got, runs)
+	}
+	// Files under hidden directories (ucn's own cache) don't count
+	if err := os.MkdirAll(filepath.Join(root, ".ucn-cache"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".ucn-cache", "index.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if get(); runs != 1 {
+		t.Errorf("a write to .ucn-cache reran ucn")
+	}
+	if err := os.WriteFile(src, []byte("package a\n\nfunc f() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := get(); got != 2 || runs != 2 {
+		t.Errorf("edited module: line %d after %d runs, want a fresh run", got, runs)
+	}
+}
+
+func TestAnalyzersValid(t *testing.T) {
+	seen := map[string]bool{}
+	for _, a := range Analyzers {
+		if seen[a.Name] {
+			t.Errorf("two analyzers named %s", a.Name)
+		}
+		seen[a.Name] = true
+	}
+	if len(Analyzers) != len(goRules) {
+		t.Errorf("%d analyzers for %d rules", len(Analyzers), len(goRules))
+	}
+}
@@ -0,0 +1,399 @@
+/**
+ * languages/bash.js - Tree-sitter based shell script parsing (bash/sh)
+ *
+ * Handles: function definitions (`name() { ... }` and `function name { ... }`),
+ * command invocations as calls, `source` / `.` of other scripts as imports,
+ * and top-level variable assignments.
+ *
+ * Shell functions are global once their script is sourced — any script in
+ * the repo can invoke them by bare name, so liveness is purely name-based.
+ * Names handed to builtins (`trap cleanup EXIT`, `complete -F _comp cmd`)
+ * are invocations too. `export -f name` publishes a function to child
+ * processes and counts as exported.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+    visitNameNodes,
+    sameNode,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+// Hooks test harnesses invoke by name (bats, shunit2)
+const TEST_HOOKS = new Set([
+    'setup', 'teardown', 'setup_file', 'teardown_file', 'setup_suite', 'teardown_suite',
+    'oneTimeSetUp', 'oneTimeTearDown', 'setUp', 'tearDown', 'suite',
+]);
+
+// Hooks the shell itself invokes
+const SHELL_HOOKS = new Set(['command_not_found_handle', 'command_not_found_handler']);
+
+// Leading path expressions that mean "this script's directory":
+// $(dirname "$0")/, ${BASH_SOURCE%/*}/, "$DIR"/, ${SCRIPT_DIR}/ ...
+const SCRIPT_DIR_PREFIX = new RegExp('^(?:' + [
+    // $(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)
+    String.raw`"?\$\(cd\s+"?\$\(dirname\s+"?\$\{?(?:0|BASH_SOURCE(?:\[0\])?)\}?"?\)"?\s*&&\s*pwd\)"?`,
+    // $(dirname "$0") / $(dirname "${BASH_SOURCE[0]}")
+    String.raw`"?\$\(dirname\s+"?\$\{?(?:0|BASH_SOURCE(?:\[0\])?)\}?"?\)"?`,
+    // ${BASH_SOURCE%/*}
+    String.raw`"?\$\{BASH_SOURCE(?:\[0\])?%\/\*\}"?`,
+    // $DIR / ${SCRIPT_DIR} — a script-local directory variable (not $HOME/XDG)
+    String.raw`"?\$\{?(?!HOME\b|XDG_)[A-Z_][A-Z0-9_]*\}?"?`,
+].join('|') + ')/');
+
+function commandName(node) {
+    const nameNode = node.childForFieldName('name');
+    if (!nameNode) return null;
+    const word = nameNode.namedChild(0) || nameNode;
+    return word.type === 'word' ? word : null;
+}
+
+function commandArgs(node) {
+    const args = [];
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (sameNode(child, node.childForFieldName('name'))) continue;
+        if (child.type === 'variable_assignment' || child.type === 'file_redirect' ||
+            child.type === 'herestring_redirect') continue;
+        args.push(child);
+    }
+    return args;
+}
+
+/** Literal text of a word/string argument, else null (expansions are dynamic) */
+function literalText(node) {
+    if (!node) return null;
+    if (node.type === 'word') return node.text;
+    if (node.type === 'raw_string') return node.text.slice(1, -1);
+    if (node.type === 'string') {
+        if (node.namedChildren.some(c => c.type !== 'string_content')) return null;
+        return node.text.slice(1, -1);
+    }
+    return null;
+}
+
+/**
+ * Function names a builtin receives as arguments:
+ *   trap 'cleanup; restore' EXIT INT   → cleanup, restore
+ *   complete -F _mycmd_complete mycmd  → _mycmd_complete
+ */
+function callbackNames(cmd, args) {
+    if (cmd === 'trap' && args.length > 0) {
+        const handler = literalText(args[0]);
+        if (!handler || handler === '-' || /^\d+$/.test(handler)) return [];
+        return handler.split(/[;&|]+/).map(s => s.trim().split(/\s+/)[0]).filter(Boolean);
+    }
+    if (cmd === 'complete' || cmd === 'compdef') {
+        const idx = args.findIndex(a => a.text === '-F');
+        if (idx !== -1 && args[idx + 1]) return [args[idx + 1].text];
+        if (cmd === 'compdef' && args[0]) return [args[0].text];
+    }
+    return [];
+}
+
+/** `export -f a b` / `declare -fx a` → exported function names */
+function exportedFunctionNames(tree) {
+    const names = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'declaration_command') return true;
+        const text = node.text;
+        if (!/^(export\s+-f|declare\s+-[a-z]*f[a-z]*x|declare\s+-[a-z]*x[a-z]*f)\b/.test(text)) return false;
+        for (const word of text.split(/\s+/).slice(2)) {
+            if (/^[\w:-]+$/.test(word)) names.push(word);
+        }
+        return false;
+    });
+    return names;
+}
+
+/** `# comment` block directly above a function */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    if (i < 0 || !/^\s*#(?!!)/.test(lines[i])) return null;
+    while (i > 0 && /^\s*#(?!!)/.test(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*#+\s?/, '').trim() || null;
+}
+
+function collectFunctions(tree, lines) {
+    const functions = [];
+    const exported = new Set(exportedFunctionNames(tree));
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'function_definition') return true;
+        const nameNode = node.childForFieldName('name');
+        if (!nameNode) return true;
+        const { startLine, endLine, indent } = nodeToLocation(node, lines);
+        const docstring = extractDocstring(lines, startLine);
+        let isNested = false;
+        for (let p = node.parent; p; p = p.parent) {
+            if (p.type === 'function_definition') { isNested = true; break; }
+        }
+        functions.push({
+            name: nameNode.text,
+            // Shell functions take positional arguments ($1, $@) — no signature
+            params: '',
+            paramsStructured: [],
+            startLine,
+            endLine,
+            indent,
+            modifiers: exported.has(nameNode.text) ? ['export'] : [],
+            ...(isNested && { isNested: true }),
+            ...(docstring && { docstring }),
+        });
+        return true;
+    });
+    functions.sort((a, b) => a.startLine - b.startLine);
+    return functions;
+}
+
+/** Top-level `readonly NAME=...` / `declare -r NAME=...` / `NAME=...` */
+function collectState(tree, lines) {
+    const objects = [];
+    const root = tree.rootNode;
+    for (let i = 0; i < root.namedChildCount; i++) {
+        const node = root.namedChild(i);
+        let assignments = [];
+        let readonly = false;
+        if (node.type === 'variable_assignment') {
+            assignments = [node];
+        } else if (node.type === 'declaration_command') {
+            readonly = /^(readonly|declare\s+-[a-z]*r)/.test(node.text);
+            assignments = node.namedChildren.filter(c => c.type === 'variable_assignment');
+        }
+        for (const a of assignments) {
+            const nameNode = a.childForFieldName('name');
+            // Config-shaped only: UPPER_CASE names
+            if (!nameNode || !/^[A-Z][A-Z0-9_]*$/.test(nameNode.text)) continue;
+            const { startLine, endLine } = nodeToLocation(node, lines);
+            objects.push({
+                name: nameNode.text,
+                startLine, endLine,
+                ...(readonly && { isConst: true }),
+            });
+        }
+    }
+    return objects;
+}
+
+function findFunctions(code, parser) {
+    return collectFunctions(parseTree(parser, code), code.split('\n'));
+}
+
+function findClasses(code, parser) {
+    return [];
+}
+
+function findStateObjects(code, parser) {
+    return collectState(parseTree(parser, code), code.split('\n'));
+}
+
+/**
+ * Parse a shell script completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    return {
+        language: 'bash',
+        totalLines: lines.length,
+        functions: collectFunctions(tree, lines),
+        classes: [],
+        stateObjects: collectState(tree, lines),
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find all command invocations in shell code
+ *
+ *   deploy "$env"          → call to deploy
+ *   trap cleanup EXIT      → call to cleanup (handler invoked by the shell)
+ *   complete -F _comp cmd  → call to _comp
+ *
+ * Every command is recorded — builtins and external programs simply never
+ * resolve to a project function.
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    const functionStack = [];
+
+    const getCurrentEnclosingFunction = () => {
+        return functionStack.length > 0
+            ? { ...functionStack[functionStack.length - 1] }
+            : null;
+    };
+
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'function_definition') {
+            const nameNode = node.childForFieldName('name');
+            functionStack.push({
+                name: nameNode ? nameNode.text : '<anonymous>',
+                startLine: node.startPosition.row + 1,
+                endLine: node.endPosition.row + 1,
+            });
+            return true;
+        }
+        if (node.type !== 'command') return true;
+        const word = commandName(node);
+        if (!word) return true;
+        const args = commandArgs(node);
+        const enclosingFunction = getCurrentEnclosingFunction();
+        calls.push({
+            name: word.text,
+            line: word.startPosition.row + 1,
+            isMethod: false,
+            argCount: args.length,
+            enclosingFunction,
+            uncertain: false,
+        });
+        for (const name of callbackNames(word.text, args)) {
+            calls.push({
+                name,
+                line: node.startPosition.row + 1,
+                isMethod: false,
+                isFunctionReference: true,
+                enclosingFunction,
+                uncertain: false,
+            });
+        }
+        return true;
+    }, {
+        onLeave: (node) => {
+            if (node.type === 'function_definition') functionStack.pop();
+        }
+    });
+
+    return calls;
+}
+
+/**
+ * Normalize a sourced path: a leading script-directory expression
+ * (`$(dirname "$0")/`, `${BASH_SOURCE%/*}/`, `$DIR/`) becomes `./`.
+ * Returns null when the path is still dynamic after that.
+ */
+function normalizeSourcePath(text) {
+    let p = text.trim();
+    const stripped = p.replace(SCRIPT_DIR_PREFIX, './');
+    p = stripped.replace(/^"(.*)"$/, '$1').replace(/^'(.*)'$/, '$1').replace(/"/g, '');
+    if (/[$`*?]/.test(p)) return null;
+    return p;
+}
+
+/**
+ * Find sourced scripts: `source lib/common.sh`, `. "$(dirname "$0")/env.sh"`.
+ * A path built from other variables is dynamic.
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const imports = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'command') return true;
+        const word = commandName(node);
+        if (!word || (word.text !== 'source' && word.text !== '.')) return true;
+        const arg = commandArgs(node)[0];
+        if (!arg) return false;
+        const line = node.startPosition.row + 1;
+        const module = normalizeSourcePath(arg.text);
+        if (module) {
+            imports.push({ module, names: [], type: 'source', line });
+        } else {
+            imports.push({ module: arg.text, names: [], type: 'source', line, dynamic: true });
+        }
+        return false;
+    });
+    return imports;
+}
+
+/**
+ * Exported functions: `export -f name` / `declare -fx name`
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const exported = new Set(exportedFunctionNames(tree));
+    return collectFunctions(tree, lines)
+        .filter(fn => exported.has(fn.name))
+        .map(fn => ({ name: fn.name, type: 'export -f', line: fn.startLine }));
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+
+    visitNameNodes(tree, code, name, (node) => {
+        if (node.text !== name) return true;
+        if (node.type !== 'word' && node.type !== 'variable_name') return true;
+        const line = node.startPosition.row + 1;
+        const column = node.startPosition.column;
+        const parent = node.parent;
+        let usageType = 'reference';
+
+        if (parent?.type === 'function_definition' && sameNode(parent.childForFieldName('name'), node)) {
+            usageType = 'definition';
+        } else if (parent?.type === 'command_name') {
+            usageType = 'call';
+        } else if (parent?.type === 'variable_assignment' && sameNode(parent.childForFieldName('name'), node)) {
+            usageType = 'definition';
+        }
+
+        usages.push({ line, column, usageType });
+        return true;
+    });
+
+    return usages;
+}
+
+/**
+ * Classify a shell entry point:
+ * - 'test':      bats/shunit2 hooks and shunit2 `test*` functions
+ * - 'framework': hooks the shell invokes itself (command_not_found_handle)
+ */
+function getEntryPointKind(symbol) {
+    if (TEST_HOOKS.has(symbol.name)) return 'test';
+    if (/^test[A-Z_]/.test(symbol.name)) return 'test';
+    if (SHELL_HOOKS.has(symbol.name)) return 'framework';
+    return null;
+}
+
+/**
+ * Check if a symbol is a shell-convention entry point.
+ */
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    normalizeSourcePath,
+    parse
+};
@@ -0,0 +1,736 @@
+/**
+ * languages/dart.js - Tree-sitter based Dart parsing
+ *
+ * Handles: top-level functions/getters, classes (with members), mixins,
+ * extensions, enums, top-level const/final declarations, and directives —
+ * import/export, `part 'x.dart'` and `part of`.
+ *
+ * Visibility is library-level: a leading underscore makes a name private to
+ * its LIBRARY, which spans the defining file plus its `part` files (see
+ * partFiles — graph-build stitches them into one unit). Everything else is
+ * public API.
+ *
+ * Flutter: framework-invoked widget/state lifecycle methods (build,
+ * initState, dispose, ...) and @override members are entry points — the
+ * framework calls them, no project code does.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+    visitNameNodes,
+    sameNode,
+    extractJSDocstring,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+// Members the Flutter framework (and dart:convert) invoke by contract on
+// widgets, states, painters, render objects and bindings observers.
+const FLUTTER_LIFECYCLE = new Set([
+    'build', 'createState', 'initState', 'didChangeDependencies', 'didUpdateWidget',
+    'deactivate', 'activate', 'dispose', 'reassemble', 'debugFillProperties',
+    'createElement', 'updateShouldNotify', 'createRenderObject', 'updateRenderObject',
+    'didUnmountRenderObject', 'paint', 'shouldRepaint', 'shouldRebuildSemantics',
+    'performLayout', 'hitTest', 'didChangeAppLifecycleState', 'didChangeMetrics',
+    'didChangePlatformBrightness', 'didHaveMemoryPressure', 'didPopRoute', 'didPushRoute',
+    'toJson', 'noSuchMethod', 'toString',
+]);
+
+const CLASS_NODE_TYPES = {
+    class_definition: 'class',
+    mixin_declaration: 'class',
+    extension_declaration: 'class',
+    enum_declaration: 'enum',
+};
+
+const SIGNATURE_TYPES = new Set([
+    'function_signature', 'getter_signature', 'setter_signature',
+]);
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+function findDescendant(node, types) {
+    let found = null;
+    traverseTree(node, (n) => {
+        if (found) return false;
+        if (types.has(n.type)) { found = n; return false; }
+        return true;
+    });
+    return found;
+}
+
+/** First string literal under a directive, unquoted */
+function directiveUri(node) {
+    const str = findDescendant(node, new Set(['string_literal']));
+    return str ? str.text.replace(/^r?(['"]{1,3})|(['"]{1,3})$/g, '') : null;
+}
+
+function nameOf(node) {
+    return node.childForFieldName('name') || namedChildOfType(node, 'identifier');
+}
+
+/** Annotations directly preceding a declaration (siblings in the grammar) */
+function precedingAnnotations(node) {
+    const names = [];
+    for (let prev = node.previousNamedSibling; prev; prev = prev.previousNamedSibling) {
+        if (prev.type !== 'annotation' && prev.type !== 'marker_annotation') break;
+        names.push(prev.text.replace(/^@/, '').replace(/\(.*$/s, '').trim());
+    }
+    return names;
+}
+
+/** Keyword tokens (static, abstract, const, ...) of a declaration, outside its signature/body */
+function keywordTokens(node) {
+    const kws = new Set();
+    const visit = (n) => {
+        for (const child of n.children) {
+            if (!child.isNamed) {
+                if (/^[a-z]+$/.test(child.type)) kws.add(child.type);
+                continue;
+            }
+            if (SIGNATURE_TYPES.has(child.type) || child.type === 'formal_parameter_list' ||
+                child.type === 'function_body' || child.type.endsWith('body')) continue;
+            visit(child);
+        }
+    };
+    visit(node);
+    return kws;
+}
+
+/** `///` doc block (or `/** *\/`) above a declaration, skipping annotations */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    while (i >= 0 && (/^\s*@/.test(lines[i]) || lines[i].trim() === '')) i--;
+    if (i >= 0 && /^\s*\/\/\//.test(lines[i])) {
+        while (i > 0 && /^\s*\/\/\//.test(lines[i - 1])) i--;
+        return lines[i].trim().replace(/^\/\/\/\s?/, '') || null;
+    }
+    return extractJSDocstring(lines, startLine);
+}
+
+function extractParams(sigNode) {
+    const paramsNode = namedChildOfType(sigNode, 'formal_parameter_list') ||
+        sigNode.childForFieldName('parameters');
+    if (!paramsNode) return { params: sigNode.type === 'getter_signature' ? '' : '...', paramsStructured: [] };
+    const paramsStructured = [];
+    traverseTree(paramsNode, (n) => {
+        if (n.type === 'formal_parameter') {
+            const ident = nameOf(n);
+            if (ident) {
+                const text = n.text;
+                const type = text.slice(0, text.lastIndexOf(ident.text)).trim();
+                paramsStructured.push({
+                    name: ident.text,
+                    ...(type && !/^(this\.|super\.)$/.test(type) && { type }),
+                    ...(n.parent?.type === 'optional_formal_parameters' && { optional: true }),
+                });
+            }
+            return false;
+        }
+        return true;
+    });
+    return {
+        params: paramsNode.text.replace(/^\(|\)$/g, '').trim(),
+        paramsStructured,
+    };
+}
+
+function returnTypeOf(sigNode, nameNode) {
+    if (!nameNode) return null;
+    const prefix = sigNode.text.slice(0, nameNode.startIndex - sigNode.startIndex)
+        .replace(/\b(static|external|abstract|get|set|covariant|late)\b/g, '').trim();
+    return prefix || null;
+}
+
+/**
+ * Resolve a signature-bearing node (method_signature, declaration, or a bare
+ * signature) to the function it declares. Constructors, factories and
+ * operators are not emitted — `Foo(...)` calls resolve to the class itself.
+ */
+function signatureOf(node) {
+    if (SIGNATURE_TYPES.has(node.type)) return node;
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (SIGNATURE_TYPES.has(child.type)) return child;
+        if (child.type === 'constructor_signature' || child.type === 'factory_constructor_signature' ||
+            child.type === 'redirecting_factory_constructor_signature' || child.type === 'operator_signature') {
+            return null;
+        }
+    }
+    return null;
+}
+
+function buildFunction(declNode, sigNode, bodyNode, lines, extra = {}) {
+    const nameNode = nameOf(sigNode);
+    if (!nameNode) return null;
+    const startNode = declNode;
+    const { startLine, indent } = nodeToLocation(startNode, lines);
+    const endLine = (bodyNode || declNode).endPosition.row + 1;
+    const { params, paramsStructured } = extractParams(sigNode);
+    const annotations = precedingAnnotations(declNode);
+    const kws = keywordTokens(declNode);
+    const modifiers = [];
+    if (nameNode.text.startsWith('_')) modifiers.push('private');
+    for (const kw of ['static', 'abstract', 'external']) if (kws.has(kw)) modifiers.push(kw);
+    if (sigNode.type === 'getter_signature') modifiers.push('get');
+    if (sigNode.type === 'setter_signature') modifiers.push('set');
+    for (const a of annotations) {
+        const lower = a.toLowerCase();
+        if (!modifiers.includes(lower)) modifiers.push(lower);
+    }
+    if (!bodyNode && !kws.has('external') && extra.memberOf) modifiers.push('abstract');
+    const returnType = returnTypeOf(sigNode, nameNode);
+    const docstring = extractDocstring(lines, startLine);
+    const bodyText = bodyNode ? bodyNode.text : '';
+    const nameLine = nameNode.startPosition.row + 1;
+    return {
+        name: nameNode.text,
+        params,
+        paramsStructured,
+        startLine,
+        endLine,
+        indent,
+        modifiers,
+        ...(returnType && { returnType }),
+        ...(/^async\b/.test(bodyText) && { isAsync: true }),
+        ...(/^(sync|async)\*/.test(bodyText) && { isGenerator: true }),
+        ...(docstring && { docstring }),
+        ...(nameLine !== startLine && { nameLine }),
+    };
+}
+
+/** The function_body that follows a signature, when the declaration has one */
+function bodyAfter(node) {
+    const next = node.nextNamedSibling;
+    return next && next.type === 'function_body' ? next : null;
+}
+
+/** Top-level functions and getters: `function_signature` + `function_body` siblings in `program` */
+function collectFunctions(tree, lines) {
+    const functions = [];
+    const root = tree.rootNode;
+    for (let i = 0; i < root.namedChildCount; i++) {
+        const node = root.namedChild(i);
+        const sig = signatureOf(node);
+        if (!sig) continue;
+        const fn = buildFunction(node, sig, bodyAfter(node), lines);
+        if (fn) functions.push(fn);
+    }
+    return functions;
+}
+
+function classBody(node) {
+    return node.childForFieldName('body') ||
+        namedChildOfType(node, 'class_body') ||
+        namedChildOfType(node, 'extension_body') ||
+        namedChildOfType(node, 'enum_body');
+}
+
+/** Declared field names under a member `declaration` */
+function fieldNames(declNode) {
+    const names = [];
+    traverseTree(declNode, (n) => {
+        if (n.type === 'initialized_identifier' || n.type === 'static_final_declaration') {
+            const ident = namedChildOfType(n, 'identifier');
+            if (ident) names.push(ident);
+            return false;
+        }
+        if (n.type === 'initialized_identifier_list' || n.type === 'identifier_list' ||
+            n.type === 'static_final_declaration_list') {
+            return true;
+        }
+        if (n !== declNode && n.type !== 'type_identifier' && n.isNamed && n.parent === declNode &&
+            n.type === 'identifier' && n.nextSibling?.type === ';') {
+            names.push(n);
+        }
+        return n === declNode;
+    });
+    return names;
+}
+
+function extractMembers(classNode, lines) {
+    const members = [];
+    const body = classBody(classNode);
+    if (!body) return members;
+
+    if (body.type === 'enum_body') {
+        for (let i = 0; i < body.namedChildCount; i++) {
+            const c = body.namedChild(i);
+            if (c.type !== 'enum_constant') continue;
+            const ident = nameOf(c);
+            if (!ident) continue;
+            const { startLine, endLine } = nodeToLocation(c, lines);
+            members.push({ name: ident.text, startLine, endLine, memberType: 'constant' });
+        }
+    }
+
+    for (let i = 0; i < body.namedChildCount; i++) {
+        const child = body.namedChild(i);
+        if (child.type !== 'method_signature' && child.type !== 'declaration') continue;
+        const sig = signatureOf(child);
+        if (sig) {
+            const fn = buildFunction(child, sig, bodyAfter(child), lines, { memberOf: true });
+            if (!fn) continue;
+            const memberType = fn.modifiers.includes('static') ? 'static'
+                : fn.modifiers.includes('get') ? 'get'
+                : fn.modifiers.includes('set') ? 'set'
+                : fn.modifiers.includes('abstract') ? 'abstract' : 'method';
+            members.push({ ...fn, memberType, isMethod: true });
+            continue;
+        }
+        if (child.type !== 'declaration' || findDescendant(child, new Set(['constructor_signature',
+            'factory_constructor_signature', 'redirecting_factory_constructor_signature']))) continue;
+        // Field declarations: `final String title;`, `static const max = 3;`
+        const kws = keywordTokens(child);
+        const typeNode = namedChildOfType(child, 'type_identifier');
+        for (const ident of fieldNames(child)) {
+            const { startLine, endLine } = nodeToLocation(child, lines);
+            members.push({
+                name: ident.text,
+                startLine,
+                endLine,
+                memberType: 'field',
+                modifiers: [
+                    ...(ident.text.startsWith('_') ? ['private'] : []),
+                    ...(kws.has('static') ? ['static'] : []),
+                    ...(kws.has('final') ? ['final'] : []),
+                    ...(kws.has('const') ? ['const'] : []),
+                ],
+                ...(typeNode && { fieldType: child.text.slice(typeNode.startIndex - child.startIndex).split(/\s+/)[0] }),
+            });
+        }
+    }
+    return members;
+}
+
+/** `extends A with M1, M2` → extends 'A', implements includes mixins */
+function superTypes(node) {
+    const sup = node.childForFieldName('superclass') || namedChildOfType(node, 'superclass');
+    const ifaces = node.childForFieldName('interfaces') || namedChildOfType(node, 'interfaces');
+    const onClause = namedChildOfType(node, 'mixin_application_class') ||
+        (node.type === 'mixin_declaration' ? namedChildOfType(node, 'type_not_void_list') : null);
+    let extendsInfo = null;
+    const impl = [];
+    const strip = s => s.replace(/<.*$/s, '').trim();
+    if (sup) {
+        const m = sup.text.match(/extends\s+([\w.]+)/);
+        if (m) extendsInfo = m[1];
+        const w = sup.text.match(/\bwith\s+(.+)$/s);
+        if (w) impl.push(...w[1].split(',').map(strip).filter(Boolean));
+    }
+    if (ifaces) impl.push(...ifaces.text.replace(/^implements\s+/, '').split(',').map(strip).filter(Boolean));
+    if (onClause) impl.push(...onClause.text.split(',').map(strip).filter(Boolean));
+    return { extendsInfo, impl };
+}
+
+function collectClasses(tree, lines) {
+    const classes = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        const kind = CLASS_NODE_TYPES[node.type];
+        if (!kind) return true;
+        const nameNode = nameOf(node);
+        // Unnamed extensions (`extension on String`) add members to a
+        // foreign type and cannot be referenced — nothing to audit.
+        if (!nameNode) return true;
+        const { startLine, endLine } = nodeToLocation(node, lines);
+        const kws = keywordTokens(node);
+        const modifiers = [];
+        if (nameNode.text.startsWith('_')) modifiers.push('private');
+        if (node.type === 'mixin_declaration') modifiers.push('mixin');
+        if (node.type === 'extension_declaration') modifiers.push('extension');
+        for (const kw of ['abstract', 'sealed', 'base', 'final', 'interface']) if (kws.has(kw)) modifiers.push(kw);
+        for (const a of precedingAnnotations(node)) modifiers.push(a.toLowerCase());
+        const { extendsInfo, impl } = superTypes(node);
+        const docstring = extractDocstring(lines, startLine);
+        classes.push({
+            name: nameNode.text,
+            startLine,
+            endLine,
+            type: kind,
+            members: extractMembers(node, lines),
+            modifiers,
+            ...(docstring && { docstring }),
+            ...(extendsInfo && { extends: extendsInfo }),
+            ...(impl.length > 0 && { implements: impl }),
+        });
+        return false;
+    });
+    classes.sort((a, b) => a.startLine - b.startLine);
+    return classes;
+}
+
+/** Top-level `const`/`final` declarations (configuration-shaped state) */
+function collectState(tree, lines) {
+    const objects = [];
+    const root = tree.rootNode;
+    for (let i = 0; i < root.namedChildCount; i++) {
+        const node = root.namedChild(i);
+        if (signatureOf(node) || CLASS_NODE_TYPES[node.type]) continue;
+        if (node.type === 'import_or_export' || node.type.endsWith('_directive') || node.type === 'library_name') continue;
+        const kws = keywordTokens(node);
+        if (!kws.has('const') && !kws.has('final')) continue;
+        for (const ident of fieldNames(node)) {
+            const { startLine, endLine } = nodeToLocation(node, lines);
+            objects.push({
+                name: ident.text,
+                startLine, endLine,
+                modifiers: ident.text.startsWith('_') ? ['private'] : [],
+                ...(kws.has('const') && { isConst: true }),
+            });
+        }
+    }
+    return objects;
+}
+
+/** `part 'src/a.dart';` URIs — files compiled into this library */
+function collectParts(tree) {
+    const parts = [];
+    const root = tree.rootNode;
+    for (let i = 0; i < root.namedChildCount; i++) {
+        const node = root.namedChild(i);
+        if (node.type !== 'part_directive') continue;
+        const uri = directiveUri(node);
+        if (uri) parts.push(uri);
+    }
+    return parts;
+}
+
+function findFunctions(code, parser) {
+    return collectFunctions(parseTree(parser, code), code.split('\n'));
+}
+
+function findClasses(code, parser) {
+    return collectClasses(parseTree(parser, code), code.split('\n'));
+}
+
+function findStateObjects(code, parser) {
+    return collectState(parseTree(parser, code), code.split('\n'));
+}
+
+/**
+ * Parse a Dart file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const parts = collectParts(tree);
+    return {
+        language: 'dart',
+        totalLines: lines.length,
+        functions: collectFunctions(tree, lines),
+        classes: collectClasses(tree, lines),
+        stateObjects: collectState(tree, lines),
+        ...(parts.length > 0 && { partFiles: parts }),
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/** Is this `selector` node an argument list (a call)? */
+function isArgumentSelector(node) {
+    return node?.type === 'selector' && namedChildOfType(node, 'argument_part') !== null;
+}
+
+/** Name identifier of a `.name` / `?.name` selector, else null */
+function selectorName(node) {
+    if (node?.type !== 'selector') return null;
+    const sel = namedChildOfType(node, 'unconditional_assignable_selector') ||
+        namedChildOfType(node, 'conditional_assignable_selector');
+    return sel ? namedChildOfType(sel, 'identifier') : null;
+}
+
+/**
+ * Find all function calls in Dart code
+ *
+ * The grammar models postfix chains as sibling sequences:
+ *   foo(x)        → identifier, selector(argument_part)
+ *   a.b.foo(x)    → identifier, selector(.b), selector(.foo), selector(argument_part)
+ *   ..foo(x)      → cascade_section(cascade_selector, argument_part)
+ *   new Foo(x) / const Foo(x) → constructor calls
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [options] - { imports: import prefix names }
+ * @returns {Array<{name: string, line: number, isMethod: boolean, receiver?: string, isConstructor?: boolean}>}
+ */
+function findCallsInCode(code, parser, options = {}) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    const importNames = new Set(options.imports || []);
+    const functionStack = [];
+    const bodyStack = [];  // startIndex of the function_body owning each frame
+
+    const getCurrentEnclosingFunction = () => {
+        return functionStack.length > 0
+            ? { ...functionStack[functionStack.length - 1] }
+            : null;
+    };
+
+    const argCountOf = (node) => {
+        const args = findDescendant(node, new Set(['arguments']));
+        return args ? args.namedChildren.filter(a => a.type !== 'comment').length : 0;
+    };
+
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'function_body') {
+            const sigHolder = node.previousNamedSibling;
+            const sig = sigHolder && signatureOf(sigHolder);
+            const nameNode = sig && nameOf(sig);
+            if (nameNode) {
+                functionStack.push({
+                    name: nameNode.text,
+                    startLine: sigHolder.startPosition.row + 1,
+                    endLine: node.endPosition.row + 1,
+                });
+                bodyStack.push(node.startIndex);
+            }
+            return true;
+        }
+
+        if (isArgumentSelector(node)) {
+            const prev = node.previousNamedSibling;
+            if (!prev) return true;
+            const line = node.startPosition.row + 1;
+            const argCount = argCountOf(node);
+            const enclosingFunction = getCurrentEnclosingFunction();
+            if (prev.type === 'identifier') {
+                calls.push({
+                    name: prev.text,
+                    line,
+                    isMethod: false,
+                    argCount,
+                    enclosingFunction,
+                    ...(/^[A-Z]/.test(prev.text) && { isConstructor: true }),
+                    uncertain: false,
+                });
+                return true;
+            }
+            const ident = selectorName(prev);
+            if (!ident) return true;
+            // Receiver: the chain text before the `.name` selector
+            const siblings = node.parent ? node.parent.namedChildren : [];
+            const idx = siblings.findIndex(s => sameNode(s, prev));
+            const receiver = siblings.slice(0, idx).map(s => s.text).join('').replace(/[?!]$/, '');
+            const isPrefix = importNames.has(receiver) && /^[a-z_]/.test(receiver);
+            calls.push({
+                name: ident.text,
+                line,
+                isMethod: !isPrefix,
+                ...(receiver && { receiver }),
+                argCount,
+                enclosingFunction,
+                uncertain: false,
+            });
+            return true;
+        }
+
+        if (node.type === 'cascade_section') {
+            const sel = namedChildOfType(node, 'cascade_selector');
+            const ident = sel && namedChildOfType(sel, 'identifier');
+            if (ident && namedChildOfType(node, 'argument_part')) {
+                calls.push({
+                    name: ident.text,
+                    line: ident.startPosition.row + 1,
+                    isMethod: true,
+                    argCount: argCountOf(node),
+                    enclosingFunction: getCurrentEnclosingFunction(),
+                    uncertain: true,
+                });
+            }
+            return true;
+        }
+
+        if (node.type === 'new_expression' || node.type === 'const_object_expression') {
+            const typeNode = namedChildOfType(node, 'type_identifier') || namedChildOfType(node, 'identifier');
+            if (typeNode) {
+                // `new Foo.named()` constructs Foo
+                calls.push({
+                    name: typeNode.text.split('.')[0],
+                    line: typeNode.startPosition.row + 1,
+                    isMethod: false,
+                    isConstructor: true,
+                    argCount: argCountOf(node),
+                    enclosingFunction: getCurrentEnclosingFunction(),
+                    uncertain: false,
+                });
+            }
+            return true;
+        }
+        return true;
+    }, {
+        onLeave: (node) => {
+            if (node.type === 'function_body' && bodyStack[bodyStack.length - 1] === node.startIndex) {
+                bodyStack.pop();
+                functionStack.pop();
+            }
+        }
+    });
+
+    return calls;
+}
+
+/**
+ * Find directives:
+ *   import 'package:app/x.dart' as x show A, B;  → names [x] / [A, B]
+ *   export 'src/y.dart';                         → type 'export' (re-export)
+ *   part 'src/z.dart';                           → type 'part'
+ *   part of 'lib.dart'; / part of lib.name;      → type 'part-of'
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const imports = [];
+    const root = tree.rootNode;
+    for (let i = 0; i < root.namedChildCount; i++) {
+        const node = root.namedChild(i);
+        const line = node.startPosition.row + 1;
+        if (node.type === 'import_or_export') {
+            const uri = directiveUri(node);
+            if (!uri) continue;
+            const isExport = findDescendant(node, new Set(['library_export'])) !== null;
+            const text = node.text;
+            const prefix = text.match(/\bas\s+(\w+)/);
+            const show = text.match(/\bshow\s+([\w\s,]+?)\s*(?:hide\b|;|$)/);
+            const names = prefix ? [prefix[1]]
+                : show ? show[1].split(',').map(s => s.trim()).filter(Boolean) : [];
+            imports.push({
+                module: uri,
+                names,
+                type: isExport ? 'export' : (text.includes('deferred') ? 'deferred' : 'import'),
+                line,
+            });
+        } else if (node.type === 'part_directive') {
+            const uri = directiveUri(node);
+            if (uri) imports.push({ module: uri, names: [], type: 'part', line });
+        } else if (node.type === 'part_of_directive') {
+            const uri = directiveUri(node);
+            // `part of lib.name;` names the library, not a file — unresolvable
+            // here; graph-build links it from the library's `part` side.
+            if (uri) imports.push({ module: uri, names: [], type: 'part-of', line });
+        }
+    }
+    return imports;
+}
+
+/**
+ * Library API: every top-level declaration whose name has no leading
+ * underscore, plus re-exported libraries.
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const exports = [];
+    const push = (name, type, line) => {
+        if (!name.startsWith('_')) exports.push({ name, type, line });
+    };
+    for (const fn of collectFunctions(tree, lines)) push(fn.name, 'function', fn.startLine);
+    for (const cls of collectClasses(tree, lines)) push(cls.name, cls.type, cls.startLine);
+    for (const s of collectState(tree, lines)) push(s.name, 'state', s.startLine);
+    for (const imp of findImportsInCode(code, parser)) {
+        if (imp.type === 'export') exports.push({ name: '*', type: 're-export', source: imp.module, line: imp.line });
+    }
+    exports.sort((a, b) => a.line - b.line);
+    return exports;
+}
+
+const DEF_PARENT_TYPES = new Set([
+    'function_signature', 'getter_signature', 'setter_signature', 'class_definition',
+    'mixin_declaration', 'extension_declaration', 'enum_declaration', 'enum_constant',
+    'formal_parameter', 'initialized_identifier', 'static_final_declaration', 'type_alias',
+]);
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+
+    visitNameNodes(tree, code, name, (node) => {
+        if ((node.type !== 'identifier' && node.type !== 'type_identifier') || node.text !== name) return true;
+        const line = node.startPosition.row + 1;
+        const column = node.startPosition.column;
+        const parent = node.parent;
+        let usageType = 'reference';
+
+        if (parent) {
+            if (DEF_PARENT_TYPES.has(parent.type) && sameNode(nameOf(parent), node)) {
+                usageType = 'definition';
+            } else if (parent.type === 'combinator' || parent.type === 'import_specification') {
+                usageType = 'import';
+            } else if (isArgumentSelector(node.nextNamedSibling)) {
+                usageType = 'call';
+            } else if (parent.type === 'unconditional_assignable_selector' ||
+                       parent.type === 'conditional_assignable_selector') {
+                const selector = parent.parent;
+                const siblings = selector?.parent ? selector.parent.namedChildren : [];
+                const idx = siblings.findIndex(s => sameNode(s, selector));
+                const receiver = siblings.slice(0, idx).map(s => s.text).join('');
+                usageType = isArgumentSelector(selector?.nextNamedSibling) ? 'call' : 'reference';
+                usages.push({ line, column, usageType, ...(receiver && { receiver }) });
+                return true;
+            } else if (parent.type === 'new_expression' || parent.type === 'const_object_expression') {
+                usageType = 'call';
+            }
+        }
+
+        usages.push({ line, column, usageType });
+        return true;
+    });
+
+    return usages;
+}
+
+/**
+ * Classify a Dart entry point:
+ * - 'main':      top-level `main()` (apps, scripts and test files alike)
+ * - 'framework': @override members, Flutter lifecycle methods (build,
+ *                initState, dispose, ...) and named extensions (applied by
+ *                the type system to their `on` type, never named at use sites)
+ */
+function getEntryPointKind(symbol) {
+    const mods = symbol.modifiers || [];
+    if (symbol.name === 'main' && !symbol.className) return 'main';
+    if (mods.includes('override')) return 'framework';
+    if (mods.includes('extension')) return 'framework';
+    if (symbol.className && FLUTTER_LIFECYCLE.has(symbol.name)) return 'framework';
+    return null;
+}
+
+/**
+ * Check if a symbol is a Dart-convention entry point.
+ */
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    parse
+};
@@ -0,0 +1,722 @@
+/**
+ * languages/elixir.js - Tree-sitter based Elixir parsing
+ *
+ * Handles: defmodule (nested too), def/defp/defmacro/defmacrop/defguard/
+ * defdelegate with public vs private visibility, module attributes as state,
+ * and the alias/import/require/use directives.
+ *
+ * Elixir has almost no dedicated syntax — every definition is a macro CALL
+ * (`def name(args) do ... end` is call(target: def, arguments: call(name))),
+ * so the walkers below classify `call` nodes by their target identifier.
+ *
+ * OTP behaviour callbacks (GenServer.handle_call, Supervisor.init,
+ * Application.start, ...) are dispatched by the runtime, never called by
+ * name — they carry a 'callback' modifier and isEntryPoint() keeps them out
+ * of deadcode, while an unused `defp` stays claimable.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+    visitNameNodes,
+    sameNode,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+// Definition macros → visibility modifier
+const DEF_KINDS = {
+    def: 'public',
+    defp: 'private',
+    defmacro: 'public',
+    defmacrop: 'private',
+    defguard: 'public',
+    defguardp: 'private',
+    defdelegate: 'public',
+};
+
+const DIRECTIVES = new Set(['alias', 'import', 'require', 'use']);
+
+// Special forms and definition macros — calls to these are syntax, not
+// references to project functions.
+const NON_CALL_TARGETS = new Set([
+    ...Object.keys(DEF_KINDS), ...DIRECTIVES,
+    'defmodule', 'defstruct', 'defexception', 'defprotocol', 'defimpl',
+    'defoverridable', 'if', 'unless', 'case', 'cond', 'with', 'for', 'try',
+    'receive', 'quote', 'unquote', 'unquote_splicing', 'fn', 'raise',
+    'reraise', 'throw', 'super', '__MODULE__', '__ENV__', '__CALLER__',
+    '__DIR__', '__STACKTRACE__',
+]);
+
+// Callbacks each behaviour's runtime invokes. `use X` / `@behaviour X`
+// activates the set; an explicit `@impl` marks a single def regardless.
+const BEHAVIOUR_CALLBACKS = {
+    GenServer: ['init', 'handle_call', 'handle_cast', 'handle_info', 'handle_continue',
+        'terminate', 'code_change', 'format_status', 'child_spec', 'start_link'],
+    Supervisor: ['init', 'child_spec', 'start_link'],
+    DynamicSupervisor: ['init', 'child_spec', 'start_link'],
+    Agent: ['child_spec', 'start_link'],
+    Task: ['child_spec', 'start_link', 'run'],
+    Application: ['start', 'stop', 'prep_stop', 'config_change', 'start_phase'],
+    GenStage: ['init', 'handle_demand', 'handle_events', 'handle_call', 'handle_cast',
+        'handle_info', 'handle_subscribe', 'handle_cancel', 'terminate', 'code_change'],
+    Plug: ['init', 'call'],
+    'Plug.Router': ['init', 'call'],
+    'Mix.Task': ['run'],
+    'Phoenix.LiveView': ['mount', 'render', 'handle_event', 'handle_params', 'handle_info',
+        'handle_call', 'handle_cast', 'terminate', 'update'],
+    'Phoenix.LiveComponent': ['mount', 'render', 'update', 'handle_event', 'preload'],
+    'Phoenix.Channel': ['join', 'handle_in', 'handle_out', 'handle_info', 'terminate'],
+    ':gen_server': ['init', 'handle_call', 'handle_cast', 'handle_info', 'terminate', 'code_change'],
+    ':gen_statem': ['init', 'callback_mode', 'handle_event', 'terminate', 'code_change'],
+    ':application': ['start', 'stop'],
+    ':supervisor': ['init'],
+};
+
+// Module attributes that are compiler/doc annotations, not user constants
+const RESERVED_ATTRIBUTES = new Set([
+    'doc', 'moduledoc', 'typedoc', 'spec', 'type', 'typep', 'opaque', 'callback',
+    'macrocallback', 'optional_callbacks', 'impl', 'behaviour', 'derive',
+    'enforce_keys', 'compile', 'deprecated', 'dialyzer', 'external_resource',
+    'file', 'on_load', 'on_definition', 'before_compile', 'after_compile',
+    'vsn', 'since', 'tag', 'moduletag', 'describetag',
+]);
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+/** Target identifier text of a local call (`foo(...)`, `def ...`), else null */
+function callTargetName(node) {
+    if (node?.type !== 'call') return null;
+    const target = node.childForFieldName('target');
+    return target?.type === 'identifier' ? target.text : null;
+}
+
+/** `@name value` → { name, valueNode } for a module attribute node */
+function moduleAttribute(node) {
+    if (node?.type !== 'unary_operator') return null;
+    const op = node.childForFieldName('operator');
+    if (op?.text !== '@') return null;
+    const operand = node.childForFieldName('operand');
+    if (!operand) return null;
+    if (operand.type === 'identifier') return { name: operand.text, valueNode: null, operand };
+    const name = callTargetName(operand);
+    if (!name) return null;
+    const args = namedChildOfType(operand, 'arguments');
+    return { name, valueNode: args?.namedChild(0) || null, operand };
+}
+
+/**
+ * Name and parameter nodes of a definition's head:
+ *   def foo(a, b)            → call(foo, arguments)
+ *   def foo(a) when a > 0    → binary_operator(when, left: call(foo))
+ *   def foo                  → identifier
+ */
+function defHead(defCall) {
+    const args = namedChildOfType(defCall, 'arguments');
+    let head = args?.namedChild(0);
+    if (!head) return null;
+    if (head.type === 'binary_operator' && head.childForFieldName('operator')?.text === 'when') {
+        head = head.childForFieldName('left');
+    }
+    if (head?.type === 'identifier') return { nameNode: head, paramsNode: null };
+    if (head?.type === 'call') {
+        const target = head.childForFieldName('target');
+        if (target?.type !== 'identifier') return null;
+        return { nameNode: target, paramsNode: namedChildOfType(head, 'arguments') };
+    }
+    return null;
+}
+
+/** Module name text of a `defmodule` call (`MyApp.Worker`), else null */
+function moduleNameOf(node) {
+    if (callTargetName(node) !== 'defmodule') return null;
+    const args = namedChildOfType(node, 'arguments');
+    const nameNode = args?.namedChild(0);
+    return nameNode ? nameNode.text : null;
+}
+
+/** Nearest enclosing defmodule call, else null */
+function enclosingModule(node) {
+    for (let p = node.parent; p; p = p.parent) {
+        if (moduleNameOf(p)) return p;
+    }
+    return null;
+}
+
+/**
+ * Behaviours a module adopts: `use GenServer`, `use Plug.Router, ...`,
+ * `@behaviour :gen_server`. Only the module's own body — nested modules
+ * adopt their own.
+ */
+function moduleBehaviours(moduleNode) {
+    const behaviours = new Set();
+    const body = namedChildOfType(moduleNode, 'do_block');
+    if (!body) return behaviours;
+    for (let i = 0; i < body.namedChildCount; i++) {
+        const stmt = body.namedChild(i);
+        if (callTargetName(stmt) === 'use') {
+            const arg = namedChildOfType(stmt, 'arguments')?.namedChild(0);
+            if (arg) behaviours.add(arg.text);
+            continue;
+        }
+        const attr = moduleAttribute(stmt);
+        if (attr?.name === 'behaviour' && attr.valueNode) behaviours.add(attr.valueNode.text);
+    }
+    return behaviours;
+}
+
+/** Is the def preceded (through docs/specs/comments) by an `@impl` attribute? */
+function hasImplAttribute(defCall) {
+    for (let prev = defCall.previousNamedSibling; prev; prev = prev.previousNamedSibling) {
+        if (prev.type === 'comment') continue;
+        const attr = moduleAttribute(prev);
+        if (!attr) return false;
+        if (attr.name === 'impl') return attr.valueNode?.text !== 'false';
+    }
+    return false;
+}
+
+/** Line-comment (`#`) or @doc docstring first line above a def */
+function extractDocstring(defCall, lines) {
+    for (let prev = defCall.previousNamedSibling; prev; prev = prev.previousNamedSibling) {
+        if (prev.type === 'comment') continue;
+        const attr = moduleAttribute(prev);
+        if (!attr) break;
+        if (attr.name === 'doc' && attr.valueNode?.type === 'string') {
+            const text = attr.valueNode.text.replace(/^"""|"""$|^"|"$/g, '').trim();
+            const first = text.split('\n')[0].trim();
+            return first || null;
+        }
+    }
+    const row = defCall.startPosition.row - 1;
+    const line = row >= 0 ? lines[row] : null;
+    if (line && /^\s*#/.test(line)) return line.replace(/^\s*#\s?/, '').trim() || null;
+    return null;
+}
+
+function extractParamsStructured(paramsNode) {
+    if (!paramsNode) return [];
+    const params = [];
+    for (let i = 0; i < paramsNode.namedChildCount; i++) {
+        const p = paramsNode.namedChild(i);
+        if (p.type === 'comment') continue;
+        // Default argument: `opts \\ []`
+        if (p.type === 'binary_operator' && p.childForFieldName('operator')?.text === '\\\\') {
+            const left = p.childForFieldName('left');
+            const right = p.childForFieldName('right');
+            params.push({ name: left ? left.text : p.text, optional: true, ...(right && { default: right.text }) });
+            continue;
+        }
+        params.push({ name: p.text });
+    }
+    return params;
+}
+
+// --- Single-pass helpers ---
+
+function _processFunction(node, functions, lines, behaviourCache) {
+    const kind = callTargetName(node);
+    if (!kind || !DEF_KINDS[kind]) return false;
+    const head = defHead(node);
+    if (!head) return true;
+
+    const { startLine, endLine, indent } = nodeToLocation(node, lines);
+    const name = head.nameNode.text;
+    const modifiers = [DEF_KINDS[kind]];
+    if (kind.startsWith('defmacro')) modifiers.push('macro');
+    if (kind.startsWith('defguard')) modifiers.push('guard');
+
+    const moduleNode = enclosingModule(node);
+    if (moduleNode) {
+        let behaviours = behaviourCache.get(moduleNode.startIndex);
+        if (!behaviours) {
+            behaviours = moduleBehaviours(moduleNode);
+            behaviourCache.set(moduleNode.startIndex, behaviours);
+        }
+        let isCallback = hasImplAttribute(node);
+        if (!isCallback && DEF_KINDS[kind] === 'public') {
+            for (const b of behaviours) {
+                if (BEHAVIOUR_CALLBACKS[b]?.includes(name)) { isCallback = true; break; }
+            }
+        }
+        if (isCallback) modifiers.push('callback');
+    }
+
+    const paramsStructured = extractParamsStructured(head.paramsNode);
+    const docstring = extractDocstring(node, lines);
+    const nameLine = head.nameNode.startPosition.row + 1;
+    functions.push({
+        name,
+        params: head.paramsNode ? head.paramsNode.text.replace(/^\(|\)$/g, '').trim() : '',
+        paramsStructured,
+        startLine,
+        endLine,
+        indent,
+        modifiers,
+        ...(moduleNode && { enclosingType: moduleNameOf(moduleNode) }),
+        ...(docstring && { docstring }),
+        ...(nameLine !== startLine && { nameLine }),
+    });
+    return true;
+}
+
+function _processModule(node, classes, lines) {
+    const fullName = moduleNameOf(node);
+    const kind = callTargetName(node);
+    if (!fullName) {
+        // defprotocol Name do ... end — the protocol's functions are its
+        // interface; defimpl bodies implement it for one data type.
+        if (kind !== 'defprotocol') return false;
+        const nameNode = namedChildOfType(node, 'arguments')?.namedChild(0);
+        if (!nameNode) return true;
+        const { startLine, endLine, indent } = nodeToLocation(node, lines);
+        classes.push({
+            name: nameNode.text.split('.').pop(),
+            type: 'interface',
+            startLine, endLine, indent,
+            modifiers: ['public'],
+            members: [],
+        });
+        return true;
+    }
+
+    const { startLine, endLine, indent } = nodeToLocation(node, lines);
+    let docstring = null;
+    const body = namedChildOfType(node, 'do_block');
+    if (body) {
+        for (let i = 0; i < body.namedChildCount; i++) {
+            const attr = moduleAttribute(body.namedChild(i));
+            if (attr?.name === 'moduledoc' && attr.valueNode?.type === 'string') {
+                docstring = attr.valueNode.text.replace(/^"""|"""$|^"|"$/g, '').trim().split('\n')[0].trim() || null;
+                break;
+            }
+        }
+    }
+    const behaviours = [...moduleBehaviours(node)];
+    classes.push({
+        // Nested modules are named relative to their parent in source
+        // (`defmodule Worker` inside MyApp is MyApp.Worker) — the last
+        // segment is the name references use after `alias`.
+        name: fullName.split('.').pop(),
+        type: 'module',
+        startLine, endLine, indent,
+        modifiers: ['public'],
+        members: [],
+        ...(behaviours.length > 0 && { implements: behaviours }),
+        ...(docstring && { docstring }),
+    });
+    return true;
+}
+
+function _processState(node, objects, lines) {
+    const attr = moduleAttribute(node);
+    if (!attr || !attr.valueNode || RESERVED_ATTRIBUTES.has(attr.name)) return false;
+    // Module-level only: `@attr` inside a def body reads the attribute
+    const parent = node.parent;
+    if (parent?.type !== 'do_block' || !moduleNameOf(parent.parent)) return false;
+    const { startLine, endLine } = nodeToLocation(node, lines);
+    objects.push({ name: attr.name, startLine, endLine });
+    return true;
+}
+
+function findFunctions(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const functions = [];
+    const behaviourCache = new Map();
+    traverseTreeCached(tree.rootNode, (node) => {
+        _processFunction(node, functions, lines, behaviourCache);
+        return true;
+    });
+    functions.sort((a, b) => a.startLine - b.startLine);
+    return functions;
+}
+
+function findClasses(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const classes = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        _processModule(node, classes, lines);
+        return true;
+    });
+    classes.sort((a, b) => a.startLine - b.startLine);
+    return classes;
+}
+
+function findStateObjects(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const objects = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        _processState(node, objects, lines);
+        return true;
+    });
+    objects.sort((a, b) => a.startLine - b.startLine);
+    return objects;
+}
+
+/**
+ * Parse an Elixir file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const functions = [];
+    const classes = [];
+    const stateObjects = [];
+    const behaviourCache = new Map();
+
+    traverseTreeCached(tree.rootNode, (node) => {
+        _processFunction(node, functions, lines, behaviourCache) ||
+            _processModule(node, classes, lines) ||
+            _processState(node, stateObjects, lines);
+        return true;
+    });
+
+    functions.sort((a, b) => a.startLine - b.startLine);
+    classes.sort((a, b) => a.startLine - b.startLine);
+    stateObjects.sort((a, b) => a.startLine - b.startLine);
+
+    return {
+        language: 'elixir',
+        totalLines: lines.length,
+        functions,
+        classes,
+        stateObjects,
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find all function calls in Elixir code
+ *
+ *   foo(x), x |> foo()         → local call
+ *   Mod.foo(x), :ets.new(...)   → remote call, receiver = module (isMethod false —
+ *                                 a module is a namespace, not an instance)
+ *   &foo/1, &Mod.foo/1          → function capture (reference)
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean, receiver?: string}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    const functionStack = [];
+
+    const getCurrentEnclosingFunction = () => {
+        return functionStack.length > 0
+            ? { ...functionStack[functionStack.length - 1] }
+            : null;
+    };
+
+    const argCountOf = (callNode) => {
+        const args = namedChildOfType(callNode, 'arguments');
+        let count = 0;
+        if (args) {
+            for (let i = 0; i < args.namedChildCount; i++) {
+                if (args.namedChild(i).type !== 'comment') count++;
+            }
+        }
+        // Pipe: `x |> foo(a)` calls foo/2
+        const parent = callNode.parent;
+        if (parent?.type === 'binary_operator' &&
+            parent.childForFieldName('operator')?.text === '|>' &&
+            sameNode(parent.childForFieldName('right'), callNode)) {
+            count++;
+        }
+        return count;
+    };
+
+    function visit(node) {
+        const kind = callTargetName(node);
+        if (kind && DEF_KINDS[kind]) {
+            const head = defHead(node);
+            functionStack.push({
+                name: head ? head.nameNode.text : '<anonymous>',
+                startLine: node.startPosition.row + 1,
+                endLine: node.endPosition.row + 1,
+            });
+            // The head `foo(a, b)` is a declaration, not a call — walk only
+            // the guard (`when ...`), keyword bodies (`do: ...`) and the block.
+            const args = namedChildOfType(node, 'arguments');
+            const first = args?.namedChild(0);
+            if (first?.type === 'binary_operator' && first.childForFieldName('operator')?.text === 'when') {
+                const guard = first.childForFieldName('right');
+                if (guard) traverseTree(guard, visit);
+            }
+            if (args) {
+                for (let i = 1; i < args.namedChildCount; i++) traverseTree(args.namedChild(i), visit);
+            }
+            const body = namedChildOfType(node, 'do_block');
+            if (body) traverseTree(body, visit);
+            functionStack.pop();
+            return false;
+        }
+
+        // Module attributes (`@timeout 5_000`) are not calls of `timeout`
+        if (node.type === 'unary_operator') {
+            const op = node.childForFieldName('operator')?.text;
+            const operand = node.childForFieldName('operand');
+            if (op === '@') {
+                const args = operand?.type === 'call' ? namedChildOfType(operand, 'arguments') : null;
+                if (args) traverseTree(args, visit);
+                return false;
+            }
+            // Capture: &foo/1, &Mod.foo/1
+            if (op === '&' && operand?.type === 'binary_operator' &&
+                operand.childForFieldName('operator')?.text === '/') {
+                const left = operand.childForFieldName('left');
+                if (left?.type === 'identifier') {
+                    calls.push({
+                        name: left.text,
+                        line: left.startPosition.row + 1,
+                        isMethod: false,
+                        isFunctionReference: true,
+                        isPotentialCallback: true,
+                        enclosingFunction: getCurrentEnclosingFunction(),
+                        uncertain: false,
+                    });
+                    return false;
+                }
+            }
+            return true;
+        }
+
+        if (node.type !== 'call') return true;
+        const target = node.childForFieldName('target');
+        if (!target) return true;
+
+        if (target.type === 'identifier') {
+            if (NON_CALL_TARGETS.has(target.text)) return true;
+            calls.push({
+                name: target.text,
+                line: target.startPosition.row + 1,
+                isMethod: false,
+                argCount: argCountOf(node),
+                enclosingFunction: getCurrentEnclosingFunction(),
+                uncertain: false,
+            });
+            return true;
+        }
+
+        if (target.type === 'dot') {
+            const left = target.childForFieldName('left');
+            const right = target.childForFieldName('right');
+            // `fun.(x)` — anonymous function invocation, no name to resolve
+            if (!right || right.type !== 'identifier') return true;
+            const isModule = left?.type === 'alias' || left?.type === 'atom' ||
+                (left?.type === 'identifier' && left.text === '__MODULE__');
+            calls.push({
+                name: right.text,
+                line: right.startPosition.row + 1,
+                // A lowercase receiver is a variable holding a module (or a
+                // map field access) — dynamic, so resolution is uncertain.
+                isMethod: false,
+                ...(left && { receiver: left.text }),
+                argCount: argCountOf(node),
+                enclosingFunction: getCurrentEnclosingFunction(),
+                uncertain: !isModule,
+            });
+        }
+        return true;
+    }
+
+    traverseTree(tree.rootNode, visit);
+    return calls;
+}
+
+/**
+ * Find alias/import/require/use directives
+ *
+ *   alias MyApp.Repo                → names: ['Repo']
+ *   alias MyApp.{Repo, Accounts}    → one import per expanded module
+ *   alias MyApp.Repo, as: R         → names: ['R'], aliases: Repo → R
+ *   import Ecto.Query, only: [...]  → names: []
+ *
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const imports = [];
+    let importAliases = null;
+
+    traverseTreeCached(tree.rootNode, (node) => {
+        const directive = callTargetName(node);
+        if (!directive || !DIRECTIVES.has(directive)) return true;
+        const args = namedChildOfType(node, 'arguments');
+        const target = args?.namedChild(0);
+        if (!target) return false;
+        const line = node.startPosition.row + 1;
+
+        let asName = null;
+        const keywords = args ? namedChildOfType(args, 'keywords') : null;
+        if (keywords) {
+            for (let i = 0; i < keywords.namedChildCount; i++) {
+                const pair = keywords.namedChild(i);
+                const key = pair.childForFieldName('key');
+                const value = pair.childForFieldName('value');
+                if (key && value && key.text.replace(/:\s*$/, '') === 'as') asName = value.text;
+            }
+        }
+
+        // Multi-alias: MyApp.{Repo, Accounts}
+        const multi = target.text.match(/^([\w.]+)\.\{([\s\S]*)\}$/);
+        const modules = multi
+            ? multi[2].split(',').map(s => s.trim()).filter(Boolean).map(s => `${multi[1]}.${s}`)
+            : [target.text];
+
+        for (const mod of modules) {
+            const last = mod.split('.').pop();
+            const names = [];
+            if (directive === 'alias') {
+                names.push(asName || last);
+                if (asName && asName !== last) {
+                    if (!importAliases) importAliases = [];
+                    importAliases.push({ original: last, local: asName });
+                }
+            }
+            imports.push({
+                module: mod,
+                names,
+                type: directive,
+                line,
+                // `alias __MODULE__.Sub` / `use unquote(mod)` — not a literal module
+                ...(!/^:?[\w.]+$/.test(mod) && { dynamic: true }),
+            });
+        }
+        return false;
+    });
+
+    if (importAliases) imports.aliases = importAliases;
+    return imports;
+}
+
+/**
+ * Public functions of each module are its exports (def/defmacro/defguard;
+ * defdelegate re-exports). Private (defp) definitions are module-local.
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const exports = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        const kind = callTargetName(node);
+        if (!kind || DEF_KINDS[kind] !== 'public') return true;
+        const head = defHead(node);
+        if (head) {
+            exports.push({ name: head.nameNode.text, type: kind, line: node.startPosition.row + 1 });
+        }
+        return false;
+    });
+    return exports;
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+
+    visitNameNodes(tree, code, name, (node) => {
+        if ((node.type !== 'identifier' && node.type !== 'alias') || node.text !== name) {
+            // `MyApp.Worker` is one alias token — match its last segment
+            if (node.type !== 'alias' || !node.text.endsWith('.' + name)) return true;
+        }
+        const line = node.startPosition.row + 1;
+        const column = node.startPosition.column;
+        const parent = node.parent;
+        let usageType = 'reference';
+
+        if (node.type === 'alias') {
+            const directiveCall = parent?.type === 'arguments' ? parent.parent : null;
+            if (DIRECTIVES.has(callTargetName(directiveCall))) usageType = 'import';
+            else if (callTargetName(directiveCall) === 'defmodule') usageType = 'definition';
+            usages.push({ line, column, usageType });
+            return true;
+        }
+
+        if (parent?.type === 'call' && sameNode(parent.childForFieldName('target'), node)) {
+            // Definition head: def name(...) — the call sits in a def's arguments
+            const outer = parent.parent?.type === 'arguments' ? parent.parent.parent : null;
+            const guardOuter = parent.parent?.type === 'binary_operator' &&
+                parent.parent.parent?.type === 'arguments' ? parent.parent.parent.parent : null;
+            if (DEF_KINDS[callTargetName(outer)] || DEF_KINDS[callTargetName(guardOuter)]) {
+                usageType = 'definition';
+            } else {
+                usageType = 'call';
+            }
+        } else if (parent?.type === 'dot' && sameNode(parent.childForFieldName('right'), node)) {
+            const left = parent.childForFieldName('left');
+            usages.push({
+                line, column,
+                usageType: parent.parent?.type === 'call' ? 'call' : 'reference',
+                ...(left && { receiver: left.text }),
+            });
+            return true;
+        } else if (parent?.type === 'arguments' && DEF_KINDS[callTargetName(parent.parent)]) {
+            // Zero-arity head: `def name do`
+            usageType = 'definition';
+        }
+
+        usages.push({ line, column, usageType });
+        return true;
+    });
+
+    return usages;
+}
+
+/**
+ * Classify an Elixir entry point:
+ * - 'framework': OTP/behaviour callbacks (GenServer.handle_call, ...),
+ *                `@impl`-marked defs, and compile-time hooks (__using__,
+ *                __before_compile__) — invoked by the runtime or compiler.
+ * - 'main':      escript `main/1`.
+ * ExUnit tests are `test "..." do` macro blocks, not named functions.
+ */
+function getEntryPointKind(symbol) {
+    const mods = symbol.modifiers || [];
+    if (mods.includes('callback')) return 'framework';
+    if (/^__\w+__$/.test(symbol.name)) return 'framework';
+    if (symbol.name === 'main' && mods.includes('public')) return 'main';
+    return null;
+}
+
+/**
+ * Check if a symbol is an Elixir-convention entry point.
+ */
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    parse
+};
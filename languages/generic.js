@@ -0,0 +1,233 @@
+/**
+ * languages/generic.js - Query-driven adapter for user-supplied grammars
+ *
+ * A language UCN does not ship can be analyzed from a compiled tree-sitter
+ * grammar plus a tags query (the `tags.scm` convention many grammars
+ * already ship), declared in .ucn.json "grammars":
+ *
+ *   (function_declaration name: (identifier) @name) @definition.function
+ *   (class_declaration name: (type_identifier) @name) @definition.class
+ *   (call_expression function: (identifier) @name) @reference.call
+ *   (import_header (identifier) @import)
+ *
+ * Definitions become symbols (function/method → functions, class/interface/
+ * struct/enum/module/trait/type → classes, constant/variable → state),
+ * `@reference.call` / `@reference.send` become calls, other `@reference.*`
+ * captures name references, and `@import` captures the imported module.
+ * Functions nested in a class definition are its members. Nothing is known
+ * about visibility, so nothing is exported: the analysis is name-based.
+ */
+
+'use strict';
+
+const fs = require('fs');
+const { nodeToLocation, visitNameNodes, sameNode } = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+const FUNCTION_KINDS = new Set(['function', 'method', 'macro']);
+const CLASS_KINDS = new Map([
+    ['class', 'class'], ['interface', 'interface'], ['struct', 'struct'], ['enum', 'enum'],
+    ['module', 'module'], ['trait', 'trait'], ['type', 'type'], ['object', 'class'],
+]);
+const STATE_KINDS = new Set(['constant', 'variable', 'field']);
+const CALL_KINDS = new Set(['call', 'send']);
+
+/**
+ * Build the language module for one declared grammar.
+ * @param {string} language - Declared language name
+ * @param {{queryPath: string}} spec - Resolved declaration
+ */
+function createGenericModule(language, spec) {
+    // Compiled per grammar Language object (one per parser)
+    const queries = new WeakMap();
+    let querySource = null;
+
+    const queryFor = (parser) => {
+        const lang = parser.getLanguage();
+        if (!queries.has(lang)) {
+            if (querySource === null) querySource = fs.readFileSync(spec.queryPath, 'utf-8');
+            // The parser's class is the tree-sitter binding (Parser.Query)
+            queries.set(lang, new parser.constructor.Query(lang, querySource));
+        }
+        return queries.get(lang);
+    };
+
+    const parseTree = (parser, code) => safeParse(parser, code, undefined, PARSE_OPTIONS);
+
+    /**
+     * Captures of every match, grouped: definitions (node + name node +
+     * kind), references (name node + kind) and imports (module node).
+     */
+    const collect = (tree, parser) => {
+        const definitions = [];
+        const references = [];
+        const imports = [];
+        for (const match of queryFor(parser).matches(tree.rootNode)) {
+            let def = null;
+            let ref = null;
+            let nameNode = null;
+            for (const { name, node } of match.captures) {
+                if (name === 'name') nameNode = node;
+                else if (name.startsWith('definition.')) def = { node, kind: name.slice('definition.'.length) };
+                else if (name.startsWith('reference.')) ref = { node, kind: name.slice('reference.'.length) };
+                else if (name === 'import') imports.push(node);
+            }
+            if (def && nameNode) definitions.push({ ...def, nameNode });
+            else if (ref) references.push({ ...ref, nameNode: nameNode || ref.node });
+        }
+        return { definitions, references, imports };
+    };
+
+    const collectSymbols = (tree, parser, lines) => {
+        const { definitions } = collect(tree, parser);
+        const functions = [];
+        const classes = [];
+        const stateObjects = [];
+        const classRanges = [];
+        for (const def of definitions) {
+            const kind = CLASS_KINDS.get(def.kind);
+            if (!kind) continue;
+            const { startLine, endLine, indent } = nodeToLocation(def.node, lines);
+            const cls = { name: def.nameNode.text, type: kind, startLine, endLine, indent, modifiers: [], members: [] };
+            classes.push(cls);
+            classRanges.push({ cls, start: def.node.startIndex, end: def.node.endIndex });
+        }
+        for (const def of definitions) {
+            const { startLine, endLine, indent } = nodeToLocation(def.node, lines);
+            const name = def.nameNode.text;
+            if (FUNCTION_KINDS.has(def.kind)) {
+                const fn = { name, params: '', paramsStructured: [], startLine, endLine, indent, modifiers: [],
+                    ...(def.nameNode.startPosition.row + 1 !== startLine && { nameLine: def.nameNode.startPosition.row + 1 }) };
+                // Innermost enclosing class definition owns the function
+                const owner = classRanges
+                    .filter(r => r.start <= def.node.startIndex && def.node.endIndex <= r.end)
+                    .sort((a, b) => (a.end - a.start) - (b.end - b.start))[0];
+                if (owner) {
+                    owner.cls.members.push({ ...fn, memberType: 'method', className: owner.cls.name });
+                    functions.push({ ...fn, isMethod: true, className: owner.cls.name });
+                } else {
+                    functions.push(fn);
+                }
+            } else if (STATE_KINDS.has(def.kind)) {
+                stateObjects.push({ name, startLine, endLine, ...(def.kind === 'constant' && { isConst: true }) });
+            }
+        }
+        const byLine = (a, b) => a.startLine - b.startLine;
+        return { functions: functions.sort(byLine), classes: classes.sort(byLine), stateObjects: stateObjects.sort(byLine) };
+    };
+
+    function findFunctions(code, parser) {
+        return collectSymbols(parseTree(parser, code), parser, code.split('\n')).functions;
+    }
+
+    function findClasses(code, parser) {
+        return collectSymbols(parseTree(parser, code), parser, code.split('\n')).classes;
+    }
+
+    function findStateObjects(code, parser) {
+        return collectSymbols(parseTree(parser, code), parser, code.split('\n')).stateObjects;
+    }
+
+    function parse(code, parser) {
+        const tree = parseTree(parser, code);
+        const lines = code.split('\n');
+        return {
+            language,
+            totalLines: lines.length,
+            ...collectSymbols(tree, parser, lines),
+            ...(tree.rootNode.hasError && { parseRecovery: true }),
+            imports: [],
+            exports: []
+        };
+    }
+
+    /**
+     * Calls and name references captured by the query. The enclosing
+     * function is the innermost function definition spanning the capture.
+     */
+    function findCallsInCode(code, parser) {
+        const tree = parseTree(parser, code);
+        const { definitions, references } = collect(tree, parser);
+        const functionDefs = definitions.filter(d => FUNCTION_KINDS.has(d.kind));
+        const calls = [];
+        for (const ref of references) {
+            const at = ref.nameNode;
+            const enclosing = functionDefs
+                .filter(d => d.node.startIndex <= at.startIndex && at.endIndex <= d.node.endIndex)
+                .sort((a, b) => (a.node.endIndex - a.node.startIndex) - (b.node.endIndex - b.node.startIndex))[0];
+            calls.push({
+                name: at.text,
+                line: at.startPosition.row + 1,
+                isMethod: false,
+                ...(!CALL_KINDS.has(ref.kind) && { isFunctionReference: true }),
+                enclosingFunction: enclosing ? {
+                    name: enclosing.nameNode.text,
+                    startLine: enclosing.node.startPosition.row + 1,
+                    endLine: enclosing.node.endPosition.row + 1,
+                } : null,
+                uncertain: false,
+            });
+        }
+        return calls;
+    }
+
+    /** `@import` captures, quotes stripped */
+    function findImportsInCode(code, parser) {
+        const { imports } = collect(parseTree(parser, code), parser);
+        return imports.map(node => ({
+            module: node.text.replace(/^(['"`<])(.*)[>'"`]$/, '$2'),
+            names: [],
+            type: 'import',
+            line: node.startPosition.row + 1,
+        }));
+    }
+
+    function findExportsInCode() {
+        return [];
+    }
+
+    /**
+     * Usages: identifier-like leaf tokens spelled `name`. Definition names
+     * and call captures come from the query; comment and string tokens are
+     * never usages.
+     */
+    function findUsagesInCode(code, name, parser, tree) {
+        tree = tree || parseTree(parser, code);
+        const { definitions, references } = collect(tree, parser);
+        const usages = [];
+        visitNameNodes(tree, code, name, (node) => {
+            if (node.text !== name || node.childCount > 0) return;
+            for (let p = node; p; p = p.parent) {
+                if (/comment|string/.test(p.type)) return;
+            }
+            let usageType = 'reference';
+            if (definitions.some(d => sameNode(d.nameNode, node))) usageType = 'definition';
+            else if (references.some(r => CALL_KINDS.has(r.kind) && sameNode(r.nameNode, node))) usageType = 'call';
+            usages.push({ line: node.startPosition.row + 1, column: node.startPosition.column, usageType });
+        });
+        return usages;
+    }
+
+    function getEntryPointKind(symbol) {
+        return symbol.name === 'main' && !symbol.className ? 'main' : null;
+    }
+
+    function isEntryPoint(symbol) {
+        return getEntryPointKind(symbol) !== null;
+    }
+
+    return {
+        findFunctions,
+        findClasses,
+        findStateObjects,
+        findCallsInCode,
+        findImportsInCode,
+        findExportsInCode,
+        findUsagesInCode,
+        isEntryPoint,
+        getEntryPointKind,
+        parse
+    };
+}
+
+module.exports = { createGenericModule };
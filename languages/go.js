@@ -11,6 +11,7 @@ const {
     nodeToLocation,
     parseStructuredParams,
     extractGoDocstring,
+    extractGoDeprecation,
     visitNameNodes,
     sameNode,
 } = require('./utils');
@@ -131,6 +132,7 @@ function _processFunction(node, functions, processedRanges, lines) {
             const returnType = extractReturnType(node);
             const returnedFunctionResult = extractReturnedFunctionResult(node);
             const docstring = extractGoDocstring(lines, startLine);
+            const deprecated = extractGoDeprecation(lines, startLine);
             const typeParams = extractTypeParams(node);
             const isExported = /^[A-Z]/.test(nameNode.text);
 
@@ -145,6 +147,7 @@ function _processFunction(node, functions, processedRanges, lines) {
                 ...(returnType && { returnType }),
                 ...(returnedFunctionResult && { returnedFunctionResult }),
                 ...(docstring && { docstring }),
+                ...(deprecated !== null && { deprecated }),
                 ...(typeParams && { generics: typeParams })
             });
         }
@@ -166,6 +169,7 @@ function _processFunction(node, functions, processedRanges, lines) {
             const returnType = extractReturnType(node);
             const returnedFunctionResult = extractReturnedFunctionResult(node);
             const docstring = extractGoDocstring(lines, startLine);
+            const deprecated = extractGoDeprecation(lines, startLine);
             const isExported = /^[A-Z]/.test(nameNode.text);
 
             functions.push({
@@ -180,7 +184,8 @@ function _processFunction(node, functions, processedRanges, lines) {
                 modifiers: isExported ? ['export'] : [],
                 ...(returnType && { returnType }),
                 ...(returnedFunctionResult && { returnedFunctionResult }),
-                ...(docstring && { docstring })
+                ...(docstring && { docstring }),
+                ...(deprecated !== null && { deprecated })
             });
         }
         return true;
@@ -210,6 +215,7 @@ function _processClass(node, types, processedRanges, lines) {
                 const { startLine, endLine } = nodeToLocation(node, lines);
                 const name = nameNode.text;
                 const docstring = extractGoDocstring(lines, startLine);
+                const deprecated = extractGoDeprecation(lines, startLine);
                 const typeParams = extractTypeParams(spec);
 
                 let typeKind = 'type';
@@ -220,6 +226,8 @@ function _processClass(node, types, processedRanges, lines) {
                 }
 
                 const isExported = /^[A-Z]/.test(name);
+                // In a `type ( ... )` group the name sits below the keyword
+                const nameLine = nameNode.startPosition.row + 1;
 
                 const members = typeKind === 'struct' ? extractStructFields(typeNode, lines)
                     : typeKind === 'interface' ? extractInterfaceMembers(typeNode, lines)
@@ -237,7 +245,9 @@ function _processClass(node, types, processedRanges, lines) {
                     members,
                     modifiers: isExported ? ['export'] : [],
                     ...(docstring && { docstring }),
+                    ...(deprecated !== null && { deprecated }),
                     ...(typeParams && { generics: typeParams }),
+                    ...(nameLine !== startLine && { nameLine }),
                     ...(embeddedBases.length > 0 && { extends: embeddedBases.join(', ') })
                 });
             }
@@ -253,6 +263,7 @@ function _processClass(node, types, processedRanges, lines) {
                     : typeNode.type === 'qualified_type' ? typeNode.childForFieldName('name')?.text
                     : null;
                 const { startLine, endLine } = nodeToLocation(node, lines);
+                const nameLine = nameNode.startPosition.row + 1;
                 types.push({
                     name: nameNode.text,
                     startLine,
@@ -261,6 +272,7 @@ function _processClass(node, types, processedRanges, lines) {
                     members: [],
                     modifiers: /^[A-Z]/.test(nameNode.text) ? ['export'] : [],
                     ...(aliasOf && { aliasOf }),
+                    ...(nameLine !== startLine && { nameLine }),
                 });
             }
         }
@@ -311,6 +323,10 @@ function _blockHasIota(constDecl) {
 function _processState(node, objects, lines) {
     if (node.type === 'const_declaration') {
         const isIotaBlock = _blockHasIota(node);
+        // A `const ( ... )` block of several specs is one group: deadcode
+        // reports a wholly unused block as a single finding
+        const specCount = node.namedChildren.filter(c => c.type === 'const_spec').length;
+        const constBlock = specCount > 1 ? node.startPosition.row + 1 : null;
         for (let i = 0; i < node.namedChildCount; i++) {
             const spec = node.namedChild(i);
             if (spec.type === 'const_spec') {
@@ -322,12 +338,13 @@ function _processState(node, objects, lines) {
                 if (valueNode && _isCompositeLiteral(valueNode) && GO_STATE_PATTERN.test(name)) {
                     const { startLine, endLine } = nodeToLocation(spec, lines);
                     objects.push({ name, startLine, endLine });
-                } else if (isIotaBlock && /^[A-Z]/.test(name)) {
-                    const { startLine, endLine } = nodeToLocation(spec, lines);
-                    objects.push({ name, startLine, endLine, isConst: true });
                 } else if (_isGoExportedName(name)) {
                     const { startLine, endLine } = nodeToLocation(spec, lines);
-                    objects.push({ name, startLine, endLine, isConst: true });
+                    objects.push({
+                        name, startLine, endLine, isConst: true,
+                        ...(constBlock && { constBlock }),
+                        ...(isIotaBlock && { iota: true })
+                    });
                 }
             }
         }
@@ -425,17 +442,23 @@ function extractStructFields(structNode, codeOrLines) {
         const field = fieldListNode.namedChild(i);
         if (field.type === 'field_declaration') {
             const { startLine, endLine } = nodeToLocation(field, codeOrLines);
-            const nameNode = field.childForFieldName('name');
+            // `X, Y int` declares one field per name
+            const nameNodes = field.namedChildren.filter(c => c.type === 'field_identifier');
             const typeNode = field.childForFieldName('type');
+            const tagNode = field.childForFieldName('tag');
+            const fieldTag = tagNode ? tagNode.text.slice(1, -1) : null;
 
-            if (nameNode) {
-                fields.push({
-                    name: nameNode.text,
-                    startLine,
-                    endLine,
-                    memberType: 'field',
-                    ...(typeNode && { fieldType: typeNode.text })
-                });
+            if (nameNodes.length > 0) {
+                for (const nameNode of nameNodes) {
+                    fields.push({
+                        name: nameNode.text,
+                        startLine,
+                        endLine,
+                        memberType: 'field',
+                        ...(typeNode && { fieldType: typeNode.text }),
+                        ...(fieldTag && { fieldTag })
+                    });
+                }
             } else if (typeNode) {
                 // Embedded field: has type but no name (e.g., `Base` in `type Child struct { Base; Name string }`)
                 // Use the type name as the field name
@@ -695,6 +718,40 @@ function goAssignmentTargetOf(callNode) {
     return { assignedTo: target.text };
 }
 
+/**
+ * Results this call throws away (deadcode --unused-results):
+ *   f()  /  go f()  /  defer f()  → 'all'
+ *   _ = f()   /  _, _ := f()      → 'all'
+ *   x, _ := f()                   → [1] (positions assigned to `_`)
+ *   a, _ = g(), h()               → 'all' for h (parallel assignment)
+ * undefined when every result is bound or the call is an expression.
+ */
+function goDiscardedResultsOf(callNode) {
+    const p = callNode.parent;
+    if (p?.type === 'expression_statement' || p?.type === 'go_statement' || p?.type === 'defer_statement') return 'all';
+    let n = callNode;
+    let rhsIndex = 0;
+    let rhsCount = 1;
+    let stmt = p;
+    if (p?.type === 'expression_list') {
+        rhsCount = p.namedChildCount;
+        rhsIndex = p.namedChildren.findIndex(c => c.id === callNode.id);
+        n = p;
+        stmt = p.parent;
+    }
+    if (!stmt || (stmt.type !== 'short_var_declaration' && stmt.type !== 'assignment_statement')) return undefined;
+    if (stmt.type === 'assignment_statement' && stmt.childForFieldName('operator')?.text !== '=') return undefined;
+    if (stmt.childForFieldName('right')?.id !== n.id) return undefined;
+    const left = stmt.childForFieldName('left');
+    if (!left) return undefined;
+    const names = left.type === 'expression_list' ? left.namedChildren : [left];
+    const blank = (t) => t?.type === 'identifier' && t.text === '_';
+    if (rhsCount > 1) return blank(names[rhsIndex]) ? 'all' : undefined;
+    if (names.every(blank)) return 'all';
+    const positions = names.map((t, i) => (blank(t) ? i : -1)).filter(i => i >= 0);
+    return positions.length > 0 ? positions : undefined;
+}
+
 function findCallsInCode(code, parser, options = {}) {
     const tree = parseTree(parser, code);
     const calls = [];
@@ -1252,6 +1309,7 @@ function findCallsInCode(code, parser, options = {}) {
             // bb := balancer.Get(n) lets findCallers type bb from Get's
             // declared return type at query time.
             const assigned = goAssignmentTargetOf(node);
+            const discardedResults = goDiscardedResultsOf(node);
 
             if (funcNode.type === 'identifier') {
                 const callName = funcNode.text;
@@ -1274,6 +1332,7 @@ function findCallsInCode(code, parser, options = {}) {
                     ...(assigned && { assignedTo: assigned.assignedTo }),
                     ...(assigned?.assignedTuple && { assignedTuple: true }),
                         ...(assigned?.assignedTupleRest && { assignedTupleRest: assigned.assignedTupleRest }),
+                    ...(discardedResults && { discardedResults }),
                     enclosingFunction,
                     uncertain,
                     ...(firstArg && { firstStringArg: firstArg.value, firstStringArgInterp: firstArg.interp })
@@ -1377,6 +1436,7 @@ function findCallsInCode(code, parser, options = {}) {
                         ...(assigned && { assignedTo: assigned.assignedTo }),
                         ...(assigned?.assignedTuple && { assignedTuple: true }),
                         ...(assigned?.assignedTupleRest && { assignedTupleRest: assigned.assignedTupleRest }),
+                        ...(discardedResults && { discardedResults }),
                         enclosingFunction,
                         uncertain,
                         ...(firstArg && { firstStringArg: firstArg.value, firstStringArgInterp: firstArg.interp })
@@ -1924,6 +1984,1142 @@ function findUsagesInCode(code, name, parser, tree) {
     return usages;
 }
 
+// Struct tag keys whose encoders read fields by reflection (.ucn.json
+// "externalFieldTags" replaces the list; [] audits tagged fields too)
+const DEFAULT_EXTERNAL_FIELD_TAGS = ['json', 'yaml'];
+
+/** Keys of a struct tag that name the field: `json:"id,omitempty" db:"-"` → ['json'] */
+function fieldTagKeys(tag) {
+    const keys = [];
+    for (const m of String(tag).matchAll(/(\w+):"((?:[^"\\]|\\.)*)"/g)) {
+        if (m[2] !== '-') keys.push(m[1]);
+    }
+    return keys;
+}
+
+/**
+ * Named struct fields join the deadcode audit (auditFields trait). Embedded
+ * fields promote their methods and stay out; a field tagged for a
+ * reflection encoder (json/yaml by default) is read outside the code.
+ * @param {object} symbol - Field symbol
+ * @param {object} [config] - Project .ucn.json config
+ */
+function isAuditedField(symbol, config) {
+    if (symbol.embedded) return false;
+    if (!symbol.fieldTag) return true;
+    const external = Array.isArray(config?.externalFieldTags)
+        ? config.externalFieldTags : DEFAULT_EXTERNAL_FIELD_TAGS;
+    return !fieldTagKeys(symbol.fieldTag).some(key => external.includes(key));
+}
+
+/**
+ * Whether an identifier/selector occurrence only stores into its variable:
+ * an assignment target or ++/-- operand, directly or through element
+ * indexing (`m[k] = v`), `delete(m, k)`, or a self-append `s = append(s, x)`.
+ */
+function _isGoStore(node) {
+    let target = node;
+    while (target.parent?.type === 'index_expression' &&
+        sameNode(target.parent.childForFieldName('operand'), target)) target = target.parent;
+    const parent = target.parent;
+    if (parent?.type === 'inc_statement' || parent?.type === 'dec_statement') return true;
+    if (parent?.type === 'expression_list' && parent.parent?.type === 'assignment_statement' &&
+        sameNode(parent.parent.childForFieldName('left'), parent)) return true;
+    const args = node.parent;
+    if (args?.type !== 'argument_list' || !sameNode(args.namedChild(0), node)) return false;
+    const call = args.parent;
+    const callee = call?.type === 'call_expression' ? call.childForFieldName('function')?.text : null;
+    if (callee === 'delete') return true;
+    if (callee !== 'append') return false;
+    const rhs = call.parent;
+    const stmt = rhs?.type === 'expression_list' ? rhs.parent : null;
+    return stmt?.type === 'assignment_statement' && sameNode(stmt.childForFieldName('right'), rhs) &&
+        rhs.namedChildCount === 1 && stmt.childForFieldName('left')?.text === node.text;
+}
+
+/**
+ * Field reads in a parsed file (fieldReads trait): selector fields outside
+ * assignment targets and ++/--. `s.n = 1` and composite-literal keys only
+ * write a field, and so do element stores into a map/slice field —
+ * `s.meta[k] = v`, `s.counts[k]++`, `delete(s.meta, k)` and the self-append
+ * `s.items = append(s.items, x)` — which populate data nothing may read.
+ * `s.inner.n = 1` reads `inner`.
+ * @param {object} tree - Parsed tree
+ * @returns {Array<{name: string, line: number}>}
+ */
+function findFieldReads(tree) {
+    const reads = [];
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type !== 'selector_expression') return;
+        const field = node.childForFieldName('field');
+        if (field && !_isGoStore(node)) reads.push({ name: field.text, line: field.startPosition.row + 1 });
+    });
+    return reads;
+}
+
+/**
+ * Package-level variables (and constants) and every access to a var-like
+ * name in a parsed file (packageVars trait). A write is a store (_isGoStore) — assignment,
+ * `++`/`--`, element store, delete or self-append: the stored value is
+ * never observed there; any other occurrence (including `&v` and `pkg.V`)
+ * is a read. Locals that
+ * shadow a package var only ever add accesses.
+ * @param {object} tree - Parsed tree
+ * @returns {{vars: Array<{name: string, startLine: number, endLine: number}>,
+ *   consts: Array<{name: string, startLine: number, endLine: number}>,
+ *   reads: Array<{name: string, line: number}>, writes: Array<{name: string, line: number}>}}
+ */
+function findPackageVarAccesses(tree) {
+    const vars = [];
+    const consts = [];
+    const reads = [];
+    const writes = [];
+    const declared = new Set();
+    for (const decl of tree.rootNode.namedChildren) {
+        if (decl.type !== 'var_declaration' && decl.type !== 'const_declaration') continue;
+        const specs = decl.namedChildren.flatMap(c => (c.type === 'var_spec_list' ? c.namedChildren : [c]));
+        for (const spec of specs) {
+            if (spec.type !== 'var_spec' && spec.type !== 'const_spec') continue;
+            for (const nameNode of spec.namedChildren) {
+                if (nameNode.type !== 'identifier') continue;
+                declared.add(nameNode.id);
+                if (nameNode.text === '_') continue;
+                (spec.type === 'var_spec' ? vars : consts).push(
+                    { name: nameNode.text, startLine: spec.startPosition.row + 1, endLine: spec.endPosition.row + 1 });
+            }
+        }
+    }
+    traverseTree(tree.rootNode, (node) => {
+        let target = null;
+        if (node.type === 'identifier') {
+            const parent = node.parent;
+            if (declared.has(node.id)) return;
+            // Declarations of locals, parameters, labels and named results
+            if (parent?.type === 'var_spec' || parent?.type === 'const_spec' ||
+                parent?.type === 'parameter_declaration' || parent?.type === 'variadic_parameter_declaration' ||
+                parent?.type === 'labeled_statement' || parent?.type === 'function_declaration') return;
+            if (parent?.type === 'expression_list' && parent.parent?.type === 'short_var_declaration' &&
+                sameNode(parent.parent.childForFieldName('left'), parent)) return;
+            target = node;
+        } else if (node.type === 'selector_expression') {
+            target = node.childForFieldName('field');
+            if (!target) return;
+            if (_isGoStore(node)) writes.push({ name: target.text, line: target.startPosition.row + 1 });
+            else reads.push({ name: target.text, line: target.startPosition.row + 1 });
+            return;
+        }
+        if (!target) return;
+        if (_isGoStore(target)) writes.push({ name: target.text, line: target.startPosition.row + 1 });
+        else reads.push({ name: target.text, line: target.startPosition.row + 1 });
+    });
+    return { vars, consts, reads, writes };
+}
+
+// Constructors of a package-level sentinel error value
+const GO_ERROR_CONSTRUCTOR = /^(\w*errors\.(New|Errorf)|fmt\.Errorf)$/;
+// Calls that test an error against a sentinel
+const GO_ERROR_MATCHER = /^(\w*errors\.(Is|As)|\w+\.(ErrorIs|NotErrorIs|Equal|NotEqual))$/;
+
+/**
+ * How a sentinel reference uses the error value: 'compared' (==, !=,
+ * errors.Is, a switch case), 'propagated' (returned, stored, passed on, put
+ * in a literal or sent — the value reaches someone who may compare it) or
+ * 'other' (`ErrX.Error()`, reassignment).
+ */
+function _sentinelUse(node) {
+    let n = node;
+    let p = node.parent;
+    while (p?.type === 'parenthesized_expression') { n = p; p = p.parent; }
+    if (!p) return 'other';
+    if (p.type === 'binary_expression') {
+        const op = p.childForFieldName('operator')?.text;
+        return op === '==' || op === '!=' ? 'compared' : 'other';
+    }
+    if (p.type === 'argument_list') {
+        const callee = p.parent?.childForFieldName('function')?.text || '';
+        return GO_ERROR_MATCHER.test(callee) ? 'compared' : 'propagated';
+    }
+    if (p.type === 'expression_list') {
+        const stmt = p.parent;
+        if (stmt?.type === 'expression_case') return 'compared';
+        if (stmt?.type === 'return_statement') return 'propagated';
+        if (stmt?.type === 'assignment_statement' || stmt?.type === 'short_var_declaration') {
+            return sameNode(stmt.childForFieldName('right'), p) ? 'propagated' : 'other';
+        }
+        if (stmt?.type === 'var_spec') return sameNode(stmt.childForFieldName('value'), p) ? 'propagated' : 'other';
+        return 'other';
+    }
+    if (p.type === 'return_statement' || p.type === 'keyed_element' || p.type === 'literal_element' ||
+        p.type === 'literal_value' || p.type === 'send_statement') return 'propagated';
+    return 'other';
+}
+
+/**
+ * Package-level sentinel errors (`var ErrX = errors.New(...)`, fmt.Errorf
+ * and *errors.New/Errorf too) declared in a parsed file, and how the file
+ * uses each name in `names` (every name when omitted): bare identifiers
+ * for its own package's sentinels, `pkg.ErrX` selectors for other packages'.
+ * @param {object} tree - Parsed file
+ * @param {Set<string>|null} [names] - Sentinel names whose uses to report
+ * @returns {{sentinels: Array<{name, startLine, endLine}>, uses: Array<{name, line, kind}>}}
+ */
+function findSentinelErrors(tree, names = null) {
+    const sentinels = [];
+    const declared = new Set();
+    for (const decl of tree.rootNode.namedChildren) {
+        if (decl.type !== 'var_declaration') continue;
+        const specs = decl.namedChildren.flatMap(c => (c.type === 'var_spec_list' ? c.namedChildren : [c]));
+        for (const spec of specs) {
+            if (spec.type !== 'var_spec') continue;
+            const values = spec.childForFieldName('value')?.namedChildren || [];
+            const names = spec.namedChildren.filter(c => c.type === 'identifier');
+            names.forEach((nameNode, i) => {
+                declared.add(nameNode.id);
+                const value = values[i];
+                if (nameNode.text === '_' || value?.type !== 'call_expression' ||
+                    !GO_ERROR_CONSTRUCTOR.test(value.childForFieldName('function')?.text || '')) return;
+                sentinels.push({ name: nameNode.text, startLine: spec.startPosition.row + 1, endLine: spec.endPosition.row + 1 });
+            });
+        }
+    }
+    const uses = [];
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'identifier') {
+            if (declared.has(node.id) || (names && !names.has(node.text))) return;
+            uses.push({ name: node.text, line: node.startPosition.row + 1, kind: _sentinelUse(node) });
+        } else if (node.type === 'selector_expression') {
+            const field = node.childForFieldName('field');
+            if (field && (!names || names.has(field.text))) uses.push({ name: field.text, line: field.startPosition.row + 1, kind: _sentinelUse(node) });
+        }
+    });
+    return { sentinels, uses };
+}
+
+/** How one mention of a channel variable uses it */
+function _channelUse(node) {
+    const p = node.parent;
+    if (!p) return 'escape';
+    if (p.type === 'send_statement' && sameNode(p.childForFieldName('channel'), node)) return 'send';
+    if (p.type === 'unary_expression' && p.childForFieldName('operator')?.text === '<-') return 'receive';
+    if (p.type === 'range_clause' && sameNode(p.childForFieldName('right'), node)) return 'receive';
+    if (p.type === 'parenthesized_expression') return _channelUse(p);
+    if (p.type === 'argument_list') {
+        const fn = p.parent?.childForFieldName('function')?.text;
+        if (fn === 'close') return 'close';
+        if (fn === 'len' || fn === 'cap') return 'inspect';
+    }
+    return 'escape';
+}
+
+/**
+ * Channels and goroutines of a parsed Go file (channelFlows trait).
+ * Channels are function-local variables bound to `make(chan T)`; every
+ * later mention in the function, closures included, is a send, receive
+ * (`<-ch`, `range ch`, select cases), close, len/cap, or an escape —
+ * passed, returned, stored or reassigned, after which the other end is
+ * out of sight. `go` statements are listed with the function they call.
+ * @param {object} tree - Parsed file
+ * @returns {{channels: Array<{name, line, sends, receives, closes, escapes}>,
+ *   goroutines: Array<{name, line, isMethod}>}}
+ */
+function findChannelFlows(tree) {
+    const channels = [];
+    const goroutines = [];
+    for (const fn of tree.rootNode.namedChildren) {
+        if (fn.type !== 'function_declaration' && fn.type !== 'method_declaration') continue;
+        const body = fn.childForFieldName('body');
+        if (!body) continue;
+        const made = new Map(); // name → channel entry
+        const bindings = new Set();
+        traverseTree(body, (node) => {
+            let left = null;
+            let right = null;
+            if (node.type === 'short_var_declaration' || node.type === 'assignment_statement') {
+                left = node.childForFieldName('left')?.namedChildren || [];
+                right = node.childForFieldName('right')?.namedChildren || [];
+            } else if (node.type === 'var_spec') {
+                left = node.namedChildren.filter(c => c.type === 'identifier');
+                right = node.childForFieldName('value')?.namedChildren || [];
+            } else if (node.type === 'go_statement') {
+                const call = node.namedChildren.find(c => c.type === 'call_expression');
+                const callee = call?.childForFieldName('function');
+                if (callee?.type === 'identifier') {
+                    goroutines.push({ name: callee.text, line: node.startPosition.row + 1, isMethod: false });
+                } else if (callee?.type === 'selector_expression') {
+                    const field = callee.childForFieldName('field');
+                    if (field) goroutines.push({ name: field.text, line: node.startPosition.row + 1, isMethod: true });
+                }
+                return;
+            } else {
+                return;
+            }
+            left.forEach((target, i) => {
+                const value = right[i];
+                if (target.type !== 'identifier' || target.text === '_') return;
+                const isMake = value?.type === 'call_expression' && value.childForFieldName('function')?.text === 'make' &&
+                    value.childForFieldName('arguments')?.namedChildren[0]?.type === 'channel_type';
+                if (isMake && !made.has(target.text)) {
+                    made.set(target.text, { name: target.text, line: target.startPosition.row + 1, sends: 0, receives: 0, closes: 0, escapes: 0 });
+                    bindings.add(target.id);
+                } else if (made.has(target.text) && !bindings.has(target.id)) {
+                    // Reassigned: which channel the name holds is no longer known
+                    made.get(target.text).escapes++;
+                    bindings.add(target.id);
+                }
+            });
+        });
+        if (made.size === 0) continue;
+        traverseTree(body, (node) => {
+            if (node.type !== 'identifier' || bindings.has(node.id)) return;
+            const entry = made.get(node.text);
+            if (!entry) return;
+            switch (_channelUse(node)) {
+                case 'send': entry.sends++; break;
+                case 'receive': entry.receives++; break;
+                case 'close': entry.closes++; break;
+                case 'inspect': break;
+                default: entry.escapes++;
+            }
+        });
+        channels.push(...made.values());
+    }
+    return { channels, goroutines };
+}
+
+/** Names a type_parameter_list declares, in order, with their name nodes */
+function _typeParamNames(list) {
+    const out = [];
+    for (const decl of list?.namedChildren || []) {
+        if (decl.type !== 'type_parameter_declaration') continue;
+        for (const c of decl.namedChildren) if (c.type === 'identifier') out.push(c);
+    }
+    return out;
+}
+
+/** Whether `name` is mentioned in `node` as a type or identifier, `skip` nodes aside */
+function _mentionsName(node, name, skip) {
+    let found = false;
+    traverseTree(node, (n) => {
+        if (found) return false;
+        if ((n.type === 'type_identifier' || n.type === 'identifier') && n.text === name &&
+            !skip.some(k => sameNode(k, n))) found = true;
+    });
+    return found;
+}
+
+/**
+ * Type parameters of a parsed Go file (typeParams trait). Each generic
+ * function's and type's parameters, with whether the declaration mentions
+ * them anywhere — signature, body, another parameter's constraint — and,
+ * for methods of generic types, whether the method uses the name its
+ * receiver binds at each position (`func (b Box[T]) Get() T`).
+ * @param {object} tree - Parsed file
+ * @returns {{declared: Array<{owner, ownerKind, name, position, used, line, endLine}>,
+ *   receiverUses: Array<{typeName, position, used}>}}
+ */
+function findTypeParams(tree) {
+    const declared = [];
+    const receiverUses = [];
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'function_declaration' || node.type === 'type_spec') {
+            const list = node.childForFieldName('type_parameters');
+            const names = _typeParamNames(list);
+            if (names.length === 0) return;
+            const owner = node.childForFieldName('name')?.text;
+            names.forEach((nameNode, position) => {
+                declared.push({
+                    owner,
+                    ownerKind: node.type === 'type_spec' ? 'type' : 'function',
+                    name: nameNode.text,
+                    position,
+                    used: nameNode.text === '_' || _mentionsName(node, nameNode.text, names),
+                    line: node.startPosition.row + 1,
+                    endLine: node.endPosition.row + 1,
+                });
+            });
+        } else if (node.type === 'method_declaration') {
+            const param = node.childForFieldName('receiver')?.namedChildren.find(c => c.type === 'parameter_declaration');
+            let type = param?.childForFieldName('type');
+            if (type?.type === 'pointer_type') type = type.namedChildren[0];
+            if (type?.type !== 'generic_type') return;
+            const typeName = type.childForFieldName('type')?.text;
+            const args = type.childForFieldName('type_arguments')?.namedChildren || [];
+            const bound = args.map(a => (a.type === 'type_elem' ? a.namedChildren[0] : a)).filter(Boolean);
+            const rest = ['parameters', 'result', 'body'].map(f => node.childForFieldName(f)).filter(Boolean);
+            bound.forEach((b, position) => {
+                receiverUses.push({
+                    typeName,
+                    position,
+                    used: b.text === '_' || rest.some(r => _mentionsName(r, b.text, [])),
+                });
+            });
+        }
+    });
+    return { declared, receiverUses };
+}
+
+/** Package name an import path most likely declares: `gopkg.in/yaml.v3` → yaml, `go-chi/chi/v5` → chi */
+function _goPackageName(path) {
+    const parts = path.split('/');
+    let last = parts[parts.length - 1];
+    if (/^v\d+$/.test(last) && parts.length > 1) last = parts[parts.length - 2];
+    return last.replace(/\.v\d+$/, '').replace(/^go-/, '').replace(/-go$/, '').replace(/[^\w]/g, '');
+}
+
+/** Import aliases of a parsed Go file: package name → import path */
+function _importAliases(root) {
+    const aliases = new Map();
+    for (const decl of root.namedChildren) {
+        if (decl.type !== 'import_declaration') continue;
+        const specs = decl.namedChildren.flatMap(c => (c.type === 'import_spec_list' ? c.namedChildren : [c]));
+        for (const spec of specs) {
+            if (spec.type !== 'import_spec') continue;
+            const path = spec.childForFieldName('path')?.text.slice(1, -1);
+            if (!path) continue;
+            const alias = spec.childForFieldName('name')?.text || _goPackageName(path);
+            if (alias !== '_' && alias !== '.') aliases.set(alias, path);
+        }
+    }
+    return aliases;
+}
+
+/**
+ * Init effects of a parsed Go file (initEffects trait). Each top-level
+ * function is reduced to what it does to the world: the variables it
+ * stores into (`x = v`, `m[k] = v`, `x++`, `delete(m, k)`, `pkg.V = v`)
+ * and the functions it calls as statements, which may store in turn.
+ * Locals are its own business; anything else — a method call, a `go`,
+ * a send, a write through a parameter, a switch — is an effect of its
+ * own. `var _ = f()` declarations run at init too and are counted.
+ * @param {object} tree - Parsed file
+ * @returns {{functions: Array<{name, line, endLine, stores: Array<{name, importPath}>,
+ *   calls: Array<{name, importPath}>, effects: boolean}>, blankVarInits: number}}
+ */
+function findInitEffects(tree) {
+    const aliases = _importAliases(tree.rootNode);
+    const functions = [];
+    let blankVarInits = 0;
+    for (const decl of tree.rootNode.namedChildren) {
+        if (decl.type === 'var_declaration') {
+            const specs = decl.namedChildren.flatMap(c => (c.type === 'var_spec_list' ? c.namedChildren : [c]));
+            for (const spec of specs) {
+                const value = spec.type === 'var_spec' ? spec.childForFieldName('value') : null;
+                if (value && spec.namedChildren.some(c => c.type === 'identifier' && c.text === '_') &&
+                    value.namedChildren.some(v => v.type === 'call_expression')) blankVarInits++;
+            }
+            continue;
+        }
+        if (decl.type !== 'function_declaration') continue;
+        const body = decl.childForFieldName('body');
+        if (!body) continue;
+        const params = new Set();
+        for (const list of ['parameters', 'result'].map(f => decl.childForFieldName(f)).filter(Boolean)) {
+            traverseTree(list, (node) => {
+                if (node.type === 'identifier' && node.parent?.type?.endsWith('parameter_declaration')) params.add(node.text);
+            });
+        }
+        const locals = new Set();
+        traverseTree(body, (node) => {
+            if (node.type === 'identifier' && (node.parent?.type === 'var_spec' || node.parent?.type === 'const_spec' ||
+                (node.parent?.type === 'expression_list' &&
+                    ['short_var_declaration', 'range_clause'].includes(node.parent.parent?.type) &&
+                    sameNode(node.parent.parent.childForFieldName('left'), node.parent)))) locals.add(node.text);
+        });
+        const entry = { name: decl.childForFieldName('name')?.text, line: decl.startPosition.row + 1,
+            endLine: decl.endPosition.row + 1, stores: [], calls: [], effects: false };
+        // Root variable of a store target; false when the store leaves the function's reach
+        const store = (target) => {
+            let node = target;
+            while (node) {
+                if (node.type === 'selector_expression') {
+                    const operand = node.childForFieldName('operand');
+                    if (operand?.type === 'identifier' && aliases.has(operand.text) && !locals.has(operand.text)) {
+                        entry.stores.push({ name: node.childForFieldName('field').text, importPath: aliases.get(operand.text) });
+                        return true;
+                    }
+                    node = operand;
+                } else if (node.type === 'index_expression') {
+                    node = node.childForFieldName('operand');
+                } else if (node.type === 'parenthesized_expression' ||
+                    (node.type === 'unary_expression' && node.childForFieldName('operator')?.text === '*')) {
+                    node = node.namedChildren[node.namedChildren.length - 1];
+                } else break;
+            }
+            if (node?.type !== 'identifier') return false;
+            if (node.text === '_' || locals.has(node.text)) return true;
+            if (params.has(node.text)) return false;
+            entry.stores.push({ name: node.text, importPath: null });
+            return true;
+        };
+        const statement = (node) => {
+            switch (node.type) {
+                case 'block':
+                case 'statement_list':
+                    return node.namedChildren.every(statement);
+                case 'short_var_declaration':
+                case 'var_declaration':
+                case 'const_declaration':
+                case 'type_declaration':
+                case 'return_statement':
+                case 'empty_statement':
+                case 'comment':
+                    return true;
+                case 'assignment_statement':
+                    return (node.childForFieldName('left')?.namedChildren || []).every(store);
+                case 'inc_statement':
+                case 'dec_statement':
+                    return store(node.namedChildren[0]);
+                case 'if_statement':
+                    return ['consequence', 'alternative'].every(f => !node.childForFieldName(f) || statement(node.childForFieldName(f)));
+                case 'for_statement':
+                    return !node.childForFieldName('body') || statement(node.childForFieldName('body'));
+                case 'expression_statement': {
+                    const call = node.namedChildren[0];
+                    if (call?.type !== 'call_expression') return false;
+                    const callee = call.childForFieldName('function');
+                    const args = call.childForFieldName('arguments')?.namedChildren || [];
+                    if (callee?.text === 'delete') return args.length > 0 && store(args[0]);
+                    if (callee?.type === 'identifier' && !GO_BUILTINS.has(callee.text)) {
+                        entry.calls.push({ name: callee.text, importPath: null });
+                        return true;
+                    }
+                    const operand = callee?.type === 'selector_expression' ? callee.childForFieldName('operand') : null;
+                    if (operand?.type === 'identifier' && aliases.has(operand.text) && !locals.has(operand.text)) {
+                        entry.calls.push({ name: callee.childForFieldName('field').text, importPath: aliases.get(operand.text) });
+                        return true;
+                    }
+                    return false;
+                }
+                default:
+                    return false;
+            }
+        };
+        entry.effects = !statement(body);
+        functions.push(entry);
+    }
+    return { functions, blankVarInits };
+}
+
+/** Scope end of a local declared by `decl`: its enclosing block, or for parameters the function */
+function _localScopeEnd(decl) {
+    for (let node = decl.parent; node; node = node.parent) {
+        if (decl.type === 'parameter_declaration' || decl.type === 'variadic_parameter_declaration') {
+            if (node.type === 'function_declaration' || node.type === 'method_declaration' || node.type === 'func_literal') return node.endPosition;
+        } else if (node.type === 'block' || node.type === 'statement_list' || node.type === 'for_statement' ||
+            node.type === 'if_statement' || node.type === 'expression_case' || node.type === 'type_case' ||
+            node.type === 'communication_case' || node.type === 'default_case') {
+            return node.endPosition;
+        }
+    }
+    return null;
+}
+
+const _beforeOrAt = (a, b) => a.row < b.row || (a.row === b.row && a.column <= b.column);
+
+/**
+ * Imports of a parsed Go file with where each one is used (importIssues
+ * trait), and the locals that shadow an import's package name. A use is
+ * `name.X` in an expression or a type outside every shadowing local's
+ * scope; a shadow is a parameter, `:=`, `var` or range variable named like
+ * an imported package, in scope from its declaration to the end of its
+ * block (for parameters, its function).
+ * @param {object} tree - Parsed file
+ * @returns {{imports: Array<{path, alias, name, line, specsOnLine, uses: Array<{line, column}>}>,
+ *   shadows: Array<{name, kind, line}>}}
+ */
+function findImportIssues(tree) {
+    const imports = [];
+    const rows = new Map();
+    for (const decl of tree.rootNode.namedChildren) {
+        if (decl.type !== 'import_declaration') continue;
+        const specs = decl.namedChildren.flatMap(c => (c.type === 'import_spec_list' ? c.namedChildren : [c]));
+        for (const spec of specs) {
+            if (spec.type !== 'import_spec') continue;
+            const path = spec.childForFieldName('path')?.text.slice(1, -1);
+            if (!path) continue;
+            const alias = spec.childForFieldName('name')?.text || null;
+            const row = spec.startPosition.row;
+            rows.set(row, (rows.get(row) || 0) + 1);
+            imports.push({ path, alias, name: alias || _goPackageName(path), line: row + 1, uses: [] });
+        }
+    }
+    for (const imp of imports) imp.specsOnLine = rows.get(imp.line - 1);
+    const names = new Set(imports.map(i => i.name));
+
+    const shadows = [];
+    const scopes = []; // { name, from, to }
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type !== 'identifier' || !names.has(node.text)) return;
+        const parent = node.parent;
+        let decl = null;
+        let kind = null;
+        if (parent?.type === 'parameter_declaration' || parent?.type === 'variadic_parameter_declaration') {
+            decl = parent;
+            kind = 'parameter';
+        } else if (parent?.type === 'var_spec' && parent.parent?.parent?.type !== 'source_file' &&
+            parent.parent?.type !== 'source_file') {
+            decl = parent;
+            kind = 'variable';
+        } else if (parent?.type === 'expression_list' &&
+            (parent.parent?.type === 'short_var_declaration' || parent.parent?.type === 'range_clause') &&
+            sameNode(parent.parent.childForFieldName('left'), parent)) {
+            decl = parent.parent;
+            kind = 'variable';
+        }
+        if (!decl) return;
+        const to = _localScopeEnd(decl);
+        if (!to) return;
+        shadows.push({ name: node.text, kind, line: node.startPosition.row + 1 });
+        // A local is in scope after its declaration: `url := url.Parse(s)` still reads the package
+        scopes.push({ name: node.text, from: kind === 'parameter' ? node.startPosition : decl.endPosition, to });
+    });
+
+    const byName = new Map(imports.filter(i => i.alias !== '_' && i.alias !== '.').map(i => [i.name, i]));
+    traverseTree(tree.rootNode, (node) => {
+        let ref = null;
+        if (node.type === 'selector_expression') ref = node.childForFieldName('operand');
+        else if (node.type === 'qualified_type') ref = node.childForFieldName('package');
+        if (!ref || (ref.type !== 'identifier' && ref.type !== 'package_identifier')) return;
+        const imp = byName.get(ref.text);
+        if (!imp) return;
+        const at = ref.startPosition;
+        if (scopes.some(s => s.name === ref.text && _beforeOrAt(s.from, at) && _beforeOrAt(at, s.to))) return;
+        imp.uses.push({ line: at.row + 1, column: at.column });
+    });
+    return { imports, shadows };
+}
+
+// flag/pflag definitions: String, StringVar, StringP, StringVarP, ...
+const GO_FLAG_DEFINE = /^(Bool|Int|Int64|Int32|Uint|Uint64|Uint32|String|Float64|Float32|Duration|Func|BoolFunc|TextVar|StringSlice|StringArray|StringToString|IntSlice|Count|IP|IPNet)(Var)?(P)?$/;
+// Reads of a knob by its name: pflag/cobra GetString, Lookup, Changed; viper Get*, IsSet, Sub
+const GO_KNOB_LOOKUP = /^(Get\w*|Lookup|IsSet|Changed|Sub|UnmarshalKey)$/;
+
+/** Unquoted text of a Go string literal node, or null */
+function _goStringValue(node) {
+    if (node?.type === 'interpreted_string_literal' || node?.type === 'raw_string_literal') return node.text.slice(1, -1);
+    return null;
+}
+
+/**
+ * Configuration knobs of a parsed Go file (configKnobs trait): command-line
+ * flags defined with flag/pflag (cobra's cmd.Flags() included), env
+ * variables read with os.Getenv/os.LookupEnv, and viper keys registered
+ * with BindEnv/SetDefault/BindPFlag. A knob whose value lands in a variable
+ * or field carries it as `target`; one kept only by name is read through
+ * `lookups` (GetString("name"), viper.GetBool("key"), ...). `unmarshal`
+ * is set when viper.Unmarshal hands every key to a struct.
+ * @param {object} tree - Parsed file
+ * @returns {{knobs: Array<{kind, key, target, line}>, lookups: Array<{key, line}>, unmarshal: boolean}}
+ */
+function findConfigKnobs(tree) {
+    const knobs = [];
+    const lookups = [];
+    let unmarshal = false;
+    const flagSets = new Set();
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type !== 'call_expression') return;
+        const fn = node.childForFieldName('function');
+        if (fn?.text === 'flag.NewFlagSet' || fn?.text === 'pflag.NewFlagSet') {
+            const target = _bindingTarget(node);
+            if (target) flagSets.add(target);
+        }
+    });
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type !== 'call_expression') return;
+        const fn = node.childForFieldName('function');
+        if (fn?.type !== 'selector_expression') return;
+        const method = fn.childForFieldName('field')?.text;
+        const receiver = fn.childForFieldName('operand')?.text || '';
+        const args = node.childForFieldName('arguments')?.namedChildren || [];
+        const line = node.startPosition.row + 1;
+        const flagReceiver = receiver === 'flag' || receiver === 'pflag' || flagSets.has(receiver) ||
+            /\.(Persistent|Local)?Flags\(\)$/.test(receiver);
+
+        if (flagReceiver && GO_FLAG_DEFINE.test(method)) {
+            if (/Var/.test(method)) {
+                const ref = args[0];
+                const key = _goStringValue(args[1]);
+                if (key === null) return;
+                const target = ref?.type === 'unary_expression' && ref.childForFieldName('operator')?.text === '&'
+                    ? ref.childForFieldName('operand')?.text : null;
+                knobs.push({ kind: 'flag', key, target, line });
+            } else {
+                const key = _goStringValue(args[0]);
+                if (key !== null) knobs.push({ kind: 'flag', key, target: _bindingTarget(node), line });
+            }
+        } else if (receiver === 'os' && (method === 'Getenv' || method === 'LookupEnv')) {
+            const key = _goStringValue(args[0]);
+            // Used in place (an argument, a condition) is a read; only a
+            // value stored for later can go unread
+            const target = _bindingTarget(node);
+            if (key !== null && target) knobs.push({ kind: 'env', key, target, line });
+        } else if (method === 'BindEnv' || method === 'SetDefault' || method === 'BindPFlag') {
+            const key = _goStringValue(args[0]);
+            if (key !== null) knobs.push({ kind: 'viper', key, target: null, line });
+        } else if (GO_KNOB_LOOKUP.test(method)) {
+            const key = _goStringValue(args[0]);
+            if (key !== null) lookups.push({ key, line });
+        } else if (method === 'Unmarshal' && /viper/i.test(receiver)) {
+            unmarshal = true;
+        }
+    });
+    return { knobs, lookups, unmarshal };
+}
+
+/** The variable or field a call's value is stored in (`x := call()`, `o.f = call()`, `var x = call()`) */
+function _bindingTarget(call) {
+    const list = call.parent;
+    const stmt = list?.parent;
+    if (!stmt) return null;
+    let left;
+    if (stmt.type === 'short_var_declaration' || stmt.type === 'assignment_statement') {
+        if (!sameNode(stmt.childForFieldName('right'), list)) return null;
+        left = stmt.childForFieldName('left')?.namedChildren || [];
+    } else if (stmt.type === 'var_spec') {
+        if (!sameNode(stmt.childForFieldName('value'), list)) return null;
+        left = stmt.namedChildren.filter(c => c.type === 'identifier');
+    } else {
+        return null;
+    }
+    const at = list.namedChildren.findIndex(c => sameNode(c, call));
+    const target = left[at];
+    return target && target.text !== '_' && (target.type === 'identifier' || target.type === 'selector_expression')
+        ? target.text : null;
+}
+
+// Calls that never return: the builtin and the process/log exits
+const GO_NORETURN_CALLS = new Set(['panic', 'os.Exit', 'log.Fatal', 'log.Fatalf', 'log.Fatalln',
+    'log.Panic', 'log.Panicf', 'log.Panicln', 'runtime.Goexit']);
+
+const GO_STATEMENT_TYPE = /(_statement|_declaration)$|^block$/;
+
+/**
+ * Statements of a block or case clause, whichever grammar version wraps
+ * them in a statement_list. A select case's own send/receive is skipped.
+ */
+function _blockStatements(node) {
+    if (!node) return [];
+    const communication = node.childForFieldName('communication');
+    const out = [];
+    for (const child of node.namedChildren) {
+        if (child.type === 'statement_list') out.push(...child.namedChildren.filter(c => GO_STATEMENT_TYPE.test(c.type)));
+        else if (GO_STATEMENT_TYPE.test(child.type) && !sameNode(child, communication)) out.push(child);
+    }
+    return out;
+}
+
+/** `break` that leaves this loop/switch (unlabeled, not inside a nested one) */
+function _breaksOut(node) {
+    let found = false;
+    const walk = (n) => {
+        if (found) return;
+        if (n.type === 'break_statement') { found = true; return; }
+        if (n.type === 'for_statement' || n.type.endsWith('switch_statement') ||
+            n.type === 'select_statement' || n.type === 'func_literal') return;
+        for (const c of n.namedChildren) walk(c);
+    };
+    for (const c of node.namedChildren) walk(c);
+    return found;
+}
+
+/**
+ * Boolean value of a condition when it is a constant: `true`/`false`, a
+ * file-level const bound to one, a feature-flag check `flagValue` settles,
+ * `!` of either, or a `&&`/`||` settled by one side. null when it depends
+ * on runtime values.
+ */
+function _constantCondition(node, boolConsts, flagValue = null) {
+    if (!node) return null;
+    if (node.type === 'true') return true;
+    if (node.type === 'false') return false;
+    if (node.type === 'identifier' && boolConsts.has(node.text)) return boolConsts.get(node.text);
+    if (node.type === 'call_expression' && flagValue) return flagValue(node);
+    if (node.type === 'parenthesized_expression') return _constantCondition(node.namedChild(0), boolConsts, flagValue);
+    if (node.type === 'unary_expression' && node.childForFieldName('operator')?.text === '!') {
+        const v = _constantCondition(node.childForFieldName('operand'), boolConsts, flagValue);
+        return v === null ? null : !v;
+    }
+    if (node.type === 'binary_expression') {
+        const op = node.childForFieldName('operator')?.text;
+        const l = _constantCondition(node.childForFieldName('left'), boolConsts, flagValue);
+        const r = _constantCondition(node.childForFieldName('right'), boolConsts, flagValue);
+        if (op === '&&') return l === false || r === false ? false : (l === true && r === true ? true : null);
+        if (op === '||') return l === true || r === true ? true : (l === false && r === false ? false : null);
+    }
+    return null;
+}
+
+const GO_OS = new Set(['aix', 'android', 'darwin', 'dragonfly', 'freebsd', 'hurd', 'illumos', 'ios', 'js',
+    'linux', 'nacl', 'netbsd', 'openbsd', 'plan9', 'solaris', 'wasip1', 'windows', 'zos']);
+const GO_UNIX_OS = new Set(['aix', 'android', 'darwin', 'dragonfly', 'freebsd', 'hurd', 'illumos', 'ios',
+    'linux', 'netbsd', 'openbsd', 'solaris']);
+const GO_ARCH = new Set(['386', 'amd64', 'amd64p32', 'arm', 'armbe', 'arm64', 'arm64be', 'loong64', 'mips',
+    'mipsle', 'mips64', 'mips64le', 'mips64p32', 'mips64p32le', 'ppc', 'ppc64', 'ppc64le', 'riscv', 'riscv64',
+    's390', 's390x', 'sparc', 'sparc64', 'wasm']);
+// GOOS values that also satisfy another GOOS tag
+const GO_OS_IMPLIES = { android: 'linux', ios: 'darwin', illumos: 'solaris' };
+
+/** Parse a `//go:build` expression into a tree: {tag} | {not} | {op, left, right} */
+function _parseBuildExpr(text) {
+    const tokens = text.match(/&&|\|\||!|\(|\)|[\w.]+/g) || [];
+    let pos = 0;
+    const primary = () => {
+        const t = tokens[pos++];
+        if (t === '!') return { not: primary() };
+        if (t === '(') {
+            const inner = or();
+            pos++;
+            return inner;
+        }
+        if (t === undefined) throw new Error('truncated build expression');
+        return { tag: t };
+    };
+    const and = () => {
+        let left = primary();
+        while (tokens[pos] === '&&') { pos++; left = { op: '&&', left, right: primary() }; }
+        return left;
+    };
+    const or = () => {
+        let left = and();
+        while (tokens[pos] === '||') { pos++; left = { op: '||', left, right: and() }; }
+        return left;
+    };
+    const expr = or();
+    if (pos !== tokens.length) throw new Error('malformed build expression');
+    return expr;
+}
+
+/** Whether a build tag is set for a platform: {goos, goarch, tags: Set} */
+function _buildTagSet(tag, platform) {
+    if (tag === platform.goos || tag === platform.goarch) return true;
+    if (GO_OS_IMPLIES[platform.goos] === tag) return true;
+    if (tag === 'unix') return GO_UNIX_OS.has(platform.goos);
+    if (/^go1\.\d+$/.test(tag) || tag === 'gc' || tag === 'cgo') return true;
+    return platform.tags.has(tag);
+}
+
+/** The expression cannot hold without `tag` (it is one of the top-level && operands) */
+function _requiresBuildTag(expr, tag) {
+    if (expr.tag) return expr.tag === tag;
+    return expr.op === '&&' && (_requiresBuildTag(expr.left, tag) || _requiresBuildTag(expr.right, tag));
+}
+
+function _evalBuildExpr(expr, platform) {
+    if (expr.tag) return _buildTagSet(expr.tag, platform);
+    if (expr.not) return !_evalBuildExpr(expr.not, platform);
+    const left = _evalBuildExpr(expr.left, platform);
+    return expr.op === '&&' ? left && _evalBuildExpr(expr.right, platform) : left || _evalBuildExpr(expr.right, platform);
+}
+
+/**
+ * Build constraints of a Go file (buildConstraint trait): the `//go:build`
+ * line (or legacy `// +build` lines) above the package clause, and the
+ * `_GOOS`, `_GOARCH`, `_GOOS_GOARCH` file-name suffixes. Besides the GOOS
+ * and GOARCH tags, `unix`, the go1.N release tags, `gc` and `cgo` are
+ * taken as set; any other tag only when the platform lists it.
+ * @param {string} content - File content
+ * @param {string} relativePath - File path (the name carries suffix constraints)
+ * @returns {{expression: string, builds: function({goos: string, goarch: string, tags: Set<string>}): boolean}|null}
+ *   null when the file builds everywhere (or its constraint does not parse)
+ */
+function goBuildConstraint(content, relativePath) {
+    const parts = [];
+    let expr = null;
+    const legacy = [];
+    for (const line of content.split('\n')) {
+        const t = line.trim();
+        if (t.startsWith('package ')) break;
+        const goBuild = /^\/\/go:build\s+(.+)$/.exec(t);
+        if (goBuild) {
+            try { expr = _parseBuildExpr(goBuild[1]); } catch { return null; }
+            parts.push(goBuild[1].trim());
+            continue;
+        }
+        const plusBuild = /^\/\/\s*\+build\s+(.+)$/.exec(t);
+        if (plusBuild) legacy.push(plusBuild[1].trim());
+    }
+    // `// +build a,b c` is (a && b) || c; separate lines are ANDed
+    if (!expr && legacy.length > 0) {
+        const text = legacy.map(l => `(${l.split(/\s+/).map(o => `(${o.split(',').join(' && ')})`).join(' || ')})`).join(' && ');
+        try { expr = _parseBuildExpr(text); } catch { return null; }
+        parts.push(legacy.join(' && '));
+    }
+    const base = relativePath.split('/').pop().replace(/\.go$/, '').replace(/_test$/, '');
+    const segs = base.split('_');
+    let goos = null;
+    let goarch = null;
+    if (segs.length >= 3 && GO_OS.has(segs[segs.length - 2]) && GO_ARCH.has(segs[segs.length - 1])) {
+        goos = segs[segs.length - 2];
+        goarch = segs[segs.length - 1];
+    } else if (segs.length >= 2 && GO_OS.has(segs[segs.length - 1])) {
+        goos = segs[segs.length - 1];
+    } else if (segs.length >= 2 && GO_ARCH.has(segs[segs.length - 1])) {
+        goarch = segs[segs.length - 1];
+    }
+    // A suffix the //go:build line already requires adds nothing to show
+    if (goos && !(expr && _requiresBuildTag(expr, goos))) parts.push(goos);
+    if (goarch && !(expr && _requiresBuildTag(expr, goarch))) parts.push(goarch);
+    if (parts.length === 0) return null;
+    return {
+        expression: parts.join(' && '),
+        builds: (platform) => (!expr || _evalBuildExpr(expr, platform)) &&
+            (!goos || goos === platform.goos || GO_OS_IMPLIES[platform.goos] === goos) &&
+            (!goarch || goarch === platform.goarch),
+    };
+}
+
+/**
+ * Concrete type of a compile-time interface assertion line naming `iface`
+ * (interfaceAssertion trait): `var _ Repository = (*pgRepo)(nil)`, `_
+ * store.Repo = &memRepo{}` in a var block, `new(T)` or `T{}` on the right.
+ * @param {string} line - Source line
+ * @param {string} iface - Interface name
+ * @returns {string|null} The asserted type's name ('' when the value is no
+ *   plain type, like a constructor call), or null when the line is no
+ *   assertion of `iface`
+ */
+function goInterfaceAssertion(line, iface) {
+    const m = line.match(new RegExp(`^\\s*(?:var\\s+)?_\\s+(?:\\w+\\.)?${iface}\\s*=\\s*(.+?)\\s*(?://.*)?$`));
+    if (!m) return null;
+    const value = m[1];
+    const t = value.match(/^\(\s*\*\s*(?:\w+\.)?(\w+)(?:\[[^\]]*\])?\s*\)\s*\(\s*nil\s*\)$/) ||
+        value.match(/^&?(?:\w+\.)?(\w+)(?:\[[^\]]*\])?\s*\{.*\}$/) ||
+        value.match(/^new\(\s*(?:\w+\.)?(\w+)(?:\[[^\]]*\])?\s*\)$/);
+    return t ? t[1] : '';
+}
+
+/**
+ * `//go:embed` variables of a Go file (embeddedAssets trait): the
+ * directive lines right above a var (comments and blank lines may sit
+ * between), their patterns (quoted ones unquoted, an `all:` prefix kept),
+ * and how the variable holds them — 'fs' for embed.FS, else 'string' or
+ * 'bytes'.
+ * @param {string} content - File content
+ * @returns {Array<{name: string, kind: string, line: number, varLine: number, patterns: string[]}>}
+ */
+function findEmbeds(content) {
+    const lines = content.split('\n');
+    const embeds = [];
+    let pending = null;
+    for (let i = 0; i < lines.length; i++) {
+        const text = lines[i].trim();
+        const directive = text.match(/^\/\/go:embed\s+(.*)$/);
+        if (directive) {
+            if (!pending) pending = { line: i + 1, patterns: [] };
+            for (const m of directive[1].matchAll(/"((?:[^"\\]|\\.)*)"|`([^`]*)`|(\S+)/g)) {
+                pending.patterns.push(m[1] ?? m[2] ?? m[3]);
+            }
+            continue;
+        }
+        if (!pending || text === '' || text.startsWith('//')) continue;
+        const decl = text.match(/^(?:var\s+)?(\w+)\s+(.+?)\s*(?:\/\/.*)?$/);
+        if (decl && !/^(var|const|type|func|import|package)$/.test(decl[1]) && decl[1] !== '(') {
+            const type = decl[2];
+            embeds.push({
+                name: decl[1],
+                kind: /\bembed\.FS$/.test(type) ? 'fs' : /^\[\]byte$/.test(type) ? 'bytes' : 'string',
+                line: pending.line,
+                varLine: i + 1,
+                patterns: pending.patterns,
+            });
+        }
+        // `var (` opens a block: the directive belongs to its first spec
+        if (!/^var\s*\($/.test(text)) pending = null;
+    }
+    return embeds;
+}
+
+/**
+ * Statements no execution reaches (unreachableCode trait): code after an
+ * unconditional return/goto/panic/os.Exit/log.Fatal (or an if/else, block
+ * or endless `for` that always ends that way), branches whose condition is
+ * a constant (`if false`, `if debug` with `const debug = false`), and switch
+ * cases that can never match — after `case true:` in a tagless switch, after
+ * `case any:` in a type switch, and constant-false cases. Labeled statements
+ * are goto targets and stay reachable.
+ *
+ * With `flags`, a call to one of `flags.checks` (e.g. `flags.Enabled`)
+ * whose first argument names a flag in `flags.on`/`flags.off` is a
+ * constant too; the argument may be a string literal or a file-level
+ * string const. Findings that only a flag settles carry `flag: true`.
+ * @param {object} tree - Parsed tree
+ * @param {{checks: string[], on: string[], off: string[]}} [flags]
+ * @returns {Array<{line: number, endLine: number, reason: string, flag?: boolean}>}
+ */
+function findUnreachable(tree, flags = null) {
+    const findings = [];
+    // File-level and local consts bound to a bool literal; a same-name
+    // var or parameter anywhere in the file makes the name unknown
+    const boolConsts = new Map();
+    const stringConsts = new Map();
+    const shadowed = new Set();
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'const_spec') {
+            const names = node.namedChildren.filter(c => c.type === 'identifier');
+            const values = node.childForFieldName('value')?.namedChildren || [];
+            names.forEach((n, i) => {
+                const v = values[i];
+                if (v && (v.type === 'true' || v.type === 'false')) boolConsts.set(n.text, v.type === 'true');
+                else shadowed.add(n.text);
+                if (v && (v.type === 'interpreted_string_literal' || v.type === 'raw_string_literal')) {
+                    stringConsts.set(n.text, v.text.slice(1, -1));
+                }
+            });
+        } else if (node.type === 'var_spec' || node.type === 'parameter_declaration') {
+            for (const c of node.namedChildren) if (c.type === 'identifier') shadowed.add(c.text);
+        } else if (node.type === 'short_var_declaration') {
+            for (const c of node.childForFieldName('left')?.namedChildren || []) shadowed.add(c.text);
+        }
+    });
+    for (const name of shadowed) boolConsts.delete(name);
+
+    // Feature-flag checks: `checks` match the callee text or its trailing
+    // selector path (`flags.Enabled` matches `s.flags.Enabled`)
+    let flagValue = null;
+    if (flags && flags.checks?.length > 0) {
+        const on = new Set(flags.on || []);
+        const off = new Set(flags.off || []);
+        flagValue = (call) => {
+            const fn = call.childForFieldName('function')?.text;
+            if (!fn || !flags.checks.some(c => fn === c || fn.endsWith('.' + c))) return null;
+            const arg = call.childForFieldName('arguments')?.namedChildren[0];
+            if (!arg) return null;
+            const name = arg.type === 'interpreted_string_literal' || arg.type === 'raw_string_literal'
+                ? arg.text.slice(1, -1)
+                : stringConsts.get(arg.text) ?? arg.text;
+            for (const key of [name, arg.text]) {
+                if (on.has(key)) return true;
+                if (off.has(key)) return false;
+            }
+            return null;
+        };
+    }
+    // Value of a condition, and whether only a feature flag settles it
+    const evaluate = (cond) => {
+        const value = _constantCondition(cond, boolConsts, flagValue);
+        return { value, flag: value !== null && flagValue !== null && _constantCondition(cond, boolConsts) === null };
+    };
+
+    const lineOf = (n) => n.startPosition.row + 1;
+    const endOf = (n) => n.endPosition.row + 1;
+    const report = (from, to, reason, flag = false) => findings.push({
+        line: lineOf(from), endLine: endOf(to), reason, ...(flag && { flag: true }),
+    });
+
+    const noReturnCall = (stmt) => {
+        if (stmt.type !== 'expression_statement') return false;
+        const call = stmt.namedChild(0);
+        if (call?.type !== 'call_expression') return false;
+        return GO_NORETURN_CALLS.has(call.childForFieldName('function')?.text);
+    };
+    // Why control never falls out of `stmt`, or null when it can
+    const terminates = (stmt) => {
+        switch (stmt.type) {
+            case 'return_statement': return 'return';
+            case 'goto_statement': return 'goto';
+            case 'break_statement': return 'break';
+            case 'continue_statement': return 'continue';
+            case 'block': {
+                const stmts = _blockStatements(stmt);
+                return stmts.length ? terminates(stmts[stmts.length - 1]) : null;
+            }
+            case 'if_statement': {
+                const alt = stmt.childForFieldName('alternative');
+                if (!alt) return null;
+                const a = terminates(stmt.childForFieldName('consequence'));
+                const b = terminates(alt);
+                return a && b ? (a === b ? a : 'exit') : null;
+            }
+            case 'for_statement': {
+                const endless = !stmt.namedChildren.some(c =>
+                    c.type === 'for_clause' ? c.childForFieldName('condition') : c.type !== 'block' && c.type !== 'comment');
+                return endless && !_breaksOut(stmt.childForFieldName('body') || stmt) ? 'endless for' : null;
+            }
+            default:
+                return noReturnCall(stmt) ? stmt.namedChild(0).childForFieldName('function').text : null;
+        }
+    };
+
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'block' || node.type === 'expression_case' || node.type === 'default_case' ||
+            node.type === 'type_case' || node.type === 'communication_case') {
+            const stmts = _blockStatements(node);
+            for (let i = 0; i < stmts.length - 1; i++) {
+                const why = terminates(stmts[i]);
+                if (!why) continue;
+                const rest = stmts.slice(i + 1);
+                const label = rest.findIndex(s => s.type === 'labeled_statement');
+                const dead = label === -1 ? rest : rest.slice(0, label);
+                if (dead.length > 0) {
+                    report(dead[0], dead[dead.length - 1], `after ${why} at line ${lineOf(stmts[i])}`);
+                }
+                break;
+            }
+        } else if (node.type === 'if_statement') {
+            const cond = node.childForFieldName('condition');
+            const { value, flag } = evaluate(cond);
+            const via = flag ? ' (feature flag)' : '';
+            if (value === false) {
+                report(node.childForFieldName('consequence'), node.childForFieldName('consequence'),
+                    `condition \`${cond.text}\` is always false${via}`, flag);
+            } else if (value === true && node.childForFieldName('alternative')) {
+                const alt = node.childForFieldName('alternative');
+                report(alt, alt, `else of always-true condition \`${cond.text}\`${via}`, flag);
+            }
+        } else if (node.type === 'for_statement') {
+            const clause = node.namedChildren.find(c => c.type === 'for_clause');
+            const cond = clause ? clause.childForFieldName('condition')
+                : node.namedChildren.find(c => c.type !== 'block' && c.type !== 'comment' && c.type !== 'range_clause');
+            const body = node.childForFieldName('body');
+            const { value, flag } = evaluate(cond);
+            if (body && value === false) {
+                report(body, body, `loop condition \`${cond.text}\` is always false${flag ? ' (feature flag)' : ''}`, flag);
+            }
+        } else if (node.type === 'expression_switch_statement') {
+            if (node.childForFieldName('value')) return;
+            // Tagless: the first true case wins, default included
+            let matchedAll = null;
+            let matchedByFlag = false;
+            for (const c of node.namedChildren.filter(n => n.type === 'expression_case')) {
+                if (matchedAll) {
+                    report(c, c, `case after \`case true\` at line ${lineOf(matchedAll)}`, matchedByFlag);
+                    continue;
+                }
+                const values = c.childForFieldName('value')?.namedChildren || [];
+                const known = values.map(v => evaluate(v));
+                const hit = known.find(k => k.value === true);
+                if (hit) {
+                    matchedAll = c;
+                    matchedByFlag = hit.flag;
+                } else if (known.length > 0 && known.every(k => k.value === false)) {
+                    const flag = known.some(k => k.flag);
+                    report(c, c, `case is always false${flag ? ' (feature flag)' : ''}`, flag);
+                }
+            }
+            const fallback = node.namedChildren.find(n => n.type === 'default_case');
+            if (matchedAll && fallback) {
+                report(fallback, fallback, `default after \`case true\` at line ${lineOf(matchedAll)}`, matchedByFlag);
+            }
+        } else if (node.type === 'type_switch_statement') {
+            let catchAll = null;
+            for (const c of node.namedChildren.filter(n => n.type === 'type_case')) {
+                if (catchAll) {
+                    report(c, c, `case after \`case ${catchAll.text}\` at line ${lineOf(catchAll)}`);
+                    continue;
+                }
+                const types = c.namedChildren.filter(n => n.type.endsWith('type') || n.type === 'type_identifier');
+                const any = types.find(t => t.text === 'any' || /^interface\s*\{\s*\}$/.test(t.text));
+                if (any) catchAll = any;
+            }
+        }
+    });
+    // A dead region's inner findings add nothing
+    return findings
+        .filter(f => !findings.some(o => o !== f && o.line <= f.line && f.endLine <= o.endLine &&
+            (o.line < f.line || f.endLine < o.endLine)))
+        .sort((a, b) => a.line - b.line);
+}
+
 /**
  * Classify a Go symbol as a runtime entry point of a specific kind.
  * Returns 'test' | 'main' | null.
@@ -1962,5 +3158,18 @@ module.exports = {
     findUsagesInCode,
     isEntryPoint,
     getEntryPointKind,
+    isAuditedField,
+    findFieldReads,
+    findPackageVarAccesses,
+    findSentinelErrors,
+    findChannelFlows,
+    findConfigKnobs,
+    findTypeParams,
+    findInitEffects,
+    findImportIssues,
+    findUnreachable,
+    findEmbeds,
+    goInterfaceAssertion,
+    goBuildConstraint,
     parse
 };
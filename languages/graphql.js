@@ -0,0 +1,342 @@
+/**
+ * languages/graphql.js - Tree-sitter based GraphQL parsing (SDL + operations)
+ *
+ * Handles: object types (as classes, fields as members), interfaces, enums,
+ * input types (as structs), unions and scalars (as types), `extend type`,
+ * named operations and fragments (as functions), and — as calls — field
+ * selections, fragment spreads and every named type reference.
+ *
+ * Schema fields are consumed only by operations: .graphql documents in the
+ * repo and `gql` templates embedded in host-language files (the
+ * embeddedQueries trait). Selections carry no parent type here, so a field
+ * is live when ANY operation selects a field of its name. Resolver
+ * implementations are not liveness: a resolved field nothing queries is
+ * dead on both sides.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+// Operation roots are executed by clients, never referenced by name
+const ROOT_TYPES = new Set(['Query', 'Mutation', 'Subscription']);
+
+const TYPE_KINDS = {
+    object_type_definition: 'class',
+    object_type_extension: 'class',
+    interface_type_definition: 'interface',
+    interface_type_extension: 'interface',
+    enum_type_definition: 'enum',
+    enum_type_extension: 'enum',
+    input_object_type_definition: 'struct',
+    input_object_type_extension: 'struct',
+    union_type_definition: 'type',
+    scalar_type_definition: 'type',
+};
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+/** `"""Block"""` / `"string"` description → first line */
+function descriptionText(node) {
+    const desc = namedChildOfType(node, 'description');
+    if (!desc) return null;
+    const text = desc.text.replace(/^"""|"""$|^"|"$/g, '').trim();
+    return text.split('\n')[0].trim() || null;
+}
+
+/** `# comment` lines directly above a definition */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    if (i < 0 || !/^\s*#/.test(lines[i])) return null;
+    while (i > 0 && /^\s*#/.test(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*#+\s?/, '').trim() || null;
+}
+
+function fieldMembers(container, lines, selectable) {
+    const members = [];
+    if (!container) return members;
+    for (const def of container.namedChildren) {
+        let nameNode;
+        let typeNode = null;
+        let argsNode = null;
+        if (def.type === 'field_definition' || def.type === 'input_value_definition') {
+            nameNode = namedChildOfType(def, 'name');
+            typeNode = namedChildOfType(def, 'type');
+            argsNode = namedChildOfType(def, 'arguments_definition');
+        } else if (def.type === 'enum_value_definition') {
+            const value = namedChildOfType(def, 'enum_value');
+            nameNode = value && (namedChildOfType(value, 'name') || value);
+        }
+        if (!nameNode) continue;
+        const { startLine, endLine } = nodeToLocation(def, lines);
+        const docstring = descriptionText(def);
+        members.push({
+            name: nameNode.text,
+            startLine,
+            endLine,
+            memberType: 'field',
+            modifiers: selectable ? ['selectable'] : [],
+            ...(typeNode && { fieldType: typeNode.text }),
+            ...(argsNode && { params: argsNode.text.replace(/^\(|\)$/g, '').trim() }),
+            ...(docstring && { docstring }),
+        });
+    }
+    return members;
+}
+
+function collectDefinitions(tree, lines) {
+    const classes = [];
+    const functions = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        const kind = TYPE_KINDS[node.type];
+        if (kind) {
+            const nameNode = namedChildOfType(node, 'name');
+            if (!nameNode) return false;
+            const { startLine, endLine } = nodeToLocation(node, lines);
+            const docstring = descriptionText(node) || extractDocstring(lines, startLine);
+            const fieldsNode = namedChildOfType(node, 'fields_definition') ||
+                namedChildOfType(node, 'input_fields_definition') ||
+                namedChildOfType(node, 'enum_values_definition');
+            const implementsNode = namedChildOfType(node, 'implements_interfaces');
+            const selectable = kind === 'class' || kind === 'interface';
+            classes.push({
+                name: nameNode.text,
+                startLine,
+                endLine,
+                type: kind,
+                members: fieldMembers(fieldsNode, lines, selectable),
+                modifiers: node.type.endsWith('_extension') ? ['extend'] : [],
+                ...(implementsNode && {
+                    implements: implementsNode.namedChildren
+                        .filter(c => c.type === 'named_type').map(c => c.text),
+                }),
+                ...(docstring && { docstring }),
+            });
+            return false;
+        }
+        if (node.type === 'operation_definition' || node.type === 'fragment_definition') {
+            const isFragment = node.type === 'fragment_definition';
+            const nameNode = isFragment
+                ? namedChildOfType(namedChildOfType(node, 'fragment_name') || node, 'name')
+                : namedChildOfType(node, 'name');
+            if (!nameNode) return false; // anonymous `{ ... }` operation
+            const { startLine, endLine } = nodeToLocation(node, lines);
+            const opType = isFragment ? 'fragment' : (namedChildOfType(node, 'operation_type')?.text || 'query');
+            const vars = namedChildOfType(node, 'variable_definitions');
+            const typeCondition = isFragment ? namedChildOfType(node, 'type_condition') : null;
+            const docstring = extractDocstring(lines, startLine);
+            functions.push({
+                name: nameNode.text,
+                params: vars ? vars.text.replace(/^\(|\)$/g, '').trim() : '',
+                startLine,
+                endLine,
+                modifiers: [opType],
+                ...(typeCondition && { returnType: typeCondition.text.replace(/^on\s+/, '') }),
+                ...(docstring && { docstring }),
+            });
+            return false;
+        }
+        return true;
+    });
+    return { classes, functions };
+}
+
+function findFunctions(code, parser) {
+    return collectDefinitions(parseTree(parser, code), code.split('\n')).functions;
+}
+
+function findClasses(code, parser) {
+    return collectDefinitions(parseTree(parser, code), code.split('\n')).classes;
+}
+
+function findStateObjects() {
+    return [];
+}
+
+/**
+ * Parse a GraphQL document completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const { classes, functions } = collectDefinitions(tree, lines);
+    return {
+        language: 'graphql',
+        totalLines: lines.length,
+        functions,
+        classes,
+        stateObjects: [],
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find references in a GraphQL document
+ *
+ *   query Me { viewer { ...UserCard } } → viewer (field), UserCard (fragment)
+ *   fragment UserCard on User { name }  → User (type), name (field)
+ *   type Post { author: User! }         → User (type)
+ *   schema { query: RootQuery }         → RootQuery (type)
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    let enclosing = null;
+
+    const push = (nameNode, extra) => {
+        calls.push({
+            name: nameNode.text,
+            line: nameNode.startPosition.row + 1,
+            isMethod: false,
+            enclosingFunction: enclosing ? { ...enclosing } : null,
+            uncertain: false,
+            ...extra,
+        });
+    };
+
+    const SCOPES = new Set(['operation_definition', 'fragment_definition', ...Object.keys(TYPE_KINDS)]);
+    for (const def of tree.rootNode.namedChildren) {
+        traverseTree(def, (node) => {
+            if (SCOPES.has(node.type)) {
+                const nameNode = node.type === 'fragment_definition'
+                    ? namedChildOfType(namedChildOfType(node, 'fragment_name') || node, 'name')
+                    : namedChildOfType(node, 'name');
+                enclosing = {
+                    name: nameNode ? nameNode.text : '<anonymous>',
+                    startLine: node.startPosition.row + 1,
+                    endLine: node.endPosition.row + 1,
+                };
+                return true;
+            }
+            if (node.type === 'field') {
+                const nameNode = namedChildOfType(node, 'name');
+                if (nameNode && !nameNode.text.startsWith('__')) push(nameNode, { isMethod: true, selection: true });
+                return true;
+            }
+            if (node.type === 'fragment_spread') {
+                const nameNode = namedChildOfType(namedChildOfType(node, 'fragment_name') || node, 'name');
+                if (nameNode) push(nameNode);
+                return false;
+            }
+            if (node.type === 'named_type') {
+                const nameNode = namedChildOfType(node, 'name') || node;
+                push(nameNode);
+                return false;
+            }
+            return true;
+        });
+    }
+    return calls;
+}
+
+/**
+ * GraphQL documents have no import syntax; `#import "./x.graphql"` is a
+ * graphql-tag/webpack-loader convention worth following.
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code) {
+    const imports = [];
+    const lines = code.split('\n');
+    for (let i = 0; i < lines.length; i++) {
+        const m = lines[i].match(/^\s*#import\s+["']([^"']+)["']/);
+        if (m) imports.push({ module: m[1], names: [], type: 'import', line: i + 1 });
+    }
+    return imports;
+}
+
+function findExportsInCode() {
+    return [];
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+    const DEFINING = new Set(['field_definition', 'input_value_definition', 'enum_value',
+        'operation_definition', 'fragment_name', ...Object.keys(TYPE_KINDS)]);
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'name' || node.text !== name) return true;
+        const parentType = node.parent?.type;
+        let usageType = 'reference';
+        if (DEFINING.has(parentType)) {
+            // fragment_name under a spread is a use, under a definition a def
+            usageType = parentType === 'fragment_name' && node.parent.parent?.type === 'fragment_spread'
+                ? 'call' : 'definition';
+        } else if (parentType === 'field') {
+            usageType = 'call';
+        } else if (parentType === 'alias' || parentType === 'argument' || parentType === 'variable') {
+            return true;
+        }
+        usages.push({
+            line: node.startPosition.row + 1,
+            column: node.startPosition.column,
+            usageType,
+        });
+        return true;
+    });
+    return usages;
+}
+
+/** Fields clients select (object/interface fields) join the deadcode audit */
+function isAuditedField(symbol) {
+    return (symbol.modifiers || []).includes('selectable');
+}
+
+/**
+ * Classify a GraphQL entry point:
+ * - 'main':      named operations — executed by the client that sends them
+ * - 'framework': operation root types (Query, Mutation, Subscription)
+ */
+function getEntryPointKind(symbol) {
+    const mods = symbol.modifiers || [];
+    if (symbol.type === 'function' && (mods.includes('query') || mods.includes('mutation') ||
+        mods.includes('subscription'))) {
+        return 'main';
+    }
+    if (symbol.type === 'class' && ROOT_TYPES.has(symbol.name)) return 'framework';
+    return null;
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    isAuditedField,
+    parse
+};
@@ -0,0 +1,594 @@
+/**
+ * languages/groovy.js - Groovy sources and Gradle build scripts
+ *
+ * Handles: classes/interfaces/enums/traits with their methods, script-level
+ * methods (`def computeVersion() { ... }`), closures bound to names
+ * (`def sign = { ... }`, `ext.sign = { ... }`), and Gradle tasks —
+ * `task foo { }`, `task foo(type: Copy)`, `tasks.register('foo')`,
+ * `tasks.create('foo', Zip)` — indexed as functions with the 'task' modifier.
+ *
+ * A task is invoked by another task's `dependsOn` / `finalizedBy` /
+ * `mustRunAfter` / `shouldRunAfter`, by `tasks.named('foo')` and friends, by
+ * `defaultTasks`, or from outside the build (`./gradlew foo` in CI configs
+ * and scripts — the bridgedSources trait). Those references are recorded as
+ * calls of the task name, so an unreferenced task is claimable like an
+ * uncalled function.
+ *
+ * Extraction is a lexical scan over source with comments and string bodies
+ * blanked: Gradle's DSL is command-expression Groovy (`dependsOn foo`,
+ * `apply plugin: 'java'`), which reads more reliably from tokens than from
+ * a grammar's error recovery. The tree-sitter parse reports recovery only.
+ */
+
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+/**
+ * Blank comments (both views) and string bodies (masked view only),
+ * preserving offsets and newlines. Quotes stay so `'foo'` remains a token.
+ * @returns {{noComments: string, masked: string}}
+ */
+function maskSource(code) {
+    const noComments = code.split('');
+    const masked = code.split('');
+    const n = code.length;
+    let i = 0;
+    const blank = (arr, from, to) => {
+        for (let k = from; k < to; k++) if (arr[k] !== '\n') arr[k] = ' ';
+    };
+    while (i < n) {
+        const c = code[i];
+        if (c === '/' && code[i + 1] === '/') {
+            let j = i;
+            while (j < n && code[j] !== '\n') j++;
+            blank(noComments, i, j);
+            blank(masked, i, j);
+            i = j;
+            continue;
+        }
+        if (c === '/' && code[i + 1] === '*') {
+            let j = code.indexOf('*/', i + 2);
+            j = j < 0 ? n : j + 2;
+            blank(noComments, i, j);
+            blank(masked, i, j);
+            i = j;
+            continue;
+        }
+        if (c === '"' || c === "'") {
+            const triple = code.startsWith(c.repeat(3), i);
+            const delim = triple ? c.repeat(3) : c;
+            let j = i + delim.length;
+            while (j < n && !code.startsWith(delim, j)) {
+                if (code[j] === '\\') j++;
+                else if (!triple && code[j] === '\n') break;
+                j++;
+            }
+            blank(masked, i + delim.length, Math.min(j, n));
+            // GString interpolations are code: "${BASE}.${buildNumber()}"
+            if (c === '"') {
+                for (let k = code.indexOf('${', i + delim.length); k >= 0 && k < j; k = code.indexOf('${', k + 1)) {
+                    let depth = 0;
+                    let e = k + 1;
+                    for (; e < j; e++) {
+                        if (code[e] === '{') depth++;
+                        else if (code[e] === '}' && --depth === 0) break;
+                    }
+                    for (let m = k + 2; m < e; m++) masked[m] = code[m];
+                    k = e;
+                }
+            }
+            i = Math.min(j + delim.length, n);
+            continue;
+        }
+        i++;
+    }
+    return { noComments: noComments.join(''), masked: masked.join('') };
+}
+
+function lineIndex(code) {
+    const starts = [0];
+    for (let i = 0; i < code.length; i++) if (code[i] === '\n') starts.push(i + 1);
+    return (offset) => {
+        let lo = 0;
+        let hi = starts.length - 1;
+        while (lo < hi) {
+            const mid = (lo + hi + 1) >> 1;
+            if (starts[mid] <= offset) lo = mid; else hi = mid - 1;
+        }
+        return lo + 1;
+    };
+}
+
+/** Index of the brace closing the `{` at `open` */
+function matchBrace(text, open) {
+    let depth = 0;
+    for (let i = open; i < text.length; i++) {
+        if (text[i] === '{') depth++;
+        else if (text[i] === '}' && --depth === 0) return i;
+    }
+    return text.length - 1;
+}
+
+/**
+ * End of a statement starting at `pos` (paren depth `depth`): its closure
+ * body's closing brace when one follows, else the end of the line.
+ */
+function statementEnd(text, pos, depth = 0) {
+    for (let i = pos; i < text.length; i++) {
+        const c = text[i];
+        if (c === '(' || c === '[') depth++;
+        else if (c === ')' || c === ']') depth--;
+        else if (c === '{' && depth <= 0) return matchBrace(text, i);
+        else if (c === '\n' && depth <= 0) {
+            // A trailing closure may open on the next line
+            const rest = text.slice(i + 1).match(/^\s*/)[0];
+            if (text[i + 1 + rest.length] === '{' && !rest.includes('\n\n')) continue;
+            return i - 1;
+        }
+    }
+    return text.length - 1;
+}
+
+const KEYWORDS = new Set([
+    'if', 'else', 'for', 'while', 'do', 'switch', 'case', 'default', 'try', 'catch',
+    'finally', 'return', 'throw', 'new', 'in', 'instanceof', 'as', 'assert', 'break',
+    'continue', 'synchronized', 'this', 'super', 'true', 'false', 'null', 'def',
+    'class', 'interface', 'enum', 'trait', 'extends', 'implements', 'import',
+    'package', 'static', 'final', 'public', 'private', 'protected', 'abstract',
+    'void', 'boolean', 'int', 'long', 'double', 'float', 'char', 'byte', 'short',
+    'var', 'task', 'it', 'throws', 'native', 'transient', 'volatile',
+]);
+
+const MODIFIER_WORDS = 'public|private|protected|static|final|abstract|synchronized|def|void|boolean|int|long|double|float|char|byte|short';
+const CLASS_RE = /(^|[^.\w$@])(class|interface|enum|trait)\s+([A-Za-z_$][\w$]*)([^{;]*)\{/g;
+const METHOD_RE = new RegExp(
+    `(^|[;{}\\n])[ \\t]*((?:@[\\w$.]+(?:\\([^()]*\\))?\\s+|(?:${MODIFIER_WORDS})\\s+|[A-Z][\\w$.]*(?:<[^>(){};]*>)?(?:\\[\\])*\\s+)+)` +
+    `([a-zA-Z_$][\\w$]*)\\s*\\(([^()]*(?:\\([^()]*\\)[^()]*)*)\\)\\s*(?:throws\\s+[\\w$.,\\s]+)?\\{`, 'g');
+const CLOSURE_RE = /(^|[;{}\n])[ \t]*(def\s+|ext\s*\.\s*)([a-zA-Z_$][\w$]*)\s*=\s*\{/g;
+const TASK_RE = /(^|[;{}\n])[ \t]*task\b\s*(\(?)\s*(['"]?)([A-Za-z_][\w-]*)\3/g;
+const REGISTER_RE = /(^|[;{}\n])[ \t]*(?:project\s*\.\s*)?tasks\s*\.\s*(?:register|create|maybeCreate)\s*\(\s*(['"])([\w-]+)\2/g;
+const TASK_TYPE_RE = /^\s*(?:\(\s*)?(?:type\s*:\s*|,\s*)([A-Z][\w$.]*)/;
+
+/** Annotations Gradle reads reflectively — the member is consumed by the build */
+const GRADLE_ANNOTATIONS = new Set([
+    'taskaction', 'input', 'inputfile', 'inputfiles', 'inputdirectory', 'outputfile',
+    'outputfiles', 'outputdirectory', 'outputdirectories', 'nested', 'internal',
+    'classpath', 'inject',
+]);
+
+/** `/** doc *\/` or `// doc` directly above a declaration */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    if (i < 0) return null;
+    if (/\*\/\s*$/.test(lines[i])) {
+        while (i > 0 && !/\/\*/.test(lines[i])) i--;
+        const text = lines.slice(i, startLine - 1).join('\n')
+            .replace(/^\s*\/\*+|\*+\/\s*$/g, '').replace(/^\s*\*\s?/gm, '').trim();
+        return text.split('\n')[0].trim() || null;
+    }
+    if (!/^\s*\/\//.test(lines[i])) return null;
+    while (i > 0 && /^\s*\/\//.test(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*\/\/+\s?/, '').trim() || null;
+}
+
+/** Is offset `i` of the masked text inside a string body (blanked)? */
+function insideString(noComments, masked, i) {
+    return noComments[i] !== masked[i];
+}
+
+/**
+ * Scan declarations: classes (with member methods), script methods, named
+ * closures and Gradle tasks. Offsets are kept for call attribution.
+ */
+function scanDeclarations(code) {
+    const { noComments, masked } = maskSource(code);
+    const lines = code.split('\n');
+    const lineOf = lineIndex(code);
+    const classes = [];
+    const functions = [];
+    const nameOffsets = new Set();
+
+    CLASS_RE.lastIndex = 0;
+    for (let m; (m = CLASS_RE.exec(masked));) {
+        const start = m.index + m[1].length;
+        const open = CLASS_RE.lastIndex - 1;
+        const end = matchBrace(masked, open);
+        const header = m[4];
+        const ext = header.match(/\bextends\s+([\w$.]+(?:<[^>]*>)?(?:\s*,\s*[\w$.]+(?:<[^>]*>)?)*)/);
+        const impl = header.match(/\bimplements\s+([\s\S]+?)\s*$/);
+        const prefix = masked.slice(masked.lastIndexOf('\n', start) + 1, start);
+        nameOffsets.add(start + m[0].slice(m[1].length).indexOf(m[3], m[2].length));
+        classes.push({
+            name: m[3],
+            type: m[2] === 'trait' ? 'trait' : m[2],
+            startLine: lineOf(start),
+            endLine: lineOf(end),
+            _start: start,
+            _end: end,
+            members: [],
+            modifiers: (prefix.match(/\b(public|private|protected|abstract|final|static)\b/g) || []),
+            ...(ext && { extends: m[2] === 'interface' ? ext[1].split(/\s*,\s*/) : ext[1] }),
+            ...(impl && { implements: impl[1].split(/\s*,\s*/).map(s => s.replace(/<.*$/, '').trim()) }),
+        });
+    }
+    const innermostClass = (offset) => {
+        let best = null;
+        for (const c of classes) {
+            if (offset > c._start && offset < c._end && (!best || c._start > best._start)) best = c;
+        }
+        return best;
+    };
+
+    METHOD_RE.lastIndex = 0;
+    for (let m; (m = METHOD_RE.exec(masked));) {
+        const name = m[3];
+        if (KEYWORDS.has(name)) continue;
+        const declStart = m.index + m[1].length;
+        const tokens = m[2];
+        const tokensAt = m.index + m[0].indexOf(tokens, m[1].length);
+        const nameOffset = tokensAt + tokens.length;
+        const open = METHOD_RE.lastIndex - 1;
+        const end = matchBrace(masked, open);
+        const annotations = (tokens.match(/@[\w$.]+/g) || []).map(a => a.slice(1).split('.').pop().toLowerCase());
+        const modifiers = [
+            ...(tokens.match(/\b(public|private|protected|static|final|abstract|synchronized)\b/g) || []),
+            ...annotations,
+        ];
+        const typeTokens = tokens.replace(/@[\w$.]+(?:\([^()]*\))?/g, '')
+            .replace(new RegExp(`\\b(?:${MODIFIER_WORDS.replace('|def|void', '')})\\b`, 'g'), '').trim();
+        const startLine = lineOf(tokensAt);
+        const cls = innermostClass(declStart);
+        nameOffsets.add(nameOffset);
+        const params = m[4].trim();
+        const docstring = extractDocstring(lines, startLine);
+        const fn = {
+            name,
+            params,
+            paramsStructured: params ? params.split(',').map(p => {
+                const parts = p.trim().split('=')[0].trim().split(/\s+/);
+                return { name: parts.pop(), ...(parts.length > 0 && { type: parts.join(' ') }) };
+            }) : [],
+            startLine,
+            endLine: lineOf(end),
+            _start: declStart,
+            _end: end,
+            modifiers,
+            ...(typeTokens && typeTokens !== 'def' && { returnType: typeTokens }),
+            ...(docstring && { docstring }),
+        };
+        if (cls) {
+            fn.memberType = modifiers.includes('static') ? 'static' : name === cls.name ? 'constructor' : 'method';
+            cls.members.push(fn);
+        } else {
+            functions.push(fn);
+        }
+    }
+
+    CLOSURE_RE.lastIndex = 0;
+    for (let m; (m = CLOSURE_RE.exec(masked));) {
+        const declStart = m.index + m[1].length;
+        if (innermostClass(declStart)) continue;
+        const open = CLOSURE_RE.lastIndex - 1;
+        const end = matchBrace(masked, open);
+        const startLine = lineOf(declStart);
+        const paramMatch = masked.slice(open + 1, end).match(/^\s*([\w$,\s]*?)\s*->/);
+        // Only `\s*=\s*` separates the name from the closure's brace
+        nameOffsets.add(masked.lastIndexOf(m[3], open));
+        functions.push({
+            name: m[3],
+            params: paramMatch ? paramMatch[1].trim() : '',
+            paramsStructured: paramMatch && paramMatch[1].trim()
+                ? paramMatch[1].split(',').map(p => ({ name: p.trim().split(/\s+/).pop() })) : [],
+            startLine,
+            endLine: lineOf(end),
+            _start: declStart,
+            _end: end,
+            modifiers: m[2].startsWith('ext') ? ['closure', 'ext'] : ['closure'],
+        });
+    }
+
+    const addTask = (declStart, name, nameOffset, afterName, depth) => {
+        if (insideString(noComments, masked, declStart)) return;
+        const end = statementEnd(masked, afterName, depth);
+        const typeMatch = noComments.slice(afterName, afterName + 200).match(TASK_TYPE_RE);
+        const startLine = lineOf(declStart);
+        const docstring = extractDocstring(lines, startLine);
+        nameOffsets.add(nameOffset);
+        functions.push({
+            name,
+            params: '',
+            paramsStructured: [],
+            startLine,
+            endLine: Math.max(startLine, lineOf(end)),
+            _start: declStart,
+            _end: end,
+            modifiers: ['task'],
+            ...(typeMatch && { returnType: typeMatch[1] }),
+            ...(docstring && { docstring }),
+        });
+    };
+    TASK_RE.lastIndex = 0;
+    for (let m; (m = TASK_RE.exec(noComments));) {
+        const declStart = m.index + m[1].length + m[0].slice(m[1].length).search(/\S/);
+        if (innermostClass(declStart)) continue;
+        const afterName = TASK_RE.lastIndex;
+        addTask(declStart, m[4], afterName - m[3].length - m[4].length, afterName, m[2] ? 1 : 0);
+    }
+    REGISTER_RE.lastIndex = 0;
+    for (let m; (m = REGISTER_RE.exec(noComments));) {
+        const declStart = m.index + m[1].length + m[0].slice(m[1].length).search(/\S/);
+        if (innermostClass(declStart)) continue;
+        const afterName = REGISTER_RE.lastIndex;
+        addTask(declStart, m[3], afterName - 1 - m[3].length, afterName, 1);
+    }
+
+    functions.sort((a, b) => a.startLine - b.startLine);
+    classes.sort((a, b) => a.startLine - b.startLine);
+    return { functions, classes, nameOffsets, noComments, masked, lineOf };
+}
+
+const strip = ({ _start, _end, ...rest }) => rest;
+
+function findFunctions(code) {
+    return scanDeclarations(code).functions.map(strip);
+}
+
+function findClasses(code) {
+    return scanDeclarations(code).classes.map(c => ({ ...strip(c), members: c.members.map(strip) }));
+}
+
+function findStateObjects() {
+    return [];
+}
+
+/**
+ * Parse a Groovy source or Gradle script completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const { functions, classes } = scanDeclarations(code);
+    return {
+        language: 'groovy',
+        totalLines: lines.length,
+        functions: functions.map(strip),
+        classes: classes.map(c => ({ ...strip(c), members: c.members.map(strip) })),
+        stateObjects: [],
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+// Task-graph wiring: the arguments are tasks this build invokes
+const TASK_WIRING_RE = /\b(dependsOn|finalizedBy|mustRunAfter|shouldRunAfter|defaultTasks)\b\s*\(?([^\n]*)/g;
+const TASK_LOOKUP_RE = /\btasks\s*(?:\.\s*(?:named|getByName|findByName|getByPath|findByPath|getAt)\s*\(\s*|\[\s*)(['"])(?::?[\w-]+:)*([\w-]+)\1/g;
+const TASK_CONTAINER_METHODS = new Set([
+    'register', 'create', 'maybeCreate', 'named', 'getByName', 'findByName', 'getByPath',
+    'findByPath', 'withType', 'matching', 'configureEach', 'all', 'each', 'whenTaskAdded',
+    'getAt', 'findAll', 'collect', 'size', 'names', 'getNames', 'remove', 'replace',
+]);
+
+/**
+ * Task names referenced by a wiring argument list:
+ * `'foo'`, `":lib:foo"`, `foo`, `[a, 'b']`, `tasks.named('foo')`
+ */
+function wiredTaskNames(args) {
+    const names = [];
+    // tasks.named('x') / tasks['x'] arguments are read by the lookup scan
+    args = args.replace(/\btasks\s*(?:\.\s*\w+\s*\([^)]*\)|\[[^\]]*\])/g, ' ');
+    // dependsOn(a, b) { ... } — drop the closing paren and trailing closure
+    args = args.replace(/\)\s*(\{.*)?$/, '');
+    for (const m of args.matchAll(/(['"])(?::?[\w-]+:)*([\w-]+)\1|(?<![\w$.'"])([a-zA-Z_$][\w$]*)(?!\s*[(.:'"\w])/g)) {
+        const name = m[2] || m[3];
+        if (name && !KEYWORDS.has(name) && name !== 'tasks') names.push(name);
+    }
+    return names;
+}
+
+/**
+ * Find calls in Groovy code
+ *
+ *   computeVersion()        → computeVersion
+ *   project.sign(jar)       → sign (method, receiver project)
+ *   println "x"             → println (command expression)
+ *   new Signer(key)         → Signer (constructor)
+ *   list.each(this.&render) → render (reference)
+ *   dependsOn 'packDocs'    → packDocs (task reference)
+ *   tasks.named('packDocs') → packDocs (task reference)
+ *
+ * @param {string} code - Source code to analyze
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code) {
+    const { functions, classes, nameOffsets, noComments, masked, lineOf } = scanDeclarations(code);
+    const scopes = [...functions, ...classes.flatMap(c => c.members)];
+    const enclosing = (offset) => {
+        let best = null;
+        for (const s of scopes) {
+            if (offset >= s._start && offset <= s._end && (!best || s._start > best._start)) best = s;
+        }
+        return best ? { name: best.name, startLine: best.startLine, endLine: best.endLine } : null;
+    };
+    const calls = [];
+    const push = (offset, call) => calls.push({
+        line: lineOf(offset),
+        enclosingFunction: enclosing(offset),
+        uncertain: false,
+        ...call,
+    });
+
+    // Parenthesized and closure-taking calls: name(...), recv.name(...), new X(...)
+    for (const m of masked.matchAll(/([a-zA-Z_$][\w$]*)\s*\(/g)) {
+        const name = m[1];
+        if (KEYWORDS.has(name) || nameOffsets.has(m.index)) continue;
+        const before = masked.slice(Math.max(0, m.index - 64), m.index);
+        if (/\bnew\s+$/.test(before)) {
+            push(m.index, { name, isMethod: false, isConstructor: true });
+            continue;
+        }
+        const recv = before.match(/([\w$]+|\))\s*\??\.\s*&?\s*$/);
+        if (recv) {
+            push(m.index, {
+                name,
+                isMethod: true,
+                ...(recv[1] !== ')' && { receiver: recv[1] }),
+                uncertain: recv[1] === ')',
+            });
+            continue;
+        }
+        // `Type name(...)` without a body is an abstract declaration
+        if (/(?:^|[;{}\n])\s*(?:(?:public|private|protected|static|final|abstract|def|void)\s+)*[A-Z][\w$.]*(?:<[^>]*>)?(?:\[\])*\s+$/.test(before)) {
+            continue;
+        }
+        push(m.index, { name, isMethod: false });
+    }
+
+    // Command expressions at statement start: `println "x"`, `signJar file`
+    for (const m of masked.matchAll(/(^|[;{}\n])[ \t]*([a-z_$][\w$]*)[ \t]+(?=[\w$'"[(-])(?!(?:in|instanceof|as)\b|->)/g)) {
+        const name = m[2];
+        const offset = m.index + m[0].length - m[0].replace(/^[;{}\n]?[ \t]*/, '').length;
+        if (KEYWORDS.has(name) || nameOffsets.has(offset)) continue;
+        // `type name = value` is a declaration
+        const lineEnd = masked.indexOf('\n', offset);
+        const rest = masked.slice(offset + name.length, lineEnd < 0 ? masked.length : lineEnd);
+        if (/^\s*[\w$]+\s*=(?!=)/.test(rest)) continue;
+        push(offset, { name, isMethod: false });
+    }
+
+    // Method pointers: this.&render, obj.&render
+    for (const m of masked.matchAll(/\.&\s*([a-zA-Z_$][\w$]*)/g)) {
+        push(m.index, { name: m[1], isMethod: false, isFunctionReference: true });
+    }
+
+    // Gradle task references — strings matter here, so read noComments
+    for (const m of noComments.matchAll(TASK_WIRING_RE)) {
+        if (insideString(noComments, masked, m.index)) continue;
+        for (const name of wiredTaskNames(m[2])) {
+            push(m.index, { name, isMethod: false, isFunctionReference: true });
+        }
+    }
+    for (const m of noComments.matchAll(TASK_LOOKUP_RE)) {
+        if (insideString(noComments, masked, m.index)) continue;
+        push(m.index, { name: m[2], isMethod: false, isFunctionReference: true });
+    }
+    for (const m of masked.matchAll(/(?<![\w$.])tasks\s*\.\s*([a-zA-Z_$][\w$]*)\b(?!\s*[({])/g)) {
+        if (TASK_CONTAINER_METHODS.has(m[1])) continue;
+        push(m.index, { name: m[1], isMethod: false, isFunctionReference: true });
+    }
+
+    return calls;
+}
+
+/**
+ * `import a.b.C`, `import static a.b.C.m`, `import a.b.*`,
+ * `apply from: 'gradle/publishing.gradle'`
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code) {
+    const { noComments } = maskSource(code);
+    const imports = [];
+    const lines = noComments.split('\n');
+    for (let i = 0; i < lines.length; i++) {
+        const imp = lines[i].match(/^\s*import\s+(static\s+)?([\w$.]+(?:\.\*)?)(?:\s+as\s+([\w$]+))?/);
+        if (imp) {
+            const last = imp[2].split('.').pop();
+            imports.push({
+                module: imp[2],
+                names: last === '*' ? [] : [imp[3] || last],
+                type: imp[1] ? 'static' : 'import',
+                line: i + 1,
+            });
+            continue;
+        }
+        const from = lines[i].match(/\bapply\s*\(?\s*from\s*:\s*(['"])([^'"]+)\1/);
+        if (from) imports.push({ module: from[2], names: [], type: 'apply', line: i + 1 });
+    }
+    return imports;
+}
+
+/**
+ * Non-private top-level types are the file's exports (Groovy's default
+ * visibility is public); `ext.` closures are visible to every project.
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code) {
+    const { functions, classes } = scanDeclarations(code);
+    const exports = [];
+    for (const c of classes) {
+        const nested = classes.some(o => o !== c && c._start > o._start && c._end < o._end);
+        if (!nested && !c.modifiers.includes('private')) {
+            exports.push({ name: c.name, type: c.type, line: c.startLine });
+        }
+    }
+    for (const f of functions) {
+        if (f.modifiers.includes('ext')) exports.push({ name: f.name, type: 'ext', line: f.startLine });
+    }
+    return exports;
+}
+
+/**
+ * Find all usages of a name: its declarations, calls (`name(`) and other
+ * word occurrences in code (string task references included).
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name) {
+    const { nameOffsets, noComments, masked, lineOf } = scanDeclarations(code);
+    const usages = [];
+    const escaped = name.replace(/[$]/g, '\\$');
+    const re = new RegExp(`(?<![\\w$])${escaped}(?![\\w$])`, 'g');
+    const lineStarts = (offset) => noComments.lastIndexOf('\n', offset - 1) + 1;
+    for (const m of noComments.matchAll(re)) {
+        const inString = insideString(noComments, masked, m.index);
+        // A string is a use only as a task name: dependsOn 'x', tasks.named('x')
+        if (inString && !/^['"]$/.test(noComments[m.index - 1]) && !/:$/.test(noComments[m.index - 1])) continue;
+        let usageType = 'reference';
+        if (nameOffsets.has(m.index)) usageType = 'definition';
+        else if (!inString && /^\s*\(/.test(masked.slice(m.index + name.length))) usageType = 'call';
+        usages.push({ line: lineOf(m.index), column: m.index - lineStarts(m.index), usageType });
+    }
+    return usages;
+}
+
+/**
+ * Classify a Groovy entry point:
+ * - 'main':      static main()
+ * - 'test':      JUnit annotations, Spock fixture methods
+ * - 'framework': members Gradle reads reflectively (@TaskAction, @Input,
+ *                @OutputFile, ...) and @Override implementations
+ */
+function getEntryPointKind(symbol) {
+    const mods = symbol.modifiers || [];
+    if (symbol.name === 'main' && mods.includes('static')) return 'main';
+    if (mods.some(m => m === 'test' || m === 'before' || m === 'after' || m === 'beforeeach' || m === 'aftereach')) return 'test';
+    if (/^(setup|cleanup|setupSpec|cleanupSpec)$/.test(symbol.name) && /Spec$/.test(symbol.className || '')) return 'test';
+    if (mods.some(m => GRADLE_ANNOTATIONS.has(m)) || mods.includes('override')) return 'framework';
+    return null;
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+/** Tasks are run from CI configs and scripts by name (`./gradlew packDocs`) */
+function externallyInvokedNames(symbol) {
+    return (symbol.modifiers || []).includes('task') ? [symbol.name] : [];
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    externallyInvokedNames,
+    maskSource,
+    parse
+};
@@ -0,0 +1,549 @@
+/**
+ * languages/haskell.js - Tree-sitter based Haskell parsing
+ *
+ * Handles: top-level function bindings (multi-clause equations merged into
+ * one symbol, with their type signature), data/newtype declarations (record
+ * fields as members), type synonyms, type classes (as interfaces whose
+ * members are the method signatures), instances (as impl blocks), the module
+ * header's export list and import declarations.
+ *
+ * Visibility is the export list: `module Shapes (area, Shape(..)) where`
+ * exports exactly those names (and T(..)'s constructors and fields); a
+ * module without a list exports every top-level binding. Exported symbols
+ * carry the 'export' modifier, so deadcode reports unexported dead bindings
+ * by default and exported-but-unused ones with --include-exported.
+ *
+ * Every variable and type-name reference in an expression or type is a
+ * recorded call (applications are calls, other occurrences references —
+ * `map helper xs` passes helper), so the usage picture never needs a text
+ * scan: a name in an export or import list is not a use.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+    sameNode,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+const BINDING_TYPES = new Set(['function', 'bind']);
+const TYPE_DECL_TYPES = {
+    data_type: 'struct',
+    newtype: 'struct',
+    type_synomym: 'type',
+    type_synonym: 'type',
+    class: 'interface',
+    instance: 'impl',
+};
+
+/** Strip `--` line comments and `{- -}` block comments (pragmas kept out too) */
+function stripComments(code) {
+    return code
+        .replace(/\{-[\s\S]*?-\}/g, m => m.replace(/[^\n]/g, ' '))
+        .replace(/--(?![!#$%&*+./<=>?@\\^|~:]).*$/gm, m => ' '.repeat(m.length));
+}
+
+/** Read a balanced `( ... )` group starting at text[i] === '(' → [inner, nextIndex] */
+function readGroup(text, i) {
+    let depth = 0;
+    for (let j = i; j < text.length; j++) {
+        if (text[j] === '(') depth++;
+        else if (text[j] === ')' && --depth === 0) return [text.slice(i + 1, j), j + 1];
+    }
+    return [text.slice(i + 1), text.length];
+}
+
+/** Split on commas at paren depth 0 */
+function splitTopLevel(text, sep = ',') {
+    const parts = [];
+    let depth = 0;
+    let start = 0;
+    for (let i = 0; i < text.length; i++) {
+        const c = text[i];
+        if (c === '(' || c === '[') depth++;
+        else if (c === ')' || c === ']') depth--;
+        else if (depth === 0 && text.startsWith(sep, i)) {
+            parts.push(text.slice(start, i));
+            start = i + sep.length;
+        }
+    }
+    parts.push(text.slice(start));
+    return parts.map(p => p.trim()).filter(Boolean);
+}
+
+/**
+ * Module header: `module Data.Shape (Shape(..), area, module Data.Util) where`
+ * @returns {{module: string|null, line: number, exports: Array<{name: string, subordinates: string[]|'all'|null, kind: string}>|null}}
+ *   exports is null when the module has no export list (everything exported)
+ */
+function moduleHeader(code) {
+    const text = stripComments(code);
+    const m = /^module\s+([A-Z][\w.']*)\s*/m.exec(text);
+    if (!m) return { module: null, line: 0, exports: null };
+    const line = text.slice(0, m.index).split('\n').length;
+    let i = m.index + m[0].length;
+    if (text[i] !== '(') return { module: m[1], line, exports: null };
+    const [list] = readGroup(text, i);
+    const exports = [];
+    for (const item of splitTopLevel(list)) {
+        const mod = item.match(/^module\s+([\w.]+)/);
+        if (mod) {
+            exports.push({ name: mod[1], subordinates: null, kind: 'module' });
+            continue;
+        }
+        const entry = item.replace(/^(?:type|pattern)\s+/, '').match(/^(\([^)]*\)|[\w']+)\s*(?:\(([\s\S]*)\))?$/);
+        if (!entry) continue;
+        const sub = entry[2] === undefined ? null
+            : entry[2].trim() === '..' ? 'all'
+                : splitTopLevel(entry[2]);
+        exports.push({ name: entry[1], subordinates: sub, kind: /^[A-Z]/.test(entry[1]) ? 'type' : 'value' });
+    }
+    return { module: m[1], line, exports };
+}
+
+/**
+ * `import qualified Data.Map.Strict as M (lookup)` and friends
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code) {
+    const text = stripComments(code);
+    const imports = [];
+    const lines = text.split('\n');
+    for (let i = 0; i < lines.length; i++) {
+        if (!/^import\s/.test(lines[i])) continue;
+        // Join layout continuation lines (indented import lists)
+        let decl = lines[i];
+        let j = i + 1;
+        while (j < lines.length && /^\s+\S/.test(lines[j])) decl += ' ' + lines[j++];
+        const m = decl.match(/^import\s+(?:\{-#\s*SOURCE\s*#-\}\s*)?(qualified\s+)?(?:"[^"]*"\s+)?([A-Z][\w.']*)(\s+qualified)?(?:\s+as\s+([A-Z][\w.']*))?(\s+hiding)?\s*(\([\s\S]*\))?/);
+        if (!m) continue;
+        const qualified = !!(m[1] || m[3]);
+        const hiding = !!m[5];
+        const names = [];
+        if (m[6] && !hiding) {
+            const [list] = readGroup(m[6], 0);
+            for (const item of splitTopLevel(list)) {
+                const entry = item.replace(/^(?:type|pattern)\s+/, '').match(/^(\([^)]*\)|[\w']+)/);
+                if (entry) names.push(entry[1]);
+            }
+        }
+        imports.push({
+            module: m[2],
+            names,
+            type: qualified ? 'qualified' : 'import',
+            line: i + 1,
+            ...(m[4] && { alias: m[4] }),
+        });
+    }
+    return imports;
+}
+
+/** Haddock `-- |` (or plain `--`) comment block directly above a declaration */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    if (i < 0 || !/^\s*--/.test(lines[i])) return null;
+    while (i > 0 && /^\s*--/.test(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*--\s*\|?\s*/, '').trim() || null;
+}
+
+/** `a -> (b -> c) -> IO d` → ['a', '(b -> c)', 'IO d'] (constraint context dropped) */
+function signatureParts(typeText) {
+    const noContext = typeText.includes('=>')
+        ? splitTopLevel(typeText, '=>').pop()
+        : typeText;
+    return splitTopLevel(noContext.replace(/^forall\b[^.]*\./, ''), '->');
+}
+
+function nameOf(node) {
+    const name = node.childForFieldName('name');
+    if (name) return name.text;
+    const first = node.namedChildren.find(c => c.type === 'variable' || c.type === 'name');
+    return first ? first.text : null;
+}
+
+/** Top-level declarations: children of the `declarations` node (or the root) */
+function topLevelDeclarations(tree) {
+    const root = tree.rootNode;
+    const decls = root.namedChildren.find(c => c.type === 'declarations');
+    return (decls || root).namedChildren;
+}
+
+/** Exported name set, or null when the module exports everything */
+function exportedNames(code) {
+    const { exports } = moduleHeader(code);
+    if (!exports) return null;
+    const names = new Set();
+    const allSubs = new Set();
+    for (const e of exports) {
+        if (e.kind === 'module') continue;
+        names.add(e.name);
+        if (e.subordinates === 'all') allSubs.add(e.name);
+        else if (Array.isArray(e.subordinates)) e.subordinates.forEach(s => names.add(s));
+    }
+    names.allSubordinatesOf = allSubs;
+    return names;
+}
+
+function collectDeclarations(code, tree) {
+    const lines = code.split('\n');
+    const exported = exportedNames(code);
+    const isExported = (name, owner) => !exported || exported.has(name) ||
+        (owner && exported.allSubordinatesOf.has(owner));
+    const functions = [];
+    const classes = [];
+    const signatures = new Map();
+
+    for (const node of topLevelDeclarations(tree)) {
+        if (node.type === 'signature') {
+            const type = node.childForFieldName('type');
+            const names = node.text.split('::')[0].split(',').map(s => s.trim()).filter(Boolean);
+            for (const name of names) {
+                signatures.set(name, {
+                    line: node.startPosition.row + 1,
+                    type: type ? type.text : node.text.split('::').slice(1).join('::').trim(),
+                });
+            }
+            continue;
+        }
+        if (BINDING_TYPES.has(node.type)) {
+            const nameNode = node.childForFieldName('name');
+            if (!nameNode) continue; // pattern binding: (a, b) = ...
+            const name = nameNode.text;
+            const { startLine, endLine } = nodeToLocation(node, lines);
+            const prev = functions[functions.length - 1];
+            // Equations of one function are consecutive clauses
+            if (prev && prev.name === name) {
+                prev.endLine = endLine;
+                continue;
+            }
+            const patterns = node.childForFieldName('patterns');
+            functions.push({
+                name,
+                params: patterns ? patterns.text : '',
+                paramsStructured: patterns
+                    ? patterns.namedChildren.map(p => ({ name: p.text })) : [],
+                startLine,
+                endLine,
+                modifiers: isExported(name) ? ['export'] : [],
+            });
+            continue;
+        }
+        const kind = TYPE_DECL_TYPES[node.type];
+        if (!kind) continue;
+        const name = nameOf(node);
+        if (!name) continue;
+        const { startLine, endLine } = nodeToLocation(node, lines);
+        const members = [];
+        traverseTree(node, (child) => {
+            if (child === node) return true;
+            if (kind === 'struct' && child.type === 'field') {
+                const fieldName = child.childForFieldName('name') ||
+                    child.namedChildren.find(c => c.type === 'field_name' || c.type === 'variable');
+                const fieldType = child.childForFieldName('type');
+                if (fieldName) {
+                    members.push({
+                        name: fieldName.text,
+                        ...nodeToLocation(child, lines),
+                        memberType: 'field',
+                        ...(fieldType && { fieldType: fieldType.text }),
+                        modifiers: isExported(fieldName.text, name) ? ['export'] : [],
+                    });
+                }
+                return false;
+            }
+            if (kind === 'interface' && child.type === 'signature') {
+                const methodName = nameOf(child);
+                const type = child.childForFieldName('type');
+                if (methodName) {
+                    members.push({
+                        name: methodName,
+                        ...nodeToLocation(child, lines),
+                        memberType: 'method',
+                        isSignature: true,
+                        ...(type && { returnType: signatureParts(type.text).pop() }),
+                        modifiers: isExported(methodName, name) ? ['export'] : [],
+                    });
+                }
+                return false;
+            }
+            if (kind === 'impl' && BINDING_TYPES.has(child.type)) {
+                const methodName = child.childForFieldName('name')?.text;
+                if (methodName && members[members.length - 1]?.name !== methodName) {
+                    members.push({
+                        name: methodName,
+                        ...nodeToLocation(child, lines),
+                        memberType: 'method',
+                        modifiers: ['instance'],
+                    });
+                } else if (methodName) {
+                    members[members.length - 1].endLine = nodeToLocation(child, lines).endLine;
+                }
+                return false;
+            }
+            return true;
+        });
+        const docstring = extractDocstring(lines, startLine);
+        const instanceType = kind === 'impl'
+            ? node.text.replace(/^instance\s+(?:[\s\S]*?=>\s*)?/, '').split(/\s+where\b/)[0].trim()
+            : null;
+        classes.push({
+            name,
+            startLine,
+            endLine,
+            type: kind,
+            members,
+            modifiers: kind !== 'impl' && isExported(name) ? ['export'] : [],
+            ...(instanceType && { implements: [instanceType] }),
+            ...(docstring && { docstring }),
+        });
+    }
+
+    // Attach signatures: the function starts at its signature (and Haddock)
+    for (const fn of functions) {
+        const sig = signatures.get(fn.name);
+        if (sig) {
+            const parts = signatureParts(sig.type);
+            fn.returnType = parts[parts.length - 1];
+            if (sig.line < fn.startLine) fn.startLine = sig.line;
+        }
+        const docstring = extractDocstring(lines, fn.startLine);
+        if (docstring) fn.docstring = docstring;
+    }
+    return { functions, classes };
+}
+
+function findFunctions(code, parser) {
+    return collectDeclarations(code, parseTree(parser, code)).functions;
+}
+
+function findClasses(code, parser) {
+    return collectDeclarations(code, parseTree(parser, code)).classes;
+}
+
+function findStateObjects() {
+    return [];
+}
+
+/**
+ * Parse a Haskell file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const { functions, classes } = collectDeclarations(code, tree);
+    return {
+        language: 'haskell',
+        totalLines: lines.length,
+        functions,
+        classes,
+        stateObjects: [],
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/** Is the node the NAME of the declaration it sits in (not a use)? */
+function isDefinitionName(node) {
+    const parent = node.parent;
+    if (!parent || parent.type === 'instance') return false;
+    return sameNode(parent.childForFieldName('name'), node) &&
+        (BINDING_TYPES.has(parent.type) || parent.type === 'signature' ||
+            parent.type in TYPE_DECL_TYPES || parent.type === 'field');
+}
+
+/** Names a declaration binds locally: argument patterns, where/let bindings, lambda and generator patterns */
+function localNames(decl) {
+    const names = new Set();
+    const patternRoots = [];
+    traverseTree(decl, (node) => {
+        for (const field of ['patterns', 'pattern']) {
+            const p = node.childForFieldName(field);
+            if (p) patternRoots.push(p);
+        }
+        if (node !== decl && BINDING_TYPES.has(node.type)) {
+            const name = node.childForFieldName('name');
+            if (name) names.add(name.text);
+        }
+        return true;
+    });
+    for (const root of patternRoots) {
+        traverseTree(root, (node) => {
+            if (node.type === 'variable') names.add(node.text);
+            return true;
+        });
+    }
+    return names;
+}
+
+/**
+ * Find calls in Haskell code
+ *
+ *   area s            → area (call)
+ *   map helper xs     → map (call), helper (reference, potential callback)
+ *   M.lookup k m      → lookup (call, receiver M)
+ *   f :: Shape -> Int → Shape (type reference)
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const calls = [];
+    const header = tree.rootNode.namedChildren.find(c => c.type === 'header');
+    const imports = tree.rootNode.namedChildren.find(c => c.type === 'imports');
+
+    for (const decl of topLevelDeclarations(tree)) {
+        if (decl === header || decl === imports || decl.type === 'header' || decl.type === 'imports') continue;
+        const locals = localNames(decl);
+        const declName = BINDING_TYPES.has(decl.type) ? decl.childForFieldName('name')?.text : null;
+        const enclosingFunction = declName
+            ? { name: declName, ...nodeToLocation(decl, lines) }
+            : null;
+        const inPattern = (node) => {
+            for (let n = node; n && !sameNode(n, decl); n = n.parent) {
+                const parent = n.parent;
+                if (parent && (sameNode(parent.childForFieldName('patterns'), n) ||
+                    sameNode(parent.childForFieldName('pattern'), n))) return true;
+            }
+            return false;
+        };
+
+        traverseTree(decl, (node) => {
+            if (node.type === 'qualified' || node.type === 'qualified_variable') {
+                const id = node.childForFieldName('id') || node.namedChildren[node.namedChildCount - 1];
+                const mod = node.childForFieldName('module');
+                if (id && /^[\w']+$/.test(id.text)) {
+                    const parent = node.parent;
+                    const applied = parent?.type === 'apply' && sameNode(parent.childForFieldName('function'), node);
+                    calls.push({
+                        name: id.text,
+                        line: node.startPosition.row + 1,
+                        isMethod: false,
+                        ...(mod && { receiver: mod.text.replace(/\.$/, '') }),
+                        ...(!applied && { isFunctionReference: true }),
+                        enclosingFunction,
+                        uncertain: false,
+                    });
+                }
+                return false;
+            }
+            if (node.type === 'variable') {
+                if (isDefinitionName(node) || locals.has(node.text) || inPattern(node)) return false;
+                const parent = node.parent;
+                const applied = parent?.type === 'apply' && sameNode(parent.childForFieldName('function'), node);
+                calls.push({
+                    name: node.text,
+                    line: node.startPosition.row + 1,
+                    isMethod: false,
+                    ...(!applied && {
+                        isFunctionReference: true,
+                        ...(parent?.type === 'apply' && { isPotentialCallback: true }),
+                    }),
+                    enclosingFunction,
+                    uncertain: false,
+                });
+                return false;
+            }
+            // Type-level names: signatures, constraints, instance heads
+            if (node.type === 'name' && !isDefinitionName(node)) {
+                calls.push({
+                    name: node.text,
+                    line: node.startPosition.row + 1,
+                    isMethod: false,
+                    isFunctionReference: true,
+                    enclosingFunction,
+                    uncertain: false,
+                });
+                return false;
+            }
+            return true;
+        });
+    }
+    return calls;
+}
+
+/**
+ * The export list names the module's exports; without one, every top-level
+ * binding and type is exported. `module X` entries re-export an import.
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code, parser) {
+    const header = moduleHeader(code);
+    if (header.exports) {
+        return header.exports.filter(e => e.kind !== 'module')
+            .map(e => ({ name: e.name, type: e.kind, line: header.line }));
+    }
+    const { functions, classes } = collectDeclarations(code, parseTree(parser, code));
+    return [
+        ...functions.map(f => ({ name: f.name, type: 'value', line: f.startLine })),
+        ...classes.filter(c => c.type !== 'impl').map(c => ({ name: c.name, type: 'type', line: c.startLine })),
+    ];
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'variable' && node.type !== 'name') return true;
+        if (node.text !== name) return false;
+        const parent = node.parent;
+        let usageType = 'reference';
+        if (isDefinitionName(node)) {
+            usageType = parent.type === 'signature' ? 'reference' : 'definition';
+        } else if (parent?.type === 'apply' && sameNode(parent.childForFieldName('function'), node)) {
+            usageType = 'call';
+        } else if (parent && /^(import|export|exports|import_list)/.test(parent.type)) {
+            usageType = 'import';
+        }
+        usages.push({ line: node.startPosition.row + 1, column: node.startPosition.column, usageType });
+        return false;
+    });
+    return usages;
+}
+
+/**
+ * Classify a Haskell entry point:
+ * - 'main':      `main` (the Main module's IO action)
+ * - 'test':      QuickCheck `prop_*` properties and hspec-discover `spec`
+ * - 'framework': instance methods — dispatched through their type class
+ */
+function getEntryPointKind(symbol) {
+    if (symbol.name === 'main' && !symbol.className) return 'main';
+    if (/^prop_/.test(symbol.name) || (symbol.name === 'spec' && !symbol.className)) return 'test';
+    if ((symbol.modifiers || []).includes('instance')) return 'framework';
+    return null;
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    moduleHeader,
+    parse
+};
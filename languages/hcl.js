@@ -0,0 +1,372 @@
+/**
+ * languages/hcl.js - Tree-sitter based HCL parsing (Terraform configurations)
+ *
+ * Handles: `variable`, `output`, `locals`, `module`, `resource` and `data`
+ * blocks as declarations; `var.x`, `local.x`, `module.m.out` and resource
+ * attribute references as calls; `module` blocks with a `source` as imports.
+ *
+ * A Terraform module is a DIRECTORY — every .tf file in it shares one
+ * namespace. Variables are read through `var.NAME`, locals through
+ * `local.NAME`, and a child module's outputs through `module.CALL.NAME` in
+ * the calling configuration. Variables, locals and outputs are audited like
+ * constants (auditConstants): the reference is their only liveness evidence.
+ * Root-configuration outputs (outside a `modules/` directory) are read by
+ * `terraform output` and remote state, so they are entry points.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+// Reference roots that are not user declarations
+const BUILTIN_ROOTS = new Set(['each', 'count', 'self', 'path', 'terraform']);
+
+// Declarations audited for references (see header)
+const AUDITED_BLOCKS = new Set(['variable', 'output', 'local']);
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+/** `"vpc"` string_lit or bare identifier label → text */
+function labelText(node) {
+    if (node.type === 'identifier') return node.text;
+    if (node.type === 'string_lit') {
+        const lit = namedChildOfType(node, 'template_literal');
+        return lit ? lit.text : '';
+    }
+    return null;
+}
+
+/** Block type identifier and its labels */
+function blockHeader(block) {
+    const children = block.namedChildren;
+    const typeNode = children[0];
+    if (!typeNode || typeNode.type !== 'identifier') return null;
+    const labels = [];
+    for (let i = 1; i < children.length; i++) {
+        const text = labelText(children[i]);
+        if (text === null) break;
+        labels.push({ text, node: children[i] });
+    }
+    return { type: typeNode.text, labels };
+}
+
+function blockBody(block) {
+    return namedChildOfType(block, 'body');
+}
+
+/** `name = expr` attributes directly inside a block body */
+function bodyAttributes(body) {
+    if (!body) return [];
+    return body.namedChildren.filter(c => c.type === 'attribute');
+}
+
+function attributeValue(body, name) {
+    const attr = bodyAttributes(body).find(a => a.namedChild(0)?.text === name);
+    return attr ? attr.namedChild(1) : null;
+}
+
+/** The literal string of an expression (`source = "./modules/vpc"`), or null */
+function literalString(expr) {
+    if (!expr) return null;
+    let node = expr;
+    while (node && node.type !== 'string_lit' && node.namedChildCount === 1) node = node.namedChild(0);
+    if (!node || node.type !== 'string_lit') return null;
+    if (node.namedChildren.some(c => c.type === 'template_interpolation')) return null;
+    const lit = namedChildOfType(node, 'template_literal');
+    return lit ? lit.text : '';
+}
+
+/** `# comment` / `// comment` lines directly above a block */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    const isComment = (l) => /^\s*(#|\/\/)/.test(l);
+    if (i < 0 || !isComment(lines[i])) return null;
+    while (i > 0 && isComment(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*(#+|\/\/+)\s?/, '').trim() || null;
+}
+
+function topLevelBlocks(tree) {
+    const body = namedChildOfType(tree.rootNode, 'body');
+    return body ? body.namedChildren.filter(c => c.type === 'block') : [];
+}
+
+function collectStateObjects(tree, lines) {
+    const objects = [];
+    for (const block of topLevelBlocks(tree)) {
+        const header = blockHeader(block);
+        if (!header) continue;
+        const { startLine, endLine } = nodeToLocation(block, lines);
+        const docstring = extractDocstring(lines, startLine);
+        const body = blockBody(block);
+
+        if (header.type === 'locals') {
+            for (const attr of bodyAttributes(body)) {
+                const nameNode = attr.namedChild(0);
+                if (!nameNode) continue;
+                const loc = nodeToLocation(attr, lines);
+                objects.push({
+                    name: nameNode.text,
+                    startLine: loc.startLine,
+                    endLine: loc.endLine,
+                    modifiers: ['local'],
+                    isConst: true,
+                });
+            }
+            continue;
+        }
+
+        const [first, second] = header.labels;
+        if ((header.type === 'variable' || header.type === 'output' || header.type === 'module') && first) {
+            const description = literalString(attributeValue(body, 'description'));
+            const source = header.type === 'module' ? literalString(attributeValue(body, 'source')) : null;
+            objects.push({
+                name: first.text,
+                startLine,
+                endLine,
+                modifiers: [header.type],
+                ...(AUDITED_BLOCKS.has(header.type) && { isConst: true }),
+                ...(source && { source }),
+                ...((description || docstring) && { docstring: description || docstring }),
+            });
+        } else if ((header.type === 'resource' || header.type === 'data') && first && second) {
+            objects.push({
+                name: second.text,
+                startLine,
+                endLine,
+                modifiers: [header.type],
+                resourceType: first.text,
+                ...(docstring && { docstring }),
+            });
+        }
+    }
+    return objects;
+}
+
+function findFunctions() {
+    return [];
+}
+
+function findClasses() {
+    return [];
+}
+
+function findStateObjects(code, parser) {
+    return collectStateObjects(parseTree(parser, code), code.split('\n'));
+}
+
+/**
+ * Parse an HCL file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    return {
+        language: 'hcl',
+        totalLines: lines.length,
+        functions: [],
+        classes: [],
+        stateObjects: collectStateObjects(tree, lines),
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/** `var.region` / `module.vpc.id` → ['var', 'region'] / ['module', 'vpc', 'id'] */
+function referencePath(varExpr) {
+    const path = [{ text: varExpr.text, node: varExpr }];
+    let sib = varExpr.nextNamedSibling;
+    while (sib && sib.type === 'get_attr') {
+        const ident = namedChildOfType(sib, 'identifier');
+        if (!ident) break;
+        path.push({ text: ident.text, node: ident });
+        sib = sib.nextNamedSibling;
+    }
+    return path;
+}
+
+/**
+ * Find references in HCL
+ *
+ *   var.region             → region (receiver var)
+ *   local.tags             → tags (receiver local)
+ *   module.vpc.subnet_ids  → vpc (receiver module), subnet_ids (receiver module.vpc)
+ *   aws_vpc.main.id        → main (receiver aws_vpc)
+ *   data.aws_ami.ubuntu.id → ubuntu (receiver data.aws_ami)
+ *
+ * The enclosing top-level block stands in for the enclosing function.
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    let enclosing = null;
+
+    const push = (name, receiver, node) => {
+        calls.push({
+            name,
+            line: node.startPosition.row + 1,
+            isMethod: false,
+            receiver,
+            enclosingFunction: enclosing ? { ...enclosing } : null,
+            uncertain: false,
+        });
+    };
+
+    for (const block of topLevelBlocks(tree)) {
+        const header = blockHeader(block);
+        const label = header?.labels[header.labels.length - 1];
+        enclosing = header ? {
+            name: label ? label.text : header.type,
+            startLine: block.startPosition.row + 1,
+            endLine: block.endPosition.row + 1,
+        } : null;
+        traverseTree(block, (node) => {
+            if (node.type !== 'variable_expr') return true;
+            const ident = namedChildOfType(node, 'identifier') || node;
+            const segs = referencePath(node);
+            const root = ident.text;
+            if (BUILTIN_ROOTS.has(root) || segs.length < 2) return true;
+            if (root === 'var' || root === 'local') {
+                push(segs[1].text, root, segs[1].node);
+            } else if (root === 'module') {
+                push(segs[1].text, 'module', segs[1].node);
+                if (segs[2]) push(segs[2].text, `module.${segs[1].text}`, segs[2].node);
+            } else if (root === 'data') {
+                if (segs[2]) push(segs[2].text, `data.${segs[1].text}`, segs[2].node);
+            } else {
+                push(segs[1].text, root, segs[1].node);
+            }
+            return true;
+        });
+    }
+    return calls;
+}
+
+/**
+ * `module "vpc" { source = "./modules/vpc" }` imports the module directory.
+ * Registry and VCS sources are recorded too and resolve as external.
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const imports = [];
+    for (const block of topLevelBlocks(tree)) {
+        const header = blockHeader(block);
+        if (!header || header.type !== 'module' || !header.labels[0]) continue;
+        const source = literalString(attributeValue(blockBody(block), 'source'));
+        if (source === null) continue;
+        imports.push({
+            module: source,
+            names: [header.labels[0].text],
+            type: 'module',
+            line: block.startPosition.row + 1,
+        });
+    }
+    return imports;
+}
+
+/**
+ * A module's interface is its variables and outputs, consumed by name from
+ * the calling configuration — there is no per-file export list.
+ */
+function findExportsInCode() {
+    return [];
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+    const definitionNodes = new Set();
+    for (const block of topLevelBlocks(tree)) {
+        const header = blockHeader(block);
+        if (!header) continue;
+        if (header.type === 'locals') {
+            for (const attr of bodyAttributes(blockBody(block))) {
+                const n = attr.namedChild(0);
+                if (n && n.text === name) definitionNodes.add(n.startIndex);
+            }
+        } else {
+            const label = header.type === 'resource' || header.type === 'data'
+                ? header.labels[1] : header.labels[0];
+            if (label && label.text === name) {
+                const pos = label.node.startPosition;
+                usages.push({ line: pos.row + 1, column: pos.column + (label.node.type === 'string_lit' ? 1 : 0), usageType: 'definition' });
+            }
+        }
+    }
+
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'identifier' || node.text !== name) return true;
+        const parentType = node.parent?.type;
+        if (parentType !== 'get_attr' && parentType !== 'attribute') return true;
+        const isDef = definitionNodes.has(node.startIndex);
+        // Attribute keys other than locals are argument names, not references
+        if (parentType === 'attribute' && !isDef) return true;
+        usages.push({
+            line: node.startPosition.row + 1,
+            column: node.startPosition.column,
+            usageType: isDef ? 'definition' : 'reference',
+        });
+        return true;
+    });
+
+    return usages;
+}
+
+/**
+ * Classify an HCL entry point:
+ * - 'framework': outputs of a root configuration (not under `modules/`) —
+ *                read by `terraform output` and terraform_remote_state.
+ */
+function getEntryPointKind(symbol) {
+    if ((symbol.modifiers || []).includes('output') &&
+        !/(^|\/)modules\//.test(symbol.relativePath || '')) {
+        return 'framework';
+    }
+    return null;
+}
+
+/**
+ * Check if a symbol is a Terraform entry point.
+ */
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    parse
+};
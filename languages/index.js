@@ -53,6 +53,42 @@ const STRUCTURAL_TRAITS = {
     // Whether `Type(...)` constructs a class without a `new` token. Python
     // classes are ordinary callable objects; JS/TS classes require `new`.
     classesCallableWithoutNew: false,
+    // Line-comment marker for the deadcode text scan (fix #259 made the
+    // markers language-shaped): `#` for Python/Elixir, `//` elsewhere.
+    lineComment: '//',
+    // Whether deadcode audits constant state symbols (isConst) alongside
+    // functions and classes. Off by default: most languages index only
+    // config-shaped or exported constants, so a partial audit would mislead.
+    auditConstants: false,
+    // Whether deadcode --unused-params audits this language: its usage
+    // finder must report a parameter's own declaration, which the audit
+    // discounts as the first occurrence in the function.
+    auditParams: false,
+    // Deadcode text-scan shape for non-code languages (SQL, Terraform,
+    // protobuf) — see the per-language entries below.
+    stringReferenced: false,
+    declarationOnlyLine: null,
+    textScanUsages: true,
+    directoryModules: false,
+    generatedNameForms: null,
+    auditFields: null,
+    fieldReads: null,
+    unreachableCode: null,
+    packageVars: null,
+    sentinelErrors: null,
+    channelFlows: null,
+    configKnobs: null,
+    interfaceAssertion: null,
+    typeParams: null,
+    initEffects: null,
+    importIssues: null,
+    buildConstraint: null,
+    fixtureDir: null,
+    embeddedAssets: null,
+    embeddedQueries: null,
+    componentFiles: false,
+    bridgedSources: null,
+    headerExtension: null,
 };
 const NOMINAL_TRAITS = {
     typeSystem: 'nominal',
@@ -117,6 +153,32 @@ const NOMINAL_TRAITS = {
     // methods — false. Per-language override, not preset-wide.
     memberAccessNeverMethod: false,
     classesCallableWithoutNew: false,
+    lineComment: '//',
+    auditConstants: false,
+    auditParams: false,
+    stringReferenced: false,
+    declarationOnlyLine: null,
+    textScanUsages: true,
+    directoryModules: false,
+    generatedNameForms: null,
+    auditFields: null,
+    fieldReads: null,
+    unreachableCode: null,
+    packageVars: null,
+    sentinelErrors: null,
+    channelFlows: null,
+    configKnobs: null,
+    interfaceAssertion: null,
+    typeParams: null,
+    initEffects: null,
+    importIssues: null,
+    buildConstraint: null,
+    fixtureDir: null,
+    embeddedAssets: null,
+    embeddedQueries: null,
+    componentFiles: false,
+    bridgedSources: null,
+    headerExtension: null,
 };
 
 // Language configurations
@@ -129,6 +191,7 @@ const LANGUAGES = {
         treeSitterModule: () => require('tree-sitter-javascript'),
         traits: {
             ...STRUCTURAL_TRAITS,
+            auditParams: true,
             selfParam: ['this'],
             testFileCandidates: (base, ext) => [`${base}.test${ext}`, `${base}.spec${ext}`, `${base}.test.ts`, `${base}.test.js`, `${base}.spec.ts`, `${base}.spec.js`],
             testDirs: ['__tests__'],
@@ -142,6 +205,7 @@ const LANGUAGES = {
         treeSitterModule: () => require('tree-sitter-typescript').typescript,
         traits: {
             ...STRUCTURAL_TRAITS,
+            auditParams: true,
             selfParam: ['this'],
             testFileCandidates: (base, ext) => [`${base}.test${ext}`, `${base}.spec${ext}`, `${base}.test.ts`, `${base}.test.js`, `${base}.spec.ts`, `${base}.spec.js`],
             testDirs: ['__tests__'],
@@ -155,6 +219,7 @@ const LANGUAGES = {
         treeSitterModule: () => require('tree-sitter-typescript').tsx,
         traits: {
             ...STRUCTURAL_TRAITS,
+            auditParams: true,
             selfParam: ['this'],
             testFileCandidates: (base, ext) => [`${base}.test${ext}`, `${base}.spec${ext}`, `${base}.test.ts`, `${base}.test.js`, `${base}.spec.ts`, `${base}.spec.js`],
             testDirs: ['__tests__'],
@@ -168,6 +233,7 @@ const LANGUAGES = {
         treeSitterModule: () => require('tree-sitter-python'),
         traits: {
             ...STRUCTURAL_TRAITS,
+            auditParams: true,
             selfParam: ['self', 'cls'],
             // fix #224: `from pkg import name` may bind a SUBMODULE file, not
             // a symbol — graph-build resolves the composed dotted specifier
@@ -177,6 +243,14 @@ const LANGUAGES = {
             classesCallableWithoutNew: true,
             testFileCandidates: (base, ext) => [`test_${base}.py`, `${base}_test.py`],
             testDirs: ['tests'],
+            lineComment: '#',
+            // Jupyter notebooks call project libraries from their code cells
+            // (Julia ones through PyCall); only cell code counts, not outputs
+            bridgedSources: {
+                extensions: ['.ipynb'],
+                names: (symbol) => [symbol.name],
+                extract: (content) => require('./notebook').notebookSource(content, ['python', 'julia']),
+            },
         },
     },
     go: {
@@ -187,6 +261,7 @@ const LANGUAGES = {
         treeSitterModule: () => require('tree-sitter-go'),
         traits: {
             ...NOMINAL_TRAITS,
+            auditParams: true,
             selfParam: null,
             packageScope: 'directory',
             hasReceiverPackageCalls: true,
@@ -194,6 +269,24 @@ const LANGUAGES = {
             hasDynamicImports: false,
             typeQualifiedCallStyle: 'method-expr',
             methodCallReachesFunctions: true,
+            // Constants (iota enums included) and struct fields are audited;
+            // only reads (not writes) keep a field alive
+            auditConstants: true,
+            auditFields: (symbol, config) => require('./go').isAuditedField(symbol, config),
+            fieldReads: (tree) => require('./go').findFieldReads(tree),
+            unreachableCode: (tree, flags) => require('./go').findUnreachable(tree, flags),
+            packageVars: (tree) => require('./go').findPackageVarAccesses(tree),
+            sentinelErrors: (tree, names) => require('./go').findSentinelErrors(tree, names),
+            channelFlows: (tree) => require('./go').findChannelFlows(tree),
+            configKnobs: (tree) => require('./go').findConfigKnobs(tree),
+            interfaceAssertion: (line, iface) => require('./go').goInterfaceAssertion(line, iface),
+            typeParams: (tree) => require('./go').findTypeParams(tree),
+            initEffects: (tree) => require('./go').findInitEffects(tree),
+            importIssues: (tree) => require('./go').findImportIssues(tree),
+            buildConstraint: (content, relativePath) => require('./go').goBuildConstraint(content, relativePath),
+            // The go tool ignores testdata/; tests read their fixtures from it
+            fixtureDir: 'testdata',
+            embeddedAssets: (content) => require('./go').findEmbeds(content),
             testFileCandidates: (base, ext) => [`${base}_test.go`],
         },
     },
@@ -205,6 +298,7 @@ const LANGUAGES = {
         treeSitterModule: () => require('tree-sitter-rust'),
         traits: {
             ...NOMINAL_TRAITS,
+            auditParams: true,
             selfParam: ['self', '&self', '&mut self', 'mut self'],
             hasDynamicImports: false,
             typeQualifiedCallStyle: 'path',
@@ -221,6 +315,7 @@ const LANGUAGES = {
         treeSitterModule: () => require('tree-sitter-java'),
         traits: {
             ...NOMINAL_TRAITS,
+            auditParams: true,
             selfParam: ['this'],
             allMethodsVirtual: true,
             hasArityOverloads: true,
@@ -240,6 +335,393 @@ const LANGUAGES = {
             selfParam: ['this'],
             testFileCandidates: (base, ext) => [`${base}.test${ext}`, `${base}.spec${ext}`],
         },
+    },
+    vue: {
+        name: 'vue',
+        extensions: ['.vue'],
+        treeSitterLang: 'html',
+        module: () => require('./vue'),
+        treeSitterModule: () => require('tree-sitter-html'),
+        traits: {
+            ...STRUCTURAL_TRAITS,
+            selfParam: ['this'],
+            testFileCandidates: (base) => [`${base}.spec.ts`, `${base}.spec.js`, `${base}.test.ts`, `${base}.test.js`],
+            testDirs: ['__tests__'],
+            // Props declared by the component (defineProps / `props:`)
+            auditFields: (symbol) => require('./vue').isAuditedProp(symbol),
+            // Components are consumed by file — import, route or tag name
+            componentFiles: true,
+        },
+    },
+    svelte: {
+        name: 'svelte',
+        extensions: ['.svelte'],
+        treeSitterLang: 'html',
+        module: () => require('./svelte'),
+        treeSitterModule: () => require('tree-sitter-html'),
+        traits: {
+            ...STRUCTURAL_TRAITS,
+            selfParam: [],
+            testFileCandidates: (base) => [`${base}.test.ts`, `${base}.test.js`, `${base}.spec.ts`, `${base}.spec.js`],
+            componentFiles: true,
+        },
+    },
+
+    // --- Optional-grammar languages ---
+    // The grammar package is an optional peer dependency, pinned in
+    // package.json but not installed with ucn (native builds for every
+    // grammar would tax every install). The language activates only when
+    // `grammarPackage` resolves — e.g. `npm install tree-sitter-elixir` next
+    // to ucn — and is otherwise invisible: detectLanguage() returns null and
+    // discovery does not scan its extensions (see isLanguageAvailable).
+    elixir: {
+        name: 'elixir',
+        extensions: ['.ex', '.exs'],
+        treeSitterLang: 'elixir',
+        optional: true,
+        grammarPackage: 'tree-sitter-elixir',
+        module: () => require('./elixir'),
+        treeSitterModule: () => require('tree-sitter-elixir'),
+        traits: {
+            ...STRUCTURAL_TRAITS,
+            selfParam: null,
+            // def/defp is the visibility marker (captured as modifiers); a
+            // module has no "members" that inherit its export status.
+            implicitlyPublicMembers: false,
+            hasDefaultParams: true,
+            methodCallReachesFunctions: false,
+            lineComment: '#',
+            testFileCandidates: (base, ext) => [`${base}_test.exs`],
+            testDirs: ['test'],
+        },
+    },
+    zig: {
+        name: 'zig',
+        extensions: ['.zig'],
+        treeSitterLang: 'zig',
+        optional: true,
+        grammarPackage: '@tree-sitter-grammars/tree-sitter-zig',
+        module: () => require('./zig'),
+        treeSitterModule: () => require('@tree-sitter-grammars/tree-sitter-zig'),
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: ['self'],
+            hasDynamicImports: false,
+            // `util.helper()` through an @import binding is a namespace call —
+            // the binding names are passed to findCallsInCode.
+            hasReceiverPackageCalls: true,
+            typeQualifiedCallStyle: 'method-expr',
+            // Container-level consts are comptime-known: an unused non-pub
+            // const is dead code just like an unused non-pub fn.
+            auditConstants: true,
+            testFileCandidates: (base, ext) => [`${base}_test.zig`],
+            testDirs: ['test', 'tests'],
+        },
+    },
+    lua: {
+        name: 'lua',
+        extensions: ['.lua'],
+        treeSitterLang: 'lua',
+        optional: true,
+        grammarPackage: '@tree-sitter-grammars/tree-sitter-lua',
+        module: () => require('./lua'),
+        treeSitterModule: () => require('@tree-sitter-grammars/tree-sitter-lua'),
+        traits: {
+            ...STRUCTURAL_TRAITS,
+            // `obj:method()` passes the receiver as an implicit `self`
+            selfParam: ['self'],
+            // `local` is the visibility marker (captured as modifiers); the
+            // returned module table is the export surface, not a class.
+            implicitlyPublicMembers: false,
+            hasDefaultParams: false,
+            // `M.f()` through a require() binding is a namespace call
+            hasReceiverPackageCalls: true,
+            lineComment: '--',
+            testFileCandidates: (base, ext) => [`${base}_spec.lua`, `${base}_test.lua`],
+            testDirs: ['spec', 'test', 'tests'],
+        },
+    },
+    dart: {
+        name: 'dart',
+        extensions: ['.dart'],
+        treeSitterLang: 'dart',
+        optional: true,
+        grammarPackage: 'tree-sitter-dart',
+        module: () => require('./dart'),
+        treeSitterModule: () => require('tree-sitter-dart'),
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: ['this'],
+            // Optional positional `[x = 1]` and named `{x = 1}` parameters
+            hasDefaultParams: true,
+            // Every instance method is virtual; `foo()` inside a class is
+            // an implicit this-call; `Foo()` constructs without `new`.
+            allMethodsVirtual: true,
+            bareCallReachesMethods: true,
+            classesCallableWithoutNew: true,
+            universalSupertype: 'Object',
+            // Members are public unless `_`-prefixed (captured as 'private')
+            implicitlyPublicMembers: true,
+            // `import 'x.dart' as p; p.helper()` is a prefix call, not a method
+            hasReceiverPackageCalls: true,
+            hasDynamicImports: false,
+            testFileCandidates: (base, ext) => [`${base}_test.dart`],
+            testDirs: ['test', 'integration_test'],
+        },
+    },
+    bash: {
+        name: 'bash',
+        extensions: ['.sh', '.bash'],
+        treeSitterLang: 'bash',
+        optional: true,
+        grammarPackage: 'tree-sitter-bash',
+        module: () => require('./bash'),
+        treeSitterModule: () => require('tree-sitter-bash'),
+        traits: {
+            ...STRUCTURAL_TRAITS,
+            selfParam: null,
+            // Functions take positional arguments only — no signature to
+            // extend, no defaults to render.
+            hasDefaultParams: false,
+            implicitlyPublicMembers: false,
+            methodCallReachesFunctions: false,
+            lineComment: '#',
+            testFileCandidates: (base, ext) => [`${base}_test${ext}`, `test_${base}${ext}`],
+            testDirs: ['test', 'tests'],
+        },
+    },
+    sql: {
+        name: 'sql',
+        extensions: ['.sql', '.pgsql'],
+        treeSitterLang: 'sql',
+        optional: true,
+        grammarPackage: '@derekstride/tree-sitter-sql',
+        module: () => require('./sql'),
+        treeSitterModule: () => require('@derekstride/tree-sitter-sql'),
+        traits: {
+            ...STRUCTURAL_TRAITS,
+            selfParam: null,
+            hasDefaultParams: true,
+            implicitlyPublicMembers: false,
+            methodCallReachesFunctions: false,
+            lineComment: '--',
+            // Tables, views and functions are consumed by query strings in
+            // application code — a match inside another language's string
+            // literal is a real use, and `schema.name` is not a member access.
+            stringReferenced: true,
+            // Statements that restate an object without reading it: a table
+            // that is only ever ALTERed, indexed or granted is still unused.
+            declarationOnlyLine: /^\s*(?:ALTER|DROP|COMMENT\s+ON|GRANT|REVOKE|CREATE\s+(?:UNIQUE\s+)?INDEX|ANALYZE|VACUUM)\b/i,
+            testFileCandidates: (base, ext) => [`${base}_test${ext}`, `test_${base}${ext}`],
+            testDirs: ['test', 'tests'],
+        },
+    },
+    hcl: {
+        name: 'hcl',
+        extensions: ['.tf'],
+        treeSitterLang: 'hcl',
+        optional: true,
+        grammarPackage: '@tree-sitter-grammars/tree-sitter-hcl',
+        module: () => require('./hcl'),
+        treeSitterModule: () => require('@tree-sitter-grammars/tree-sitter-hcl'),
+        traits: {
+            ...STRUCTURAL_TRAITS,
+            selfParam: null,
+            hasDefaultParams: false,
+            implicitlyPublicMembers: false,
+            methodCallReachesFunctions: false,
+            lineComment: '#',
+            // variable / output / locals declarations are audited like
+            // constants: `var.x`, `local.x`, `module.m.x` are their only uses.
+            auditConstants: true,
+            // Every reference form is a recorded call; a bare word match is
+            // an argument name (`region = ...`), not a reference.
+            textScanUsages: false,
+            // A module is a directory of .tf files, sourced by `module` blocks
+            directoryModules: true,
+            packageScope: 'directory',
+            testFileCandidates: (base) => [`${base}.tftest.hcl`],
+            testDirs: ['tests'],
+        },
+    },
+    proto: {
+        name: 'proto',
+        extensions: ['.proto'],
+        treeSitterLang: 'proto',
+        optional: true,
+        grammarPackage: 'tree-sitter-proto',
+        module: () => require('./proto'),
+        treeSitterModule: () => require('tree-sitter-proto'),
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: null,
+            hasDefaultParams: false,
+            implicitlyPublicMembers: false,
+            methodCallReachesFunctions: false,
+            // Consumers use the code protoc generates, not the .proto names:
+            // service Foo → FooClient / FooStub / FooServicer, rpc GetX → getX.
+            generatedNameForms: (symbol) => require('./proto').generatedNames(symbol),
+            testFileCandidates: () => [],
+            testDirs: [],
+        },
+    },
+    graphql: {
+        name: 'graphql',
+        extensions: ['.graphql', '.gql', '.graphqls'],
+        treeSitterLang: 'graphql',
+        optional: true,
+        grammarPackage: 'tree-sitter-graphql',
+        module: () => require('./graphql'),
+        treeSitterModule: () => require('tree-sitter-graphql'),
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: null,
+            hasDefaultParams: true,
+            implicitlyPublicMembers: false,
+            methodCallReachesFunctions: false,
+            lineComment: '#',
+            // Selectable schema fields are audited; operations select them
+            auditFields: (symbol) => require('./graphql').isAuditedField(symbol),
+            // Operations embedded in host-language files: gql`...`,
+            // graphql(`...`), /* GraphQL */ `...`
+            embeddedQueries: /(?:\b(?:gql|graphql)\s*\(?\s*|\/\*\s*GraphQL\s*\*\/\s*)`([^`]*)`/g,
+            testFileCandidates: () => [],
+            testDirs: [],
+        },
+    },
+    objc: {
+        name: 'objc',
+        extensions: ['.m', '.h'],
+        treeSitterLang: 'objc',
+        optional: true,
+        grammarPackage: 'tree-sitter-objc',
+        module: () => require('./objc'),
+        treeSitterModule: () => require('tree-sitter-objc'),
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: ['self'],
+            // Every message send dispatches dynamically; `[x alloc]` names
+            // the class without `new`.
+            allMethodsVirtual: true,
+            classesCallableWithoutNew: true,
+            universalSupertype: 'NSObject',
+            // Methods declared in a header are callable by any importer
+            implicitlyPublicMembers: true,
+            // A class is declared by `Foo.h` and implemented in `Foo.m`;
+            // superclass and adopted protocols live on the header side.
+            headerExtension: '.h',
+            // Header method declarations, `@class` forward declarations and
+            // `#import` lines restate a name without using it
+            declarationOnlyLine: /^\s*(?:[-+]\s*\([^{]*|@class\s.*|#\s*(?:import|include)\s.*)$/,
+            // Swift files in a mixed target call ObjC through the names the
+            // Clang importer derives (fetchUserWithId: → fetchUser(withId:)).
+            bridgedSources: {
+                extensions: ['.swift'],
+                names: (symbol) => [symbol.name, ...require('./objc').swiftNames(symbol)],
+            },
+            testFileCandidates: (base) => [`${base}Tests.m`, `${base}Test.m`],
+            testDirs: ['Tests'],
+        },
+    },
+    haskell: {
+        name: 'haskell',
+        extensions: ['.hs'],
+        treeSitterLang: 'haskell',
+        optional: true,
+        grammarPackage: 'tree-sitter-haskell',
+        module: () => require('./haskell'),
+        treeSitterModule: () => require('tree-sitter-haskell'),
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: null,
+            hasDynamicImports: false,
+            // `M.lookup` through `import qualified Data.Map as M`
+            hasReceiverPackageCalls: true,
+            // Type-class methods are called bare: `area s`, `show x`
+            bareCallReachesMethods: true,
+            lineComment: '--',
+            // Every variable and type reference is a recorded call; a word
+            // match in an export or import list is not a use.
+            textScanUsages: false,
+            testFileCandidates: (base) => [`${base}Spec.hs`, `${base}Test.hs`],
+            testDirs: ['test'],
+        },
+    },
+    ocaml: {
+        name: 'ocaml',
+        extensions: ['.ml'],
+        treeSitterLang: 'ocaml',
+        optional: true,
+        grammarPackage: 'tree-sitter-ocaml',
+        module: () => require('./ocaml'),
+        treeSitterModule: () => require('tree-sitter-ocaml').ocaml,
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: null,
+            // Optional arguments: `?(retries = 3)`
+            hasDefaultParams: true,
+            hasDynamicImports: false,
+            // `Cache.find` is a call into the compilation unit cache.ml
+            hasReceiverPackageCalls: true,
+            lineComment: '(*',
+            // Top-level values (`let default_size = 64`) are audited with
+            // the functions: an unused one is as dead as an unused function.
+            auditConstants: true,
+            // foo.mli is foo.ml's interface: it decides what is exported
+            headerExtension: '.mli',
+            testFileCandidates: (base) => [`test_${base}.ml`, `${base}_test.ml`],
+            testDirs: ['test'],
+        },
+    },
+    ocaml_interface: {
+        name: 'ocaml_interface',
+        extensions: ['.mli'],
+        treeSitterLang: 'ocaml_interface',
+        optional: true,
+        grammarPackage: 'tree-sitter-ocaml',
+        module: () => require('./ocaml'),  // Same module, interface grammar
+        treeSitterModule: () => require('tree-sitter-ocaml').interface,
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: null,
+            hasDefaultParams: true,
+            hasDynamicImports: false,
+            hasReceiverPackageCalls: true,
+            lineComment: '(*',
+            // `val find : ...` declares the implementation's value; it is
+            // not a use of it
+            declarationOnlyLine: /^\s*(?:val|external)\s/,
+            testFileCandidates: () => [],
+            testDirs: [],
+        },
+    },
+    groovy: {
+        name: 'groovy',
+        extensions: ['.groovy', '.gradle'],
+        treeSitterLang: 'groovy',
+        optional: true,
+        grammarPackage: 'tree-sitter-groovy',
+        module: () => require('./groovy'),
+        treeSitterModule: () => require('tree-sitter-groovy'),
+        traits: {
+            ...NOMINAL_TRAITS,
+            selfParam: ['this'],
+            hasDefaultParams: true,
+            // Dynamic dispatch everywhere; `helper()` inside a class is an
+            // implicit this-call; members are public unless marked.
+            allMethodsVirtual: true,
+            bareCallReachesMethods: true,
+            implicitlyPublicMembers: true,
+            universalSupertype: 'Object',
+            // Gradle tasks are run by name from outside the build: CI
+            // workflows and wrapper scripts (`./gradlew packDocs`)
+            bridgedSources: {
+                extensions: ['.yml', '.yaml', '.sh'],
+                names: (symbol) => require('./groovy').externallyInvokedNames(symbol),
+            },
+            testFileCandidates: (base) => [`${base}Spec.groovy`, `${base}Test.groovy`],
+            testDirs: ['src/test'],
+        },
     }
 };
 
@@ -251,6 +733,111 @@ for (const [langName, config] of Object.entries(LANGUAGES)) {
     }
 }
 
+// Optional grammars resolved once per process: language -> boolean
+const _grammarAvailable = new Map();
+
+/**
+ * Register a project's .ucn.json "grammars" — languages UCN does not ship,
+ * analyzed by the query-driven adapter (languages/generic.js):
+ *
+ *   { "language": "kotlin", "extensions": [".kt", ".kts"],
+ *     "grammar": "tree-sitter-kotlin", "query": "tools/kotlin-tags.scm" }
+ *
+ * `grammar` is a package name or path resolved from the project root
+ * (`export` picks a named export, e.g. "tsx"); `query` is a path relative
+ * to the root. A grammar is a native addon, so declarations load only when
+ * the user opts in with UCN_ALLOW_GRAMMARS=1 — indexing a cloned
+ * repository must not run code its config names. Built-in languages and
+ * their extensions are never replaced.
+ *
+ * @param {object[]} declarations - .ucn.json "grammars"
+ * @param {string} root - Project root
+ * @returns {Array<{language: string, error?: string}>}
+ */
+function registerGrammars(declarations, root) {
+    if (!Array.isArray(declarations) || declarations.length === 0) return [];
+    if (process.env.UCN_ALLOW_GRAMMARS !== '1') {
+        return declarations.map(d => ({ language: d?.language, error: 'not loaded: set UCN_ALLOW_GRAMMARS=1 to allow project grammars' }));
+    }
+    const fs = require('fs');
+    const results = [];
+    for (const decl of declarations) {
+        const language = decl?.language;
+        if (typeof language !== 'string' || !/^[A-Za-z][\w-]*$/.test(language) ||
+            !Array.isArray(decl.extensions) || decl.extensions.length === 0 || !decl.grammar || !decl.query) {
+            results.push({ language, error: 'not loaded: needs language, extensions, grammar and query' });
+            continue;
+        }
+        if (LANGUAGES[language] && !LANGUAGES[language].userGrammar) {
+            results.push({ language, error: 'not loaded: built-in language' });
+            continue;
+        }
+        let grammarPath;
+        try {
+            grammarPath = require.resolve(decl.grammar, { paths: [root] });
+        } catch {
+            results.push({ language, error: `not loaded: "${decl.grammar}" not found` });
+            continue;
+        }
+        const queryPath = path.resolve(root, decl.query);
+        if (!fs.existsSync(queryPath)) {
+            results.push({ language, error: `not loaded: query "${decl.query}" not found` });
+            continue;
+        }
+        const adapter = require('./generic').createGenericModule(language, { queryPath });
+        const extensions = decl.extensions.map(e => (e.startsWith('.') ? e : `.${e}`).toLowerCase());
+        LANGUAGES[language] = {
+            name: language,
+            extensions,
+            treeSitterLang: language,
+            optional: true,
+            userGrammar: true,
+            grammarPackage: grammarPath,
+            module: () => adapter,
+            treeSitterModule: () => {
+                const grammar = require(grammarPath);
+                return decl.export ? grammar[decl.export] : grammar;
+            },
+            traits: {
+                ...STRUCTURAL_TRAITS,
+                // Nothing is known about the language: a bare call may be an
+                // implicit-receiver method call
+                bareCallReachesMethods: true,
+                lineComment: decl.lineComment || null,
+                testFileCandidates: () => [],
+            },
+        };
+        for (const ext of extensions) {
+            if (!EXT_MAP[ext] || LANGUAGES[EXT_MAP[ext]].userGrammar) EXT_MAP[ext] = language;
+        }
+        delete parsers[language];
+        _grammarAvailable.delete(language);
+        results.push({ language });
+    }
+    return results;
+}
+
+/**
+ * Whether a language can be parsed in this install. Core languages always
+ * can; optional-grammar languages only when their grammar package resolves.
+ * @param {string} language - Language name
+ * @returns {boolean}
+ */
+function isLanguageAvailable(language) {
+    const config = LANGUAGES[language];
+    if (!config) return false;
+    if (!config.optional) return true;
+    if (!_grammarAvailable.has(language)) {
+        let ok = false;
+        try {
+            require.resolve(config.grammarPackage);
+            ok = true;
+        } catch { /* not installed */ }
+        _grammarAvailable.set(language, ok);
+    }
+    return _grammarAvailable.get(language);
+}
+
 /**
  * Load tree-sitter module (lazy)
  * @returns {object} TreeSitter class
@@ -331,7 +918,7 @@ function getParser(language) {
     } catch (e) {
         throw new Error(
             `Failed to load tree-sitter grammar for ${language}.\n` +
-            `Install with: npm install tree-sitter-${language}\n` +
+            `Install with: npm install ${config.grammarPackage || `tree-sitter-${language}`}\n` +
             `Original error: ${e.message}`,
             { cause: e }
         );
@@ -348,7 +935,11 @@ function getParser(language) {
  */
 function detectLanguage(filePath) {
     const ext = path.extname(filePath).toLowerCase();
-    return EXT_MAP[ext] || null;
+    const language = EXT_MAP[ext];
+    if (!language) return null;
+    // Optional grammar not installed — the file is not code UCN can analyze
+    if (LANGUAGES[language].optional && !isLanguageAvailable(language)) return null;
+    return language;
 }
 
 /**
@@ -381,6 +972,20 @@ function getSupportedExtensions() {
     return Object.keys(EXT_MAP);
 }
 
+/**
+ * Extensions (without the dot) of optional-grammar languages whose grammar
+ * is installed. Discovery appends these to its fixed core extension list.
+ * @returns {string[]}
+ */
+function getOptionalExtensions() {
+    const exts = [];
+    for (const [langName, config] of Object.entries(LANGUAGES)) {
+        if (!config.optional || !isLanguageAvailable(langName)) continue;
+        for (const ext of config.extensions) exts.push(ext.slice(1));
+    }
+    return exts;
+}
+
 /**
  * Get all supported languages
  * @returns {string[]}
@@ -490,6 +1095,9 @@ module.exports = {
     isSupported,
     getSupportedExtensions,
     getSupportedLanguages,
+    getOptionalExtensions,
+    registerGrammars,
+    isLanguageAvailable,
     LANGUAGES,
     PARSE_OPTIONS,
     getParseOptions,
@@ -1363,6 +1363,9 @@ function extractClassMembers(classNode, codeOrLines) {
 const _STATE_PATTERN = /^(CONFIG|[A-Z][a-zA-Z]*(?:State|Store|Context|Options|Settings)|[A-Z][A-Z_]+|Entities|Input)$/;
 const _ACTION_PATTERN = /^(action\w*|[a-z]+Action|[a-z]+State)$/;
 const _FACTORY_FUNCTIONS = ['register', 'createAction', 'defineAction', 'makeAction'];
+// svelte/store constructors — module-scope stores are shared state read by
+// components through `$name` subscriptions
+const _STORE_FUNCTIONS = new Set(['writable', 'readable', 'derived']);
 
 function _isFactoryCall(node) {
     if (node.type !== 'call_expression') return false;
@@ -1372,6 +1375,15 @@ function _isFactoryCall(node) {
     return funcName && _FACTORY_FUNCTIONS.includes(funcName);
 }
 
+/** `const count = writable(0)` at module scope (or exported from it) */
+function _isStoreDeclaration(declNode, valueNode) {
+    if (valueNode.type !== 'call_expression') return false;
+    const funcNode = valueNode.childForFieldName('function');
+    if (!funcNode || funcNode.type !== 'identifier' || !_STORE_FUNCTIONS.has(funcNode.text)) return false;
+    const scope = declNode.parent?.type === 'export_statement' ? declNode.parent.parent : declNode.parent;
+    return scope?.type === 'program';
+}
+
 /**
  * Process a node for state object extraction (single-pass helper)
  * Returns true if node was matched, false otherwise
@@ -1395,6 +1407,10 @@ function _processState(node, objects, lines) {
                     } else if (_isFactoryCall(valueNode) && (_ACTION_PATTERN.test(name) || _STATE_PATTERN.test(name))) {
                         const { startLine, endLine } = nodeToLocation(node, lines);
                         objects.push({ name, startLine, endLine });
+                    } else if (_isStoreDeclaration(node, valueNode)) {
+                        const { startLine, endLine } = nodeToLocation(node, lines);
+                        const exported = node.parent?.type === 'export_statement';
+                        objects.push({ name, startLine, endLine, modifiers: exported ? ['store', 'export'] : ['store'] });
                     }
                 }
             }
@@ -1657,6 +1673,9 @@ function jsConstructorTypeQualifier(ctorNode) {
     return root?.type === 'identifier' ? root.text : undefined;
 }
 
+// React Router route-object keys whose values the router renders or calls
+const ROUTE_COMPONENT_KEYS = new Set(['Component', 'component', 'ErrorBoundary', 'HydrateFallback', 'loader', 'action']);
+
 function findCallsInCode(code, parser) {
     const tree = parseTree(parser, code);
     const calls = [];
@@ -2549,6 +2568,38 @@ function findCallsInCode(code, parser) {
             return true;
         }
 
+        // React Router route objects: `{ path: 'settings', Component: Settings,
+        // loader: settingsLoader }` anywhere in a route table — the router
+        // renders and calls these. Objects passed directly as call arguments
+        // are already scanned with the call.
+        if (node.type === 'object' && node.parent?.type !== 'arguments') {
+            const pairs = [];
+            let isRoute = false;
+            for (let i = 0; i < node.namedChildCount; i++) {
+                const prop = node.namedChild(i);
+                if (prop.type !== 'pair') continue;
+                const key = prop.childForFieldName('key')?.text;
+                if (key === 'path' || key === 'index') isRoute = true;
+                else if (ROUTE_COMPONENT_KEYS.has(key)) pairs.push(prop);
+            }
+            if (isRoute) {
+                for (const prop of pairs) {
+                    const val = prop.childForFieldName('value');
+                    if (val?.type !== 'identifier' || SKIP_IDENTS.has(val.text) || nonCallableNames.has(val.text)) continue;
+                    calls.push({
+                        name: val.text,
+                        line: val.startPosition.row + 1,
+                        isMethod: false,
+                        isFunctionReference: true,
+                        isPotentialCallback: true,
+                        ...(isShadowedByLocal(val, val.text) && { localShadow: true }),
+                        enclosingFunction: getCurrentEnclosingFunction()
+                    });
+                }
+            }
+            return true;
+        }
+
         // Handle JSX component usage: <Component /> or <Component>...</Component>
         // Only track PascalCase names (React components), not lowercase (HTML elements)
         if (node.type === 'jsx_self_closing_element' || node.type === 'jsx_opening_element') {
@@ -2809,6 +2860,36 @@ function findReExports(code, parser) {
     return reExports;
 }
 
+// React code-splitting wrappers: `lazy(() => import('./Page'))`
+const LAZY_COMPONENT_WRAPPERS = /^(?:React\.)?lazy$|^loadable$/;
+
+/**
+ * React position of a dynamic `import()` call: 'component' inside
+ * `lazy(() => import('./Page'))` (React.lazy, loadable), 'route' as a React
+ * Router route's `lazy: () => import('./routes/page')`. The framework, not
+ * project code, consumes what such a module exports.
+ * @returns {'component'|'route'|null}
+ */
+function lazyImportKind(importCall) {
+    let fn = importCall.parent;
+    // `() => { return import('./Page'); }`
+    if (fn?.type === 'return_statement') fn = fn.parent?.parent;
+    if (!fn) return null;
+    if (fn.type === 'method_definition') {
+        return fn.childForFieldName('name')?.text === 'lazy' ? 'route' : null;
+    }
+    if (fn.type !== 'arrow_function' && fn.type !== 'function_expression') return null;
+    const holder = fn.parent;
+    if (holder?.type === 'pair') {
+        return holder.childForFieldName('key')?.text === 'lazy' ? 'route' : null;
+    }
+    if (holder?.type === 'arguments') {
+        const callee = holder.parent?.childForFieldName('function');
+        if (callee && LAZY_COMPONENT_WRAPPERS.test(callee.text)) return 'component';
+    }
+    return null;
+}
+
 /**
  * Find all imports in JavaScript/TypeScript code using tree-sitter AST
  * @param {string} code - Source code to analyze
@@ -3007,7 +3088,9 @@ function findImportsInCode(code, parser) {
                     const line = node.startPosition.row + 1;
                     if (firstArg && firstArg.type === 'string') {
                         const modulePath = firstArg.text.slice(1, -1);
-                        imports.push({ module: modulePath, names: [], type: 'dynamic', line, dynamic: false });
+                        const lazy = lazyImportKind(node);
+                        imports.push({ module: modulePath, names: [], type: 'dynamic', line, dynamic: false,
+                            ...(lazy && { lazy }) });
                     } else if (firstArg) {
                         imports.push({ module: firstArg.text, names: [], type: 'dynamic', line, dynamic: true });
                     }
@@ -0,0 +1,552 @@
+/**
+ * languages/lua.js - Tree-sitter based Lua parsing
+ *
+ * Handles: `local function f`, global `function f`, table-member functions
+ * (`function M.f` / `function M:method`), functions bound by assignment
+ * (`local f = function() end`), module-level locals, require() imports and
+ * the module's returned table as its export surface.
+ *
+ * Visibility: `local` functions are file-private — an unused one is
+ * claimable dead code. Members of the returned module table and globals
+ * are reachable from other files (and from the host), so they count as
+ * exported. Functions assigned onto tables the file does not declare
+ * (`function love.update(dt)`) are host callbacks — entry points.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+    visitNameNodes,
+    sameNode,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+// Lifecycle functions engines call on a script's GLOBAL environment
+// (Defold/LÖVE-style `function update(dt)`, `function on_message(...)`).
+const GLOBAL_CALLBACKS = new Set([
+    'init', 'final', 'update', 'fixed_update', 'late_update', 'on_message',
+    'on_input', 'on_reload', 'load', 'draw',
+]);
+
+function isLocalKeyword(node) {
+    for (let i = 0; i < node.childCount; i++) {
+        const child = node.child(i);
+        if (child.isNamed) break;
+        if (child.type === 'local') return true;
+    }
+    return false;
+}
+
+/** String literal content of a node (`"x"`, `'x'`, `[[x]]`), else null */
+function stringValue(node) {
+    if (node?.type !== 'string') return null;
+    const content = node.childForFieldName('content');
+    if (content) return content.text;
+    return node.text.replace(/^(["']|\[=*\[)|(["']|\]=*\])$/g, '');
+}
+
+/** `require("a.b")` / `require "a.b"` → 'a.b', else null */
+function requireTarget(node) {
+    if (node?.type !== 'function_call') return null;
+    const name = node.childForFieldName('name');
+    if (name?.type !== 'identifier' || name.text !== 'require') return null;
+    const args = node.childForFieldName('arguments');
+    if (!args) return null;
+    if (args.type === 'string') return stringValue(args);
+    const first = args.namedChild(0);
+    return first ? stringValue(first) : null;
+}
+
+/**
+ * Table names the file itself declares at top level (`local M = {}`,
+ * `M = {}`) — members assigned onto any other table belong to the host.
+ */
+function declaredTables(root) {
+    const names = new Set();
+    for (let i = 0; i < root.namedChildCount; i++) {
+        let stmt = root.namedChild(i);
+        if (stmt.type === 'variable_declaration') stmt = stmt.namedChild(0);
+        if (stmt?.type !== 'assignment_statement') continue;
+        const vars = stmt.namedChild(0);
+        const values = stmt.namedChild(1);
+        if (!vars || !values) continue;
+        for (let j = 0; j < vars.namedChildCount; j++) {
+            const v = vars.namedChild(j);
+            const value = values.namedChild(j);
+            if (v.type === 'identifier' && value &&
+                (value.type === 'table_constructor' || requireTarget(value) ||
+                 /^setmetatable\b/.test(value.text))) {
+                names.add(v.text);
+            }
+        }
+    }
+    // Locals/globals declared without a table literal but later returned
+    // still own their members.
+    const ret = moduleReturn(root);
+    if (ret?.type === 'identifier') names.add(ret.text);
+    return names;
+}
+
+/** The top-level `return <expr>` value of a module chunk, else null */
+function moduleReturn(root) {
+    for (let i = root.namedChildCount - 1; i >= 0; i--) {
+        const stmt = root.namedChild(i);
+        if (stmt.type === 'comment') continue;
+        if (stmt.type !== 'return_statement') return null;
+        const list = stmt.namedChild(0);
+        if (!list) return null;
+        return list.type === 'expression_list' ? list.namedChild(0) : list;
+    }
+    return null;
+}
+
+/** `--- doc` / `-- doc` comment directly above a declaration */
+function extractDocstring(lines, startLine) {
+    const row = startLine - 2;
+    if (row < 0) return null;
+    const m = (lines[row] || '').match(/^\s*---?\s?(.*)$/);
+    if (!m || /^\s*--\[/.test(lines[row])) return null;
+    let first = m[1];
+    for (let r = row - 1; r >= 0; r--) {
+        const above = (lines[r] || '').match(/^\s*---?\s?(.*)$/);
+        if (!above) break;
+        first = above[1];
+    }
+    return first.trim() || null;
+}
+
+function extractParams(paramsNode) {
+    if (!paramsNode) return { params: '...', paramsStructured: [] };
+    const paramsStructured = [];
+    for (let i = 0; i < paramsNode.namedChildCount; i++) {
+        const p = paramsNode.namedChild(i);
+        if (p.type === 'comment') continue;
+        if (p.type === 'vararg_expression' || p.text === '...') {
+            paramsStructured.push({ name: '...', rest: true });
+        } else {
+            paramsStructured.push({ name: p.text });
+        }
+    }
+    return { params: paramsNode.text.replace(/^\(|\)$/g, '').trim(), paramsStructured };
+}
+
+function buildFunction(declNode, fnNode, nameText, lines, extra) {
+    const { startLine, endLine, indent } = nodeToLocation(declNode, lines);
+    const { params, paramsStructured } = extractParams(fnNode.childForFieldName('parameters'));
+    const docstring = extractDocstring(lines, startLine);
+    return {
+        name: nameText,
+        params,
+        paramsStructured,
+        startLine,
+        endLine,
+        indent,
+        ...extra,
+        ...(docstring && { docstring }),
+    };
+}
+
+/**
+ * Collect every function definition:
+ *   local function f()       → private
+ *   function f()             → global (public)
+ *   function M.f() / M:f()   → member of table M (public when M is the
+ *                              module table; host callback when M is foreign)
+ *   local f = function() end → private, bound by assignment
+ */
+function collectFunctions(tree, lines) {
+    const functions = [];
+    const root = tree.rootNode;
+    const tables = declaredTables(root);
+
+    traverseTreeCached(root, (node) => {
+        if (node.type === 'function_declaration') {
+            const nameNode = node.childForFieldName('name');
+            if (!nameNode) return true;
+            const topLevel = node.parent?.type === 'chunk';
+            if (nameNode.type === 'identifier') {
+                const local = isLocalKeyword(node);
+                functions.push(buildFunction(node, node, nameNode.text, lines, {
+                    modifiers: local ? ['local'] : (topLevel ? ['public', 'global'] : ['global']),
+                    ...(!topLevel && local && { isNested: true }),
+                }));
+                return true;
+            }
+            const isMethodSyntax = nameNode.type === 'method_index_expression';
+            const table = nameNode.childForFieldName('table');
+            const field = nameNode.childForFieldName(isMethodSyntax ? 'method' : 'field');
+            if (!table || !field) return true;
+            const tableRoot = table.text.split(/[.:]/)[0];
+            const owned = tables.has(tableRoot);
+            const modifiers = owned ? ['public'] : ['public', 'callback'];
+            functions.push(buildFunction(node, node, field.text, lines, {
+                modifiers,
+                // `M.f` declares no lexical name — only `M.f(...)` reaches it
+                memberAssigned: true,
+                enclosingType: table.text,
+                ...(isMethodSyntax && { isMethod: true, receiver: table.text }),
+            }));
+            return true;
+        }
+
+        // local f = function() end / M.f = function() end
+        if (node.type === 'assignment_statement') {
+            const vars = node.namedChild(0);
+            const values = node.namedChild(1);
+            if (!vars || !values) return true;
+            const local = node.parent?.type === 'variable_declaration';
+            for (let j = 0; j < vars.namedChildCount; j++) {
+                const v = vars.namedChild(j);
+                const value = values.namedChild(j);
+                if (value?.type !== 'function_definition') continue;
+                const declNode = local ? node.parent : node;
+                if (v.type === 'identifier') {
+                    functions.push(buildFunction(declNode, value, v.text, lines, {
+                        modifiers: local ? ['local'] : ['public', 'global'],
+                        isFunctionVariable: true,
+                    }));
+                } else if (v.type === 'dot_index_expression') {
+                    const table = v.childForFieldName('table');
+                    const field = v.childForFieldName('field');
+                    if (!table || !field) continue;
+                    const owned = tables.has(table.text.split('.')[0]);
+                    functions.push(buildFunction(declNode, value, field.text, lines, {
+                        modifiers: owned ? ['public'] : ['public', 'callback'],
+                        memberAssigned: true,
+                        enclosingType: table.text,
+                        isFunctionVariable: true,
+                    }));
+                }
+            }
+        }
+        return true;
+    });
+
+    functions.sort((a, b) => a.startLine - b.startLine);
+    return functions;
+}
+
+/** Top-level `local NAME = <non-function value>` (excluding require bindings) */
+function collectState(tree, lines) {
+    const objects = [];
+    const root = tree.rootNode;
+    for (let i = 0; i < root.namedChildCount; i++) {
+        const decl = root.namedChild(i);
+        if (decl.type !== 'variable_declaration') continue;
+        const assign = decl.namedChild(0);
+        if (assign?.type !== 'assignment_statement') continue;
+        const vars = assign.namedChild(0);
+        const values = assign.namedChild(1);
+        if (!vars || !values) continue;
+        for (let j = 0; j < vars.namedChildCount; j++) {
+            const v = vars.namedChild(j);
+            const value = values.namedChild(j);
+            if (!value || v.type !== 'identifier') continue;
+            if (value.type === 'function_definition' || requireTarget(value)) continue;
+            const { startLine, endLine } = nodeToLocation(decl, lines);
+            objects.push({
+                name: v.text,
+                startLine, endLine,
+                modifiers: ['local'],
+                // `local MAX = 10` — Lua 5.4 `<const>` attribs or ALL_CAPS naming
+                ...((/<const>/.test(decl.text) || /^[A-Z][A-Z0-9_]+$/.test(v.text)) &&
+                    value.type !== 'table_constructor' && { isConst: true }),
+            });
+        }
+    }
+    return objects;
+}
+
+function findFunctions(code, parser) {
+    return collectFunctions(parseTree(parser, code), code.split('\n'));
+}
+
+function findClasses(code, parser) {
+    return [];
+}
+
+function findStateObjects(code, parser) {
+    return collectState(parseTree(parser, code), code.split('\n'));
+}
+
+/**
+ * Parse a Lua file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    return {
+        language: 'lua',
+        totalLines: lines.length,
+        functions: collectFunctions(tree, lines),
+        classes: [],
+        stateObjects: collectState(tree, lines),
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find all function calls in Lua code
+ *
+ *   f(x)         → local/global call
+ *   M.f(x)       → table-member call (isMethod false — a module namespace)
+ *   obj:f(x)     → method call (implicit self)
+ *   f "str"      → call with string argument
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean, receiver?: string}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    const functionStack = [];
+
+    const getCurrentEnclosingFunction = () => {
+        return functionStack.length > 0
+            ? { ...functionStack[functionStack.length - 1] }
+            : null;
+    };
+
+    const isFunctionNode = (node) =>
+        node.type === 'function_declaration' || node.type === 'function_definition';
+
+    traverseTree(tree.rootNode, (node) => {
+        if (isFunctionNode(node)) {
+            const nameNode = node.childForFieldName('name');
+            let name = '<anonymous>';
+            if (nameNode) {
+                const field = nameNode.childForFieldName('field') || nameNode.childForFieldName('method');
+                name = field ? field.text : nameNode.text;
+            } else if (node.parent?.type === 'expression_list') {
+                // local f = function() — name from the paired variable
+                const assign = node.parent.parent;
+                const idx = node.parent.namedChildren.findIndex(c => sameNode(c, node));
+                const v = assign?.namedChild(0)?.namedChild(idx);
+                if (v) {
+                    const field = v.childForFieldName?.('field');
+                    name = field ? field.text : v.text;
+                }
+            }
+            functionStack.push({
+                name,
+                startLine: node.startPosition.row + 1,
+                endLine: node.endPosition.row + 1,
+            });
+            return true;
+        }
+        if (node.type !== 'function_call') return true;
+        if (requireTarget(node)) return true;
+
+        const nameNode = node.childForFieldName('name');
+        const args = node.childForFieldName('arguments');
+        const argCount = !args ? 0 : (args.type === 'arguments'
+            ? args.namedChildren.filter(a => a.type !== 'comment').length : 1);
+        const enclosingFunction = getCurrentEnclosingFunction();
+        if (!nameNode) return true;
+
+        if (nameNode.type === 'identifier') {
+            calls.push({
+                name: nameNode.text,
+                line: nameNode.startPosition.row + 1,
+                isMethod: false,
+                argCount,
+                enclosingFunction,
+                uncertain: false,
+            });
+        } else if (nameNode.type === 'dot_index_expression' || nameNode.type === 'method_index_expression') {
+            const isMethodSyntax = nameNode.type === 'method_index_expression';
+            const table = nameNode.childForFieldName('table');
+            const field = nameNode.childForFieldName(isMethodSyntax ? 'method' : 'field');
+            if (!field) return true;
+            calls.push({
+                name: field.text,
+                line: field.startPosition.row + 1,
+                isMethod: isMethodSyntax,
+                ...(table && { receiver: table.text }),
+                argCount,
+                enclosingFunction,
+                uncertain: false,
+            });
+        }
+        return true;
+    }, {
+        onLeave: (node) => {
+            if (isFunctionNode(node)) functionStack.pop();
+        }
+    });
+
+    return calls;
+}
+
+/**
+ * Find require() imports: `local util = require("util")` binds `util`;
+ * a bare `require "plugin"` runs the module for side effects. A non-literal
+ * argument (`require(name)`) is dynamic.
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const imports = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'function_call') return true;
+        const name = node.childForFieldName('name');
+        if (name?.type !== 'identifier' || name.text !== 'require') return true;
+        const line = node.startPosition.row + 1;
+        const target = requireTarget(node);
+        if (!target) {
+            const args = node.childForFieldName('arguments');
+            imports.push({ module: args ? args.text.replace(/^\(|\)$/g, '') : null, names: [], type: 'require', line, dynamic: true });
+            return false;
+        }
+        // Binding: the paired variable of `local x = require(...)`
+        let names = [];
+        const list = node.parent;
+        if (list?.type === 'expression_list' && list.parent?.type === 'assignment_statement') {
+            const idx = list.namedChildren.findIndex(c => sameNode(c, node));
+            const v = list.parent.namedChild(0)?.namedChild(idx);
+            if (v?.type === 'identifier') names = [v.text];
+        }
+        imports.push({ module: target, names, type: 'require', line });
+        return false;
+    });
+    return imports;
+}
+
+/**
+ * Export surface: the members of the table the module returns
+ * (`return M` → every `M.f` / `M:f`), or the keys of a returned table
+ * literal (`return { run = run }`). Top-level globals are exported too.
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const exports = [];
+    const root = tree.rootNode;
+    const ret = moduleReturn(root);
+    if (ret?.type === 'table_constructor') {
+        for (let i = 0; i < ret.namedChildCount; i++) {
+            const field = ret.namedChild(i);
+            const key = field.childForFieldName('name');
+            const value = field.childForFieldName('value');
+            if (key?.type === 'identifier') {
+                exports.push({ name: key.text, type: 'return', line: field.startPosition.row + 1 });
+            } else if (!key && value?.type === 'identifier') {
+                exports.push({ name: value.text, type: 'return', line: field.startPosition.row + 1 });
+            }
+        }
+    } else if (ret?.type === 'identifier') {
+        const moduleName = ret.text;
+        traverseTreeCached(root, (node) => {
+            let target = null;
+            if (node.type === 'function_declaration') target = node.childForFieldName('name');
+            else if (node.type === 'assignment_statement') target = node.namedChild(0)?.namedChild(0);
+            if (target && (target.type === 'dot_index_expression' || target.type === 'method_index_expression')) {
+                const table = target.childForFieldName('table');
+                const field = target.childForFieldName('field') || target.childForFieldName('method');
+                if (table?.text === moduleName && field) {
+                    exports.push({ name: field.text, type: 'module-table', line: node.startPosition.row + 1 });
+                }
+            }
+            return true;
+        });
+    }
+    return exports;
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+
+    visitNameNodes(tree, code, name, (node) => {
+        if (node.type !== 'identifier' || node.text !== name) return true;
+        const line = node.startPosition.row + 1;
+        const column = node.startPosition.column;
+        const parent = node.parent;
+        let usageType = 'reference';
+
+        if (parent) {
+            if (parent.type === 'function_declaration' && sameNode(parent.childForFieldName('name'), node)) {
+                usageType = 'definition';
+            } else if (parent.type === 'parameters') {
+                usageType = 'definition';
+            } else if (parent.type === 'function_call' && sameNode(parent.childForFieldName('name'), node)) {
+                usageType = 'call';
+            } else if (parent.type === 'dot_index_expression' || parent.type === 'method_index_expression') {
+                const table = parent.childForFieldName('table');
+                if (!sameNode(table, node)) {
+                    const grand = parent.parent;
+                    if (grand?.type === 'function_declaration') {
+                        usageType = 'definition';
+                    } else {
+                        usageType = grand?.type === 'function_call' ? 'call' : 'reference';
+                    }
+                    usages.push({ line, column, usageType, ...(table && { receiver: table.text }) });
+                    return true;
+                }
+            } else if (parent.type === 'variable_list') {
+                const assign = parent.parent;
+                const decl = assign?.parent;
+                if (decl?.type === 'variable_declaration') usageType = 'definition';
+                else {
+                    const idx = parent.namedChildren.findIndex(c => sameNode(c, node));
+                    const value = assign?.namedChild(1)?.namedChild(idx);
+                    if (requireTarget(value)) usageType = 'import';
+                }
+            }
+        }
+
+        usages.push({ line, column, usageType });
+        return true;
+    });
+
+    return usages;
+}
+
+/**
+ * Classify a Lua entry point:
+ * - 'framework': functions assigned onto host tables (`function love.draw()`)
+ *                and global engine lifecycle functions (`function update(dt)`).
+ * Lua has no `main` function convention — scripts run top to bottom.
+ */
+function getEntryPointKind(symbol) {
+    const mods = symbol.modifiers || [];
+    if (mods.includes('callback')) return 'framework';
+    if (mods.includes('global') && GLOBAL_CALLBACKS.has(symbol.name)) return 'framework';
+    return null;
+}
+
+/**
+ * Check if a symbol is a Lua-convention entry point.
+ */
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    parse
+};
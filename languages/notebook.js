@@ -0,0 +1,73 @@
+/**
+ * languages/notebook.js - Jupyter notebook (.ipynb) code-cell extraction
+ *
+ * Notebooks are not indexed as a language: their code cells are reference
+ * sources for the Python symbols they call (the Python bridgedSources
+ * trait). A function of a project library used only from an analysis
+ * notebook is alive.
+ *
+ * Handles nbformat 4 (`cells[].source`, string or line array) and nbformat 3
+ * (`worksheets[].cells[].input`). The notebook language comes from
+ * `metadata.language_info.name` / `metadata.kernelspec.language` (Python
+ * when absent); a cell's own language (`%%julia`, VS Code's
+ * `metadata.vscode.languageId`) overrides it per cell.
+ */
+
+'use strict';
+
+const CELL_MAGIC_LANGUAGES = new Map([
+    ['python', 'python'], ['python3', 'python'], ['julia', 'julia'],
+    ['bash', 'bash'], ['sh', 'bash'], ['script', 'bash'], ['javascript', 'javascript'],
+    ['js', 'javascript'], ['html', 'html'], ['sql', 'sql'], ['R', 'r'], ['latex', 'latex'],
+]);
+
+function cellSource(cell) {
+    const src = cell.source ?? cell.input ?? '';
+    return Array.isArray(src) ? src.join('') : String(src);
+}
+
+/**
+ * Code cells of a notebook with their languages.
+ * @param {string} content - Raw .ipynb JSON
+ * @returns {Array<{index: number, language: string, source: string}>} [] for unreadable notebooks
+ */
+function codeCells(content) {
+    let nb;
+    try { nb = JSON.parse(content); } catch { return []; }
+    if (!nb || typeof nb !== 'object') return [];
+    const meta = nb.metadata || {};
+    const notebookLanguage = String(meta.language_info?.name || meta.kernelspec?.language || 'python').toLowerCase();
+    const cells = Array.isArray(nb.cells) ? nb.cells
+        : (nb.worksheets || []).flatMap(w => (Array.isArray(w?.cells) ? w.cells : []));
+
+    const result = [];
+    cells.forEach((cell, index) => {
+        if (!cell || cell.cell_type !== 'code') return;
+        const source = cellSource(cell);
+        const magic = source.match(/^\s*%%(\w+)/);
+        const language = (magic && CELL_MAGIC_LANGUAGES.get(magic[1])) ||
+            cell.metadata?.vscode?.languageId || cell.language || notebookLanguage;
+        result.push({ index, language: String(language).toLowerCase(), source });
+    });
+    return result;
+}
+
+/**
+ * Source text of a notebook's cells in the given languages, one cell per
+ * paragraph. Line magics and shell escapes (`%timeit f(x)`, `!pip ...`)
+ * stay: their arguments are code.
+ * @param {string} content - Raw .ipynb JSON
+ * @param {string[]} languages - Cell languages to keep
+ */
+function notebookSource(content, languages) {
+    const wanted = new Set(languages);
+    return codeCells(content)
+        .filter(cell => wanted.has(cell.language))
+        .map(cell => cell.source)
+        .join('\n\n');
+}
+
+module.exports = {
+    codeCells,
+    notebookSource,
+};
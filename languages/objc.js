@@ -0,0 +1,561 @@
+/**
+ * languages/objc.js - Tree-sitter based Objective-C parsing
+ *
+ * Handles: @interface (as class, with superclass and adopted protocols),
+ * @implementation and categories (as impl blocks whose methods belong to the
+ * class), @protocol (as interface), method declarations/definitions named by
+ * their full selector (`fetchUserWithId:completion:`), @property (as fields),
+ * C functions, message sends and @selector() as calls, and #import/@import.
+ *
+ * A method is named by its selector everywhere — definitions, message
+ * sends and @selector() references — so the call graph matches exactly.
+ *
+ * Mixed iOS codebases: Swift files are not indexed, but they consume ObjC
+ * classes and methods through the names the Clang importer gives them
+ * (`fetchUserWithId:completion:` → `fetchUser(withId:completion:)`).
+ * swiftNames() maps a symbol to those names; deadcode reads .swift sources
+ * for them (the bridgedSources trait) before claiming an ObjC symbol.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+const CONTAINER_TYPES = new Set([
+    'class_interface', 'class_implementation', 'category_interface',
+    'category_implementation', 'protocol_declaration',
+]);
+const METHOD_TYPES = new Set(['method_declaration', 'method_definition']);
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+/** `/// doc`, `// doc` or `/** doc *\/` directly above a declaration */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    if (i < 0) return null;
+    if (/\*\/\s*$/.test(lines[i])) {
+        while (i > 0 && !/\/\*/.test(lines[i])) i--;
+        const text = lines.slice(i, startLine - 1).join('\n')
+            .replace(/^\s*\/\*+|\*+\/\s*$/g, '').replace(/^\s*\*\s?/gm, '').trim();
+        return text.split('\n')[0].trim() || null;
+    }
+    if (!/^\s*\/\//.test(lines[i])) return null;
+    while (i > 0 && /^\s*\/\//.test(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*\/\/+\s?/, '').trim() || null;
+}
+
+/** Read a parenthesized type starting at text[i] === '(' → [type, nextIndex] */
+function readParenType(text, i) {
+    let depth = 0;
+    for (let j = i; j < text.length; j++) {
+        if (text[j] === '(') depth++;
+        else if (text[j] === ')' && --depth === 0) return [text.slice(i + 1, j).trim(), j + 1];
+    }
+    return [text.slice(i + 1).trim(), text.length];
+}
+
+/**
+ * Parse a method header — `- (void)fetchUserWithId:(NSString *)userId completion:(Block)done`
+ * @returns {{selector: string, returnType: string|null, params: Array<{name: string, type: string}>, isClassMethod: boolean}}
+ */
+function parseMethodHeader(header) {
+    let text = header.trim();
+    const isClassMethod = text.startsWith('+');
+    text = text.replace(/^[-+]\s*/, '');
+    let i = 0;
+    let returnType = null;
+    if (text[0] === '(') [returnType, i] = readParenType(text, 0);
+    const keywords = [];
+    const params = [];
+    const word = /[A-Za-z_]\w*/y;
+    const skipSpace = () => { while (i < text.length && /\s/.test(text[i])) i++; };
+    while (i < text.length) {
+        skipSpace();
+        word.lastIndex = i;
+        const kw = word.exec(text);
+        const keyword = kw ? kw[0] : '';
+        if (kw) i = word.lastIndex;
+        skipSpace();
+        if (text[i] !== ':') {
+            // Unary selector, or trailing attributes (NS_SWIFT_NAME(...), __attribute__)
+            if (keywords.length === 0 && keyword) keywords.push(keyword);
+            break;
+        }
+        i++;
+        skipSpace();
+        let type = 'id';
+        if (text[i] === '(') [type, i] = readParenType(text, i);
+        skipSpace();
+        word.lastIndex = i;
+        const param = word.exec(text);
+        if (param) i = word.lastIndex;
+        keywords.push(`${keyword}:`);
+        params.push({ name: param ? param[0] : '', type });
+        if (!param) break;
+    }
+    return { selector: keywords.join(''), returnType, params, isClassMethod };
+}
+
+/** Text of a method node up to its body */
+function methodHeaderText(node) {
+    const body = namedChildOfType(node, 'compound_statement');
+    const text = body ? node.text.slice(0, body.startIndex - node.startIndex) : node.text;
+    return text.replace(/;\s*$/, '');
+}
+
+function methodMember(node, lines) {
+    const header = methodHeaderText(node);
+    const { selector, returnType, params, isClassMethod } = parseMethodHeader(header);
+    if (!selector) return null;
+    const { startLine, endLine } = nodeToLocation(node, lines);
+    const docstring = extractDocstring(lines, startLine);
+    const swiftName = header.match(/NS_SWIFT_NAME\(\s*([A-Za-z_]\w*)/);
+    const modifiers = isClassMethod ? ['static'] : [];
+    if (returnType === 'IBAction') modifiers.push('IBAction');
+    if (swiftName) modifiers.push(`swift:${swiftName[1]}`);
+    return {
+        name: selector,
+        startLine,
+        endLine,
+        memberType: isClassMethod ? 'static' : 'method',
+        params: params.map(p => `${p.type} ${p.name}`.trim()).join(', '),
+        paramsStructured: params,
+        ...(returnType && { returnType }),
+        modifiers,
+        ...(node.type === 'method_declaration' && { isSignature: true }),
+        ...(docstring && { docstring }),
+    };
+}
+
+/** `@property (nonatomic, copy) NSString *name;` → { name, type } */
+function propertyMember(node, lines) {
+    const text = node.text.replace(/^@property\s*(\([^)]*\))?/, '').replace(/;\s*$/, '').trim();
+    const m = text.match(/^([\s\S]*?)\s*\**\s*([A-Za-z_]\w*)\s*(?:NS_\w+(?:\([^)]*\))?\s*)*$/);
+    if (!m) return null;
+    const { startLine, endLine } = nodeToLocation(node, lines);
+    const attrs = node.text.match(/^@property\s*\(([^)]*)\)/);
+    return {
+        name: m[2],
+        startLine,
+        endLine,
+        memberType: 'field',
+        fieldType: m[1].replace(/\s*\*$/, '').trim(),
+        modifiers: attrs ? attrs[1].split(',').map(a => a.trim()).filter(Boolean) : [],
+    };
+}
+
+/** name / superclass / category / protocols of an ObjC container */
+function containerHeader(node) {
+    const identifiers = [];
+    let sawColon = false;
+    let inParens = false;
+    let superclass = null;
+    let category = null;
+    const protocols = [];
+    for (const child of node.children) {
+        if (child.type === ':') { sawColon = true; continue; }
+        if (child.type === '(') { inParens = true; continue; }
+        if (child.type === ')') { inParens = false; continue; }
+        if (/protocol|parameterized_arguments/.test(child.type) && child.isNamed) {
+            protocols.push(...(child.text.match(/[A-Za-z_]\w*/g) || []));
+            continue;
+        }
+        if (child.type !== 'identifier' && child.type !== 'type_identifier') {
+            if (child.isNamed && identifiers.length > 0) break; // body begins
+            continue;
+        }
+        if (inParens) category = child.text;
+        else if (sawColon && !superclass) superclass = child.text;
+        else identifiers.push(child.text);
+    }
+    const hasParens = node.children.some(c => c.type === '(');
+    return {
+        name: node.childForFieldName('name')?.text || identifiers[0] || null,
+        superclass: node.childForFieldName('superclass')?.text || superclass,
+        category: node.childForFieldName('category')?.text || category,
+        isCategory: hasParens || node.type.startsWith('category_'),
+        protocols,
+    };
+}
+
+function collectClasses(tree, lines) {
+    const classes = [];
+    for (const node of tree.rootNode.namedChildren) {
+        if (!CONTAINER_TYPES.has(node.type)) continue;
+        const { name, superclass, category, isCategory, protocols } = containerHeader(node);
+        if (!name) continue;
+        const isProtocol = node.type === 'protocol_declaration';
+        const isImplementation = node.type.endsWith('_implementation');
+        const members = [];
+        traverseTree(node, (child) => {
+            if (child === node) return true;
+            if (METHOD_TYPES.has(child.type)) {
+                const member = methodMember(child, lines);
+                if (member) members.push(member);
+                return false;
+            }
+            if (child.type === 'property_declaration') {
+                const member = propertyMember(child, lines);
+                if (member) members.push(member);
+                return false;
+            }
+            return !CONTAINER_TYPES.has(child.type) && child.type !== 'compound_statement';
+        });
+        const { startLine, endLine } = nodeToLocation(node, lines);
+        const docstring = extractDocstring(lines, startLine);
+        // An @interface declares the class; implementations, categories and
+        // class extensions (`@interface Foo ()`) add to it like impl blocks.
+        // Header method declarations are signatures of the implementation's
+        // definitions and are not indexed twice.
+        const type = isProtocol ? 'interface' : (isImplementation || isCategory) ? 'impl' : 'class';
+        classes.push({
+            name,
+            startLine,
+            endLine,
+            type,
+            members: type === 'class' ? members.filter(m => m.memberType === 'field') : members,
+            modifiers: isCategory ? ['category', ...(category ? [category] : [])] : [],
+            ...(superclass && !isProtocol && { extends: superclass }),
+            ...(isProtocol && protocols.length > 0 && { extends: protocols.join(', ') }),
+            ...(!isProtocol && protocols.length > 0 && { implements: protocols }),
+            ...(docstring && { docstring }),
+        });
+    }
+    return classes;
+}
+
+/** C declarator chain → function name */
+function declaratorName(node) {
+    let d = node.childForFieldName('declarator');
+    while (d && d.type !== 'identifier') {
+        d = d.childForFieldName('declarator') || namedChildOfType(d, 'identifier');
+    }
+    return d ? d.text : null;
+}
+
+function collectFunctions(tree, lines) {
+    const functions = [];
+    for (const node of tree.rootNode.namedChildren) {
+        if (node.type !== 'function_definition') continue;
+        const name = declaratorName(node);
+        if (!name) continue;
+        const fnDecl = node.childForFieldName('declarator');
+        const paramsNode = fnDecl?.childForFieldName('parameters');
+        const typeNode = node.childForFieldName('type');
+        const { startLine, endLine } = nodeToLocation(node, lines);
+        const docstring = extractDocstring(lines, startLine);
+        functions.push({
+            name,
+            params: paramsNode ? paramsNode.text.replace(/^\(|\)$/g, '').trim() : '',
+            paramsStructured: [],
+            startLine,
+            endLine,
+            modifiers: /^\s*static\b/.test(node.text) ? ['static'] : [],
+            ...(typeNode && { returnType: typeNode.text }),
+            ...(docstring && { docstring }),
+        });
+    }
+    return functions;
+}
+
+function findFunctions(code, parser) {
+    return collectFunctions(parseTree(parser, code), code.split('\n'));
+}
+
+function findClasses(code, parser) {
+    return collectClasses(parseTree(parser, code), code.split('\n'));
+}
+
+function findStateObjects() {
+    return [];
+}
+
+/**
+ * Parse an Objective-C file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    return {
+        language: 'objc',
+        totalLines: lines.length,
+        functions: collectFunctions(tree, lines),
+        classes: collectClasses(tree, lines),
+        stateObjects: [],
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Selector of a message send: keyword identifiers directly followed by `:`
+ * (possibly wrapped in keyword-argument nodes), or the single unary name.
+ */
+function messageSelector(node, receiver) {
+    const keywords = [];
+    let unary = null;
+    const scan = (parent) => {
+        const children = parent.children;
+        for (let i = 0; i < children.length; i++) {
+            const child = children[i];
+            if (child === receiver) continue;
+            if ((child.type === 'identifier' || child.type === 'field_identifier') &&
+                children[i + 1]?.type === ':') {
+                keywords.push(`${child.text}:`);
+            } else if (child.type === ':' && (i === 0 || children[i - 1].type !== 'identifier')) {
+                if (parent !== node || keywords.length > 0) keywords.push(':');
+            } else if (child.isNamed && /keyword/.test(child.type)) {
+                scan(child);
+            } else if (!unary && keywords.length === 0 && parent === node &&
+                (child.type === 'identifier' || child.type === 'field_identifier')) {
+                unary = child.text;
+            }
+        }
+    };
+    scan(node);
+    return keywords.length > 0 ? keywords.join('') : unary;
+}
+
+/**
+ * Find calls in Objective-C code
+ *
+ *   [client fetchUserWithId:uid completion:nil] → fetchUserWithId:completion: (receiver client)
+ *   [[Cache alloc] init]                         → Cache (class reference), alloc, init
+ *   @selector(refresh:)                          → refresh: (function reference)
+ *   NSLog(@"x"); helper(1);                      → NSLog, helper
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const calls = [];
+    const scopeStack = [];
+
+    const enclosing = () => scopeStack.length > 0 ? { ...scopeStack[scopeStack.length - 1] } : null;
+    const SCOPES = new Set(['method_definition', 'function_definition']);
+
+    traverseTree(tree.rootNode, (node) => {
+        if (SCOPES.has(node.type)) {
+            const name = node.type === 'function_definition'
+                ? declaratorName(node)
+                : parseMethodHeader(methodHeaderText(node)).selector;
+            const { startLine, endLine } = nodeToLocation(node, lines);
+            scopeStack.push({ name: name || '<anonymous>', startLine, endLine });
+            return true;
+        }
+        if (node.type === 'message_expression') {
+            const receiver = node.childForFieldName('receiver') || node.namedChild(0);
+            const selector = messageSelector(node, receiver);
+            if (selector) {
+                const receiverText = receiver && /^[A-Za-z_]\w*$/.test(receiver.text) ? receiver.text : null;
+                calls.push({
+                    name: selector,
+                    line: node.startPosition.row + 1,
+                    isMethod: true,
+                    ...(receiverText && { receiver: receiverText }),
+                    enclosingFunction: enclosing(),
+                    uncertain: !receiverText,
+                });
+                // `[Cache alloc]`, `[Cache sharedInstance]` name the class itself
+                if (receiverText && /^[A-Z]/.test(receiverText)) {
+                    calls.push({
+                        name: receiverText,
+                        line: receiver.startPosition.row + 1,
+                        isMethod: false,
+                        isFunctionReference: true,
+                        enclosingFunction: enclosing(),
+                        uncertain: false,
+                    });
+                }
+            }
+            return true;
+        }
+        if (node.type === 'selector_expression') {
+            const selector = node.text.replace(/^@selector\s*\(|\)$/g, '').replace(/\s+/g, '');
+            if (selector) {
+                calls.push({
+                    name: selector,
+                    line: node.startPosition.row + 1,
+                    isMethod: true,
+                    isFunctionReference: true,
+                    enclosingFunction: enclosing(),
+                    uncertain: false,
+                });
+            }
+            return false;
+        }
+        if (node.type === 'call_expression') {
+            const fn = node.childForFieldName('function');
+            if (fn?.type === 'identifier') {
+                calls.push({
+                    name: fn.text,
+                    line: fn.startPosition.row + 1,
+                    isMethod: false,
+                    enclosingFunction: enclosing(),
+                    uncertain: false,
+                });
+            }
+            return true;
+        }
+        return true;
+    }, {
+        onLeave: (node) => {
+            if (SCOPES.has(node.type)) scopeStack.pop();
+        }
+    });
+    return calls;
+}
+
+/**
+ * `#import "Foo.h"`, `#import <UIKit/UIKit.h>`, `#include "x.h"`, `@import UIKit;`
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code) {
+    const imports = [];
+    const lines = code.split('\n');
+    for (let i = 0; i < lines.length; i++) {
+        const inc = lines[i].match(/^\s*#\s*(import|include)\s+(["<])([^">]+)[">]/);
+        if (inc) {
+            imports.push({ module: inc[3], names: [], type: inc[2] === '<' ? 'system' : inc[1], line: i + 1 });
+            continue;
+        }
+        const mod = lines[i].match(/^\s*@import\s+([\w.]+)\s*;/);
+        if (mod) imports.push({ module: mod[1], names: [], type: 'module', line: i + 1 });
+    }
+    return imports;
+}
+
+/** Everything declared in a header is visible to its importers */
+function findExportsInCode() {
+    return [];
+}
+
+/**
+ * Find all usages of a name in code using AST. Selectors (names with `:`)
+ * match message sends, @selector() and method definitions.
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+    const push = (node, usageType) => usages.push({
+        line: node.startPosition.row + 1,
+        column: node.startPosition.column,
+        usageType,
+    });
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (METHOD_TYPES.has(node.type)) {
+            if (parseMethodHeader(methodHeaderText(node)).selector === name) push(node, 'definition');
+            return true;
+        }
+        if (node.type === 'message_expression') {
+            const receiver = node.childForFieldName('receiver') || node.namedChild(0);
+            if (messageSelector(node, receiver) === name) push(node, 'call');
+            return true;
+        }
+        if (node.type === 'selector_expression') {
+            if (node.text.replace(/^@selector\s*\(|\)$/g, '').replace(/\s+/g, '') === name) push(node, 'reference');
+            return false;
+        }
+        if ((node.type === 'identifier' || node.type === 'type_identifier') && node.text === name) {
+            const parentType = node.parent?.type;
+            let usageType = 'reference';
+            if (CONTAINER_TYPES.has(parentType) && node.parent.childForFieldName('name') === node) {
+                usageType = 'definition';
+            } else if (parentType === 'function_declarator') {
+                usageType = 'definition';
+            } else if (parentType === 'call_expression') {
+                usageType = 'call';
+            }
+            push(node, usageType);
+        }
+        return true;
+    });
+    return usages;
+}
+
+// Clang importer prepositions that split a selector's first keyword into a
+// Swift base name and first argument label
+const SWIFT_PREPOSITIONS = /^(.+?)(With|For|From|To|By|In|At|Of|Using|On)(?=[A-Z])/;
+
+/**
+ * Names Swift code uses for an Objective-C symbol, beyond its own:
+ *
+ *   fetchUserWithId:completion: → fetchUser, fetchUserWithId
+ *   initWithName:               → init
+ *   reload                      → (own name)
+ *   NS_SWIFT_NAME(load(from:))  → load
+ */
+function swiftNames(symbol) {
+    const forms = new Set();
+    for (const mod of symbol.modifiers || []) {
+        if (mod.startsWith('swift:')) forms.add(mod.slice('swift:'.length));
+    }
+    if (symbol.name.includes(':')) {
+        const first = symbol.name.split(':')[0];
+        if (/^init[A-Z]|^init$/.test(first)) {
+            forms.add('init');
+        } else if (first) {
+            forms.add(first);
+            const m = first.match(SWIFT_PREPOSITIONS);
+            if (m) forms.add(m[1]);
+        }
+    }
+    forms.delete(symbol.name);
+    return [...forms];
+}
+
+/**
+ * Classify an Objective-C entry point:
+ * - 'main':      C main()
+ * - 'framework': +load/+initialize (runtime-invoked), IBAction methods
+ *                (wired in storyboards/nibs), and app-delegate callbacks
+ */
+function getEntryPointKind(symbol) {
+    if (symbol.type === 'function' && symbol.name === 'main') return 'main';
+    const mods = symbol.modifiers || [];
+    if ((symbol.name === 'load' || symbol.name === 'initialize') && mods.includes('static')) return 'framework';
+    if (mods.includes('IBAction')) return 'framework';
+    if (/^application:|^applicationDid|^applicationWill/.test(symbol.name)) return 'framework';
+    return null;
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    parseMethodHeader,
+    swiftNames,
+    parse
+};
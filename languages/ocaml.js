@@ -0,0 +1,435 @@
+/**
+ * languages/ocaml.js - Tree-sitter based OCaml parsing
+ *
+ * Handles implementations (.ml) and interfaces (.mli) — the same module
+ * serves both grammars, as javascript.js serves JS/TS/TSX:
+ * - `let` bindings with parameters (or a `fun`/`function` body) are
+ *   functions; other named bindings are values (state, isConst).
+ * - type definitions (records as structs with their fields, variants as
+ *   enums), exceptions, and `module X = struct ... end` (as modules; their
+ *   bindings are indexed as functions/values too).
+ * - `val` / `external` specifications of an interface are its exports.
+ *
+ * Visibility: an implementation with a companion interface (`foo.ml` +
+ * `foo.mli`, the headerExtension trait) exports exactly what the interface
+ * declares; without one, every top-level binding is exported. Deadcode
+ * therefore flags a value present in foo.ml, absent from foo.mli and never
+ * used inside foo.ml.
+ *
+ * OCaml has no import statements: `open M`, `include M` and every qualified
+ * `M.x` reference name the compilation unit m.ml (findImportsInCode).
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+    sameNode,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+/** Blank `(* ... *)` comments (nested) and string literals, preserving offsets */
+function maskCommentsAndStrings(code) {
+    let out = '';
+    let depth = 0;
+    let inString = false;
+    for (let i = 0; i < code.length; i++) {
+        const c = code[i];
+        if (!inString && code.startsWith('(*', i)) {
+            depth++;
+            out += '  ';
+            i++;
+            continue;
+        }
+        if (depth > 0) {
+            if (code.startsWith('*)', i)) {
+                depth--;
+                out += '  ';
+                i++;
+            } else {
+                out += c === '\n' ? '\n' : ' ';
+            }
+            continue;
+        }
+        if (c === '"' && code[i - 1] !== '\\' && code[i - 1] !== "'") {
+            inString = !inString;
+            out += ' ';
+            continue;
+        }
+        out += inString && c !== '\n' ? ' ' : c;
+    }
+    return out;
+}
+
+/** `(** doc *)` directly above a definition */
+function extractDocstring(lines, startLine) {
+    const i = startLine - 2;
+    if (i < 0 || !/\*\)\s*$/.test(lines[i])) return null;
+    let j = i;
+    while (j > 0 && !/\(\*\*/.test(lines[j])) j--;
+    if (!/\(\*\*/.test(lines[j])) return null;
+    const text = lines.slice(j, i + 1).join('\n').replace(/^\s*\(\*\*|\*\)\s*$/g, '').trim();
+    return text.split('\n')[0].trim() || null;
+}
+
+/** Named bindings of a `let` definition: `let f x = ...`, `let rec f = ... and g = ...` */
+function letBindings(valueDefinition) {
+    const bindings = [];
+    for (const binding of valueDefinition.namedChildren) {
+        if (binding.type !== 'let_binding') continue;
+        const pattern = binding.childForFieldName('pattern');
+        // `let () = ...` / `let _ = ...` / tuple patterns bind no name
+        if (!pattern || pattern.type !== 'value_name') continue;
+        const params = binding.namedChildren.filter(c => c.type === 'parameter');
+        const body = binding.childForFieldName('body');
+        const isFunction = params.length > 0 ||
+            body?.type === 'fun_expression' || body?.type === 'function_expression';
+        bindings.push({ binding, name: pattern.text, params, isFunction });
+    }
+    return bindings;
+}
+
+const CONTAINER_TYPES = new Set(['structure', 'compilation_unit', 'module_binding', 'module_definition']);
+
+/** A definition directly in the file or in a (nested) `struct ... end` */
+function isModuleLevel(node) {
+    for (let p = node.parent; p; p = p.parent) {
+        if (p.type === 'compilation_unit') return true;
+        if (!CONTAINER_TYPES.has(p.type)) return false;
+    }
+    return false;
+}
+
+function collectDeclarations(tree, lines) {
+    const functions = [];
+    const classes = [];
+    const stateObjects = [];
+
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'value_definition') {
+            if (!isModuleLevel(node)) return false;
+            for (const { binding, name, params, isFunction } of letBindings(node)) {
+                const loc = nodeToLocation(binding === node.namedChildren[0] ? node : binding, lines);
+                const docstring = extractDocstring(lines, loc.startLine);
+                const typeNode = binding.childForFieldName('type');
+                if (isFunction) {
+                    functions.push({
+                        name,
+                        params: params.map(p => p.text).join(' '),
+                        paramsStructured: params.map(p => ({
+                            name: p.text.replace(/^[~?]/, '').replace(/^\(|\)$/g, '').split(/[\s:=]/)[0],
+                        })),
+                        startLine: loc.startLine,
+                        endLine: loc.endLine,
+                        modifiers: /^let\s+rec\b/.test(node.text) ? ['rec'] : [],
+                        ...(typeNode && { returnType: typeNode.text }),
+                        ...(docstring && { docstring }),
+                    });
+                } else {
+                    stateObjects.push({
+                        name,
+                        startLine: loc.startLine,
+                        endLine: loc.endLine,
+                        modifiers: [],
+                        isConst: true,
+                        ...(docstring && { docstring }),
+                    });
+                }
+            }
+            return false;
+        }
+        if (node.type === 'type_binding') {
+            const nameNode = node.childForFieldName('name');
+            if (!nameNode) return false;
+            const record = namedChildOfType(node, 'record_declaration');
+            const variant = namedChildOfType(node, 'variant_declaration');
+            const members = [];
+            if (record) {
+                for (const field of record.namedChildren) {
+                    if (field.type !== 'field_declaration') continue;
+                    const fieldName = namedChildOfType(field, 'field_name');
+                    if (!fieldName) continue;
+                    const typeText = field.text.split(':').slice(1).join(':').trim();
+                    members.push({
+                        name: fieldName.text,
+                        ...nodeToLocation(field, lines),
+                        memberType: 'field',
+                        ...(typeText && { fieldType: typeText }),
+                        modifiers: /^mutable\b/.test(field.text) ? ['mutable'] : [],
+                    });
+                }
+            }
+            const { startLine, endLine } = nodeToLocation(node, lines);
+            const docstring = extractDocstring(lines, startLine);
+            classes.push({
+                name: nameNode.text,
+                startLine,
+                endLine,
+                type: record ? 'struct' : variant ? 'enum' : 'type',
+                members,
+                modifiers: [],
+                ...(docstring && { docstring }),
+            });
+            return false;
+        }
+        if (node.type === 'exception_definition') {
+            const ctor = namedChildOfType(node, 'constructor_declaration');
+            const nameNode = ctor ? ctor.namedChildren.find(c => c.type === 'constructor_name') : null;
+            if (nameNode) {
+                classes.push({
+                    name: nameNode.text,
+                    ...nodeToLocation(node, lines),
+                    type: 'class',
+                    members: [],
+                    modifiers: ['exception'],
+                });
+            }
+            return false;
+        }
+        if (node.type === 'module_binding') {
+            const nameNode = node.childForFieldName('name') || namedChildOfType(node, 'module_name');
+            if (nameNode && isModuleLevel(node)) {
+                const { startLine, endLine } = nodeToLocation(node.parent || node, lines);
+                const docstring = extractDocstring(lines, startLine);
+                classes.push({
+                    name: nameNode.text,
+                    startLine,
+                    endLine,
+                    type: 'module',
+                    members: [],
+                    modifiers: [],
+                    ...(docstring && { docstring }),
+                });
+            }
+            return true;
+        }
+        return true;
+    });
+
+    functions.sort((a, b) => a.startLine - b.startLine);
+    classes.sort((a, b) => a.startLine - b.startLine);
+    return { functions, classes, stateObjects };
+}
+
+function findFunctions(code, parser) {
+    return collectDeclarations(parseTree(parser, code), code.split('\n')).functions;
+}
+
+function findClasses(code, parser) {
+    return collectDeclarations(parseTree(parser, code), code.split('\n')).classes;
+}
+
+function findStateObjects(code, parser) {
+    return collectDeclarations(parseTree(parser, code), code.split('\n')).stateObjects;
+}
+
+/**
+ * Parse an OCaml implementation or interface completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const { functions, classes, stateObjects } = collectDeclarations(tree, lines);
+    return {
+        language: 'ocaml',
+        totalLines: lines.length,
+        functions,
+        classes,
+        stateObjects,
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find calls in OCaml code
+ *
+ *   helper x          → helper (call)
+ *   List.map render l → map (call, receiver List), render (reference, callback)
+ *   Cache.find k      → find (call, receiver Cache)
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const calls = [];
+    const functionStack = [];
+    const enclosing = () => functionStack.length > 0 ? { ...functionStack[functionStack.length - 1] } : null;
+
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'let_binding' && node.parent?.type === 'value_definition' &&
+            isModuleLevel(node.parent)) {
+            const pattern = node.childForFieldName('pattern');
+            if (pattern?.type === 'value_name') {
+                functionStack.push({ name: pattern.text, ...nodeToLocation(node, lines), _node: node });
+            }
+            return true;
+        }
+        if (node.type !== 'value_path') return true;
+        const nameNode = namedChildOfType(node, 'value_name');
+        if (!nameNode) return false;
+        const modulePath = namedChildOfType(node, 'module_path');
+        const parent = node.parent;
+        const applied = parent?.type === 'application_expression' &&
+            sameNode(parent.childForFieldName('function'), node);
+        const frame = enclosing();
+        if (frame) delete frame._node;
+        calls.push({
+            name: nameNode.text,
+            line: node.startPosition.row + 1,
+            isMethod: false,
+            ...(modulePath && { receiver: modulePath.text }),
+            ...(!applied && {
+                isFunctionReference: true,
+                ...(parent?.type === 'application_expression' && { isPotentialCallback: true }),
+            }),
+            enclosingFunction: frame,
+            uncertain: false,
+        });
+        return false;
+    }, {
+        onLeave: (node) => {
+            if (functionStack.length > 0 && functionStack[functionStack.length - 1]._node === node) {
+                functionStack.pop();
+            }
+        }
+    });
+    return calls;
+}
+
+/**
+ * Compilation units a file depends on: `open M`, `include M`, and the head
+ * of every qualified path `M.x` / `M.N.x`. Lowercased first letter gives
+ * the file (`Cache` → cache.ml). Grammar-independent.
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code) {
+    const masked = maskCommentsAndStrings(code);
+    const imports = [];
+    const seen = new Set();
+    const lines = masked.split('\n');
+    for (let i = 0; i < lines.length; i++) {
+        const directive = lines[i].match(/^\s*(open!?|include)\s+([A-Z][\w']*)/);
+        if (directive) {
+            seen.add(directive[2]);
+            imports.push({ module: directive[2], names: [], type: directive[1].replace('!', ''), line: i + 1 });
+        }
+        for (const m of lines[i].matchAll(/(?<![\w'.])([A-Z][\w']*)\.(?=[a-z_A-Z(])/g)) {
+            if (seen.has(m[1])) continue;
+            seen.add(m[1]);
+            imports.push({ module: m[1], names: [], type: 'qualified', line: i + 1 });
+        }
+    }
+    return imports;
+}
+
+const DECLARATION = /^(\s*)(val|external|let(?:\s+rec)?|and|type(?:\s+nonrec)?|exception|module(?:\s+type)?)\s+(.*)$/;
+
+/**
+ * Exported names. An interface exports its `val`/`external` specifications,
+ * types, exceptions and modules; an implementation (read alone) exports
+ * every top-level binding. Implementations with a companion interface defer
+ * to it (headerExtension trait, applied by deadcode).
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code) {
+    const masked = maskCommentsAndStrings(code);
+    const exports = [];
+    const lines = masked.split('\n');
+    let depth = 0;
+    for (let i = 0; i < lines.length; i++) {
+        const line = lines[i];
+        // Only the file's own level and nested struct/sig blocks — an
+        // indented `let` at depth 0 is local to an expression
+        const m = line.match(DECLARATION);
+        if (m && (m[1].length === 0 || depth > 0)) {
+            const kind = m[2].split(/\s+/)[0];
+            // `type 'a t` / `type ('a, 'b) t`: skip the type parameters
+            const rest = kind === 'type' ? m[3].replace(/^(?:'\w+|\([^)]*\))\s+/, '') : m[3];
+            const name = rest.match(/^([a-zA-Z_][\w']*|\([^)]*\))/)?.[1];
+            if (name && name !== '_' && !(kind === 'let' && name.startsWith('('))) {
+                exports.push({ name, type: kind, line: i + 1 });
+            }
+        }
+        depth += (line.match(/\b(struct|sig|object)\b/g) || []).length;
+        depth -= (line.match(/\bend\b/g) || []).length;
+        if (depth < 0) depth = 0;
+    }
+    return exports;
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+    const NAME_TYPES = new Set(['value_name', 'type_constructor', 'module_name', 'constructor_name', 'field_name']);
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (!NAME_TYPES.has(node.type)) return true;
+        if (node.text !== name) return false;
+        const parent = node.parent;
+        let usageType = 'reference';
+        if (parent?.type === 'let_binding' || parent?.type === 'value_specification' ||
+            parent?.type === 'type_binding' || parent?.type === 'module_binding' ||
+            parent?.type === 'external') {
+            usageType = 'definition';
+        } else if (parent?.type === 'value_path') {
+            const outer = parent.parent;
+            if (outer?.type === 'application_expression' &&
+                sameNode(outer.childForFieldName('function'), parent)) usageType = 'call';
+        }
+        usages.push({ line: node.startPosition.row + 1, column: node.startPosition.column, usageType });
+        return false;
+    });
+    return usages;
+}
+
+/**
+ * Classify an OCaml entry point:
+ * - 'main': a top-level `main` (`let () = main ()` runs it; the unit
+ *   binding itself names nothing). Inline tests (`let%test`) are ppx
+ *   extensions, not named bindings.
+ */
+function getEntryPointKind(symbol) {
+    if (symbol.name === 'main' && symbol.type === 'function') return 'main';
+    return null;
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    parse
+};
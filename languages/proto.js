@@ -0,0 +1,362 @@
+/**
+ * languages/proto.js - Tree-sitter based Protocol Buffers parsing
+ *
+ * Handles: messages (as structs, fields as members, nested messages with
+ * their enclosing type), enums, services (as interfaces, rpcs as members),
+ * message type references in fields and rpc signatures as calls, and
+ * `import "x.proto"` as imports.
+ *
+ * Application code never names a .proto symbol directly — it uses the code
+ * protoc generates from it. generatedNames() maps a definition to the
+ * identifiers each target language's generator emits (`UserServiceClient`,
+ * `UserServiceStub`, `getUser`, `get_user`), and deadcode counts those forms
+ * in hand-written files as uses of the definition.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+function declName(node, nameType) {
+    const holder = namedChildOfType(node, nameType);
+    if (!holder) return null;
+    return namedChildOfType(holder, 'identifier') || holder;
+}
+
+/** `// comment` lines directly above a declaration */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    if (i < 0 || !/^\s*\/\//.test(lines[i])) return null;
+    while (i > 0 && /^\s*\/\//.test(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*\/\/+\s?/, '').trim() || null;
+}
+
+/** Last identifier of a (possibly qualified) type reference */
+function typeRefName(typeNode) {
+    const ids = typeNode.namedChildren.filter(c => c.type === 'identifier');
+    return ids.length > 0 ? ids[ids.length - 1].text : typeNode.text.replace(/^.*\./, '');
+}
+
+function messageMembers(body, lines) {
+    const members = [];
+    if (!body) return members;
+    const pushField = (node, nameNode, typeNode) => {
+        const { startLine, endLine } = nodeToLocation(node, lines);
+        members.push({
+            name: nameNode.text,
+            startLine,
+            endLine,
+            memberType: 'field',
+            ...(typeNode && { fieldType: typeNode.text }),
+        });
+    };
+    for (const child of body.namedChildren) {
+        if (child.type === 'field' || child.type === 'map_field') {
+            const nameNode = child.namedChildren.find(c => c.type === 'identifier');
+            if (nameNode) pushField(child, nameNode, namedChildOfType(child, 'type'));
+        } else if (child.type === 'oneof') {
+            for (const f of child.namedChildren) {
+                if (f.type !== 'oneof_field') continue;
+                const nameNode = f.namedChildren.find(c => c.type === 'identifier');
+                if (nameNode) pushField(f, nameNode, namedChildOfType(f, 'type'));
+            }
+        }
+    }
+    return members;
+}
+
+function enumMembers(body, lines) {
+    const members = [];
+    if (!body) return members;
+    for (const child of body.namedChildren) {
+        if (child.type !== 'enum_field') continue;
+        const nameNode = namedChildOfType(child, 'identifier');
+        if (!nameNode) continue;
+        const { startLine, endLine } = nodeToLocation(child, lines);
+        members.push({ name: nameNode.text, startLine, endLine, memberType: 'field' });
+    }
+    return members;
+}
+
+function rpcMembers(service, lines) {
+    const members = [];
+    for (const child of service.namedChildren) {
+        if (child.type !== 'rpc') continue;
+        const nameNode = declName(child, 'rpc_name');
+        if (!nameNode) continue;
+        // `rpc Chat(stream Msg) returns (stream Msg)` — `stream` precedes its type
+        const types = [];
+        let stream = false;
+        for (let i = 0; i < child.childCount; i++) {
+            const c = child.child(i);
+            if (c.type === 'stream') stream = true;
+            else if (c.type === 'message_or_enum_type') { types.push({ node: c, stream }); stream = false; }
+        }
+        const [request, response] = types;
+        const { startLine, endLine } = nodeToLocation(child, lines);
+        const docstring = extractDocstring(lines, startLine);
+        const modifiers = ['rpc'];
+        if (request?.stream) modifiers.push('client-stream');
+        if (response?.stream) modifiers.push('server-stream');
+        members.push({
+            name: nameNode.text,
+            startLine,
+            endLine,
+            memberType: 'method',
+            params: request ? request.node.text : '',
+            paramsStructured: request ? [{ name: 'request', type: request.node.text }] : [],
+            ...(response && { returnType: response.node.text }),
+            modifiers,
+            ...(docstring && { docstring }),
+        });
+    }
+    return members;
+}
+
+function collectClasses(tree, lines) {
+    const classes = [];
+    const visit = (node, enclosing) => {
+        for (const child of node.namedChildren) {
+            let cls = null;
+            if (child.type === 'message') {
+                const nameNode = declName(child, 'message_name');
+                if (!nameNode) continue;
+                const body = namedChildOfType(child, 'message_body');
+                cls = { name: nameNode.text, type: 'struct', members: messageMembers(body, lines), node: child, body };
+            } else if (child.type === 'enum') {
+                const nameNode = declName(child, 'enum_name');
+                if (!nameNode) continue;
+                cls = { name: nameNode.text, type: 'enum', members: enumMembers(namedChildOfType(child, 'enum_body'), lines), node: child };
+            } else if (child.type === 'service') {
+                const nameNode = declName(child, 'service_name');
+                if (!nameNode) continue;
+                cls = { name: nameNode.text, type: 'interface', members: rpcMembers(child, lines), node: child };
+            }
+            if (!cls) continue;
+            const { startLine, endLine } = nodeToLocation(cls.node, lines);
+            const docstring = extractDocstring(lines, startLine);
+            classes.push({
+                name: cls.name,
+                startLine,
+                endLine,
+                type: cls.type,
+                members: cls.members,
+                modifiers: [child.type],
+                ...(enclosing && { enclosingType: enclosing, isNested: true }),
+                ...(docstring && { docstring }),
+            });
+            if (cls.body) visit(cls.body, enclosing ? `${enclosing}.${cls.name}` : cls.name);
+        }
+    };
+    visit(tree.rootNode, null);
+    return classes;
+}
+
+function findFunctions() {
+    return [];
+}
+
+function findClasses(code, parser) {
+    return collectClasses(parseTree(parser, code), code.split('\n'));
+}
+
+function findStateObjects() {
+    return [];
+}
+
+/**
+ * Parse a .proto file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    return {
+        language: 'proto',
+        totalLines: lines.length,
+        functions: [],
+        classes: collectClasses(tree, lines),
+        stateObjects: [],
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find message/enum type references in proto definitions
+ *
+ *   repeated Address addresses = 2;           → Address
+ *   rpc GetUser(GetUserRequest) returns (User) → GetUserRequest, User
+ *   google.protobuf.Timestamp created = 3;     → Timestamp (receiver google.protobuf)
+ *
+ * The enclosing rpc or message stands in for the enclosing function.
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    const scopeStack = [];
+    const SCOPES = { message: 'message_name', rpc: 'rpc_name' };
+
+    traverseTree(tree.rootNode, (node) => {
+        if (SCOPES[node.type]) {
+            const nameNode = declName(node, SCOPES[node.type]);
+            scopeStack.push({
+                name: nameNode ? nameNode.text : '<anonymous>',
+                startLine: node.startPosition.row + 1,
+                endLine: node.endPosition.row + 1,
+            });
+            return true;
+        }
+        if (node.type === 'message_or_enum_type') {
+            const name = typeRefName(node);
+            const qualifier = node.text.replace(/^\./, '').slice(0, -name.length).replace(/\.$/, '');
+            calls.push({
+                name,
+                line: node.startPosition.row + 1,
+                isMethod: false,
+                ...(qualifier && { receiver: qualifier }),
+                enclosingFunction: scopeStack.length > 0 ? { ...scopeStack[scopeStack.length - 1] } : null,
+                uncertain: false,
+            });
+            return false;
+        }
+        return true;
+    }, {
+        onLeave: (node) => {
+            if (SCOPES[node.type]) scopeStack.pop();
+        }
+    });
+    return calls;
+}
+
+/**
+ * `import "common/address.proto";` (and `import public` / `import weak`)
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const imports = [];
+    for (const node of tree.rootNode.namedChildren) {
+        if (node.type !== 'import') continue;
+        const pathNode = node.childForFieldName('path') || namedChildOfType(node, 'string');
+        if (!pathNode) continue;
+        const modifier = node.children.find(c => c.type === 'public' || c.type === 'weak');
+        imports.push({
+            module: pathNode.text.replace(/^["']|["']$/g, ''),
+            names: [],
+            type: modifier ? modifier.type : 'import',
+            line: node.startPosition.row + 1,
+        });
+    }
+    return imports;
+}
+
+/**
+ * Every top-level definition is reachable from the package namespace; the
+ * consumers are generated stubs, matched through generatedNames().
+ */
+function findExportsInCode() {
+    return [];
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+    const DEF_PARENTS = new Set(['message_name', 'enum_name', 'service_name', 'rpc_name']);
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'identifier' || node.text !== name) return true;
+        const parentType = node.parent?.type;
+        let usageType;
+        if (DEF_PARENTS.has(parentType)) usageType = 'definition';
+        else if (parentType === 'message_or_enum_type') usageType = 'reference';
+        else return true;
+        usages.push({
+            line: node.startPosition.row + 1,
+            column: node.startPosition.column,
+            usageType,
+        });
+        return true;
+    });
+    return usages;
+}
+
+/**
+ * Identifiers protoc plugins generate for a definition, beyond its own name:
+ *
+ *   service UserService → UserServiceClient, NewUserServiceClient,
+ *                         RegisterUserServiceServer, UnimplementedUserServiceServer (Go)
+ *                         UserServiceStub, UserServiceServicer,
+ *                         add_UserServiceServicer_to_server (Python)
+ *                         UserServiceGrpc, UserServiceImplBase (Java)
+ *                         UserServiceService (grpc-js)
+ *   rpc GetUser         → getUser (Java, JS), get_user (Rust tonic)
+ *   nested Outer.Inner  → Outer_Inner (Go)
+ */
+function generatedNames(symbol) {
+    const name = symbol.name;
+    if (symbol.type === 'interface') {
+        return [
+            `${name}Client`, `${name}Server`, `New${name}Client`, `Register${name}Server`,
+            `Unimplemented${name}Server`, `${name}Stub`, `${name}Servicer`,
+            `add_${name}Servicer_to_server`, `${name}Grpc`, `${name}ImplBase`, `${name}Service`,
+        ];
+    }
+    if (symbol.type === 'method') {
+        const lower = name[0].toLowerCase() + name.slice(1);
+        const snake = name.replace(/([a-z0-9])([A-Z])/g, '$1_$2').toLowerCase();
+        return [...new Set([lower, snake])].filter(n => n !== name);
+    }
+    if (symbol.enclosingType) {
+        return [`${symbol.enclosingType.replace(/\./g, '_')}_${name}`];
+    }
+    return [];
+}
+
+function getEntryPointKind() {
+    return null;
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    generatedNames,
+    parse
+};
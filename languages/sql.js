@@ -0,0 +1,386 @@
+/**
+ * languages/sql.js - Tree-sitter based SQL parsing (PostgreSQL dialect)
+ *
+ * Handles: CREATE TABLE (columns as fields), CREATE [MATERIALIZED] VIEW,
+ * CREATE FUNCTION / PROCEDURE, CREATE TYPE ... AS ENUM, object references
+ * inside queries (FROM/JOIN/INSERT INTO/REFERENCES), function invocations,
+ * trigger EXECUTE FUNCTION targets, and psql `\i` / `\ir` includes.
+ *
+ * Database objects live in one global namespace — nothing is "exported".
+ * They are consumed by other SQL (views, functions, foreign keys) and by
+ * application code through query strings; the stringReferenced trait makes
+ * deadcode count a name inside another language's string literal as usage.
+ *
+ * Unquoted identifiers fold to lower case (PostgreSQL semantics); quoted
+ * identifiers keep their spelling.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+/** PostgreSQL identifier folding: "Quoted" keeps case, bare folds to lower */
+function foldIdentifier(text) {
+    if (/^".*"$/.test(text)) return text.slice(1, -1).replace(/""/g, '"');
+    if (/^`.*`$/.test(text)) return text.slice(1, -1);
+    return text.toLowerCase();
+}
+
+/** `schema.name` object reference → { name, schema } */
+function objectName(refNode) {
+    if (!refNode) return null;
+    const nameNode = refNode.childForFieldName('name') ||
+        refNode.namedChildren.filter(c => c.type === 'identifier').pop();
+    if (!nameNode) return null;
+    const schemaNode = refNode.childForFieldName('schema');
+    return {
+        name: foldIdentifier(nameNode.text),
+        ...(schemaNode && { schema: foldIdentifier(schemaNode.text) }),
+        node: nameNode,
+    };
+}
+
+function hasKeyword(node, keyword) {
+    for (let i = 0; i < node.childCount; i++) {
+        if (node.child(i).type === keyword) return true;
+    }
+    return false;
+}
+
+/** `-- comment` directly above a statement */
+function extractDocstring(lines, startLine) {
+    let i = startLine - 2;
+    if (i < 0 || !/^\s*--/.test(lines[i])) return null;
+    while (i > 0 && /^\s*--/.test(lines[i - 1])) i--;
+    return lines[i].replace(/^\s*-+\s?/, '').trim() || null;
+}
+
+function functionParams(node) {
+    const args = namedChildOfType(node, 'function_arguments');
+    if (!args) return { params: '', paramsStructured: [] };
+    const paramsStructured = [];
+    for (const arg of args.namedChildren) {
+        if (arg.type !== 'function_argument') continue;
+        const ident = namedChildOfType(arg, 'identifier');
+        const typeText = ident ? arg.text.slice(ident.endIndex - arg.startIndex).trim() : arg.text;
+        paramsStructured.push({
+            name: ident ? ident.text : `$${paramsStructured.length + 1}`,
+            ...(typeText && { type: typeText.replace(/\s+DEFAULT\b.*$/is, '') }),
+            ...(/\bDEFAULT\b|=/i.test(arg.text) && { optional: true }),
+        });
+    }
+    return { params: args.text.replace(/^\(|\)$/g, '').trim(), paramsStructured };
+}
+
+function collectFunctions(tree, lines) {
+    const functions = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type !== 'create_function' && node.type !== 'create_procedure') return true;
+        const ref = objectName(namedChildOfType(node, 'object_reference'));
+        if (!ref) return false;
+        const { startLine, endLine } = nodeToLocation(node, lines);
+        const { params, paramsStructured } = functionParams(node);
+        const returns = node.text.match(/\bRETURNS\s+((?:SETOF\s+)?[\w.]+(?:\s*\[\])?|TABLE\s*\([^)]*\))/i);
+        const docstring = extractDocstring(lines, startLine);
+        functions.push({
+            name: ref.name,
+            params,
+            paramsStructured,
+            startLine,
+            endLine,
+            modifiers: [node.type === 'create_procedure' ? 'procedure' : 'function'],
+            ...(ref.schema && { enclosingType: ref.schema }),
+            ...(returns && { returnType: returns[1] }),
+            ...(/\bRETURNS\s+TRIGGER\b/i.test(node.text) && { isTriggerFunction: true }),
+            ...(docstring && { docstring }),
+        });
+        return false;
+    });
+    return functions;
+}
+
+function tableColumns(node, lines) {
+    const members = [];
+    const defs = namedChildOfType(node, 'column_definitions');
+    if (!defs) return members;
+    for (const col of defs.namedChildren) {
+        if (col.type !== 'column_definition') continue;
+        const nameNode = col.childForFieldName('name') || namedChildOfType(col, 'identifier');
+        if (!nameNode) continue;
+        const typeNode = col.childForFieldName('type');
+        const { startLine, endLine } = nodeToLocation(col, lines);
+        members.push({
+            name: foldIdentifier(nameNode.text),
+            startLine,
+            endLine,
+            memberType: 'field',
+            ...(typeNode && { fieldType: typeNode.text }),
+        });
+    }
+    return members;
+}
+
+const CLASS_NODE_TYPES = {
+    create_table: 'table',
+    create_view: 'view',
+    create_materialized_view: 'view',
+};
+
+function collectClasses(tree, lines) {
+    const classes = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        let kind = CLASS_NODE_TYPES[node.type];
+        if (!kind && node.type === 'create_type' && /\bAS\s+ENUM\b/i.test(node.text)) kind = 'enum';
+        if (!kind) return true;
+        const ref = objectName(namedChildOfType(node, 'object_reference'));
+        if (!ref) return false;
+        const { startLine, endLine } = nodeToLocation(node, lines);
+        const docstring = extractDocstring(lines, startLine);
+        const modifiers = [];
+        if (node.type === 'create_materialized_view') modifiers.push('materialized');
+        if (hasKeyword(node, 'keyword_temporary') || hasKeyword(node, 'keyword_temp')) modifiers.push('temporary');
+        classes.push({
+            name: ref.name,
+            startLine,
+            endLine,
+            type: kind,
+            members: kind === 'table' ? tableColumns(node, lines) : [],
+            modifiers,
+            ...(ref.schema && { enclosingType: ref.schema }),
+            ...(docstring && { docstring }),
+        });
+        return false;
+    });
+    return classes;
+}
+
+function findFunctions(code, parser) {
+    return collectFunctions(parseTree(parser, code), code.split('\n'));
+}
+
+function findClasses(code, parser) {
+    return collectClasses(parseTree(parser, code), code.split('\n'));
+}
+
+function findStateObjects(code, parser) {
+    return [];
+}
+
+/**
+ * Parse a SQL file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    return {
+        language: 'sql',
+        totalLines: lines.length,
+        functions: collectFunctions(tree, lines),
+        classes: collectClasses(tree, lines),
+        stateObjects: [],
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find object references in SQL
+ *
+ *   calc_total(o.id)                  → call to calc_total
+ *   FROM orders o JOIN customers c    → references to orders, customers
+ *   INSERT INTO audit_log ...         → reference to audit_log
+ *   REFERENCES customers(id)          → reference (foreign key)
+ *   EXECUTE FUNCTION touch_updated()  → call (trigger target)
+ *
+ * Relation references are recorded as calls so table/view liveness flows
+ * through the callee index like function liveness. DDL that only restates
+ * an object (ALTER TABLE, CREATE INDEX ... ON, GRANT) is not a reference.
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+const DECLARATION_ONLY_NODE = /^(alter_|drop_|create_index$|comment_|grant$|revoke$)/;
+
+function findCallsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    const functionStack = [];
+    const definingNodes = new Set(['create_table', 'create_view', 'create_materialized_view',
+        'create_function', 'create_procedure', 'create_type']);
+
+    const getCurrentEnclosingFunction = () => {
+        return functionStack.length > 0
+            ? { ...functionStack[functionStack.length - 1] }
+            : null;
+    };
+
+    const pushRef = (refNode, extra) => {
+        const ref = objectName(refNode);
+        if (!ref) return;
+        calls.push({
+            name: ref.name,
+            line: ref.node.startPosition.row + 1,
+            isMethod: false,
+            ...(ref.schema && { receiver: ref.schema }),
+            enclosingFunction: getCurrentEnclosingFunction(),
+            uncertain: false,
+            ...extra,
+        });
+    };
+
+    traverseTree(tree.rootNode, (node) => {
+        // ALTER / DROP / CREATE INDEX / COMMENT ON / GRANT restate an object
+        // without reading it — not a reference
+        if (DECLARATION_ONLY_NODE.test(node.type)) return false;
+        if (node.type === 'create_function' || node.type === 'create_procedure' ||
+            node.type === 'create_view' || node.type === 'create_materialized_view') {
+            const ref = objectName(namedChildOfType(node, 'object_reference'));
+            functionStack.push({
+                name: ref ? ref.name : '<anonymous>',
+                startLine: node.startPosition.row + 1,
+                endLine: node.endPosition.row + 1,
+            });
+            return true;
+        }
+        if (node.type === 'invocation') {
+            const argsNode = node.childForFieldName('parameters') || namedChildOfType(node, 'parameters');
+            pushRef(namedChildOfType(node, 'object_reference'), {
+                argCount: argsNode ? argsNode.namedChildCount : 0,
+            });
+            return true;
+        }
+        if (node.type === 'object_reference') {
+            // The defined object's own name is a declaration, not a reference
+            if (definingNodes.has(node.parent?.type) &&
+                node.parent.namedChildren.find(c => c.type === 'object_reference')?.startIndex === node.startIndex) {
+                return false;
+            }
+            if (node.parent?.type === 'invocation') return false;
+            pushRef(node, { isFunctionReference: node.parent?.type === 'create_trigger' });
+            return false;
+        }
+        return true;
+    }, {
+        onLeave: (node) => {
+            if (node.type === 'create_function' || node.type === 'create_procedure' ||
+                node.type === 'create_view' || node.type === 'create_materialized_view') {
+                functionStack.pop();
+            }
+        }
+    });
+
+    return calls;
+}
+
+/**
+ * psql includes: `\i schema/tables.sql`, `\ir ../functions.sql`
+ * (`\ir` is relative to the including file; `\i` to the working directory,
+ * which for migration runners is the project root).
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code) {
+    const imports = [];
+    const lines = code.split('\n');
+    for (let i = 0; i < lines.length; i++) {
+        const m = lines[i].match(/^\s*\\(i|ir|include|include_relative)\s+(['"]?)([^'"\s]+)\2/);
+        if (!m) continue;
+        const relative = m[1] === 'ir' || m[1] === 'include_relative';
+        const target = relative && !m[3].startsWith('.') ? `./${m[3]}` : m[3];
+        imports.push({ module: target, names: [], type: 'include', line: i + 1 });
+    }
+    return imports;
+}
+
+/**
+ * Database objects share one global namespace — there is no export surface.
+ */
+function findExportsInCode() {
+    return [];
+}
+
+/**
+ * Find all usages of a name in code using AST (identifiers fold like the
+ * database does: bare `Orders` is `orders`)
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+
+    const visit = (node) => {
+        if (node.type !== 'identifier' || foldIdentifier(node.text) !== name) return true;
+        const line = node.startPosition.row + 1;
+        const column = node.startPosition.column;
+        const ref = node.parent?.type === 'object_reference' ? node.parent : null;
+        const holder = ref?.parent;
+        let usageType = 'reference';
+        if (holder && /^create_/.test(holder.type) &&
+            holder.namedChildren.find(c => c.type === 'object_reference')?.startIndex === ref.startIndex) {
+            usageType = 'definition';
+        } else if (holder?.type === 'invocation' || holder?.type === 'create_trigger') {
+            usageType = 'call';
+        } else if (node.parent?.type === 'column_definition') {
+            usageType = 'definition';
+        }
+        usages.push({ line, column, usageType });
+        return true;
+    };
+    // Case folding defeats the text pre-filter of visitNameNodes — walk everything
+    traverseTree(tree.rootNode, visit);
+
+    return usages;
+}
+
+/**
+ * Classify a SQL entry point:
+ * - 'framework': trigger functions (RETURNS trigger) — the database invokes
+ *                them through CREATE TRIGGER, which may live in a migration
+ *                UCN does not see.
+ */
+function getEntryPointKind(symbol) {
+    if (symbol.isTriggerFunction || /\bRETURNS\s+TRIGGER\b/i.test(symbol.returnType || '')) return 'framework';
+    if (/^trigger$/i.test(symbol.returnType || '')) return 'framework';
+    return null;
+}
+
+/**
+ * Check if a symbol is a SQL-convention entry point.
+ */
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    foldIdentifier,
+    parse
+};
@@ -0,0 +1,385 @@
+/**
+ * languages/svelte.js - Svelte component support
+ *
+ * Script blocks (instance and `context="module"`) are extracted exactly as
+ * for Vue SFCs (vue.js extractScript) and analyzed by javascript.js. Svelte
+ * markup is not HTML-shaped enough for tree-sitter-html (`{#each}` blocks,
+ * `on:click={…}` with spaces in unquoted values), so template references
+ * are scanned from the markup text with script and style blanked out:
+ *
+ * - Component tags (`<Button>`, `<svelte:component this={X}>`) and
+ *   directive names (`use:tooltip`, `transition:fade`, `bind:value`).
+ * - `{…}` expressions in text, blocks and attributes; `{#each}`, `{:then}`,
+ *   `{#snippet}` and `let:` bindings are block-scoped locals.
+ * - Store auto-subscriptions: `$count` anywhere reads the store `count`.
+ *
+ * Reactive statements: `$: total = a + b` implicitly declares `total`; it is
+ * indexed as a state symbol with the 'reactive' modifier.
+ */
+
+const { getLanguageModule } = require('./index');
+const { extractScript, expressionReferences } = require('./vue');
+
+// Svelte 5 runes look like store subscriptions but read no store
+const RUNES = new Set(['$state', '$derived', '$effect', '$props', '$bindable', '$inspect', '$host']);
+
+/** Blank script/style blocks and comments, preserving offsets and newlines */
+function markupText(code) {
+    const blank = m => m.replace(/[^\n]/g, ' ');
+    return code
+        .replace(/<(script|style)\b[^>]*>[\s\S]*?<\/\1\s*>/gi, blank)
+        .replace(/<!--[\s\S]*?-->/g, blank);
+}
+
+/** `$count` → ` count` so a store read resolves to the store's own name */
+function unwrapStoreReads(expr) {
+    return expr.replace(/(?<![\w$])\$[A-Za-z_][\w]*/g, m => RUNES.has(m) ? m : ' ' + m.slice(1));
+}
+
+/** Names bound by `item, i (key)` / `{ id, name }` / `[a, b]` patterns */
+function bindingNames(pattern) {
+    return (pattern.replace(/\([^)]*\)\s*$/, '').match(/[A-Za-z_$][\w$]*/g) || []);
+}
+
+/** End offset of the `{…}` expression starting at `start` (the `{`), string-aware */
+function matchBrace(text, start) {
+    let depth = 0;
+    for (let i = start; i < text.length; i++) {
+        const ch = text[i];
+        if (ch === '"' || ch === "'" || ch === '`') {
+            const close = text.indexOf(ch, i + 1);
+            if (close === -1) return -1;
+            i = close;
+            continue;
+        }
+        if (ch === '{') depth++;
+        else if (ch === '}' && --depth === 0) return i;
+    }
+    return -1;
+}
+
+/**
+ * Extract references from the markup (grammar-independent).
+ *
+ *   <Button on:click={save} use:tooltip />      → Button, save, tooltip
+ *   {#each $todos as todo}{format(todo)}{/each} → todos, format
+ *
+ * @param {string} code - Raw .svelte source
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function markupReferences(code) {
+    const text = markupText(code);
+    const lineStarts = [0];
+    for (let i = 0; i < code.length; i++) if (code[i] === '\n') lineStarts.push(i + 1);
+    const lineOf = (offset) => {
+        let lo = 0, hi = lineStarts.length - 1;
+        while (lo < hi) {
+            const mid = (lo + hi + 1) >> 1;
+            if (lineStarts[mid] <= offset) lo = mid; else hi = mid - 1;
+        }
+        return lo + 1;
+    };
+    const calls = [];
+    const push = (name, offset, extra) => calls.push({
+        name,
+        line: lineOf(offset),
+        isMethod: false,
+        enclosingFunction: null,
+        uncertain: false,
+        ...extra,
+    });
+    const scopes = [{ closer: null, names: new Set() }];
+    const locals = () => new Set(scopes.flatMap(s => [...s.names]));
+    const pushExpression = (expr, offset, isHandler) => {
+        const source = unwrapStoreReads(expr);
+        if (isHandler && /^\s*[A-Za-z_$][\w$]*\s*$/.test(source)) {
+            const name = source.trim();
+            if (!locals().has(name)) {
+                push(name, offset + source.indexOf(name), { isFunctionReference: true, isPotentialCallback: true });
+            }
+            return;
+        }
+        for (const ref of expressionReferences(source, locals())) {
+            push(ref.name, offset + ref.offset, ref.isCall ? {} : { isFunctionReference: true });
+        }
+    };
+
+    // One pass in document order: tags open/close `let:` scopes, `{#…}`
+    // blocks open/close their bindings' scopes
+    const handleTag = (whole, tagName, attrs, offset) => {
+        const attrsOffset = offset + 1 + tagName.length;
+        if (/^[A-Z]/.test(tagName)) {
+            push(tagName.split('.').pop(), offset + 1, { isJsxComponent: true, isFunctionReference: true });
+        }
+        for (const d of attrs.matchAll(/\b(use|transition|in|out|animate):([A-Za-z_$][\w$]*)/g)) {
+            push(d[2], attrsOffset + d.index + d[1].length + 1, { isFunctionReference: true });
+        }
+        // Shorthand directives name the variable itself: bind:value, class:active
+        for (const d of attrs.matchAll(/\b(bind|class):([A-Za-z_$][\w$]*)(?=[\s/>]|$)/g)) {
+            push(d[2], attrsOffset + d.index + d[1].length + 1, { isFunctionReference: true });
+        }
+        for (let i = attrs.indexOf('{'); i !== -1; i = attrs.indexOf('{', i + 1)) {
+            const end = matchBrace(attrs, i);
+            if (end === -1) break;
+            const isHandler = /\bon:?[\w|-]+=\s*$/.test(attrs.slice(0, i));
+            pushExpression(attrs.slice(i + 1, end), attrsOffset + i + 1, isHandler);
+            i = end;
+        }
+        // let:item binds a local for the element's children
+        const lets = [...attrs.matchAll(/\blet:([A-Za-z_$][\w$]*)/g)].map(a => a[1]);
+        if (lets.length > 0 && !whole.endsWith('/>')) scopes.push({ closer: tagName, names: new Set(lets) });
+    };
+    const handleBlock = (body, start) => {
+        const block = body.match(/^\s*([#:/@])(\w+)\s*/);
+        if (!block) {
+            pushExpression(body, start, false);
+            return;
+        }
+        const [prefix, sigil, keyword] = block;
+        const rest = body.slice(prefix.length);
+        const restOffset = start + prefix.length;
+        const top = scopes[scopes.length - 1];
+        if (sigil === '/') {
+            if (top.closer === `#${keyword}`) scopes.pop();
+        } else if (sigil === '#' && keyword === 'each') {
+            const parts = rest.match(/^([\s\S]*?)\s+as\s+([\s\S]*)$/);
+            pushExpression(parts ? parts[1] : rest, restOffset, false);
+            scopes.push({ closer: '#each', names: new Set(parts ? bindingNames(parts[2]) : []) });
+            const key = parts && parts[2].match(/\(([^)]*)\)\s*$/);
+            if (key) pushExpression(key[1], restOffset + rest.lastIndexOf(key[1]), false);
+        } else if (sigil === '#' && keyword === 'await') {
+            const parts = rest.match(/^([\s\S]*?)(?:\s+(?:then|catch)\s+([\s\S]*))?$/);
+            pushExpression(parts[1], restOffset, false);
+            scopes.push({ closer: '#await', names: new Set(parts[2] ? bindingNames(parts[2]) : []) });
+        } else if (sigil === '#' && keyword === 'snippet') {
+            const params = rest.match(/\(([\s\S]*)\)/);
+            scopes.push({ closer: '#snippet', names: new Set(params ? bindingNames(params[1]) : []) });
+        } else if (sigil === ':' && (keyword === 'then' || keyword === 'catch')) {
+            for (const n of bindingNames(rest)) top.names.add(n);
+        } else if (sigil === '@' && keyword === 'const') {
+            const parts = rest.match(/^([\s\S]*?)=([\s\S]*)$/);
+            if (parts) {
+                for (const n of bindingNames(parts[1])) top.names.add(n);
+                pushExpression(parts[2], restOffset + parts[1].length + 1, false);
+            }
+        } else {
+            // {#if x}, {:else if x}, {#key x}, {@html x}, {@render s()}, {@debug x}
+            pushExpression(rest.replace(/^if\b/, '  '), restOffset, false);
+        }
+    };
+
+    const openTag = /<([A-Za-z][\w.:-]*)((?:[^>"'{]|"[^"]*"|'[^']*'|\{[^}]*\})*)>/y;
+    const closeTag = /<\/([A-Za-z][\w.:-]*)\s*>/y;
+    let i = 0;
+    while (i < text.length) {
+        if (text[i] === '<') {
+            openTag.lastIndex = i;
+            const open = openTag.exec(text);
+            if (open) {
+                handleTag(open[0], open[1], open[2], i);
+                i += open[0].length;
+                continue;
+            }
+            closeTag.lastIndex = i;
+            const close = closeTag.exec(text);
+            if (close) {
+                if (scopes[scopes.length - 1].closer === close[1]) scopes.pop();
+                i += close[0].length;
+                continue;
+            }
+        } else if (text[i] === '{') {
+            const end = matchBrace(text, i);
+            if (end === -1) break;
+            handleBlock(text.slice(i + 1, end), i + 1);
+            i = end + 1;
+            continue;
+        }
+        i++;
+    }
+
+    return calls;
+}
+
+/**
+ * Extract markup references plus store subscriptions in script blocks:
+ *
+ *   $: doubled = $count * 2                     → count
+ *
+ * @param {string} code - Raw .svelte source
+ * @param {object} htmlParser - tree-sitter parser configured for HTML
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function extractTemplateCalls(code, htmlParser) {
+    const calls = markupReferences(code);
+    const script = extractScript(code, htmlParser);
+    if (script) {
+        const { safeParse } = require('./index');
+        const tree = safeParse(script.jsParser, script.virtualJS);
+        const visit = (node) => {
+            if (node.type === 'identifier' && /^\$[A-Za-z_]/.test(node.text) && !RUNES.has(node.text)) {
+                calls.push({
+                    name: node.text.slice(1),
+                    line: node.startPosition.row + 1,
+                    isMethod: false,
+                    isFunctionReference: true,
+                    enclosingFunction: null,
+                    uncertain: false,
+                });
+            }
+            for (const child of node.namedChildren) visit(child);
+        };
+        visit(tree.rootNode);
+    }
+    return calls;
+}
+
+/**
+ * `$: name = expr` reactive declarations. The label is Svelte's, the
+ * assignment declares `name` at component scope.
+ */
+function reactiveDeclarations(tree) {
+    const decls = [];
+    for (const node of tree.rootNode.namedChildren) {
+        if (node.type !== 'labeled_statement') continue;
+        const label = node.childForFieldName('label');
+        const body = node.childForFieldName('body');
+        if (label?.text !== '$' || body?.type !== 'expression_statement') continue;
+        const expr = body.namedChildren[0];
+        if (expr?.type !== 'assignment_expression') continue;
+        const left = expr.childForFieldName('left');
+        const names = left?.type === 'identifier' ? [left.text] : bindingNames(left?.text || '');
+        for (const name of names) {
+            decls.push({
+                name,
+                startLine: node.startPosition.row + 1,
+                endLine: node.endPosition.row + 1,
+                modifiers: ['reactive'],
+            });
+        }
+    }
+    return decls;
+}
+
+// ── Exported language module interface ──────────────────────────────────────
+
+function parse(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) {
+        return {
+            language: 'svelte',
+            totalLines: code.split('\n').length,
+            functions: [],
+            classes: [],
+            stateObjects: [],
+            imports: [],
+            exports: []
+        };
+    }
+    const { safeParse } = require('./index');
+    const jsResult = result.jsModule.parse(result.virtualJS, result.jsParser);
+    const reactive = reactiveDeclarations(safeParse(result.jsParser, result.virtualJS));
+    jsResult.stateObjects = jsResult.stateObjects.concat(reactive).sort((a, b) => a.startLine - b.startLine);
+    jsResult.language = 'svelte';
+    jsResult.totalLines = code.split('\n').length;
+    return jsResult;
+}
+
+function findFunctions(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findFunctions(result.virtualJS, result.jsParser);
+}
+
+function findClasses(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findClasses(result.virtualJS, result.jsParser);
+}
+
+function findStateObjects(code, parser) {
+    return parse(code, parser).stateObjects;
+}
+
+function findCallsInCode(code, parser) {
+    const result = extractScript(code, parser);
+    const scriptCalls = result ? result.jsModule.findCallsInCode(result.virtualJS, result.jsParser) : [];
+    return scriptCalls.concat(extractTemplateCalls(code, parser));
+}
+
+function findCallbackUsages(code, name, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findCallbackUsages(result.virtualJS, name, result.jsParser);
+}
+
+function findReExports(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findReExports(result.virtualJS, result.jsParser);
+}
+
+function findImportsInCode(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findImportsInCode(result.virtualJS, result.jsParser);
+}
+
+/**
+ * `export let title` is a prop, not a module export — importers get the
+ * component's default export only. Exports of `context="module"` scripts
+ * are real named exports and pass through.
+ */
+function findExportsInCode(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    const moduleScripts = result.blocks.scripts.filter(s => s.attrs.context === 'module' || 'module' in s.attrs);
+    if (moduleScripts.length === 0) return [];
+    const inModuleScript = (line) => moduleScripts.some(s =>
+        line > s.startRow && line <= s.startRow + s.text.split('\n').length);
+    return result.jsModule.findExportsInCode(result.virtualJS, result.jsParser)
+        .filter(e => inModuleScript(e.line));
+}
+
+function findUsagesInCode(code, name, parser) {
+    const result = extractScript(code, parser);
+    const scriptUsages = result
+        ? result.jsModule.findUsagesInCode(result.virtualJS, name, result.jsParser)
+        : [];
+    const templateUsages = extractTemplateCalls(code, parser)
+        .filter(c => c.name === name)
+        .map(c => ({ line: c.line, column: 0, usageType: c.isFunctionReference && !c.isJsxComponent ? 'reference' : 'call' }));
+    if (templateUsages.length === 0) return scriptUsages;
+    return scriptUsages.concat(templateUsages);
+}
+
+/**
+ * Classify a Svelte symbol as a runtime entry point.
+ * Script symbols are classified by the JS predicate.
+ */
+function getEntryPointKind(symbol) {
+    return getLanguageModule('javascript').getEntryPointKind(symbol);
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    parse,
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findCallbackUsages,
+    findReExports,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    // Exported for deadcode and testing
+    extractScript,
+    extractTemplateCalls,
+    markupReferences,
+    unwrapStoreReads,
+};
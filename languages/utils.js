@@ -360,6 +360,28 @@ function extractGoDocstring(codeOrLines, startLine) {
     return null;
 }
 
+/**
+ * Extract the deprecation notice from a Go doc comment: the paragraph that
+ * starts with "Deprecated: " (the convention gopls and staticcheck follow)
+ * @param {string|string[]} codeOrLines - Source code or pre-split lines
+ * @param {number} startLine - 1-indexed line number of the declaration
+ * @returns {string|null} The notice after "Deprecated:" ('' when bare), or null
+ */
+function extractGoDeprecation(codeOrLines, startLine) {
+    const lines = Array.isArray(codeOrLines) ? codeOrLines : codeOrLines.split('\n');
+    let i = startLine - 2;
+    const block = [];
+    while (i >= 0 && lines[i].trim().startsWith('//')) {
+        block.unshift(lines[i].trim().replace(/^\/\/\s?/, ''));
+        i--;
+    }
+    const at = block.findIndex(l => /^Deprecated:/.test(l));
+    if (at < 0) return null;
+    const paragraph = [block[at].replace(/^Deprecated:\s*/, '')];
+    for (let j = at + 1; j < block.length && block[j].trim() !== ''; j++) paragraph.push(block[j].trim());
+    return paragraph.join(' ').trim();
+}
+
 /**
  * Extract Rust documentation comment from code
  * Looks for /// or //! comments directly above the item
@@ -989,6 +1011,7 @@ module.exports = {
     extractJSDocstring,
     extractPythonDocstring,
     extractGoDocstring,
+    extractGoDeprecation,
     extractRustDocstring,
     extractJavaDocstring,
     paramTypesFromStructured,
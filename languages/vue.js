@@ -0,0 +1,607 @@
+/**
+ * languages/vue.js - Vue single-file component support
+ *
+ * Parses the SFC with tree-sitter-html, which sees three kinds of top-level
+ * block: <script> (and <script setup>), <template>, and <style>. Script
+ * blocks become a line-preserving virtual JS/TS string (same approach as
+ * html.js) analyzed by javascript.js with the parser `lang` selects.
+ *
+ * Vue-specific surface layered on top:
+ * - Template references: component tags (`<UserCard>` and kebab-case
+ *   `<user-card>`, both recorded as UserCard), directive and mustache
+ *   expressions (`v-if`, `:prop`, `@event`, `#slot`, `{{ }}`), and
+ *   `v-bind(x)` inside <style>. All are recorded as calls, so script
+ *   functions, computeds and props used only by the template stay alive.
+ * - Options API: functions under `methods` and `computed` are indexed.
+ * - Props (`defineProps`, options `props`) are indexed as fields with the
+ *   'prop' modifier; the auditFields trait puts them in the deadcode audit.
+ *
+ * A component is consumed by file (import, route, tag name), which a
+ * per-file parse cannot see; deadcode reports unreferenced .vue files from
+ * the project graph (the componentFiles trait).
+ */
+
+const { getParser, getLanguageModule } = require('./index');
+const { buildVirtualJSContent } = require('./html');
+
+// Built-in components and elements Vue resolves itself
+const BUILTIN_TAGS = new Set([
+    'component', 'transition', 'transition-group', 'keep-alive', 'teleport',
+    'suspense', 'slot', 'template',
+]);
+
+const EXPRESSION_KEYWORDS = new Set([
+    'true', 'false', 'null', 'undefined', 'this', 'in', 'of', 'typeof',
+    'instanceof', 'new', 'void', 'delete', 'if', 'else', 'return', 'function',
+    'const', 'let', 'var', 'await', 'async', 'NaN', 'Infinity',
+]);
+
+function findChild(node, type) {
+    return node.children.find(c => c.type === type) || null;
+}
+
+function attributeValue(attr) {
+    const valueNode = attr.children.find(c =>
+        c.type === 'quoted_attribute_value' || c.type === 'attribute_value');
+    if (!valueNode) return null;
+    const inner = valueNode.type === 'quoted_attribute_value'
+        ? valueNode.children.find(c => c.type === 'attribute_value')
+        : valueNode;
+    return inner || null;
+}
+
+function startTagAttributes(startTag) {
+    const attrs = {};
+    for (const attr of startTag.children) {
+        if (attr.type !== 'attribute') continue;
+        const nameNode = findChild(attr, 'attribute_name');
+        if (!nameNode) continue;
+        const valueNode = attributeValue(attr);
+        attrs[nameNode.text.toLowerCase()] = valueNode ? valueNode.text : '';
+    }
+    return attrs;
+}
+
+/**
+ * Split an SFC into its top-level blocks.
+ * Scripts with `src=` are external and skipped.
+ *
+ * @param {string} code - Raw .vue source
+ * @param {object} htmlParser - tree-sitter parser configured for HTML
+ * @returns {{scripts: Array<{text: string, startRow: number, startCol: number, setup: boolean, lang: string, attrs: object}>,
+ *            styles: Array<{text: string, startRow: number}>, template: object|null, hasError: boolean}}
+ */
+function extractBlocks(code, htmlParser) {
+    const { safeParse, getParseOptions } = require('./index');
+    const tree = safeParse(htmlParser, code, undefined, getParseOptions(code.length));
+    const blocks = { scripts: [], styles: [], template: null, hasError: !!tree.rootNode.hasError };
+    for (const node of tree.rootNode.namedChildren) {
+        const startTag = findChild(node, 'start_tag');
+        const rawText = findChild(node, 'raw_text');
+        if (node.type === 'script_element') {
+            if (!startTag || !rawText || !rawText.text) continue;
+            const attrs = startTagAttributes(startTag);
+            if ('src' in attrs) continue;
+            blocks.scripts.push({
+                text: rawText.text,
+                startRow: rawText.startPosition.row,
+                startCol: rawText.startPosition.column,
+                setup: 'setup' in attrs,
+                lang: (attrs.lang || 'js').toLowerCase(),
+                attrs,
+            });
+        } else if (node.type === 'style_element') {
+            if (rawText) blocks.styles.push({ text: rawText.text, startRow: rawText.startPosition.row });
+        } else if (node.type === 'element' && startTag &&
+            findChild(startTag, 'tag_name')?.text.toLowerCase() === 'template' && !blocks.template) {
+            blocks.template = node;
+        }
+    }
+    return blocks;
+}
+
+/** The parser the script blocks need: `lang="ts"` / `lang="tsx"` or plain JS */
+function scriptLanguage(scripts) {
+    if (scripts.some(s => s.lang === 'tsx')) return 'tsx';
+    if (scripts.some(s => s.lang === 'ts')) return 'typescript';
+    return 'javascript';
+}
+
+/**
+ * Build the virtual script for an SFC. Returns null without script blocks.
+ *
+ * @param {string} code - Raw .vue source
+ * @param {object} htmlParser - tree-sitter parser configured for HTML
+ * @returns {{virtualJS: string, scriptLang: string, jsParser: object, jsModule: object, blocks: object}|null}
+ */
+function extractScript(code, htmlParser) {
+    const blocks = extractBlocks(code, htmlParser);
+    if (blocks.scripts.length === 0) return null;
+    const scriptLang = scriptLanguage(blocks.scripts);
+    return {
+        virtualJS: buildVirtualJSContent(code, blocks.scripts),
+        scriptLang,
+        jsParser: getParser(scriptLang),
+        jsModule: getLanguageModule(scriptLang),
+        blocks,
+    };
+}
+
+/** `user-card` / `UserCard` → UserCard; plain HTML elements → null */
+function componentName(tagName) {
+    if (!tagName || BUILTIN_TAGS.has(tagName.toLowerCase())) return null;
+    if (/^[A-Z]/.test(tagName)) return tagName.split('.').pop();
+    if (!tagName.includes('-')) return null;
+    return tagName.split('-').filter(Boolean).map(p => p[0].toUpperCase() + p.slice(1)).join('');
+}
+
+/** Names bound by a v-for alias or slot-props pattern: `(item, i)`, `{ row, index }` */
+function patternNames(pattern) {
+    return (pattern.match(/[A-Za-z_$][\w$]*/g) || []).filter(n => !EXPRESSION_KEYWORDS.has(n));
+}
+
+/**
+ * Identifiers a template expression reads from the component scope.
+ * Member properties (`a.b` → only a), object keys, string contents,
+ * `$`-prefixed instance properties and locals are skipped.
+ *
+ * @returns {Array<{name: string, offset: number, isCall: boolean}>}
+ */
+function expressionReferences(expr, locals) {
+    // Blank string literals, keeping offsets
+    const text = expr.replace(/(['"`])(?:\\.|(?!\1)[^\\])*\1/g, m => ' '.repeat(m.length));
+    const arrowLocals = new Set();
+    for (const m of text.matchAll(/(\([^()]*\)|[A-Za-z_$][\w$]*)\s*=>/g)) {
+        for (const n of patternNames(m[1])) arrowLocals.add(n);
+    }
+    const refs = [];
+    const re = /[A-Za-z_$][\w$]*/g;
+    let m;
+    while ((m = re.exec(text)) !== null) {
+        const name = m[0];
+        const before = text.slice(0, m.index).trimEnd();
+        const after = text.slice(m.index + name.length);
+        if (before.endsWith('.') && !before.endsWith('...')) continue;
+        if (/^\s*:(?!:)/.test(after) && /[{,]$/.test(before)) continue;
+        if (name.startsWith('$') || EXPRESSION_KEYWORDS.has(name)) continue;
+        if (locals.has(name) || arrowLocals.has(name)) continue;
+        if (/^\d/.test(name)) continue;
+        refs.push({ name, offset: m.index, isCall: /^\s*\(/.test(after) });
+    }
+    return refs;
+}
+
+function lineAt(node, text, offset) {
+    let line = node.startPosition.row + 1;
+    for (let i = 0; i < offset; i++) if (text[i] === '\n') line++;
+    return line;
+}
+
+/**
+ * Extract references from the <template> and <style> blocks.
+ *
+ *   <user-card :user="current" @select="onSelect" /> → UserCard, current, onSelect
+ *   <li v-for="item in items">{{ format(item) }}</li> → items, format
+ *   .box { color: v-bind(themeColor) }                → themeColor
+ *
+ * @param {string} code - Raw .vue source
+ * @param {object} htmlParser - tree-sitter parser configured for HTML
+ * @param {object} [blocks] - Pre-extracted blocks
+ * @returns {Array<{name: string, line: number, isMethod: boolean}>}
+ */
+function extractTemplateCalls(code, htmlParser, blocks) {
+    blocks = blocks || extractBlocks(code, htmlParser);
+    const calls = [];
+    const push = (name, line, extra) => calls.push({
+        name,
+        line,
+        isMethod: false,
+        enclosingFunction: null,
+        uncertain: false,
+        ...extra,
+    });
+    const pushExpression = (valueNode, locals, isHandler) => {
+        const text = valueNode.text;
+        // `@click="onSelect"` / `@click="store.save"` pass the function itself
+        if (isHandler && /^\s*[A-Za-z_$][\w$]*(\.[A-Za-z_$][\w$]*)*\s*$/.test(text)) {
+            const name = text.trim().split('.')[0];
+            if (!name.startsWith('$') && !locals.has(name)) {
+                push(name, valueNode.startPosition.row + 1, text.includes('.') ? {} : {
+                    isFunctionReference: true,
+                    isPotentialCallback: true,
+                });
+            }
+            return;
+        }
+        for (const ref of expressionReferences(text, locals)) {
+            push(ref.name, lineAt(valueNode, text, ref.offset), ref.isCall ? {} : { isFunctionReference: true });
+        }
+    };
+
+    const visit = (node, locals) => {
+        if (node.type === 'script_element' || node.type === 'style_element') return;
+        if (node.type === 'text') {
+            const text = node.text;
+            for (const m of text.matchAll(/\{\{([\s\S]*?)\}\}/g)) {
+                const offset = m.index + 2;
+                for (const ref of expressionReferences(m[1], locals)) {
+                    push(ref.name, lineAt(node, text, offset + ref.offset),
+                        ref.isCall ? {} : { isFunctionReference: true });
+                }
+            }
+            return;
+        }
+        if (node.type === 'element') {
+            const tag = findChild(node, 'start_tag') || findChild(node, 'self_closing_tag');
+            let scope = locals;
+            if (tag) {
+                const tagName = findChild(tag, 'tag_name');
+                const component = tagName && componentName(tagName.text);
+                if (component) {
+                    push(component, tagName.startPosition.row + 1, { isJsxComponent: true, isFunctionReference: true });
+                }
+                // Bindings introduced on this element are visible to its own
+                // attributes and its children
+                const deferred = [];
+                for (const attr of tag.children) {
+                    if (attr.type !== 'attribute') continue;
+                    const attrName = findChild(attr, 'attribute_name')?.text || '';
+                    const valueNode = attributeValue(attr);
+                    if (!valueNode) continue;
+                    if (attrName === 'v-for') {
+                        const m = valueNode.text.match(/^([\s\S]*?)\s+(?:in|of)\s+([\s\S]*)$/);
+                        if (!m) continue;
+                        scope = new Set([...scope, ...patternNames(m[1])]);
+                        for (const ref of expressionReferences(m[2], locals)) {
+                            push(ref.name, lineAt(valueNode, valueNode.text, m[0].length - m[2].length + ref.offset),
+                                ref.isCall ? {} : { isFunctionReference: true });
+                        }
+                    } else if (/^(v-slot|#)/.test(attrName) || attrName === 'slot-scope') {
+                        scope = new Set([...scope, ...patternNames(valueNode.text)]);
+                    } else if (/^(v-|:|@)/.test(attrName)) {
+                        deferred.push({ valueNode, isHandler: /^(@|v-on:)/.test(attrName) });
+                    }
+                }
+                for (const { valueNode, isHandler } of deferred) pushExpression(valueNode, scope, isHandler);
+            }
+            for (const child of node.children) visit(child, scope);
+            return;
+        }
+        for (const child of node.children) visit(child, locals);
+    };
+    if (blocks.template) {
+        for (const child of blocks.template.children) visit(child, new Set());
+    }
+
+    for (const style of blocks.styles) {
+        for (const m of style.text.matchAll(/v-bind\(\s*['"]?([A-Za-z_$][\w$]*)/g)) {
+            const line = style.startRow + 1 + (style.text.slice(0, m.index).match(/\n/g) || []).length;
+            push(m[1], line, { isFunctionReference: true });
+        }
+    }
+    return calls;
+}
+
+// ── Component options and props ──────────────────────────────────────────────
+
+/** The component options object: `export default {…}` / `export default defineComponent({…})` */
+function componentOptions(tree) {
+    for (const node of tree.rootNode.namedChildren) {
+        if (node.type !== 'export_statement') continue;
+        let value = node.childForFieldName('value') ||
+            node.namedChildren.find(c => c.type === 'object' || c.type === 'call_expression');
+        if (value?.type === 'call_expression') {
+            value = value.childForFieldName('arguments')?.namedChildren.find(c => c.type === 'object');
+        }
+        if (value?.type === 'object') return value;
+    }
+    return null;
+}
+
+function optionProperty(options, key) {
+    if (!options) return null;
+    for (const prop of options.namedChildren) {
+        if (prop.type !== 'pair') continue;
+        const keyNode = prop.childForFieldName('key');
+        if (keyNode && keyNode.text.replace(/^['"]|['"]$/g, '') === key) return prop.childForFieldName('value');
+    }
+    return null;
+}
+
+/** Functions under `methods:` and `computed:`, indexed with their option as modifier */
+function optionFunctions(options, lines) {
+    const functions = [];
+    for (const group of ['methods', 'computed']) {
+        const obj = optionProperty(options, group);
+        if (!obj || obj.type !== 'object') continue;
+        for (const prop of obj.namedChildren) {
+            let nameNode = null;
+            let fnNode = null;
+            if (prop.type === 'method_definition') {
+                nameNode = prop.childForFieldName('name');
+                fnNode = prop;
+            } else if (prop.type === 'pair') {
+                const value = prop.childForFieldName('value');
+                if (value && (value.type === 'function_expression' || value.type === 'arrow_function')) {
+                    nameNode = prop.childForFieldName('key');
+                    fnNode = value;
+                }
+            }
+            if (!nameNode || !fnNode) continue;
+            const paramsNode = fnNode.childForFieldName('parameters');
+            const startLine = prop.startPosition.row + 1;
+            functions.push({
+                name: nameNode.text,
+                params: paramsNode ? paramsNode.text.replace(/^\(|\)$/g, '').trim() : '',
+                paramsStructured: [],
+                startLine,
+                endLine: prop.endPosition.row + 1,
+                indent: (lines[startLine - 1] || '').match(/^\s*/)[0].length,
+                isArrow: fnNode.type === 'arrow_function',
+                modifiers: [group === 'methods' ? 'method' : 'computed'],
+            });
+        }
+    }
+    return functions;
+}
+
+/** Prop names declared by an array (`['title']`) or object (`{ title: String }`) */
+function runtimePropNodes(node) {
+    if (!node) return [];
+    if (node.type === 'array') {
+        return node.namedChildren.filter(c => c.type === 'string')
+            .map(c => ({ name: c.text.slice(1, -1), node: c }));
+    }
+    if (node.type === 'object') {
+        return node.namedChildren
+            .filter(c => c.type === 'pair' || c.type === 'shorthand_property_identifier')
+            .map(c => {
+                const key = c.type === 'pair' ? c.childForFieldName('key') : c;
+                return { name: key.text.replace(/^['"]|['"]$/g, ''), node: c };
+            });
+    }
+    return [];
+}
+
+/**
+ * Props the component declares.
+ *   defineProps(['title'])  defineProps({ title: String })
+ *   defineProps<{ title: string }>()   withDefaults(defineProps<…>(), {…})
+ *   export default { props: ['title'] }
+ * A named type argument (`defineProps<Props>()`) is returned as typeName:
+ * its interface is indexed by the TS module and marked in place.
+ *
+ * @returns {{props: Array<{name: string, node: object}>, typeName: string|null, binding: string|null}}
+ */
+function declaredProps(tree) {
+    const result = { props: [], typeName: null, binding: null };
+    const visit = (node) => {
+        if (node.type === 'call_expression' && node.childForFieldName('function')?.text === 'defineProps') {
+            const args = node.childForFieldName('arguments');
+            result.props.push(...runtimePropNodes(args?.namedChildren[0]));
+            const typeArgs = node.childForFieldName('type_arguments') || findChild(node, 'type_arguments');
+            const typeNode = typeArgs?.namedChildren[0];
+            if (typeNode?.type === 'object_type') {
+                for (const sig of typeNode.namedChildren) {
+                    const nameNode = sig.type === 'property_signature' && sig.childForFieldName('name');
+                    if (nameNode) result.props.push({ name: nameNode.text, node: sig });
+                }
+            } else if (typeNode?.type === 'type_identifier') {
+                result.typeName = typeNode.text;
+            }
+            // const props = defineProps(…) / withDefaults(defineProps(…), …)
+            let holder = node.parent;
+            while (holder && (holder.type === 'arguments' || holder.type === 'call_expression')) holder = holder.parent;
+            if (holder?.type === 'variable_declarator') {
+                const nameNode = holder.childForFieldName('name');
+                if (nameNode?.type === 'identifier') result.binding = nameNode.text;
+            }
+            return;
+        }
+        for (const child of node.namedChildren) visit(child);
+    };
+    visit(tree.rootNode);
+    result.props.push(...runtimePropNodes(optionProperty(componentOptions(tree), 'props')));
+    return result;
+}
+
+function propMembers(props) {
+    return props.map(({ name, node }) => ({
+        name,
+        startLine: node.startPosition.row + 1,
+        endLine: node.endPosition.row + 1,
+        memberType: 'field',
+        modifiers: ['prop'],
+    }));
+}
+
+/**
+ * Vue additions to the script's parse: options-API functions, and props —
+ * as members of a synthetic `Props` interface, or marked on the named
+ * interface `defineProps<Props>()` uses.
+ */
+function augmentScriptResult(jsResult, tree, lines) {
+    const options = componentOptions(tree);
+    const known = new Set(jsResult.functions.map(f => `${f.name}:${f.startLine}`));
+    for (const fn of optionFunctions(options, lines)) {
+        if (!known.has(`${fn.name}:${fn.startLine}`)) jsResult.functions.push(fn);
+    }
+    const { props, typeName } = declaredProps(tree);
+    const typed = typeName && jsResult.classes.find(c => c.name === typeName);
+    if (typed) {
+        for (const m of typed.members || []) {
+            if (m.memberType === 'field' || !m.memberType) m.modifiers = [...(m.modifiers || []), 'prop'];
+        }
+    }
+    if (props.length > 0) {
+        const first = props[0].node;
+        const last = props[props.length - 1].node;
+        jsResult.classes.push({
+            name: 'Props',
+            startLine: first.startPosition.row + 1,
+            endLine: last.endPosition.row + 1,
+            type: 'interface',
+            members: propMembers(props),
+            modifiers: ['props'],
+        });
+    }
+    return jsResult;
+}
+
+/**
+ * `props.title` / `this.title` reads in the script. A prop or computed read
+ * through its receiver is not a call, so the JS module records nothing.
+ */
+function componentMemberReads(tree, binding) {
+    const receivers = new Set(['this', ...(binding ? [binding] : [])]);
+    const calls = [];
+    const visit = (node) => {
+        if (node.type === 'member_expression') {
+            const obj = node.childForFieldName('object');
+            const prop = node.childForFieldName('property');
+            if (obj && prop && receivers.has(obj.text) && node.parent?.type !== 'call_expression') {
+                calls.push({
+                    name: prop.text,
+                    line: prop.startPosition.row + 1,
+                    isMethod: true,
+                    receiver: obj.text,
+                    isFunctionReference: true,
+                    enclosingFunction: null,
+                    uncertain: false,
+                });
+            }
+        }
+        for (const child of node.namedChildren) visit(child);
+    };
+    visit(tree.rootNode);
+    return calls;
+}
+
+// ── Exported language module interface ──────────────────────────────────────
+
+function emptyResult(code, hasError) {
+    return {
+        language: 'vue',
+        totalLines: code.split('\n').length,
+        functions: [],
+        classes: [],
+        stateObjects: [],
+        ...(hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+function parse(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return emptyResult(code, extractBlocks(code, parser).hasError);
+    const { safeParse } = require('./index');
+    const jsResult = result.jsModule.parse(result.virtualJS, result.jsParser);
+    augmentScriptResult(jsResult, safeParse(result.jsParser, result.virtualJS), code.split('\n'));
+    jsResult.language = 'vue';
+    jsResult.totalLines = code.split('\n').length;
+    if (result.blocks.hasError) jsResult.parseRecovery = true;
+    return jsResult;
+}
+
+function findFunctions(code, parser) {
+    return parse(code, parser).functions;
+}
+
+function findClasses(code, parser) {
+    return parse(code, parser).classes;
+}
+
+function findStateObjects(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findStateObjects(result.virtualJS, result.jsParser);
+}
+
+function findCallsInCode(code, parser) {
+    const result = extractScript(code, parser);
+    const blocks = result ? result.blocks : extractBlocks(code, parser);
+    const templateCalls = extractTemplateCalls(code, parser, blocks);
+    if (!result) return templateCalls;
+    const { safeParse } = require('./index');
+    const tree = safeParse(result.jsParser, result.virtualJS);
+    const scriptCalls = result.jsModule.findCallsInCode(result.virtualJS, result.jsParser);
+    return scriptCalls.concat(componentMemberReads(tree, declaredProps(tree).binding), templateCalls);
+}
+
+function findCallbackUsages(code, name, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findCallbackUsages(result.virtualJS, name, result.jsParser);
+}
+
+function findReExports(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findReExports(result.virtualJS, result.jsParser);
+}
+
+function findImportsInCode(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findImportsInCode(result.virtualJS, result.jsParser);
+}
+
+function findExportsInCode(code, parser) {
+    const result = extractScript(code, parser);
+    if (!result) return [];
+    return result.jsModule.findExportsInCode(result.virtualJS, result.jsParser);
+}
+
+function findUsagesInCode(code, name, parser) {
+    const result = extractScript(code, parser);
+    const scriptUsages = result
+        ? result.jsModule.findUsagesInCode(result.virtualJS, name, result.jsParser)
+        : [];
+    const templateUsages = extractTemplateCalls(code, parser, result?.blocks)
+        .filter(c => c.name === name)
+        .map(c => ({ line: c.line, column: 0, usageType: c.isFunctionReference && !c.isJsxComponent ? 'reference' : 'call' }));
+    if (templateUsages.length === 0) return scriptUsages;
+    return scriptUsages.concat(templateUsages);
+}
+
+/** Props join the deadcode audit: a prop nothing reads is dead API */
+function isAuditedProp(symbol) {
+    return (symbol.modifiers || []).includes('prop');
+}
+
+/**
+ * Classify a Vue symbol as a runtime entry point.
+ * The synthetic props interface is the component's contract with its
+ * parents; script symbols are classified by the JS predicate.
+ */
+function getEntryPointKind(symbol) {
+    if ((symbol.modifiers || []).includes('props')) return 'framework';
+    return getLanguageModule('javascript').getEntryPointKind(symbol);
+}
+
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    parse,
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findCallbackUsages,
+    findReExports,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    isAuditedProp,
+    // Exported for deadcode and testing
+    extractBlocks,
+    extractScript,
+    extractTemplateCalls,
+    componentName,
+    expressionReferences,
+};
@@ -0,0 +1,521 @@
+/**
+ * languages/zig.js - Tree-sitter based Zig parsing
+ *
+ * Handles: fn declarations (pub / export / extern), container types bound
+ * to constants (`const Foo = struct { ... }` — struct, union, enum, opaque)
+ * with their fields and methods, container-level const/var declarations
+ * (comptime constants), and @import bindings.
+ *
+ * Visibility is the `pub` keyword: non-pub declarations are file-private,
+ * so an unused non-pub fn, struct or comptime constant is claimable dead
+ * code. `export fn` is C-ABI surface (called from outside Zig) and counts as
+ * exported.
+ */
+
+const {
+    traverseTree,
+    traverseTreeCached,
+    nodeToLocation,
+    visitNameNodes,
+    sameNode,
+} = require('./utils');
+const { PARSE_OPTIONS, safeParse } = require('./index');
+
+function parseTree(parser, code) {
+    return safeParse(parser, code, undefined, PARSE_OPTIONS);
+}
+
+const CONTAINER_KINDS = {
+    struct_declaration: 'struct',
+    union_declaration: 'struct',
+    enum_declaration: 'enum',
+    opaque_declaration: 'type',
+};
+
+function namedChildOfType(node, type) {
+    for (let i = 0; i < node.namedChildCount; i++) {
+        const child = node.namedChild(i);
+        if (child.type === type) return child;
+    }
+    return null;
+}
+
+/**
+ * Declaration keywords (`pub`, `export`, `extern`, `inline`, `comptime`,
+ * `threadlocal`) — the grammar places `pub` as an anonymous token either on
+ * the declaration itself or just before it in the container, so both
+ * positions are checked.
+ */
+function declKeywords(node) {
+    const kws = new Set();
+    for (let i = 0; i < node.childCount; i++) {
+        const child = node.child(i);
+        if (child.isNamed) break;
+        kws.add(child.type);
+    }
+    for (let prev = node.previousSibling; prev && !prev.isNamed; prev = prev.previousSibling) {
+        if (prev.type === ';' || prev.type === '}' || prev.type === ',') break;
+        kws.add(prev.type);
+    }
+    return kws;
+}
+
+function declName(node) {
+    return node.childForFieldName('name') || namedChildOfType(node, 'identifier');
+}
+
+/** The container this declaration lives in (source_file or a struct body) */
+function enclosingContainer(node) {
+    for (let p = node.parent; p; p = p.parent) {
+        if (p.type === 'source_file' || CONTAINER_KINDS[p.type]) return p;
+        if (p.type === 'function_declaration' || p.type === 'block') return null;
+    }
+    return null;
+}
+
+/** `@import("x")` call inside an expression, else null */
+function importTarget(valueNode) {
+    if (!valueNode || valueNode.type !== 'builtin_function') return null;
+    const ident = namedChildOfType(valueNode, 'builtin_identifier');
+    if (ident?.text !== '@import') return null;
+    const args = namedChildOfType(valueNode, 'arguments');
+    const str = args?.namedChild(0);
+    if (!str) return null;
+    return str.text.replace(/^"|"$/g, '');
+}
+
+/** Value expression of a const/var declaration */
+function declValue(node) {
+    const value = node.childForFieldName('value');
+    if (value) return value;
+    // Last named child after `=`
+    let seenEq = false;
+    for (let i = 0; i < node.childCount; i++) {
+        const child = node.child(i);
+        if (child.type === '=') { seenEq = true; continue; }
+        if (seenEq && child.isNamed) return child;
+    }
+    return null;
+}
+
+/** `/// doc` comment lines directly above a declaration */
+function extractDocstring(lines, startLine) {
+    const row = startLine - 2;
+    if (row < 0) return null;
+    const m = (lines[row] || '').match(/^\s*\/\/\/\s?(.*)$/);
+    if (!m) return null;
+    let first = m[1];
+    for (let r = row - 1; r >= 0; r--) {
+        const above = (lines[r] || '').match(/^\s*\/\/\/\s?(.*)$/);
+        if (!above) break;
+        first = above[1];
+    }
+    return first.trim() || null;
+}
+
+function extractParams(paramsNode) {
+    if (!paramsNode) return { params: '...', paramsStructured: [] };
+    const paramsStructured = [];
+    for (let i = 0; i < paramsNode.namedChildCount; i++) {
+        const p = paramsNode.namedChild(i);
+        if (p.type === 'comment') continue;
+        const nameNode = p.childForFieldName('name') || namedChildOfType(p, 'identifier');
+        const typeNode = p.childForFieldName('type');
+        const text = p.text;
+        const colon = text.indexOf(':');
+        paramsStructured.push({
+            name: nameNode ? nameNode.text : (colon > 0 ? text.slice(0, colon).trim() : text),
+            ...((typeNode || colon > 0) && { type: typeNode ? typeNode.text : text.slice(colon + 1).trim() }),
+        });
+    }
+    return { params: paramsNode.text.replace(/^\(|\)$/g, '').trim(), paramsStructured };
+}
+
+function returnTypeOf(fnNode) {
+    const typeNode = fnNode.childForFieldName('type') || fnNode.childForFieldName('return_type');
+    return typeNode ? typeNode.text : null;
+}
+
+function buildFunction(node, lines) {
+    const nameNode = declName(node);
+    if (!nameNode) return null;
+    const kws = declKeywords(node);
+    const { startLine, endLine, indent } = nodeToLocation(node, lines);
+    const { params, paramsStructured } = extractParams(namedChildOfType(node, 'parameters'));
+    const modifiers = [];
+    if (kws.has('pub')) modifiers.push('public');
+    if (kws.has('export')) modifiers.push('export');
+    if (kws.has('extern')) modifiers.push('extern');
+    if (kws.has('inline')) modifiers.push('inline');
+    const returnType = returnTypeOf(node);
+    const docstring = extractDocstring(lines, startLine);
+    return {
+        name: nameNode.text,
+        params,
+        paramsStructured,
+        startLine,
+        endLine,
+        indent,
+        modifiers,
+        ...(returnType && { returnType }),
+        ...(docstring && { docstring }),
+        // `extern fn` has no body — a declaration of foreign code
+        ...(kws.has('extern') && !namedChildOfType(node, 'block') && { isSignature: true }),
+    };
+}
+
+/** Fields and methods declared inside a container type body */
+function containerMembers(containerNode, lines) {
+    const members = [];
+    for (let i = 0; i < containerNode.namedChildCount; i++) {
+        const child = containerNode.namedChild(i);
+        if (child.type === 'container_field') {
+            const nameNode = declName(child);
+            if (!nameNode) continue;
+            const { startLine, endLine } = nodeToLocation(child, lines);
+            const typeNode = child.childForFieldName('type');
+            members.push({
+                name: nameNode.text,
+                startLine, endLine,
+                memberType: 'field',
+                // Zig struct fields are always public
+                modifiers: ['public'],
+                ...(typeNode && { fieldType: typeNode.text }),
+            });
+        } else if (child.type === 'function_declaration') {
+            const fn = buildFunction(child, lines);
+            if (!fn) continue;
+            const params = fn.paramsStructured;
+            // First param typed as the container (self: *Self / Foo) → instance method
+            const isInstance = params.length > 0 && /^(self|this)$/.test(params[0].name);
+            members.push({ ...fn, memberType: isInstance ? 'method' : 'static', isMethod: isInstance });
+        }
+    }
+    return members;
+}
+
+// --- Single-pass helpers ---
+
+function _processFunction(node, functions, lines) {
+    if (node.type !== 'function_declaration') return false;
+    // Container methods are extracted as class members
+    const container = enclosingContainer(node);
+    if (container && container.type !== 'source_file') return true;
+    const fn = buildFunction(node, lines);
+    if (fn) functions.push(fn);
+    return true;
+}
+
+function _processDeclaration(node, classes, objects, lines) {
+    if (node.type !== 'variable_declaration') return false;
+    if (!enclosingContainer(node)) return true; // function-local
+    const nameNode = declName(node);
+    if (!nameNode) return true;
+    const value = declValue(node);
+    if (importTarget(value)) return true;
+    const kws = declKeywords(node);
+    const isConst = node.text.replace(/^(pub\s+|export\s+|extern\s+|threadlocal\s+)*/, '').startsWith('const');
+    const { startLine, endLine, indent } = nodeToLocation(node, lines);
+    const modifiers = kws.has('pub') ? ['public'] : [];
+    const docstring = extractDocstring(lines, startLine);
+
+    const kind = value && CONTAINER_KINDS[value.type];
+    if (kind && isConst) {
+        const cls = {
+            name: nameNode.text,
+            type: kind,
+            startLine, endLine, indent,
+            modifiers,
+            members: containerMembers(value, lines),
+            ...(docstring && { docstring }),
+        };
+        classes.push(cls);
+        return true;
+    }
+
+    objects.push({
+        name: nameNode.text,
+        startLine, endLine,
+        modifiers: isConst ? [...modifiers, 'comptime'] : modifiers,
+        ...(isConst && { isConst: true }),
+    });
+    return true;
+}
+
+function findFunctions(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const functions = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        _processFunction(node, functions, lines);
+        return true;
+    });
+    functions.sort((a, b) => a.startLine - b.startLine);
+    return functions;
+}
+
+function findClasses(code, parser) {
+    return parse(code, parser).classes;
+}
+
+function findStateObjects(code, parser) {
+    return parse(code, parser).stateObjects;
+}
+
+/**
+ * Parse a Zig file completely
+ */
+function parse(code, parser) {
+    const tree = parseTree(parser, code);
+    const lines = code.split('\n');
+    const functions = [];
+    const classes = [];
+    const stateObjects = [];
+
+    traverseTreeCached(tree.rootNode, (node) => {
+        _processFunction(node, functions, lines) ||
+            _processDeclaration(node, classes, stateObjects, lines);
+        return true;
+    });
+
+    functions.sort((a, b) => a.startLine - b.startLine);
+    classes.sort((a, b) => a.startLine - b.startLine);
+    stateObjects.sort((a, b) => a.startLine - b.startLine);
+
+    return {
+        language: 'zig',
+        totalLines: lines.length,
+        functions,
+        classes,
+        stateObjects,
+        ...(tree.rootNode.hasError && { parseRecovery: true }),
+        imports: [],
+        exports: []
+    };
+}
+
+/**
+ * Find all function calls in Zig code
+ *
+ *   helper(x)             → local call
+ *   util.helper(x)        → call through an @import binding (isMethod false)
+ *   self.deinit()         → method call
+ *   Foo.init(alloc)       → type-qualified (static) call
+ *
+ * @param {string} code - Source code to analyze
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [options] - { imports: string[] } — @import binding names
+ * @returns {Array<{name: string, line: number, isMethod: boolean, receiver?: string}>}
+ */
+function findCallsInCode(code, parser, options = {}) {
+    const tree = parseTree(parser, code);
+    const calls = [];
+    const functionStack = [];
+    const importNames = new Set(options.imports || []);
+
+    const getCurrentEnclosingFunction = () => {
+        return functionStack.length > 0
+            ? { ...functionStack[functionStack.length - 1] }
+            : null;
+    };
+
+    traverseTree(tree.rootNode, (node) => {
+        if (node.type === 'function_declaration') {
+            const nameNode = declName(node);
+            functionStack.push({
+                name: nameNode ? nameNode.text : '<anonymous>',
+                startLine: node.startPosition.row + 1,
+                endLine: node.endPosition.row + 1,
+            });
+            return true;
+        }
+        if (node.type !== 'call_expression') return true;
+
+        const fnNode = node.childForFieldName('function') || node.namedChild(0);
+        if (!fnNode) return true;
+        const args = namedChildOfType(node, 'arguments');
+        const argCount = args ? args.namedChildren.filter(a => a.type !== 'comment').length : 0;
+        const enclosingFunction = getCurrentEnclosingFunction();
+
+        if (fnNode.type === 'identifier') {
+            calls.push({
+                name: fnNode.text,
+                line: fnNode.startPosition.row + 1,
+                isMethod: false,
+                argCount,
+                enclosingFunction,
+                uncertain: false,
+            });
+        } else if (fnNode.type === 'field_expression') {
+            const member = fnNode.childForFieldName('member') ||
+                fnNode.namedChild(fnNode.namedChildCount - 1);
+            const object = fnNode.childForFieldName('object') || fnNode.namedChild(0);
+            if (!member || member.type !== 'identifier' || sameNode(member, object)) return true;
+            const receiver = object ? object.text : null;
+            const root = receiver ? receiver.split('.')[0] : null;
+            // Namespaces: an @import binding (util.helper) or a type name
+            // (Foo.init) — neither is an instance dispatch.
+            const isNamespace = root && (importNames.has(root) || /^[A-Z]/.test(root));
+            calls.push({
+                name: member.text,
+                line: member.startPosition.row + 1,
+                isMethod: !isNamespace,
+                ...(receiver && { receiver }),
+                argCount,
+                enclosingFunction,
+                uncertain: false,
+            });
+        }
+        return true;
+    }, {
+        onLeave: (node) => {
+            if (node.type === 'function_declaration') functionStack.pop();
+        }
+    });
+
+    return calls;
+}
+
+/**
+ * Find @import bindings: `const util = @import("util.zig");`
+ * @returns {Array<{module: string, names: string[], type: string, line: number}>}
+ */
+function findImportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const imports = [];
+    traverseTreeCached(tree.rootNode, (node) => {
+        if (node.type === 'variable_declaration') {
+            const target = importTarget(declValue(node));
+            if (target) {
+                const nameNode = declName(node);
+                imports.push({
+                    module: target,
+                    names: nameNode ? [nameNode.text] : [],
+                    type: 'import',
+                    line: node.startPosition.row + 1,
+                });
+                return false;
+            }
+            return true;
+        }
+        // Bare `@import("x").member` usages (no binding)
+        if (node.type === 'builtin_function') {
+            const target = importTarget(node);
+            if (target && node.parent?.type !== 'variable_declaration') {
+                imports.push({ module: target, names: [], type: 'import', line: node.startPosition.row + 1 });
+            }
+        }
+        return true;
+    });
+    return imports;
+}
+
+/**
+ * `pub` container-level declarations of the file (the file is a struct;
+ * its pub members are the importable surface).
+ * @returns {Array<{name: string, type: string, line: number}>}
+ */
+function findExportsInCode(code, parser) {
+    const tree = parseTree(parser, code);
+    const exports = [];
+    const root = tree.rootNode;
+    for (let i = 0; i < root.namedChildCount; i++) {
+        const node = root.namedChild(i);
+        if (node.type !== 'function_declaration' && node.type !== 'variable_declaration') continue;
+        const kws = declKeywords(node);
+        if (!kws.has('pub') && !kws.has('export')) continue;
+        const nameNode = declName(node);
+        if (!nameNode) continue;
+        if (node.type === 'variable_declaration' && importTarget(declValue(node))) continue;
+        exports.push({
+            name: nameNode.text,
+            type: node.type === 'function_declaration' ? 'fn' : 'decl',
+            line: node.startPosition.row + 1,
+        });
+    }
+    return exports;
+}
+
+/**
+ * Find all usages of a name in code using AST
+ * @param {string} code - Source code
+ * @param {string} name - Symbol name to find
+ * @param {object} parser - Tree-sitter parser instance
+ * @param {object} [tree] - Pre-parsed tree (per-operation cache); parsed here when absent
+ * @returns {Array<{line: number, column: number, usageType: string}>}
+ */
+function findUsagesInCode(code, name, parser, tree) {
+    tree = tree || parseTree(parser, code);
+    const usages = [];
+
+    visitNameNodes(tree, code, name, (node) => {
+        if (node.type !== 'identifier' || node.text !== name) return true;
+        const line = node.startPosition.row + 1;
+        const column = node.startPosition.column;
+        const parent = node.parent;
+        let usageType = 'reference';
+
+        if (parent) {
+            if ((parent.type === 'function_declaration' || parent.type === 'variable_declaration' ||
+                 parent.type === 'container_field' || parent.type === 'parameter') &&
+                sameNode(declName(parent), node)) {
+                usageType = parent.type === 'variable_declaration' && importTarget(declValue(parent))
+                    ? 'import' : 'definition';
+            } else if (parent.type === 'call_expression') {
+                usageType = 'call';
+            } else if (parent.type === 'field_expression') {
+                const object = parent.childForFieldName('object') || parent.namedChild(0);
+                if (!sameNode(object, node)) {
+                    const isCall = parent.parent?.type === 'call_expression';
+                    usages.push({
+                        line, column,
+                        usageType: isCall ? 'call' : 'reference',
+                        ...(object && { receiver: object.text }),
+                    });
+                    return true;
+                }
+            }
+        }
+
+        usages.push({ line, column, usageType });
+        return true;
+    });
+
+    return usages;
+}
+
+/**
+ * Classify a Zig entry point:
+ * - 'main':      `pub fn main` (the executable root)
+ * - 'framework': root-source overrides the std library looks up by name
+ *                (`panic`, `std_options`) and `export fn` C-ABI symbols.
+ * `test "..." {}` blocks are anonymous and never indexed as functions.
+ */
+function getEntryPointKind(symbol) {
+    const mods = symbol.modifiers || [];
+    if (symbol.name === 'main' && !symbol.className) return 'main';
+    if (mods.includes('export')) return 'framework';
+    if (!symbol.className && mods.includes('public') &&
+        (symbol.name === 'panic' || symbol.name === 'std_options')) return 'framework';
+    return null;
+}
+
+/**
+ * Check if a symbol is a Zig-convention entry point.
+ */
+function isEntryPoint(symbol) {
+    return getEntryPointKind(symbol) !== null;
+}
+
+module.exports = {
+    findFunctions,
+    findClasses,
+    findStateObjects,
+    findCallsInCode,
+    findImportsInCode,
+    findExportsInCode,
+    findUsagesInCode,
+    isEntryPoint,
+    getEntryPointKind,
+    parse
+};
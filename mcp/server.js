@@ -267,7 +267,7 @@ FINDING CODE:
 - search <term>: Text search (like grep, respects .gitignore). Supports regex by default (e.g. "\\d+" or "foo|bar"). Supports context=N for surrounding lines, exclude/in for file filtering. Case-insensitive by default; set case_sensitive=true for exact case. Invalid regex auto-falls back to plain text. STRUCTURAL MODE: Add type=function|class|call|method|type to query the symbol index instead of text. Combine with param=, returns=, decorator=, receiver= (for calls), exported=true, unused=true. Term becomes optional name filter (glob). Example: type=function, param=Request → all functions taking Request.
 - tests <name>: Find test files covering a function, test case names, and how it's called in tests. Use before modifying or to find test patterns to follow.
 - affected_tests <name>: Which tests to run after changing a function. Combines blast (transitive callers) with test detection. Shows test files, coverage %, and uncovered functions. Use depth= to control depth.
-- deadcode: Generate unreferenced-symbol candidates for review. Never treat the result as standalone deletion proof. Exported, decorated, and test symbols are excluded by default; use include_exported/include_decorated/include_tests to expand the audit.
+- deadcode: Generate unreferenced-symbol candidates for review. Never treat the result as standalone deletion proof. Exported, decorated, and test symbols are excluded by default; use include_exported/include_decorated/include_tests to expand the audit. interface_methods=true instead lists interface methods no caller invokes through the interface; unused_params=true lists function parameters never read in the body; unreachable_code=true lists statements no execution reaches (Go); package_vars=true lists package-level variables never read (Go); types=true audits type declarations only, type aliases and generic types included; unused_results=true lists functions whose return values every caller discards (Go); orphan_files=true lists whole files that nothing imports and whose declarations nothing outside the file uses; build_variants=true lists functions defined only in files no target platform builds (Go build tags; set the matrix with platforms); test_only=true lists symbols referenced only from test files, kept apart from fully unused ones; test_helpers=true lists helper functions in test files that no test calls and testdata fixtures and golden files no test names; sentinel_errors=true lists package-level sentinel errors (var ErrX = errors.New(...)) never returned, passed on, or compared (Go); library=true treats exported Go identifiers as dead unless another package or a dependent checkout (dependents) imports and uses them; embeds=true lists //go:embed variables never read and embedded files no path literal names (Go); channels=true lists local channels never received from or never sent to, and go statements whose function results are dropped (Go); config_knobs=true lists flags (flag, pflag, cobra), env variables (os.Getenv) and viper keys whose values are never read (Go); satisfies_only=true lists interfaces whose only uses are var _ I = (*T)(nil) assertions, with the methods of T that exist only to satisfy them (Go); redundant_assertions=true lists those var _ I = (*T)(nil) assertion lines themselves as low-severity cleanups (Go); type_params=true lists type parameters of generic functions and types that the signature, body, and methods never mention (Go); init_effects=true lists init() functions that only fill package variables nothing reads and blank imports (_ "pkg") whose registrations nothing looks up (Go); import_issues=true lists imports unused in files only some build tags compile, paths imported twice under different names, and imports shadowed by a local, each with machine-applicable edits (Go); config_keys=true lists keys in YAML/JSON/TOML config files that no code reads and config struct fields (yaml/toml/mapstructure tags) that no config file sets (.ucn.json configFiles picks the files). complexity=true adds cyclomatic and cognitive complexity to each dead function and lists every function over max_cyclomatic (default 10) or max_cognitive (default 15) as a separate finding. size=true separately lists functions over max_function_lines (default 80) or max_statements (default 50) and types over max_methods (default 20) or max_fields (default 15); .ucn.json size sets the limits per language.
 - entrypoints: Detect framework entry points: routes, handlers, DI providers, tasks. Auto-detects Express, Flask, Spring, Gin, Actix, and more. Use framework= to filter by specific framework.
 - endpoints: HTTP API surface with server routes and client requests. Use bridge=true to match clients to servers across language boundaries; method=/prefix= to filter; server_only/client_only to reduce output.
 
@@ -281,7 +281,7 @@ FILE DEPENDENCIES (require file param):
 - imports: All imports with resolved file paths. Use to understand dependencies before modifying or moving a file. Resolves relative, package, and language-specific patterns.
 - exporters: Every file that imports or depends on this file. Use before moving, renaming, or deleting.
 - file_exports: File's public API: all exported functions, classes, variables with signatures. Use to understand what a module offers before importing. Requires explicit export markers; use toc --detailed as fallback.
-- graph: File-level dependency tree. Use it to understand module clusters and dependency chains. Set direction ("imports"/"importers"/"both"). Use depth=1 for large codebases.
+- graph: File-level dependency tree. Use it to understand module clusters and dependency chains. Set direction ("imports"/"importers"/"both"). Use depth=1 for large codebases. symbols=true instead returns the symbol call graph as Graphviz DOT: name is a glob over symbol names (e.g. "Handle*"), in/file/exclude scope the starting symbols, depth (default 2) counts call hops, direction is "callees"/"callers"/"both". Each node is marked as an entry point or as unreachable from every entry point, which shows why deadcode does or does not report it.
 - circular_deps: Detect circular import chains. Shows cycle paths and involved files. Use file= to check a specific file, exclude= to ignore paths.
 
 REFACTORING:
@@ -300,6 +300,11 @@ OTHER:
 - api: Public API surface of project or file: all exported/public symbols with signatures. Use to understand what a library exposes. Pass file to scope to one file. Python needs __all__; use toc instead.
 - stats: Quick project stats: file counts, symbol counts, lines of code by language and symbol type. Use functions=true for per-function line counts sorted by size (complexity audit). Set hot=true with top=N for the most-called functions (project orientation primitive).
 - audit_async: Find async calls inside async functions that are likely missing await (probable bugs). JS/TS/Python only. Filter with file/exclude/limit.
+- clones: Groups of near-duplicate functions across files and packages (identifiers and literals normalized). Tune with min_lines (default 6), min_tokens (default 50), similarity (0-1, default 0.9); file= keeps groups touching matching files. literals=true instead lists string and number literals repeated verbatim in min_files (default 3) or more files, with every location and any constant already holding the value.
+- deprecated: Symbols marked deprecated (Go "// Deprecated:" doc paragraph). Lists the still-referenced ones with every referencing site, then the unreferenced ones that can be deleted now. Filter with file/exclude/in; include_tests also reports deprecated symbols declared in tests.
+- rules: Every rule the finding commands (deadcode and its modes, clones, audit_async, deprecated) report, with its default severity and confidence, the command and flag that check it, and what .ucn.json "rules" sets for it.
+- explain <name>: name is a rule id or name, or a finding fingerprint (16 hex digits, from jsonl/SARIF output or a baseline). A rule gets its entry. A fingerprint is looked up by re-running the finding commands with the other params given (pass the deadcode mode that reported it, e.g. unused_params=true); a dead-code finding then lists the checks that could have kept it and its caller trace (depth levels) with each caller's reachability from the entry points.
+- why <name>: the shortest chain of confirmed calls from a reachability root (entry point, .ucn.json entryPoints match, handler registry member, top-level call) to the symbol, with why the root counts; when there is none, its direct callers and how many unverified calls were not followed. Use it to check a surprising deadcode verdict. file/class_name/line pick the definition.
 
 READING OUTPUT (trust contract):
 - Caller/impact answers partition literal-name text lines. CONFIRMED entries carry binding/receiver/import evidence; UNVERIFIED entries are possible callers without target proof. ACCOUNT reconciles that text ground set. CONTRACT states the boundary explicitly.
@@ -320,7 +325,7 @@ Understand and change:
 - trace: downward execution tree. reverse_trace/blast: upward/transitive impact.
 - fn/class/lines: extract only the source needed. smart: target plus dependencies.
 - verify: confirmed-site arity check. plan: refactor preview. check/diff_impact: change preflight.
-- tests/affected_tests: relevant tests. usages: all AST usage kinds. deadcode: conservative candidate list.
+- tests/affected_tests: relevant tests. usages: all AST usage kinds. deadcode: conservative candidate list. clones: near-duplicate functions. deprecated: deprecated symbols and who still uses them. rules/explain: what a rule or finding means and why it was reported. why: how a symbol is reached from an entry point.
 
 Architecture and search:
 - toc/stats/api/entrypoints: project surface. imports/exporters/file_exports/graph/circular_deps: file graph.
@@ -369,6 +374,45 @@ server.registerTool(
             context: z.number().int().nonnegative().max(1000).optional().describe('Lines of context around each match. Non-negative integer.'),
             include_exported: z.boolean().optional().describe('Include exported symbols in deadcode results'),
             include_decorated: z.boolean().optional().describe('Include decorated/annotated symbols in deadcode results'),
+            include_generated: z.boolean().optional().describe('deadcode/clones/audit_async/deprecated: also report findings in generated files ("Code generated ... DO NOT EDIT" headers, *.pb.go and the like), hidden by default'),
+            generated_markers: z.string().optional().describe('Extra comma-separated text markers that flag a file as generated when found in its first 500 characters'),
+            interface_methods: z.boolean().optional().describe('deadcode: report interface methods no caller invokes through the interface (implementations may still be called directly)'),
+            unused_params: z.boolean().optional().describe('deadcode: report function parameters never read in the body (parameters whose signature an interface or base fixes are marked)'),
+            unreachable_code: z.boolean().optional().describe('deadcode: report statements no execution reaches — after return/panic, constant-false branches, switch cases that never match (Go)'),
+            types: z.boolean().optional().describe('deadcode: audit only type declarations — classes, structs, interfaces, enums, type aliases and defined types (generic ones included); exported types need include_exported'),
+            unused_results: z.boolean().optional().describe('deadcode: report functions whose return values every call site discards, whole or one position such as an ignored error (Go)'),
+            package_vars: z.boolean().optional().describe('deadcode: report package-level variables never read, split into write-only and never referenced (Go)'),
+            orphan_files: z.boolean().optional().describe('deadcode: report whole source files nothing imports and whose every declaration is unused outside the file — delete-the-file candidates'),
+            build_variants: z.boolean().optional().describe('deadcode: report functions defined only under build constraints (//go:build, _GOOS file suffixes) that no target platform satisfies'),
+            platforms: z.string().optional().describe('deadcode build_variants: comma-separated target platforms as goos/goarch (default: linux/amd64,linux/arm64,darwin/amd64,darwin/arm64,windows/amd64, or .ucn.json "platforms")'),
+            test_only: z.boolean().optional().describe('deadcode: report symbols whose every reference is in a test file — removing them means removing their tests too'),
+            test_helpers: z.boolean().optional().describe('deadcode: report helper functions in test files no test calls, and files under testdata/ (fixtures, golden files) no code of the package names'),
+            sentinel_errors: z.boolean().optional().describe('deadcode: report package-level sentinel errors (var ErrX = errors.New(...)) that nothing returns, passes on, or compares with ==/errors.Is (Go)'),
+            library: z.boolean().optional().describe('deadcode: library mode — report exported Go identifiers no importer uses (other packages of the module, plus dependents). A nonzero usage count means it is used inside its package: unexport it'),
+            dependents: z.string().optional().describe('deadcode library: comma-separated paths to checkouts of dependent repositories whose Go imports count as external usage (or .ucn.json "dependents")'),
+            embeds: z.boolean().optional().describe('deadcode: report //go:embed variables never read, and files an embed.FS embeds that no path literal using it names (Go)'),
+            channels: z.boolean().optional().describe('deadcode: report function-local channels that are never received from or never sent to/closed, and go statements calling functions whose return values are dropped (Go)'),
+            config_knobs: z.boolean().optional().describe('deadcode: report command-line flags (flag/pflag/cobra), os.Getenv values and viper keys that are registered or stored but never read or looked up (Go)'),
+            satisfies_only: z.boolean().optional().describe('deadcode: report interfaces never used as a type except in compile-time assertions (var _ I = (*T)(nil)), together with the methods of the asserted types nothing else calls — both can be removed together (Go)'),
+            redundant_assertions: z.boolean().optional().describe('deadcode: report compile-time interface assertions (var _ I = (*T)(nil)) whose interface is never used anywhere else — low-severity cleanup (Go)'),
+            type_params: z.boolean().optional().describe('deadcode: report type parameters of generic functions and types never mentioned in the signature, body, other constraints, or (for types) any method — the generic can be simplified (Go)'),
+            complexity: z.boolean().optional().describe('deadcode: add cyclomatic and cognitive complexity to dead functions and report every function over the thresholds'),
+            max_cyclomatic: z.number().int().min(0).optional().describe('deadcode complexity: cyclomatic complexity above which a function is reported (default: 10, or .ucn.json complexity.cyclomatic)'),
+            max_cognitive: z.number().int().min(0).optional().describe('deadcode complexity: cognitive complexity above which a function is reported (default: 15, or .ucn.json complexity.cognitive)'),
+            size: z.boolean().optional().describe('deadcode: also report functions over the line/statement limits and types over the method/field limits'),
+            max_function_lines: z.number().int().min(0).optional().describe('deadcode size: line limit per function (default: 80)'),
+            max_statements: z.number().int().min(0).optional().describe('deadcode size: statement limit per function (default: 50)'),
+            max_methods: z.number().int().min(0).optional().describe('deadcode size: method limit per type (default: 20)'),
+            max_fields: z.number().int().min(0).optional().describe('deadcode size: field limit per type (default: 15)'),
+            import_issues: z.boolean().optional().describe('deadcode: report Go imports unused under build tags, duplicated under another alias, or shadowed by a local, with line edits (expression to newExpression) that fix them'),
+            symbols: z.boolean().optional().describe('graph: symbol call graph (Graphviz DOT) of symbols matching name instead of the file import tree'),
+            config_keys: z.boolean().optional().describe('deadcode: report keys in the project config files (YAML/JSON/TOML) that no code reads, and tagged config struct fields that no config file populates'),
+            init_effects: z.boolean().optional().describe('deadcode: report init() functions whose only effect is populating package variables nothing reads, and blank imports whose package runs no live init or registers a driver or image format nothing opens (Go)'),
+            min_lines: z.number().int().positive().max(100000).optional().describe('clones: skip functions shorter than this many lines (default: 6)'),
+            min_tokens: z.number().int().positive().max(1000000).optional().describe('clones: skip functions with fewer normalized tokens (default: 50)'),
+            similarity: z.number().gt(0).max(1).optional().describe('clones: minimum token similarity for two functions to count as clones, 0-1 (default: 0.9)'),
+            literals: z.boolean().optional().describe('clones: list string and number literals copy-pasted across files instead of duplicate functions'),
+            min_files: z.number().int().positive().max(100000).optional().describe('clones literals: report a literal once it appears in this many files (default: 3)'),
             calls_only: z.boolean().optional().describe('Only direct calls and test-case matches (tests command)'),
             max_lines: z.number().int().positive().max(1000000).optional().describe('Max source lines for class (large classes show summary by default). Must be a positive integer.'),
             direction: z.enum(['imports', 'importers', 'both']).optional().describe('Graph direction: imports (what this file uses), importers (who uses this file), both (default: both)'),
@@ -696,7 +740,8 @@ server.registerTool(
                     top: ep.top || 0,
                     decoratedHint: !ep.includeDecorated && result.excludedDecorated > 0 ? `${result.excludedDecorated} decorated/annotated symbol(s) hidden (framework-registered). Use include_decorated=true to include them.` : undefined,
                     exportedHint: !ep.includeExported && result.excludedExported > 0 ? `${result.excludedExported} exported symbol(s) excluded from the audit (public API may have external callers). Use include_exported=true to audit them.` : undefined,
-                    externalContractHint: !ep.includeExported && result.excludedExternalContract > 0 ? `${result.excludedExternalContract} symbol(s) hidden (override an out-of-tree base class — reachable via external contract, not dead). Use include_exported=true to include them.` : undefined
+                    externalContractHint: !ep.includeExported && result.excludedExternalContract > 0 ? `${result.excludedExternalContract} symbol(s) hidden (override an out-of-tree base class — reachable via external contract, not dead). Use include_exported=true to include them.` : undefined,
+                    generatedHint: result.excludedGenerated > 0 ? `${result.excludedGenerated} symbol(s) in generated files hidden. Use include_generated=true to include them.` : undefined
                 });
                 if (dcNote) dcText += '\n\n' + mn(dcNote);
                 return tr(dcText);
@@ -747,6 +792,7 @@ server.registerTool(
                 index = getIndex(project_dir, ep);
                 const { ok, result, error } = execute(index, 'graph', ep);
                 if (!ok) return te(error);
+                if (result.symbols) return tr(output.formatGraph(result));
                 return tr(output.formatGraph(result, {
                     showAll: ep.all || ep.depth !== undefined,
                     maxDepth: ep.depth ?? 2, file: ep.file,
@@ -830,6 +876,49 @@ server.registerTool(
                 return tr(text);
             }
 
+            case 'clones': {
+                index = getIndex(project_dir, ep);
+                const { ok, result, error, note } = execute(index, 'clones', ep);
+                if (!ok) return te(error);
+                let text = output.formatClones(result);
+                if (note) text += '\n\n' + mn(note);
+                return tr(text);
+            }
+
+            case 'deprecated': {
+                index = getIndex(project_dir, ep);
+                const { ok, result, error, note } = execute(index, 'deprecated', ep);
+                if (!ok) return te(error);
+                let text = output.formatDeprecated(result);
+                if (note) text += '\n\n' + mn(note);
+                return tr(text);
+            }
+
+            case 'rules': {
+                index = getIndex(project_dir, ep);
+                const { ok, result, error } = execute(index, 'rules', ep);
+                if (!ok) return te(error);
+                return tr(output.formatRules(result));
+            }
+
+            case 'explain': {
+                index = getIndex(project_dir, ep);
+                const { ok, result, error, note } = execute(index, 'explain', ep);
+                if (!ok) return te(error);
+                let text = output.formatExplain(result);
+                if (note) text += '\n\n' + mn(note);
+                return tr(text);
+            }
+
+            case 'why': {
+                index = getIndex(project_dir, ep);
+                const { ok, result, error, note } = execute(index, 'why', ep);
+                if (!ok) return te(error);
+                let text = output.formatWhy(result);
+                if (note) text += '\n\n' + mn(note);
+                return tr(text);
+            }
+
             // ── Extracting Code (via execute) ────────────────────────────
 
             case 'fn': {
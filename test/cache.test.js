@@ -1309,6 +1309,77 @@ describe('Regression: F-003 matchesFilters boundary edge cases', () => {
     });
 });
 
+describe('--include/--exclude globs and regexes', () => {
+    it('matchesFilters reads doublestar globs and re: patterns', () => {
+        const index = new ProjectIndex(PROJECT_DIR);
+        assert.ok(!index.matchesFilters('third_party/vendor/lib.go', { exclude: ['vendor/'] }), 'unanchored dir glob');
+        assert.ok(index.matchesFilters('src/vendor/lib.go', { exclude: ['/vendor/'] }), 'leading / anchors at the root');
+        assert.ok(!index.matchesFilters('api/v1/user.pb.go', { exclude: ['**/*.pb.go'] }));
+        assert.ok(!index.matchesFilters('pkg/model_gen.go', { exclude: ['re:_gen\\.go$'] }));
+        assert.ok(index.matchesFilters('pkg/generator.go', { exclude: ['re:_gen\\.go$'] }));
+        assert.ok(index.matchesFilters('src/a/b.js', { include: ['src/**'] }));
+        assert.ok(!index.matchesFilters('lib/b.js', { include: ['src/**', '*.ts'] }));
+        assert.ok(index.matchesFilters('lib/b.ts', { include: ['src/**', '*.ts'] }));
+    });
+
+    it('build skips files the path patterns leave out, before parsing', () => {
+        const dir = tmp({
+            'package.json': '{}',
+            'src/app.js': 'function app() {}\n',
+            'src/gen/out.js': 'function generated() {}\n',
+            'vendor/dep.js': 'function dep() {}\n',
+            'tools/build.js': 'function build() {}\n',
+        });
+        try {
+            const index = new ProjectIndex(dir);
+            index.build(null, { quiet: true, include: ['src/**', 'vendor/'], exclude: ['re:/gen/', 'vendor/', 'test'] });
+            const files = [...index.files.keys()].map(f => path.relative(dir, f).replace(/\\/g, '/'));
+            assert.deepStrictEqual(files, ['src/app.js']);
+        } finally {
+            rm(dir);
+        }
+    });
+});
+
+describe('build progress and debug log', () => {
+    it('reports phases, per-phase timings and debug events', () => {
+        const log = require('../core/log');
+        const dir = tmp({
+            'package.json': '{}',
+            'src/a.js': 'function a() { b(); }\n',
+            'src/b.js': 'function b() {}\n',
+        });
+        const lines = [];
+        log.configure({ verbosity: 1, format: 'json', write: line => lines.push(line) });
+        try {
+            const updates = [];
+            const index = new ProjectIndex(dir);
+            index.build(null, { quiet: true, progress: u => updates.push(u) });
+            const phases = [...new Set(updates.map(u => u.phase))];
+            assert.deepStrictEqual(phases, ['discover', 'parse', 'graphs', 'calls']);
+            const parsed = updates.filter(u => u.phase === 'parse').pop();
+            assert.strictEqual(parsed.done, parsed.total);
+            assert.deepStrictEqual(Object.keys(index.buildTimings), ['discover', 'parse', 'graphs', 'calls']);
+
+            const events = lines.map(l => JSON.parse(l));
+            assert.deepStrictEqual(events.filter(e => e.event === 'build.phase').map(e => e.phase),
+                ['discover', 'parse', 'graphs', 'calls']);
+            assert.ok(events.some(e => e.event === 'build.done'));
+            assert.ok(!events.some(e => e.level === 'trace'), '-v leaves out trace events');
+        } finally {
+            log.configure({ verbosity: 0, format: 'text', write: line => process.stderr.write(line + '\n') });
+            rm(dir);
+        }
+
+        // Without a terminal, progress is plain lines
+        const out = [];
+        const progress = log.createProgress({ stream: { write: t => out.push(t) }, intervalMs: 0 });
+        progress({ phase: 'parse', done: 1, total: 2, symbols: 3 });
+        progress.finish({ parse: 5 });
+        assert.deepStrictEqual(out, ['Parsing 1/2 files (50%), 3 symbols\n', 'Build phases: parse 5ms\n']);
+    });
+});
+
 describe('Diff Impact', () => {
     // FIX 108: parseDiff correctly extracts file paths and line ranges
     it('FIX 108 — parseDiff extracts file paths and line ranges from unified diff', () => {
@@ -1146,6 +1146,33 @@ describe('check command', () => {
             assert.strictEqual(deleted.account.safeToDelete, false);
         } finally { rm(dir); }
     });
+
+    it('checks an unsaved buffer in place of its file, against the rest of the project', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': 'function helper() { return 1; }\nmodule.exports = { helper };\n',
+            'app.js': 'const { helper } = require(\'./lib\');\nhelper();\n',
+        });
+        try {
+            const index = idx(dir);
+            const buffer = 'function helper() { return 1; }\nfunction draft() { return 2; }\nmodule.exports = { helper };\n';
+            const { ok, result } = execute(index, 'check', { content: buffer, file: 'lib.js' });
+            assert.ok(ok);
+            assert.deepStrictEqual([result.file, result.language, result.onDisk], ['lib.js', 'javascript', true]);
+            const findings = [...output.findingsOf('check', result)];
+            assert.deepStrictEqual(findings.map(f => [f.rule, f.at.startLine]), [['dead-code', 2]],
+                'draft is only in the buffer; helper is used from app.js');
+            assert.match(output.formatCheck(result), /lib\.js:2 {2}warning {2}dead-code/);
+            assert.strictEqual(fs.readFileSync(path.join(dir, 'lib.js'), 'utf-8').includes('draft'), false);
+            assert.strictEqual(index.saveCache(), null, 'an overlaid index is never cached');
+
+            const scratch = execute(idx(dir), 'check', { content: 'function scratch() {}\n', lang: 'js' }).result;
+            assert.strictEqual(scratch.file, 'ucn-stdin.js');
+            assert.strictEqual(scratch.onDisk, false);
+            assert.match(execute(idx(dir), 'check', { content: '' }).error, /--file=<path the buffer is saved as> or --lang/);
+            assert.match(execute(idx(dir), 'check', { content: '', lang: 'cobol' }).error, /Unknown language "cobol"/);
+        } finally { rm(dir); }
+    });
 });
 
 // ── auditAsync ────────────────────────────────────────────────────────────────
@@ -1224,6 +1251,913 @@ describe('audit-async behavioral', () => {
     });
 });
 
+// ── clones ──────────────────────────────────────────────────────────────────
+
+describe('clones behavioral', () => {
+    const body = (name, items, acc) => [
+        `function ${name}(${items}, rate) {`,
+        `    let ${acc} = 0;`,
+        `    for (const entry of ${items}) {`,
+        `        if (entry.status === 'void') continue;`,
+        `        ${acc} += entry.amount * entry.quantity;`,
+        `        if (entry.discount) ${acc} -= entry.discount;`,
+        '    }',
+        `    return Math.round(${acc} * (1 + rate) * 100) / 100;`,
+        '}',
+        `module.exports = { ${name} };`,
+    ].join('\n');
+    const FIXTURE = {
+        'package.json': '{"name":"test"}',
+        'src/orders/total.js': body('orderTotal', 'lines', 'sum'),
+        // Renamed identifiers and a changed literal still tokenize the same
+        'src/billing/invoice.js': body('invoiceTotal', 'rows', 'total').replace("'void'", "'cancelled'"),
+        // One changed statement: a near-miss copy
+        'src/cart/cart.js': body('cartTotal', 'items', 'acc').replace('continue', 'break'),
+        'src/other.js': [
+            'function unrelated(a, b) {',
+            '    const out = [];',
+            '    while (a < b) { out.push(a * 2); a++; }',
+            '    return out.join(",");',
+            '}',
+            'module.exports = { unrelated };',
+        ].join('\n'),
+    };
+
+    it('groups renamed and near-miss copies across packages', () => {
+        const dir = tmp(FIXTURE);
+        try {
+            const index = idx(dir);
+            const { ok, result } = execute(index, 'clones', { minTokens: 20 });
+            assert.ok(ok);
+            assert.strictEqual(result.groups.length, 1);
+            const [group] = result.groups;
+            assert.deepStrictEqual(group.members.map(m => m.name), ['invoiceTotal', 'cartTotal', 'orderTotal']);
+            assert.strictEqual(group.packages, 3);
+            assert.strictEqual(group.exact, false);
+            assert.ok(group.similarity >= 0.9 && group.similarity < 1);
+            assert.match(output.formatClones(result), /3 functions, \d+% similar, across 3 packages/);
+        } finally { rm(dir); }
+    });
+
+    it('similarity and size thresholds narrow the groups', () => {
+        const dir = tmp(FIXTURE);
+        try {
+            const index = idx(dir);
+            const strict = execute(index, 'clones', { minTokens: 20, similarity: 1 }).result;
+            assert.strictEqual(strict.groups.length, 1);
+            assert.deepStrictEqual(strict.groups[0].members.map(m => m.name), ['invoiceTotal', 'orderTotal']);
+            assert.strictEqual(strict.groups[0].exact, true);
+            assert.strictEqual(execute(index, 'clones', { minTokens: 20, minLines: 20 }).result.groups.length, 0);
+            assert.strictEqual(execute(index, 'clones', { minTokens: 500 }).result.groups.length, 0);
+            const touching = execute(index, 'clones', { minTokens: 20, file: 'other.js' }).result;
+            assert.strictEqual(touching.groups.length, 0);
+        } finally { rm(dir); }
+    });
+
+    it('rejects invalid thresholds', () => {
+        const dir = tmp(FIXTURE);
+        try {
+            const index = idx(dir);
+            assert.match(execute(index, 'clones', { similarity: 1.5 }).error, /--similarity/);
+            assert.match(execute(index, 'clones', { minLines: 0 }).error, /--min-lines/);
+        } finally { rm(dir); }
+    });
+
+    it('--literals lists values copy-pasted across files', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'src/config.js': "const JSON_TYPE = 'application/json';\nmodule.exports = { JSON_TYPE };",
+            'src/a.js': "const fs = require('fs');\nfunction a(res) { res.set('Content-Type', 'application/json'); return 8080; }\nmodule.exports = { a };",
+            'src/b.js': "function b(res) { res.type('application/json'); return 8080; }\nmodule.exports = { b };",
+            'src/c.js': "const fs = require('fs');\nfunction c() { return 'application/json'; }\nmodule.exports = { c };",
+        });
+        try {
+            const index = idx(dir);
+            const { ok, result } = execute(index, 'clones', { literals: true });
+            assert.ok(ok);
+            assert.deepStrictEqual(result.literals.map(l => [l.value, l.files, l.count]), [['application/json', 4, 4]]);
+            assert.deepStrictEqual(result.literals[0].constant, { name: 'JSON_TYPE', file: 'src/config.js', line: 1 });
+            assert.match(output.formatClones(result), /"application\/json" \(string\) — 4 occurrence\(s\) in 4 files; constant JSON_TYPE/);
+            const loose = execute(index, 'clones', { literals: true, minFiles: 2 }).result;
+            assert.deepStrictEqual(loose.literals.map(l => l.value), ['application/json', '8080']);
+            assert.match(execute(index, 'clones', { minFiles: 0 }).error, /--min-files/);
+        } finally { rm(dir); }
+    });
+});
+
+// ── deadcode --complexity ───────────────────────────────────────────────────
+
+describe('deadcode --complexity behavioral', () => {
+    const FIXTURE = {
+        'package.json': '{"name":"test"}',
+        'lib.js': [
+            'function grade(score, curve) {',
+            '    if (score > 90 && curve) {',
+            "        return 'A';",
+            '    } else if (score > 80) {',
+            "        return 'B';",
+            '    } else {',
+            '        for (const c of curve || []) {',
+            '            if (c) return c;',
+            '        }',
+            '    }',
+            "    return score > 50 ? 'C' : 'F';",
+            '}',
+            'function unusedHelper(x) { return x && x.y; }',
+            'module.exports = { grade };',
+        ].join('\n'),
+        'app.js': "const { grade } = require('./lib');\nconsole.log(grade(95, true));\n",
+    };
+
+    it('adds metrics to dead functions and reports live ones over threshold', () => {
+        const dir = tmp(FIXTURE);
+        try {
+            const index = idx(dir);
+            const { ok, result } = execute(index, 'deadcode', { complexity: true, maxCognitive: 10 });
+            assert.ok(ok);
+            const helper = result.find(d => d.name === 'unusedHelper');
+            assert.deepStrictEqual([helper.cyclomatic, helper.cognitive], [2, 1]);
+            assert.deepStrictEqual(result.complexityThresholds, { cyclomatic: 10, cognitive: 10 });
+            assert.deepStrictEqual(result.complexityFindings.map(f => [f.name, f.cyclomatic, f.cognitive]), [['grade', 8, 11]]);
+            assert.match(output.formatDeadcode(result), /Complexity over threshold \(cyclomatic > 10 or cognitive > 10\): 1 function\(s\)/);
+            assert.strictEqual(execute(index, 'deadcode', { complexity: true }).result.complexityFindings.length, 0);
+        } finally { rm(dir); }
+    });
+
+    it('rejects invalid thresholds', () => {
+        const dir = tmp(FIXTURE);
+        try {
+            const index = idx(dir);
+            assert.match(execute(index, 'deadcode', { maxCyclomatic: -1 }).error, /--max-cyclomatic/);
+        } finally { rm(dir); }
+    });
+});
+
+// ── deadcode --size ─────────────────────────────────────────────────────────
+
+describe('deadcode --size behavioral', () => {
+    const FIXTURE = {
+        'package.json': '{"name":"test"}',
+        '.ucn.json': JSON.stringify({ size: { functionLines: 100, javascript: { functionLines: 6 } } }),
+        'lib.js': [
+            'class Store {',
+            '    get(k) { return this.m[k]; }',
+            '    set(k, v) { this.m[k] = v; }',
+            '    del(k) { delete this.m[k]; }',
+            '}',
+            'function load(store) {',
+            "    store.set('a', 1);",
+            "    store.set('b', 2);",
+            "    store.set('c', 3);",
+            "    store.del('a');",
+            "    return store.get('b');",
+            '}',
+            'module.exports = { Store, load };',
+        ].join('\n'),
+    };
+
+    it('reports long functions and types with too many members', () => {
+        const dir = tmp(FIXTURE);
+        try {
+            const index = idx(dir);
+            const { ok, result } = execute(index, 'deadcode', { size: true, maxMethods: 2 });
+            assert.ok(ok);
+            assert.deepStrictEqual(result.sizeFindings.map(f => [f.name, f.over.map(o => `${o.metric}:${o.value}>${o.limit}`)]), [
+                ['Store', ['methods:3>2']],
+                ['load', ['lines:7>6']],
+            ]);
+            assert.match(output.formatDeadcode(result), /load \(function\) 7 lines > 6/);
+            const statements = execute(index, 'deadcode', { maxStatements: 4, maxFunctionLines: 50 }).result.sizeFindings;
+            assert.deepStrictEqual(statements.map(f => [f.name, f.over.map(o => o.metric)]), [['load', ['statements']]]);
+        } finally { rm(dir); }
+    });
+});
+
+// ── SQLite export ───────────────────────────────────────────────────────────
+
+describe('results database export', () => {
+    it('scripts findings, symbols and call edges for one run', () => {
+        const dir = tmp(SIMPLE_FIXTURE);
+        try {
+            const index = idx(dir);
+            const { resultsDbScript } = require('../core/results-db');
+            const { result } = execute(index, 'deadcode', {});
+            const script = resultsDbScript(index, 'deadcode', result);
+            assert.match(script, /CREATE TABLE IF NOT EXISTS findings \(/);
+            assert.match(script, /^INSERT INTO runs \(command, root, ucn_version, created_at\) VALUES \('deadcode', /m);
+            assert.match(script, /INSERT INTO findings VALUES \(\(SELECT max\(id\) FROM runs\), 'dead-code', 'warning', 'warning', 'high', 'lib\.js', 2, 2, 'unused', /);
+            assert.match(script, /INSERT INTO symbols VALUES \(\(SELECT max\(id\) FROM runs\), 'lib\.js:1', 'helper', NULL, 'function', 'lib\.js', 1, 1, 1\);/);
+            assert.match(script, /INSERT INTO refs VALUES \(\(SELECT max\(id\) FROM runs\), 'app\.js:2', 'lib\.js:1', 1\);/);
+            assert.match(script, /COMMIT;$/);
+
+            let sqlite = null;
+            try { sqlite = require('node:sqlite'); } catch { /* Node < 22.5 */ }
+            if (sqlite) {
+                const db = new sqlite.DatabaseSync(':memory:');
+                db.exec(script);
+                db.exec(script);
+                assert.strictEqual(db.prepare('SELECT count(*) AS n FROM runs').get().n, 2);
+                assert.deepStrictEqual({ ...db.prepare("SELECT symbol FROM findings WHERE run_id = 2 AND rule = 'dead-code'").get() }, { symbol: 'unused' });
+                db.close();
+            }
+        } finally { rm(dir); }
+    });
+});
+
+describe('baseline', () => {
+    it('hides the findings a baseline holds and shows new ones', () => {
+        const dir = tmp(SIMPLE_FIXTURE);
+        try {
+            const { createBaseline, parseBaseline, applyBaseline } = require('../core/baseline');
+            const { findingFingerprint } = require('../core/output/sarif');
+            const before = execute(idx(dir), 'deadcode', {}).result;
+            const baseline = createBaseline('deadcode', before, null);
+            assert.strictEqual(baseline.version, 2);
+            assert.deepStrictEqual(baseline.findings.map(e => [e.command, e.fingerprint, e.file]),
+                [['deadcode', findingFingerprint('dead-code', 'lib.js:unused:function'), 'lib.js']]);
+            assert.match(baseline.findings[0].fingerprint, /^[0-9a-f]{16}$/);
+
+            // Code moves and a new dead function appears
+            fs.writeFileSync(path.join(dir, 'lib.js'), `
+// moved down a line
+function helper(x) { return x + 1; }
+function unused() { return 42; }
+function alsoUnused() { return 7; }
+module.exports = { helper };
+`);
+            const after = execute(idx(dir), 'deadcode', {}).result;
+            const { result, suppressed } = applyBaseline('deadcode', after, parseBaseline(JSON.stringify(baseline)).baseline);
+            assert.strictEqual(suppressed, 1);
+            assert.deepStrictEqual(result.map(r => r.name), ['alsoUnused']);
+            assert.strictEqual(result.excludedExported, after.excludedExported, 'result keeps its summary fields');
+            assert.match(output.formatDeadcode(result), /alsoUnused/);
+
+            // A version 1 baseline, with unhashed fingerprints, is refused
+            const v1 = { version: 1, findings: [{ command: 'deadcode', fingerprint: 'dead-code:lib.js:unused:function' }] };
+            assert.match(parseBaseline(JSON.stringify(v1)).error, /re-create it with ucn baseline create/);
+
+            // Other commands' entries survive re-creating for one command
+            const merged = createBaseline('clones', { groups: [] }, baseline);
+            assert.strictEqual(merged.findings.length, 1);
+            assert.match(parseBaseline('{"version": 9, "findings": []}').error, /newer/);
+            assert.match(parseBaseline('[]').error, /findings/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('--sort and --group-by', () => {
+    it('reorders findings in place and splits them into groups', () => {
+        const { arrangeFindings } = require('../core/arrange');
+        const item = (name, file, startLine, endLine, extra) => ({ name, type: 'function', file, startLine, endLine, ...extra });
+        const result = Object.assign([
+            item('small', 'pkg/a/x.js', 1, 2),
+            item('big', 'pkg/b/y.js', 10, 40),
+            item('mid', 'pkg/a/z.js', 5, 14),
+            item('p', 'pkg/b/y.js', 3, 3, { isParam: true }),
+        ], { excludedExported: 2 });
+
+        const bySaved = arrangeFindings('deadcode', result, { sort: 'loc-saved' }).result;
+        assert.deepStrictEqual(bySaved.map(r => r.name), ['big', 'mid', 'small', 'p']);
+        assert.strictEqual(bySaved.excludedExported, 2, 'result keeps its summary fields');
+        assert.deepStrictEqual(result.map(r => r.name), ['small', 'big', 'mid', 'p'], 'input is not mutated');
+        assert.deepStrictEqual(arrangeFindings('deadcode', result, { sort: 'symbol' }).result.map(r => r.name),
+            ['big', 'mid', 'p', 'small']);
+
+        const { result: grouped, groups } = arrangeFindings('deadcode', result, { groupBy: 'package', sort: 'file' });
+        assert.deepStrictEqual(grouped.map(r => r.name), ['small', 'mid', 'p', 'big']);
+        assert.deepStrictEqual(groups.map(g => [g.key, g.count, g.result.map(r => r.name)]),
+            [['pkg/a', 2, ['small', 'mid']], ['pkg/b', 2, ['p', 'big']]]);
+        assert.match(output.formatDeadcode(groups[1].result), /big/);
+    });
+
+    it('groups by CODEOWNERS owner, unowned last', () => {
+        const dir = tmp({
+            '.github/CODEOWNERS': '# owners\n* @core\n/pkg/api/ @api-team @lead\ndocs @writers\n',
+        });
+        try {
+            const { arrangeFindings } = require('../core/arrange');
+            const result = {
+                issues: [
+                    { file: 'pkg/api/h.js', line: 3, calleeName: 'save', callerName: 'handle' },
+                    { file: 'lib/u.js', line: 1, calleeName: 'load', callerName: 'run' },
+                    { file: 'pkg/api/v1/g.js', line: 9, calleeName: 'fetch', callerName: 'get' },
+                ],
+            };
+            const { groups } = arrangeFindings('auditAsync', result, { groupBy: 'owner', root: dir });
+            assert.deepStrictEqual(groups.map(g => [g.key, g.result.issues.map(i => i.calleeName)]),
+                [['@api-team @lead', ['save', 'fetch']], ['@core', ['load']]]);
+
+            fs.rmSync(path.join(dir, '.github/CODEOWNERS'));
+            const unowned = arrangeFindings('auditAsync', result, { groupBy: 'owner', root: dir }).groups;
+            assert.deepStrictEqual(unowned.map(g => [g.key, g.count]), [['(unowned)', 3]]);
+        } finally { rm(dir); }
+    });
+});
+
+describe('inline suppressions', () => {
+    it('hides findings a ucn:ignore comment names and reports stale comments', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': `
+// ucn:ignore[dead-code] kept for the plugin API
+function unused() { return 42; }
+function legacy() { return 1; } // ucn:ignore[DeadCode]
+/**
+ * Old helper
+ * ucn:ignore[dead-code]
+ */
+function stillDead() { return 2; }
+function helper(x) { return x + 1; }
+// ucn:ignore[dead-code]
+module.exports = { helper };
+// ucn:ignore[dead-cod]
+function typo() { return 3; }
+`,
+            'app.js': `const { helper } = require('./lib');\nhelper(1);\n`,
+        });
+        try {
+            const { ok, result } = execute(idx(dir), 'deadcode', {});
+            assert.ok(ok);
+            assert.deepStrictEqual(result.map(r => r.name), ['typo']);
+            const { active, stale } = result.suppressions;
+            assert.deepStrictEqual(active.map(s => [s.line, s.hidden]), [[2, 1], [4, 1], [7, 1]]);
+            assert.strictEqual(active[0].reason, 'kept for the plugin API');
+            assert.deepStrictEqual(stale.map(s => [s.line, s.unknownRules || null]), [[11, null], [13, ['dead-cod']]]);
+
+            const text = output.formatDeadcode(result);
+            assert.match(text, /Suppressions: 3 active, 2 stale/);
+            assert.match(text, /lib\.js:11 ucn:ignore\[dead-code\] \[stale: hid nothing — remove it\]/);
+            assert.match(text, /lib\.js:13 ucn:ignore\[dead-cod\] \[stale: unknown rule dead-cod\]/);
+            assert.deepStrictEqual(JSON.parse(output.formatDeadcodeJson(result)).data.suppressions, result.suppressions);
+
+            // A rule this run didn't check is neither active nor stale
+            const clones = execute(idx(dir), 'clones', {}).result;
+            assert.deepStrictEqual(clones.suppressions.stale.map(s => s.line), [13]);
+        } finally { rm(dir); }
+    });
+
+    it('reads directives only in comments', () => {
+        const { parseSuppressions } = require('../core/suppress');
+        const js = [
+            "const usage = '// ucn:ignore[dead-code]';",
+            'const hint = "ucn:ignore";',
+            '/**',
+            ' * ucn:ignore[dead-code] plugin hook',
+            ' */',
+            'function hook() {}',
+            'function inline() {} /* ucn:ignore[dead-code] */',
+        ].join('\n');
+        assert.deepStrictEqual(parseSuppressions('a.js', js, 'javascript').map(s => [s.line, s.from, s.to, s.reason]),
+            [[4, 4, 6, 'plugin hook'], [7, 7, 7, '']]);
+        const py = 'x = "# ucn:ignore[dead-code]"\ndef f(): pass  # ucn: ignore[unused-param]\n';
+        assert.deepStrictEqual(parseSuppressions('a.py', py, 'python').map(s => [s.line, s.rules]), [[2, ['unused-param']]]);
+        // `//` is floor division in Python, not a comment
+        assert.deepStrictEqual(parseSuppressions('a.py', 'n = a // ucn:ignore\n', 'python'), []);
+    });
+});
+
+describe('finding fingerprints', () => {
+    it('stay the same across OSes and clone order, and differ by rule and symbol', () => {
+        const { findingFingerprint } = require('../core/output/sarif');
+        assert.strictEqual(findingFingerprint('dead-code', 'src\\lib.js:unused:function'),
+            findingFingerprint('dead-code', 'src/lib.js:unused:function'));
+        assert.strictEqual(findingFingerprint('clone', 'b.js:f|a.js:g'), findingFingerprint('clone', 'a.js:g|b.js:f'));
+        assert.notStrictEqual(findingFingerprint('dead-code', 'lib.js:unused:function'),
+            findingFingerprint('dead-export', 'lib.js:unused:function'));
+        assert.notStrictEqual(findingFingerprint('dead-code', 'lib.js:A.run:method'),
+            findingFingerprint('dead-code', 'lib.js:B.run:method'));
+    });
+
+    it('tell apart same-named parameters and the unreachable blocks of one function', () => {
+        const { findingsOf } = require('../core/output/sarif');
+        const items = [
+            { name: 'ctx', type: 'parameter', functionName: 'a', file: 'lib.js', startLine: 1, endLine: 3 },
+            { name: 'ctx', type: 'parameter', functionName: 'b', file: 'lib.js', startLine: 5, endLine: 7 },
+            { name: 'run', type: 'unreachable', file: 'lib.js', startLine: 10, endLine: 10 },
+            { name: 'run', type: 'unreachable', file: 'lib.js', startLine: 14, endLine: 15 },
+        ];
+        const fingerprints = [...findingsOf('deadcode', items)].map(f => f.fingerprint);
+        assert.strictEqual(new Set(fingerprints).size, 4, fingerprints.join(' '));
+
+        // Lines shifting leaves them as they were
+        const moved = items.map(i => ({ ...i, startLine: i.startLine + 3, endLine: i.endLine + 3 }));
+        assert.deepStrictEqual([...findingsOf('deadcode', moved)].map(f => f.fingerprint), fingerprints);
+    });
+
+    it('hides findings .ucn.json "ignore" lists, wherever they move', () => {
+        const { findingFingerprint } = require('../core/output/sarif');
+        const fp = findingFingerprint('dead-code', 'lib.js:unused:function');
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            '.ucn.json': JSON.stringify({ ignore: [
+                { fingerprint: fp, rule: 'dead-code', reason: 'called from the plugin host' },
+                { fingerprint: '0123456789abcdef', rule: 'dead-code' },
+            ] }),
+            'lib.js': `function helper(x) { return x + 1; }\nfunction unused() { return 42; }\nfunction alsoUnused() { return 7; }\nmodule.exports = { helper };\n`,
+            'app.js': `const { helper } = require('./lib');\nhelper(1);\n`,
+        });
+        try {
+            const { result } = execute(idx(dir), 'deadcode', { includeTests: true });
+            assert.deepStrictEqual(result.map(r => r.name), ['alsoUnused']);
+            const { active, stale } = result.suppressions;
+            assert.deepStrictEqual(active.map(s => [s.fingerprint, s.hidden, s.reason]), [[fp, 1, 'called from the plugin host']]);
+            assert.deepStrictEqual(stale.map(s => s.fingerprint), ['0123456789abcdef']);
+            const text = output.formatDeadcode(result);
+            assert.match(text, new RegExp(`\\.ucn\\.json ignore ${fp}\\[dead-code\\] hid 1 finding`));
+
+            // Moving the function doesn't change its fingerprint
+            fs.writeFileSync(path.join(dir, 'lib.js'), `\n\nfunction helper(x) { return x + 1; }\nfunction alsoUnused() { return 7; }\nfunction unused() { return 42; }\nmodule.exports = { helper };\n`);
+            assert.deepStrictEqual(execute(idx(dir), 'deadcode', {}).result.map(r => r.name), ['alsoUnused']);
+
+            // A run that skips tests can't call an entry stale
+            assert.strictEqual(execute(idx(dir), 'deadcode', {}).result.suppressions.stale.length, 0);
+        } finally { rm(dir); }
+    });
+});
+
+describe('rules and explain', () => {
+    it('lists every rule with its defaults and .ucn.json settings', () => {
+        const dir = tmp({ ...SIMPLE_FIXTURE, '.ucn.json': JSON.stringify({ rules: { 'dead-code': 'error', clone: 'off' } }) });
+        try {
+            const { ok, result } = execute(idx(dir), 'rules', {});
+            assert.ok(ok);
+            const byId = new Map(result.rules.map(r => [r.id, r]));
+            assert.strictEqual(byId.size, Object.keys(require('../core/output/sarif').RULES).length);
+            assert.deepStrictEqual(byId.get('unused-param'), {
+                id: 'unused-param', name: 'UnusedParameter', description: 'Function parameter never read',
+                level: 'warning', severity: 'warning', confidence: 'high', command: 'deadcode', flag: '--unused-params',
+            });
+            assert.deepStrictEqual(byId.get('dead-code').configured, { severity: 'error' });
+            assert.deepStrictEqual(byId.get('clone').configured, { enabled: false });
+            assert.strictEqual(byId.get('missing-await').command, 'audit-async');
+            assert.match(output.formatRules(result), /unused-param\s+warning\s+ucn deadcode --unused-params/);
+        } finally { rm(dir); }
+    });
+
+    it('explains a rule by id or name, and rejects what is neither', () => {
+        const dir = tmp(SIMPLE_FIXTURE);
+        try {
+            const index = idx(dir);
+            assert.strictEqual(execute(index, 'explain', { name: 'DeadCode' }).result.rule.id, 'dead-code');
+            assert.match(output.formatExplain(execute(index, 'explain', { name: 'dead-code' }).result), /ucn:ignore\[dead-code\]/);
+            assert.match(execute(index, 'explain', { name: 'nonsense' }).error, /neither a rule nor a finding fingerprint/);
+            assert.match(execute(index, 'explain', { name: '0123456789abcdef' }).error, /No finding with fingerprint/);
+        } finally { rm(dir); }
+    });
+
+    it('explains a dead-code finding with its checks and caller trace', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': `function helper(x) { return x + 1; }\nfunction orphan() { return leaf(); }\nfunction leaf() { return 2; }\nmodule.exports = { helper };\n`,
+            'app.js': `const { helper } = require('./lib');\nhelper(1);\n`,
+        });
+        try {
+            const { findingFingerprint } = require('../core/output/sarif');
+            const index = idx(dir);
+            const { ok, result } = execute(index, 'explain', { name: findingFingerprint('dead-code', 'lib.js:orphan:function') });
+            assert.ok(ok, 'orphan is dead and found by its fingerprint');
+            assert.strictEqual(result.command, 'deadcode');
+            assert.deepStrictEqual([result.finding.file, result.finding.startLine], ['lib.js', 2]);
+            assert.ok(result.why.checks.every(c => !c.passed));
+            assert.strictEqual(result.why.reachability.reachable, false);
+            assert.strictEqual(result.why.reachability.trace.name, 'orphan');
+            assert.deepStrictEqual(result.why.reachability.trace.callers, []);
+
+            const text = output.formatExplain(result);
+            assert.match(text, /Checks that would have kept it:/);
+            assert.match(text, /Reachability: not reachable/);
+            assert.match(text, /orphan \(lib\.js:2\) — unreachable, no callers/);
+            assert.strictEqual(JSON.parse(output.formatExplainJson(result)).meta.fingerprint, result.fingerprint);
+        } finally { rm(dir); }
+    });
+});
+
+describe('why', () => {
+    const WHY_FIXTURE = {
+        'package.json': '{"name":"test"}',
+        'lib.js': `function helper(x) { return step(x); }\nfunction step(x) { return x + 1; }\nfunction orphan() { return leaf(); }\nfunction leaf() { return 2; }\nmodule.exports = { helper, leaf };\n`,
+        'app.js': `const { helper } = require('./lib');\nfunction main() { return helper(1); }\nmain();\n`,
+    };
+
+    it('prints the shortest chain from a reachability root', () => {
+        const dir = tmp(WHY_FIXTURE);
+        try {
+            const { ok, result } = execute(idx(dir), 'why', { name: 'step' });
+            assert.ok(ok);
+            assert.strictEqual(result.reachable, true);
+            assert.deepStrictEqual(result.chain.map(s => s.name), ['main', 'helper', 'step']);
+            assert.ok(result.via, 'says why main is a root');
+            const text = output.formatWhy(result);
+            assert.match(text, /Reachable from main/);
+            assert.match(text, /→ step \(lib\.js:2\)/);
+        } finally { rm(dir); }
+    });
+
+    it('says there is no chain and lists the direct callers', () => {
+        const dir = tmp(WHY_FIXTURE);
+        try {
+            const index = idx(dir);
+            const { result } = execute(index, 'why', { name: 'leaf' });
+            assert.strictEqual(result.reachable, false);
+            assert.deepStrictEqual(result.chain, []);
+            assert.deepStrictEqual(result.callers.map(c => c.name), ['orphan']);
+            assert.match(output.formatWhy(result), /Not reachable[\s\S]*← orphan \(lib\.js:3\)/);
+            assert.strictEqual(JSON.parse(output.formatWhyJson(result)).meta.reachable, false);
+            assert.match(execute(index, 'why', { name: 'missing' }).error, /not found/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('diff against a base ref', () => {
+    it('reports only findings introduced since the ref, and lists the resolved', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': 'function old() { return 1; }\nfunction gone() { return 2; }\nfunction helper() { return 3; }\nmodule.exports = { helper };\n',
+        });
+        try {
+            execFileSync('git', ['init'], { cwd: dir, stdio: 'pipe' });
+            execFileSync('git', ['add', '.'], { cwd: dir, stdio: 'pipe' });
+            execFileSync('git', ['-c', 'user.email=test@test.com', '-c', 'user.name=Test', 'commit', '-m', 'init'], { cwd: dir, stdio: 'pipe' });
+
+            // old moves down a line, gone is deleted, brandNew is dead on arrival
+            fs.writeFileSync(path.join(dir, 'lib.js'),
+                '// header\nfunction old() { return 1; }\nfunction brandNew() { return 4; }\nfunction helper() { return 3; }\nmodule.exports = { helper };\n');
+
+            const { ok, result } = execute(idx(dir), 'deadcode', { diffBase: 'HEAD' });
+            assert.ok(ok);
+            assert.deepStrictEqual(result.map(r => r.name), ['brandNew']);
+            assert.strictEqual(result.diff.introduced, 1);
+            assert.deepStrictEqual(result.diff.resolved.map(r => [r.file, r.line]), [['lib.js', 2]]);
+            assert.match(output.formatDeadcode(result), /Compared with HEAD: 1 introduced, 1 resolved\n {2}resolved lib\.js:2 .*gone/);
+            assert.deepStrictEqual(execFileSync('git', ['status', '--porcelain'], { cwd: dir, encoding: 'utf-8' }), ' M lib.js\n',
+                'the base checkout leaves the repository alone');
+
+            const bad = execute(idx(dir), 'deadcode', { diffBase: 'no-such-branch' });
+            assert.strictEqual(bad.ok, false);
+            assert.match(bad.error, /Unknown git ref: no-such-branch/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('ucn fix', () => {
+    const CLI = path.join(__dirname, '..', 'cli', 'index.js');
+    const LIB = [
+        'function used() { return 1; }',
+        'function twoA() { return 2; } function twoB() { return 3; }',
+        '',
+        '// Nothing calls this',
+        'function helper() {',
+        '    return used();',
+        '}',
+        '',
+        'module.exports = { used };',
+        '',
+    ].join('\n');
+
+    it('plans whole-line removals with doc comments, and skips code sharing a line', () => {
+        const { planFixes, fixedFiles } = require('../core/fix');
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': LIB });
+        try {
+            const index = idx(dir);
+            const { result } = execute(index, 'deadcode', {});
+            const { fixes, skipped } = planFixes(index, result);
+            assert.deepStrictEqual(fixes.map(f => [f.item.name, f.ranges]), [['helper', [[4, 8]]]]);
+            assert.deepStrictEqual(skipped.map(s => [s.item.name, s.reason]),
+                [['twoA', 'it shares a line with other code'], ['twoB', 'it shares a line with other code']]);
+            assert.strictEqual(fixedFiles(index, fixes)[0].after,
+                'function used() { return 1; }\nfunction twoA() { return 2; } function twoB() { return 3; }\n\nmodule.exports = { used };\n');
+        } finally { rm(dir); }
+    });
+
+    it('finds the imports only the removed lines use, by the syntax tree', () => {
+        const { orphanedImports } = require('../core/fix');
+        const src = [
+            "const fs = require('fs');",
+            "const path = require('path');",
+            "const os = require('os');",
+            '',
+            '// fs and path are read in helper only',
+            'function helper() {',
+            '    return fs.readFileSync(path.join(os.tmpdir(), "x"));',
+            '}',
+            '',
+            "module.exports = { name: 'path', dir: os.tmpdir() };",
+            '',
+        ].join('\n');
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': src });
+        try {
+            assert.deepStrictEqual(orphanedImports(idx(dir), 'lib.js', [[5, 9]]), [[1, 1], [2, 2]],
+                'a name in a comment or a string is no use; os is still used');
+        } finally { rm(dir); }
+    });
+
+    it('--dry-run prints a unified diff and writes nothing; plain fix writes', () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': LIB });
+        try {
+            const run = (...a) => execFileSync('node', [CLI, 'fix', '--no-cache', ...a], { cwd: dir, encoding: 'utf-8', stdio: ['pipe', 'pipe', 'pipe'] });
+            const diff = run('--dry-run');
+            assert.match(diff, /^--- a\/lib\.js\n\+\+\+ b\/lib\.js\n@@ -1,10 \+1,5 @@\n/);
+            assert.match(diff, /\n-function helper\(\) \{\n/);
+            assert.strictEqual(fs.readFileSync(path.join(dir, 'lib.js'), 'utf-8'), LIB);
+
+            assert.match(run(), /Removed function helper \(lib\.js:5\)\n1 removal\(s\) in 1 file\(s\)/);
+            assert.doesNotMatch(fs.readFileSync(path.join(dir, 'lib.js'), 'utf-8'), /helper/);
+        } finally { rm(dir); }
+    });
+
+    it('deadcode --format patch prints the removals for git apply and writes nothing', () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': LIB });
+        try {
+            const run = (...a) => execFileSync('node', [CLI, ...a, '--no-cache'], { cwd: dir, encoding: 'utf-8', stdio: ['pipe', 'pipe', 'pipe'] });
+            const patch = run('deadcode', '--format', 'patch');
+            assert.strictEqual(patch, run('fix', '--dry-run'));
+            assert.match(patch, /^--- a\/lib\.js\n/);
+            assert.strictEqual(fs.readFileSync(path.join(dir, 'lib.js'), 'utf-8'), LIB);
+            assert.throws(() => run('clones', '--format', 'patch'), /--format patch applies to deadcode findings/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('watch', () => {
+    it('compares findings by fingerprint, so a finding that moved is neither new nor resolved', () => {
+        const { compareFindings } = require('../core/watch');
+        const dead = (name, line) => ({ name, type: 'function', file: 'lib.js', startLine: line, endLine: line, isExported: false, usageCount: 0 });
+        const { total, introduced, resolved } = compareFindings('deadcode', [dead('gone', 1), dead('kept', 2)], [dead('kept', 5), dead('fresh', 6)]);
+        assert.strictEqual(total, 2);
+        assert.deepStrictEqual(introduced.map(f => f.at.name), ['fresh']);
+        assert.deepStrictEqual(resolved.map(f => f.at.name), ['gone']);
+    });
+
+    it('re-runs the command on the incrementally rebuilt index when a file changes', async () => {
+        const { watchProject } = require('../core/watch');
+        const dir = tmp(SIMPLE_FIXTURE);
+        let watch;
+        try {
+            const update = await new Promise((resolve, reject) => {
+                watch = watchProject(idx(dir), ix => execute(ix, 'deadcode', {}).result,
+                    { command: 'deadcode', onUpdate: resolve, onError: reject, settleMs: 50 });
+                fs.appendFileSync(path.join(dir, 'lib.js'), 'function lateArrival() { return 0; }\n');
+            });
+            assert.deepStrictEqual(update.changed, ['lib.js']);
+            assert.deepStrictEqual(update.introduced.map(f => f.at.name), ['lateArrival']);
+            assert.deepStrictEqual(update.resolved, []);
+            assert.strictEqual(update.total, 2);
+        } finally {
+            watch?.close();
+            rm(dir);
+        }
+    });
+});
+
+describe('tui', () => {
+    it('groups findings, previews their code, and ignores them by comment or baseline', () => {
+        const { createTui } = require('../core/tui');
+        const dir = tmp(SIMPLE_FIXTURE);
+        try {
+            const index = idx(dir);
+            const baseline = path.join(dir, 'known.json');
+            const tui = createTui(index, 'deadcode', execute(index, 'deadcode', {}).result, { baseline });
+            const screen = () => tui.render(20, 100).join('\n').replace(/\x1b\[\d+m/g, '');
+            assert.match(screen(), /▸ \(root\) \(1\)/);
+            tui.key('return');
+            tui.key('down');
+            assert.match(screen(), /lib\.js:2 {2}.*unused/);
+            assert.match(screen(), /▶2 │ function unused\(\) \{ return 42; \}/);
+
+            tui.key('i');
+            assert.match(fs.readFileSync(path.join(dir, 'lib.js'), 'utf-8'), /\n\/\/ ucn:ignore\[dead-code\]\nfunction unused/);
+            assert.match(screen(), /lib\.js:3 {2}.*unused.*\[ignored: comment\]/, 'later lines move down');
+            tui.key('b');
+            assert.match(screen(), /Already ignored \(comment\)/);
+            assert.strictEqual(fs.existsSync(baseline), false);
+
+            tui.key('g');
+            assert.match(screen(), /▸ dead-code \(DeadCode\) \(1\)/);
+            tui.key('return');
+            tui.key('down');
+            tui.key('r');
+            assert.match(screen(), /References of unused/);
+            tui.key('left');
+            assert.strictEqual(tui.key('q'), 'quit');
+        } finally { rm(dir); }
+    });
+});
+
+describe('config files', () => {
+    it('reads YAML and TOML and merges directory configs upward', () => {
+        const { parseYaml, parseToml } = require('../core/config');
+        assert.deepStrictEqual(parseYaml('rules:\n  dead-code: off  # noisy\nexclude:\n  - "gen/"\n  - legacy\nsize: { functionLines: 60 }\n'),
+            { rules: { 'dead-code': 'off' }, exclude: ['gen/', 'legacy'], size: { functionLines: 60 } });
+        assert.deepStrictEqual(parseToml('root = true\n[rules.clone]\nseverity = "error"\n[[entryPoints]]\nname = \'main\'\n'),
+            { root: true, rules: { clone: { severity: 'error' } }, entryPoints: [{ name: 'main' }] });
+        assert.throws(() => parseYaml('a: |\n  text\n'), /line 1: block scalars/);
+
+        const dir = tmp({
+            ...SIMPLE_FIXTURE,
+            '.ucn.yaml': 'rules:\n  dead-code: warning\ncomplexity:\n  cyclomatic: 12\n',
+            'sub/.ucn.yml': 'exclude: [gen/]\nrules: { dead-code: "off" }\ncomplexity: { cognitive: 3 }\ngrammars: []\n',
+            'sub/gen/out.js': 'function generated() {}\n',
+            'sub/mod.js': 'function unusedInSub() {}\n',
+            'sub/own/ucn.toml': 'root = true\nentryPoints = ["runCli", { file = "hooks/*.js" }]\n[rules]\nclone = "error"\n',
+            'sub/own/cli.js': 'function runCli() {}\nfunction staleInOwn() {}\n',
+            'sub/own/hooks/pre.js': 'function preHook() {}\n',
+        });
+        try {
+            const index = idx(dir);
+            assert.deepStrictEqual(index.configFor('lib.js'), { rules: { 'dead-code': 'warning' }, complexity: { cyclomatic: 12 } });
+            assert.deepStrictEqual(index.configFor('sub/mod.js'), {
+                rules: { 'dead-code': 'off' }, complexity: { cyclomatic: 12, cognitive: 3 }, exclude: ['gen/'],
+            }, 'nearest wins, objects merge, root-only keys are ignored');
+            assert.deepStrictEqual(index.configFor('sub/own/x.js'), {
+                entryPoints: ['runCli', { file: 'hooks/*.js' }], rules: { clone: 'error' },
+            }, 'root: true stops the merge');
+            const files = [...index.files.keys()].map(f => path.relative(dir, f).replace(/\\/g, '/'));
+            assert.ok(!files.includes('sub/gen/out.js'));
+
+            const names = execute(index, 'deadcode', {}).result.map(d => d.name);
+            assert.ok(names.includes('unused'));
+            assert.ok(!names.includes('unusedInSub'), 'dead-code is off under sub/');
+            assert.ok(names.includes('staleInOwn'), 'sub/own/ is its own root, dead-code is on again');
+            assert.ok(!names.includes('runCli') && !names.includes('preHook'), 'configured entry points are used');
+        } finally { rm(dir); }
+    });
+
+    it('matches entry point patterns, packages and registries', () => {
+        const { entryPointMatches } = require('../core/config');
+        const qualified = { qualifiers: () => ['main', 'server'] };
+        assert.ok(entryPointMatches('main.main', { name: 'main' }, 'cmd/server/main.go', qualified));
+        assert.ok(entryPointMatches('Test*', { name: 'TestParse' }, 'parse_test.go'));
+        assert.ok(!entryPointMatches('Test*', { name: 'parse' }, 'parse.go'));
+        assert.ok(entryPointMatches('*.ServeHTTP', { name: 'ServeHTTP', className: 'Router' }, 'router.go'));
+        const api = { package: 'pkg/api', exported: true };
+        assert.ok(entryPointMatches(api, { name: 'Get' }, 'pkg/api/get.go', { exported: () => true }));
+        assert.ok(!entryPointMatches(api, { name: 'get' }, 'pkg/api/get.go', { exported: () => false }));
+        assert.ok(!entryPointMatches(api, { name: 'Get' }, 'pkg/api/v2/get.go', { exported: () => true }), 'not subpackages');
+        assert.ok(!entryPointMatches({ registry: 'http.HandleFunc' }, { name: 'HandleFunc' }, 'a.go'), 'registries name calls');
+
+        const dir = tmp({
+            ...SIMPLE_FIXTURE,
+            '.ucn.json': JSON.stringify({ entryPoints: ['Handle*', 'app.boot', { package: 'api', exported: true }, { registry: 'router.add' }] }),
+            'handlers.js': 'function HandleGet() {}\nfunction unusedHandler() {}\n',
+            'app.js': 'function boot() {}\n',
+            'api/index.js': 'function publicApi() {}\nfunction privateApi() {}\nmodule.exports = { publicApi };\n',
+            'routes.js': 'const router = { add(path, fn) { return [path, fn]; } };\nfunction onPing() { return 1; }\nrouter.add(\'/ping\', onPing);\n',
+        });
+        try {
+            let index = idx(dir);
+            const names = execute(index, 'deadcode', { includeExported: true }).result.map(d => d.name);
+            assert.ok(names.includes('unusedHandler') && names.includes('privateApi'));
+            assert.ok(!names.includes('HandleGet') && !names.includes('boot') && !names.includes('publicApi'));
+
+            const eps = execute(index, 'entrypoints', {}).result;
+            const ping = eps.find(e => e.name === 'onPing');
+            assert.strictEqual(ping.type, 'config');
+            assert.match(ping.evidence[0], /registered with router\.add at routes\.js:3/);
+            assert.match(execute(index, 'why', { name: 'onPing' }).result.via, /router\.add/);
+            assert.match(output.formatEntrypoints(eps), /Configured \(\.ucn\.json entryPoints\)/);
+
+            fs.writeFileSync(path.join(dir, '.ucn.json'), JSON.stringify({ builtinEntryPoints: false, entryPoints: ['boot'] }));
+            index = idx(dir);
+            assert.deepStrictEqual(execute(index, 'entrypoints', {}).result.map(e => [e.name, e.type]), [['boot', 'config']],
+                'only the configured entry points are left');
+        } finally { rm(dir); }
+    });
+});
+
+describe('generated files', () => {
+    it('hides findings in generated files unless asked, with custom markers', () => {
+        const dir = tmp({
+            ...SIMPLE_FIXTURE,
+            'gen/api.js': '// Code generated by apigen. DO NOT EDIT.\nfunction unusedStub() {}\n',
+            'gen/own.js': '// AUTOGENERATED by our tool\nfunction unusedOwn() {}\n',
+        });
+        try {
+            const index = idx(dir);
+            const names = p => execute(index, 'deadcode', p).result.map(d => d.name);
+            assert.ok(!names({}).includes('unusedStub'));
+            assert.ok(names({}).includes('unused'));
+            assert.strictEqual(execute(index, 'deadcode', {}).result.excludedGenerated, 1);
+            assert.ok(names({ includeGenerated: true }).includes('unusedStub'));
+            assert.ok(!names({ generatedMarkers: ['AUTOGENERATED'] }).includes('unusedOwn'));
+            assert.ok(names({}).includes('unusedOwn'));
+            assert.match(output.formatDeadcode(execute(index, 'deadcode', {}).result), /1 symbol\(s\) in generated files hidden/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('exit code policy', () => {
+    it('fails on severity and per-rule budgets', () => {
+        const { parseMaxFindings, policyFailures } = require('../core/fail-policy');
+        assert.deepStrictEqual([...parseMaxFindings(['DeadCode=1', '5']).budgets], [['dead-code', 1], ['*', 5]]);
+        assert.deepStrictEqual([...parseMaxFindings({ 'unused-param': 0 }).budgets], [['unused-param', 0]]);
+        assert.match(parseMaxFindings(['unused-func=3']).error, /Unknown rule in --max-findings: unused-func/);
+        assert.match(parseMaxFindings(['dead-code=-1']).error, /non-negative integer/);
+
+        const dir = tmp(SIMPLE_FIXTURE);
+        try {
+            const { result } = execute(idx(dir), 'deadcode', {});
+            assert.deepStrictEqual(policyFailures('deadcode', result, {}), []);
+            assert.deepStrictEqual(policyFailures('deadcode', result, { failOn: 'error' }), []);
+            assert.deepStrictEqual(policyFailures('deadcode', result, { failOn: 'warning' }),
+                ['1 finding(s) at severity warning or above (--fail-on=warning)']);
+            assert.deepStrictEqual(policyFailures('deadcode', result, { failOn: 'error', rules: { 'dead-code': 'error' } }),
+                ['1 finding(s) at severity error or above (--fail-on=error)']);
+            assert.deepStrictEqual(policyFailures('deadcode', result, { budgets: parseMaxFindings(['dead-code=0']).budgets }),
+                ['1 dead-code finding(s), over the budget of 0 (--max-findings)']);
+            assert.deepStrictEqual(policyFailures('deadcode', result, { budgets: parseMaxFindings(['1']).budgets }), []);
+        } finally { rm(dir); }
+    });
+
+    it('--top keeps the largest dead code and --max-dead-lines budgets removable lines', () => {
+        const { parseMaxDeadLines, policyFailures } = require('../core/fail-policy');
+        assert.strictEqual(parseMaxDeadLines('40').limit, 40);
+        assert.strictEqual(parseMaxDeadLines(undefined).limit, null);
+        assert.match(parseMaxDeadLines('-3').error, /non-negative integer/);
+
+        const body = n => Array.from({ length: n }, (_, i) => `    const v${i} = ${i};`).join('\n');
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': [
+                `function tiny() {\n${body(1)}\n}`,
+                `function huge() {\n${body(30)}\n}`,
+                `function medium() {\n${body(10)}\n}`,
+                'function used() { return 1; }',
+                'module.exports = { used };',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const all = execute(index, 'deadcode', {}).result;
+            const { result, note } = execute(index, 'deadcode', { top: 2 });
+            assert.deepStrictEqual(result.map(r => r.name), ['huge', 'medium']);
+            assert.deepStrictEqual(result.limitInfo, { total: 3, shown: 2, deadLines: 47, top: 2 });
+            assert.match(note, /Showing the 2 largest of 3 dead-code findings: 44 of 47 removable lines/);
+            assert.match(output.formatDeadcode(result, { top: 2 }), /Dead code: 3 unused symbol\(s\) \(showing the 2 largest\)/);
+
+            // The budget counts what --top left out of the listing
+            assert.deepStrictEqual(policyFailures('deadcode', result, { maxDeadLines: 46 }),
+                ['47 dead line(s), over the budget of 46 (--max-dead-lines)']);
+            assert.deepStrictEqual(policyFailures('deadcode', all, { maxDeadLines: 47 }), []);
+        } finally { rm(dir); }
+    });
+
+    it('counts the findings --top and --limit cut in every budget', () => {
+        const { parseMaxFindings, policyFailures } = require('../core/fail-policy');
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': Array.from({ length: 6 }, (_, i) => `function dead${i}() { return ${i}; }`).join('\n') +
+                '\nfunction used() { return 1; }\nmodule.exports = { used };\n',
+        });
+        try {
+            const index = idx(dir);
+            for (const params of [{ limit: 2 }, { top: 3 }, { top: 3, limit: 1 }]) {
+                const { result } = execute(index, 'deadcode', params);
+                assert.ok(result.length < 6);
+                assert.strictEqual(result.limitInfo.whole.length, 6);
+                assert.deepStrictEqual(policyFailures('deadcode', result, { failOn: 'warning', budgets: parseMaxFindings(['5', 'dead-code=4']).budgets }), [
+                    '6 finding(s) at severity warning or above (--fail-on=warning)',
+                    '6 finding(s), over the budget of 5 (--max-findings)',
+                    '6 dead-code finding(s), over the budget of 4 (--max-findings)',
+                ]);
+            }
+            const cli = (...a) => {
+                try {
+                    execFileSync('node', [path.join(__dirname, '..', 'cli', 'index.js'), dir, 'deadcode', '--no-cache', ...a], { encoding: 'utf-8', stdio: ['pipe', 'pipe', 'pipe'] });
+                    return '';
+                } catch (e) { return e.stderr; }
+            };
+            assert.match(cli('--limit', '2', '--max-findings', '5'), /Failed: 6 finding\(s\), over the budget of 5/);
+            assert.strictEqual(cli('--limit', '2', '--max-findings', '6'), '');
+        } finally { rm(dir); }
+    });
+
+    it('hides baselined findings before --top and --limit pick the rows', () => {
+        const { createBaseline } = require('../core/baseline');
+        const body = n => Array.from({ length: n }, (_, i) => `    const v${i} = ${i};`).join('\n');
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': [
+                `function known() {\n${body(20)}\n}`,
+                `function fresh() {\n${body(5)}\n}`,
+                `function small() {\n${body(1)}\n}`,
+                'module.exports = {};',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const known = execute(index, 'deadcode', {}).result.filter(r => r.name === 'known');
+            const file = path.join(dir, 'baseline.json');
+            fs.writeFileSync(file, JSON.stringify(createBaseline('deadcode', known)));
+            const { ok, result, note } = execute(index, 'deadcode', { baseline: file, top: 1 });
+            assert.ok(ok);
+            assert.deepStrictEqual(result.map(r => r.name), ['fresh'], 'the largest new finding, not a hole where known was');
+            assert.deepStrictEqual(result.limitInfo, { total: 2, shown: 1, deadLines: 10, top: 1 });
+            assert.match(note, /1 known finding\(s\) hidden by baseline/);
+            assert.match(execute(index, 'deadcode', { baseline: path.join(dir, 'missing.json') }).error, /Baseline not found/);
+        } finally { rm(dir); }
+    });
+});
+
 // ── endpoints command behavioral ─────────────────────────────────────────────
 
 describe('endpoints command behavioral', () => {
@@ -1858,3 +2792,564 @@ describe('fix #228: diffImpact old-side identity and deletion attribution', () =
         } finally { rm(dir); }
     });
 });
+
+describe('ucn completion and man', () => {
+    const CLI = path.join(__dirname, '..', 'cli', 'index.js');
+    const run = (...a) => execFileSync('node', [CLI, ...a], { encoding: 'utf-8', stdio: ['pipe', 'pipe', 'pipe'] });
+
+    it('parses the usage text into commands and flags', () => {
+        const { parseUsage } = require('../core/completion');
+        const usage = parseUsage(run('--help'));
+        const commands = usage.sections.flatMap(s => s.commands);
+        const reverse = commands.find(c => c.name === 'reverse-trace');
+        assert.strictEqual(reverse.args, '<name>');
+        assert.match(reverse.summary, /^Upward call chain/);
+        assert.ok(commands.some(c => c.name === 'completion') && commands.some(c => c.name === 'fix'));
+        const size = usage.flags.find(f => f.names.includes('--max-statements'));
+        assert.deepStrictEqual(size.names, ['--max-function-lines', '--max-statements', '--max-methods', '--max-fields']);
+        assert.match(size.summary, /^Limits for --size/);
+        assert.deepStrictEqual(usage.flags.find(f => f.names.includes('-i')).names, ['-i', '--interactive']);
+    });
+
+    it('bash completion offers commands, per-command flags and flag values', () => {
+        const script = run('completion', 'bash');
+        const complete = (...words) => execFileSync('bash', ['-c',
+            `${script}\nCOMP_WORDS=(${words.map(w => `'${w}'`).join(' ')}); COMP_CWORD=$((\${#COMP_WORDS[@]}-1)); _ucn; echo "\${COMPREPLY[*]}"`],
+        { encoding: 'utf-8' }).trim().split(/\s+/);
+        assert.ok(complete('ucn', 'deadc').includes('deadcode'));
+        assert.ok(complete('ucn', 'deadcode', '--unused-p').includes('--unused-params'));
+        assert.ok(!complete('ucn', 'about', '--unused-p').includes('--unused-params'), 'deadcode flag not offered for about');
+        assert.ok(complete('ucn', 'about', '--no-c').includes('--no-cache'), 'global flag offered everywhere');
+        assert.deepStrictEqual(complete('ucn', 'deadcode', '--sort', 'loc'), ['loc-saved']);
+        assert.deepStrictEqual(complete('ucn', 'deadcode', '--format', '=', 'sa'), ['sarif']);
+        assert.deepStrictEqual(complete('ucn', 'completion', 'z'), ['zsh']);
+    });
+
+    it('emits every shell and the man page; rejects an unknown shell', () => {
+        for (const shell of ['zsh', 'fish', 'powershell']) assert.match(run('completion', shell), /deadcode/);
+        assert.match(run('completion', 'zsh'), /^#compdef ucn/);
+        const man = run('man');
+        assert.match(man, /^\.TH UCN 1 "" "ucn \d+\.\d+\.\d+"/);
+        assert.match(man, /\.SS FIND CODE/);
+        assert.match(man, /\.B \\-\\-max\\-dead\\-lines=N\n/);
+        assert.throws(() => run('completion', 'tcsh'), /Usage: ucn completion <bash\|zsh\|fish\|powershell>/);
+    });
+});
+
+describe('ucn lsp', () => {
+    const { pathToFileURL, fileURLToPath } = require('url');
+    const { createLspServer, messageReader, frame } = require('../core/lsp');
+    const LIB = 'function used() { return 1; }\n\n// Nothing calls this\nfunction helper() {\n    return used();\n}\n\nmodule.exports = { used };\n';
+
+    it('reads framed messages split across chunks', () => {
+        const got = [];
+        const read = messageReader(m => got.push(m));
+        const bytes = Buffer.from(frame({ id: 1, method: 'a', params: { s: 'é' } }) + frame({ method: 'b' }));
+        for (let i = 0; i < bytes.length; i += 7) read(bytes.subarray(i, i + 7));
+        assert.deepStrictEqual(got, [{ id: 1, method: 'a', params: { s: 'é' } }, { method: 'b' }]);
+    });
+
+    it('publishes deadcode diagnostics, follows buffers, offers removals and references', () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': LIB, 'app.js': 'const { used } = require("./lib");\nused();\n' });
+        const sent = [];
+        const server = createLspServer({ send: m => sent.push(m), watch: false, cache: false });
+        const request = (id, method, params) => {
+            server.handle({ jsonrpc: '2.0', id, method, params });
+            return sent.find(m => m.id === id);
+        };
+        const notify = (method, params) => server.handle({ jsonrpc: '2.0', method, params });
+        const diagnostics = (uri) => {
+            const all = sent.filter(m => m.method === 'textDocument/publishDiagnostics' && m.params.uri === uri);
+            return all[all.length - 1]?.params.diagnostics;
+        };
+        try {
+            assert.strictEqual(request(0, 'textDocument/references', {}).error.code, -32002, 'not initialized yet');
+            const init = request(1, 'initialize', { rootUri: pathToFileURL(dir).href, capabilities: {} });
+            assert.strictEqual(init.result.capabilities.referencesProvider, true);
+            notify('initialized', {});
+            const uri = pathToFileURL(path.join(dir, 'lib.js')).href;
+            const [dead] = diagnostics(uri);
+            assert.strictEqual(dead.code, 'dead-code');
+            assert.deepStrictEqual(dead.range, { start: { line: 3, character: 9 }, end: { line: 3, character: 15 } });
+            assert.deepStrictEqual(dead.tags, [1]);
+
+            const actions = request(2, 'textDocument/codeAction', {
+                textDocument: { uri }, range: dead.range, context: { diagnostics: [dead] },
+            }).result;
+            assert.strictEqual(actions[0].title, 'Remove unused function helper');
+            assert.deepStrictEqual(actions[0].edit.changes[uri], [
+                { range: { start: { line: 2, character: 0 }, end: { line: 7, character: 0 } }, newText: '' },
+            ]);
+
+            const refs = request(3, 'textDocument/references', {
+                textDocument: { uri }, position: { line: 0, character: 11 }, context: { includeDeclaration: false },
+            }).result;
+            const at = refs.map(r => `${path.basename(fileURLToPath(r.uri))}:${r.range.start.line}`);
+            assert.ok(at.includes('app.js:1') && at.includes('lib.js:4'), at.join(' '));
+            assert.ok(!at.includes('lib.js:0'), 'the declaration is left out');
+            assert.deepStrictEqual(refs.find(r => r.uri.endsWith('app.js') && r.range.start.line === 1).range.start, { line: 1, character: 0 });
+
+            // An unsaved edit that calls helper clears its finding
+            notify('textDocument/didOpen', { textDocument: { uri, languageId: 'javascript', version: 1, text: LIB + 'helper();\n' } });
+            server.flush();
+            assert.deepStrictEqual(diagnostics(uri), []);
+            // Closing the buffer goes back to the file on disk
+            notify('textDocument/didClose', { textDocument: { uri } });
+            server.flush();
+            assert.strictEqual(diagnostics(uri).length, 1);
+
+            assert.strictEqual(request(4, 'shutdown').result, null);
+        } finally {
+            server.close();
+            rm(dir);
+        }
+    });
+
+    it('lists the references of the definition the file sees, not every same-named one', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'a/util.js': 'export function format(x) { return String(x); }\n',
+            'a/use.js': "import { format } from './util';\nformat(1);\n",
+            'b/util.js': 'export function format(x) { return JSON.stringify(x); }\n',
+            'b/use.js': "import { format } from './util';\nformat(2);\n",
+            'c/own.js': 'function format(x) { return x; }\nformat(3);\n',
+        });
+        const sent = [];
+        const server = createLspServer({ send: m => sent.push(m), watch: false, cache: false });
+        const request = (id, method, params) => {
+            server.handle({ jsonrpc: '2.0', id, method, params });
+            return sent.find(m => m.id === id);
+        };
+        let id = 1;
+        const refs = (file, line, character) => request(++id, 'textDocument/references', {
+            textDocument: { uri: pathToFileURL(path.join(dir, file)).href }, position: { line, character }, context: { includeDeclaration: true },
+        }).result.map(r => `${path.relative(dir, fileURLToPath(r.uri)).replace(/\\/g, '/')}:${r.range.start.line}`).sort();
+        try {
+            request(1, 'initialize', { rootUri: pathToFileURL(dir).href, capabilities: {} });
+            server.handle({ jsonrpc: '2.0', method: 'initialized', params: {} });
+            // From a call, through its import
+            assert.deepStrictEqual(refs('a/use.js', 1, 1), ['a/use.js:0', 'a/use.js:1', 'a/util.js:0']);
+            // From the definition
+            assert.deepStrictEqual(refs('b/util.js', 0, 17), ['b/use.js:0', 'b/use.js:1', 'b/util.js:0']);
+            // A local definition shadows the others
+            assert.deepStrictEqual(refs('c/own.js', 1, 1), ['c/own.js:0', 'c/own.js:1']);
+        } finally {
+            server.close();
+            rm(dir);
+        }
+    });
+
+    it('removes a dead function with the imports only it used, or suppresses it', () => {
+        const src = "const fs = require('fs');\nconst path = require('path');\n\n// Nothing calls this\nfunction helper() {\n    return fs.readFileSync('x');\n}\n\nmodule.exports = { sep: path.sep };\n";
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': src });
+        const sent = [];
+        const server = createLspServer({ send: m => sent.push(m), watch: false, cache: false });
+        const request = (id, method, params) => {
+            server.handle({ jsonrpc: '2.0', id, method, params });
+            return sent.find(m => m.id === id);
+        };
+        try {
+            request(1, 'initialize', { rootUri: pathToFileURL(dir).href, capabilities: {} });
+            server.handle({ jsonrpc: '2.0', method: 'initialized', params: {} });
+            const uri = pathToFileURL(path.join(dir, 'lib.js')).href;
+            const cursor = { start: { line: 5, character: 4 }, end: { line: 5, character: 4 } };
+            const [remove, suppress] = request(2, 'textDocument/codeAction', { textDocument: { uri }, range: cursor, context: { diagnostics: [] } }).result;
+            assert.strictEqual(remove.title, 'Remove unused function helper and 1 import(s) only it uses');
+            assert.deepStrictEqual(remove.edit.changes[uri], [
+                { range: { start: { line: 0, character: 0 }, end: { line: 1, character: 0 } }, newText: '' },
+                { range: { start: { line: 3, character: 0 }, end: { line: 8, character: 0 } }, newText: '' },
+            ], 'fs goes, path stays; the doc comment and a blank line go with the function');
+            assert.strictEqual(suppress.title, 'Suppress dead-code for helper with a comment');
+            assert.deepStrictEqual(suppress.edit.changes[uri], [
+                { range: { start: { line: 4, character: 0 }, end: { line: 4, character: 0 } }, newText: '// ucn:ignore[dead-code]\n' },
+            ]);
+        } finally {
+            server.close();
+            rm(dir);
+        }
+    });
+
+    it('answers the custom requests of an editor extension', () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': LIB, 'app.js': 'const { used } = require("./lib");\nused();\n' });
+        const sent = [];
+        const server = createLspServer({ send: m => sent.push(m), watch: false, cache: false });
+        const request = (id, method, params) => {
+            server.handle({ jsonrpc: '2.0', id, method, params });
+            return sent.find(m => m.id === id);
+        };
+        try {
+            const init = request(1, 'initialize', { rootUri: pathToFileURL(dir).href, capabilities: {} });
+            assert.deepStrictEqual(init.result.capabilities.experimental.ucn.requests, ['ucn/whyUsed', 'ucn/deadCodeTree']);
+            server.handle({ jsonrpc: '2.0', method: 'initialized', params: {} });
+            assert.ok(sent.some(m => m.method === 'ucn/deadCodeChanged'));
+
+            const uri = pathToFileURL(path.join(dir, 'lib.js')).href;
+            const tree = request(2, 'ucn/deadCodeTree', {}).result;
+            const lib = tree.children.find(n => n.kind === 'file' && n.path === 'lib.js');
+            assert.strictEqual(lib.uri, uri);
+            assert.deepStrictEqual(lib.children.map(f => [f.name, f.rule, f.range.start.line]), [['helper', 'dead-code', 3]]);
+            assert.strictEqual(request(3, 'ucn/deadCodeTree', { groupBy: 'owner' }).error.code, -32602);
+
+            const unused = request(4, 'ucn/whyUsed', { textDocument: { uri }, position: { line: 3, character: 11 } }).result;
+            assert.deepStrictEqual([unused.symbol.name, unused.reachable, unused.chain], ['helper', false, []]);
+            assert.deepStrictEqual(unused.symbol.location, { uri, range: { start: { line: 3, character: 9 }, end: { line: 3, character: 15 } } });
+            const used = request(5, 'ucn/whyUsed', { textDocument: { uri: pathToFileURL(path.join(dir, 'app.js')).href }, position: { line: 1, character: 1 } }).result;
+            assert.strictEqual(used.symbol.location.uri, uri, 'a call resolves to the definition');
+            assert.strictEqual(used.reachable, true);
+            assert.strictEqual(request(6, 'ucn/whyUsed', { textDocument: { uri }, position: { line: 1, character: 0 } }).result, null, 'no identifier there');
+        } finally {
+            server.close();
+            rm(dir);
+        }
+    });
+
+    it('builds the dead code tree by path or by rule', () => {
+        const { findingTree } = require('../core/lsp');
+        const range = line => ({ start: { line, character: 0 }, end: { line, character: 1 } });
+        const entries = [
+            { file: 'src/b.js', uri: 'file:///p/src/b.js', range: range(9), name: 'late', rule: 'dead-code' },
+            { file: 'top.js', uri: 'file:///p/top.js', range: range(0), name: 'x', rule: 'unused-param' },
+            { file: 'src/b.js', uri: 'file:///p/src/b.js', range: range(2), name: 'early', rule: 'dead-code' },
+            { file: 'src/a/c.js', uri: 'file:///p/src/a/c.js', range: range(4), name: 'c', rule: 'dead-code' },
+        ];
+        const shape = nodes => nodes.map(n => (n.kind === 'finding' ? n.name : [`${n.kind} ${n.name} (${n.count})`, shape(n.children)]));
+        assert.deepStrictEqual(shape(findingTree(entries, 'path')), [
+            ['directory src (3)', [
+                ['directory a (1)', [['file c.js (1)', ['c']]]],
+                ['file b.js (2)', ['early', 'late']],
+            ]],
+            ['file top.js (1)', ['x']],
+        ]);
+        const byRule = findingTree(entries, 'rule');
+        assert.deepStrictEqual(shape(byRule).map(([rule]) => rule), ['rule dead-code (3)', 'rule unused-param (1)']);
+        assert.strictEqual(byRule[0].title, 'DeadCode');
+        assert.deepStrictEqual(byRule[0].children.map(f => f.name), ['src/a/c.js', 'src/b.js']);
+    });
+});
+
+describe('ucn serve', () => {
+    const { createApi, createApiServer } = require('../core/serve');
+    const LIB = 'function used() { return 1; }\n\nfunction helper() {\n    return used();\n}\n\nmodule.exports = { used };\n';
+
+    it('analyzes, lists findings and answers references over HTTP', async () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': LIB, 'app.js': 'const { used } = require("./lib");\nused();\n' });
+        const server = createApiServer(createApi(idx(dir)));
+        await new Promise(resolve => server.listen(0, '127.0.0.1', resolve));
+        const base = `http://127.0.0.1:${server.address().port}`;
+        const get = async (p, init) => {
+            const res = await fetch(base + p, init);
+            return { status: res.status, body: await res.json() };
+        };
+        try {
+            const analyzed = await get('/analyze', { method: 'POST', body: JSON.stringify({ commands: ['deadcode', 'clones'] }) });
+            assert.strictEqual(analyzed.status, 200);
+            assert.deepStrictEqual(analyzed.body.findings, { deadcode: 1, clones: 0 });
+
+            const { body } = await get('/findings?command=deadcode');
+            assert.strictEqual(body.total, 1);
+            assert.deepStrictEqual(
+                { rule: body.findings[0].rule, file: body.findings[0].file, line: body.findings[0].line, symbol: body.findings[0].symbol },
+                { rule: 'dead-code', file: 'lib.js', line: 3, symbol: 'helper' });
+            assert.match(body.findings[0].fingerprint, /^[0-9a-f]{16}$/);
+            assert.strictEqual((await get('/findings?rule=clone')).body.total, 0);
+
+            const refs = await get(`/symbols/${encodeURIComponent('lib.js:1:used')}/references`);
+            assert.strictEqual(refs.body.symbol.id, 'lib.js:1:used');
+            assert.ok(refs.body.references.some(r => r.file === 'app.js' && r.line === 2 && r.type === 'call'));
+            assert.strictEqual((await get('/symbols/used/references')).body.total, refs.body.total, 'a name works as the id');
+
+            const metrics = await fetch(base + '/metrics');
+            assert.match(metrics.headers.get('content-type'), /^text\/plain; version=0\.0\.4/);
+            const text = await metrics.text();
+            assert.match(text, /ucn_findings\{command="deadcode",rule="dead-code",severity="warning",package="\."\} 1\n/);
+            assert.match(text, /ucn_dead_symbols\{package="\."\} 1\n/);
+            assert.match(text, /ucn_last_run_timestamp_seconds\{command="clones"\} \d+\n/);
+        } finally {
+            server.close();
+            rm(dir);
+        }
+    });
+
+    it('answers bad requests with a JSON error', async () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'lib.js': LIB });
+        const server = createApiServer(createApi(idx(dir)));
+        await new Promise(resolve => server.listen(0, '127.0.0.1', resolve));
+        const base = `http://127.0.0.1:${server.address().port}`;
+        const get = async (p, init) => {
+            const res = await fetch(base + p, init);
+            return { status: res.status, allow: res.headers.get('allow'), body: await res.json() };
+        };
+        try {
+            assert.deepStrictEqual(await get('/nowhere'), { status: 404, allow: null, body: { error: 'No route GET /nowhere' } });
+            assert.strictEqual((await get('/analyze')).allow, 'POST');
+            assert.strictEqual((await get('/analyze', { method: 'POST', body: '{"commands":["toc"]}' })).status, 400);
+            assert.strictEqual((await get('/analyze', { method: 'POST', body: 'nope' })).status, 400);
+            assert.strictEqual((await get('/findings?limit=-1')).status, 400);
+            assert.strictEqual((await get('/findings?command=clones')).status, 400, 'clones did not run');
+            assert.strictEqual((await get('/symbols/nothing/references')).status, 404);
+        } finally {
+            server.close();
+            rm(dir);
+        }
+    });
+
+    it('rates findings with the rules of their directory', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'lib.js': LIB,
+            'legacy/.ucn.json': '{"rules":{"dead-code":{"severity":"error"}}}',
+            'legacy/old.js': LIB,
+        });
+        try {
+            const api = createApi(idx(dir));
+            const bySeverity = Object.fromEntries(api.findings({ command: 'deadcode' }).findings.map(f => [f.file, f.severity]));
+            assert.deepStrictEqual(bySeverity, { 'lib.js': 'warning', 'legacy/old.js': 'error' });
+        } finally {
+            rm(dir);
+        }
+    });
+
+    it('maps gRPC Structs and API errors', () => {
+        const { structToObject, grpcCode } = require('../core/grpc');
+        const { ApiError } = require('../core/serve');
+        assert.deepStrictEqual(structToObject({
+            fields: {
+                includeExported: { kind: 'boolValue', boolValue: true },
+                exclude: { kind: 'listValue', listValue: { values: [{ kind: 'stringValue', stringValue: 'gen' }] } },
+                minLines: { kind: 'numberValue', numberValue: 6 },
+                in: { kind: 'nullValue', nullValue: 'NULL_VALUE' },
+            },
+        }), { includeExported: true, exclude: ['gen'], minLines: 6, in: null });
+        assert.deepStrictEqual(structToObject(null), {});
+        assert.strictEqual(grpcCode(new ApiError(400, 'bad')), 3);
+        assert.strictEqual(grpcCode(new ApiError(404, 'missing')), 5);
+        assert.strictEqual(grpcCode(new Error('boom')), 13);
+    });
+});
+
+describe('ucn hook', () => {
+    const hook = require('../core/hook');
+
+    it('installs a pre-commit hook and leaves a foreign one alone', () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}' });
+        try {
+            execFileSync('git', ['init'], { cwd: dir, stdio: 'pipe' });
+            const { file, replaced } = hook.installHook(dir);
+            assert.ok(file.endsWith(path.join('.git', 'hooks', 'pre-commit')), file);
+            assert.strictEqual(replaced, false);
+            assert.match(fs.readFileSync(file, 'utf-8'), /exec ucn hook run/);
+            assert.ok(fs.statSync(file).mode & 0o100, 'the hook is executable');
+            assert.strictEqual(hook.installHook(dir).replaced, true, 'its own hook is updated');
+
+            fs.writeFileSync(file, '#!/bin/sh\nmake lint\n');
+            assert.throws(() => hook.installHook(dir), /not written by ucn/);
+            assert.strictEqual(hook.installHook(dir, { force: true }).replaced, true);
+        } finally {
+            rm(dir);
+        }
+    });
+
+    it('checks the staged files, keeping their findings alone', () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}', 'a.js': 'function a() {}\n', 'b.js': 'function b() {}\n', 'notes.txt': 'x\n' });
+        try {
+            execFileSync('git', ['init'], { cwd: dir, stdio: 'pipe' });
+            execFileSync('git', ['add', 'a.js', 'notes.txt'], { cwd: dir, stdio: 'pipe' });
+            const files = hook.hookFiles([], dir).map(f => path.basename(f));
+            assert.deepStrictEqual(files, ['a.js'], 'staged, in a language ucn analyzes');
+            assert.deepStrictEqual(hook.hookFiles(['b.js'], dir), [path.join(dir, 'b.js')]);
+
+            const result = [
+                { name: 'a', type: 'function', file: 'a.js', startLine: 1, endLine: 1 },
+                { name: 'b', type: 'function', file: 'b.js', startLine: 1, endLine: 1 },
+            ];
+            const kept = hook.onlyInFiles('deadcode', result, dir, new Set([path.join(dir, 'b.js')]));
+            assert.deepStrictEqual(kept.map(d => d.name), ['b']);
+        } finally {
+            rm(dir);
+        }
+    });
+});
+
+describe('--report github-checks', () => {
+    const github = require('../core/report/github');
+    const DEAD = Array.from({ length: 60 }, (_, i) => ({ name: `f${i}`, type: 'function', file: 'lib/a.js', startLine: i + 1, endLine: i + 2 }));
+
+    it('creates a check run and sends annotations past the first 50 as updates', async () => {
+        const calls = [];
+        const fetch = async (url, init) => {
+            calls.push({ url, method: init.method, headers: init.headers, body: JSON.parse(init.body) });
+            return { ok: true, status: 201, text: async () => '{"id": 7}' };
+        };
+        const env = { GITHUB_TOKEN: 'tok', GITHUB_REPOSITORY: 'o/r', GITHUB_SHA: 'abc123', GITHUB_API_URL: 'https://ghe.example/api/v3' };
+        const message = await github.post('deadcode', DEAD, { env, fetch, root: '/nonexistent', failed: true });
+        assert.match(message, /Posted check run "ucn deadcode" \(failure, 60 annotation\(s\)\)/);
+        assert.deepStrictEqual(calls.map(c => `${c.method} ${c.url}`), [
+            'POST https://ghe.example/api/v3/repos/o/r/check-runs',
+            'PATCH https://ghe.example/api/v3/repos/o/r/check-runs/7',
+        ]);
+        assert.strictEqual(calls[0].headers.Authorization, 'Bearer tok');
+        assert.strictEqual(calls[0].body.head_sha, 'abc123');
+        assert.strictEqual(calls[0].body.conclusion, 'failure');
+        assert.strictEqual(calls[0].body.output.annotations.length, 50);
+        assert.deepStrictEqual(
+            { path: calls[0].body.output.annotations[0].path, start: calls[0].body.output.annotations[0].start_line, end: calls[0].body.output.annotations[0].end_line, level: calls[0].body.output.annotations[0].annotation_level },
+            { path: 'lib/a.js', start: 1, end: 2, level: 'warning' });
+        assert.strictEqual(calls[1].body.output.annotations.length, 10);
+    });
+
+    it('follows re-run requests from the Checks UI', () => {
+        const rerun = { action: 'rerequested', check_run: { name: 'ucn clones', head_sha: 'def456' } };
+        assert.deepStrictEqual(github.target({ GITHUB_EVENT_NAME: 'check_run', GITHUB_SHA: 'main' }, rerun), { sha: 'def456', rerun: 'ucn clones' });
+        assert.deepStrictEqual(github.target({ GITHUB_EVENT_NAME: 'check_suite' }, { action: 'rerequested', check_suite: { head_sha: 'aaa' } }), { sha: 'aaa', rerun: null });
+        assert.deepStrictEqual(github.target({ GITHUB_EVENT_NAME: 'pull_request', GITHUB_SHA: 'merge' }, { pull_request: { head: { sha: 'head' } } }), { sha: 'head', rerun: null });
+        assert.strictEqual(github.checkRun('deadcode', []).conclusion, 'success');
+        assert.strictEqual(github.checkRun('deadcode', DEAD).conclusion, 'neutral');
+    });
+
+    it('says which credentials are missing', async () => {
+        await assert.rejects(github.post('deadcode', DEAD, { env: {}, fetch: null, root: '/' }), /UCN_GITHUB_TOKEN/);
+        await assert.rejects(github.post('deadcode', DEAD, { env: { GITHUB_TOKEN: 't' }, fetch: null, root: '/' }), /GITHUB_REPOSITORY/);
+    });
+});
+
+describe('--report bitbucket and azure-devops', () => {
+    const DEAD = [
+        { name: 'a', type: 'function', file: 'lib/a.js', startLine: 3, endLine: 5 },
+        { name: 'b', type: 'function', file: 'lib/b.js', startLine: 8, endLine: 9 },
+    ];
+    const recorder = (respond = () => null) => {
+        const calls = [];
+        const fetch = async (url, init) => {
+            const call = { url, method: init.method, headers: init.headers, body: init.body && JSON.parse(init.body) };
+            calls.push(call);
+            const body = respond(call);
+            const status = body?.status || 200;
+            return { ok: status < 400, status, statusText: '', text: async () => JSON.stringify(body?.json ?? {}) };
+        };
+        return { calls, fetch };
+    };
+
+    it('writes a Bitbucket Code Insights report with an annotation per finding', async () => {
+        const { calls, fetch } = recorder(c => (c.method === 'DELETE' ? { status: 404, json: { error: { message: 'no report' } } } : null));
+        const env = { UCN_BITBUCKET_TOKEN: 'tok', BITBUCKET_WORKSPACE: 'ws', BITBUCKET_REPO_SLUG: 'app', BITBUCKET_COMMIT: 'abc123' };
+        const message = await require('../core/report/bitbucket').post('deadcode', DEAD, { env, fetch, root: '/nonexistent', failed: false });
+        assert.match(message, /\(passed, 2 annotation\(s\)\)/);
+        const report = 'https://api.bitbucket.org/2.0/repositories/ws/app/commit/abc123/reports/ucn-deadcode';
+        assert.deepStrictEqual(calls.map(c => `${c.method} ${c.url}`), [`DELETE ${report}`, `PUT ${report}`, `POST ${report}/annotations`]);
+        assert.strictEqual(calls[1].headers.Authorization, 'Bearer tok');
+        assert.deepStrictEqual(calls[1].body.data, [{ title: 'Findings', type: 'NUMBER', value: 2 }]);
+        assert.deepStrictEqual(calls[2].body.map(a => `${a.path}:${a.line}:${a.severity}`), ['lib/a.js:3:MEDIUM', 'lib/b.js:8:MEDIUM']);
+        assert.match(calls[2].body[0].external_id, /^[0-9a-f]{16}$/);
+    });
+
+    it('comments on an Azure DevOps pull request once per finding and closes fixed ones', async () => {
+        const azure = require('../core/report/azure');
+        const threads = azure.findingThreads('deadcode', DEAD);
+        const [keyA] = threads.keys();
+        const existing = [
+            { id: 1, status: 'active', properties: { 'ucn.finding': { $value: keyA }, 'ucn.command': { $value: 'deadcode' } } },
+            { id: 2, status: 'active', properties: { 'ucn.finding': { $value: 'gone' }, 'ucn.command': { $value: 'deadcode' } } },
+            { id: 3, status: 'active', properties: { 'ucn.finding': { $value: 'other' }, 'ucn.command': { $value: 'clones' } } },
+        ];
+        const { calls, fetch } = recorder(c => (c.method === 'GET' ? { json: { value: existing } } : null));
+        const env = {
+            SYSTEM_ACCESSTOKEN: 'tok', SYSTEM_COLLECTIONURI: 'https://dev.azure.com/org/', SYSTEM_TEAMPROJECT: 'My Project',
+            BUILD_REPOSITORY_ID: 'repo-id', SYSTEM_PULLREQUEST_PULLREQUESTID: '42',
+        };
+        const message = await azure.post('deadcode', DEAD, { env, fetch, root: '/nonexistent' });
+        assert.strictEqual(message, 'Posted 1 new comment thread(s) on pull request 42, 1 already there, 1 marked fixed');
+        const base = 'https://dev.azure.com/org/My%20Project/_apis/git/repositories/repo-id/pullRequests/42/threads';
+        assert.deepStrictEqual(calls.map(c => `${c.method} ${c.url}`), [
+            `GET ${base}?api-version=7.1`,
+            `PATCH ${base}/2?api-version=7.1`,
+            `POST ${base}?api-version=7.1`,
+        ]);
+        assert.deepStrictEqual(calls[2].body.threadContext.filePath, '/lib/b.js');
+        assert.deepStrictEqual(calls[2].body.threadContext.rightFileStart, { line: 8, offset: 1 });
+        await assert.rejects(azure.post('deadcode', DEAD, { env: { ...env, SYSTEM_PULLREQUEST_PULLREQUESTID: '' }, fetch, root: '/' }), /Not a pull request build/);
+    });
+});
+
+describe('--notify', () => {
+    const notifier = require('../core/notify');
+    const finding = (name, line) => ({ name, type: 'function', file: 'lib/a.js', startLine: line, endLine: line + 1 });
+
+    it('sends totals, then new and resolved findings since the last run', async () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}' });
+        try {
+            const calls = [];
+            const fetch = async (url, init) => {
+                calls.push({ url, headers: init.headers, body: JSON.parse(init.body) });
+                return { ok: true, status: 200, text: async () => 'ok' };
+            };
+            const options = {
+                root: dir, fetch, now: '2026-10-16T00:00:00Z',
+                env: { SLACK_URL: 'https://hooks.slack.example/T1', TOKEN: 'sekrit' },
+                notify: [
+                    { type: 'slack', url: '${SLACK_URL}', when: 'changes' },
+                    { type: 'webhook', url: 'https://ci.example/ucn', headers: { Authorization: 'Bearer ${TOKEN}' } },
+                ],
+            };
+            const first = await notifier.notify('deadcode', [finding('a', 1), finding('b', 5)], options);
+            assert.deepStrictEqual(first, ['Notify slack: sent 2 finding(s)', 'Notify webhook: sent 2 finding(s)']);
+            assert.strictEqual(calls[0].url, 'https://hooks.slack.example/T1');
+            assert.match(calls[0].body.text, /^\*ucn deadcode\* · .*: 2 finding\(s\) \(first run\)/);
+            assert.strictEqual(calls[1].headers.Authorization, 'Bearer sekrit');
+            assert.strictEqual(calls[1].body.new, null);
+            assert.ok(fs.existsSync(notifier.statePath(dir, 'deadcode')));
+
+            calls.length = 0;
+            const second = await notifier.notify('deadcode', [finding('b', 5), finding('c', 9)], { ...options, now: '2026-10-17T00:00:00Z' });
+            assert.deepStrictEqual(second, ['Notify slack: sent 2 finding(s), 1 new, 1 resolved', 'Notify webhook: sent 2 finding(s), 1 new, 1 resolved']);
+            assert.deepStrictEqual(calls[1].body.new.map(f => f.name), ['c']);
+            assert.deepStrictEqual(calls[1].body.resolved.map(f => f.name), ['a']);
+            assert.strictEqual(calls[1].body.since, '2026-10-16T00:00:00Z');
+            assert.match(calls[0].body.text, /\*New\*\n• `lib\/a\.js:9` /);
+
+            calls.length = 0;
+            const same = await notifier.notify('deadcode', [finding('b', 5), finding('c', 9)], options);
+            assert.strictEqual(same[0], 'Notify slack: skipped', 'no changes, and slack only wants changes');
+            assert.strictEqual(calls.length, 1);
+        } finally {
+            rm(dir);
+        }
+    });
+
+    it('keeps the state when a sink fails, and masks its URL', async () => {
+        const dir = tmp({ 'package.json': '{"name":"test"}' });
+        try {
+            const fetch = async () => ({ ok: false, status: 403, statusText: 'Forbidden', text: async () => 'invalid_token' });
+            const options = { root: dir, fetch, env: { SLACK_URL: 'https://hooks.slack.example/secret' }, notify: [{ type: 'slack', url: '${SLACK_URL}' }] };
+            await assert.rejects(notifier.notify('deadcode', [finding('a', 1)], options), (e) => {
+                assert.match(e.message, /config notify\[0\] \(slack\): POST <url> failed with 403: invalid_token/);
+                assert.ok(!e.message.includes('secret'));
+                return true;
+            });
+            assert.ok(!fs.existsSync(notifier.statePath(dir, 'deadcode')));
+            await assert.rejects(notifier.notify('deadcode', [], { ...options, env: {} }), /environment variable SLACK_URL is not set/);
+        } finally {
+            rm(dir);
+        }
+    });
+
+    it('checks the config', () => {
+        assert.match(notifier.parseSinks([{ type: 'teams', url: 'x' }]).error, /type must be one of slack, webhook/);
+        assert.match(notifier.parseSinks([{ type: 'slack' }]).error, /missing url/);
+        assert.match(notifier.parseSinks({ type: 'webhook', url: 'x', when: 'daily' }).error, /when must be one of always, changes, findings/);
+        assert.strictEqual(notifier.parseSinks([{ type: 'webhook', url: 'x' }]).sinks[0].when, 'always');
+    });
+});
+
+describe('global value flags', () => {
+    const CLI = path.join(__dirname, '..', 'cli', 'index.js');
+    const run = (...a) => execFileSync('node', [CLI, ...a], { encoding: 'utf-8', stdio: ['pipe', 'pipe', 'pipe'] });
+
+    it('reject a missing value in either spelling with the flag\'s own message', () => {
+        for (const flag of [['--sqlite'], ['--sqlite='], ['--sqlite', '--no-cache']]) {
+            assert.throws(() => run('deadcode', ...flag), /--sqlite needs a database file/);
+        }
+        assert.throws(() => run('deadcode', '--baseline'), /--baseline needs a baseline file/);
+        assert.throws(() => run('serve', '--host='), /--host needs an address to listen on/);
+        assert.throws(() => run('deadcode', '--format=template'), /--template/);
+    });
+});
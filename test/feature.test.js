@@ -151,6 +151,39 @@ export function b() { return a() + 2; }
             fs.rmSync(tmpDir, { recursive: true, force: true });
         }
     });
+
+    it('--symbols emits the call graph around matching symbols as DOT', () => {
+        const dir = tmp({
+            'cli.js': [
+                '#!/usr/bin/env node',
+                'function main() { return handle(); }',
+                'function handle() { return helper(); }',
+                'function helper() { return 1; }',
+                'function orphan() { return helper(); }',
+                'main();',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const graph = index.symbolGraph({ pattern: 'help*', direction: 'callers', maxDepth: 1 });
+            assert.deepStrictEqual(graph.nodes.map(n => [n.name, n.depth]), [['handle', 1], ['helper', 0], ['orphan', 1]]);
+            assert.deepStrictEqual(graph.edges.map(e => `${e.from} -> ${e.to}`), ['cli.js:3 -> cli.js:4', 'cli.js:5 -> cli.js:4']);
+            assert.strictEqual(graph.depthTruncated, true, 'main sits one hop past depth 1');
+            assert.strictEqual(graph.nodes.find(n => n.name === 'orphan').reachable, false);
+            assert.strictEqual(graph.nodes.find(n => n.name === 'helper').reachable, true);
+
+            const deep = index.symbolGraph({ pattern: 'helper', direction: 'callers', maxDepth: 2 });
+            assert.strictEqual(deep.nodes.find(n => n.name === 'main').entrypoint, true);
+
+            const dot = output.formatGraph(graph);
+            assert.match(dot, /^digraph ucn \{$/m);
+            assert.match(dot, /"cli\.js:4" \[label="helper\\ncli\.js:4", penwidth=2\];/);
+            assert.match(dot, /"cli\.js:5" \[[^\]]*style="rounded,filled,dashed"/);
+            assert.match(dot, /"cli\.js:3" -> "cli\.js:4";/);
+        } finally {
+            rm(dir);
+        }
+    });
 });
 
 
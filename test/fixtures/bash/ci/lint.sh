@@ -0,0 +1,6 @@
+#!/bin/sh
+# Not sourced by anything: the CI job runs it directly.
+
+orphan_check() {
+    shellcheck ./*.sh
+}
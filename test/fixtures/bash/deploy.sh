@@ -0,0 +1,25 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+source "$(dirname "$0")/lib/common.sh"
+. lib/log.sh
+
+readonly TMP_DIR="$(mktemp -d)"
+
+cleanup() {
+    rm -rf "$TMP_DIR"
+}
+trap cleanup EXIT
+
+# Roll back the last release (superseded by the platform's own rollback).
+unused_rollback() {
+    kubectl rollout undo deployment/app
+}
+
+main() {
+    log_info "deploying"
+    ensure_tool kubectl
+    kubectl apply -f manifests/
+}
+
+main "$@"
@@ -0,0 +1,19 @@
+# Shared helpers for ops scripts.
+
+die() {
+    echo "error: $*" >&2
+    exit 1
+}
+
+ensure_tool() {
+    command -v "$1" >/dev/null 2>&1 || die "missing tool: $1"
+}
+
+legacy_retry() {
+    local n=0
+    until "$@"; do
+        n=$((n + 1))
+        [ "$n" -ge 3 ] && return 1
+        sleep 1
+    done
+}
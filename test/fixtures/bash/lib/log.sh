@@ -0,0 +1,9 @@
+log_info() {
+    printf '[info] %s\n' "$*"
+}
+
+log_debug() {
+    printf '[debug] %s\n' "$*"
+}
+
+export -f log_info
@@ -0,0 +1,49 @@
+import 'package:flutter/material.dart';
+import 'package:counter_app/src/counter.dart';
+import 'src/format.dart' as fmt;
+
+void main() => runApp(const CounterApp());
+
+class CounterApp extends StatelessWidget {
+  const CounterApp({super.key});
+
+  @override
+  Widget build(BuildContext context) {
+    return const MaterialApp(home: CounterPage());
+  }
+}
+
+class CounterPage extends StatefulWidget {
+  const CounterPage({super.key});
+
+  @override
+  State<CounterPage> createState() => _CounterPageState();
+}
+
+class _CounterPageState extends State<CounterPage> {
+  final counter = Counter();
+
+  // Lifecycle methods without @override are still framework-invoked.
+  void initState() {
+    super.initState();
+  }
+
+  void dispose() {
+    super.dispose();
+  }
+
+  void _increment() {
+    setState(() => counter.increment());
+  }
+
+  void _unusedReset() {
+    counter.value = 0;
+  }
+
+  Widget build(BuildContext context) {
+    return TextButton(
+      onPressed: _increment,
+      child: Text(fmt.label(counter.value)),
+    );
+  }
+}
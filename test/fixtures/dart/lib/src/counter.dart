@@ -0,0 +1,11 @@
+part 'counter_history.dart';
+
+/// A counter whose history lives in a part file.
+class Counter {
+  int value = 0;
+
+  void increment() {
+    value++;
+    _record(value);
+  }
+}
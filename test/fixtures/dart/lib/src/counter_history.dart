@@ -0,0 +1,7 @@
+part of 'counter.dart';
+
+final _history = <int>[];
+
+void _record(int v) => _history.add(v);
+
+void _dropHistory() => _history.clear();
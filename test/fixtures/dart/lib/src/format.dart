@@ -0,0 +1,5 @@
+String label(int v) => 'Count: ${_pad('$v')}';
+
+String _pad(String s) => s.padLeft(4);
+
+String _shout(String s) => s.toUpperCase();
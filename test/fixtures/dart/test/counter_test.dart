@@ -0,0 +1,9 @@
+import 'package:flutter_test/flutter_test.dart';
+import 'package:counter_app/src/counter.dart';
+
+void main() {
+  test('increments', () {
+    final c = Counter()..increment();
+    expect(c.value, 1);
+  });
+}
@@ -0,0 +1,12 @@
+defmodule MyApp.Formatter do
+  @doc "Render a count for display."
+  def format(count) do
+    count
+    |> Integer.to_string()
+    |> pad()
+  end
+
+  defp pad(text), do: String.pad_leading(text, 4)
+
+  defp unused_pad(text), do: String.pad_trailing(text, 4)
+end
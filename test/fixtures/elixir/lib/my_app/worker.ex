@@ -0,0 +1,38 @@
+defmodule MyApp.Worker do
+  @moduledoc "Counter server."
+  use GenServer
+
+  alias MyApp.Formatter
+
+  @default_count 0
+
+  def start_link(opts \\ []) do
+    GenServer.start_link(__MODULE__, opts, name: __MODULE__)
+  end
+
+  def current do
+    GenServer.call(__MODULE__, :current)
+  end
+
+  @impl true
+  def init(_opts) do
+    {:ok, @default_count}
+  end
+
+  @impl true
+  def handle_call(:current, _from, count) do
+    {:reply, Formatter.format(count), count}
+  end
+
+  def handle_cast({:add, n}, count) do
+    {:noreply, bump(count, n)}
+  end
+
+  defp bump(count, n) when n > 0, do: count + n
+  defp bump(count, _n), do: count
+
+  # Never called — should be reported as dead code
+  defp stale_helper(x) do
+    x * 2
+  end
+end
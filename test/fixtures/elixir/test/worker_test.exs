@@ -0,0 +1,8 @@
+defmodule MyApp.WorkerTest do
+  use ExUnit.Case
+
+  test "current count starts at zero" do
+    start_supervised!(MyApp.Worker)
+    assert MyApp.Worker.current() == "   0"
+  end
+end
@@ -0,0 +1,17 @@
+import { gql } from '@apollo/client';
+
+export const POST_QUERY = gql`
+  query Post($id: ID!) {
+    post(id: $id) {
+      id
+      title
+      author { name }
+    }
+  }
+`;
+
+export const PUBLISH = gql`
+  mutation Publish($input: PublishPostInput!) {
+    publishPost(input: $input) { id }
+  }
+`;
@@ -0,0 +1,10 @@
+// Resolver implementations keep no field alive on their own
+export const resolvers = {
+    Query: {
+        viewer: (_, __, ctx) => ctx.user,
+        legacySearch: (_, { term }, ctx) => ctx.db.search(term),
+    },
+    Post: {
+        wordCount: (post) => post.body.split(/\s+/).length,
+    },
+};
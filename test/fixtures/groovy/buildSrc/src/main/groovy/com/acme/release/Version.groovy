@@ -0,0 +1,18 @@
+package com.acme.release
+
+class Version {
+    static final String BASE = '2.1'
+
+    static String current() {
+        "${BASE}.${buildNumber()}"
+    }
+
+    private static String buildNumber() {
+        System.getenv('BUILD_NUMBER') ?: '0'
+    }
+
+    // Release names were dropped with 2.0
+    String codename() {
+        'falcon'
+    }
+}
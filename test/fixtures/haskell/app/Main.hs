@@ -0,0 +1,10 @@
+module Main (main) where
+
+import qualified Geometry.Format as F
+import Geometry.Shape (Shape(..))
+
+main :: IO ()
+main = mapM_ (putStrLn . F.describe) shapes
+
+shapes :: [Shape]
+shapes = [Circle 1, Rect 2 3]
@@ -0,0 +1,18 @@
+module Geometry.Format (describe, legacyBanner) where
+
+import Geometry.Shape (Shape(..), area)
+
+class Pretty a where
+    pretty :: a -> String
+
+instance Pretty Shape where
+    pretty (Circle r) = "circle " ++ show r
+    pretty (Rect w h) = "rect " ++ show w ++ "x" ++ show h
+
+describe :: Shape -> String
+describe s = pretty s ++ " of area " ++ showArea (area s)
+  where
+    showArea a = show (round a :: Int)
+
+legacyBanner :: String
+legacyBanner = "geometry v0"
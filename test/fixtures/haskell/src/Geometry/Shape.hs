@@ -0,0 +1,31 @@
+module Geometry.Shape
+    ( Shape(..)
+    , area
+    , perimeter
+    , scale
+    ) where
+
+-- | A closed planar figure.
+data Shape
+    = Circle Double
+    | Rect Double Double
+
+-- | Surface covered by a shape.
+area :: Shape -> Double
+area (Circle r) = pi * square r
+area (Rect w h) = w * h
+
+perimeter :: Shape -> Double
+perimeter (Circle r) = 2 * pi * r
+perimeter (Rect w h) = 2 * (w + h)
+
+-- | Grow a shape by a factor.
+scale :: Double -> Shape -> Shape
+scale k (Circle r) = Circle (k * r)
+scale k (Rect w h) = Rect (k * w) (k * h)
+
+square :: Double -> Double
+square x = x * x
+
+cube :: Double -> Double
+cube x = x * square x
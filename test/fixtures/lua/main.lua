@@ -0,0 +1,22 @@
+-- Entry script: the host (LÖVE) calls love.load / love.update / love.draw.
+package.path = "src/?.lua;src/?/init.lua;" .. package.path
+
+local util = require("util")
+local game = require("game")
+local state = require("game.state")
+
+local function unused_debug_overlay()
+    print("fps")
+end
+
+function love.load()
+    game.start()
+end
+
+function love.update(dt)
+    state.tick(util.round(dt))
+end
+
+function love.draw()
+    game.render()
+end
@@ -0,0 +1,7 @@
+local util = require("util")
+
+describe("util", function()
+    it("rounds", function()
+        assert.are.equal(2, util.round(1.6))
+    end)
+end)
@@ -0,0 +1,10 @@
+local Game = {}
+
+function Game.start()
+    Game.running = true
+end
+
+function Game.render()
+end
+
+return Game
@@ -0,0 +1,11 @@
+local State = {}
+
+local stale_counter = function()
+    return 0
+end
+
+function State.tick(dt)
+    State.elapsed = (State.elapsed or 0) + dt
+end
+
+return State
@@ -0,0 +1,7 @@
+-- Never required by anything: an unreferenced module.
+local Orphan = {}
+
+function Orphan.noop()
+end
+
+return Orphan
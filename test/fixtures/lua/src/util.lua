@@ -0,0 +1,17 @@
+local M = {}
+
+local function clamp(v, lo, hi)
+    if v < lo then return lo end
+    if v > hi then return hi end
+    return v
+end
+
+local function dead_local()
+    return 42
+end
+
+function M.round(x)
+    return clamp(math.floor(x + 0.5), 0, 1000)
+end
+
+return M
@@ -0,0 +1,5 @@
+#import <Foundation/Foundation.h>
+
+@interface LegacyFormatter : NSObject
+- (NSString *)formatName:(NSString *)name;
+@end
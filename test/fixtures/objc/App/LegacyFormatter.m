@@ -0,0 +1,9 @@
+#import "LegacyFormatter.h"
+
+@implementation LegacyFormatter
+
+- (NSString *)formatName:(NSString *)name {
+    return [name uppercaseString];
+}
+
+@end
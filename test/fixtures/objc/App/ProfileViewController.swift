@@ -0,0 +1,10 @@
+import UIKit
+
+final class ProfileViewController: UIViewController {
+    override func viewDidLoad() {
+        super.viewDidLoad()
+        UserStore.sharedStore().fetchUser(withId: "me") { user in
+            print(user as Any)
+        }
+    }
+}
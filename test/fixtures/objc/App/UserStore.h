@@ -0,0 +1,15 @@
+#import <Foundation/Foundation.h>
+
+@class User;
+
+/// Loads and caches users for the profile screens.
+@interface UserStore : NSObject
+
+@property (nonatomic, copy) NSString *endpoint;
+
++ (instancetype)sharedStore;
+- (void)fetchUserWithId:(NSString *)userId completion:(void (^)(User *user))completion;
+- (void)reload;
+- (void)purgeCache;
+
+@end
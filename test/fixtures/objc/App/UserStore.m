@@ -0,0 +1,28 @@
+#import "UserStore.h"
+
+@interface UserStore ()
+@property (nonatomic, strong) NSMutableDictionary *cache;
+@end
+
+@implementation UserStore
+
++ (instancetype)sharedStore {
+    static UserStore *store;
+    static dispatch_once_t once;
+    dispatch_once(&once, ^{ store = [[UserStore alloc] init]; });
+    return store;
+}
+
+- (void)fetchUserWithId:(NSString *)userId completion:(void (^)(User *user))completion {
+    completion(self.cache[userId]);
+}
+
+- (void)reload {
+    [self.cache removeAllObjects];
+}
+
+- (void)purgeCache {
+    self.cache = nil;
+}
+
+@end
@@ -0,0 +1,9 @@
+#import <UIKit/UIKit.h>
+#import "UserStore.h"
+
+int main(int argc, char *argv[]) {
+    @autoreleasepool {
+        [[UserStore sharedStore] reload];
+        return UIApplicationMain(argc, argv, nil, @"AppDelegate");
+    }
+}
@@ -0,0 +1,5 @@
+let main () =
+  let cache = Cache.create () in
+  print_endline (Render.describe (Cache.find cache "greeting"))
+
+let () = main ()
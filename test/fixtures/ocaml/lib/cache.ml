@@ -0,0 +1,17 @@
+type t = { table : (int, string) Hashtbl.t }
+
+let default_size = 64
+
+let stale_limit = 300
+
+(** Stable key for the hash table. *)
+let hash_key key = Hashtbl.hash (String.lowercase_ascii key)
+
+let create () = { table = Hashtbl.create default_size }
+
+let find cache key =
+  match Hashtbl.find_opt cache.table (hash_key key) with
+  | Some value -> value
+  | None -> ""
+
+let evict_all cache = Hashtbl.reset cache.table
@@ -0,0 +1,8 @@
+(** A string-keyed cache. *)
+
+type t
+
+val create : unit -> t
+(** [create ()] is an empty cache. *)
+
+val find : t -> string -> string
@@ -0,0 +1,3 @@
+let banner name = Printf.sprintf "== %s ==" name
+
+let describe value = "value: " ^ value
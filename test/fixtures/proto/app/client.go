@@ -0,0 +1,13 @@
+package app
+
+import (
+	"context"
+
+	userv1 "example.com/demo/gen/user/v1"
+	"google.golang.org/grpc"
+)
+
+func FetchUser(ctx context.Context, conn *grpc.ClientConn, id string) (*userv1.User, error) {
+	client := userv1.NewUserServiceClient(conn)
+	return client.GetUser(ctx, &userv1.GetUserRequest{Id: id})
+}
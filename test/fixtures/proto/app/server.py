@@ -0,0 +1,6 @@
+from gen import user_pb2, user_pb2_grpc
+
+
+class UserServer(user_pb2_grpc.UserServiceServicer):
+    def ListUsers(self, request, context):
+        yield user_pb2.ListUsersResponse(users=[])
@@ -0,0 +1,37 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package userv1
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+)
+
+type UserServiceClient interface {
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error) {
+	out := new(PurgeUserResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/PurgeUser", in, out, opts...)
+	return out, err
+}
+
+func _UserService_PurgeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(PurgeUserRequest)
+	return srv.(UserServiceServer).PurgeUser(ctx, in)
+}
+
+type LegacyProfile struct {
+	Bio string
+}
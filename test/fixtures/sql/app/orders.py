@@ -0,0 +1,11 @@
+"""Order queries issued by the web tier."""
+
+
+def lifetime_totals(conn):
+    return conn.execute("SELECT * FROM order_totals ORDER BY lifetime_total DESC").fetchall()
+
+
+def discounted(conn, order_id):
+    return conn.execute(
+        "SELECT order_discount(total) FROM orders WHERE id = %s", (order_id,)
+    ).fetchone()
@@ -0,0 +1,2 @@
+\i schema/tables.sql
+\ir schema/functions.sql
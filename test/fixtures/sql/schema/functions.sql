@@ -0,0 +1,18 @@
+-- Keep updated_at current on every write
+CREATE FUNCTION touch_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_touch BEFORE UPDATE ON orders
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+
+CREATE FUNCTION order_discount(amount NUMERIC) RETURNS NUMERIC AS $$
+    SELECT amount * 0.9;
+$$ LANGUAGE sql;
+
+CREATE FUNCTION unused_rollup(since TIMESTAMPTZ) RETURNS BIGINT AS $$
+    SELECT count(*) FROM orders WHERE updated_at > since;
+$$ LANGUAGE sql;
@@ -0,0 +1,31 @@
+-- Customers who can place orders
+CREATE TABLE customers (
+    id SERIAL PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE public.orders (
+    id SERIAL PRIMARY KEY,
+    customer_id INTEGER NOT NULL REFERENCES customers(id),
+    total NUMERIC(10, 2) NOT NULL,
+    updated_at TIMESTAMPTZ
+);
+
+-- Replaced by the event stream; nothing reads it anymore
+CREATE TABLE legacy_audit (
+    id SERIAL PRIMARY KEY,
+    payload JSONB
+);
+
+ALTER TABLE legacy_audit ADD COLUMN source TEXT;
+CREATE INDEX legacy_audit_source_idx ON legacy_audit (source);
+
+CREATE VIEW order_totals AS
+    SELECT c.id, c.email, sum(o.total) AS lifetime_total
+    FROM customers c
+    JOIN public.orders o ON o.customer_id = c.id
+    GROUP BY c.id, c.email;
+
+CREATE MATERIALIZED VIEW stale_report AS
+    SELECT count(*) FROM customers;
@@ -0,0 +1,7 @@
+import { writable, derived } from 'svelte/store';
+
+export const todos = writable([]);
+
+export const remaining = derived(todos, ($todos) => $todos.filter(t => !t.done).length);
+
+export const theme = writable('light');
@@ -0,0 +1,38 @@
+variable "region" {
+  description = "AWS region to deploy into"
+  type        = string
+  default     = "eu-west-1"
+}
+
+# Superseded by per-environment feature flags
+variable "enable_debug" {
+  type    = bool
+  default = false
+}
+
+locals {
+  tags = {
+    Project = "ucn-demo"
+  }
+  stale_prefix = "old-"
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "network" {
+  source = "./modules/network"
+  cidr   = "10.0.0.0/16"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-123456"
+  instance_type = "t3.micro"
+  subnet_id     = module.network.subnet_id
+  tags          = local.tags
+}
+
+output "instance_id" {
+  value = aws_instance.web.id
+}
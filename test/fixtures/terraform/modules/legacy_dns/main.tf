@@ -0,0 +1,11 @@
+variable "zone" {
+  type = string
+}
+
+resource "aws_route53_zone" "this" {
+  name = var.zone
+}
+
+output "zone_id" {
+  value = aws_route53_zone.this.zone_id
+}
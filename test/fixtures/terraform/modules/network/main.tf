@@ -0,0 +1,17 @@
+variable "cidr" {
+  type = string
+}
+
+variable "enable_ipv6" {
+  type    = bool
+  default = false
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = var.cidr
+}
+
+resource "aws_subnet" "primary" {
+  vpc_id     = aws_vpc.main.id
+  cidr_block = cidrsubnet(var.cidr, 8, 1)
+}
@@ -0,0 +1,7 @@
+output "subnet_id" {
+  value = aws_subnet.primary.id
+}
+
+output "vpc_arn" {
+  value = aws_vpc.main.arn
+}
@@ -0,0 +1,19 @@
+const std = @import("std");
+const util = @import("util.zig");
+
+const max_items = 16;
+const unused_limit = 64;
+
+pub fn main() void {
+    var list = util.Stack.init();
+    list.push(max_items);
+    std.debug.print("{d}\n", .{util.clamp(list.top(), 0, 10)});
+}
+
+fn unusedHelper(x: u32) u32 {
+    return x * 2;
+}
+
+test "clamp keeps range" {
+    try std.testing.expectEqual(@as(u32, 5), util.clamp(5, 0, 10));
+}
@@ -0,0 +1,30 @@
+const std = @import("std");
+
+/// Fixed-size stack.
+pub const Stack = struct {
+    items: [8]u32,
+    len: usize,
+
+    pub fn init() Stack {
+        return .{ .items = undefined, .len = 0 };
+    }
+
+    pub fn push(self: *Stack, v: u32) void {
+        self.items[self.len] = v;
+        self.len += 1;
+    }
+
+    pub fn top(self: *Stack) u32 {
+        return self.items[self.len - 1];
+    }
+};
+
+const Orphan = struct {
+    value: u32,
+};
+
+pub fn clamp(v: u32, lo: u32, hi: u32) u32 {
+    return @min(@max(v, lo), hi);
+}
+
+fn deadPrivate() void {}
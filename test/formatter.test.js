@@ -2684,3 +2684,438 @@ describe('formatOrient', () => {
         assert.ok(!text.includes('ucn about null'), 'no suggestion when none available');
     });
 });
+
+describe('formatSarif', () => {
+    it('maps deadcode findings to SARIF 2.1.0 rules, regions and fixes', () => {
+        const results = [
+            { name: 'helper', type: 'function', file: 'src/a.js', startLine: 3, endLine: 9, usageCount: 0 },
+            {
+                name: 'strings', type: 'import', file: 'b.go', startLine: 5, endLine: 5, usageCount: 0,
+                importIssue: 'unused', builtWith: 'integration',
+                edits: [{ file: 'b.go', line: 5, expression: '\t"strings"', newExpression: '', suggestion: 'Delete import "strings"' }],
+            },
+        ];
+        results.complexityFindings = [{ name: 'big', type: 'function', file: 'a.go', startLine: 1, endLine: 50, cyclomatic: 12, cognitive: 20 }];
+        results.complexityThresholds = { cyclomatic: 10, cognitive: 15 };
+        const log = JSON.parse(output.formatSarif('deadcode', results));
+        assert.strictEqual(log.version, '2.1.0');
+        const [run] = log.runs;
+        assert.strictEqual(run.tool.driver.name, 'ucn');
+        assert.deepStrictEqual(run.tool.driver.rules.map(r => [r.id, r.defaultConfiguration.level]),
+            [['dead-code', 'warning'], ['import-unused', 'warning'], ['complexity', 'note']]);
+        assert.deepStrictEqual(run.results.map(r => [r.ruleId, r.ruleIndex, r.level]),
+            [['dead-code', 0, 'warning'], ['import-unused', 1, 'warning'], ['complexity', 2, 'note']]);
+        const [dead, imp] = run.results;
+        assert.deepStrictEqual(dead.locations[0].physicalLocation,
+            { artifactLocation: { uri: 'src/a.js', uriBaseId: 'SRCROOT' }, region: { startLine: 3, endLine: 9 } });
+        assert.match(dead.message.text, /helper \(function\)/);
+        assert.match(imp.message.text, /unused when built with integration/);
+        assert.deepStrictEqual(imp.fixes[0].artifactChanges[0].replacements,
+            [{ deletedRegion: { startLine: 5, startColumn: 1, endLine: 6, endColumn: 1 } }]);
+        assert.ok(dead.partialFingerprints['ucnFinding/v1']);
+    });
+
+    it('puts the other copies of a clone or literal in relatedLocations', () => {
+        const log = JSON.parse(output.formatSarif('clones', {
+            literals: [{ value: 'application/json', kind: 'string', count: 3, files: 3,
+                locations: [{ file: 'a.js', line: 1 }, { file: 'b.js', line: 2 }, { file: 'c.js', line: 3 }] }],
+        }));
+        const [result] = log.runs[0].results;
+        assert.strictEqual(result.ruleId, 'duplicate-literal');
+        assert.strictEqual(result.locations[0].physicalLocation.artifactLocation.uri, 'a.js');
+        assert.deepStrictEqual(result.relatedLocations.map(l => l.physicalLocation.artifactLocation.uri), ['b.js', 'c.js']);
+        assert.ok(output.SARIF_COMMANDS.has('auditAsync'));
+    });
+
+    it('gives every finding a severity and confidence, configurable per rule', () => {
+        const results = [
+            { name: 'helper', type: 'function', file: 'src/a.js', startLine: 3, endLine: 9, usageCount: 0 },
+            { name: 'Handle', type: 'method', file: 'src/b.java', startLine: 2, endLine: 4, usageCount: 0, annotations: ['bean'] },
+        ];
+        results.complexityFindings = [{ name: 'big', type: 'function', file: 'a.go', startLine: 1, endLine: 50, cyclomatic: 12, cognitive: 20 }];
+        results.complexityThresholds = { cyclomatic: 10, cognitive: 15 };
+        const levels = (rules) => [...output.findingsOf('deadcode', results, rules)].map(f => [f.rule, f.severity, f.confidence, f.level]);
+        assert.deepStrictEqual(levels(), [
+            ['dead-code', 'warning', 'high', 'warning'],
+            ['dead-code', 'warning', 'low', 'warning'],
+            ['complexity', 'info', 'medium', 'note'],
+        ]);
+        // By id or by name; a bare string is the severity; unknown values are ignored
+        assert.deepStrictEqual(levels({ 'dead-code': { severity: 'error', confidence: 'medium' }, HighComplexity: 'warning' }), [
+            ['dead-code', 'error', 'medium', 'error'],
+            ['dead-code', 'error', 'medium', 'error'],
+            ['complexity', 'warning', 'medium', 'warning'],
+        ]);
+        assert.deepStrictEqual(levels({ 'dead-code': { severity: 'fatal', confidence: 'sure' } }), levels());
+
+        const run = JSON.parse(output.formatSarif('deadcode', results, { rules: { 'dead-code': 'error' } })).runs[0];
+        assert.strictEqual(run.tool.driver.rules[0].defaultConfiguration.level, 'warning');
+        assert.strictEqual(run.results[1].level, 'error');
+        assert.deepStrictEqual(run.results[1].properties, { severity: 'error', confidence: 'low' });
+        // Per-file severities don't leak into the rule: whichever file comes first
+        const perFile = file => (file === 'src/a.js' ? { 'dead-code': 'error' } : {});
+        const sarifLevels = rs => JSON.parse(output.formatSarif('deadcode', rs, { rules: perFile })).runs[0].tool.driver.rules.map(r => r.defaultConfiguration.level);
+        assert.deepStrictEqual(sarifLevels(results), ['warning', 'note']);
+        assert.deepStrictEqual(sarifLevels(Object.assign([...results].reverse(), { complexityFindings: results.complexityFindings, complexityThresholds: results.complexityThresholds })), ['warning', 'note']);
+        const xml = output.formatCheckstyle('deadcode', results, { rules: { 'dead-code': 'error' } });
+        assert.match(xml, /<error line="3" [^>]*severity="error"/);
+        assert.match(output.formatTap('deadcode', results), /^not ok 3 - a\.go:1 .* # TODO advisory$/m);
+        const csv = output.formatCsv('deadcode', results, { rules: { 'dead-code': 'error' } }).split('\n');
+        assert.deepStrictEqual(csv.map(r => r.split(',').pop()), ['severity', 'error', 'error', 'info']);
+    });
+
+    it('gives heuristic warnings their rule\'s medium confidence in every format', () => {
+        const audit = { issues: [{ file: 'a.js', line: 4, callerName: 'main', calleeName: 'save' }] };
+        assert.strictEqual(output.defaultConfidence('missing-await', null), 'medium');
+        assert.strictEqual(output.defaultConfidence('dead-code', null), 'high');
+        assert.deepStrictEqual(output.formatCsv('auditAsync', audit).split('\n')[1].split(',').slice(4, 6), ['missing-await', 'medium']);
+        const run = JSON.parse(output.formatSarif('auditAsync', audit)).runs[0];
+        assert.deepStrictEqual(run.results[0].properties, { severity: 'warning', confidence: 'medium' });
+        const [line] = [...output.jsonLines('auditAsync', audit)].map(l => JSON.parse(l));
+        assert.deepStrictEqual([line.ruleId, line.severity, line.confidence], ['missing-await', 'warning', 'medium']);
+        // .ucn.json still wins over the rule's own confidence
+        assert.strictEqual([...output.findingsOf('auditAsync', audit, { MissingAwait: { confidence: 'high' } })][0].confidence, 'high');
+    });
+});
+
+describe('jsonLines', () => {
+    it('yields one flat JSON object per finding', () => {
+        const results = [
+            { name: 'helper', type: 'function', file: 'src/a.js', startLine: 3, endLine: 9, usageCount: 0 },
+            { name: 'x', type: 'parameter', functionName: 'run', file: 'src/b.js', startLine: 2, endLine: 2 },
+        ];
+        const lines = [...output.jsonLines('deadcode', results)];
+        assert.strictEqual(lines.length, 2);
+        assert.ok(lines.every(l => !l.includes('\n')));
+        const [dead, param] = lines.map(l => JSON.parse(l));
+        assert.deepStrictEqual([dead.command, dead.ruleId, dead.level, dead.file, dead.startLine, dead.endLine],
+            ['deadcode', 'dead-code', 'warning', 'src/a.js', 3, 9]);
+        assert.strictEqual(dead.data.name, 'helper');
+        assert.strictEqual(param.ruleId, 'unused-param');
+        assert.strictEqual(param.endLine, undefined);
+        const issues = [...output.jsonLines('auditAsync', { issues: [{ file: 'a.js', line: 4, callerName: 'main', calleeName: 'save' }] })];
+        assert.match(JSON.parse(issues[0]).message, /save\(\) is async but not awaited in main/);
+    });
+});
+
+describe('formatHtmlReport', () => {
+    it('groups findings by directory with highlighted snippets and dead LOC', () => {
+        const dir = tmp({ 'src/a.js': 'function helper() {\n    return "x" < 1;\n}\n' });
+        try {
+            const results = [
+                { name: 'helper', type: 'function', file: 'src/a.js', startLine: 1, endLine: 3, usageCount: 0 },
+                { name: 'x', type: 'parameter', functionName: 'run', file: 'lib/b.js', startLine: 2, endLine: 2 },
+            ];
+            const html = output.formatHtmlReport('deadcode', results, { root: dir });
+            assert.match(html, /^<!DOCTYPE html>/);
+            assert.ok(!/<(script|link)[^>]+src=|<link[^>]+href=/.test(html), 'no external assets');
+            assert.match(html, /id="pkg-src"/);
+            assert.match(html, /id="pkg-lib"/);
+            assert.match(html, /<td><a href="#pkg-src">src<\/a><\/td><td>1<\/td><td>3<\/td>/);
+            assert.match(html, /<span class="k">function<\/span> helper/);
+            assert.match(html, /<span class="s">&quot;x&quot;<\/span> &lt; <span class="n">1<\/span>/);
+            assert.match(html, /<b>4<\/b>Dead LOC/);
+            assert.match(html, /table class="sortable"/);
+        } finally {
+            rm(dir);
+        }
+    });
+});
+
+describe('formatMermaid', () => {
+    it('renders file, symbol and trace graphs as fenced flowcharts', () => {
+        const files = output.formatMermaid('graph', {
+            root: '/r/a.js', direction: 'imports', maxDepth: 2,
+            nodes: [{ file: '/r/a.js', relativePath: 'a.js' }, { file: '/r/lib/b.js', relativePath: 'lib/b.js' }],
+            edges: [{ from: '/r/a.js', to: '/r/lib/b.js' }],
+        });
+        assert.match(files, /^```mermaid\nflowchart LR\n/);
+        assert.match(files, /subgraph d1\["lib"\]\n {8}n1\["b\.js"\]\n {4}end/);
+        assert.match(files, /n0 --> n1/);
+        assert.match(files, /```$/);
+
+        const calls = output.formatMermaid('graph', {
+            symbols: true, pattern: 'help*', direction: 'callers', maxDepth: 1,
+            nodes: [
+                { id: 'a.js:3', name: 'main', file: 'a.js', line: 3, entrypoint: true, reachable: true },
+                { id: 'a.js:4', name: 'helper', file: 'a.js', line: 4, seed: true, reachable: true },
+                { id: 'a.js:5', name: 'orphan', className: 'Box<T>', file: 'a.js', line: 5, reachable: false },
+            ],
+            edges: [{ from: 'a.js:3', to: 'a.js:4', calls: 2 }, { from: 'a.js:5', to: 'a.js:4', calls: 1 }],
+        });
+        assert.match(calls, /n2\["Box#lt;T#gt;\.orphan<br\/>a\.js:5"\]/);
+        assert.match(calls, /n0 -->\|2\| n1/);
+        assert.match(calls, /class n0 entry\n {4}class n2 dead\n {4}class n1 seed/);
+
+        const trace = output.formatMermaid('trace', {
+            root: 'main', file: 'a.js', line: 1, direction: 'down', maxDepth: 2,
+            tree: { children: [{ name: 'f', file: 'b.js', line: 3, callCount: 2, children: [
+                { name: 'main', file: 'a.js', line: 1, alreadyShown: true, children: [] }] }] },
+        });
+        assert.match(trace, /flowchart TD/);
+        assert.match(trace, /n0 -->\|2x\| n1\n {4}n1 --> n0/);
+        assert.ok(output.MERMAID_COMMANDS.has('trace'));
+    });
+});
+
+describe('formatCsv / formatTsv', () => {
+    it('prints findings under the stable column header', () => {
+        const results = [
+            { name: 'helper', type: 'function', file: 'src/a.js', startLine: 3, endLine: 9, usageCount: 0 },
+            { name: 'Run', type: 'method', className: 'Job', file: 'src/b.go', startLine: 7, endLine: 8, isExported: true },
+        ];
+        const csv = output.formatCsv('deadcode', results).split('\n');
+        assert.strictEqual(csv[0], 'file,line,symbol,kind,rule,confidence,message,severity');
+        assert.match(csv[1], /^src\/a\.js,3,helper,function,dead-code,high,/);
+        assert.match(csv[2], /^src\/b\.go,7,Job\.Run,method,dead-code,low,/);
+        const quoted = output.formatCsv('auditAsync', { issues: [{ file: 'a,b.js', line: 4, callerName: 'main', calleeName: 'save' }] });
+        assert.match(quoted.split('\n')[1], /^"a,b\.js",4,main,call,missing-await,medium,/);
+        const tsv = output.formatTsv('deadcode', results).split('\n');
+        assert.deepStrictEqual(tsv[1].split('\t').slice(0, 6), ['src/a.js', '3', 'helper', 'function', 'dead-code', 'high']);
+        assert.deepStrictEqual(output.CSV_COLUMNS.slice(0, 6), ['file', 'line', 'symbol', 'kind', 'rule', 'confidence']);
+    });
+});
+
+describe('formatJunit', () => {
+    it('fails one test case per rule and package', () => {
+        const results = [
+            { name: 'helper', type: 'function', file: 'src/a.js', startLine: 3, endLine: 9, usageCount: 0 },
+            { name: 'other', type: 'function', file: 'src/b.js', startLine: 1, endLine: 2, usageCount: 0 },
+            { name: 'x', type: 'parameter', functionName: 'run<T>', file: 'lib/c.js', startLine: 2, endLine: 2 },
+        ];
+        const xml = output.formatJunit('deadcode', results);
+        assert.match(xml, /^<\?xml version="1\.0" encoding="UTF-8"\?>/);
+        assert.match(xml, /<testsuites name="ucn deadcode" tests="2" failures="2">/);
+        assert.match(xml, /<testsuite name="dead-code" tests="1" failures="1">/);
+        assert.match(xml, /<testcase classname="ucn\.dead-code" name="src" file="src\/a\.js">\n\s+<failure type="DeadCode" message="2 finding\(s\) in src">src\/a\.js:3: [^\n]*\nsrc\/b\.js:1: /);
+        assert.match(xml, /<testsuite name="unused-param"[\s\S]*name="lib"/);
+        assert.ok(!/<T>/.test(xml), 'markup is escaped');
+        assert.match(output.formatJunit('deadcode', []), /tests="1" failures="0"[\s\S]*name="no findings"\/>/);
+    });
+});
+
+describe('formatCheckstyle', () => {
+    it('lists one error per finding under its file', () => {
+        const results = [
+            { name: 'b', type: 'function', file: 'src/a.js', startLine: 9, endLine: 12, usageCount: 0 },
+            { name: 'a', type: 'function', file: 'src/a.js', startLine: 3, endLine: 5, usageCount: 0 },
+        ];
+        results.complexityThresholds = { cyclomatic: 10, cognitive: 15 };
+        results.complexityFindings = [{ name: 'big', file: 'lib/"q".js', startLine: 1, endLine: 80, cyclomatic: 30, cognitive: 40 }];
+        const xml = output.formatCheckstyle('deadcode', results);
+        assert.match(xml, /<checkstyle version="8\.0">\n {2}<file name="lib\/&quot;q&quot;\.js">\n {4}<error line="1" column="1" severity="info" [^>]*source="ucn\.HighComplexity"\/>/);
+        assert.match(xml, /<file name="src\/a\.js">\n {4}<error line="3" [^>]*severity="warning"[^>]*source="ucn\.DeadCode"\/>\n {4}<error line="9" /);
+        assert.match(output.formatCheckstyle('deadcode', []), /<checkstyle version="8\.0">\n<\/checkstyle>/);
+    });
+});
+
+describe('formatGitlabCodeQuality', () => {
+    it('emits issues with unique line-free fingerprints', () => {
+        const deprecated = {
+            used: [{ name: 'Old', file: 'a.go', startLine: 1, endLine: 3, references: [
+                { file: 'b.go', line: 4, caller: 'main' }, { file: 'b.go', line: 9, caller: 'main' }] }],
+            unused: [],
+        };
+        const issues = JSON.parse(output.formatGitlabCodeQuality('deprecated', deprecated));
+        assert.strictEqual(issues.length, 2);
+        assert.deepStrictEqual(issues[0].location, { path: 'b.go', lines: { begin: 4, end: 4 } });
+        assert.strictEqual(issues[0].check_name, 'DeprecatedUse');
+        assert.strictEqual(issues[0].severity, 'minor');
+        assert.match(issues[0].fingerprint, /^[0-9a-f]{32}$/);
+        assert.notStrictEqual(issues[0].fingerprint, issues[1].fingerprint);
+
+        const moved = { ...deprecated, used: [{ ...deprecated.used[0], references: [{ file: 'b.go', line: 40, caller: 'main' }] }] };
+        assert.strictEqual(JSON.parse(output.formatGitlabCodeQuality('deprecated', moved))[0].fingerprint, issues[0].fingerprint);
+    });
+});
+
+describe('formatRdjson / rdjsonLines', () => {
+    it('emits reviewdog diagnostics with import edits as suggestions', () => {
+        const results = [
+            { name: 'helper', type: 'function', file: 'src/a.js', startLine: 3, endLine: 9, usageCount: 0 },
+            { name: 'os', type: 'import', file: 'cmd/main.go', startLine: 5, endLine: 5, importIssue: 'unused', buildTags: ['integration'],
+                edits: [{ file: 'cmd/main.go', line: 5, expression: '\t"os"', newExpression: '', suggestion: 'Delete import "os"' }] },
+        ];
+        const doc = JSON.parse(output.formatRdjson('deadcode', results));
+        assert.strictEqual(doc.source.name, 'ucn');
+        const [dead, imp] = doc.diagnostics;
+        assert.deepStrictEqual(dead.location, { path: 'src/a.js', range: { start: { line: 3 }, end: { line: 9 } } });
+        assert.deepStrictEqual([dead.severity, dead.code.value], ['WARNING', 'dead-code']);
+        assert.deepStrictEqual(imp.suggestions, [{ range: { start: { line: 5, column: 1 }, end: { line: 6, column: 1 } }, text: '' }]);
+        const lines = [...output.rdjsonLines('deadcode', results)];
+        assert.strictEqual(lines.length, 2);
+        assert.strictEqual(JSON.parse(lines[1]).source.name, 'ucn');
+        assert.strictEqual(JSON.parse(lines[1]).code.value, 'import-unused');
+    });
+});
+
+describe('formatSonarqube', () => {
+    it('emits generic issue data with a rule per rule and severity', () => {
+        const results = [
+            { name: 'a', type: 'function', file: 'src/a.js', startLine: 3, endLine: 9, usageCount: 0 },
+            { name: 'b', type: 'function', file: 'legacy/b.js', startLine: 1, endLine: 1, usageCount: 0 },
+        ];
+        const rules = file => (file.startsWith('legacy/') ? { 'dead-code': { severity: 'error' } } : {});
+        const doc = JSON.parse(output.formatSonarqube('deadcode', results, { rules }));
+        assert.deepStrictEqual(doc.rules.map(r => [r.id, r.name, r.engineId, r.impacts[0].severity]), [
+            ['dead-code', 'DeadCode', 'ucn', 'MEDIUM'],
+            ['dead-code:error', 'DeadCode', 'ucn', 'HIGH'],
+        ]);
+        assert.deepStrictEqual(doc.issues[0], {
+            ruleId: 'dead-code',
+            primaryLocation: { message: doc.issues[0].primaryLocation.message, filePath: 'src/a.js', textRange: { startLine: 3, endLine: 9 } },
+        });
+        assert.strictEqual(doc.issues[1].ruleId, 'dead-code:error');
+        assert.deepStrictEqual(doc.issues[1].primaryLocation.textRange, { startLine: 1 });
+        assert.deepStrictEqual(JSON.parse(output.formatSonarqube('deadcode', [])), { rules: [], issues: [] });
+    });
+});
+
+describe('formatMarkdown', () => {
+    it('summarizes findings per rule, top offender and package', () => {
+        const results = [
+            { name: 'big', type: 'function', file: 'src/a.js', startLine: 10, endLine: 49, usageCount: 0 },
+            { name: 'small', type: 'function', file: 'src/a.js', startLine: 1, endLine: 2, usageCount: 0 },
+            { name: 'x', type: 'parameter', functionName: 'a|b', file: 'lib/c.js', startLine: 2, endLine: 2 },
+        ];
+        const md = output.formatMarkdown('deadcode', results);
+        assert.match(md, /^## ucn deadcode: 3 finding\(s\), ~43 dead line\(s\)/);
+        assert.match(md, /\| DeadCode \| warning \| 2 \| 42 \|/);
+        assert.match(md, /### Top offenders\n\n\| Symbol \| Location \| Lines \| Rule \|\n\|---\|---\|--:\|---\|\n\| `big` \| src\/a\.js:10 \| 40 \| DeadCode \|/);
+        assert.match(md, /<details><summary><code>src<\/code>: 2 finding\(s\), 42 dead line\(s\)<\/summary>/);
+        assert.match(md, /a\\\|b/);
+        assert.strictEqual(output.formatMarkdown('deadcode', []), '## ucn deadcode: 0 finding(s)\n\nNo findings.');
+    });
+});
+
+describe('formatPrometheus', () => {
+    it('exposes findings and dead code per package as gauges', () => {
+        const results = [
+            { name: 'a', type: 'function', file: 'src/a.js', startLine: 1, endLine: 10, usageCount: 0 },
+            { name: 'b', type: 'function', file: 'src/b.js', startLine: 1, endLine: 5, usageCount: 0 },
+            { name: 'x', type: 'parameter', functionName: 'run', file: 'lib/"q"/c.js', startLine: 2, endLine: 2 },
+        ];
+        const text = output.formatPrometheus('deadcode', results, { durationSeconds: 1.5, timestamp: 1700000000500 });
+        assert.match(text, /# TYPE ucn_findings gauge\n/);
+        assert.match(text, /ucn_findings\{command="deadcode",rule="dead-code",severity="warning",package="src"\} 2\n/);
+        assert.match(text, /ucn_findings\{command="deadcode",rule="unused-param",severity="warning",package="lib\/\\"q\\""\} 1\n/);
+        assert.match(text, /ucn_dead_symbols\{package="src"\} 2\n/);
+        assert.match(text, /ucn_dead_lines\{package="src"\} 15\n/);
+        assert.match(text, /ucn_analysis_duration_seconds\{command="deadcode"\} 1\.500\n/);
+        assert.match(text, /ucn_last_run_timestamp_seconds\{command="deadcode"\} 1700000000\n$/);
+        assert.ok(!/ucn_dead_lines/.test(output.formatPrometheus('clones', { groups: [] })));
+    });
+
+    it('puts several runs under one header per metric', () => {
+        const dead = [{ name: 'a', type: 'function', file: 'src/a.js', startLine: 1, endLine: 3, usageCount: 0 }];
+        const text = output.formatPrometheusRuns([['deadcode', dead], ['clones', { groups: [] }]], { durationSeconds: 2, timestamp: 1700000000000 });
+        assert.strictEqual(text.match(/# TYPE ucn_last_run_timestamp_seconds gauge/g).length, 1);
+        assert.match(text, /ucn_last_run_timestamp_seconds\{command="deadcode"\} 1700000000\nucn_last_run_timestamp_seconds\{command="clones"\} 1700000000\n$/);
+        assert.match(text, /ucn_analysis_duration_seconds\{command="clones"\} 2\.000\n/);
+        assert.match(text, /ucn_dead_symbols\{package="src"\} 1\n/);
+    });
+});
+
+describe('formatCoverageHtml', () => {
+    it('renders whole files with dead lines shaded', () => {
+        const dir = tmp({ 'src/a.js': 'function live() {}\nfunction dead() {\n    return 1;\n}\n' });
+        try {
+            const results = [{ name: 'dead', type: 'function', file: 'src/a.js', startLine: 2, endLine: 4, usageCount: 0 }];
+            const html = output.formatCoverageHtml('deadcode', results, { root: dir });
+            assert.match(html, /^<!DOCTYPE html>/);
+            assert.match(html, /3 of 4 line\(s\) in 1 file\(s\)/);
+            assert.match(html, /<td><a href="#src\/a\.js">src\/a\.js<\/a><\/td><td>3<\/td><td>4<\/td><td><span class="meter"><i style="width:75%"><\/i><\/span> 75%<\/td>/);
+            assert.match(html, /<span class="line"><span class="ln">1<\/span><span class="k">function<\/span> live/);
+            assert.match(html, /<span class="line dead" title="Symbol with no references: dead \(function\) \(warning, high confidence\)"><span class="ln">2<\/span>/);
+            assert.match(html, /<span class="line dead"[^>]*><span class="ln">4<\/span>\}<\/span>\n<\/pre>/);
+            assert.match(output.formatCoverageHtml('deadcode', [], { root: dir }), /No dead lines\./);
+        } finally {
+            rm(dir);
+        }
+    });
+});
+
+describe('formatLcov', () => {
+    it('marks dead lines uncovered and the rest of the file covered', () => {
+        const dir = tmp({ 'src/a.js': 'function live() {}\n\nfunction dead() {\n    return 1;\n}\n' });
+        try {
+            const results = [{ name: 'dead', type: 'function', file: 'src/a.js', startLine: 3, endLine: 5, usageCount: 0 }];
+            assert.strictEqual(output.formatLcov('deadcode', results, { root: dir }), [
+                'TN:ucn-deadcode', 'SF:src/a.js',
+                'FN:3,dead', 'FNDA:0,dead', 'FNF:1', 'FNH:0',
+                'DA:1,1', 'DA:3,0', 'DA:4,0', 'DA:5,0',
+                'LF:4', 'LH:1', 'end_of_record',
+            ].join('\n') + '\n');
+            assert.strictEqual(output.formatLcov('deadcode', [], { root: dir }), '');
+        } finally {
+            rm(dir);
+        }
+    });
+});
+
+describe('compileTemplate / formatTemplate', () => {
+    const results = [
+        { name: 'dead', type: 'function', file: 'src/a.js', startLine: 2, endLine: 4, usageCount: 0 },
+        { name: 'gone', className: 'A', type: 'method', file: 'lib/b.js', startLine: 7, endLine: 9, usageCount: 0 },
+    ];
+
+    it('renders fields, ranges, conditionals and pipelines over the findings', () => {
+        const tmpl = output.compileTemplate([
+            '{{- /* a Slack payload */ -}}',
+            '{"text": {{printf "%d dead symbol(s)" .Total | json}}, "lines": [',
+            '{{- range $i, $f := .Findings}}{{if $i}},{{end}}',
+            '  {{json (printf "%s:%d %s" $f.File $f.Line $f.Symbol)}}',
+            '{{- end}}',
+            ']}',
+            '{{range .Rules}}{{.Name}}={{.Count}}{{else}}none{{end}} {{if eq .Command "clones"}}c{{else if .Total}}t{{else}}n{{end}} {{with index .Findings 1}}{{.Package}}{{end}}',
+        ].join('\n'), 'slack.tmpl');
+        assert.strictEqual(output.formatTemplate(tmpl, 'deadcode', results), [
+            '{"text": "2 dead symbol(s)", "lines": [',
+            '  "src/a.js:2 dead",',
+            '  "lib/b.js:7 A.gone"',
+            ']}',
+            'DeadCode=2 t lib',
+        ].join('\n'));
+        assert.strictEqual(output.formatTemplate(tmpl, 'deadcode', []).split('\n').pop(), 'none n ');
+    });
+
+    it('reports syntax and runtime errors with the template name and line', () => {
+        assert.throws(() => output.compileTemplate('ok\n{{if .Total}}', 'a.tmpl'), /^Error: template: a\.tmpl:2: unexpected EOF/);
+        assert.throws(() => output.compileTemplate('{{end}}', 'a.tmpl'), /template: a\.tmpl:1: unexpected \{\{end\}\}/);
+        assert.throws(() => output.compileTemplate('{{.Total', 'a.tmpl'), /unclosed action/);
+        const tmpl = output.compileTemplate('{{.Total | shout}}', 'a.tmpl');
+        assert.throws(() => output.formatTemplate(tmpl, 'deadcode', results), /function "shout" not defined/);
+    });
+});
+
+describe('formatTap', () => {
+    it('emits a failing test point per finding with a YAML diagnostic', () => {
+        const tap = output.formatTap('deadcode', [
+            { name: 'dead', type: 'function', file: 'src/a.js', startLine: 2, endLine: 4, usageCount: 0 },
+        ]);
+        assert.strictEqual(tap, [
+            'TAP version 13',
+            '1..1',
+            'not ok 1 - src/a.js:2 Symbol with no references: dead (function)',
+            '  ---',
+            '  rule: DeadCode',
+            '  severity: warning',
+            '  confidence: high',
+            '  file: src/a.js',
+            '  line: 2',
+            '  endLine: 4',
+            '  symbol: dead',
+            '  ...',
+        ].join('\n') + '\n');
+    });
+
+    it('marks advisory findings TODO and passes an empty run', () => {
+        const tap = output.formatTap('clones', {
+            literals: [{ value: '#fff', kind: 'string', count: 2, files: 2,
+                locations: [{ file: 'x.js', line: 1 }, { file: 'y.js', line: 3 }] }],
+        });
+        assert.match(tap, /^not ok 1 - x\.js:1 .*\\#fff.* # TODO advisory$/m);
+        assert.match(tap, /^  severity: info\n  confidence: medium$/m);
+        assert.strictEqual(output.formatTap('deadcode', []), 'TAP version 13\n1..1\nok 1 - ucn deadcode: no findings\n');
+    });
+});
@@ -216,12 +216,13 @@ class McpClient {
 // ── Language helpers ─────────────────────────────────────────────────────────
 
 /**
- * Iterate over all primary languages (skips html/tsx which delegate to JS).
+ * Iterate over all primary languages (skips html/tsx which delegate to JS,
+ * and optional-grammar languages whose grammar is not a dependency).
  * Calls fn(langName, traits, primaryExtension) for each.
  */
 function forEachLanguage(fn) {
     for (const [name, config] of Object.entries(LANGUAGES)) {
-        if (name === 'html' || name === 'tsx') continue;
+        if (name === 'html' || name === 'tsx' || config.optional) continue;
         fn(name, config.traits, config.extensions[0]);
     }
 }
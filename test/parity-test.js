@@ -969,6 +969,12 @@ describe('Architecture Guards', () => {
             'line',
             // endpoints command
             'bridge', 'unmatched', 'method', 'prefix',
+            // deadcode --types
+            'types',
+            // clones threshold
+            'similarity',
+            // deadcode --build-variants matrix
+            'platforms',
         ];
         for (const p of directParams) knownCamelParams.add(p);
 
@@ -1040,7 +1046,7 @@ describe('Architecture Guards', () => {
         // CLI flags that are structural (not per-command) — exempt from FLAG_APPLICABILITY
         // CLI structural/mode flags not per-command — exempt from FLAG_APPLICABILITY
         const exemptFlags = new Set([
-            'json', 'no-cache', 'clear-cache', 'interactive', 'i',
+            'json', 'format', 'no-cache', 'clear-cache', 'interactive', 'i',
             'help', 'h', 'version', 'v', 'mcp', 'no-quiet', 'quiet', 'verbose',
             'expand', 'not', 'no-follow-symlinks', 'no-regex', 'default',
             'max-files', 'max-chars', 'workers',
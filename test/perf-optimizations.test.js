@@ -1248,4 +1248,30 @@ describe('index reliability: parallel build equals sequential build', () => {
                 'parallel and sequential builds must produce identical indexes');
         } finally { rm(dir); }
     });
+
+    it('pool size does not change the index, and --jobs bounds the pool', () => {
+        const { tmp, rm, indexSnapshot } = require('./helpers');
+        const { ProjectIndex } = require('../core/project');
+        const { poolSize } = require('../core/parallel-build');
+        assert.strictEqual(poolSize(520, 3), 3);
+        assert.strictEqual(poolSize(520, 1), 1, '--jobs 1 builds sequentially');
+        assert.strictEqual(poolSize(150, 16), 2, 'at least 100 files per worker');
+        assert.ok(poolSize(5000) <= 8, 'auto-detect caps the pool');
+
+        // Uneven files, so workers finish their claims in varying order
+        const spec = { 'package.json': '{"name":"pool"}' };
+        for (let i = 0; i < 320; i++) {
+            const body = Array.from({ length: i % 7 === 0 ? 200 : 1 }, (_, j) => `    const v${j} = h${i}(${j});`);
+            spec[`p${i}.js`] = [`function h${i}(x) { return x; }`, `function f${i}() {`, ...body, '}', `module.exports = { f${i} };`].join('\n');
+        }
+        const dir = tmp(spec);
+        try {
+            const snapshots = [0, 2, 3, 4].map(jobs => {
+                const index = new ProjectIndex(dir);
+                index.build(null, { quiet: true, jobs });
+                return indexSnapshot(index);
+            });
+            for (const snap of snapshots.slice(1)) assert.strictEqual(snap, snapshots[0]);
+        } finally { rm(dir); }
+    });
 });
@@ -0,0 +1,101 @@
+/**
+ * UCN Shell Script Regression Tests
+ *
+ * Shell is an optional-grammar language: tree-sitter-bash is not a declared
+ * dependency, so the parser-backed suites skip when it is absent. Sourced-path
+ * normalization and resolution are grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { isTestFile } = require('../core/discovery');
+const { resolveImport } = require('../core/imports');
+const { normalizeSourcePath } = require('../languages/bash');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('bash');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-bash not installed';
+const BASH_FIXTURES = path.join(FIXTURES_PATH, 'bash');
+
+describe('Shell: optional grammar gating', () => {
+    it('.sh/.bash are analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('deploy.sh'), HAS_GRAMMAR ? 'bash' : null);
+        assert.strictEqual(detectLanguage('env.bash'), HAS_GRAMMAR ? 'bash' : null);
+    });
+
+    it('test scripts are test files', () => {
+        assert.ok(isTestFile('scripts/deploy_test.sh', 'bash'));
+        assert.ok(isTestFile('tests/smoke.sh', 'bash'));
+        assert.ok(!isTestFile('scripts/deploy.sh', 'bash'));
+    });
+});
+
+describe('Shell: sourced paths', () => {
+    it('normalizes script-directory prefixes to ./', () => {
+        assert.strictEqual(normalizeSourcePath('"$(dirname "$0")/lib/common.sh"'), './lib/common.sh');
+        assert.strictEqual(normalizeSourcePath('"${BASH_SOURCE%/*}/util.sh"'), './util.sh');
+        assert.strictEqual(normalizeSourcePath('"$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)/env.sh"'), './env.sh');
+        assert.strictEqual(normalizeSourcePath('"$SCRIPT_DIR/x.sh"'), './x.sh');
+        assert.strictEqual(normalizeSourcePath('lib/log.sh'), 'lib/log.sh');
+    });
+
+    it('leaves user-environment and computed paths dynamic', () => {
+        assert.strictEqual(normalizeSourcePath('"$HOME/.bashrc"'), null);
+        assert.strictEqual(normalizeSourcePath('"$dir/$name.sh"'), null);
+    });
+
+    it('resolves relative to the sourcing script, then the project root', () => {
+        const opts = { language: 'bash', root: BASH_FIXTURES };
+        const from = path.join(BASH_FIXTURES, 'deploy.sh');
+        assert.strictEqual(resolveImport('./lib/common.sh', from, opts), path.join(BASH_FIXTURES, 'lib', 'common.sh'));
+        assert.strictEqual(resolveImport('lib/log.sh', path.join(BASH_FIXTURES, 'ci', 'lint.sh'), opts),
+            path.join(BASH_FIXTURES, 'lib', 'log.sh'));
+        assert.strictEqual(resolveImport('lib/missing.sh', from, opts), null);
+    });
+});
+
+describe('Shell: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('extracts both function syntaxes and export -f', () => {
+        const result = parse(`greet() { echo hi; }
+function farewell { echo bye; }
+export -f greet
+`, 'bash');
+        const byName = Object.fromEntries(result.functions.map(f => [f.name, f]));
+        assert.deepStrictEqual(byName.greet.modifiers, ['export']);
+        assert.deepStrictEqual(byName.farewell.modifiers, []);
+    });
+
+    it('records trap handlers and complete -F functions as calls', () => {
+        const { getParser, getLanguageModule } = require('../languages');
+        const calls = getLanguageModule('bash').findCallsInCode(`trap 'cleanup; restore' EXIT
+complete -F _tool_complete tool
+`, getParser('bash'));
+        const names = calls.map(c => c.name);
+        for (const n of ['cleanup', 'restore', '_tool_complete']) assert.ok(names.includes(n), names.join(','));
+    });
+});
+
+describe('Shell: deadcode', { skip }, () => {
+    it('reports functions never invoked across the repo\'s scripts', () => {
+        const index = idx(BASH_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['unused_rollback', 'legacy_retry', 'log_debug', 'orphan_check']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        for (const name of ['main', 'cleanup', 'die', 'ensure_tool', 'log_info']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+
+    it('links sourced libraries into the import graph', () => {
+        const index = idx(BASH_FIXTURES);
+        const resolved = index.imports('deploy.sh').map(i => i.resolved);
+        assert.ok(resolved.includes(path.join('lib', 'common.sh')), JSON.stringify(resolved));
+        assert.ok(resolved.includes(path.join('lib', 'log.sh')), JSON.stringify(resolved));
+    });
+});
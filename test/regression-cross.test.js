@@ -6017,3 +6017,69 @@ describe('fix #261: zero-candidate method calls route external', () => {
         } finally { rm(dir); }
     });
 });
+
+describe('feature: cross-language link rules (.ucn.json "links")', () => {
+    const { expandNameTemplate } = require('../core/links');
+
+    it('expands name templates over CLI and identifier spellings', () => {
+        assert.strictEqual(expandNameTemplate('{Pascal}', 'sync-users'), 'SyncUsers');
+        assert.strictEqual(expandNameTemplate('{camel}', 'sync_users'), 'syncUsers');
+        assert.strictEqual(expandNameTemplate('{snake}', 'getUserByID'), 'get_user_by_id');
+        assert.strictEqual(expandNameTemplate('run{Pascal}Cmd', 'export'), 'runExportCmd');
+        assert.strictEqual(expandNameTemplate('{name}', 'get-user'), 'get-user');
+    });
+
+    it('keeps Go handlers named only by an OpenAPI spec or a Python script alive', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/svc\n\ngo 1.21\n',
+            'handlers/users.go': [
+                'package handlers',
+                '',
+                'func getUser() string { return "u" }',
+                '',
+                'func syncUsers() int { return 1 }',
+                '',
+                'func legacyExport() int { return 2 }',
+            ].join('\n'),
+            'api/openapi.yaml': 'paths:\n  /users/{id}:\n    get:\n      operationId: getUser\n',
+            'scripts/nightly.py': 'import subprocess\n\nsubprocess.run(["svc", "sync-users"])\n',
+            '.ucn.json': JSON.stringify({
+                links: [
+                    { name: 'openapi', from: 'api/openapi.yaml', match: 'operationId:\\s*(\\w+)', to: { language: 'go' } },
+                    { name: 'cli', from: 'scripts/**/*.py', match: '\\["svc",\\s*"([\\w-]+)"', to: { language: 'go', name: '{camel}' } },
+                ],
+            }),
+        });
+        try {
+            const index = idx(dir);
+            const { links, rules } = index.links();
+            assert.deepStrictEqual(links.map(l => [l.rule, l.file, l.line, l.target.name]), [
+                ['openapi', 'api/openapi.yaml', 4, 'getUser'],
+                ['cli', 'scripts/nightly.py', 3, 'syncUsers'],
+            ]);
+            assert.deepStrictEqual(rules.map(r => [r.name, r.links]), [['openapi', 1], ['cli', 1]]);
+            const dead = index.deadcode().map(d => d.name);
+            assert.ok(dead.includes('legacyExport'), `legacyExport stays dead: ${dead}`);
+            assert.ok(!dead.includes('getUser') && !dead.includes('syncUsers'), `linked handlers are used: ${dead}`);
+        } finally { rm(dir); }
+    });
+
+    it('doctor reports invalid rules and rules that link nothing', () => {
+        const dir = tmp({
+            'app.py': 'def main():\n    pass\n',
+            '.ucn.json': JSON.stringify({
+                links: [
+                    { name: 'broken', from: '*.yaml', match: '(unclosed' },
+                    { name: 'empty', from: 'docs/*.md', match: 'see `(\\w+)`' },
+                ],
+            }),
+        });
+        try {
+            const index = idx(dir);
+            const { ok, result } = execute(index, 'doctor', {});
+            assert.ok(ok);
+            assert.match(result.dimensions.semanticRecall.reason, /link rule "broken" has an invalid "match" regex/);
+            assert.match(result.dimensions.semanticRecall.reason, /link rule "empty" links nothing/);
+        } finally { rm(dir); }
+    });
+});
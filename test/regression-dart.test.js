@@ -0,0 +1,126 @@
+/**
+ * UCN Dart Regression Tests
+ *
+ * Dart is an optional-grammar language: tree-sitter-dart is not a declared
+ * dependency, so the parser-backed suites skip when it is absent. URI
+ * resolution and library-part stitching are grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { isTestFile } = require('../core/discovery');
+const { resolveImport } = require('../core/imports');
+const { stitchLibraryParts } = require('../core/graph-build');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('dart');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-dart not installed';
+const DART_FIXTURES = path.join(FIXTURES_PATH, 'dart');
+
+describe('Dart: optional grammar gating', () => {
+    it('.dart is analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('lib/main.dart'), HAS_GRAMMAR ? 'dart' : null);
+    });
+
+    it('_test.dart files and integration_test/ are test files', () => {
+        assert.ok(isTestFile('test/counter_test.dart', 'dart'));
+        assert.ok(isTestFile('integration_test/app.dart', 'dart'));
+        assert.ok(!isTestFile('lib/src/counter.dart', 'dart'));
+    });
+});
+
+describe('Dart: URI resolution', () => {
+    const resolve = (uri, from) => resolveImport(uri, path.join(DART_FIXTURES, from),
+        { language: 'dart', root: DART_FIXTURES });
+
+    it('maps package:<own package>/ to lib/', () => {
+        assert.strictEqual(resolve('package:counter_app/src/counter.dart', 'test/counter_test.dart'),
+            path.join(DART_FIXTURES, 'lib', 'src', 'counter.dart'));
+    });
+
+    it('resolves scheme-less URIs (imports and parts) relative to the file', () => {
+        assert.strictEqual(resolve('src/format.dart', 'lib/main.dart'),
+            path.join(DART_FIXTURES, 'lib', 'src', 'format.dart'));
+        assert.strictEqual(resolve('counter_history.dart', 'lib/src/counter.dart'),
+            path.join(DART_FIXTURES, 'lib', 'src', 'counter_history.dart'));
+    });
+
+    it('leaves dart: and third-party packages external', () => {
+        assert.strictEqual(resolve('dart:async', 'lib/main.dart'), null);
+        assert.strictEqual(resolve('package:flutter/material.dart', 'lib/main.dart'), null);
+    });
+});
+
+describe('Dart: library part stitching', () => {
+    it('links parts with their library and with the library\'s importers', () => {
+        const root = '/proj';
+        const lib = '/proj/lib/a.dart', part = '/proj/lib/a_part.dart', user = '/proj/bin/main.dart';
+        const index = {
+            root,
+            files: new Map([
+                [lib, { partFiles: ['a_part.dart'], moduleResolved: { 'a_part.dart': 'lib/a_part.dart' } }],
+                [part, { moduleResolved: {} }],
+                [user, { moduleResolved: { 'package:x/a.dart': 'lib/a.dart' } }],
+            ]),
+            importGraph: new Map([[lib, new Set([part])], [part, new Set()], [user, new Set([lib])]]),
+            exportGraph: new Map([[lib, new Set([user])], [part, new Set([lib])]]),
+        };
+        stitchLibraryParts(index);
+        assert.ok(index.importGraph.get(user).has(part));
+        assert.ok(index.importGraph.get(part).has(lib));
+        assert.deepStrictEqual([...index.exportGraph.get(part)].sort(), [user, lib].sort());
+    });
+});
+
+describe('Dart: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('models classes, underscore privacy and @override', () => {
+        const result = parse(`class Greeter extends Base with Loud implements Named {
+  final String name;
+  Greeter(this.name);
+  @override
+  String greet() => 'hi $name';
+  void _secret() {}
+  static Greeter create() => Greeter('x');
+}
+`, 'dart');
+        const cls = result.classes.find(c => c.name === 'Greeter');
+        assert.strictEqual(cls.extends, 'Base');
+        assert.ok(cls.implements.includes('Loud') && cls.implements.includes('Named'));
+        const byName = Object.fromEntries(cls.members.map(m => [m.name, m]));
+        assert.ok(byName.greet.modifiers.includes('override'));
+        assert.ok(byName._secret.modifiers.includes('private'));
+        assert.strictEqual(byName.create.memberType, 'static');
+        assert.strictEqual(byName.name.memberType, 'field');
+        assert.ok(!byName.Greeter, 'constructors resolve to the class, not a member');
+    });
+
+    it('records part directives', () => {
+        const result = parse(`part 'src/a.dart';\npart 'src/b.dart';\nvoid main() {}\n`, 'dart');
+        assert.deepStrictEqual(result.partFiles, ['src/a.dart', 'src/b.dart']);
+    });
+});
+
+describe('Dart: deadcode', { skip }, () => {
+    it('flags dead private functions across part files, not Flutter lifecycle methods', () => {
+        const index = idx(DART_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['_unusedReset', '_dropHistory', '_shout']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        for (const name of ['main', 'build', 'createState', 'initState', 'dispose', '_increment',
+            '_record', '_pad', 'label', 'increment']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+
+    it('stitches part files into their library\'s importers', () => {
+        const index = idx(DART_FIXTURES);
+        const importers = index.exporters('lib/src/counter_history.dart').map(e => e.file);
+        assert.ok(importers.some(f => f.endsWith(path.join('lib', 'main.dart'))), JSON.stringify(importers));
+    });
+});
@@ -0,0 +1,113 @@
+/**
+ * UCN Elixir Regression Tests
+ *
+ * Elixir is an optional-grammar language: tree-sitter-elixir is not a
+ * declared dependency, so the parser-backed suites skip when it is absent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { detectProjectPattern, isTestFile } = require('../core/discovery');
+const { tmp, rm, idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('elixir');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-elixir not installed';
+
+describe('Elixir: optional grammar gating', () => {
+    it('.ex/.exs are analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('lib/app.ex'), HAS_GRAMMAR ? 'elixir' : null);
+        assert.strictEqual(detectLanguage('test/app_test.exs'), HAS_GRAMMAR ? 'elixir' : null);
+        const dir = tmp({ 'mix.exs': 'defmodule X.MixProject do\nend\n' });
+        try {
+            assert.strictEqual(detectProjectPattern(dir).includes('exs'), HAS_GRAMMAR);
+        } finally {
+            rm(dir);
+        }
+    });
+
+    it('ExUnit files are test files', () => {
+        assert.ok(isTestFile('test/worker_test.exs', 'elixir'));
+        assert.ok(!isTestFile('lib/worker.ex', 'elixir'));
+    });
+});
+
+describe('Elixir: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('models def vs defp visibility, guards and multi-clause heads', () => {
+        const result = parse(`defmodule Calc do
+  def add(a, b), do: a + b
+  defp clamp(n) when n < 0, do: 0
+  defp clamp(n), do: n
+  defmacro twice(x) do
+    quote do: unquote(x) * 2
+  end
+end
+`, 'elixir');
+        const add = result.functions.find(f => f.name === 'add');
+        assert.deepStrictEqual(add.modifiers, ['public']);
+        assert.strictEqual(add.params, 'a, b');
+        const clamps = result.functions.filter(f => f.name === 'clamp');
+        assert.strictEqual(clamps.length, 2);
+        assert.ok(clamps.every(f => f.modifiers.includes('private')));
+        const twice = result.functions.find(f => f.name === 'twice');
+        assert.ok(twice.modifiers.includes('macro'));
+        const mod = result.classes.find(c => c.name === 'Calc');
+        assert.strictEqual(mod.type, 'module');
+    });
+
+    it('marks behaviour callbacks and @impl defs, not plain defs', () => {
+        const result = parse(`defmodule Counter do
+  use GenServer
+  def init(n), do: {:ok, n}
+  @impl true
+  def handle_info(_msg, s), do: {:noreply, s}
+  def value(pid), do: GenServer.call(pid, :value)
+end
+`, 'elixir');
+        const mods = Object.fromEntries(result.functions.map(f => [f.name, f.modifiers]));
+        assert.ok(mods.init.includes('callback'));
+        assert.ok(mods.handle_info.includes('callback'));
+        assert.ok(!mods.value.includes('callback'));
+    });
+
+    it('extracts alias/import/require/use directives', () => {
+        const { extractImports } = require('../core/imports');
+        const { imports } = extractImports(`defmodule A do
+  alias MyApp.{Repo, Accounts}
+  alias MyApp.Billing, as: Pay
+  import Ecto.Query
+  use GenServer
+end
+`, 'elixir');
+        const byModule = Object.fromEntries(imports.map(i => [i.module, i]));
+        assert.deepStrictEqual(byModule['MyApp.Repo'].names, ['Repo']);
+        assert.deepStrictEqual(byModule['MyApp.Accounts'].names, ['Accounts']);
+        assert.deepStrictEqual(byModule['MyApp.Billing'].names, ['Pay']);
+        assert.strictEqual(byModule['Ecto.Query'].type, 'import');
+        assert.strictEqual(byModule.GenServer.type, 'use');
+    });
+});
+
+describe('Elixir: deadcode', { skip }, () => {
+    it('flags unused defp but not OTP callbacks', () => {
+        const index = idx(path.join(FIXTURES_PATH, 'elixir'));
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        assert.ok(dead.includes('stale_helper'), `stale_helper should be dead: ${dead}`);
+        assert.ok(dead.includes('unused_pad'), `unused_pad should be dead: ${dead}`);
+        for (const live of ['init', 'handle_call', 'handle_cast', 'start_link', 'bump', 'pad', 'format']) {
+            assert.ok(!dead.includes(live), `${live} should not be dead: ${dead}`);
+        }
+    });
+
+    it('resolves aliased modules to Mix lib/ paths', () => {
+        const index = idx(path.join(FIXTURES_PATH, 'elixir'));
+        const imports = index.imports('lib/my_app/worker.ex');
+        const formatter = imports.find(i => i.module === 'MyApp.Formatter');
+        assert.strictEqual(formatter?.resolved, path.join('lib', 'my_app', 'formatter.ex'),
+            JSON.stringify(imports));
+    });
+});
@@ -6639,3 +6639,1236 @@ func use() string {
         } finally { rm(dir); }
     });
 });
+
+describe('feature: unused Go struct fields', () => {
+    it('reports fields that are written but never read', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'worker.go': `package main
+
+import "sync"
+
+type worker struct {
+    name     string
+    retries  int
+    lastErr  error
+    mu       sync.Mutex
+    queue    []string
+    a, b     int
+    ID       string \`json:"id"\`
+    internal string \`json:"-"\`
+    spec     string \`yaml:"spec"\`
+}
+
+func newWorker() *worker {
+    return &worker{name: "w", retries: 3, internal: "x"}
+}
+
+func (w *worker) run() string {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.lastErr = nil
+    w.retries++
+    w.queue[0] = w.name
+    return w.name + string(rune(w.b))
+}
+
+func main() {
+    newWorker().run()
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const fields = index.deadcode().filter(d => d.type === 'field');
+            assert.deepStrictEqual(fields.map(d => [d.className, d.name]).sort(), [
+                ['worker', 'a'], ['worker', 'internal'], ['worker', 'lastErr'], ['worker', 'queue'], ['worker', 'retries'],
+            ]);
+        } finally { rm(dir); }
+    });
+
+    it('reports maps and slices that are only ever populated as write-only', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'task.go': `package main
+
+type Task struct {
+    Metadata map[string]string
+    Tags     []string
+    Counts   map[string]int
+    Name     string
+}
+
+func (t *Task) annotate(k, v string) {
+    t.Metadata[k] = v
+    delete(t.Metadata, "stale")
+    t.Tags = append(t.Tags, k)
+    t.Counts[k]++
+    println(len(t.Counts), t.Name)
+}
+
+func main() {
+    t := &Task{Metadata: map[string]string{}, Counts: map[string]int{}}
+    t.annotate("a", "b")
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const fields = index.deadcode({ includeExported: true }).filter(d => d.type === 'field');
+            assert.deepStrictEqual(fields.map(d => [d.name, d.access]), [
+                ['Metadata', 'write-only'], ['Tags', 'write-only'],
+            ]);
+            const text = require('../core/output').formatDeadcode(fields);
+            assert.match(text, /Task\.Metadata \(field\) \[exported\] \[written \d+x, never read\]/);
+        } finally { rm(dir); }
+    });
+
+    it('audits encoder-tagged fields when externalFieldTags is configured empty', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            '.ucn.json': JSON.stringify({ externalFieldTags: [] }),
+            'model.go': 'package main\n\ntype model struct {\n    spec string `yaml:"spec"`\n}\n\nfunc main() { _ = model{spec: "s"} }\n',
+        });
+        try {
+            const index = idx(dir);
+            assert.deepStrictEqual(index.deadcode().filter(d => d.type === 'field').map(d => d.name), ['spec']);
+        } finally { rm(dir); }
+    });
+
+    it('audits tagged fields only outside the external encoder tags', () => {
+        const go = require('../languages/go');
+        assert.strictEqual(go.isAuditedField({ fieldTag: 'json:"id,omitempty"' }), false);
+        assert.strictEqual(go.isAuditedField({ fieldTag: 'json:"-" db:"id"' }), true);
+        assert.strictEqual(go.isAuditedField({ fieldTag: 'yaml:"spec"' }, { externalFieldTags: ['json'] }), true);
+        assert.strictEqual(go.isAuditedField({ embedded: true }), false);
+    });
+});
+
+describe('feature: deadcode --interface-methods', () => {
+    it('reports interface methods only ever called on concrete types', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'store.go': `package main
+
+type store interface {
+    save(id string) error
+    find(id string) string
+    purge() error
+}
+
+type cachedStore interface {
+    store
+    flush()
+}
+
+type memStore struct{}
+
+func (m *memStore) save(id string) error  { return nil }
+func (m *memStore) find(id string) string { return id }
+func (m *memStore) purge() error          { return nil }
+func (m *memStore) flush()                {}
+
+func lookup(s store) string { return s.find("a") }
+
+func reset(c cachedStore) { c.purge() }
+
+func main() {
+    m := &memStore{}
+    m.save("b")
+    m.flush()
+    lookup(m)
+    reset(m)
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { interfaceMethods: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.className, d.name, d.implementedBy]), [
+                ['store', 'save', ['memStore']],
+                ['cachedStore', 'flush', ['memStore']],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /store\.save \(method\) \[never called through store; implemented by memStore\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: unused Go constants', () => {
+    it('reports dead constants and folds a wholly unused const block into one finding', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'status.go': `package main
+
+const (
+    StatusPending = iota
+    StatusRunning
+    StatusFailed
+)
+
+const (
+    ModeFast = "fast"
+    ModeSafe = "safe"
+)
+
+const MaxRetries = 3
+
+func main() {
+    println(StatusPending, StatusRunning, MaxRetries)
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const dead = index.deadcode({ includeExported: true }).filter(d => d.file === 'status.go');
+            assert.deepStrictEqual(dead.map(d => [d.name, d.type, d.members]), [
+                ['StatusFailed', 'state', undefined],
+                ['ModeFast', 'const block', ['ModeFast', 'ModeSafe']],
+            ]);
+            const text = require('../core/output').formatDeadcode(dead);
+            assert.match(text, /StatusFailed \(state\) \[exported\] \[iota enum — removal renumbers the values after it\]/);
+            assert.match(text, /ModeFast, ModeSafe \(const block\)/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --unused-params', () => {
+    it('reports parameters never read and marks signatures an interface fixes', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': `package main
+
+type saver interface {
+    save(ctx string, id int) error
+}
+
+type memStore struct{}
+
+func (m *memStore) save(ctx string, id int) error {
+    println(id)
+    return nil
+}
+
+func add(a, b int, label string, _ bool) int {
+    return a + b
+}
+
+func main() {
+    var s saver = &memStore{}
+    s.save("x", add(1, 2, "", false))
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { unusedParams: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.functionName, d.name, d.signatureFixedBy]), [
+                ['save', 'ctx', 'saver'],
+                ['add', 'label', undefined],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /ctx in memStore\.save \(parameter\) \[signature fixed by saver/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --unreachable-code', () => {
+    it('reports code after exits, constant-false branches and unmatched switch cases', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': `package main
+
+import "os"
+
+const debug = false
+
+func run(n int) int {
+    if n < 0 {
+        return -1
+    } else {
+        os.Exit(2)
+    }
+    n++
+    return n
+}
+
+func trace() {
+    if debug {
+        println("trace")
+    }
+}
+
+func pick(n int) int {
+    switch {
+    case true:
+        return n
+    case n > 10:
+        return 10
+    }
+    return 0
+}
+
+func retry() {
+    for {
+        if step() {
+            goto done
+        }
+    }
+    println("never")
+done:
+    println("ok")
+}
+
+func step() bool { return true }
+
+func main() { run(1); trace(); pick(2); retry() }
+`,
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { unreachableCode: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.startLine, d.reason]), [
+                ['run', 13, 'after exit at line 8'],
+                ['trace', 18, 'condition `debug` is always false'],
+                ['pick', 27, 'case after `case true` at line 25'],
+                ['retry', 39, 'after endless for at line 34'],
+            ]);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --package-vars', () => {
+    it('separates write-only package variables from never-referenced ones', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'ids.go': `package main
+
+var idCounter int
+
+var (
+    cache   = map[string]int{}
+    seen    bool
+)
+
+func nextID() {
+    idCounter++
+    seen = true
+}
+`,
+            'main.go': `package main
+
+func main() {
+    nextID()
+    if seen {
+        println("ok")
+    }
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { packageVars: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.access]), [
+                ['idCounter', 'write-only'],
+                ['cache', 'unused'],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /idCounter \(variable\) \[written 1x, never read\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --types', () => {
+    it('audits type aliases, defined types and generic types on their own', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'types.go': `package main
+
+type (
+    userID  = string
+    celsius float64
+    stack[T any] struct{ items []T }
+    queue[T any] struct{ items []T }
+)
+
+type Legacy struct{}
+
+func unusedHelper() {}
+
+func main() {
+    var s stack[celsius]
+    _ = s
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { types: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.type]), [
+                ['userID', 'type'],
+                ['queue', 'struct'],
+            ]);
+            assert.strictEqual(result.result.excludedExported, 1, 'Legacy is exported');
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /userID \(type\) \[alias of string\]/);
+            assert.match(text, /queue \(struct\) \[generic queue\[T any\]\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --unused-results', () => {
+    it('reports results every call site discards, whole or by position', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': `package main
+
+func flush() error { return nil }
+
+func parse(s string) (int, error) { return len(s), nil }
+
+func size() int { return 1 }
+
+func main() {
+    flush()
+    defer flush()
+    n, _ := parse("a")
+    m, _ := parse("bb")
+    k := size()
+    println(n, m, k)
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { unusedResults: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.discardedResults]), [
+                ['flush', 'all'],
+                ['parse', [{ index: 1, type: 'error' }]],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /flush \(function\) \[returns error; ignored at all 2 call sites\]/);
+            assert.match(text, /parse \(function\) \[result #2 error discarded at all 2 call sites\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --orphan-files', () => {
+    it('reports files whose declarations nothing outside the file uses', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': `package main
+
+import "example.com/test/util"
+
+func main() { util.Run() }
+`,
+            'util/run.go': `package util
+
+func Run() { helper() }
+`,
+            'util/helper.go': `package util
+
+func helper() { println(limit) }
+`,
+            'util/limits.go': `package util
+
+const limit = 3
+`,
+            'util/legacy.go': `package util
+
+const legacyPrefix = "v1:"
+
+func formatLegacy(s string) string { return legacyPrefix + s }
+
+func legacyAll(xs []string) []string {
+    out := make([]string, 0, len(xs))
+    for _, x := range xs {
+        out = append(out, formatLegacy(x))
+    }
+    return out
+}
+`,
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { orphanFiles: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.type, d.file, d.declarations]), [
+                ['file', 'util/legacy.go', 3],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /legacy\.go \(file\) \[3 declarations, none used outside the file; imported by nothing\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --build-variants', () => {
+    it('reports functions only built for platforms outside the matrix', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': 'package main\n\nfunc main() { openFile() }\n',
+            'open_unix.go': '//go:build unix\n\npackage main\n\nfunc openFile() {}\n',
+            'open_windows.go': 'package main\n\nfunc openFile() {}\n',
+            'open_plan9.go': '//go:build plan9 && !race\n\npackage main\n\nfunc plan9Handle() {}\n',
+        });
+        try {
+            const index = idx(dir);
+            const byDefault = execute(index, 'deadcode', { buildVariants: true });
+            assert.ok(byDefault.ok);
+            assert.deepStrictEqual(byDefault.result.map(d => [d.file, d.name, d.buildConstraint]), [
+                ['open_plan9.go', 'plan9Handle', 'plan9 && !race'],
+            ]);
+            const linuxOnly = execute(index, 'deadcode', { buildVariants: true, platforms: 'linux/amd64,darwin/arm64' });
+            assert.deepStrictEqual(linuxOnly.result.map(d => [d.file, d.name, d.builtVariants]), [
+                ['open_plan9.go', 'plan9Handle', 0],
+                ['open_windows.go', 'openFile', 1],
+            ]);
+            const text = require('../core/output').formatDeadcode(linuxOnly.result);
+            assert.match(text, /openFile \(function\) \[build: windows — no target platform builds it; 1 built variant\]/);
+            assert.match(execute(index, 'deadcode', { buildVariants: true, platforms: 'linux' }).error, /--platforms/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --test-only', () => {
+    it('reports symbols referenced only from _test.go files', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': 'package main\n\nfunc main() { run(nil) }\n',
+            'tasks.go': 'package main\n\nfunc run(ts []int) { _ = ts }\n\nfunc filterByPriority(ts []int, p int) []int { return ts }\n\nfunc unused() {}\n',
+            'tasks_test.go': 'package main\n\nimport "testing"\n\nfunc TestFilter(t *testing.T) { filterByPriority(nil, 1) }\n',
+        });
+        try {
+            const index = idx(dir);
+            const plain = execute(index, 'deadcode', {});
+            assert.deepStrictEqual(plain.result.map(d => d.name), ['unused']);
+            const result = execute(index, 'deadcode', { testOnly: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.file, d.name, d.testFiles]), [
+                ['tasks.go', 'filterByPriority', ['tasks_test.go']],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /filterByPriority \(function\) \[used only by tests: tasks_test\.go\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --test-helpers', () => {
+    it('reports test helpers no test calls and testdata files no test names', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'render.go': 'package render\n\nfunc Render(s string) string { return s }\n',
+            'render_test.go': [
+                'package render',
+                '',
+                'import (',
+                '\t"os"',
+                '\t"path/filepath"',
+                '\t"testing"',
+                ')',
+                '',
+                'func TestRender(t *testing.T) {',
+                '\tfor _, name := range []string{"basic"} {',
+                '\t\twant := load(t, name+".golden")',
+                '\t\tif Render(name) != want { t.Fail() }',
+                '\t}',
+                '\tload(t, "input.json")',
+                '}',
+                '',
+                'func TestCases(t *testing.T) {',
+                '\tentries, _ := os.ReadDir(filepath.Join("testdata", "cases"))',
+                '\t_ = entries',
+                '}',
+                '',
+                'func load(t *testing.T, name string) string {',
+                '\tb, _ := os.ReadFile(filepath.Join("testdata", name))',
+                '\treturn string(b)',
+                '}',
+                '',
+                'func staleHelper() string { return "" }',
+                '',
+            ].join('\n'),
+            'testdata/basic.golden': 'basic\n',
+            'testdata/input.json': '{}\n',
+            'testdata/cases/one.txt': '1\n',
+            'testdata/removed.golden': 'old\n',
+            'testdata/stale.json': '{}\n',
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { testHelpers: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.file, d.name, d.type]), [
+                ['render_test.go', 'staleHelper', 'function'],
+                ['testdata/removed.golden', 'removed.golden', 'fixture'],
+                ['testdata/stale.json', 'stale.json', 'fixture'],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /removed\.golden \(fixture\) \[golden file — no test names it\]/);
+            assert.match(text, /stale\.json \(fixture\) \[fixture — no test names it\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deprecated command', () => {
+    it('splits Deprecated: symbols into still used and unreferenced', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': 'package main\n\nfunc main() {\n\tOpenLegacy("x")\n}\n',
+            'legacy.go': [
+                'package main',
+                '',
+                '// OpenLegacy opens a file.',
+                '//',
+                '// Deprecated: use Open instead.',
+                'func OpenLegacy(path string) error { return nil }',
+                '',
+                '// Deprecated: no longer needed.',
+                'func cleanupOld() {}',
+                '',
+                '// OldClient talks to the v1 API.',
+                '//',
+                '// Deprecated: use Client.',
+                'type OldClient struct{}',
+                '',
+                'func (c *OldClient) Do() {}',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deprecated', {});
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.used.map(d => [d.name, d.notice, d.references.map(r => `${r.file}:${r.line}`)]), [
+                ['OpenLegacy', 'use Open instead.', ['main.go:4']],
+            ]);
+            assert.deepStrictEqual(result.result.unused.map(d => d.name), ['cleanupOld', 'OldClient']);
+            const text = require('../core/output').formatDeprecated(result.result);
+            assert.match(text, /OpenLegacy \(function\) — use Open instead\.\n {2}1 reference\(s\):\n {4}main\.go:4 in main/);
+            assert.match(text, /safe to delete: 2 symbol\(s\)/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --sentinel-errors', () => {
+    it('reports sentinel errors never returned or compared', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'store.go': [
+                'package main',
+                '',
+                'import (',
+                '\t"errors"',
+                '\t"fmt"',
+                ')',
+                '',
+                'var (',
+                '\terrNotFound = errors.New("not found")',
+                '\terrConflict = fmt.Errorf("conflict")',
+                '\terrStale    = errors.New("stale")',
+                '\terrUnused   = errors.New("unused")',
+                ')',
+                '',
+                'func get(k string) error {',
+                '\tif k == "" {',
+                '\t\treturn errNotFound',
+                '\t}',
+                '\treturn nil',
+                '}',
+                '',
+                'func main() {',
+                '\terr := get("")',
+                '\tif errors.Is(err, errConflict) {',
+                '\t\tfmt.Println(errStale.Error())',
+                '\t}',
+                '}',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { sentinelErrors: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.usageCount]), [
+                ['errStale', 1],
+                ['errUnused', 0],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /errStale \(variable\) \[sentinel error — never returned or compared; 1 other reference\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --unreachable-code with featureFlags', () => {
+    it('treats retired flag checks as constants and reports functions only they call', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            '.ucn.json': JSON.stringify({
+                featureFlags: { checks: ['flags.Enabled'], on: ['new-ui'], off: ['legacy-export'] },
+            }),
+            'flags/flags.go': 'package flags\n\nfunc Enabled(name string) bool { return name != "" }\n',
+            'main.go': [
+                'package main',
+                '',
+                'import "example.com/test/flags"',
+                '',
+                'const flagNewUI = "new-ui"',
+                '',
+                'func run() {',
+                '\tif flags.Enabled("legacy-export") {',
+                '\t\texportLegacy()',
+                '\t}',
+                '\tif flags.Enabled(flagNewUI) {',
+                '\t\trender()',
+                '\t} else {',
+                '\t\trenderOld()',
+                '\t}',
+                '}',
+                '',
+                'func exportLegacy() { writeCSV() }',
+                '',
+                'func writeCSV() {}',
+                '',
+                'func render() {}',
+                '',
+                'func renderOld() { render() }',
+                '',
+                'func main() { run() }',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { unreachableCode: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.startLine, d.reason]), [
+                ['run', 8, 'condition `flags.Enabled("legacy-export")` is always false (feature flag)'],
+                ['run', 13, 'else of always-true condition `flags.Enabled(flagNewUI)` (feature flag)'],
+                ['exportLegacy', 18, 'called only from dead feature-flag branches'],
+                ['writeCSV', 20, 'called only from dead feature-flag branches'],
+                ['renderOld', 24, 'called only from dead feature-flag branches'],
+            ]);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --library', () => {
+    it('reports exported API no other package or dependent imports', () => {
+        const dir = tmp({
+            'lib/go.mod': 'module example.com/lib\ngo 1.21',
+            'lib/store/store.go': [
+                'package store',
+                '',
+                'type Store struct{}',
+                '',
+                'func New() *Store { return &Store{} }',
+                '',
+                'func (s *Store) Get() string { return format() }',
+                '',
+                'func (s *Store) Reset() {}',
+                '',
+                'func Orphan() {}',
+                '',
+                'func Format() string { return "" }',
+                '',
+                'func Legacy() {}',
+                '',
+                'func format() string { return Format() }',
+                '',
+            ].join('\n'),
+            'lib/cmd/app/main.go': [
+                'package main',
+                '',
+                'import "example.com/lib/store"',
+                '',
+                'func main() { store.New().Get() }',
+                '',
+                'func Exported() {}',
+                '',
+            ].join('\n'),
+            'consumer/main.go': [
+                'package main',
+                '',
+                'import st "example.com/lib/store"',
+                '',
+                'func main() { st.Legacy() }',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(path.join(dir, 'lib'));
+            const result = execute(index, 'deadcode', { library: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.usageCount]), [
+                ['Reset', 0],
+                ['Orphan', 0],
+                ['Format', 1],
+                ['Legacy', 0],
+            ]);
+            const withDependent = execute(index, 'deadcode', { library: true, dependents: '../consumer' });
+            assert.deepStrictEqual(withDependent.result.map(d => d.name), ['Reset', 'Orphan', 'Format']);
+            const text = require('../core/output').formatDeadcode(withDependent.result);
+            assert.match(text, /Format \(function\) \[exported\] \[no importer — used only inside its package: unexport\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --embeds', () => {
+    it('reports unread embed variables and embedded files no path names', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'web/static/app.js': 'console.log(1)\n',
+            'web/static/css/site.css': 'body {}\n',
+            'web/static/_draft/x.css': 'p {}\n',
+            'web/tmpl/index.html': '<p></p>\n',
+            'web/tmpl/old.html': '<p></p>\n',
+            'web/notes.txt': 'notes\n',
+            'web/web.go': [
+                'package web',
+                '',
+                'import "embed"',
+                '',
+                '//go:embed static',
+                'var static embed.FS',
+                '',
+                '//go:embed tmpl/*.html',
+                'var templates embed.FS',
+                '',
+                '//go:embed notes.txt',
+                'var notes string',
+                '',
+                'func Page() ([]byte, error) {',
+                '\tif _, err := static.ReadDir("static/css"); err != nil {',
+                '\t\treturn nil, err',
+                '\t}',
+                '\treturn templates.ReadFile("tmpl/index.html")',
+                '}',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { embeds: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.file, d.name, d.type]), [
+                ['web/static/app.js', 'static/app.js', 'asset'],
+                ['web/tmpl/old.html', 'tmpl/old.html', 'asset'],
+                ['web/web.go', 'notes', 'variable'],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /notes \(variable\) \[go:embed notes\.txt — never read\]/);
+            assert.match(text, /tmpl\/old\.html \(asset\) \[embedded by templates — no path names it\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --channels', () => {
+    it('reports channels missing an end and goroutines dropping results', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': [
+                'package main',
+                '',
+                'func compute(n int) (int, error) { return n, nil }',
+                '',
+                'func worker(jobs chan int) {}',
+                '',
+                'func run() {',
+                '\tresults := make(chan int, 1)',
+                '\tdone := make(chan struct{})',
+                '\tjobs := make(chan int)',
+                '\tok := make(chan int)',
+                '\tgo func() {',
+                '\t\tresults <- 1',
+                '\t\tok <- 2',
+                '\t}()',
+                '\t<-done',
+                '\tfor v := range ok {',
+                '\t\tprintln(v)',
+                '\t}',
+                '\tworker(jobs)',
+                '\tgo compute(3)',
+                '\tgo worker(jobs)',
+                '}',
+                '',
+                'func main() { run() }',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { channels: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.type, d.startLine, d.channelIssue || d.discardedResults]), [
+                ['results', 'channel', 8, 'never received from'],
+                ['done', 'channel', 9, 'never sent to or closed'],
+                ['compute', 'goroutine', 21, '(int, error)'],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /results in run \(channel\) \[channel never received from\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --config-knobs', () => {
+    it('reports flags, env variables and viper keys never read', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'main.go': [
+                'package main',
+                '',
+                'import (',
+                '\t"flag"',
+                '\t"os"',
+                '',
+                '\t"github.com/spf13/viper"',
+                ')',
+                '',
+                'type options struct{ verbose, dryRun bool }',
+                '',
+                'var region = os.Getenv("REGION")',
+                '',
+                'func main() {',
+                '\tvar opts options',
+                '\tport := flag.Int("port", 8080, "listen port")',
+                '\tname := flag.String("name", "", "unused name")',
+                '\tflag.BoolVar(&opts.verbose, "verbose", false, "")',
+                '\tflag.BoolVar(&opts.dryRun, "dry-run", false, "")',
+                '\tviper.SetDefault("timeout", 30)',
+                '\tviper.SetDefault("retries", 3)',
+                '\tflag.Parse()',
+                '\t_ = name',
+                '\tif opts.verbose {',
+                '\t\tprintln(*port, viper.GetInt("retries"))',
+                '\t}',
+                '}',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { configKnobs: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.type, d.knobTarget || null]), [
+                ['REGION', 'env', 'region'],
+                ['dry-run', 'flag', 'opts.dryRun'],
+                ['timeout', 'config', null],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /timeout \(config\) \[viper key never looked up\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --satisfies-only', () => {
+    it('reports interfaces used only in assertions with the methods kept for them', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'repo.go': [
+                'package main',
+                '',
+                'type repository interface {',
+                '\tSave(id string) error',
+                '\tLoad(id string) error',
+                '\tString() string',
+                '}',
+                '',
+                'var _ repository = (*pgRepo)(nil)',
+                '',
+                'type pgRepo struct{}',
+                '',
+                'func (r *pgRepo) Save(id string) error { return nil }',
+                '',
+                'func (r *pgRepo) Load(id string) error { return nil }',
+                '',
+                'func (r *pgRepo) String() string { return "pg" }',
+                '',
+                'type runner interface{ Run() }',
+                '',
+                'var _ runner = job{}',
+                '',
+                'type job struct{}',
+                '',
+                'func (job) Run() {}',
+                '',
+                'func start(r runner) { r.Run() }',
+                '',
+                'func main() {',
+                '\trepo := &pgRepo{}',
+                '\t_ = repo.Load("x")',
+                '\tstart(job{})',
+                '}',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { satisfiesOnly: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.type, d.className || null]), [
+                ['repository', 'interface', null],
+                ['Save', 'method', 'pgRepo'],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /repository \(interface\) \[only in var _ assertions for pgRepo — no value has this type\]/);
+            assert.match(text, /pgRepo\.Save \(method\).*\[exists only to satisfy repository\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --redundant-assertions', () => {
+    it('reports assertions whose interface has no other use', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'svc.go': [
+                'package main',
+                '',
+                'type repository interface{ Save() error }',
+                '',
+                'type notifier interface{ Notify() }',
+                '',
+                'var (',
+                '\t_ repository = (*dataService)(nil)',
+                '\t_ notifier   = mailer{}',
+                ')',
+                '',
+                'type dataService struct{}',
+                '',
+                'func (d *dataService) Save() error { return nil }',
+                '',
+                'type mailer struct{}',
+                '',
+                'func (mailer) Notify() {}',
+                '',
+                'func send(n notifier) { n.Notify() }',
+                '',
+                'func main() { send(mailer{}); _ = (&dataService{}).Save() }',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { redundantAssertions: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.type, d.startLine, d.assertedType, d.severity]), [
+                ['repository', 'assertion', 8, 'dataService', 'low'],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /repository \(assertion\) \[redundant for dataService — repository has no other use; low severity\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --type-params', () => {
+    it('reports type parameters a generic declaration never mentions', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'gen.go': [
+                'package main',
+                '',
+                'func mapSlice[T any, U any](xs []T) []T { return xs }',
+                '',
+                'type box[T any] struct{ n int }',
+                '',
+                'func (b box[E]) get() E { var zero E; return zero }',
+                '',
+                'type pair[K comparable, V any] struct{ k K }',
+                '',
+                'func main() { _ = mapSlice[int, string](nil); _ = box[int]{}.get(); _ = pair[int, int]{} }',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { typeParams: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.name, d.type, d.functionName]), [
+                ['U', 'type parameter', 'mapSlice'],
+                ['V', 'type parameter', 'pair'],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /V in pair \(type parameter\) \[unused by the type and its methods\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --init-effects', () => {
+    it('reports inits that only fill unread variables and the blank imports pulling them in', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'reg/reg.go': [
+                'package reg',
+                '',
+                'var names []string',
+                '',
+                'func Register(n string) { names = append(names, n) }',
+                '',
+            ].join('\n'),
+            'plug/plug.go': [
+                'package plug',
+                '',
+                'import "example.com/test/reg"',
+                '',
+                'var cache = map[string]int{}',
+                '',
+                'func init() {',
+                '\tcache["x"] = 1',
+                '\treg.Register("plug")',
+                '}',
+                '',
+            ].join('\n'),
+            'live/live.go': [
+                'package live',
+                '',
+                'import "net/http"',
+                '',
+                'func init() { http.HandleFunc("/", nil) }',
+                '',
+            ].join('\n'),
+            'main.go': [
+                'package main',
+                '',
+                'import (',
+                '\t_ "example.com/test/live"',
+                '\t_ "example.com/test/plug"',
+                '\t_ "github.com/lib/pq"',
+                ')',
+                '',
+                'func main() {}',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { initEffects: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.file, d.name, d.startLine, d.initStores || d.blankImport]), [
+                ['main.go', 'example.com/test/plug', 5, 'its init only fills variables nothing reads'],
+                ['main.go', 'github.com/lib/pq', 6, 'registers a database driver, but nothing calls sql.Open'],
+                ['plug/plug.go', 'init', 7, ['cache', 'names']],
+            ]);
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /init \(function\) \[init only fills cache, names — never read\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --import-issues', () => {
+    it('reports tag-only unused, duplicated and shadowed imports with edits', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'tagged.go': [
+                '//go:build integration',
+                '',
+                'package main',
+                '',
+                'import (',
+                '\t"fmt"',
+                '\t"net/url"',
+                '\tu2 "net/url"',
+                '\t"strings"',
+                ')',
+                '',
+                'func run(raw string) string {',
+                '\tparsed, _ := url.Parse(raw)',
+                '\tfmt.Println(u2.QueryEscape(raw))',
+                '\treturn parsed.Host',
+                '}',
+                '',
+            ].join('\n'),
+            'shadow.go': [
+                'package main',
+                '',
+                'import "path"',
+                '',
+                'func join(dir string) string {',
+                '\tfull := path.Join(dir, "x")',
+                '\tpath := full + "/"',
+                '\treturn path',
+                '}',
+                '',
+                'func main() { _ = join("a") }',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { importIssues: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.file, d.name, d.startLine, d.importIssue]), [
+                ['shadow.go', 'path', 3, 'shadowed'],
+                ['tagged.go', 'net/url', 8, 'duplicate'],
+                ['tagged.go', 'strings', 9, 'unused'],
+            ]);
+            const [shadowed, duplicate, unused] = result.result;
+            assert.deepStrictEqual(shadowed.shadowedBy, [{ name: 'path', kind: 'variable', line: 7 }]);
+            assert.deepStrictEqual(shadowed.edits.map(e => [e.line, e.newExpression]), [
+                [3, 'import pathpkg "path"'],
+                [6, '\tfull := pathpkg.Join(dir, "x")'],
+            ]);
+            assert.deepStrictEqual(duplicate.edits.map(e => [e.line, e.newExpression]), [
+                [8, ''],
+                [14, '\tfmt.Println(url.QueryEscape(raw))'],
+            ]);
+            assert.strictEqual(unused.builtWith, 'integration');
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /strings \(import\) \[unused when built with integration\]/);
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --config-keys', () => {
+    it('reports config keys no code reads and tagged fields no config file sets', () => {
+        const dir = tmp({
+            'go.mod': 'module example.com/test\ngo 1.21',
+            'config/app.yaml': [
+                'server:',
+                '  port: 8080',
+                '  timeout: 30s',
+                'legacy:',
+                '  cache_size: 10',
+                '  ttl: 5',
+                'log_level: debug',
+                '',
+            ].join('\n'),
+            'main.go': [
+                'package main',
+                '',
+                'type Config struct {',
+                '\tServer   ServerConfig `yaml:"server"`',
+                '\tLogLevel string       `yaml:"log_level"`',
+                '\tMetrics  bool         `yaml:"metrics"`',
+                '\tSecret   string       `yaml:"secret" env:"SECRET"`',
+                '}',
+                '',
+                'type ServerConfig struct {',
+                '\tPort int `yaml:"port"`',
+                '}',
+                '',
+                'func main() { _ = Config{} }',
+                '',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const result = execute(index, 'deadcode', { configKeys: true });
+            assert.ok(result.ok);
+            assert.deepStrictEqual(result.result.map(d => [d.file, d.name, d.startLine]), [
+                ['config/app.yaml', 'server.timeout', 3],
+                ['config/app.yaml', 'legacy', 4],
+                ['main.go', 'Metrics', 6],
+            ]);
+            assert.strictEqual(result.result[1].configKey, 2);
+            assert.strictEqual(result.result[2].configField, 'metrics');
+            const text = require('../core/output').formatDeadcode(result.result);
+            assert.match(text, /legacy \(config key\) \[config section — no code reads it or its 2 key\(s\)\]/);
+        } finally { rm(dir); }
+    });
+});
@@ -0,0 +1,102 @@
+/**
+ * UCN User Grammar Regression Tests
+ *
+ * .ucn.json "grammars" plug a compiled tree-sitter grammar plus a tags
+ * query into the generic adapter (languages/generic.js). Registration is
+ * grammar-independent; the end-to-end suite reuses the shipped JavaScript
+ * grammar as the "user" grammar and skips when tree-sitter is absent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const fs = require('fs');
+const path = require('path');
+
+const { registerGrammars, detectLanguage } = require('../languages');
+const { tmp, rm, idx } = require('./helpers');
+
+let HAS_TREE_SITTER = true;
+try { require.resolve('tree-sitter'); require.resolve('tree-sitter-javascript'); } catch { HAS_TREE_SITTER = false; }
+const skip = HAS_TREE_SITTER ? false : 'tree-sitter not installed';
+
+const TAGS = [
+    '(function_declaration name: (identifier) @name) @definition.function',
+    '(class_declaration name: (identifier) @name) @definition.class',
+    '(method_definition name: (property_identifier) @name) @definition.method',
+    '(call_expression function: (identifier) @name) @reference.call',
+    '(call_expression function: (member_expression property: (property_identifier) @name)) @reference.call',
+    '(import_statement source: (string) @import)',
+].join('\n');
+
+const withGrammarsAllowed = (fn) => {
+    const saved = process.env.UCN_ALLOW_GRAMMARS;
+    process.env.UCN_ALLOW_GRAMMARS = '1';
+    try { return fn(); } finally {
+        if (saved === undefined) delete process.env.UCN_ALLOW_GRAMMARS;
+        else process.env.UCN_ALLOW_GRAMMARS = saved;
+    }
+};
+
+describe('User grammars: registration', () => {
+    it('loads nothing unless the user allows project grammars', () => {
+        const saved = process.env.UCN_ALLOW_GRAMMARS;
+        delete process.env.UCN_ALLOW_GRAMMARS;
+        try {
+            const results = registerGrammars([{ language: 'gated', extensions: ['.gated'], grammar: 'x', query: 'q.scm' }], '/');
+            assert.match(results[0].error, /UCN_ALLOW_GRAMMARS=1/);
+            assert.strictEqual(detectLanguage('a.gated'), null);
+        } finally {
+            if (saved !== undefined) process.env.UCN_ALLOW_GRAMMARS = saved;
+        }
+    });
+
+    it('reports unusable declarations and never replaces built-in languages', () => {
+        const dir = tmp({ 'tags.scm': TAGS, 'grammar/index.js': 'module.exports = {};' });
+        try {
+            const results = withGrammarsAllowed(() => registerGrammars([
+                { language: 'python', extensions: ['.py'], grammar: './grammar', query: 'tags.scm' },
+                { language: 'nogrammar', extensions: ['.ng'], grammar: './missing', query: 'tags.scm' },
+                { language: 'noquery', extensions: ['.nq'], grammar: './grammar', query: 'missing.scm' },
+                { language: 'toy', extensions: ['toy', '.py'], grammar: './grammar', query: 'tags.scm' },
+            ], dir));
+            assert.deepStrictEqual(results.map(r => [r.language, !r.error]),
+                [['python', false], ['nogrammar', false], ['noquery', false], ['toy', true]]);
+            assert.strictEqual(detectLanguage('a.py'), 'python');
+            assert.strictEqual(detectLanguage('a.toy'), 'toy');
+        } finally { rm(dir); }
+    });
+});
+
+describe('User grammars: analysis', { skip }, () => {
+    it('indexes definitions and calls from the tags query and finds dead code', () => {
+        const dir = tmp({
+            'tools/jsq-tags.scm': TAGS,
+            'src/app.jsq': [
+                "import { format } from './util';",
+                'function main() { return format(render()); }',
+                'function render() { return 1; }',
+                '// render() in a comment is no use of legacy',
+                'function legacy() { return 2; }',
+                'main();',
+            ].join('\n'),
+            'src/util.jsq': 'export function format(x) { return String(x); }\n',
+        });
+        fs.writeFileSync(path.join(dir, '.ucn.json'), JSON.stringify({
+            grammars: [{
+                language: 'jsq',
+                extensions: ['.jsq'],
+                grammar: require.resolve('tree-sitter-javascript'),
+                query: 'tools/jsq-tags.scm',
+            }],
+        }));
+        try {
+            const index = withGrammarsAllowed(() => idx(dir));
+            assert.deepStrictEqual(index.grammars, [{ language: 'jsq' }]);
+            assert.deepStrictEqual(index.symbols.get('render').map(s => s.relativePath), ['src/app.jsq']);
+            const dead = index.deadcode().map(d => d.name);
+            assert.deepStrictEqual(dead, ['legacy']);
+            const callers = index.findCallers('render').map(c => c.line);
+            assert.deepStrictEqual(callers, [2]);
+        } finally { rm(dir); }
+    });
+});
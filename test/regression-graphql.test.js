@@ -0,0 +1,92 @@
+/**
+ * UCN GraphQL Regression Tests
+ *
+ * GraphQL is an optional-grammar language: tree-sitter-graphql is not a
+ * declared dependency, so the parser-backed suites skip when it is absent.
+ * Embedded-document detection and `#import` extraction are grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable, langTraits } = require('../languages');
+const { findImportsInCode, isAuditedField } = require('../languages/graphql');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('graphql');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-graphql not installed';
+const GQL_FIXTURES = path.join(FIXTURES_PATH, 'graphql');
+
+describe('GraphQL: optional grammar gating', () => {
+    it('.graphql/.gql are analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('schema.graphql'), HAS_GRAMMAR ? 'graphql' : null);
+        assert.strictEqual(detectLanguage('viewer.gql'), HAS_GRAMMAR ? 'graphql' : null);
+    });
+});
+
+describe('GraphQL: embedded documents and imports', () => {
+    const documents = (code) => {
+        const pattern = langTraits('graphql').embeddedQueries;
+        pattern.lastIndex = 0;
+        return [...code.matchAll(pattern)].map(m => m[1].trim());
+    };
+
+    it('finds gql, graphql() and /* GraphQL */ templates', () => {
+        const code = [
+            'const A = gql`query A { a }`;',
+            'const B = graphql(`query B { b }`);',
+            'const C = /* GraphQL */ `query C { c }`;',
+            'const D = `query D { d }`;',
+        ].join('\n');
+        assert.deepStrictEqual(documents(code), ['query A { a }', 'query B { b }', 'query C { c }']);
+    });
+
+    it('extracts graphql-tag #import lines', () => {
+        const imports = findImportsInCode('#import "./fragments.graphql"\nquery Q { ...F }\n');
+        assert.deepStrictEqual(imports.map(i => [i.module, i.line]), [['./fragments.graphql', 1]]);
+    });
+
+    it('audits selectable fields only', () => {
+        assert.ok(isAuditedField({ name: 'title', type: 'field', modifiers: ['selectable'] }));
+        assert.ok(!isAuditedField({ name: 'title', type: 'field', modifiers: [] }));
+    });
+});
+
+describe('GraphQL: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('indexes types with fields, operations and fragments', () => {
+        const result = parse(`type Post implements Node { id: ID! title(format: String): String }
+input NewPost { title: String! }
+query Feed { posts { id } }
+fragment PostCard on Post { title }
+`, 'graphql');
+        const post = result.classes.find(c => c.name === 'Post');
+        assert.deepStrictEqual(post.implements, ['Node']);
+        assert.deepStrictEqual(post.members.map(m => m.name), ['id', 'title']);
+        assert.ok(post.members[0].modifiers.includes('selectable'));
+        const input = result.classes.find(c => c.name === 'NewPost');
+        assert.strictEqual(input.type, 'struct');
+        assert.deepStrictEqual(input.members[0].modifiers, []);
+        const byName = Object.fromEntries(result.functions.map(f => [f.name, f]));
+        assert.deepStrictEqual(byName.Feed.modifiers, ['query']);
+        assert.strictEqual(byName.PostCard.returnType, 'Post');
+    });
+});
+
+describe('GraphQL: deadcode', { skip }, () => {
+    it('flags schema fields, types and fragments no operation uses', () => {
+        const index = idx(GQL_FIXTURES);
+        const dead = index.deadcode({ includeExported: true })
+            .filter(d => /\.graphql$/.test(d.file)).map(d => d.name);
+        for (const name of ['legacySearch', 'internalNotes', 'wordCount', 'Badge', 'StaleCard']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        // resolvers.js implements legacySearch and wordCount — not liveness
+        for (const name of ['viewer', 'post', 'publishPost', 'avatarUrl', 'author', 'User', 'Post',
+            'Node', 'PublishPostInput', 'UserCard', 'Query', 'Mutation']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+});
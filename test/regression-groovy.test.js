@@ -0,0 +1,108 @@
+/**
+ * UCN Groovy / Gradle Regression Tests
+ *
+ * Groovy is an optional-grammar language: tree-sitter-groovy is not a
+ * declared dependency, so the project-level suites skip when it is absent.
+ * Declaration and task-reference extraction are lexical and
+ * grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { findFunctions, findClasses, findCallsInCode, findImportsInCode } = require('../languages/groovy');
+const { resolveImport } = require('../core/imports');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('groovy');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-groovy not installed';
+const GROOVY_FIXTURES = path.join(FIXTURES_PATH, 'groovy');
+
+describe('Groovy: optional grammar gating', () => {
+    it('.groovy/.gradle are analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('build.gradle'), HAS_GRAMMAR ? 'groovy' : null);
+        assert.strictEqual(detectLanguage('src/main/groovy/Version.groovy'), HAS_GRAMMAR ? 'groovy' : null);
+    });
+});
+
+describe('Groovy: Gradle scripts', () => {
+    const script = `task cleanCache(type: Delete) {
+    delete 'build/cache'
+}
+tasks.register('packDocs', Zip) {
+    dependsOn 'stageDocs', tasks.named('lint')
+    finalizedBy verifyDocs
+}
+def sign = { File f -> f.text.hashCode() }
+ext.archiveName = { flavor -> "docs-\${flavor}.zip" }
+def computeVersion() {
+    "1.\${buildNumber()}"
+}
+`;
+
+    it('indexes tasks, named closures and script methods', () => {
+        const fns = findFunctions(script);
+        assert.deepStrictEqual(fns.map(f => [f.name, f.modifiers, f.returnType || null]), [
+            ['cleanCache', ['task'], 'Delete'],
+            ['packDocs', ['task'], 'Zip'],
+            ['sign', ['closure'], null],
+            ['archiveName', ['closure', 'ext'], null],
+            ['computeVersion', [], null],
+        ]);
+    });
+
+    it('records task wiring and GString interpolations as calls', () => {
+        const names = findCallsInCode(script).map(c => c.name);
+        for (const name of ['stageDocs', 'lint', 'verifyDocs', 'buildNumber']) {
+            assert.ok(names.includes(name), `${name} should be referenced: ${names}`);
+        }
+        // Quoted text outside task wiring is not a reference
+        assert.ok(!names.includes('cache'), `${names}`);
+    });
+
+    it('reads classes with annotated members', () => {
+        const [cls] = findClasses(`class Publish extends DefaultTask {
+    @Input String channel
+    @TaskAction
+    void publish() { upload(channel) }
+    private void upload(String c) {}
+}`);
+        assert.strictEqual(cls.name, 'Publish');
+        assert.deepStrictEqual(cls.members.map(m => [m.name, m.modifiers]),
+            [['publish', ['taskaction']], ['upload', ['private']]]);
+    });
+
+    it('resolves apply-from scripts against the root project', () => {
+        const [imp] = findImportsInCode('apply from: "$rootDir/gradle/docs.gradle"\n');
+        assert.strictEqual(imp.type, 'apply');
+        const resolved = resolveImport(imp.module, path.join(GROOVY_FIXTURES, 'build.gradle'),
+            { language: 'groovy', root: GROOVY_FIXTURES });
+        assert.strictEqual(resolved, path.join(GROOVY_FIXTURES, 'gradle', 'docs.gradle'));
+    });
+});
+
+describe('Groovy: deadcode', { skip }, () => {
+    it('flags tasks and functions nothing invokes', () => {
+        const index = idx(GROOVY_FIXTURES);
+        const dead = index.deadcode().map(d => d.name);
+        for (const name of ['cleanCache', 'verifyDocs', 'legacyChecksum']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        // assembleDist is a default task, packDocs/stageDocs are dependencies,
+        // publishDocs runs from CI, buildNumber is called inside a GString
+        for (const name of ['assembleDist', 'packDocs', 'stageDocs', 'publishDocs', 'sign',
+            'computeVersion', 'current', 'buildNumber']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+
+    it('reports unused public members and ext closures with --include-exported', () => {
+        const index = idx(GROOVY_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['codename', 'docsArchiveName']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+    });
+});
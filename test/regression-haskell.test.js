@@ -0,0 +1,113 @@
+/**
+ * UCN Haskell Regression Tests
+ *
+ * Haskell is an optional-grammar language: tree-sitter-haskell is not a
+ * declared dependency, so the parser-backed suites skip when it is absent.
+ * Export-list and import parsing are grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { findImportsInCode, moduleHeader } = require('../languages/haskell');
+const { resolveImport } = require('../core/imports');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('haskell');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-haskell not installed';
+const HS_FIXTURES = path.join(FIXTURES_PATH, 'haskell');
+
+describe('Haskell: optional grammar gating', () => {
+    it('.hs is analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('src/Geometry/Shape.hs'), HAS_GRAMMAR ? 'haskell' : null);
+    });
+});
+
+describe('Haskell: module headers and imports', () => {
+    it('reads the export list, subordinates and re-exports', () => {
+        const header = moduleHeader(`{-# LANGUAGE OverloadedStrings #-}
+-- | Shapes (area) is not the header
+module Data.Shape
+    ( Shape(..)
+    , Point(x, y)
+    , area
+    , (<+>)
+    , module Data.Util
+    ) where
+`);
+        assert.strictEqual(header.module, 'Data.Shape');
+        assert.strictEqual(header.line, 3);
+        assert.deepStrictEqual(header.exports.map(e => [e.name, e.subordinates, e.kind]), [
+            ['Shape', 'all', 'type'],
+            ['Point', ['x', 'y'], 'type'],
+            ['area', null, 'value'],
+            ['(<+>)', null, 'value'],
+            ['Data.Util', null, 'module'],
+        ]);
+    });
+
+    it('treats a module without a list as exporting everything', () => {
+        assert.strictEqual(moduleHeader('module Main where\nmain = pure ()\n').exports, null);
+    });
+
+    it('extracts qualified, aliased, hiding and multi-line imports', () => {
+        const imports = findImportsInCode(`import qualified Data.Map.Strict as M
+import Data.List (sortOn,
+                  nub)
+import Prelude hiding (lookup)
+import Data.Maybe qualified as Maybe
+`);
+        assert.deepStrictEqual(imports.map(i => [i.module, i.type, i.names, i.alias]), [
+            ['Data.Map.Strict', 'qualified', [], 'M'],
+            ['Data.List', 'import', ['sortOn', 'nub'], undefined],
+            ['Prelude', 'import', [], undefined],
+            ['Data.Maybe', 'qualified', [], 'Maybe'],
+        ]);
+    });
+
+    it('resolves module names through the cabal hs-source-dirs', () => {
+        const resolved = resolveImport('Geometry.Shape', path.join(HS_FIXTURES, 'app', 'Main.hs'),
+            { language: 'haskell', root: HS_FIXTURES });
+        assert.strictEqual(resolved, path.join(HS_FIXTURES, 'src', 'Geometry', 'Shape.hs'));
+    });
+});
+
+describe('Haskell: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('merges equations, attaches signatures and marks exports', () => {
+        const result = parse(`module M (area) where
+
+area :: Shape -> Double
+area (Circle r) = r
+area (Rect w h) = w * h
+
+helper x = x
+`, 'haskell');
+        const area = result.functions.find(f => f.name === 'area');
+        assert.deepStrictEqual([area.startLine, area.endLine, area.returnType], [3, 5, 'Double']);
+        assert.deepStrictEqual(area.modifiers, ['export']);
+        assert.deepStrictEqual(result.functions.find(f => f.name === 'helper').modifiers, []);
+    });
+});
+
+describe('Haskell: deadcode', { skip }, () => {
+    it('reports unexported dead bindings by default', () => {
+        const index = idx(HS_FIXTURES);
+        const dead = index.deadcode().map(d => d.name);
+        assert.deepStrictEqual(dead.filter(n => ['cube', 'square', 'perimeter'].includes(n)), ['cube']);
+    });
+
+    it('reports exported functions unused across the package', () => {
+        const index = idx(HS_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['perimeter', 'scale', 'legacyBanner', 'cube']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        for (const name of ['area', 'describe', 'square', 'shapes', 'main', 'Pretty', 'Shape', 'pretty']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+});
@@ -0,0 +1,101 @@
+/**
+ * UCN Terraform (HCL) Regression Tests
+ *
+ * HCL is an optional-grammar language: @tree-sitter-grammars/tree-sitter-hcl
+ * is not a declared dependency, so the parser-backed suites skip when it is
+ * absent. Module directory resolution is grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { isTestFile } = require('../core/discovery');
+const { resolveImport } = require('../core/imports');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('hcl');
+const skip = HAS_GRAMMAR ? false : '@tree-sitter-grammars/tree-sitter-hcl not installed';
+const TF_FIXTURES = path.join(FIXTURES_PATH, 'terraform');
+
+describe('Terraform: optional grammar gating', () => {
+    it('.tf is analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('main.tf'), HAS_GRAMMAR ? 'hcl' : null);
+    });
+
+    it('terraform test files are test files', () => {
+        assert.ok(isTestFile('tests/network.tftest.hcl', 'hcl'));
+        assert.ok(!isTestFile('modules/network/main.tf', 'hcl'));
+    });
+});
+
+describe('Terraform: module sources', () => {
+    const opts = { language: 'hcl', root: TF_FIXTURES };
+    const from = path.join(TF_FIXTURES, 'main.tf');
+
+    it('resolves a local module directory to its main.tf', () => {
+        assert.strictEqual(resolveImport('./modules/network', from, opts),
+            path.join(TF_FIXTURES, 'modules', 'network', 'main.tf'));
+    });
+
+    it('treats registry and missing sources as external', () => {
+        assert.strictEqual(resolveImport('terraform-aws-modules/vpc/aws', from, opts), null);
+        assert.strictEqual(resolveImport('./modules/missing', from, opts), null);
+    });
+});
+
+describe('Terraform: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('indexes variables, outputs, locals, modules and resources', () => {
+        const result = parse(`variable "region" { default = "eu-west-1" }
+locals {
+  tags = {}
+}
+module "network" { source = "./modules/network" }
+resource "aws_vpc" "main" { cidr_block = "10.0.0.0/16" }
+output "vpc_id" { value = aws_vpc.main.id }
+`, 'hcl');
+        const byName = Object.fromEntries(result.stateObjects.map(s => [s.name, s]));
+        assert.deepStrictEqual(byName.region.modifiers, ['variable']);
+        assert.ok(byName.region.isConst);
+        assert.deepStrictEqual(byName.tags.modifiers, ['local']);
+        assert.strictEqual(byName.network.source, './modules/network');
+        assert.ok(!byName.network.isConst);
+        assert.strictEqual(byName.main.resourceType, 'aws_vpc');
+        assert.deepStrictEqual(byName.vpc_id.modifiers, ['output']);
+    });
+
+    it('records var/local/module references with their receivers', () => {
+        const { getParser, getLanguageModule } = require('../languages');
+        const calls = getLanguageModule('hcl').findCallsInCode(`resource "aws_instance" "web" {
+  subnet_id = module.network.subnet_id
+  tags      = merge(local.tags, { Region = var.region })
+}
+`, getParser('hcl'));
+        const refs = calls.map(c => `${c.receiver}.${c.name}`);
+        for (const r of ['module.network', 'module.network.subnet_id', 'local.tags', 'var.region']) {
+            assert.ok(refs.includes(r), refs.join(','));
+        }
+    });
+});
+
+describe('Terraform: deadcode', { skip }, () => {
+    it('flags variables, locals and module outputs nothing references', () => {
+        const index = idx(TF_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['enable_debug', 'stale_prefix', 'enable_ipv6', 'vpc_arn', 'zone_id']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        for (const name of ['region', 'tags', 'cidr', 'subnet_id', 'zone', 'instance_id']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+
+    it('flags module directories no module block sources', () => {
+        const index = idx(TF_FIXTURES);
+        const modules = index.deadcode().filter(d => d.type === 'module').map(d => d.name);
+        assert.deepStrictEqual(modules, [path.join('modules', 'legacy_dns')]);
+    });
+});
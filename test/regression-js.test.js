@@ -8740,3 +8740,84 @@ describe('fix #277c: nested destructured arrow params are locals, not callbacks'
         } finally { rm(dir); }
     });
 });
+
+describe('React: lazy() imports and route tables', () => {
+    const project = () => tmp({
+        'package.json': '{"name":"t"}',
+        'src/App.jsx': [
+            "import { lazy } from 'react';",
+            "import { createBrowserRouter } from 'react-router-dom';",
+            "import { Home } from './Home';",
+            "const Settings = lazy(() => import('./screens/SettingsPage'));",
+            'export const router = createBrowserRouter([',
+            "  { path: '/', Component: Home, children: [",
+            "    { path: 'settings', element: <Settings /> },",
+            "    { path: 'billing', lazy: () => import('./routes/billing') },",
+            '  ] },',
+            ']);',
+        ].join('\n'),
+        'src/Home.jsx': 'export function Home() { return <h1>Home</h1>; }',
+        'src/screens/SettingsPage.jsx': 'export default function SettingsPage() { return <form />; }',
+        'src/routes/billing.jsx': [
+            'export async function loader() { return fetch("/api/billing"); }',
+            'export function Component() { return <table />; }',
+            'export function formatInvoice(n) { return `#${n}`; }',
+        ].join('\n'),
+    });
+
+    it('records lazy import positions and route-object components', () => {
+        const { findImportsInCode, findCallsInCode } = require('../languages/javascript');
+        const { getParser } = require('../languages');
+        const code = [
+            "const A = lazy(() => import('./A'));",
+            "const B = React.lazy(() => { return import('./B'); });",
+            "const routes = [{ path: '/c', lazy: () => import('./c') }, { index: true, Component: Home }];",
+            "import('./plain').then(m => m.run());",
+        ].join('\n');
+        const parser = getParser('javascript');
+        const imports = findImportsInCode(code, parser);
+        assert.deepStrictEqual(imports.map(i => [i.module, i.lazy || null]),
+            [['./A', 'component'], ['./B', 'component'], ['./c', 'route'], ['./plain', null]]);
+        const home = findCallsInCode(code, parser).find(c => c.name === 'Home');
+        assert.ok(home && home.isFunctionReference, `route Component is a reference: ${JSON.stringify(home)}`);
+    });
+
+    it('treats lazily loaded components and route modules as framework entry points', () => {
+        const dir = project();
+        try {
+            const index = idx(dir);
+            const { detectEntrypoints } = require('../core/entrypoints');
+            const react = detectEntrypoints(index, { framework: 'react' }).map(e => e.name).sort();
+            assert.deepStrictEqual(react, ['Component', 'SettingsPage', 'loader']);
+
+            const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+            assert.ok(dead.includes('formatInvoice'), `formatInvoice should be dead: ${dead}`);
+            for (const name of ['SettingsPage', 'Component', 'loader', 'Home']) {
+                assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+            }
+        } finally { rm(dir); }
+    });
+});
+
+describe('feature: deadcode --orphan-files', () => {
+    it('reports unimported files and keeps scripts and imported modules', () => {
+        const dir = tmp({
+            'package.json': '{"name":"test"}',
+            'cli.js': "#!/usr/bin/env node\nconst { run } = require('./app');\nrun();\n",
+            'app.js': 'function run() { return 1; }\nmodule.exports = { run };\n',
+            'old.js': [
+                'function oldFormat(x) { return pad(x); }',
+                'function pad(x) { return String(x).padStart(4); }',
+                'module.exports = { oldFormat };',
+            ].join('\n'),
+        });
+        try {
+            const index = idx(dir);
+            const hidden = execute(index, 'deadcode', { orphanFiles: true }).result;
+            assert.deepStrictEqual(hidden.map(d => d.file), []);
+            assert.strictEqual(hidden.excludedExported, 1);
+            const all = execute(index, 'deadcode', { orphanFiles: true, includeExported: true }).result;
+            assert.deepStrictEqual(all.map(d => [d.file, d.declarations]), [['old.js', 2]]);
+        } finally { rm(dir); }
+    });
+});
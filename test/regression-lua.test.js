@@ -0,0 +1,123 @@
+/**
+ * UCN Lua Regression Tests
+ *
+ * Lua is an optional-grammar language: @tree-sitter-grammars/tree-sitter-lua
+ * is not a declared dependency, so the parser-backed suites skip when it is
+ * absent. require() resolution is grammar-independent and always runs.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { isTestFile } = require('../core/discovery');
+const { resolveImport } = require('../core/imports');
+const { tmp, rm, idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('lua');
+const skip = HAS_GRAMMAR ? false : '@tree-sitter-grammars/tree-sitter-lua not installed';
+const LUA_FIXTURES = path.join(FIXTURES_PATH, 'lua');
+
+describe('Lua: optional grammar gating', () => {
+    it('.lua is analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('main.lua'), HAS_GRAMMAR ? 'lua' : null);
+    });
+
+    it('busted specs are test files', () => {
+        assert.ok(isTestFile('spec/util_spec.lua', 'lua'));
+        assert.ok(isTestFile('src/util_test.lua', 'lua'));
+        assert.ok(!isTestFile('src/util.lua', 'lua'));
+    });
+});
+
+describe('Lua: require() resolution', () => {
+    const resolve = (mod, from) => resolveImport(mod, path.join(LUA_FIXTURES, from),
+        { language: 'lua', root: LUA_FIXTURES });
+
+    it('follows package.path templates set by the entry script', () => {
+        assert.strictEqual(resolve('util', 'main.lua'), path.join(LUA_FIXTURES, 'src', 'util.lua'));
+        assert.strictEqual(resolve('game.state', 'main.lua'), path.join(LUA_FIXTURES, 'src', 'game', 'state.lua'));
+    });
+
+    it('tries ?/init.lua for package directories', () => {
+        assert.strictEqual(resolve('game', 'main.lua'), path.join(LUA_FIXTURES, 'src', 'game', 'init.lua'));
+    });
+
+    it('root-level package.path applies to requires from other files', () => {
+        assert.strictEqual(resolve('util', 'spec/util_spec.lua'), path.join(LUA_FIXTURES, 'src', 'util.lua'));
+    });
+
+    it('honors .luarc.json runtime.path and leaves unknown modules external', () => {
+        const dir = tmp({
+            '.luarc.json': JSON.stringify({ 'runtime.path': ['lib/?.lua'] }),
+            'lib/net/http.lua': 'return {}\n',
+            'app.lua': 'local http = require("net.http")\n',
+        });
+        try {
+            const opts = { language: 'lua', root: dir };
+            assert.strictEqual(resolveImport('net.http', path.join(dir, 'app.lua'), opts),
+                path.join(dir, 'lib', 'net', 'http.lua'));
+            assert.strictEqual(resolveImport('socket', path.join(dir, 'app.lua'), opts), null);
+        } finally {
+            rm(dir);
+        }
+    });
+});
+
+describe('Lua: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('distinguishes local, global, module-table and host-callback functions', () => {
+        const result = parse(`local M = {}
+local function helper(a, b) return a + b end
+function shout(s) return s:upper() end
+function M.add(a, b) return helper(a, b) end
+function M:describe() return self.name end
+function love.draw() end
+return M
+`, 'lua');
+        const byName = Object.fromEntries(result.functions.map(f => [f.name, f]));
+        assert.deepStrictEqual(byName.helper.modifiers, ['local']);
+        assert.ok(byName.shout.modifiers.includes('global'));
+        assert.ok(byName.add.memberAssigned);
+        assert.ok(!byName.add.modifiers.includes('callback'));
+        assert.ok(byName.describe.isMethod);
+        assert.ok(byName.draw.modifiers.includes('callback'));
+    });
+
+    it('extracts require() bindings and the returned module table as exports', () => {
+        const { extractImports, extractExports } = require('../core/imports');
+        const code = `local json = require("lib.json")
+require "plugins"
+local M = {}
+function M.encode(v) return json.encode(v) end
+return M
+`;
+        const { imports } = extractImports(code, 'lua');
+        const byModule = Object.fromEntries(imports.map(i => [i.module, i]));
+        assert.deepStrictEqual(byModule['lib.json'].names, ['json']);
+        assert.deepStrictEqual(byModule.plugins.names, []);
+        const { exports } = extractExports(code, 'lua');
+        assert.deepStrictEqual(exports.map(e => e.name), ['encode']);
+    });
+});
+
+describe('Lua: deadcode', { skip }, () => {
+    it('flags dead local functions but not host callbacks or used module members', () => {
+        const index = idx(LUA_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['unused_debug_overlay', 'dead_local', 'stale_counter']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        for (const name of ['load', 'update', 'draw', 'clamp', 'round', 'tick', 'start']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+
+    it('unreferenced modules have no importers', () => {
+        const index = idx(LUA_FIXTURES);
+        assert.strictEqual(index.exporters('src/orphan.lua').length, 0);
+        assert.ok(index.exporters('src/util.lua').length >= 1);
+    });
+});
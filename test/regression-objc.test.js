@@ -0,0 +1,96 @@
+/**
+ * UCN Objective-C Regression Tests
+ *
+ * Objective-C is an optional-grammar language: tree-sitter-objc is not a
+ * declared dependency, so the parser-backed suites skip when it is absent.
+ * Selector parsing, Swift name derivation and import extraction are
+ * grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable, langTraits } = require('../languages');
+const { findImportsInCode, parseMethodHeader, swiftNames } = require('../languages/objc');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('objc');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-objc not installed';
+const OBJC_FIXTURES = path.join(FIXTURES_PATH, 'objc');
+
+describe('Objective-C: optional grammar gating', () => {
+    it('.m/.h are analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('App/UserStore.m'), HAS_GRAMMAR ? 'objc' : null);
+        assert.strictEqual(detectLanguage('App/UserStore.h'), HAS_GRAMMAR ? 'objc' : null);
+    });
+});
+
+describe('Objective-C: selectors and Swift names', () => {
+    it('names methods by their full selector', () => {
+        const m = parseMethodHeader('- (void)fetchUserWithId:(NSString *)userId completion:(void (^)(User *user))completion');
+        assert.strictEqual(m.selector, 'fetchUserWithId:completion:');
+        assert.strictEqual(m.returnType, 'void');
+        assert.deepStrictEqual(m.params, [
+            { name: 'userId', type: 'NSString *' },
+            { name: 'completion', type: 'void (^)(User *user)' },
+        ]);
+        const unary = parseMethodHeader('+ (instancetype)sharedStore NS_SWIFT_NAME(shared())');
+        assert.strictEqual(unary.selector, 'sharedStore');
+        assert.ok(unary.isClassMethod);
+    });
+
+    it('derives the names the Clang importer gives Swift', () => {
+        const names = (name, modifiers = []) => swiftNames({ name, modifiers }).sort();
+        assert.deepStrictEqual(names('fetchUserWithId:completion:'), ['fetchUser', 'fetchUserWithId']);
+        assert.deepStrictEqual(names('initWithName:'), ['init']);
+        assert.deepStrictEqual(names('reload'), []);
+        assert.deepStrictEqual(names('sharedStore', ['static', 'swift:shared']), ['shared']);
+        assert.deepStrictEqual(langTraits('objc').bridgedSources.extensions, ['.swift']);
+    });
+
+    it('extracts #import, #include and @import', () => {
+        const imports = findImportsInCode('#import <UIKit/UIKit.h>\n#import "UserStore.h"\n@import CoreData;\n');
+        assert.deepStrictEqual(imports.map(i => [i.module, i.type]),
+            [['UIKit/UIKit.h', 'system'], ['UserStore.h', 'import'], ['CoreData', 'module']]);
+    });
+});
+
+describe('Objective-C: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('indexes interfaces, implementations and selector-named methods', () => {
+        const result = parse(`@interface Cache : NSObject <NSCopying>
+@property (nonatomic, copy) NSString *name;
+- (void)storeValue:(id)value forKey:(NSString *)key;
+@end
+
+@implementation Cache
+- (void)storeValue:(id)value forKey:(NSString *)key { }
++ (void)load { }
+@end
+`, 'objc');
+        const iface = result.classes.find(c => c.type === 'class');
+        assert.strictEqual(iface.name, 'Cache');
+        assert.strictEqual(iface.extends, 'NSObject');
+        assert.deepStrictEqual(iface.implements, ['NSCopying']);
+        assert.deepStrictEqual(iface.members.map(m => m.name), ['name']);
+        const impl = result.classes.find(c => c.type === 'impl');
+        assert.deepStrictEqual(impl.members.map(m => [m.name, m.memberType]),
+            [['storeValue:forKey:', 'method'], ['load', 'static']]);
+    });
+});
+
+describe('Objective-C: deadcode', { skip }, () => {
+    it('keeps methods Swift calls under their imported names', () => {
+        const index = idx(OBJC_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['purgeCache', 'LegacyFormatter', 'formatName:']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        // ProfileViewController.swift calls fetchUser(withId:) and sharedStore()
+        for (const name of ['fetchUserWithId:completion:', 'sharedStore', 'reload', 'UserStore', 'main']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+});
@@ -0,0 +1,80 @@
+/**
+ * UCN OCaml Regression Tests
+ *
+ * OCaml is an optional-grammar language: tree-sitter-ocaml is not a
+ * declared dependency, so the parser-backed suites skip when it is absent.
+ * Interface export extraction and compilation-unit references are
+ * grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable, langTraits } = require('../languages');
+const { findExportsInCode, findImportsInCode } = require('../languages/ocaml');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('ocaml');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-ocaml not installed';
+const ML_FIXTURES = path.join(FIXTURES_PATH, 'ocaml');
+
+describe('OCaml: optional grammar gating', () => {
+    it('.ml/.mli are analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('lib/cache.ml'), HAS_GRAMMAR ? 'ocaml' : null);
+        assert.strictEqual(detectLanguage('lib/cache.mli'), HAS_GRAMMAR ? 'ocaml_interface' : null);
+        assert.strictEqual(langTraits('ocaml').headerExtension, '.mli');
+    });
+});
+
+describe('OCaml: interfaces and references', () => {
+    it('exports what an interface declares, including nested signatures', () => {
+        const exports = findExportsInCode(`(** val hidden : int — a comment *)
+type 'a t
+type config = { size : int }
+val create : int -> 'a t
+external now : unit -> float = "caml_now"
+exception Miss of string
+module Stats : sig
+  val hits : int ref
+end
+`);
+        assert.deepStrictEqual(exports.map(e => [e.name, e.type]), [
+            ['t', 'type'], ['config', 'type'], ['create', 'val'], ['now', 'external'],
+            ['Miss', 'exception'], ['Stats', 'module'], ['hits', 'val'],
+        ]);
+    });
+
+    it('exports every top-level binding of an implementation read alone', () => {
+        const exports = findExportsInCode('let helper x = x\nlet () = main ()\nlet rec go n =\n  let inner = n in go inner\nand stop = 0\n');
+        assert.deepStrictEqual(exports.map(e => e.name), ['helper', 'go', 'stop']);
+    });
+
+    it('records open, include and qualified-path compilation units', () => {
+        const imports = findImportsInCode('open Printf\nlet x = Cache.find c "Foo.bar" (* Baz.x *)\nlet y = Stdlib.List.map f l\n');
+        assert.deepStrictEqual(imports.map(i => [i.module, i.type]),
+            [['Printf', 'open'], ['Cache', 'qualified'], ['Stdlib', 'qualified']]);
+    });
+});
+
+describe('OCaml: deadcode', { skip }, () => {
+    it('flags values absent from the interface and unused in the implementation', () => {
+        const index = idx(ML_FIXTURES);
+        const dead = index.deadcode().map(d => d.name);
+        for (const name of ['evict_all', 'stale_limit']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        // create/find are in cache.mli; hash_key/default_size are used
+        // internally; render.ml has no interface, so banner is exported
+        for (const name of ['create', 'find', 'hash_key', 'default_size', 'banner', 'main']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+
+    it('reports unused exports with --include-exported', () => {
+        const index = idx(ML_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        assert.ok(dead.includes('banner'), `banner should be dead: ${dead}`);
+        assert.ok(!dead.includes('describe'), `describe should not be dead: ${dead}`);
+    });
+});
@@ -0,0 +1,98 @@
+/**
+ * UCN Protocol Buffers Regression Tests
+ *
+ * Protobuf is an optional-grammar language: tree-sitter-proto is not a
+ * declared dependency, so the parser-backed suites skip when it is absent.
+ * Generated-name mapping and import resolution are grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { resolveImport } = require('../core/imports');
+const { generatedNames } = require('../languages/proto');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('proto');
+const skip = HAS_GRAMMAR ? false : 'tree-sitter-proto not installed';
+const PROTO_FIXTURES = path.join(FIXTURES_PATH, 'proto');
+
+describe('Protobuf: optional grammar gating', () => {
+    it('.proto is analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('user.proto'), HAS_GRAMMAR ? 'proto' : null);
+    });
+});
+
+describe('Protobuf: generated names', () => {
+    it('maps a service to its generated client/server/stub names', () => {
+        const forms = generatedNames({ name: 'UserService', type: 'interface' });
+        for (const f of ['UserServiceClient', 'NewUserServiceClient', 'RegisterUserServiceServer',
+            'UserServiceStub', 'UserServiceServicer', 'add_UserServiceServicer_to_server', 'UserServiceGrpc']) {
+            assert.ok(forms.includes(f), `${f} missing: ${forms}`);
+        }
+    });
+
+    it('maps an rpc to its camelCase and snake_case method names', () => {
+        assert.deepStrictEqual(generatedNames({ name: 'GetUser', type: 'method' }), ['getUser', 'get_user']);
+    });
+
+    it('maps a nested message to its flattened Go name', () => {
+        assert.deepStrictEqual(generatedNames({ name: 'Item', type: 'struct', enclosingType: 'Order' }), ['Order_Item']);
+        assert.deepStrictEqual(generatedNames({ name: 'User', type: 'struct' }), []);
+    });
+});
+
+describe('Protobuf: imports', () => {
+    it('resolves imports against the file directory and its ancestors', () => {
+        const opts = { language: 'proto', root: PROTO_FIXTURES };
+        const from = path.join(PROTO_FIXTURES, 'proto', 'user', 'v1', 'user.proto');
+        assert.strictEqual(resolveImport('common/v1/address.proto', from, opts),
+            path.join(PROTO_FIXTURES, 'proto', 'common', 'v1', 'address.proto'));
+        assert.strictEqual(resolveImport('google/protobuf/timestamp.proto', from, opts), null);
+    });
+});
+
+describe('Protobuf: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('indexes messages, enums and services with rpc members', () => {
+        const result = parse(`syntax = "proto3";
+message Order {
+  message Item { string sku = 1; }
+  repeated Item items = 1;
+}
+enum Status { STATUS_UNSPECIFIED = 0; }
+service Orders {
+  rpc Watch(WatchRequest) returns (stream Order);
+}
+`, 'proto');
+        const byName = Object.fromEntries(result.classes.map(c => [c.name, c]));
+        assert.strictEqual(byName.Order.type, 'struct');
+        assert.deepStrictEqual(byName.Order.members.map(m => m.name), ['items']);
+        assert.strictEqual(byName.Item.enclosingType, 'Order');
+        assert.strictEqual(byName.Status.type, 'enum');
+        assert.strictEqual(byName.Orders.type, 'interface');
+        const watch = byName.Orders.members[0];
+        assert.strictEqual(watch.name, 'Watch');
+        assert.strictEqual(watch.returnType, 'Order');
+        assert.ok(watch.modifiers.includes('server-stream'));
+    });
+});
+
+describe('Protobuf: deadcode', { skip }, () => {
+    it('reports messages and rpcs no hand-written code uses', () => {
+        const index = idx(PROTO_FIXTURES);
+        const dead = index.deadcode({ includeExported: true })
+            .filter(d => d.file.endsWith('.proto')).map(d => d.name);
+        for (const name of ['LegacyProfile', 'Obsolete', 'PurgeUser']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        // GetUser is called by the Go client, ListUsers implemented by the
+        // Python servicer; generated stubs keep nothing alive
+        for (const name of ['User', 'Role', 'Address', 'UserService', 'GetUser', 'ListUsers', 'GetUserRequest']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+});
@@ -4543,3 +4543,40 @@ describe('fix #276: Python alias and chained-builtin precision', () => {
         } finally { rm(dir); }
     });
 });
+
+describe('Jupyter notebooks as reference sources', () => {
+    const { codeCells } = require('../languages/notebook');
+    const notebook = (cells, metadata = { kernelspec: { language: 'python' } }) =>
+        JSON.stringify({ nbformat: 4, metadata, cells });
+
+    it('extracts code cells with their languages, never markdown or outputs', () => {
+        const cells = codeCells(notebook([
+            { cell_type: 'markdown', source: ['see zscore'] },
+            { cell_type: 'code', source: ['from stats import zscore\n', 'zscore(df)'], outputs: [{ text: 'winsorize' }] },
+            { cell_type: 'code', source: '%%julia\nusing PyCall' },
+        ]));
+        assert.deepStrictEqual(cells.map(c => [c.index, c.language, c.source]), [
+            [1, 'python', 'from stats import zscore\nzscore(df)'],
+            [2, 'julia', '%%julia\nusing PyCall'],
+        ]);
+        assert.deepStrictEqual(codeCells('{"cells": [truncated'), []);
+    });
+
+    it('keeps library functions called only from a notebook alive', () => {
+        const dir = tmp({
+            'analysis/stats.py': 'def zscore(xs):\n    return xs\n\n\ndef winsorize(xs):\n    return xs\n',
+            'notebooks/explore.ipynb': notebook([
+                { cell_type: 'code', source: ['from analysis.stats import zscore\n', 'zscore([1, 2])\n'],
+                    outputs: [{ output_type: 'stream', text: ['winsorize skipped\n'] }] },
+            ]),
+            'notebooks/.ipynb_checkpoints/explore-checkpoint.ipynb': notebook([
+                { cell_type: 'code', source: ['winsorize([1, 2])\n'] },
+            ]),
+        });
+        try {
+            const dead = idx(dir).deadcode({ includeExported: true }).map(d => d.name);
+            assert.ok(!dead.includes('zscore'), `zscore is used by the notebook: ${dead}`);
+            assert.ok(dead.includes('winsorize'), `outputs and checkpoints are not uses: ${dead}`);
+        } finally { rm(dir); }
+    });
+});
@@ -0,0 +1,102 @@
+/**
+ * UCN SQL Regression Tests
+ *
+ * SQL is an optional-grammar language: @derekstride/tree-sitter-sql is not a
+ * declared dependency, so the parser-backed suites skip when it is absent.
+ * Identifier folding, psql include extraction and include resolution are
+ * grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { isTestFile } = require('../core/discovery');
+const { resolveImport } = require('../core/imports');
+const { foldIdentifier, findImportsInCode } = require('../languages/sql');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('sql');
+const skip = HAS_GRAMMAR ? false : '@derekstride/tree-sitter-sql not installed';
+const SQL_FIXTURES = path.join(FIXTURES_PATH, 'sql');
+
+describe('SQL: optional grammar gating', () => {
+    it('.sql/.pgsql are analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('schema.sql'), HAS_GRAMMAR ? 'sql' : null);
+        assert.strictEqual(detectLanguage('fn.pgsql'), HAS_GRAMMAR ? 'sql' : null);
+    });
+
+    it('pgTAP-style test files are test files', () => {
+        assert.ok(isTestFile('db/orders_test.sql', 'sql'));
+        assert.ok(isTestFile('test/orders.sql', 'sql'));
+        assert.ok(!isTestFile('schema/orders.sql', 'sql'));
+    });
+});
+
+describe('SQL: identifiers and includes', () => {
+    it('folds bare identifiers to lower case and keeps quoted spelling', () => {
+        assert.strictEqual(foldIdentifier('Orders'), 'orders');
+        assert.strictEqual(foldIdentifier('"OrderLines"'), 'OrderLines');
+        assert.strictEqual(foldIdentifier('"say ""hi"""'), 'say "hi"');
+    });
+
+    it('extracts psql \\i and \\ir includes', () => {
+        const imports = findImportsInCode('\\i schema/tables.sql\n\\ir schema/functions.sql\nSELECT 1;\n');
+        assert.deepStrictEqual(imports.map(i => i.module), ['schema/tables.sql', './schema/functions.sql']);
+        assert.deepStrictEqual(imports.map(i => i.line), [1, 2]);
+    });
+
+    it('resolves \\i from the project root and \\ir from the including file', () => {
+        const opts = { language: 'sql', root: SQL_FIXTURES };
+        const from = path.join(SQL_FIXTURES, 'migrate.sql');
+        assert.strictEqual(resolveImport('schema/tables.sql', from, opts),
+            path.join(SQL_FIXTURES, 'schema', 'tables.sql'));
+        assert.strictEqual(resolveImport('./schema/functions.sql', from, opts),
+            path.join(SQL_FIXTURES, 'schema', 'functions.sql'));
+        assert.strictEqual(resolveImport('schema/missing.sql', from, opts), null);
+    });
+});
+
+describe('SQL: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('indexes tables with columns, views and functions by folded name', () => {
+        const result = parse(`CREATE TABLE public.Orders (id SERIAL PRIMARY KEY, total NUMERIC);
+CREATE MATERIALIZED VIEW order_stats AS SELECT count(*) FROM orders;
+CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN RETURN NEW; END; $$ LANGUAGE plpgsql;
+`, 'sql');
+        const table = result.classes.find(c => c.name === 'orders');
+        assert.ok(table, JSON.stringify(result.classes));
+        assert.strictEqual(table.type, 'table');
+        assert.deepStrictEqual(table.members.map(m => m.name), ['id', 'total']);
+        const view = result.classes.find(c => c.name === 'order_stats');
+        assert.strictEqual(view.type, 'view');
+        assert.ok(view.modifiers.includes('materialized'));
+        assert.ok(result.functions.some(f => f.name === 'touch'));
+    });
+
+    it('records relation references but not ALTER / CREATE INDEX targets', () => {
+        const { getParser, getLanguageModule } = require('../languages');
+        const calls = getLanguageModule('sql').findCallsInCode(`ALTER TABLE audit ADD COLUMN x TEXT;
+CREATE INDEX audit_x ON audit (x);
+SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id;
+`, getParser('sql'));
+        const names = calls.map(c => c.name);
+        assert.ok(names.includes('orders') && names.includes('customers'), names.join(','));
+        assert.ok(!names.includes('audit'), names.join(','));
+    });
+});
+
+describe('SQL: deadcode', { skip }, () => {
+    it('flags database objects neither SQL nor application query strings reference', () => {
+        const index = idx(SQL_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['legacy_audit', 'stale_report', 'unused_rollup']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        for (const name of ['customers', 'orders', 'order_totals', 'order_discount', 'touch_updated_at']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+});
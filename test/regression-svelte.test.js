@@ -0,0 +1,96 @@
+/**
+ * UCN Svelte Regression Tests
+ *
+ * Svelte components parse their script blocks as JS/TS; markup references
+ * are scanned from the template text, which is grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, langTraits } = require('../languages');
+const { resolveImport } = require('../core/imports');
+const { markupReferences, unwrapStoreReads } = require('../languages/svelte');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const SVELTE_FIXTURES = path.join(FIXTURES_PATH, 'svelte');
+
+describe('Svelte: detection', () => {
+    it('.svelte is a built-in language with component-file deadcode', () => {
+        assert.strictEqual(detectLanguage('src/lib/Button.svelte'), 'svelte');
+        assert.strictEqual(langTraits('svelte').componentFiles, true);
+    });
+});
+
+describe('Svelte: markup references', () => {
+    it('records components, directives, handlers and block expressions', () => {
+        const code = [
+            '<script>let hidden;</script>',
+            '<Button on:click={save} use:tooltip={{ text: hint }} class:active>',
+            '  {#each $todos as todo, i (todo.id)}',
+            '    <li transition:fade>{format(todo)} {i}</li>',
+            '  {/each}',
+            '</Button>',
+            '{#await load() then data}{data.name}{/await}',
+        ].join('\n');
+        const refs = markupReferences(code).map(r => `${r.name}:${r.line}`);
+        assert.deepStrictEqual(refs, ['Button:2', 'tooltip:2', 'active:2', 'save:2', 'hint:2',
+            'todos:3', 'fade:4', 'format:4', 'load:7']);
+    });
+
+    it('scopes let: bindings to the element that declares them', () => {
+        const refs = markupReferences('<List let:row>{render(row)}</List>\n{row}').map(r => r.name);
+        assert.deepStrictEqual(refs, ['List', 'render', 'row']);
+    });
+
+    it('reads $store subscriptions as the store name, but not runes', () => {
+        assert.strictEqual(unwrapStoreReads('$count + $$props.x + $state(0)'), ' count + $$props.x + $state(0)');
+    });
+});
+
+describe('Svelte: imports', () => {
+    it('resolves the SvelteKit $lib alias to src/lib', () => {
+        const from = path.join(SVELTE_FIXTURES, 'src', 'routes', '+page.svelte');
+        assert.strictEqual(resolveImport('$lib/stores.js', from, { language: 'svelte', root: SVELTE_FIXTURES }),
+            path.join(SVELTE_FIXTURES, 'src', 'lib', 'stores.js'));
+    });
+});
+
+describe('Svelte: parsing', () => {
+    const { parse } = require('../core/parser');
+
+    it('indexes reactive declarations as state', () => {
+        const result = parse(`<script>
+  export let a = 1;
+  $: doubled = a * 2;
+</script>
+<p>{doubled}</p>
+`, 'svelte');
+        const reactive = result.stateObjects.find(s => s.name === 'doubled');
+        assert.deepStrictEqual(reactive.modifiers, ['reactive']);
+        assert.strictEqual(reactive.startLine, 3);
+    });
+
+    it('indexes module-scope stores', () => {
+        const result = parse(`import { writable } from 'svelte/store';
+export const count = writable(0);
+function local() { const temp = writable(1); return temp; }
+`, 'javascript');
+        assert.deepStrictEqual(result.stateObjects.map(s => [s.name, s.modifiers]), [['count', ['store', 'export']]]);
+    });
+});
+
+describe('Svelte: deadcode', () => {
+    it('flags unimported components, unused stores and functions', () => {
+        const index = idx(SVELTE_FIXTURES);
+        const dead = index.deadcode({ includeExported: true }).map(d => d.name);
+        for (const name of ['LegacyBanner', 'theme', 'archive']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        // $todos/$remaining subscriptions keep the stores alive; +page is routed
+        for (const name of ['TodoItem', 'todos', 'remaining', 'toggle', 'add', '+page']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+});
@@ -0,0 +1,94 @@
+/**
+ * UCN Vue Single-File Component Regression Tests
+ *
+ * SFCs parse with tree-sitter-html; script blocks are analyzed as JS/TS and
+ * template expressions are recorded as calls. Template-expression scanning
+ * and tag-name mapping are grammar-independent.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, langTraits } = require('../languages');
+const { componentName, expressionReferences, isAuditedProp } = require('../languages/vue');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const VUE_FIXTURES = path.join(FIXTURES_PATH, 'vue');
+
+describe('Vue: detection', () => {
+    it('.vue is a built-in language with component-file deadcode', () => {
+        assert.strictEqual(detectLanguage('src/App.vue'), 'vue');
+        assert.strictEqual(langTraits('vue').componentFiles, true);
+        assert.ok(isAuditedProp({ name: 'title', type: 'field', modifiers: ['prop'] }));
+        assert.ok(!isAuditedProp({ name: 'title', type: 'field', modifiers: [] }));
+    });
+});
+
+describe('Vue: template references', () => {
+    it('maps kebab-case and PascalCase tags to the component name', () => {
+        assert.strictEqual(componentName('user-card'), 'UserCard');
+        assert.strictEqual(componentName('UserCard'), 'UserCard');
+        assert.strictEqual(componentName('div'), null);
+        assert.strictEqual(componentName('keep-alive'), null);
+    });
+
+    it('reads component-scope names, skipping members, keys, strings and locals', () => {
+        const refs = expressionReferences(`{ active: isActive } && fmt(item.name, 'total') || $t('x')`, new Set(['item']));
+        assert.deepStrictEqual(refs.map(r => [r.name, r.isCall]), [['isActive', false], ['fmt', true]]);
+    });
+
+    it('treats arrow-function parameters as locals', () => {
+        const refs = expressionReferences('rows.filter(r => r.visible).map((a, i) => a + offset)', new Set());
+        assert.deepStrictEqual(refs.map(r => r.name), ['rows', 'offset']);
+    });
+});
+
+describe('Vue: parsing', () => {
+    const { parse } = require('../core/parser');
+
+    it('indexes <script setup> functions and typed props', () => {
+        const result = parse(`<script setup lang="ts">
+const props = defineProps<{ title: string; count: number }>();
+function bump() { return props.count + 1; }
+</script>
+<template><h1 @click="bump">{{ title }}</h1></template>
+`, 'vue');
+        assert.ok(result.functions.some(f => f.name === 'bump' && f.startLine === 3));
+        const propsIface = result.classes.find(c => c.name === 'Props');
+        assert.deepStrictEqual(propsIface.members.map(m => m.name), ['title', 'count']);
+        assert.deepStrictEqual(propsIface.members[0].modifiers, ['prop']);
+    });
+
+    it('indexes options-API methods and computeds', () => {
+        const result = parse(`<script>
+export default {
+  props: { size: Number },
+  computed: { area() { return this.size * this.size; } },
+  methods: { grow() { this.size++; } },
+};
+</script>
+`, 'vue');
+        const byName = Object.fromEntries(result.functions.map(f => [f.name, f]));
+        assert.deepStrictEqual(byName.area.modifiers, ['computed']);
+        assert.deepStrictEqual(byName.grow.modifiers, ['method']);
+        assert.deepStrictEqual(result.classes.find(c => c.name === 'Props').members.map(m => m.name), ['size']);
+    });
+});
+
+describe('Vue: deadcode', () => {
+    it('flags unused components, props, methods and computeds', () => {
+        const index = idx(VUE_FIXTURES);
+        const dead = index.deadcode({ includeExported: true })
+            .filter(d => d.file.endsWith('.vue')).map(d => d.name);
+        for (const name of ['OrphanPanel', 'legacyTitle', 'tooltip', 'obsoleteLabel', 'neverCalled', 'unusedHelper']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        // <user-card> and <StatusBadge> are template uses; App and pages/ are
+        // mounted by the framework
+        for (const name of ['UserCard', 'StatusBadge', 'App', 'Index', 'onSelect', 'initials',
+            'compact', 'user', 'status', 'label', 'refresh', 'render', 'Props']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+});
@@ -0,0 +1,71 @@
+/**
+ * UCN Zig Regression Tests
+ *
+ * Zig is an optional-grammar language (@tree-sitter-grammars/tree-sitter-zig
+ * is not a declared dependency) — parser-backed suites skip without it.
+ */
+
+const { describe, it } = require('node:test');
+const assert = require('node:assert');
+const path = require('path');
+
+const { detectLanguage, isLanguageAvailable } = require('../languages');
+const { isTestFile } = require('../core/discovery');
+const { idx, FIXTURES_PATH } = require('./helpers');
+
+const HAS_GRAMMAR = isLanguageAvailable('zig');
+const skip = HAS_GRAMMAR ? false : '@tree-sitter-grammars/tree-sitter-zig not installed';
+
+describe('Zig: optional grammar gating', () => {
+    it('.zig is analyzed exactly when the grammar is installed', () => {
+        assert.strictEqual(detectLanguage('src/main.zig'), HAS_GRAMMAR ? 'zig' : null);
+        assert.ok(isTestFile('src/parser_test.zig', 'zig'));
+        assert.ok(!isTestFile('src/parser.zig', 'zig'));
+    });
+});
+
+describe('Zig: parsing', { skip }, () => {
+    const { parse } = require('../core/parser');
+
+    it('models pub vs non-pub declarations and container members', () => {
+        const result = parse(`const std = @import("std");
+const limit = 4;
+pub const Point = struct {
+    x: i32,
+    pub fn len(self: Point) i32 { return self.x; }
+};
+pub fn api() void {}
+fn local() void {}
+`, 'zig');
+        const byName = Object.fromEntries(result.functions.map(f => [f.name, f]));
+        assert.ok(byName.api.modifiers.includes('public'));
+        assert.ok(!byName.local.modifiers.includes('public'));
+        const point = result.classes.find(c => c.name === 'Point');
+        assert.strictEqual(point.type, 'struct');
+        assert.ok(point.members.some(m => m.name === 'x' && m.memberType === 'field'));
+        assert.ok(point.members.some(m => m.name === 'len' && m.memberType === 'method'));
+        const limit = result.stateObjects.find(s => s.name === 'limit');
+        assert.ok(limit.isConst);
+        // @import bindings are imports, not state
+        assert.ok(!result.stateObjects.some(s => s.name === 'std'));
+    });
+});
+
+describe('Zig: deadcode', { skip }, () => {
+    it('reports unused non-pub fns, structs and comptime constants', () => {
+        const index = idx(path.join(FIXTURES_PATH, 'zig'));
+        const dead = index.deadcode({}).map(d => d.name);
+        for (const name of ['unusedHelper', 'deadPrivate', 'Orphan', 'unused_limit']) {
+            assert.ok(dead.includes(name), `${name} should be dead: ${dead}`);
+        }
+        for (const name of ['main', 'max_items', 'clamp', 'Stack']) {
+            assert.ok(!dead.includes(name), `${name} should not be dead: ${dead}`);
+        }
+    });
+
+    it('resolves relative @import paths', () => {
+        const index = idx(path.join(FIXTURES_PATH, 'zig'));
+        const util = index.imports('src/main.zig').find(i => i.module === 'util.zig');
+        assert.strictEqual(util?.resolved, path.join('src', 'util.zig'));
+    });
+});